@@ -8,7 +8,11 @@
 package application
 
 import (
+	"archive/zip"
 	stderrors "errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/charm/v8"
@@ -120,29 +124,82 @@ type DeployArgs struct {
 	// value being the unique ID of a pre-uploaded resources in
 	// storage.
 	Resources map[string]string
+
+	// LocalCharm is the local charm being deployed, when it has not
+	// been added to the model. It is only used by ValidateDeploy, which
+	// sends the content of the charm's files to the controller so that
+	// the charm need not be added.
+	LocalCharm charm.Charm
 }
 
 // Deploy obtains the charm, either locally or from the charm store, and deploys
 // it. Placement directives, if provided, specify the machine on which the charm
 // is deployed.
 func (c *Client) Deploy(args DeployArgs) error {
+	deployArgs, err := c.deployParams(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	err = c.facade.FacadeCall("Deploy", deployArgs, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// ValidateDeploy makes the checks that the controller would make when
+// deploying the application described by args, without deploying it.
+// The outcome of each check is returned.
+func (c *Client) ValidateDeploy(args DeployArgs) ([]params.DeployCheck, error) {
+	if c.BestAPIVersion() < 15 {
+		return nil, errors.NotSupportedf("validating a deployment on this version of Juju")
+	}
+	deployArgs, err := c.deployParams(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if args.LocalCharm != nil {
+		content, err := localCharmContent(args.LocalCharm)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		deployArgs.Applications[0].Charm = content
+	}
+	var results params.DeployValidationResults
+	err = c.facade.FacadeCall("ValidateDeploy", deployArgs, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Checks, nil
+}
+
+// deployParams returns the arguments to the Deploy and ValidateDeploy
+// calls for the application described by args.
+func (c *Client) deployParams(args DeployArgs) (params.ApplicationsDeploy, error) {
 	if len(args.AttachStorage) > 0 {
 		if args.NumUnits != 1 {
-			return errors.New("cannot attach existing storage when more than one unit is requested")
+			return params.ApplicationsDeploy{}, errors.New("cannot attach existing storage when more than one unit is requested")
 		}
 		if c.BestAPIVersion() < 5 {
-			return errors.New("this juju controller does not support AttachStorage")
+			return params.ApplicationsDeploy{}, errors.New("this juju controller does not support AttachStorage")
 		}
 	}
 	attachStorage := make([]string, len(args.AttachStorage))
 	for i, id := range args.AttachStorage {
 		if !names.IsValidStorage(id) {
-			return errors.NotValidf("storage ID %q", id)
+			return params.ApplicationsDeploy{}, errors.NotValidf("storage ID %q", id)
 		}
 		attachStorage[i] = names.NewStorageTag(id).String()
 	}
 	origin := args.CharmOrigin.ParamsCharmOrigin()
-	deployArgs := params.ApplicationsDeploy{
+	return params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			ApplicationName:  args.ApplicationName,
 			Series:           args.Series,
@@ -160,14 +217,7 @@ func (c *Client) Deploy(args DeployArgs) error {
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
 		}},
-	}
-	var results params.ErrorResults
-	var err error
-	err = c.facade.FacadeCall("Deploy", deployArgs, &results)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	return errors.Trace(results.OneError())
+	}, nil
 }
 
 // GetCharmURL returns the charm URL the given application is
@@ -1052,6 +1102,135 @@ func (c *Client) ResolveUnitErrors(units []string, all, retry bool) error {
 	return errors.Trace(results.Combine())
 }
 
+// KillHooks requests that any hook currently running on each of the
+// specified units be killed.
+func (c *Client) KillHooks(units []string) error {
+	if c.BestAPIVersion() < 14 {
+		return errors.NotSupportedf("killing hooks on this version of Juju")
+	}
+	entities := make([]params.Entity, len(units))
+	for i, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return errors.NotValidf("unit name %q", unit)
+		}
+		entities[i].Tag = names.NewUnitTag(unit).String()
+	}
+	args := params.Entities{Entities: entities}
+	results := new(params.ErrorResults)
+	err := c.facade.FacadeCall("KillHooks", args, results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.Combine())
+}
+
+// HookHistory returns up to size of the most recent hook executions
+// recorded for the unit, newest first. If size is zero, all the
+// recorded executions are returned.
+func (c *Client) HookHistory(unit string, size int) ([]params.HookRecord, error) {
+	if c.BestAPIVersion() < 14 {
+		return nil, errors.NotSupportedf("hook history on this version of Juju")
+	}
+	if !names.IsValidUnit(unit) {
+		return nil, errors.NotValidf("unit name %q", unit)
+	}
+	args := params.HookHistoryRequests{
+		Requests: []params.HookHistoryRequest{{
+			Tag:  names.NewUnitTag(unit).String(),
+			Size: size,
+		}},
+	}
+	var results params.HookHistoryResults
+	err := c.facade.FacadeCall("HookHistory", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Records, nil
+}
+
+// UnitWorkload returns the state of the pod running a unit in a container
+// model, along with recent pod events and up to logLines of the most
+// recent logs from each of its containers.
+func (c *Client) UnitWorkload(unit string, logLines int) (*params.UnitWorkload, error) {
+	if c.BestAPIVersion() < 16 {
+		return nil, errors.NotSupportedf("inspecting unit workloads on this version of Juju")
+	}
+	if !names.IsValidUnit(unit) {
+		return nil, errors.NotValidf("unit name %q", unit)
+	}
+	args := params.UnitWorkloadRequests{
+		Requests: []params.UnitWorkloadRequest{{
+			Tag:      names.NewUnitTag(unit).String(),
+			LogLines: logLines,
+		}},
+	}
+	var results params.UnitWorkloadResults
+	err := c.facade.FacadeCall("UnitsWorkload", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
+// AdoptK8sWorkloadArgs holds the arguments for adopting an existing
+// Kubernetes workload.
+type AdoptK8sWorkloadArgs struct {
+	// Kind is the kind of workload, either "deployment" or "statefulset".
+	Kind string
+
+	// Name is the name of the workload, which is also the name of the
+	// application created for it.
+	Name string
+
+	// CharmID identifies the charm to deploy the application with.
+	CharmID charmstore.CharmID
+
+	// CharmOrigin is the origin of the charm.
+	CharmOrigin apicharm.Origin
+}
+
+// AdoptK8sWorkload takes ownership of an existing deployment or stateful
+// set in a container model, creating an application of the same name and
+// a unit for each of its pods. It returns the names of the new units.
+func (c *Client) AdoptK8sWorkload(args AdoptK8sWorkloadArgs) ([]string, error) {
+	if c.BestAPIVersion() < 17 {
+		return nil, errors.NotSupportedf("adopting workloads on this version of Juju")
+	}
+	origin := args.CharmOrigin.ParamsCharmOrigin()
+	p := params.AdoptK8sWorkloadArgs{
+		Args: []params.AdoptK8sWorkloadArg{{
+			Kind:        args.Kind,
+			Name:        args.Name,
+			CharmURL:    args.CharmID.URL.String(),
+			CharmOrigin: &origin,
+			Channel:     string(args.CharmID.Channel),
+		}},
+	}
+	var results params.AdoptK8sWorkloadResults
+	if err := c.facade.FacadeCall("AdoptK8sWorkloads", p, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Units, nil
+}
+
 func validateApplicationScale(scale, scaleChange int) error {
 	if scale < 0 && scaleChange == 0 {
 		return errors.NotValidf("scale < 0")
@@ -1203,3 +1382,53 @@ func unitInfoFromParams(in params.UnitInfoResult) UnitInfo {
 	}
 	return info
 }
+
+// localCharmContent returns the content of the files of the local charm
+// ch that the controller needs to validate a deployment of it.
+func localCharmContent(ch charm.Charm) (*params.DeployCharmContent, error) {
+	var readFile func(name string) ([]byte, error)
+	switch ch := ch.(type) {
+	case *charm.CharmDir:
+		readFile = func(name string) ([]byte, error) {
+			return ioutil.ReadFile(filepath.Join(ch.Path, name))
+		}
+	case *charm.CharmArchive:
+		archive, err := zip.OpenReader(ch.Path)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer func() { _ = archive.Close() }()
+		readFile = func(name string) ([]byte, error) {
+			for _, f := range archive.File {
+				if f.Name != name {
+					continue
+				}
+				r, err := f.Open()
+				if err != nil {
+					return nil, err
+				}
+				defer func() { _ = r.Close() }()
+				return ioutil.ReadAll(r)
+			}
+			return nil, os.ErrNotExist
+		}
+	default:
+		return nil, errors.NotSupportedf("validating the deployment of a %T charm", ch)
+	}
+
+	var content params.DeployCharmContent
+	for name, field := range map[string]*string{
+		"metadata.yaml":    &content.Metadata,
+		"config.yaml":      &content.Config,
+		"lxd-profile.yaml": &content.LXDProfile,
+	} {
+		data, err := readFile(name)
+		if os.IsNotExist(err) && name != "metadata.yaml" {
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading %s", name)
+		}
+		*field = string(data)
+	}
+	return &content, nil
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -1321,6 +1322,251 @@ func (s *applicationSuite) TestResolveUnitErrorsInvalidUnit(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, `unit name "mysql" not valid`)
 }
 
+func (s *applicationSuite) TestKillHooks(c *gc.C) {
+	var called bool
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "KillHooks")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{
+				{Tag: "unit-mysql-0"},
+				{Tag: "unit-mysql-1"},
+			},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 2)
+		return nil
+	}, 14)
+	err := client.KillHooks([]string{"mysql/0", "mysql/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestKillHooksInvalidUnit(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	}, 14)
+	err := client.KillHooks([]string{"mysql"})
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+}
+
+func (s *applicationSuite) TestHookHistory(c *gc.C) {
+	record := params.HookRecord{
+		HookName: "install",
+		Started:  time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+		Duration: time.Second,
+	}
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Check(request, gc.Equals, "HookHistory")
+		c.Assert(a, jc.DeepEquals, params.HookHistoryRequests{
+			Requests: []params.HookHistoryRequest{{Tag: "unit-mysql-0", Size: 5}},
+		})
+		result := response.(*params.HookHistoryResults)
+		result.Results = []params.HookHistoryResult{{Records: []params.HookRecord{record}}}
+		return nil
+	}, 14)
+	history, err := client.HookHistory("mysql/0", 5)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []params.HookRecord{record})
+}
+
+func (s *applicationSuite) TestHookHistoryError(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.HookHistoryResults)
+		result.Results = []params.HookHistoryResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	}, 14)
+	_, err := client.HookHistory("mysql/0", 0)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestHookHistoryNotSupported(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	}, 13)
+	_, err := client.HookHistory("mysql/0", 0)
+	c.Assert(err, gc.ErrorMatches, "hook history on this version of Juju not supported")
+}
+
+func (s *applicationSuite) TestUnitWorkload(c *gc.C) {
+	workload := &params.UnitWorkload{
+		PodName: "mysql-0",
+		Phase:   "Running",
+		Containers: []params.KubernetesContainerInfo{{
+			Name:         "mysql",
+			RestartCount: 1,
+			Logs:         []string{"ready"},
+		}},
+	}
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Check(request, gc.Equals, "UnitsWorkload")
+		c.Assert(a, jc.DeepEquals, params.UnitWorkloadRequests{
+			Requests: []params.UnitWorkloadRequest{{Tag: "unit-mysql-0", LogLines: 20}},
+		})
+		result := response.(*params.UnitWorkloadResults)
+		result.Results = []params.UnitWorkloadResult{{Result: workload}}
+		return nil
+	}, 16)
+	result, err := client.UnitWorkload("mysql/0", 20)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, workload)
+}
+
+func (s *applicationSuite) TestUnitWorkloadError(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.UnitWorkloadResults)
+		result.Results = []params.UnitWorkloadResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	}, 16)
+	_, err := client.UnitWorkload("mysql/0", 0)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestUnitWorkloadNotSupported(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	}, 15)
+	_, err := client.UnitWorkload("mysql/0", 0)
+	c.Assert(err, gc.ErrorMatches, "inspecting unit workloads on this version of Juju not supported")
+}
+
+func (s *applicationSuite) TestAdoptK8sWorkload(c *gc.C) {
+	curl := charm.MustParseURL("ch:mariadb-k8s-3")
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Check(request, gc.Equals, "AdoptK8sWorkloads")
+		c.Assert(a, jc.DeepEquals, params.AdoptK8sWorkloadArgs{
+			Args: []params.AdoptK8sWorkloadArg{{
+				Kind:     "statefulset",
+				Name:     "mariadb",
+				CharmURL: "mariadb-k8s-3",
+				CharmOrigin: &params.CharmOrigin{
+					Source: "charm-hub",
+					Risk:   "stable",
+				},
+				Channel: "stable",
+			}},
+		})
+		result := response.(*params.AdoptK8sWorkloadResults)
+		result.Results = []params.AdoptK8sWorkloadResult{{Units: []string{"mariadb/0", "mariadb/1"}}}
+		return nil
+	}, 17)
+	units, err := client.AdoptK8sWorkload(application.AdoptK8sWorkloadArgs{
+		Kind:        "statefulset",
+		Name:        "mariadb",
+		CharmID:     charmstore.CharmID{URL: curl, Channel: "stable"},
+		CharmOrigin: apicharm.Origin{Source: apicharm.OriginCharmHub, Risk: "stable"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []string{"mariadb/0", "mariadb/1"})
+}
+
+func (s *applicationSuite) TestAdoptK8sWorkloadError(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.AdoptK8sWorkloadResults)
+		result.Results = []params.AdoptK8sWorkloadResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	}, 17)
+	_, err := client.AdoptK8sWorkload(application.AdoptK8sWorkloadArgs{
+		Kind:    "deployment",
+		Name:    "mariadb",
+		CharmID: charmstore.CharmID{URL: charm.MustParseURL("ch:mariadb-k8s-3")},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestAdoptK8sWorkloadNotSupported(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	}, 16)
+	_, err := client.AdoptK8sWorkload(application.AdoptK8sWorkloadArgs{})
+	c.Assert(err, gc.ErrorMatches, "adopting workloads on this version of Juju not supported")
+}
+
+func (s *applicationSuite) TestValidateDeploy(c *gc.C) {
+	checks := []params.DeployCheck{
+		{Name: "charm"},
+		{Name: "storage", Error: &params.Error{Message: `pool "fast" not found`}},
+	}
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Check(request, gc.Equals, "ValidateDeploy")
+		args, ok := a.(params.ApplicationsDeploy)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.Applications, gc.HasLen, 1)
+		c.Assert(args.Applications[0].ApplicationName, gc.Equals, "mysql")
+		c.Assert(args.Applications[0].CharmURL, gc.Equals, "cs:mysql-1")
+		c.Assert(args.Applications[0].AttachStorage, jc.DeepEquals, []string{"storage-data-0"})
+		result := response.(*params.DeployValidationResults)
+		result.Results = []params.DeployValidationResult{{ApplicationName: "mysql", Checks: checks}}
+		return nil
+	}, 15)
+	result, err := client.ValidateDeploy(application.DeployArgs{
+		CharmID:         charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-1")},
+		ApplicationName: "mysql",
+		NumUnits:        1,
+		AttachStorage:   []string{"data/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, checks)
+}
+
+func (s *applicationSuite) TestValidateDeployLocalCharm(c *gc.C) {
+	for _, ch := range []charm.Charm{
+		testcharms.Repo.CharmDir("lxd-profile"),
+		testcharms.Repo.CharmArchive(c.MkDir(), "lxd-profile"),
+	} {
+		client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+			args := a.(params.ApplicationsDeploy)
+			content := args.Applications[0].Charm
+			c.Assert(content, gc.NotNil)
+			c.Check(content.Metadata, gc.Matches, "(?s)name: lxd-profile\n.*")
+			c.Check(content.Config, gc.Equals, "options: {}\n")
+			c.Check(content.LXDProfile, gc.Matches, "(?s).*security.nesting.*")
+			result := response.(*params.DeployValidationResults)
+			result.Results = []params.DeployValidationResult{{ApplicationName: "lxd-profile"}}
+			return nil
+		}, 15)
+		_, err := client.ValidateDeploy(application.DeployArgs{
+			CharmID:    charmstore.CharmID{URL: charm.MustParseURL("local:quantal/lxd-profile-0")},
+			LocalCharm: ch,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *applicationSuite) TestValidateDeployError(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.DeployValidationResults)
+		result.Results = []params.DeployValidationResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	}, 15)
+	_, err := client.ValidateDeploy(application.DeployArgs{
+		CharmID: charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-1")},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestValidateDeployNotSupported(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	}, 14)
+	_, err := client.ValidateDeploy(application.DeployArgs{})
+	c.Assert(err, gc.ErrorMatches, "validating a deployment on this version of Juju not supported")
+}
+
+func (s *applicationSuite) TestKillHooksNotSupported(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	}, 13)
+	err := client.KillHooks([]string{"mysql/0"})
+	c.Assert(err, gc.ErrorMatches, "killing hooks on this version of Juju not supported")
+}
+
 func (s *applicationSuite) TestResolveUnitErrorsAll(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
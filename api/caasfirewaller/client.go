@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
@@ -130,6 +131,106 @@ func (c *Client) IsExposed(appName string) (bool, error) {
 	return results.Results[0].Result, nil
 }
 
+// WatchApplicationRelations returns a StringsWatcher that notifies of
+// changes to the relations of the specified CAAS application.
+func (c *Client) WatchApplicationRelations(appName string) (watcher.StringsWatcher, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("watching application relations on this version of Juju")
+	}
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.StringsWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationsRelations", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
+// WatchRelationIngressNetworks returns a StringsWatcher that notifies of
+// changes to the networks a consuming model has asked to be allowed in
+// for the specified cross model relation.
+func (c *Client) WatchRelationIngressNetworks(relationKey string) (watcher.StringsWatcher, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("watching relation ingress networks on this version of Juju")
+	}
+	if !names.IsValidRelation(relationKey) {
+		return nil, errors.NotValidf("relation key %q", relationKey)
+	}
+	args := entities(names.NewRelationTag(relationKey))
+
+	var results params.StringsWatchResults
+	if err := c.facade.FacadeCall("WatchRelationsIngressNetworks", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
+// WatchForModelConfigChanges returns a NotifyWatcher that notifies of
+// changes to the model config.
+func (c *Client) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("watching model config on this version of Juju")
+	}
+	return common.NewModelWatcher(c.facade).WatchForModelConfigChanges()
+}
+
+// NetworkPolicy returns the sources allowed to reach each endpoint
+// of the specified CAAS application, and whether the traffic reaching
+// it is to be restricted.
+func (c *Client) NetworkPolicy(appName string) (caas.NetworkPolicyParams, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return caas.NetworkPolicyParams{}, errors.NotSupportedf("network policies on this version of Juju")
+	}
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return caas.NetworkPolicyParams{}, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.KubernetesNetworkPolicyResults
+	if err := c.facade.FacadeCall("NetworkPolicies", args, &results); err != nil {
+		return caas.NetworkPolicyParams{}, err
+	}
+	if n := len(results.Results); n != 1 {
+		return caas.NetworkPolicyParams{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return caas.NetworkPolicyParams{}, maybeNotFound(err)
+	}
+	policy := caas.NetworkPolicyParams{
+		Endpoints: make(map[string]caas.EndpointIngress),
+	}
+	if results.Results[0].Result == nil {
+		return policy, nil
+	}
+	policy.Enabled = results.Results[0].Result.Enabled
+	for name, ingress := range results.Results[0].Result.Endpoints {
+		policy.Endpoints[name] = caas.EndpointIngress{
+			Applications: ingress.Applications,
+			CIDRs:        ingress.CIDRs,
+		}
+	}
+	return policy, nil
+}
+
 // maybeNotFound returns an error satisfying errors.IsNotFound
 // if the supplied error has a CodeNotFound error.
 func maybeNotFound(err *params.Error) error {
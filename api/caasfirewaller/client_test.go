@@ -13,6 +13,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/caasfirewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 )
@@ -196,3 +197,186 @@ func (s *FirewallerSuite) TestApplicationConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg, jc.DeepEquals, application.ConfigAttributes{"foo": "bar"})
 }
+
+func (s *FirewallerSuite) TestWatchApplicationRelations(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CAASFirewaller")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchApplicationsRelations")
+			c.Assert(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{
+					Tag: "application-gitlab",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+			*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+				Results: []params.StringsWatchResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			return nil
+		}),
+		BestVersion: 2,
+	}
+
+	client := caasfirewaller.NewClient(apiCaller)
+	watcher, err := client.WatchApplicationRelations("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *FirewallerSuite) TestWatchApplicationRelationsNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	_, err := client.WatchApplicationRelations("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *FirewallerSuite) TestWatchRelationIngressNetworks(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CAASFirewaller")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchRelationsIngressNetworks")
+			c.Assert(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{
+					Tag: "relation-gitlab.website#remote-apache.proxy",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+			*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+				Results: []params.StringsWatchResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			return nil
+		}),
+		BestVersion: 2,
+	}
+
+	client := caasfirewaller.NewClient(apiCaller)
+	watcher, err := client.WatchRelationIngressNetworks("gitlab:website remote-apache:proxy")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *FirewallerSuite) TestWatchRelationIngressNetworksNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	_, err := client.WatchRelationIngressNetworks("gitlab:website remote-apache:proxy")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *FirewallerSuite) TestWatchForModelConfigChanges(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CAASFirewaller")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchForModelConfigChanges")
+			c.Assert(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+			return errors.New("FAIL")
+		}),
+		BestVersion: 2,
+	}
+
+	client := caasfirewaller.NewClient(apiCaller)
+	watcher, err := client.WatchForModelConfigChanges()
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *FirewallerSuite) TestWatchForModelConfigChangesNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	_, err := client.WatchForModelConfigChanges()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *FirewallerSuite) TestNetworkPolicy(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CAASFirewaller")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "NetworkPolicies")
+			c.Assert(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{
+					Tag: "application-gitlab",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.KubernetesNetworkPolicyResults{})
+			*(result.(*params.KubernetesNetworkPolicyResults)) = params.KubernetesNetworkPolicyResults{
+				Results: []params.KubernetesNetworkPolicyResult{{
+					Result: &params.KubernetesNetworkPolicy{
+						Enabled: true,
+						Endpoints: map[string]params.KubernetesEndpointIngress{
+							"db":      {Applications: []string{"mysql"}},
+							"website": {CIDRs: []string{"10.0.0.0/8"}},
+						},
+					},
+				}},
+			}
+			return nil
+		}),
+		BestVersion: 2,
+	}
+
+	client := caasfirewaller.NewClient(apiCaller)
+	policy, err := client.NetworkPolicy("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, caas.NetworkPolicyParams{
+		Enabled: true,
+		Endpoints: map[string]caas.EndpointIngress{
+			"db":      {Applications: []string{"mysql"}},
+			"website": {CIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+}
+
+func (s *FirewallerSuite) TestNetworkPolicyError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.KubernetesNetworkPolicyResults)) = params.KubernetesNetworkPolicyResults{
+				Results: []params.KubernetesNetworkPolicyResult{{Error: &params.Error{
+					Code:    params.CodeNotFound,
+					Message: "bletch",
+				}}},
+			}
+			return nil
+		}),
+		BestVersion: 2,
+	}
+
+	client := caasfirewaller.NewClient(apiCaller)
+	_, err := client.NetworkPolicy("gitlab")
+	c.Assert(err, gc.ErrorMatches, "bletch")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FirewallerSuite) TestNetworkPolicyNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	_, err := client.NetworkPolicy("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
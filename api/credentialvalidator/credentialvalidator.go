@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -109,3 +110,26 @@ func (c *Facade) WatchModelCredential() (watcher.NotifyWatcher, error) {
 	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// UpdateModelCredential replaces the content of the cloud credential that
+// the model uses, which becomes valid.
+func (c *Facade) UpdateModelCredential(credential cloud.Credential) error {
+	if v := c.facade.BestAPIVersion(); v < 3 {
+		return errors.NotSupportedf("UpdateModelCredential on CredentialValidator v%v", v)
+	}
+	in := params.UpdateModelCredentialArg{
+		Credential: params.CloudCredential{
+			AuthType:   string(credential.AuthType()),
+			Attributes: credential.Attributes(),
+		},
+	}
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("UpdateModelCredential", in, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/credentialvalidator"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
 )
 
 var _ = gc.Suite(&CredentialValidatorSuite{})
@@ -180,3 +181,44 @@ func (s *CredentialValidatorSuite) TestWatchModelCredentialCallV1(c *gc.C) {
 	_, err := client.WatchModelCredential()
 	c.Assert(err, gc.ErrorMatches, "WatchModelCredential on CredentialValidator v1 not supported")
 }
+
+func (s *CredentialValidatorSuite) TestUpdateModelCredential(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CredentialValidator")
+		c.Check(request, gc.Equals, "UpdateModelCredential")
+		c.Assert(arg, jc.DeepEquals, params.UpdateModelCredentialArg{
+			Credential: params.CloudCredential{
+				AuthType:   "certificate",
+				Attributes: map[string]string{"Token": "rotated"},
+			},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		*(result.(*params.ErrorResult)) = params.ErrorResult{}
+		return nil
+	})
+
+	client := credentialvalidator.NewFacade(apitesting.BestVersionCaller{apiCaller, 3})
+	err := client.UpdateModelCredential(cloud.NewCredential(cloud.CertificateAuthType, map[string]string{"Token": "rotated"}))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CredentialValidatorSuite) TestUpdateModelCredentialBackendFailure(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ErrorResult)) = params.ErrorResult{Error: apiservererrors.ServerError(errors.New("boom"))}
+		return nil
+	})
+
+	client := credentialvalidator.NewFacade(apitesting.BestVersionCaller{apiCaller, 3})
+	err := client.UpdateModelCredential(cloud.NewEmptyCredential())
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *CredentialValidatorSuite) TestUpdateModelCredentialCallV2(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("foo")
+	})
+
+	client := credentialvalidator.NewFacade(apitesting.BestVersionCaller{apiCaller, 2})
+	err := client.UpdateModelCredential(cloud.NewEmptyCredential())
+	c.Assert(err, gc.ErrorMatches, "UpdateModelCredential on CredentialValidator v2 not supported")
+}
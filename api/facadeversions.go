@@ -18,7 +18,7 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  13,
+	"Application":                  17,
 	"ApplicationOffers":            3,
 	"ApplicationScaler":            1,
 	"Backups":                      2,
@@ -26,12 +26,12 @@ var facadeVersions = map[string]int{
 	"Bundle":                       4,
 	"CAASAgent":                    1,
 	"CAASAdmission":                1,
-	"CAASFirewaller":               1,
+	"CAASFirewaller":               2,
 	"CAASModelOperator":            1,
 	"CAASOperator":                 1,
 	"CAASOperatorProvisioner":      1,
 	"CAASOperatorUpgrader":         1,
-	"CAASUnitProvisioner":          1,
+	"CAASUnitProvisioner":          2,
 	"CharmHub":                     1,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       3,
@@ -40,7 +40,7 @@ var facadeVersions = map[string]int{
 	"Cloud":                        7,
 	"Controller":                   9,
 	"CredentialManager":            1,
-	"CredentialValidator":          2,
+	"CredentialValidator":          3,
 	"CrossController":              1,
 	"CrossModelRelations":          2,
 	"Deployer":                     1,
@@ -57,7 +57,7 @@ var facadeVersions = map[string]int{
 	"ImageMetadata":                3,
 	"ImageMetadataManager":         1,
 	"InstanceMutater":              2,
-	"InstancePoller":               4,
+	"InstancePoller":               5,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
@@ -65,7 +65,7 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               6,
+	"MachineManager":               10,
 	"MachineUndertaker":            1,
 	"Machiner":                     4,
 	"MeterStatus":                  2,
@@ -81,6 +81,7 @@ var facadeVersions = map[string]int{
 	"ModelGeneration":              4,
 	"ModelManager":                 9,
 	"ModelSummaryWatcher":          1,
+	"ModelSuspender":               1,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
@@ -100,7 +101,7 @@ var facadeVersions = map[string]int{
 	"RetryStrategy":                1,
 	"Singular":                     2,
 	"Spaces":                       6,
-	"SSHClient":                    2,
+	"SSHClient":                    3,
 	"StatusHistory":                2,
 	"Storage":                      6,
 	"StorageProvisioner":           4,
@@ -108,7 +109,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      4,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       17,
+	"Uniter":                       19,
 	"Upgrader":                     1,
 	"UpgradeSeries":                3,
 	"UpgradeSteps":                 2,
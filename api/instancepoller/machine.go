@@ -152,6 +152,20 @@ func (m *Machine) SetInstanceStatus(status status.Status, message string, data m
 	return result.OneError()
 }
 
+// ApplySpotInterruptionPolicy applies the model's spot-interruption-policy
+// to this machine, whose spot instance has been interrupted.
+func (m *Machine) ApplySpotInterruptionPolicy() error {
+	var result params.ErrorResults
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: m.tag.String()},
+	}}
+	err := m.facade.FacadeCall("ApplySpotInterruptionPolicy", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // SetProviderNetworkConfig updates the provider addresses for this machine.
 func (m *Machine) SetProviderNetworkConfig(ifList network.InterfaceInfos) (network.ProviderAddresses, bool, error) {
 	var results params.SetProviderNetworkConfigResults
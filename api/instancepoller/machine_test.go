@@ -100,6 +100,10 @@ var machineErrorTests = []struct {
 		return m.SetInstanceStatus("", "", nil)
 	},
 	resultsRef: params.ErrorResults{},
+}, {
+	method:     "ApplySpotInterruptionPolicy",
+	wrapper:    (*instancepoller.Machine).ApplySpotInterruptionPolicy,
+	resultsRef: params.ErrorResults{},
 }, {
 	method: "SetProviderNetworkConfig",
 	wrapper: func(m *instancepoller.Machine) error {
@@ -225,6 +229,17 @@ func (s *MachineSuite) TestSetInstanceStatusSuccess(c *gc.C) {
 	c.Check(apiCaller.CallCount, gc.Equals, 1)
 }
 
+func (s *MachineSuite) TestApplySpotInterruptionPolicySuccess(c *gc.C) {
+	results := params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	}
+	apiCaller := successAPICaller(c, "ApplySpotInterruptionPolicy", entitiesArgs, results)
+	machine := instancepoller.NewMachine(apiCaller, s.tag, life.Alive)
+	err := machine.ApplySpotInterruptionPolicy()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(apiCaller.CallCount, gc.Equals, 1)
+}
+
 func (s *MachineSuite) TestSetProviderNetworkConfigSuccess(c *gc.C) {
 	cfg := network.InterfaceInfos{{
 		DeviceIndex: 0,
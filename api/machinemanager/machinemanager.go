@@ -13,6 +13,8 @@ import (
 	apiwatcher "github.com/juju/juju/api/watcher"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -133,6 +135,153 @@ func (client *Client) destroyMachines(method string, machines []string) ([]param
 	return allResults, nil
 }
 
+// InstanceTypes returns, for each of the given constraints, the instance
+// types of the model's cloud region that satisfy them, cheapest first.
+func (client *Client) InstanceTypes(cons []constraints.Value) ([]params.InstanceTypesResult, error) {
+	args := params.ModelInstanceTypesConstraints{
+		Constraints: make([]params.ModelInstanceTypesConstraint, len(cons)),
+	}
+	for i, value := range cons {
+		value := value
+		args.Constraints[i].Value = &value
+	}
+	var results params.InstanceTypesResults
+	if err := client.facade.FacadeCall("InstanceTypes", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(cons) {
+		return nil, errors.Errorf("expected %d results, got %d", len(cons), n)
+	}
+	return results.Results, nil
+}
+
+// ResizeMachine changes the hardware of a provisioned machine in place,
+// so that it satisfies the machine's constraints overridden by the given
+// ones, and returns the machine's new hardware.
+func (client *Client) ResizeMachine(machineId string, cons constraints.Value) (*instance.HardwareCharacteristics, error) {
+	if client.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("resize-machine")
+	}
+	if !names.IsValidMachine(machineId) {
+		return nil, errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.ResizeMachinesParams{
+		Machines: []params.ResizeMachineParams{{
+			MachineTag:  names.NewMachineTag(machineId).String(),
+			Constraints: cons,
+		}},
+	}
+	var results params.ResizeMachineResults
+	if err := client.facade.FacadeCall("ResizeMachine", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, apiservererrors.RestoreError(err)
+	}
+	return results.Results[0].HardwareCharacteristics, nil
+}
+
+// MigrateMachine moves the instance of a provisioned machine to the given
+// member of the cloud's cluster, and returns the machine's new
+// availability zone.
+func (client *Client) MigrateMachine(machineId, member string) (string, error) {
+	if client.BestAPIVersion() < 10 {
+		return "", errors.NotSupportedf("migrate-machine")
+	}
+	if !names.IsValidMachine(machineId) {
+		return "", errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.MigrateMachinesParams{
+		Machines: []params.MigrateMachineParams{{
+			MachineTag: names.NewMachineTag(machineId).String(),
+			Member:     member,
+		}},
+	}
+	var results params.StringResults
+	if err := client.facade.FacadeCall("MigrateMachine", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", apiservererrors.RestoreError(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// SuspendMachines powers off the instances of the given machines, keeping
+// them in the model with the status "suspended".
+func (client *Client) SuspendMachines(machines ...string) ([]params.ErrorResult, error) {
+	if client.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("suspend-machine")
+	}
+	return client.suspendMachines("SuspendMachine", machines)
+}
+
+// ResumeMachines powers the instances of the given suspended machines
+// on again.
+func (client *Client) ResumeMachines(machines ...string) ([]params.ErrorResult, error) {
+	if client.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("resume-machine")
+	}
+	return client.suspendMachines("ResumeMachine", machines)
+}
+
+// SuspendModel asks for all of the model's machines to be suspended, in
+// reverse order of the dependencies between their applications.
+func (client *Client) SuspendModel() error {
+	if client.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("suspend-model")
+	}
+	return client.facade.FacadeCall("SuspendModel", nil, nil)
+}
+
+// ResumeModel asks for a suspended model's machines to be resumed, in
+// order of the dependencies between their applications.
+func (client *Client) ResumeModel() error {
+	if client.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("resume-model")
+	}
+	return client.facade.FacadeCall("ResumeModel", nil, nil)
+}
+
+func (client *Client) suspendMachines(method string, machines []string) ([]params.ErrorResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, 0, len(machines)),
+	}
+	allResults := make([]params.ErrorResult, len(machines))
+	index := make([]int, 0, len(machines))
+	for i, machineId := range machines {
+		if !names.IsValidMachine(machineId) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("machine ID %q", machineId).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.Entities = append(args.Entities, params.Entity{
+			Tag: names.NewMachineTag(machineId).String(),
+		})
+	}
+	if len(args.Entities) > 0 {
+		var result params.ErrorResults
+		if err := client.facade.FacadeCall(method, args, &result); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n := len(result.Results); n != len(args.Entities) {
+			return nil, errors.Errorf("expected %d result(s), got %d", len(args.Entities), n)
+		}
+		for i, result := range result.Results {
+			allResults[index[i]] = result
+		}
+	}
+	return allResults, nil
+}
+
 // UpgradeSeriesPrepare notifies the controller that a series upgrade is taking
 // place for a given machine and as such the machine is guarded against
 // operations that would impede, fail, or interfere with the upgrade process.
@@ -14,6 +14,8 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -248,3 +250,216 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParamsNilWait(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expected)
 }
+
+func (s *MachinemanagerSuite) TestInstanceTypes(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "InstanceTypes")
+			mem := constraints.MustParse("mem=8G")
+			c.Assert(a, jc.DeepEquals, params.ModelInstanceTypesConstraints{
+				Constraints: []params.ModelInstanceTypesConstraint{
+					{Value: &constraints.Value{}},
+					{Value: &mem},
+				},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.InstanceTypesResults{})
+			out := response.(*params.InstanceTypesResults)
+			*out = params.InstanceTypesResults{Results: []params.InstanceTypesResult{
+				{InstanceTypes: []params.InstanceType{{Name: "t3.small"}}},
+				{Error: &params.Error{Message: "boom"}},
+			}}
+			return nil
+		}))
+	results, err := client.InstanceTypes([]constraints.Value{{}, constraints.MustParse("mem=8G")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.InstanceTypesResult{
+		{InstanceTypes: []params.InstanceType{{Name: "t3.small"}}},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *MachinemanagerSuite) TestResizeMachine(c *gc.C) {
+	mem := uint64(8192)
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ResizeMachine")
+				c.Assert(a, jc.DeepEquals, params.ResizeMachinesParams{
+					Machines: []params.ResizeMachineParams{{
+						MachineTag:  "machine-0",
+						Constraints: constraints.MustParse("mem=8G"),
+					}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.ResizeMachineResults{})
+				out := response.(*params.ResizeMachineResults)
+				*out = params.ResizeMachineResults{Results: []params.ResizeMachineResult{{
+					HardwareCharacteristics: &instance.HardwareCharacteristics{Mem: &mem},
+				}}}
+				return nil
+			})})
+	hc, err := client.ResizeMachine("0", constraints.MustParse("mem=8G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hc, jc.DeepEquals, &instance.HardwareCharacteristics{Mem: &mem})
+}
+
+func (s *MachinemanagerSuite) TestResizeMachineError(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				out := response.(*params.ResizeMachineResults)
+				*out = params.ResizeMachineResults{Results: []params.ResizeMachineResult{{
+					Error: &params.Error{Message: "boom"},
+				}}}
+				return nil
+			})})
+	_, err := client.ResizeMachine("0", constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachinemanagerSuite) TestResizeMachineNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 6,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call %q", request)
+				return nil
+			})})
+	_, err := client.ResizeMachine("0", constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "resize-machine not supported")
+}
+
+func (s *MachinemanagerSuite) TestMigrateMachine(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 10,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "MigrateMachine")
+				c.Assert(a, jc.DeepEquals, params.MigrateMachinesParams{
+					Machines: []params.MigrateMachineParams{{
+						MachineTag: "machine-0",
+						Member:     "node02",
+					}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.StringResults{})
+				out := response.(*params.StringResults)
+				*out = params.StringResults{Results: []params.StringResult{{Result: "node02"}}}
+				return nil
+			})})
+	zone, err := client.MigrateMachine("0", "node02")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone, gc.Equals, "node02")
+}
+
+func (s *MachinemanagerSuite) TestMigrateMachineError(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 10,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				out := response.(*params.StringResults)
+				*out = params.StringResults{Results: []params.StringResult{{
+					Error: &params.Error{Message: "boom"},
+				}}}
+				return nil
+			})})
+	_, err := client.MigrateMachine("0", "node02")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachinemanagerSuite) TestMigrateMachineNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 9,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call %q", request)
+				return nil
+			})})
+	_, err := client.MigrateMachine("0", "node02")
+	c.Assert(err, gc.ErrorMatches, "migrate-machine not supported")
+}
+
+func (s *MachinemanagerSuite) TestSuspendMachines(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "SuspendMachine")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+				})
+				out := response.(*params.ErrorResults)
+				*out = params.ErrorResults{Results: []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "boom"}},
+				}}
+				return nil
+			})})
+	results, err := client.SuspendMachines("0", "1", "!")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+		{Error: &params.Error{Message: `machine ID "!" not valid`}},
+	})
+}
+
+func (s *MachinemanagerSuite) TestResumeMachines(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ResumeMachine")
+				out := response.(*params.ErrorResults)
+				*out = params.ErrorResults{Results: []params.ErrorResult{{}}}
+				return nil
+			})})
+	results, err := client.ResumeMachines("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *MachinemanagerSuite) TestSuspendMachinesNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call %q", request)
+				return nil
+			})})
+	_, err := client.SuspendMachines("0")
+	c.Assert(err, gc.ErrorMatches, "suspend-machine not supported")
+	_, err = client.ResumeMachines("0")
+	c.Assert(err, gc.ErrorMatches, "resume-machine not supported")
+}
+
+func (s *MachinemanagerSuite) TestSuspendModel(c *gc.C) {
+	var called []string
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 9,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(a, gc.IsNil)
+				called = append(called, request)
+				return nil
+			})})
+	err := client.SuspendModel()
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.ResumeModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.DeepEquals, []string{"SuspendModel", "ResumeModel"})
+}
+
+func (s *MachinemanagerSuite) TestSuspendModelNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call %q", request)
+				return nil
+			})})
+	err := client.SuspendModel()
+	c.Assert(err, gc.ErrorMatches, "suspend-model not supported")
+	err = client.ResumeModel()
+	c.Assert(err, gc.ErrorMatches, "resume-model not supported")
+}
@@ -0,0 +1,168 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher"
+)
+
+const modelSuspenderFacade = "ModelSuspender"
+
+// Client provides access to the model suspender API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client-side model suspender facade.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, modelSuspenderFacade),
+	}
+}
+
+// Suspension holds whether the suspension of the model has been
+// requested, and the model's suspend schedule.
+type Suspension struct {
+	Requested bool
+	Schedule  string
+}
+
+// Stage holds machines that are suspended or resumed together.
+type Stage struct {
+	Machines []Machine
+}
+
+// Machine holds a machine to be suspended or resumed, and the units
+// hosted on it and its containers.
+type Machine struct {
+	Tag        names.MachineTag
+	InstanceId instance.Id
+	Status     status.Status
+	Units      []Unit
+}
+
+// Unit holds a unit and its progress through the suspension or
+// resumption of the model.
+type Unit struct {
+	Tag           names.UnitTag
+	SuspendStatus model.UnitSuspendStatus
+}
+
+// WatchModelSuspension returns a NotifyWatcher that triggers when the
+// suspension of the model is requested or cancelled, or when its suspend
+// schedule might have changed.
+func (c *Client) WatchModelSuspension() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchModelSuspension", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// ModelSuspension returns whether the suspension of the model has been
+// requested, and the model's suspend schedule.
+func (c *Client) ModelSuspension() (Suspension, error) {
+	var result params.ModelSuspensionResult
+	if err := c.facade.FacadeCall("ModelSuspension", nil, &result); err != nil {
+		return Suspension{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return Suspension{}, errors.Trace(result.Error)
+	}
+	return Suspension{
+		Requested: result.Requested,
+		Schedule:  result.Schedule,
+	}, nil
+}
+
+// SetModelSuspendRequested records whether the model should be suspended.
+func (c *Client) SetModelSuspendRequested(requested bool) error {
+	args := params.SetModelSuspendRequested{Requested: requested}
+	return errors.Trace(c.facade.FacadeCall("SetModelSuspendRequested", args, nil))
+}
+
+// SuspensionPlan returns the stages in which the model's machines are
+// suspended. Resumption runs through the stages in reverse.
+func (c *Client) SuspensionPlan() ([]Stage, error) {
+	var result params.SuspensionPlanResult
+	if err := c.facade.FacadeCall("SuspensionPlan", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	stages := make([]Stage, len(result.Stages))
+	for i, stage := range result.Stages {
+		for _, m := range stage.Machines {
+			machineTag, err := names.ParseMachineTag(m.Tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			machine := Machine{
+				Tag:        machineTag,
+				InstanceId: instance.Id(m.InstanceId),
+				Status:     status.Status(m.Status),
+			}
+			for _, u := range m.Units {
+				unitTag, err := names.ParseUnitTag(u.Tag)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				machine.Units = append(machine.Units, Unit{
+					Tag:           unitTag,
+					SuspendStatus: u.SuspendStatus,
+				})
+			}
+			stages[i].Machines = append(stages[i].Machines, machine)
+		}
+	}
+	return stages, nil
+}
+
+// SetUnitSuspendStatus records the progress of the given units through
+// the suspension or resumption of the model.
+func (c *Client) SetUnitSuspendStatus(suspendStatus model.UnitSuspendStatus, units ...names.UnitTag) error {
+	args := params.UnitSuspendStatusParams{
+		Params: make([]params.UnitSuspendStatusParam, len(units)),
+	}
+	for i, unit := range units {
+		args.Params[i] = params.UnitSuspendStatusParam{
+			Entity: params.Entity{Tag: unit.String()},
+			Status: suspendStatus,
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetUnitSuspendStatus", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// SetMachineStatus sets the status of the given machine.
+func (c *Client) SetMachineStatus(machine names.MachineTag, machineStatus status.Status, info string) error {
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{{
+			Tag:    machine.String(),
+			Status: machineStatus.String(),
+			Info:   info,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetMachineStatus", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender_test
+
+import (
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelsuspender"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestModelSuspension(c *gc.C) {
+	caller := testing.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "ModelSuspender")
+		c.Check(request, gc.Equals, "ModelSuspension")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ModelSuspensionResult{})
+		*result.(*params.ModelSuspensionResult) = params.ModelSuspensionResult{
+			Requested: true,
+			Schedule:  "Sat,Sun 00:00-24:00",
+		}
+		return nil
+	})
+	client := modelsuspender.NewClient(caller)
+	suspension, err := client.ModelSuspension()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(suspension, jc.DeepEquals, modelsuspender.Suspension{
+		Requested: true,
+		Schedule:  "Sat,Sun 00:00-24:00",
+	})
+}
+
+func (s *clientSuite) TestModelSuspensionError(c *gc.C) {
+	caller := testing.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		*result.(*params.ModelSuspensionResult) = params.ModelSuspensionResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	client := modelsuspender.NewClient(caller)
+	_, err := client.ModelSuspension()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestSetModelSuspendRequested(c *gc.C) {
+	caller := testing.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SetModelSuspendRequested")
+		c.Check(arg, jc.DeepEquals, params.SetModelSuspendRequested{Requested: true})
+		return nil
+	})
+	client := modelsuspender.NewClient(caller)
+	err := client.SetModelSuspendRequested(true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestSuspensionPlan(c *gc.C) {
+	caller := testing.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SuspensionPlan")
+		c.Assert(result, gc.FitsTypeOf, &params.SuspensionPlanResult{})
+		*result.(*params.SuspensionPlanResult) = params.SuspensionPlanResult{
+			Stages: []params.SuspensionStage{{
+				Machines: []params.SuspensionMachine{{
+					Tag:        "machine-1",
+					InstanceId: "i-1",
+					Status:     "started",
+					Units: []params.SuspensionUnit{
+						{Tag: "unit-wordpress-0", SuspendStatus: model.UnitSuspending},
+					},
+				}},
+			}, {
+				Machines: []params.SuspensionMachine{{
+					Tag:        "machine-0",
+					InstanceId: "i-0",
+					Status:     "started",
+				}},
+			}},
+		}
+		return nil
+	})
+	client := modelsuspender.NewClient(caller)
+	stages, err := client.SuspensionPlan()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stages, jc.DeepEquals, []modelsuspender.Stage{{
+		Machines: []modelsuspender.Machine{{
+			Tag:        names.NewMachineTag("1"),
+			InstanceId: "i-1",
+			Status:     status.Started,
+			Units: []modelsuspender.Unit{{
+				Tag:           names.NewUnitTag("wordpress/0"),
+				SuspendStatus: model.UnitSuspending,
+			}},
+		}},
+	}, {
+		Machines: []modelsuspender.Machine{{
+			Tag:        names.NewMachineTag("0"),
+			InstanceId: "i-0",
+			Status:     status.Started,
+		}},
+	}})
+}
+
+func (s *clientSuite) TestSetUnitSuspendStatus(c *gc.C) {
+	caller := testing.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SetUnitSuspendStatus")
+		c.Check(arg, jc.DeepEquals, params.UnitSuspendStatusParams{
+			Params: []params.UnitSuspendStatusParam{
+				{Entity: params.Entity{Tag: "unit-mysql-0"}, Status: model.UnitSuspending},
+				{Entity: params.Entity{Tag: "unit-mysql-1"}, Status: model.UnitSuspending},
+			},
+		})
+		*result.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := modelsuspender.NewClient(caller)
+	err := client.SetUnitSuspendStatus(model.UnitSuspending,
+		names.NewUnitTag("mysql/0"), names.NewUnitTag("mysql/1"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestSetMachineStatus(c *gc.C) {
+	caller := testing.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SetMachineStatus")
+		c.Check(arg, jc.DeepEquals, params.SetStatus{
+			Entities: []params.EntityStatusArgs{
+				{Tag: "machine-1", Status: "started", Info: "resuming"},
+			},
+		})
+		*result.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := modelsuspender.NewClient(caller)
+	err := client.SetMachineStatus(names.NewMachineTag("1"), status.Started, "resuming")
+	c.Assert(err, jc.ErrorIsNil)
+}
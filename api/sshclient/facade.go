@@ -100,6 +100,28 @@ func (facade *Facade) PublicKeys(target string) ([]string, error) {
 	return out.Results[0].PublicKeys, nil
 }
 
+// ProxyJump returns the "[user@]host" of the SSH jump host through
+// which the SSH target provided is reached, or "" if it is reached
+// directly. The target may be provided as a machine ID or unit name.
+func (facade *Facade) ProxyJump(target string) (string, error) {
+	entities, err := targetToEntities(target)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var out params.StringResults
+	err = facade.caller.FacadeCall("ProxyJump", entities, &out)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(out.Results) != 1 {
+		return "", countError(len(out.Results))
+	}
+	if err := out.Results[0].Error; err != nil {
+		return "", errors.Trace(err)
+	}
+	return out.Results[0].Result, nil
+}
+
 // Proxy returns whether SSH connections should be proxied through the
 // controller hosts for the associated model.
 func (facade *Facade) Proxy() (bool, error) {
@@ -199,6 +199,41 @@ func (s *FacadeSuite) TestPublicKeys(c *gc.C) {
 	}})
 }
 
+func (s *FacadeSuite) TestProxyJump(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		c.Check(id, gc.Equals, "")
+		*result.(*params.StringResults) = params.StringResults{
+			Results: []params.StringResult{{Result: "admin@bastion"}},
+		}
+		return nil
+	})
+	facade := sshclient.NewFacade(apiCaller)
+	jump, err := facade.ProxyJump("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(jump, gc.Equals, "admin@bastion")
+	stub.CheckCalls(c, []jujutesting.StubCall{{
+		"SSHClient.ProxyJump",
+		[]interface{}{params.Entities{[]params.Entity{{
+			Tag: names.NewMachineTag("0").String(),
+		}}}},
+	}})
+}
+
+func (s *FacadeSuite) TestProxyJumpTargetError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*result.(*params.StringResults) = params.StringResults{
+			Results: []params.StringResult{{Error: apiservererrors.ServerError(errors.New("boom"))}},
+		}
+		return nil
+	})
+	facade := sshclient.NewFacade(apiCaller)
+	jump, err := facade.ProxyJump("0")
+	c.Check(jump, gc.Equals, "")
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
 func (s *FacadeSuite) TestPublicKeysError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		return errors.New("boom")
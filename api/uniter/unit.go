@@ -20,11 +20,13 @@ import (
 
 // Unit represents a juju unit as seen by a uniter worker.
 type Unit struct {
-	st           *State
-	tag          names.UnitTag
-	life         life.Value
-	resolvedMode params.ResolvedMode
-	providerID   string
+	st              *State
+	tag             names.UnitTag
+	life            life.Value
+	resolvedMode    params.ResolvedMode
+	providerID      string
+	hookKillVersion int
+	suspendStatus   model.UnitSuspendStatus
 }
 
 // Tag returns the unit's tag.
@@ -57,6 +59,18 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// HookKillVersion returns a counter that is incremented each time an
+// operator asks for the unit's running hook to be killed.
+func (u *Unit) HookKillVersion() int {
+	return u.hookKillVersion
+}
+
+// SuspendStatus returns the unit's progress through the suspension or
+// resumption of its model.
+func (u *Unit) SuspendStatus() model.UnitSuspendStatus {
+	return u.suspendStatus
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -80,6 +94,8 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.providerID = result.ProviderID
+	u.hookKillVersion = result.HookKillVersion
+	u.suspendStatus = result.SuspendStatus
 	return nil
 }
 
@@ -101,6 +117,48 @@ func (u *Unit) SetUnitStatus(unitStatus status.Status, info string, data map[str
 	return result.OneError()
 }
 
+// RecordHookRun adds a record of a hook execution to the unit's hook
+// history on the controller.
+func (u *Unit) RecordHookRun(record params.HookRecord) error {
+	if u.st.facade.BestAPIVersion() < 18 {
+		return errors.NotImplementedf("RecordHookRun")
+	}
+	var result params.ErrorResults
+	args := params.UnitHookRecords{
+		Records: []params.UnitHookRecord{
+			{Tag: u.tag.String(), Record: record},
+		},
+	}
+	err := u.st.facade.FacadeCall("RecordHookRuns", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// SetSuspendStatus records the unit's progress through the suspension or
+// resumption of its model.
+func (u *Unit) SetSuspendStatus(status model.UnitSuspendStatus) error {
+	if u.st.facade.BestAPIVersion() < 19 {
+		return errors.NotImplementedf("SetSuspendStatus")
+	}
+	var result params.ErrorResults
+	args := params.UnitSuspendStatusParams{
+		Params: []params.UnitSuspendStatusParam{
+			{Entity: params.Entity{Tag: u.tag.String()}, Status: status},
+		},
+	}
+	err := u.st.facade.FacadeCall("SetSuspendStatus", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := result.OneError(); err != nil {
+		return errors.Trace(err)
+	}
+	u.suspendStatus = status
+	return nil
+}
+
 // UnitStatus gets the status details of the unit.
 func (u *Unit) UnitStatus() (params.StatusResult, error) {
 	var results params.StatusResults
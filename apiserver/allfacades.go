@@ -94,6 +94,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/metricsmanager"
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget"
+	"github.com/juju/juju/apiserver/facades/controller/modelsuspender"
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
@@ -157,6 +158,10 @@ func AllFacades() *facade.Registry {
 	reg("Application", 11, application.NewFacadeV11) // Get call returns the endpoint bindings
 	reg("Application", 12, application.NewFacadeV12) // Adds UnitsInfo()
 	reg("Application", 13, application.NewFacadeV13) // Adds CharmOrigin to Deploy
+	reg("Application", 14, application.NewFacadeV14) // Adds KillHooks
+	reg("Application", 15, application.NewFacadeV15) // Adds ValidateDeploy
+	reg("Application", 16, application.NewFacadeV16) // Adds UnitsWorkload
+	reg("Application", 17, application.NewFacadeV17) // Adds AdoptK8sWorkloads
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
@@ -186,14 +191,16 @@ func AllFacades() *facade.Registry {
 
 	// CAAS related facades.
 	// Move these to the correct place above once the feature flag disappears.
-	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacade)
+	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacadeV1)
+	reg("CAASFirewaller", 2, caasfirewaller.NewStateFacade) // Adds WatchApplicationsRelations and NetworkPolicies
 	reg("CAASOperator", 1, caasoperator.NewStateFacade)
 	reg("CAASAdmission", 1, caasadmission.NewStateFacade)
 	reg("CAASAgent", 1, caasagent.NewStateFacade)
 	reg("CAASModelOperator", 1, caasmodeloperator.NewAPIFromContext)
 	reg("CAASOperatorProvisioner", 1, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPI)
 	reg("CAASOperatorUpgrader", 1, caasoperatorupgrader.NewStateCAASOperatorUpgraderAPI)
-	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacade)
+	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacadeV1)
+	reg("CAASUnitProvisioner", 2, caasunitprovisioner.NewStateFacade) // Adds WatchApplicationsConfig
 
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
@@ -207,7 +214,8 @@ func AllFacades() *facade.Registry {
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("CredentialManager", 1, credentialmanager.NewCredentialManagerAPI)
 	reg("CredentialValidator", 1, credentialvalidator.NewCredentialValidatorAPIv1)
-	reg("CredentialValidator", 2, credentialvalidator.NewCredentialValidatorAPI) // adds WatchModelCredential
+	reg("CredentialValidator", 2, credentialvalidator.NewCredentialValidatorAPIv2) // adds WatchModelCredential
+	reg("CredentialValidator", 3, credentialvalidator.NewCredentialValidatorAPI)   // adds UpdateModelCredential
 	reg("ExternalControllerUpdater", 1, externalcontrollerupdater.NewStateAPI)
 
 	reg("Deployer", 1, deployer.NewDeployerAPI)
@@ -229,7 +237,8 @@ func AllFacades() *facade.Registry {
 	reg("InstanceMutater", 2, instancemutater.NewFacadeV2)
 
 	reg("InstancePoller", 3, instancepoller.NewFacadeV3)
-	reg("InstancePoller", 4, instancepoller.NewFacade)
+	reg("InstancePoller", 4, instancepoller.NewFacadeV4)
+	reg("InstancePoller", 5, instancepoller.NewFacade)
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 
@@ -241,10 +250,14 @@ func AllFacades() *facade.Registry {
 	reg("MachineActions", 1, machineactions.NewExternalFacade)
 
 	reg("MachineManager", 2, machinemanager.NewFacade)
-	reg("MachineManager", 3, machinemanager.NewFacade)   // Adds DestroyMachine and ForceDestroyMachine.
-	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Adds DestroyMachineWithParams.
-	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
-	reg("MachineManager", 6, machinemanager.NewFacadeV6) // DestroyMachinesWithParams gains maxWait.
+	reg("MachineManager", 3, machinemanager.NewFacade)     // Adds DestroyMachine and ForceDestroyMachine.
+	reg("MachineManager", 4, machinemanager.NewFacadeV4)   // Adds DestroyMachineWithParams.
+	reg("MachineManager", 5, machinemanager.NewFacadeV5)   // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
+	reg("MachineManager", 6, machinemanager.NewFacadeV6)   // DestroyMachinesWithParams gains maxWait.
+	reg("MachineManager", 7, machinemanager.NewFacadeV7)   // Adds ResizeMachine.
+	reg("MachineManager", 8, machinemanager.NewFacadeV8)   // Adds SuspendMachine and ResumeMachine.
+	reg("MachineManager", 9, machinemanager.NewFacadeV9)   // Adds SuspendModel and ResumeModel.
+	reg("MachineManager", 10, machinemanager.NewFacadeV10) // Adds MigrateMachine.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPIV1)
@@ -278,6 +291,7 @@ func AllFacades() *facade.Registry {
 	reg("ModelManager", 7, modelmanager.NewFacadeV7) // DestroyModels gains 'force' and max-wait' parameters.
 	reg("ModelManager", 8, modelmanager.NewFacadeV8) // ModelInfo gains credential validity in return.
 	reg("ModelManager", 9, modelmanager.NewFacadeV9) // Adds ValidateModelUpgrade
+	reg("ModelSuspender", 1, modelsuspender.NewFacade)
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
@@ -313,6 +327,7 @@ func AllFacades() *facade.Registry {
 
 	reg("SSHClient", 1, sshclient.NewFacade)
 	reg("SSHClient", 2, sshclient.NewFacade) // v2 adds AllAddresses() method.
+	reg("SSHClient", 3, sshclient.NewFacade) // v3 adds ProxyJump() method.
 
 	reg("Spaces", 2, spaces.NewAPIv2)
 	reg("Spaces", 3, spaces.NewAPIv3)
@@ -348,7 +363,9 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 14, uniter.NewUniterAPIV14)
 	reg("Uniter", 15, uniter.NewUniterAPIV15)
 	reg("Uniter", 16, uniter.NewUniterAPIV16)
-	reg("Uniter", 17, uniter.NewUniterAPI)
+	reg("Uniter", 17, uniter.NewUniterAPIV17)
+	reg("Uniter", 18, uniter.NewUniterAPIV18) // Adds RecordHookRuns
+	reg("Uniter", 19, uniter.NewUniterAPI)    // Adds SetSuspendStatus
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 
@@ -45,7 +45,9 @@ func (c *ModelPresenceContext) MachineStatus(machine MachineStatusGetter) (statu
 
 func canMachineBeDown(machineStatus status.StatusInfo) bool {
 	switch machineStatus.Status {
-	case status.Pending, status.Stopped:
+	case status.Pending, status.Stopped, status.Suspended:
+		// A suspended machine's instance is powered off, so its
+		// agent is not expected to be communicating.
 		return false
 	}
 	return true
@@ -79,3 +79,9 @@ func (s *MachineStatusSuite) TestNotDownIfPending(c *gc.C) {
 	s.machine.status = status.Pending
 	s.checkUntouched(c)
 }
+
+func (s *MachineStatusSuite) TestNotDownIfSuspended(c *gc.C) {
+	s.ctx.Presence = agentDown(names.NewMachineTag(s.machine.Id()).String())
+	s.machine.status = status.Suspended
+	s.checkUntouched(c)
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -20,7 +20,7 @@ func FilesystemParams(
 	f state.Filesystem,
 	storageInstance state.StorageInstance,
 	modelUUID, controllerUUID string,
-	environConfig *config.Config,
+	tagger tags.ResourceTagger,
 	poolManager poolmanager.PoolManager,
 	registry storage.ProviderRegistry,
 ) (params.FilesystemParams, error) {
@@ -39,7 +39,7 @@ func FilesystemParams(
 		size = filesystemInfo.Size
 	}
 
-	filesystemTags, err := StorageTags(storageInstance, modelUUID, controllerUUID, environConfig)
+	filesystemTags, err := StorageTags(storageInstance, modelUUID, controllerUUID, tagger)
 	if err != nil {
 		return params.FilesystemParams{}, errors.Annotate(err, "computing storage tags")
 	}
@@ -269,6 +269,9 @@ func StorageTags(
 	modelUUID, controllerUUID string,
 	tagger tags.ResourceTagger,
 ) (map[string]string, error) {
+	if storageInstance != nil {
+		tagger = tags.WithTemplateVars(tagger, storageOwnerTemplateVars(storageInstance))
+	}
 	storageTags := tags.ResourceTags(
 		names.NewModelTag(modelUUID),
 		names.NewControllerTag(controllerUUID),
@@ -283,6 +286,23 @@ func StorageTags(
 	return storageTags, nil
 }
 
+// storageOwnerTemplateVars returns the resource tag template variables
+// describing the owner of the given storage instance.
+func storageOwnerTemplateVars(storageInstance state.StorageInstance) tags.TemplateVars {
+	owner, ok := storageInstance.Owner()
+	if !ok {
+		return tags.TemplateVars{}
+	}
+	switch owner := owner.(type) {
+	case names.UnitTag:
+		applicationName, _ := names.UnitApplication(owner.Id())
+		return tags.TemplateVars{Application: applicationName, Unit: owner.Id()}
+	case names.ApplicationTag:
+		return tags.TemplateVars{Application: owner.Id()}
+	}
+	return tags.TemplateVars{}
+}
+
 // These methods are used by ModelManager and Application facades
 // when destroying models and applications/units.
 
@@ -89,3 +89,33 @@ func (*volumesSuite) TestVolumeParamsStorageTags(c *gc.C) {
 		},
 	})
 }
+
+func (*volumesSuite) TestVolumeParamsStorageTagsTemplates(c *gc.C) {
+	volumeTag := names.NewVolumeTag("100")
+	storageTag := names.NewStorageTag("mystore/0")
+	unitTag := names.NewUnitTag("mysql/123")
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"resource-tags": "owner=${owner} project=${model}-${application} unit=${unit}",
+	})
+	p, err := storagecommon.VolumeParams(
+		&fakeVolume{tag: volumeTag, params: &state.VolumeParams{
+			Pool: "loop", Size: 1024,
+		}},
+		&fakeStorageInstance{tag: storageTag, owner: unitTag},
+		testing.ModelTag.Id(),
+		testing.ControllerTag.Id(),
+		tags.WithTemplateVars(cfg, tags.TemplateVars{Owner: "bob"}),
+		&fakePoolManager{},
+		provider.CommonStorageProviders(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Tags, jc.DeepEquals, map[string]string{
+		tags.JujuController:      testing.ControllerTag.Id(),
+		tags.JujuModel:           testing.ModelTag.Id(),
+		tags.JujuStorageInstance: "mystore/0",
+		tags.JujuStorageOwner:    "mysql/123",
+		"owner":                  "bob",
+		"project":                cfg.Name() + "-mysql",
+		"unit":                   "mysql/123",
+	})
+}
@@ -29,6 +29,10 @@ type Backend interface {
 
 	// WatchModelCredential returns a watcher that is keeping an eye on what cloud credential a model uses.
 	WatchModelCredential() (state.NotifyWatcher, error)
+
+	// UpdateModelCredential replaces the content of the cloud credential
+	// that a current model uses, which becomes valid.
+	UpdateModelCredential(credential jujucloud.Credential) error
 }
 
 func NewBackend(st StateAccessor) Backend {
@@ -98,6 +102,26 @@ func (b *backend) WatchModelCredential() (state.NotifyWatcher, error) {
 	return m.WatchModelCredential(), nil
 }
 
+// UpdateModelCredential implements Backend.UpdateModelCredential.
+func (b *backend) UpdateModelCredential(credential jujucloud.Credential) error {
+	m, err := b.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tag, exists := m.CloudCredentialTag()
+	if !exists {
+		return errors.NotFoundf("model credential")
+	}
+	existing, err := b.CloudCredential(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if authType := credential.AuthType(); string(authType) != existing.AuthType {
+		return errors.NotValidf("changing auth type of credential %q from %q to %q", tag.Id(), existing.AuthType, authType)
+	}
+	return errors.Trace(b.UpdateCloudCredential(tag, credential))
+}
+
 func (b *backend) cloudSupportsNoAuth(cloudName string) (bool, error) {
 	cloud, err := b.Cloud(cloudName)
 	if err != nil {
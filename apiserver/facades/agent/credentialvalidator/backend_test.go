@@ -110,6 +110,28 @@ func (s *BackendSuite) TestWatchModelCredentialErr(c *gc.C) {
 	s.state.CheckCallNames(c, "Model")
 }
 
+func (s *BackendSuite) TestUpdateModelCredential(c *gc.C) {
+	credential := cloud.NewCredential(cloud.EmptyAuthType, nil)
+	err := s.backend.UpdateModelCredential(credential)
+	c.Assert(err, jc.ErrorIsNil)
+	s.state.CheckCallNames(c, "Model", "mockModel.CloudCredentialTag", "mockState.CloudCredentialTag", "UpdateCloudCredential")
+	s.state.CheckCall(c, 3, "UpdateCloudCredential", s.state.aModel.credentialTag, credential)
+}
+
+func (s *BackendSuite) TestUpdateModelCredentialChangingAuthType(c *gc.C) {
+	credential := cloud.NewCredential(cloud.CertificateAuthType, map[string]string{"Token": "rotated"})
+	err := s.backend.UpdateModelCredential(credential)
+	c.Assert(err, gc.ErrorMatches, `changing auth type of credential "foo/bob/one" from "empty" to "certificate" not valid`)
+	s.state.CheckCallNames(c, "Model", "mockModel.CloudCredentialTag", "mockState.CloudCredentialTag")
+}
+
+func (s *BackendSuite) TestUpdateModelCredentialUnset(c *gc.C) {
+	s.state.aModel.credentialSet = false
+	err := s.backend.UpdateModelCredential(cloud.NewEmptyCredential())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.state.CheckCallNames(c, "Model", "mockModel.CloudCredentialTag")
+}
+
 func newMockState() *mockState {
 	b := &mockState{
 		Stub:        &testing.Stub{},
@@ -162,6 +184,11 @@ func (b *mockState) InvalidateModelCredential(reason string) error {
 	return b.NextErr()
 }
 
+func (b *mockState) UpdateCloudCredential(tag names.CloudCredentialTag, credential cloud.Credential) error {
+	b.AddCall("UpdateCloudCredential", tag, credential)
+	return b.NextErr()
+}
+
 func (b *mockState) Cloud(name string) (cloud.Cloud, error) {
 	b.AddCall("Cloud", name)
 	if err := b.NextErr(); err != nil {
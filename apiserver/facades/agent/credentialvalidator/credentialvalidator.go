@@ -11,11 +11,22 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/state/watcher"
 )
 
 var logger = loggo.GetLogger("juju.api.credentialvalidator")
 
+// CredentialValidatorV3 defines the methods on version 3 facade for the
+// credentialvalidator API endpoint.
+type CredentialValidatorV3 interface {
+	InvalidateModelCredential(params.InvalidateCredentialArg) (params.ErrorResult, error)
+	ModelCredential() (params.ModelCredential, error)
+	UpdateModelCredential(params.UpdateModelCredentialArg) (params.ErrorResult, error)
+	WatchCredential(params.Entity) (params.NotifyWatchResult, error)
+	WatchModelCredential() (params.NotifyWatchResult, error)
+}
+
 // CredentialValidatorV2 defines the methods on version 2 facade for the
 // credentialvalidator API endpoint.
 type CredentialValidatorV2 interface {
@@ -36,16 +47,22 @@ type CredentialValidatorV1 interface {
 type CredentialValidatorAPI struct {
 	*credentialcommon.CredentialManagerAPI
 
-	backend   Backend
-	resources facade.Resources
+	backend    Backend
+	resources  facade.Resources
+	authorizer facade.Authorizer
 }
 
-type CredentialValidatorAPIV1 struct {
+type CredentialValidatorAPIV2 struct {
 	*CredentialValidatorAPI
 }
 
+type CredentialValidatorAPIV1 struct {
+	*CredentialValidatorAPIV2
+}
+
 var (
-	_ CredentialValidatorV2 = (*CredentialValidatorAPI)(nil)
+	_ CredentialValidatorV3 = (*CredentialValidatorAPI)(nil)
+	_ CredentialValidatorV2 = (*CredentialValidatorAPIV2)(nil)
 	_ CredentialValidatorV1 = (*CredentialValidatorAPIV1)(nil)
 )
 
@@ -54,9 +71,18 @@ func NewCredentialValidatorAPI(ctx facade.Context) (*CredentialValidatorAPI, err
 	return internalNewCredentialValidatorAPI(NewBackend(NewStateShim(ctx.State())), ctx.Resources(), ctx.Auth())
 }
 
+// NewCredentialValidatorAPIv2 creates a new CredentialValidator API endpoint on server-side.
+func NewCredentialValidatorAPIv2(ctx facade.Context) (*CredentialValidatorAPIV2, error) {
+	v3, err := NewCredentialValidatorAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &CredentialValidatorAPIV2{v3}, nil
+}
+
 // NewCredentialValidatorAPIv1 creates a new CredentialValidator API endpoint on server-side.
 func NewCredentialValidatorAPIv1(ctx facade.Context) (*CredentialValidatorAPIV1, error) {
-	v2, err := NewCredentialValidatorAPI(ctx)
+	v2, err := NewCredentialValidatorAPIv2(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +98,7 @@ func internalNewCredentialValidatorAPI(backend Backend, resources facade.Resourc
 		CredentialManagerAPI: credentialcommon.NewCredentialManagerAPI(backend),
 		resources:            resources,
 		backend:              backend,
+		authorizer:           authorizer,
 	}, nil
 }
 
@@ -131,6 +158,9 @@ func (api *CredentialValidatorAPI) ModelCredential() (params.ModelCredential, er
 // WatchModelCredential did not exist prior to v2.
 func (*CredentialValidatorAPIV1) WatchModelCredential(_, _ struct{}) {}
 
+// UpdateModelCredential did not exist prior to v3.
+func (*CredentialValidatorAPIV2) UpdateModelCredential(_, _ struct{}) {}
+
 // WatchModelCredential returns a NotifyWatcher that watches what cloud credential a model uses.
 func (api *CredentialValidatorAPI) WatchModelCredential() (params.NotifyWatchResult, error) {
 	result := params.NotifyWatchResult{}
@@ -150,3 +180,15 @@ func (api *CredentialValidatorAPI) WatchModelCredential() (params.NotifyWatchRes
 	}
 	return result, nil
 }
+
+// UpdateModelCredential replaces the content of the cloud credential that
+// the model uses, such as when a rotated service account token has been
+// read from the cluster. Only controller agents may do this.
+func (api *CredentialValidatorAPI) UpdateModelCredential(arg params.UpdateModelCredentialArg) (params.ErrorResult, error) {
+	if !api.authorizer.AuthController() {
+		return params.ErrorResult{}, apiservererrors.ErrPerm
+	}
+	credential := cloud.NewCredential(cloud.AuthType(arg.Credential.AuthType), arg.Credential.Attributes)
+	err := api.backend.UpdateModelCredential(credential)
+	return params.ErrorResult{Error: apiservererrors.ServerError(err)}, nil
+}
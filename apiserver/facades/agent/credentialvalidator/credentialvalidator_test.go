@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/credentialvalidator"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -120,6 +121,32 @@ func (s *CredentialValidatorSuite) TestWatchModelCredentialError(c *gc.C) {
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 }
 
+func (s *CredentialValidatorSuite) TestUpdateModelCredential(c *gc.C) {
+	s.authorizer.Controller = true
+	api, err := credentialvalidator.NewCredentialValidatorAPIForTest(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.UpdateModelCredential(params.UpdateModelCredentialArg{
+		Credential: params.CloudCredential{
+			AuthType:   "certificate",
+			Attributes: map[string]string{"Token": "rotated"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"UpdateModelCredential", []interface{}{
+			cloud.NewCredential(cloud.CertificateAuthType, map[string]string{"Token": "rotated"}),
+		}},
+	})
+}
+
+func (s *CredentialValidatorSuite) TestUpdateModelCredentialNotController(c *gc.C) {
+	_, err := s.api.UpdateModelCredential(params.UpdateModelCredentialArg{})
+	c.Assert(err, gc.ErrorMatches, apiservererrors.ErrPerm.Error())
+	s.backend.CheckNoCalls(c)
+}
+
 // modelUUID is the model tag we're using in the tests.
 var modelUUID = "01234567-89ab-cdef-0123-456789abcdef"
 
@@ -174,6 +201,11 @@ func (b *testBackend) InvalidateModelCredential(reason string) error {
 	return b.NextErr()
 }
 
+func (b *testBackend) UpdateModelCredential(credential cloud.Credential) error {
+	b.AddCall("UpdateModelCredential", credential)
+	return b.NextErr()
+}
+
 func (b *testBackend) WatchModelCredential() (state.NotifyWatcher, error) {
 	b.AddCall("WatchModelCredential")
 	if err := b.NextErr(); err != nil {
@@ -24,6 +24,7 @@ type StateAccessor interface {
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	WatchCredential(names.CloudCredentialTag) state.NotifyWatcher
 	InvalidateModelCredential(reason string) error
+	UpdateCloudCredential(tag names.CloudCredentialTag, credential cloud.Credential) error
 	Cloud(name string) (cloud.Cloud, error)
 }
 
@@ -176,6 +176,7 @@ func (api *ProvisionerAPI) getProvisioningInfoBase(m *state.Machine,
 		Series:            m.Series(),
 		Placement:         m.Placement(),
 		CloudInitUserData: env.Config().CloudInitUserData(),
+		ModelOwner:        api.m.Owner().Id(),
 
 		// EndpointBindings are used by MAAS by the provider. Operator defined
 		// space bindings are reflected in ProvisioningNetworkTopology.
@@ -257,7 +258,8 @@ func (api *ProvisionerAPI) machineVolumeParams(
 		}
 		volumeParams, err := storagecommon.VolumeParams(
 			volume, storageInstance, modelConfig.UUID(), controllerCfg.ControllerUUID(),
-			modelConfig, api.storagePoolManager, api.storageProviderRegistry,
+			tags.WithTemplateVars(modelConfig, tags.TemplateVars{Owner: api.m.Owner().Id()}),
+			api.storagePoolManager, api.storageProviderRegistry,
 		)
 		if err != nil {
 			return nil, nil, errors.Annotatef(err, "getting volume %q parameters", volumeTag.Id())
@@ -319,11 +321,13 @@ func (api *ProvisionerAPI) machineTags(m *state.Machine, jobs []model.MachineJob
 		return nil, errors.Trace(err)
 	}
 	unitNames := make([]string, 0, len(units))
+	applicationNames := set.NewStrings()
 	for _, unit := range units {
 		if !unit.IsPrincipal() {
 			continue
 		}
 		unitNames = append(unitNames, unit.Name())
+		applicationNames.Add(unit.ApplicationName())
 	}
 	sort.Strings(unitNames)
 
@@ -337,7 +341,12 @@ func (api *ProvisionerAPI) machineTags(m *state.Machine, jobs []model.MachineJob
 		return nil, errors.Trace(err)
 	}
 
-	machineTags := instancecfg.InstanceTags(cfg.UUID(), controllerCfg.ControllerUUID(), cfg, jobs)
+	tagger := tags.WithTemplateVars(cfg, tags.TemplateVars{
+		Owner:       api.m.Owner().Id(),
+		Application: strings.Join(applicationNames.SortedValues(), " "),
+		Unit:        strings.Join(unitNames, " "),
+	})
+	machineTags := instancecfg.InstanceTags(cfg.UUID(), controllerCfg.ControllerUUID(), tagger, jobs)
 	if len(unitNames) > 0 {
 		machineTags[tags.JujuUnitsDeployed] = strings.Join(unitNames, " ")
 	}
@@ -62,6 +62,7 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithStorage(c *gc.C) {
 				ProvisioningInfoBase: params.ProvisioningInfoBase{
 					ControllerConfig: controllerCfg,
 					Series:           "quantal",
+					ModelOwner:       s.AdminUserTag(c).Id(),
 					Jobs:             []model.MachineJob{model.JobHostUnits},
 					Tags: map[string]string{
 						tags.JujuController: coretesting.ControllerTag.Id(),
@@ -75,6 +76,7 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithStorage(c *gc.C) {
 				ProvisioningInfoBase: params.ProvisioningInfoBase{
 					ControllerConfig: controllerCfg,
 					Series:           "quantal",
+					ModelOwner:       s.AdminUserTag(c).Id(),
 					Constraints:      template.Constraints,
 					Placement:        template.Placement,
 					Jobs:             []model.MachineJob{model.JobHostUnits},
@@ -158,6 +160,7 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithSingleNegativeAndPositi
 				ProvisioningInfoBase: params.ProvisioningInfoBase{
 					ControllerConfig: controllerCfg,
 					Series:           "quantal",
+					ModelOwner:       s.AdminUserTag(c).Id(),
 					Constraints:      template.Constraints,
 					Placement:        template.Placement,
 					Jobs:             []model.MachineJob{model.JobHostUnits},
@@ -203,6 +206,7 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithMultiplePositiveSpaceCo
 		ProvisioningInfoBase: params.ProvisioningInfoBase{
 			ControllerConfig: s.ControllerConfig,
 			Series:           "quantal",
+			ModelOwner:       s.AdminUserTag(c).Id(),
 			Constraints:      template.Constraints,
 			Placement:        template.Placement,
 			Jobs:             []model.MachineJob{model.JobHostUnits},
@@ -280,6 +284,7 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithEndpointBindings(c *gc.
 				ProvisioningInfoBase: params.ProvisioningInfoBase{
 					ControllerConfig: controllerCfg,
 					Series:           "quantal",
+					ModelOwner:       s.AdminUserTag(c).Id(),
 					Jobs:             []model.MachineJob{model.JobHostUnits},
 					Tags: map[string]string{
 						tags.JujuController:    coretesting.ControllerTag.Id(),
@@ -493,6 +498,7 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithLXDProfile(c *gc.C) {
 				ProvisioningInfoBase: params.ProvisioningInfoBase{
 					ControllerConfig: controllerCfg,
 					Series:           "quantal",
+					ModelOwner:       s.AdminUserTag(c).Id(),
 					Jobs:             []model.MachineJob{model.JobHostUnits},
 					Tags: map[string]string{
 						tags.JujuController:    coretesting.ControllerTag.Id(),
@@ -538,6 +544,7 @@ func (s *withoutControllerSuite) TestStorageProviderFallbackToType(c *gc.C) {
 				ProvisioningInfoBase: params.ProvisioningInfoBase{
 					ControllerConfig: controllerCfg,
 					Series:           "quantal",
+					ModelOwner:       s.AdminUserTag(c).Id(),
 					Constraints:      template.Constraints,
 					Placement:        template.Placement,
 					Jobs:             []model.MachineJob{model.JobHostUnits},
@@ -689,6 +696,7 @@ func (s *withoutControllerSuite) TestProvisioningInfoPermissions(c *gc.C) {
 				ProvisioningInfoBase: params.ProvisioningInfoBase{
 					ControllerConfig: controllerCfg,
 					Series:           "quantal",
+					ModelOwner:       s.AdminUserTag(c).Id(),
 					Jobs:             []model.MachineJob{model.JobHostUnits},
 					Tags: map[string]string{
 						tags.JujuController: coretesting.ControllerTag.Id(),
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/storage"
@@ -765,7 +766,8 @@ func (s *StorageProvisionerAPIv3) VolumeParams(args params.Entities) (params.Vol
 		}
 		volumeParams, err := storagecommon.VolumeParams(
 			volume, storageInstance, modelCfg.UUID(), controllerCfg.ControllerUUID(),
-			modelCfg, s.poolManager, s.registry,
+			tags.WithTemplateVars(modelCfg, tags.TemplateVars{Owner: s.st.Owner().Id()}),
+			s.poolManager, s.registry,
 		)
 		if err != nil {
 			return params.VolumeParams{}, err
@@ -913,7 +915,8 @@ func (s *StorageProvisionerAPIv3) FilesystemParams(args params.Entities) (params
 		}
 		filesystemParams, err := storagecommon.FilesystemParams(
 			filesystem, storageInstance, modelConfig.UUID(), controllerCfg.ControllerUUID(),
-			modelConfig, s.poolManager, s.registry,
+			tags.WithTemplateVars(modelConfig, tags.TemplateVars{Owner: s.st.Owner().Id()}),
+			s.poolManager, s.registry,
 		)
 		if err != nil {
 			return params.FilesystemParams{}, err
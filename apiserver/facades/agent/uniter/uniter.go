@@ -39,9 +39,8 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v17) of the Uniter API, which
-// augments the payload of the CommitHookChanges API call and introduces
-// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+// UniterAPI implements the latest version (v19) of the Uniter API, which
+// adds the SetSuspendStatus call.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -80,6 +79,19 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV18 implements version (v18) of the Uniter API, which adds
+// the RecordHookRuns call.
+type UniterAPIV18 struct {
+	UniterAPI
+}
+
+// UniterAPIV17 implements version (v17) of the Uniter API, which
+// augments the payload of the CommitHookChanges API call and introduces
+// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+type UniterAPIV17 struct {
+	UniterAPI
+}
+
 // UniterAPIV16 implements version (v16) of the Uniter API, which adds
 // LXDPorfileAPIV2.
 type UniterAPIV16 struct {
@@ -259,6 +271,28 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV18 creates an instance of the V18 uniter API.
+func NewUniterAPIV18(context facade.Context) (*UniterAPIV18, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV18{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV17 creates an instance of the V17 uniter API.
+func NewUniterAPIV17(context facade.Context) (*UniterAPIV17, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV17{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV16 creates an instance of the V16 uniter API.
 func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
 	uniterAPI, err := NewUniterAPI(context)
@@ -1070,6 +1104,99 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 	return result, nil
 }
 
+// RecordHookRuns is not available in V17 of the API.
+func (u *UniterAPIV17) RecordHookRuns(_ struct{}) {}
+
+// RecordHookRuns is not available in V16 of the API.
+func (u *UniterAPIV16) RecordHookRuns(_ struct{}) {}
+
+// RecordHookRuns is not available in V15 of the API.
+func (u *UniterAPIV15) RecordHookRuns(_ struct{}) {}
+
+// RecordHookRuns adds the supplied hook execution records to the
+// hook history of each unit.
+func (u *UniterAPI) RecordHookRuns(args params.UnitHookRecords) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Records)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Records {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = unit.AddHookRecord(state.HookRecord{
+			HookName:   arg.Record.HookName,
+			Relation:   arg.Record.Relation,
+			RemoteUnit: arg.Record.RemoteUnit,
+			Started:    arg.Record.Started,
+			Duration:   arg.Record.Duration,
+			ExitCode:   arg.Record.ExitCode,
+			Output:     arg.Record.Output,
+		})
+		resultItem.Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetSuspendStatus is not available in V18 of the API.
+func (u *UniterAPIV18) SetSuspendStatus(_ struct{}) {}
+
+// SetSuspendStatus is not available in V17 of the API.
+func (u *UniterAPIV17) SetSuspendStatus(_ struct{}) {}
+
+// SetSuspendStatus is not available in V16 of the API.
+func (u *UniterAPIV16) SetSuspendStatus(_ struct{}) {}
+
+// SetSuspendStatus is not available in V15 of the API.
+func (u *UniterAPIV15) SetSuspendStatus(_ struct{}) {}
+
+// SetSuspendStatus records each unit's progress through the suspension
+// or resumption of its model, once its pre-suspend or post-resume hook
+// has run.
+func (u *UniterAPI) SetSuspendStatus(args params.UnitSuspendStatusParams) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Params)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Params {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Entity.Tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		resultItem.Error = apiservererrors.ServerError(unit.SetSuspendStatus(arg.Status))
+	}
+	return result, nil
+}
+
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -1573,6 +1700,8 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 			if unit, err = u.getUnit(tag); err == nil {
 				result.Results[i].Life = life.Value(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				result.Results[i].HookKillVersion = unit.HookKillVersion()
+				result.Results[i].SuspendStatus = unit.SuspendStatus()
 
 				var err1 error
 				result.Results[i].ProviderID, err1 = u.getProviderID(unit)
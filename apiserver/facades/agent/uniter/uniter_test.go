@@ -995,6 +995,65 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestRecordHookRuns(c *gc.C) {
+	started := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	record := params.HookRecord{
+		HookName:   "db-relation-changed",
+		Relation:   "db:1",
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Duration:   2 * time.Second,
+		ExitCode:   1,
+		Output:     "boom",
+	}
+	args := params.UnitHookRecords{Records: []params.UnitHookRecord{
+		{Tag: "unit-mysql-0", Record: record},
+		{Tag: "unit-wordpress-0", Record: record},
+		{Tag: "unit-foo-42", Record: record},
+	}}
+	result, err := s.uniter.RecordHookRuns(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	history, err := s.wordpressUnit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Started.Equal(started), jc.IsTrue)
+	c.Assert(history[0].HookName, gc.Equals, "db-relation-changed")
+	c.Assert(history[0].Relation, gc.Equals, "db:1")
+	c.Assert(history[0].RemoteUnit, gc.Equals, "mysql/0")
+	c.Assert(history[0].Duration, gc.Equals, 2*time.Second)
+	c.Assert(history[0].ExitCode, gc.Equals, 1)
+	c.Assert(history[0].Output, gc.Equals, "boom")
+}
+
+func (s *uniterSuite) TestSetSuspendStatus(c *gc.C) {
+	args := params.UnitSuspendStatusParams{Params: []params.UnitSuspendStatusParam{
+		{Entity: params.Entity{Tag: "unit-mysql-0"}, Status: model.UnitSuspended},
+		{Entity: params.Entity{Tag: "unit-wordpress-0"}, Status: model.UnitSuspended},
+		{Entity: params.Entity{Tag: "unit-foo-42"}, Status: model.UnitSuspended},
+	}}
+	result, err := s.uniter.SetSuspendStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.SuspendStatus(), gc.Equals, model.UnitSuspended)
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -3805,6 +3864,19 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, expect)
 }
 
+func (s *uniterSuite) TestRefreshSuspendStatus(c *gc.C) {
+	err := s.wordpressUnit.SetSuspendStatus(model.UnitSuspending)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{
+		Entities: []params.Entity{{s.wordpressUnit.Tag().String()}},
+	}
+	results, err := s.uniter.Refresh(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].SuspendStatus, gc.Equals, model.UnitSuspending)
+}
+
 func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	results, err := s.uniter.Refresh(params.Entities{Entities: []params.Entity{}})
 	c.Assert(err, jc.ErrorIsNil)
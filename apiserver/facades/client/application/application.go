@@ -43,6 +43,7 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/permission"
+	coreseries "github.com/juju/juju/core/series"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/feature"
@@ -109,6 +110,30 @@ type APIv12 struct {
 // It adds CharmOrigin. The ApplicationsInfo call populates the exposed
 // endpoints field in its response entries.
 type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// It adds the KillHooks and HookHistory methods.
+type APIv14 struct {
+	*APIv15
+}
+
+// APIv15 provides the Application API facade for version 15.
+// It adds the ValidateDeploy method.
+type APIv15 struct {
+	*APIv16
+}
+
+// APIv16 provides the Application API facade for version 16.
+// It adds the UnitsWorkload method.
+type APIv16 struct {
+	*APIv17
+}
+
+// APIv17 provides the Application API facade for version 17.
+// It adds the AdoptK8sWorkloads method.
+type APIv17 struct {
 	*APIBase
 }
 
@@ -224,16 +249,50 @@ func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
 }
 
 func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV14(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv13{api}, nil
 }
 
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := NewFacadeV15(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
+func NewFacadeV15(ctx facade.Context) (*APIv15, error) {
+	api, err := NewFacadeV16(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv15{api}, nil
+}
+
+func NewFacadeV16(ctx facade.Context) (*APIv16, error) {
+	api, err := NewFacadeV17(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv16{api}, nil
+}
+
+func NewFacadeV17(ctx facade.Context) (*APIv17, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv17{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
+	UnitWorkload(appName string, podName string, logLines int) (*caas.UnitWorkload, error)
+	AdoptWorkload(kind caas.WorkloadKind, name string) ([]caas.Unit, error)
 }
 
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
@@ -493,6 +552,178 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 	return result, nil
 }
 
+// ValidateDeploy isn't on the v14 API.
+func (u *APIv14) ValidateDeploy(_, _ struct{}) {}
+
+// ValidateDeploy makes the checks that Deploy would make on each of the
+// specified applications, without deploying them. Every check is run, so
+// that all of the problems with a deployment are reported together.
+func (api *APIBase) ValidateDeploy(args params.ApplicationsDeploy) (params.DeployValidationResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.DeployValidationResults{}, errors.Trace(err)
+	}
+	results := make([]params.DeployValidationResult, len(args.Applications))
+	for i, arg := range args.Applications {
+		results[i] = api.validateDeploy(arg)
+	}
+	return params.DeployValidationResults{Results: results}, nil
+}
+
+// deployChecks accumulates the outcome of the checks made on a
+// deployment, keeping the first failure for each kind of check.
+type deployChecks struct {
+	checks []params.DeployCheck
+	index  map[string]int
+}
+
+func (c *deployChecks) record(name string, err error) {
+	if c.index == nil {
+		c.index = make(map[string]int)
+	}
+	i, ok := c.index[name]
+	if !ok {
+		c.index[name] = len(c.checks)
+		c.checks = append(c.checks, params.DeployCheck{
+			Name:  name,
+			Error: apiservererrors.ServerError(err),
+		})
+		return
+	}
+	if c.checks[i].Error == nil {
+		c.checks[i].Error = apiservererrors.ServerError(err)
+	}
+}
+
+// validateDeploy makes the checks done by deployApplication, and those
+// done by state when adding the application, on a single application.
+func (api *APIBase) validateDeploy(args params.ApplicationDeploy) params.DeployValidationResult {
+	result := params.DeployValidationResult{ApplicationName: args.ApplicationName}
+	ch, curl, err := api.deployCharm(args)
+	if err != nil {
+		result.Error = apiservererrors.ServerError(err)
+		return result
+	}
+
+	var checks deployChecks
+	checks.record("model", api.check.ChangeAllowed())
+	checks.record("charm", jujuversion.CheckJujuMinVersion(ch.Meta().MinJujuVersion, jujuversion.Current))
+	checks.record("placement", checkMachinePlacement(api.backend, args))
+	checks.record("lxd-profile", lxdprofile.ValidateLXDProfile(lxdCharmProfiler{Charm: ch}))
+
+	modelType := api.model.Type()
+	if modelType != state.ModelTypeIAAS {
+		cfg, err := api.backend.ControllerConfig()
+		if err == nil {
+			err = caasPrecheck(ch, cfg, api.model, args, api.storagePoolManager, api.registry, api.caasBroker)
+		}
+		checks.record("kubernetes", err)
+	}
+
+	appConfig, _, charmSettings, err := parseCharmSettings(modelType, ch, args.ApplicationName, args.Config, args.ConfigYAML)
+	if err == nil {
+		charmSettings, err = ch.Config().ValidateSettings(charmSettings)
+	}
+	checks.record("config", err)
+
+	var subordinateErr error
+	if ch.Meta().Subordinate {
+		if args.NumUnits == 1 && len(args.Placement) == 0 {
+			// The client defaults to no units for a subordinate, but
+			// it cannot tell that a charm that has not been added to
+			// the model is a subordinate, so the default applies here.
+			args.NumUnits = 0
+		}
+		if args.NumUnits != 0 {
+			subordinateErr = errors.New("subordinate application must be deployed without units")
+		} else if !constraints.IsEmpty(&args.Constraints) {
+			subordinateErr = errors.New("subordinate application must be deployed without constraints")
+		}
+	}
+	checks.record("subordinate", subordinateErr)
+
+	attachStorage, err := parseAttachStorage(args)
+	checks.record("storage", err)
+
+	var endpointBindings map[string]string
+	bindings, err := state.NewBindings(api.backend, args.EndpointBindings)
+	if err == nil {
+		endpointBindings = bindings.Map()
+	}
+	checks.record("endpoint-bindings", err)
+
+	addArgs := state.AddApplicationArgs{
+		Name:              args.ApplicationName,
+		Series:            args.Series,
+		Charm:             api.stateCharm(ch),
+		Channel:           csparams.Channel(args.Channel),
+		Storage:           stateStorageConstraints(args.Storage),
+		Devices:           stateDeviceConstraints(args.Devices),
+		AttachStorage:     attachStorage,
+		ApplicationConfig: appConfig,
+		CharmConfig:       charmSettings,
+		NumUnits:          args.NumUnits,
+		Placement:         args.Placement,
+		Resources:         args.Resources,
+		EndpointBindings:  endpointBindings,
+	}
+	if !ch.Meta().Subordinate {
+		addArgs.Constraints = args.Constraints
+	}
+	if origin, err := convertCharmOrigin(args.CharmOrigin, curl, args.Channel); err == nil {
+		addArgs.CharmOrigin = stateCharmOrigin(origin)
+	}
+	stateChecks, err := api.backend.ValidateAddApplication(addArgs)
+	if err != nil {
+		result.Error = apiservererrors.ServerError(err)
+		return result
+	}
+	for _, check := range stateChecks {
+		checks.record(check.Name, check.Err)
+	}
+	result.Checks = checks.checks
+	return result
+}
+
+// deployCharm returns the charm to be deployed. So that nothing is
+// persisted, a local charm whose content is sent by the client is read
+// from that content, and a store charm that has not been added to the
+// model is downloaded from its store, without being added.
+func (api *APIBase) deployCharm(args params.ApplicationDeploy) (Charm, *charm.URL, error) {
+	curl, err := charm.ParseURL(args.CharmURL)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if curl.Revision < 0 {
+		return nil, nil, errors.Errorf("charm url must include revision")
+	}
+
+	var unstored charm.Charm
+	if args.Charm != nil {
+		unstored, err = readDeployCharmContent(*args.Charm)
+	} else {
+		var ch Charm
+		ch, err = api.backend.Charm(curl)
+		if err == nil || !errors.IsNotFound(err) {
+			return ch, curl, errors.Trace(err)
+		}
+		if !charm.CharmHub.Matches(curl.Schema) && !charm.CharmStore.Matches(curl.Schema) {
+			return nil, nil, errors.Trace(err)
+		}
+		unstored, err = api.downloadCharm(curl, args)
+	}
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	ch, err := api.backend.UnstoredCharm(state.CharmInfo{
+		Charm: unstored,
+		ID:    curl,
+	})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return ch, curl, nil
+}
+
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
 		return trustFields, trustDefaults, nil
@@ -713,17 +944,9 @@ func deployApplication(
 		return errors.Trace(err)
 	}
 
-	// Parse storage tags in AttachStorage.
-	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
-		return errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", args.NumUnits)
-	}
-	attachStorage := make([]names.StorageTag, len(args.AttachStorage))
-	for i, tagString := range args.AttachStorage {
-		tag, err := names.ParseStorageTag(tagString)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		attachStorage[i] = tag
+	attachStorage, err := parseAttachStorage(args)
+	if err != nil {
+		return errors.Trace(err)
 	}
 
 	bindings, err := state.NewBindings(backend, args.EndpointBindings)
@@ -754,6 +977,22 @@ func deployApplication(
 	return errors.Trace(err)
 }
 
+// parseAttachStorage parses the storage tags in args.AttachStorage.
+func parseAttachStorage(args params.ApplicationDeploy) ([]names.StorageTag, error) {
+	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
+		return nil, errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", args.NumUnits)
+	}
+	attachStorage := make([]names.StorageTag, len(args.AttachStorage))
+	for i, tagString := range args.AttachStorage {
+		tag, err := names.ParseStorageTag(tagString)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attachStorage[i] = tag
+	}
+	return attachStorage, nil
+}
+
 func convertCharmOrigin(origin *params.CharmOrigin, curl *charm.URL, charmStoreChannel string) (corecharm.Origin, error) {
 	switch {
 	case origin == nil || origin.Source == "" || origin.Source == "charm-store":
@@ -2021,6 +2260,22 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 			}
 		}
 
+		// Applications scaled by a horizontal pod autoscaler cannot also be
+		// scaled manually, otherwise Juju and the autoscaler fight.
+		appConfig, err := app.ApplicationConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		autoscale, err := k8s.ParseAutoscaleConfig(appConfig)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if autoscale != nil && autoscale.Enabled() {
+			return nil, errors.Errorf(
+				"application %q is autoscaled between %d and %d units, use set-autoscale to change the bounds or set-autoscale --disable to scale manually",
+				name, autoscale.MinUnits, autoscale.MaxUnits)
+		}
+
 		var info params.ScaleApplicationInfo
 		if arg.ScaleChange != 0 {
 			newScale, err := app.ChangeScale(arg.ScaleChange)
@@ -2683,6 +2938,311 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 	return result, nil
 }
 
+// KillHooks isn't on the v13 API.
+func (u *APIv13) KillHooks(_, _ struct{}) {}
+
+// KillHooks requests that any hook currently running on each of the
+// specified units be killed.
+func (api *APIBase) KillHooks(args params.Entities) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = unit.RequestHookKill()
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+// HookHistory isn't on the v13 API.
+func (u *APIv13) HookHistory(_, _ struct{}) {}
+
+// HookHistory returns the most recent hook executions recorded for
+// each of the specified units, newest first.
+func (api *APIBase) HookHistory(args params.HookHistoryRequests) (params.HookHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HookHistoryResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.HookHistoryResult, len(args.Requests))
+	for i, request := range args.Requests {
+		tag, err := names.ParseUnitTag(request.Tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		history, err := unit.HookHistory(request.Size)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		records := make([]params.HookRecord, len(history))
+		for j, record := range history {
+			records[j] = params.HookRecord{
+				HookName:   record.HookName,
+				Relation:   record.Relation,
+				RemoteUnit: record.RemoteUnit,
+				Started:    record.Started,
+				Duration:   record.Duration,
+				ExitCode:   record.ExitCode,
+				Output:     record.Output,
+			}
+		}
+		results[i].Records = records
+	}
+	return params.HookHistoryResults{Results: results}, nil
+}
+
+// UnitsWorkload isn't on the v15 API.
+func (u *APIv15) UnitsWorkload(_, _ struct{}) {}
+
+// UnitsWorkload returns the state of the pod running each of the specified
+// units, along with recent pod events and the last lines logged by each
+// of its containers.
+func (api *APIBase) UnitsWorkload(args params.UnitWorkloadRequests) (params.UnitWorkloadResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitWorkloadResults{}, errors.Trace(err)
+	}
+	if api.modelType != state.ModelTypeCAAS {
+		return params.UnitWorkloadResults{}, errors.NotSupportedf("inspecting workloads on a non-container model")
+	}
+
+	results := make([]params.UnitWorkloadResult, len(args.Requests))
+	for i, request := range args.Requests {
+		workload, err := api.unitWorkload(request)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Result = workload
+	}
+	return params.UnitWorkloadResults{Results: results}, nil
+}
+
+func (api *APIBase) unitWorkload(request params.UnitWorkloadRequest) (*params.UnitWorkload, error) {
+	tag, err := names.ParseUnitTag(request.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	container, err := unit.ContainerInfo()
+	if errors.IsNotFound(err) {
+		return nil, errors.NotProvisionedf("unit %q", tag.Id())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	workload, err := api.caasBroker.UnitWorkload(unit.ApplicationName(), container.ProviderId(), request.LogLines)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := &params.UnitWorkload{
+		PodName: workload.PodName,
+		Phase:   workload.Phase,
+		Message: workload.Message,
+		Node:    workload.Node,
+	}
+	for _, cond := range workload.Conditions {
+		result.Conditions = append(result.Conditions, params.KubernetesPodCondition{
+			Type:          cond.Type,
+			Status:        cond.Status,
+			Reason:        cond.Reason,
+			Message:       cond.Message,
+			LastUpdatedAt: cond.LastUpdatedAt,
+		})
+	}
+	for _, ctr := range workload.Containers {
+		result.Containers = append(result.Containers, params.KubernetesContainerInfo{
+			Name:         ctr.Name,
+			Image:        ctr.Image,
+			Init:         ctr.Init,
+			Ready:        ctr.Ready,
+			RestartCount: ctr.RestartCount,
+			State:        ctr.State,
+			Reason:       ctr.Reason,
+			Message:      ctr.Message,
+			Logs:         ctr.Logs,
+		})
+	}
+	for _, evt := range workload.Events {
+		result.Events = append(result.Events, params.KubernetesEvent{
+			Type:     evt.Type,
+			Reason:   evt.Reason,
+			Message:  evt.Message,
+			Count:    evt.Count,
+			LastSeen: evt.LastSeen,
+		})
+	}
+	return result, nil
+}
+
+// AdoptK8sWorkloads isn't on the v16 API.
+func (u *APIv16) AdoptK8sWorkloads(_, _ struct{}) {}
+
+// AdoptK8sWorkloads takes ownership of existing deployments and stateful
+// sets in a Kubernetes model. Each workload becomes an application of the
+// same name, deployed with the specified charm, with a unit for each of
+// its running pods. The pods aren't recreated. Adopting a workload again
+// completes an adoption which failed part way through.
+func (api *APIBase) AdoptK8sWorkloads(args params.AdoptK8sWorkloadArgs) (params.AdoptK8sWorkloadResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.AdoptK8sWorkloadResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.AdoptK8sWorkloadResults{}, errors.Trace(err)
+	}
+	if api.modelType != state.ModelTypeCAAS {
+		return params.AdoptK8sWorkloadResults{}, errors.NotSupportedf("adopting workloads on a non-container model")
+	}
+
+	results := make([]params.AdoptK8sWorkloadResult, len(args.Args))
+	for i, arg := range args.Args {
+		units, err := api.adoptK8sWorkload(arg)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Units = units
+	}
+	return params.AdoptK8sWorkloadResults{Results: results}, nil
+}
+
+func (api *APIBase) adoptK8sWorkload(arg params.AdoptK8sWorkloadArg) ([]string, error) {
+	kind := caas.WorkloadKind(arg.Kind)
+	if err := kind.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Juju manages the workload of an application by name, so the
+	// application takes the name of the adopted workload.
+	if !names.IsValidApplication(arg.Name) {
+		return nil, errors.NotValidf("%s name %q as an application name", kind, arg.Name)
+	}
+	curl, err := charm.ParseURL(arg.CharmURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if curl.Revision < 0 {
+		return nil, errors.Errorf("charm url must include revision")
+	}
+	// An adoption which failed part way through is completed by adopting
+	// the workload again, so the application of a previous attempt is
+	// used rather than deployed again.
+	app, err := api.backend.Application(arg.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if app != nil {
+		if appURL, _ := app.CharmURL(); appURL == nil || *appURL != *curl {
+			return nil, errors.AlreadyExistsf("application %q with a different charm", arg.Name)
+		}
+	}
+	ch, err := api.backend.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appConfig, _, charmSettings, err := parseCharmSettings(api.modelType, ch, arg.Name, nil, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bindings, err := state.NewBindings(api.backend, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	origin, err := convertCharmOrigin(arg.CharmOrigin, curl, arg.Channel)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Labelling the workload is idempotent, so it's done before anything
+	// is recorded in the model and the adoption can be retried on error.
+	adopted, err := api.caasBroker.AdoptWorkload(kind, arg.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if app == nil {
+		if _, err := api.deployApplicationFunc(api.backend, DeployApplicationParams{
+			ApplicationName:   arg.Name,
+			Series:            coreseries.Kubernetes.String(),
+			Charm:             api.stateCharm(ch),
+			CharmOrigin:       origin,
+			Channel:           csparams.Channel(arg.Channel),
+			ApplicationConfig: appConfig,
+			CharmConfig:       charmSettings,
+			EndpointBindings:  bindings.Map(),
+		}); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app, err = api.backend.Application(arg.Name); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := app.SetScale(len(adopted), 0, true); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// The pods which were given units by a previous attempt keep them.
+	existing, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitsByProviderId := make(map[string]string)
+	for _, unit := range existing {
+		info, err := unit.ContainerInfo()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitsByProviderId[info.ProviderId()] = unit.Name()
+	}
+
+	var units []string
+	for _, u := range adopted {
+		u := u
+		if name, ok := unitsByProviderId[u.Id]; ok {
+			units = append(units, name)
+			continue
+		}
+		unitParams := state.AddUnitParams{ProviderId: &u.Id}
+		if u.Address != "" {
+			unitParams.Address = &u.Address
+		}
+		if len(u.Ports) > 0 {
+			unitParams.Ports = &u.Ports
+		}
+		unit, err := app.AddUnit(unitParams)
+		if err != nil {
+			return nil, errors.Annotatef(err, "adding unit for pod %q", u.Id)
+		}
+		units = append(units, unit.Name())
+	}
+	return units, nil
+}
+
 // ApplicationInfo isn't on the v8 API.
 func (u *APIv8) ApplicationInfo(_, _ struct{}) {}
 
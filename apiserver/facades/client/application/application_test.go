@@ -138,7 +138,7 @@ func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv13 {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &application.APIv13{api}
+	return &application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{&application.APIv17{api}}}}}
 }
 
 func (s *applicationSuite) TestCharmConfig(c *gc.C) {
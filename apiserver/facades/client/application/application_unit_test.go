@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -27,6 +29,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
 	k8s "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/controller"
 	coreapplication "github.com/juju/juju/core/application"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/constraints"
@@ -36,10 +39,12 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	stateerrors "github.com/juju/juju/state/errors"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
@@ -61,7 +66,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv13
+	api          *application.APIv17
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -92,7 +97,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv13{api}
+	s.api = &application.APIv17{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -383,7 +388,7 @@ func (s *ApplicationSuite) TestUpdateCAASApplicationSettings(c *gc.C) {
 		ApplicationName: "postgresql",
 		SettingsYAML:    "postgresql:\n  stringOption: bar\n  juju-external-hostname: foo",
 	}
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{s.api}}}}}
 	err := api.Update(args)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -461,7 +466,7 @@ func (s *ApplicationSuite) TestUpdateCAASApplicationSettingsInIAASModelTriggersE
 		ApplicationName: "postgresql",
 		SettingsYAML:    "postgresql:\n  stringOption: bar\n  juju-external-hostname: foo",
 	}
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{s.api}}}}}
 	err := api.Update(args)
 	c.Assert(err, gc.ErrorMatches, `.*unknown option "juju-external-hostname"`, gc.Commentf("expected to get an error when attempting to set CAAS-specific app setting in IAAS model"))
 }
@@ -1218,7 +1223,7 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "Scale", 5)
+	app.CheckCall(c, 2, "Scale", 5)
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsNotAllowedForOperator(c *gc.C) {
@@ -1269,6 +1274,28 @@ func (s *ApplicationSuite) TestScaleApplicationsNotAllowedForDaemonSet(c *gc.C)
 	c.Assert(msg, gc.Matches, `scale a "daemon" application not supported`)
 }
 
+func (s *ApplicationSuite) TestScaleApplicationsNotAllowedWhenAutoscaled(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.applications["postgresql"].config = coreapplication.ConfigAttributes{
+		k8s.AutoscaleMinUnitsKey: int64(2),
+		k8s.AutoscaleMaxUnitsKey: int64(10),
+	}
+	for _, arg := range []params.ScaleApplicationParams{
+		{ApplicationTag: "application-postgresql", Scale: 5},
+		{ApplicationTag: "application-postgresql", ScaleChange: 1},
+	} {
+		result, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+			Applications: []params.ScaleApplicationParams{arg},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.Results, gc.HasLen, 1)
+		c.Assert(result.Results[0].Error, gc.ErrorMatches,
+			`application "postgresql" is autoscaled between 2 and 10 units, use set-autoscale .*`)
+	}
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "ApplicationConfig", "Charm", "ApplicationConfig")
+}
+
 func (s *ApplicationSuite) TestScaleApplicationsBlocked(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	s.blockChecker.SetErrors(apiservererrors.ServerError(apiservererrors.OperationBlockedError("test block")))
@@ -1297,7 +1324,7 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleChange(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "ChangeScale", 5)
+	app.CheckCall(c, 2, "ChangeScale", 5)
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleArgCheck(c *gc.C) {
@@ -1844,7 +1871,7 @@ func (s *ApplicationSuite) TestSetApplicationConfigEmptyUsesMaster(c *gc.C) {
 
 func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{s.api}}}}}
 	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -1880,7 +1907,7 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 
 func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{s.api}}}}}
 	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -1949,7 +1976,7 @@ func (s *ApplicationSuite) TestSetConfigBranch(c *gc.C) {
 
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{s.api}}}}}
 	_, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
 	c.Assert(err, gc.ErrorMatches, "blocked")
 	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
@@ -1958,7 +1985,7 @@ func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 
 func (s *ApplicationSuite) TestSetApplicationConfigPermissionDenied(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("fred"))
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{s.api}}}}}
 	_, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -2067,6 +2094,498 @@ func (s *ApplicationSuite) TestResolveUnitErrorsPermissionDenied(c *gc.C) {
 	s.application.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestKillHooks(c *gc.C) {
+	s.backend.applications["postgresql"].units[1].SetErrors(errors.NotFoundf("unit"))
+	result, err := s.api.KillHooks(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "unit-postgresql-0"},
+			{Tag: "unit-postgresql-1"},
+			{Tag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "unit not found")
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+
+	for i := 0; i < 2; i++ {
+		unit := s.backend.applications["postgresql"].units[i]
+		unit.CheckCallNames(c, "RequestHookKill")
+	}
+}
+
+func (s *ApplicationSuite) TestHookHistory(c *gc.C) {
+	started := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.hookHistory = []state.HookRecord{{
+		HookName:   "db-relation-changed",
+		Relation:   "db:1",
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Duration:   time.Second,
+		ExitCode:   1,
+		Output:     "boom",
+	}}
+	result, err := s.api.HookHistory(params.HookHistoryRequests{
+		Requests: []params.HookHistoryRequest{
+			{Tag: "unit-postgresql-0", Size: 10},
+			{Tag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0], jc.DeepEquals, params.HookHistoryResult{
+		Records: []params.HookRecord{{
+			HookName:   "db-relation-changed",
+			Relation:   "db:1",
+			RemoteUnit: "mysql/0",
+			Started:    started,
+			Duration:   time.Second,
+			ExitCode:   1,
+			Output:     "boom",
+		}},
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+	unit.CheckCall(c, 0, "HookHistory", 10)
+}
+
+func (s *ApplicationSuite) TestHookHistoryPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.HookHistory(params.HookHistoryRequests{
+		Requests: []params.HookHistoryRequest{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestUnitsWorkload(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	lastSeen := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.caasBroker.workload = &caas.UnitWorkload{
+		PodName: "postgresql-0",
+		Phase:   "Running",
+		Node:    "node-1",
+		Conditions: []caas.WorkloadCondition{{
+			Type:          "Ready",
+			Status:        "False",
+			LastUpdatedAt: lastSeen,
+		}},
+		Containers: []caas.WorkloadContainer{{
+			Name:         "postgresql",
+			Image:        "postgres:12",
+			RestartCount: 2,
+			State:        "running",
+			Logs:         []string{"ready to accept connections"},
+		}},
+		Events: []caas.WorkloadEvent{{
+			Type:     "Warning",
+			Reason:   "Unhealthy",
+			Message:  "Readiness probe failed",
+			Count:    3,
+			LastSeen: lastSeen,
+		}},
+	}
+	result, err := s.api.UnitsWorkload(params.UnitWorkloadRequests{
+		Requests: []params.UnitWorkloadRequest{
+			{Tag: "unit-postgresql-0", LogLines: 5},
+			{Tag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0], jc.DeepEquals, params.UnitWorkloadResult{
+		Result: &params.UnitWorkload{
+			PodName: "postgresql-0",
+			Phase:   "Running",
+			Node:    "node-1",
+			Conditions: []params.KubernetesPodCondition{{
+				Type:          "Ready",
+				Status:        "False",
+				LastUpdatedAt: lastSeen,
+			}},
+			Containers: []params.KubernetesContainerInfo{{
+				Name:         "postgresql",
+				Image:        "postgres:12",
+				RestartCount: 2,
+				State:        "running",
+				Logs:         []string{"ready to accept connections"},
+			}},
+			Events: []params.KubernetesEvent{{
+				Type:     "Warning",
+				Reason:   "Unhealthy",
+				Message:  "Readiness probe failed",
+				Count:    3,
+				LastSeen: lastSeen,
+			}},
+		},
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+	s.caasBroker.CheckCall(c, 0, "UnitWorkload", "postgresql", "provider-id", 5)
+}
+
+func (s *ApplicationSuite) TestUnitsWorkloadIAASModel(c *gc.C) {
+	_, err := s.api.UnitsWorkload(params.UnitWorkloadRequests{
+		Requests: []params.UnitWorkloadRequest{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.caasBroker.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestUnitsWorkloadPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	_, err := s.api.UnitsWorkload(params.UnitWorkloadRequests{
+		Requests: []params.UnitWorkloadRequest{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.caasBroker.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAdoptK8sWorkloads(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.charm = &mockCharm{
+		meta:   &charm.Meta{Name: "postgresql-k8s"},
+		config: &charm.Config{},
+	}
+	app := s.backend.applications["postgresql"]
+	app.addedUnit.name = "postgresql/0"
+	s.caasBroker.adopted = []caas.Unit{{Id: "postgresql-0", Address: "10.1.1.1"}}
+	// The application is deployed by the adoption.
+	s.backend.SetErrors(errors.NotFoundf(`application "postgresql"`))
+
+	result, err := s.api.AdoptK8sWorkloads(params.AdoptK8sWorkloadArgs{
+		Args: []params.AdoptK8sWorkloadArg{{
+			Kind:     "statefulset",
+			Name:     "postgresql",
+			CharmURL: "cs:postgresql-k8s-3",
+		}, {
+			Kind:     "daemonset",
+			Name:     "postgresql",
+			CharmURL: "cs:postgresql-k8s-3",
+		}, {
+			Kind:     "deployment",
+			Name:     "pg-12",
+			CharmURL: "cs:postgresql-k8s-3",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0], jc.DeepEquals, params.AdoptK8sWorkloadResult{
+		Units: []string{"postgresql/0"},
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `workload kind "daemonset" not valid`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `deployment name "pg-12" as an application name not valid`)
+
+	s.caasBroker.CheckCallNames(c, "AdoptWorkload")
+	s.caasBroker.CheckCall(c, 0, "AdoptWorkload", caas.WorkloadStatefulSet, "postgresql")
+	deployed := s.deployParams["postgresql"]
+	c.Assert(deployed.Series, gc.Equals, "kubernetes")
+	c.Assert(deployed.NumUnits, gc.Equals, 0)
+	providerId, address := "postgresql-0", "10.1.1.1"
+	app.CheckCallNames(c, "Scale", "AllUnits", "AddUnit")
+	app.CheckCall(c, 0, "Scale", 1)
+	app.CheckCall(c, 2, "AddUnit", state.AddUnitParams{ProviderId: &providerId, Address: &address})
+}
+
+func (s *ApplicationSuite) TestAdoptK8sWorkloadsRetry(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.charm = &mockCharm{
+		meta:   &charm.Meta{Name: "postgresql-k8s"},
+		config: &charm.Config{},
+	}
+	app := s.backend.applications["postgresql"]
+	app.curl = charm.MustParseURL("cs:postgresql-k8s-3")
+	app.units = []*mockUnit{{name: "postgresql/0"}}
+	app.addedUnit.name = "postgresql/1"
+	s.caasBroker.adopted = []caas.Unit{{Id: "provider-id"}, {Id: "postgresql-1"}}
+
+	result, err := s.api.AdoptK8sWorkloads(params.AdoptK8sWorkloadArgs{
+		Args: []params.AdoptK8sWorkloadArg{{
+			Kind:     "statefulset",
+			Name:     "postgresql",
+			CharmURL: "cs:postgresql-k8s-3",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0], jc.DeepEquals, params.AdoptK8sWorkloadResult{
+		Units: []string{"postgresql/0", "postgresql/1"},
+	})
+
+	// The application of the earlier attempt is used, and only the pod
+	// without a unit is given one.
+	_, deployed := s.deployParams["postgresql"]
+	c.Assert(deployed, jc.IsFalse)
+	providerId := "postgresql-1"
+	app.CheckCallNames(c, "CharmURL", "Scale", "AllUnits", "AddUnit")
+	app.CheckCall(c, 1, "Scale", 2)
+	app.CheckCall(c, 3, "AddUnit", state.AddUnitParams{ProviderId: &providerId})
+}
+
+func (s *ApplicationSuite) TestAdoptK8sWorkloadsDifferentCharm(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+
+	result, err := s.api.AdoptK8sWorkloads(params.AdoptK8sWorkloadArgs{
+		Args: []params.AdoptK8sWorkloadArg{{
+			Kind:     "statefulset",
+			Name:     "postgresql",
+			CharmURL: "cs:postgresql-k8s-3",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `application "postgresql" with a different charm already exists`)
+	s.caasBroker.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAdoptK8sWorkloadsIAASModel(c *gc.C) {
+	_, err := s.api.AdoptK8sWorkloads(params.AdoptK8sWorkloadArgs{
+		Args: []params.AdoptK8sWorkloadArg{{Kind: "deployment", Name: "postgresql", CharmURL: "cs:postgresql-k8s-3"}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.caasBroker.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAdoptK8sWorkloadsPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	_, err := s.api.AdoptK8sWorkloads(params.AdoptK8sWorkloadArgs{
+		Args: []params.AdoptK8sWorkloadArg{{Kind: "deployment", Name: "postgresql", CharmURL: "cs:postgresql-k8s-3"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.caasBroker.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestBlockAdoptK8sWorkloads(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.AdoptK8sWorkloads(params.AdoptK8sWorkloadArgs{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestBlockKillHooks(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.KillHooks(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestKillHooksPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.KillHooks(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.applications["postgresql"].units[0].CheckNoCalls(c)
+}
+
+func deployCheckErrors(checks []params.DeployCheck) map[string]string {
+	result := make(map[string]string)
+	for _, check := range checks {
+		if check.Error != nil {
+			result[check.Name] = check.Error.Message
+		}
+	}
+	return result
+}
+
+func (s *ApplicationSuite) TestValidateDeploy(c *gc.C) {
+	s.backend.applicationChecks = []state.ApplicationCheck{
+		{Name: "charm"},
+		{Name: "storage"},
+		{Name: "placement"},
+	}
+	results, err := s.api.ValidateDeploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			CharmOrigin:     &params.CharmOrigin{Source: "local"},
+			NumUnits:        1,
+			Config:          map[string]string{"intOption": "2"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.ApplicationName, gc.Equals, "foo")
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Checks, jc.DeepEquals, []params.DeployCheck{
+		{Name: "model"},
+		{Name: "charm"},
+		{Name: "placement"},
+		{Name: "lxd-profile"},
+		{Name: "config"},
+		{Name: "subordinate"},
+		{Name: "storage"},
+		{Name: "endpoint-bindings"},
+	})
+
+	// Nothing is deployed.
+	c.Assert(s.deployParams, gc.HasLen, 0)
+	s.backend.CheckCallNames(c, "Charm", "AllSpaceInfos", "ValidateAddApplication")
+	args := s.backend.Calls()[2].Args[0].(state.AddApplicationArgs)
+	c.Assert(args.Name, gc.Equals, "foo")
+	c.Assert(args.NumUnits, gc.Equals, 1)
+	c.Assert(args.CharmConfig, jc.DeepEquals, charm.Settings{"intOption": int64(2)})
+}
+
+func (s *ApplicationSuite) TestValidateDeployReportsAllFailures(c *gc.C) {
+	s.backend.charm.lxdProfile = &charm.LXDProfile{
+		Config: map[string]string{"boot.autostart": "true"},
+	}
+	s.backend.applicationChecks = []state.ApplicationCheck{
+		{Name: "storage", Err: errors.New("pool \"fast\" not found")},
+		{Name: "placement"},
+	}
+	s.blockChecker.SetErrors(errors.New("deployment blocked"))
+	results, err := s.api.ValidateDeploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			Config:          map[string]string{"intOption": "not-a-number"},
+			Placement:       []*instance.Placement{{Scope: instance.MachineScope, Directive: "42"}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(deployCheckErrors(results.Results[0].Checks), jc.DeepEquals, map[string]string{
+		"model":       "deployment blocked",
+		"placement":   `cannot deploy "foo" to machine 42: machine "42" not found`,
+		"lxd-profile": `invalid lxd-profile.yaml: contains config value "boot.autostart"`,
+		"config":      `option "intOption" expected int, got "not-a-number"`,
+		"storage":     `pool "fast" not found`,
+	})
+	c.Assert(s.deployParams, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestValidateDeploySubordinate(c *gc.C) {
+	s.backend.charm.meta = &charm.Meta{Subordinate: true}
+	results, err := s.api.ValidateDeploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        2,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployCheckErrors(results.Results[0].Checks), jc.DeepEquals, map[string]string{
+		"subordinate": "subordinate application must be deployed without units",
+	})
+}
+
+func (s *ApplicationSuite) TestValidateDeployCAASModel(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	results, err := s.api.ValidateDeploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			Placement:       []*instance.Placement{{}, {}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployCheckErrors(results.Results[0].Checks), jc.DeepEquals, map[string]string{
+		"kubernetes": "only 1 placement directive is supported for k8s models, got 2",
+	})
+}
+
+func (s *ApplicationSuite) TestValidateDeployCharmNotFound(c *gc.C) {
+	s.backend.charm = nil
+	results, err := s.api.ValidateDeploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+		}, {
+			ApplicationName: "bar",
+			CharmURL:        "local:bar",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `charm "local:foo-0" not found`)
+	c.Assert(results.Results[0].Checks, gc.HasLen, 0)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "charm url must include revision")
+	s.backend.CheckCallNames(c, "Charm")
+}
+
+func (s *ApplicationSuite) TestValidateDeployLocalCharmContent(c *gc.C) {
+	results, err := s.api.ValidateDeploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:focal/foo-1",
+			CharmOrigin:     &params.CharmOrigin{Source: "local"},
+			NumUnits:        1,
+			Config:          map[string]string{"title": "bar"},
+			Charm: &params.DeployCharmContent{
+				Metadata: "name: foo\nsummary: foo\ndescription: foo\nsubordinate: true\nrequires:\n  juju-info:\n    interface: juju-info\n    scope: container\n",
+				Config:   "options:\n  title:\n    type: string\n",
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(deployCheckErrors(results.Results[0].Checks), gc.HasLen, 0)
+
+	// The charm is read from the content rather than the model, and
+	// the default of no units for a subordinate is applied.
+	s.backend.CheckCallNames(c, "UnstoredCharm", "AllSpaceInfos", "ValidateAddApplication")
+	info := s.backend.Calls()[0].Args[0].(state.CharmInfo)
+	c.Assert(info.ID, gc.DeepEquals, charm.MustParseURL("local:focal/foo-1"))
+	c.Assert(info.Charm.Meta().Name, gc.Equals, "foo")
+	args := s.backend.Calls()[2].Args[0].(state.AddApplicationArgs)
+	c.Assert(args.NumUnits, gc.Equals, 0)
+	c.Assert(args.CharmConfig, jc.DeepEquals, charm.Settings{"title": "bar"})
+}
+
+func (s *ApplicationSuite) TestValidateDeployStoreCharmDownloaded(c *gc.C) {
+	s.backend.charm = nil
+	repo := &fakeCharmRepo{
+		archive: testcharms.Repo.CharmArchive(c.MkDir(), "dummy"),
+	}
+	var archivePath string
+	s.PatchValue(application.OpenCharmRepository, func(origin corecharm.Origin, _ controller.Config, _ *config.Config) (corecharm.Repository, error) {
+		c.Check(origin.Source, gc.Equals, corecharm.CharmHub)
+		return repo, nil
+	})
+	repo.downloaded = func(path string) { archivePath = path }
+
+	results, err := s.api.ValidateDeploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "ch:dummy-1",
+			CharmOrigin:     &params.CharmOrigin{Source: "charm-hub", Risk: "stable"},
+			NumUnits:        1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(deployCheckErrors(results.Results[0].Checks), gc.HasLen, 0)
+
+	repo.CheckCallNames(c, "FindDownloadURL", "DownloadCharm")
+	repo.CheckCall(c, 1, "DownloadCharm", "https://example.com/dummy.charm")
+	s.backend.CheckCallNames(c, "Charm", "UnstoredCharm", "AllSpaceInfos", "ValidateAddApplication")
+	info := s.backend.Calls()[1].Args[0].(state.CharmInfo)
+	c.Assert(info.Charm.Meta().Name, gc.Equals, "dummy")
+
+	// The downloaded archive is removed.
+	c.Assert(archivePath, gc.Not(gc.Equals), "")
+	_, err = os.Stat(filepath.Dir(archivePath))
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *ApplicationSuite) TestValidateDeployPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.ValidateDeploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestCAASExposeWithoutHostname(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	err := s.api.Expose(params.ApplicationExpose{
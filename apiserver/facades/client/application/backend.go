@@ -35,10 +35,12 @@ type Backend interface {
 	Application(string) (Application, error)
 	ApplyOperation(state.ModelOperation) error
 	AddApplication(state.AddApplicationArgs) (Application, error)
+	ValidateAddApplication(state.AddApplicationArgs) ([]state.ApplicationCheck, error)
 	RemoteApplication(string) (RemoteApplication, error)
 	AddRemoteApplication(state.AddRemoteApplicationParams) (RemoteApplication, error)
 	AddRelation(...state.Endpoint) (Relation, error)
 	Charm(*charm.URL) (Charm, error)
+	UnstoredCharm(state.CharmInfo) (Charm, error)
 	EndpointsRelation(...state.Endpoint) (Relation, error)
 	Relation(int) (Relation, error)
 	InferEndpoints(...string) ([]state.Endpoint, error)
@@ -171,6 +173,8 @@ type Unit interface {
 	IsPrincipal() bool
 	Life() state.Life
 	Resolve(retryHooks bool) error
+	RequestHookKill() error
+	HookHistory(size int) ([]state.HookRecord, error)
 	AgentTools() (*tools.Tools, error)
 
 	AssignedMachineId() (string, error)
@@ -337,6 +341,14 @@ func (s stateShim) Charm(curl *charm.URL) (Charm, error) {
 	return stateCharmShim{ch}, nil
 }
 
+func (s stateShim) UnstoredCharm(info state.CharmInfo) (Charm, error) {
+	ch, err := s.State.UnstoredCharm(info)
+	if err != nil {
+		return nil, err
+	}
+	return stateCharmShim{ch}, nil
+}
+
 func (s stateShim) EndpointsRelation(eps ...state.Endpoint) (Relation, error) {
 	r, err := s.State.EndpointsRelation(eps...)
 	if err != nil {
@@ -0,0 +1,127 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/charm/v8"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facades/client/charms"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// openCharmRepository returns the repository from which charms with the
+// given origin are downloaded.
+var openCharmRepository = charms.NewRepository
+
+// downloadCharm downloads the store charm being deployed by args into a
+// temporary directory, and reads it. The charm archive is removed once
+// it has been read, so nothing is added to the model.
+func (api *APIBase) downloadCharm(curl *charm.URL, args params.ApplicationDeploy) (charm.Charm, error) {
+	origin, err := convertCharmOrigin(args.CharmOrigin, curl, args.Channel)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerCfg, err := api.backend.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelCfg, err := api.model.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	repo, err := openCharmRepository(origin, controllerCfg, modelCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	resourceURL := curl.String()
+	downloadURL, _, err := repo.FindDownloadURL(curl, origin, args.Series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if downloadURL != nil {
+		resourceURL = downloadURL.String()
+	}
+
+	dir, err := ioutil.TempDir("", "deploy-"+curl.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	archive, err := repo.DownloadCharm(resourceURL, filepath.Join(dir, curl.Name+".charm"))
+	if err != nil {
+		return nil, errors.Annotatef(err, "downloading charm %q", curl)
+	}
+	return archive, nil
+}
+
+// deployCharmContent is a local charm read from the content of its files
+// sent by the client, rather than from the model.
+type deployCharmContent struct {
+	meta       *charm.Meta
+	config     *charm.Config
+	lxdProfile *charm.LXDProfile
+}
+
+// readDeployCharmContent reads the charm whose files hold content.
+func readDeployCharmContent(content params.DeployCharmContent) (*deployCharmContent, error) {
+	meta, err := charm.ReadMeta(strings.NewReader(content.Metadata))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading charm metadata")
+	}
+	ch := &deployCharmContent{
+		meta:       meta,
+		config:     charm.NewConfig(),
+		lxdProfile: charm.NewLXDProfile(),
+	}
+	if content.Config != "" {
+		if ch.config, err = charm.ReadConfig(strings.NewReader(content.Config)); err != nil {
+			return nil, errors.Annotate(err, "reading charm config")
+		}
+	}
+	if content.LXDProfile != "" {
+		if ch.lxdProfile, err = charm.ReadLXDProfile(strings.NewReader(content.LXDProfile)); err != nil {
+			return nil, errors.Annotate(err, "reading charm LXD profile")
+		}
+	}
+	return ch, nil
+}
+
+// Meta is part of the charm.Charm interface.
+func (c *deployCharmContent) Meta() *charm.Meta {
+	return c.meta
+}
+
+// Config is part of the charm.Charm interface.
+func (c *deployCharmContent) Config() *charm.Config {
+	return c.config
+}
+
+// Metrics is part of the charm.Charm interface. Metrics are not
+// needed to validate a deployment.
+func (c *deployCharmContent) Metrics() *charm.Metrics {
+	return nil
+}
+
+// Actions is part of the charm.Charm interface. Actions are not
+// needed to validate a deployment.
+func (c *deployCharmContent) Actions() *charm.Actions {
+	return nil
+}
+
+// Revision is part of the charm.Charm interface.
+func (c *deployCharmContent) Revision() int {
+	return 0
+}
+
+// LXDProfile is part of the charm.LXDProfiler interface.
+func (c *deployCharmContent) LXDProfile() *charm.LXDProfile {
+	return c.lxdProfile
+}
@@ -5,6 +5,7 @@ package application_test
 
 import (
 	"io"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/controller"
 	coreapplication "github.com/juju/juju/core/application"
+	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
@@ -383,6 +385,7 @@ type mockBackend struct {
 	machines                   map[string]*mockMachine
 	generation                 *mockGeneration
 	spaceInfos                 network.SpaceInfos
+	applicationChecks          []state.ApplicationCheck
 }
 
 type mockFilesystemAccess struct {
@@ -421,6 +424,22 @@ func (m *mockBackend) Charm(curl *charm.URL) (application.Charm, error) {
 	return nil, errors.NotFoundf("charm %q", curl)
 }
 
+func (m *mockBackend) UnstoredCharm(info state.CharmInfo) (application.Charm, error) {
+	m.MethodCall(m, "UnstoredCharm", info)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return info.Charm, nil
+}
+
+func (m *mockBackend) ValidateAddApplication(args state.AddApplicationArgs) ([]state.ApplicationCheck, error) {
+	m.MethodCall(m, "ValidateAddApplication", args)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.applicationChecks, nil
+}
+
 func (m *mockBackend) Unit(name string) (application.Unit, error) {
 	m.MethodCall(m, "Unit", name)
 	if err := m.NextErr(); err != nil {
@@ -937,10 +956,11 @@ func (m mockRelationUnit) Settings() (map[string]interface{}, error) {
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag        names.UnitTag
-	machineId  string
-	name       string
-	agentTools *tools.Tools
+	tag         names.UnitTag
+	machineId   string
+	name        string
+	agentTools  *tools.Tools
+	hookHistory []state.HookRecord
 }
 
 func (u *mockUnit) Tag() names.Tag {
@@ -977,6 +997,16 @@ func (u *mockUnit) Resolve(retryHooks bool) error {
 	return u.NextErr()
 }
 
+func (u *mockUnit) RequestHookKill() error {
+	u.MethodCall(u, "RequestHookKill")
+	return u.NextErr()
+}
+
+func (u *mockUnit) HookHistory(size int) ([]state.HookRecord, error) {
+	u.MethodCall(u, "HookHistory", size)
+	return u.hookHistory, u.NextErr()
+}
+
 func (u *mockUnit) AssignedMachineId() (string, error) {
 	u.MethodCall(u, "AssignedMachineId")
 	return u.machineId, u.NextErr()
@@ -1159,6 +1189,8 @@ type mockCaasBroker struct {
 	jtesting.Stub
 	caas.StorageValidator
 	caas.ClusterVersionGetter
+	workload *caas.UnitWorkload
+	adopted  []caas.Unit
 }
 
 func (m *mockCaasBroker) ValidateStorageClass(config map[string]interface{}) error {
@@ -1172,6 +1204,22 @@ func (m *mockCaasBroker) Version() (*version.Number, error) {
 	return &ver, nil
 }
 
+func (m *mockCaasBroker) UnitWorkload(appName string, podName string, logLines int) (*caas.UnitWorkload, error) {
+	m.MethodCall(m, "UnitWorkload", appName, podName, logLines)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.workload, nil
+}
+
+func (m *mockCaasBroker) AdoptWorkload(kind caas.WorkloadKind, name string) ([]caas.Unit, error) {
+	m.MethodCall(m, "AdoptWorkload", kind, name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.adopted, nil
+}
+
 type mockGeneration struct {
 	jtesting.Stub
 }
@@ -1181,6 +1229,28 @@ func (g *mockGeneration) AssignApplication(appName string) error {
 	return g.NextErr()
 }
 
+type fakeCharmRepo struct {
+	corecharm.Repository
+	jtesting.Stub
+
+	archive    *charm.CharmArchive
+	downloaded func(archivePath string)
+}
+
+func (r *fakeCharmRepo) FindDownloadURL(curl *charm.URL, origin corecharm.Origin, series string) (*url.URL, corecharm.Origin, error) {
+	r.MethodCall(r, "FindDownloadURL", curl, origin, series)
+	u, err := url.Parse("https://example.com/" + curl.Name + ".charm")
+	return u, origin, err
+}
+
+func (r *fakeCharmRepo) DownloadCharm(resourceURL, archivePath string) (*charm.CharmArchive, error) {
+	r.MethodCall(r, "DownloadCharm", resourceURL)
+	if r.downloaded != nil {
+		r.downloaded(archivePath)
+	}
+	return r.archive, r.NextErr()
+}
+
 type mockRepo struct {
 	application.Repository
 	*jtesting.CallMocker
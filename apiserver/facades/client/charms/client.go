@@ -20,8 +20,10 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/controller"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/environs/config"
 	stateerrors "github.com/juju/juju/state/errors"
 	"github.com/juju/juju/state/storage"
 	jujuversion "github.com/juju/juju/version"
@@ -453,22 +455,43 @@ func getStrategyFunc(source string) StrategyFunc {
 func (a *API) repository(origin params.CharmOrigin, mac *macaroon.Macaroon) (corecharm.Repository, error) {
 	switch origin.Source {
 	case corecharm.CharmHub.String():
-		return a.charmHubRepository()
+		cfg, err := a.backendModel.Config()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return charmHubRepository(cfg)
 	case corecharm.CharmStore.String():
-		return a.charmStoreRepository(origin, mac)
+		controllerCfg, err := a.backendState.ControllerConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return charmStoreRepository(a.csResolverGetterFunc, controllerCfg, origin.Risk, mac)
 	}
 	return nil, errors.BadRequestf("Not charm hub nor charm store charm")
 }
 
-func (a *API) charmStoreRepository(origin params.CharmOrigin, mac *macaroon.Macaroon) (corecharm.Repository, error) {
-	controllerCfg, err := a.backendState.ControllerConfig()
-	if err != nil {
-		return nil, errors.Trace(err)
+// NewRepository returns the repository from which charms with the given
+// origin are downloaded. It allows other facades to read a charm from
+// its store without adding it to the model.
+func NewRepository(origin corecharm.Origin, controllerCfg controller.Config, modelCfg *config.Config) (corecharm.Repository, error) {
+	switch origin.Source {
+	case corecharm.CharmHub:
+		return charmHubRepository(modelCfg)
+	case corecharm.CharmStore:
+		var risk string
+		if origin.Channel != nil {
+			risk = string(origin.Channel.Risk)
+		}
+		return charmStoreRepository(csResolverGetter, controllerCfg, risk, nil)
 	}
-	client, err := a.csResolverGetterFunc(
+	return nil, errors.BadRequestf("Not charm hub nor charm store charm")
+}
+
+func charmStoreRepository(getter CSResolverGetterFunc, controllerCfg controller.Config, risk string, mac *macaroon.Macaroon) (corecharm.Repository, error) {
+	client, err := getter(
 		ResolverGetterParams{
 			CSURL:              controllerCfg.CharmStoreURL(),
-			Channel:            origin.Risk,
+			Channel:            risk,
 			CharmStoreMacaroon: mac,
 		})
 	if err != nil {
@@ -477,12 +500,11 @@ func (a *API) charmStoreRepository(origin params.CharmOrigin, mac *macaroon.Maca
 	return &csRepo{repo: client}, nil
 }
 
-func (a *API) charmHubRepository() (corecharm.Repository, error) {
-	cfg, err := a.backendModel.Config()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	var chCfg charmhub.Config
+func charmHubRepository(cfg *config.Config) (corecharm.Repository, error) {
+	var (
+		chCfg charmhub.Config
+		err   error
+	)
 	chURL, ok := cfg.CharmHubURL()
 	if ok {
 		chCfg, err = charmhub.CharmHubConfigFromURL(chURL, logger.Child("client"))
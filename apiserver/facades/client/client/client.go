@@ -459,6 +459,12 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 		p.Nonce = ""
 		p.HardwareCharacteristics = instance.HardwareCharacteristics{}
 		p.Addrs = nil
+		p.SSHProxyJump = ""
+	}
+	if p.SSHProxyJump != "" {
+		if err := network.ValidateSSHProxyJump(p.SSHProxyJump); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	if p.Series == "" {
@@ -502,6 +508,7 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 		HardwareCharacteristics: p.HardwareCharacteristics,
 		Addresses:               addrs,
 		Placement:               placementDirective,
+		SSHProxyJump:            p.SSHProxyJump,
 	}
 	if p.ContainerType == "" {
 		return c.api.stateAccessor.AddOneMachine(template)
@@ -1261,6 +1261,21 @@ func (s *clientSuite) TestClientAddMachinesDefaultSeries(c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestClientAddMachinesInvalidSSHProxyJump(c *gc.C) {
+	machines, err := s.APIState.Client().AddMachines([]params.AddMachineParams{{
+		Jobs:         []model.MachineJob{model.JobHostUnits},
+		InstanceId:   "manual:10.0.0.3",
+		Nonce:        "nonce",
+		SSHProxyJump: "admin@-oProxyCommand=id",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+	c.Assert(machines[0].Error, gc.ErrorMatches, `SSH proxy jump "admin@-oProxyCommand=id", expected \[<user>@\]<host> not valid`)
+	all, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
+
 func (s *clientSuite) assertAddMachines(c *gc.C) {
 	apiParams := make([]params.AddMachineParams, 3)
 	for i := 0; i < 3; i++ {
@@ -4,6 +4,7 @@
 package client
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -1385,6 +1386,24 @@ func (context *statusContext) unitMachineID(unit *state.Unit) string {
 	return machineID
 }
 
+// unitMachineSuspended returns whether the machine hosting the unit,
+// or the host of its container, has been suspended.
+func (context *statusContext) unitMachineSuspended(unit *state.Unit) bool {
+	machineID := context.unitMachineID(unit)
+	if machineID == "" {
+		return false
+	}
+	return context.machineSuspended(state.TopParentId(machineID))
+}
+
+// machineSuspended returns whether the machine has been suspended.
+// Only top level machines can be suspended; their containers are powered
+// off with them.
+func (context *statusContext) machineSuspended(machineID string) bool {
+	machineStatus, err := context.status.MachineAgent(machineID)
+	return err == nil && machineStatus.Status == status.Suspended
+}
+
 func (context *statusContext) unitPublicAddress(unit *state.Unit) string {
 	machine := context.allMachines[context.unitMachineID(unit)]
 	if machine == nil {
@@ -1548,7 +1567,15 @@ func (c *contextUnit) Status() (status.StatusInfo, error) {
 // processUnitAndAgentStatus retrieves status information for both unit and unitAgents.
 func (c *statusContext) processUnitAndAgentStatus(unit *state.Unit, expectWorkload bool) (agentStatus, workloadStatus params.DetailedStatus) {
 	wrapped := &contextUnit{unit, expectWorkload, c}
-	agent, workload := c.presence.UnitStatus(wrapped)
+	var agent, workload common.StatusAndErr
+	if c.unitMachineSuspended(unit) {
+		// The agents on a suspended machine are expected to be
+		// absent, so they are not reported as lost.
+		agent.Status, agent.Err = wrapped.AgentStatus()
+		workload.Status, workload.Err = wrapped.Status()
+	} else {
+		agent, workload = c.presence.UnitStatus(wrapped)
+	}
 	populateStatusFromStatusInfoAndErr(&agentStatus, agent.Status, agent.Err)
 	populateStatusFromStatusInfoAndErr(&workloadStatus, workload.Status, workload.Err)
 
@@ -1588,6 +1615,15 @@ func (c *contextMachine) Status() (status.StatusInfo, error) {
 func (c *statusContext) processMachine(machine *state.Machine) (out params.DetailedStatus) {
 	wrapped := &contextMachine{machine, c}
 	statusInfo, err := c.presence.MachineStatus(wrapped)
+	if hostID := state.TopParentId(machine.Id()); hostID != machine.Id() && c.machineSuspended(hostID) {
+		// The containers of a suspended machine are off with it.
+		statusInfo = status.StatusInfo{
+			Status:  status.Suspended,
+			Message: fmt.Sprintf("host machine %s is suspended", hostID),
+			Since:   statusInfo.Since,
+		}
+		err = nil
+	}
 	populateStatusFromStatusInfoAndErr(&out, statusInfo, err)
 
 	out.Life = processLife(machine)
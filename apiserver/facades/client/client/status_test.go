@@ -290,6 +290,25 @@ func (s *statusUnitTestSuite) TestProcessMachinesWithEmbeddedContainers(c *gc.C)
 	c.Check(mStatus.Containers, gc.HasLen, 1)
 }
 
+func (s *statusUnitTestSuite) TestProcessMachinesWithSuspendedHost(c *gc.C) {
+	host := s.Factory.MakeMachine(c, &factory.MachineParams{InstanceId: instance.Id("0")})
+	container := s.Factory.MakeMachineNested(c, host.Id(), nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: container})
+	now := time.Now()
+	err := host.SetStatus(status.StatusInfo{Status: status.Suspended, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	fullStatus, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mStatus := fullStatus.Machines[host.Id()].Containers[container.Id()]
+	c.Check(mStatus.AgentStatus.Status, gc.Equals, status.Suspended.String())
+	c.Check(mStatus.AgentStatus.Info, gc.Equals, "host machine 0 is suspended")
+	uStatus := fullStatus.Applications[unit.ApplicationName()].Units[unit.Name()]
+	c.Check(uStatus.AgentStatus.Status, gc.Not(gc.Equals), status.Lost.String())
+}
+
 var testUnits = []struct {
 	unitName       string
 	setStatus      *state.MeterStatus
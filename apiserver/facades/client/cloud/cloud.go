@@ -593,6 +593,10 @@ func (api *CloudAPI) AddCredentials(args params.TaggedCredentials) (params.Error
 			cloud.AuthType(arg.Credential.AuthType),
 			arg.Credential.Attributes,
 		)
+		if err := api.checkCredentialAuthType(in); err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
 		if err := api.backend.UpdateCloudCredential(tag, in); err != nil {
 			results.Results[i].Error = apiservererrors.ServerError(err)
 			continue
@@ -667,6 +671,10 @@ func (api *CloudAPI) commonUpdateCredentials(update bool, force, legacy bool, ar
 			cloud.AuthType(arg.Credential.AuthType),
 			arg.Credential.Attributes,
 		)
+		if err := api.checkCredentialAuthType(in); err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
 
 		models, err := api.credentialModels(tag)
 		if err != nil {
@@ -725,6 +733,24 @@ func (api *CloudAPI) commonUpdateCredentials(update bool, force, legacy bool, ar
 	return params.UpdateCredentialResults{results}, nil
 }
 
+// checkCredentialAuthType returns an error if the authenticated user may
+// not upload a credential of the given credential's auth-type.
+func (api *CloudAPI) checkCredentialAuthType(credential cloud.Credential) error {
+	if credential.AuthType() != cloud.ExecAuthType {
+		return nil
+	}
+	// The controller runs the command of an exec credential, so only
+	// controller admins may upload one.
+	isControllerAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.ctlrBackend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isControllerAdmin {
+		return errors.Annotatef(apiservererrors.ErrPerm, "only controller admins can upload %q credentials", cloud.ExecAuthType)
+	}
+	return nil
+}
+
 func (api *CloudAPI) credentialModels(tag names.CloudCredentialTag) (map[string]string, error) {
 	models, err := api.backend.CredentialModels(tag)
 	if err != nil && !errors.IsNotFound(err) {
@@ -566,6 +566,57 @@ func (s *cloudSuite) TestUpdateCredentials(c *gc.C) {
 	)
 }
 
+func (s *cloudSuite) TestUpdateCredentialsExecNotAdmin(c *gc.C) {
+	s.setTestAPIForUser(c, names.NewUserTag("bruce"))
+	results, err := s.api.UpdateCredentialsCheckModels(params.UpdateCredentialArgs{
+		Credentials: []params.TaggedCredential{{
+			Tag: "cloudcred-meep_bruce_three",
+			Credential: params.CloudCredential{
+				AuthType:   "exec",
+				Attributes: map[string]string{"ExecCommand": "aws"},
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerTag")
+	c.Assert(results, jc.DeepEquals, params.UpdateCredentialResults{
+		Results: []params.UpdateCredentialResult{{
+			CredentialTag: "cloudcred-meep_bruce_three",
+			Error:         &params.Error{Message: `only controller admins can upload "exec" credentials: permission denied`, Code: params.CodeUnauthorized},
+		}},
+	})
+}
+
+func (s *cloudSuite) TestAddCredentialsExecNotAdmin(c *gc.C) {
+	s.setTestAPIForUser(c, names.NewUserTag("bruce"))
+	results, err := s.api.AddCredentials(params.TaggedCredentials{
+		Credentials: []params.TaggedCredential{{
+			Tag: "cloudcred-meep_bruce_three",
+			Credential: params.CloudCredential{
+				AuthType:   "exec",
+				Attributes: map[string]string{"ExecCommand": "aws"},
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `only controller admins can upload "exec" credentials: permission denied`)
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
+func (s *cloudSuite) TestUpdateCredentialsExecAdmin(c *gc.C) {
+	results, err := s.api.UpdateCredentialsCheckModels(params.UpdateCredentialArgs{
+		Credentials: []params.TaggedCredential{{
+			Tag: "cloudcred-meep_admin_three",
+			Credential: params.CloudCredential{
+				AuthType:   "exec",
+				Attributes: map[string]string{"ExecCommand": "aws"},
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ControllerTag", "CredentialModels", "UpdateCloudCredential")
+	c.Assert(results, jc.DeepEquals, params.UpdateCredentialResults{
+		Results: []params.UpdateCredentialResult{{CredentialTag: "cloudcred-meep_admin_three"}}})
+}
+
 func (s *cloudSuite) TestCheckCredentialsModels(c *gc.C) {
 	// Most of the actual validation functionality is tested by other tests in the suite.
 	// All we need to know is that this call does not actually update existing controller credential content.
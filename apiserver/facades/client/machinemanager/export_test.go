@@ -5,3 +5,7 @@ package machinemanager
 
 var InstanceTypes = instanceTypes
 var IsSeriesLessThan = isSeriesLessThan
+var ResizeMachines = resizeMachines
+var MigrateMachines = migrateMachines
+var SuspendMachines = suspendMachines
+var SetModelSuspendRequested = setModelSuspendRequested
@@ -164,6 +164,7 @@ func (m *mockEnviron) InstanceTypes(ctx context.ProviderCallContext, c constrain
 
 type mockModel struct {
 	machinemanager.Model
+	suspendRequested bool
 }
 
 func (mockModel) CloudCredentialTag() (names.CloudCredentialTag, bool) {
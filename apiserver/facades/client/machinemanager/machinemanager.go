@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
@@ -101,6 +102,30 @@ type MachineManagerAPIV5 struct {
 // Version 6 of Machine Manager API.
 // Changes input parameters to DestroyMachineWithParams and ForceDestroyMachine.
 type MachineManagerAPIV6 struct {
+	*MachineManagerAPIV7
+}
+
+// Version 7 of Machine Manager API.
+// Adds ResizeMachine.
+type MachineManagerAPIV7 struct {
+	*MachineManagerAPIV8
+}
+
+// Version 8 of Machine Manager API.
+// Adds SuspendMachine and ResumeMachine.
+type MachineManagerAPIV8 struct {
+	*MachineManagerAPIV9
+}
+
+// Version 9 of Machine Manager API.
+// Adds SuspendModel and ResumeModel.
+type MachineManagerAPIV9 struct {
+	*MachineManagerAPIV10
+}
+
+// Version 10 of Machine Manager API.
+// Adds MigrateMachine.
+type MachineManagerAPIV10 struct {
 	*MachineManagerAPI
 }
 
@@ -124,11 +149,47 @@ func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
 
 // NewFacadeV6 creates a new server-side MachineManager API facade.
 func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPIv7, err := NewFacadeV7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{machineManagerAPIv7}, nil
+}
+
+// NewFacadeV7 creates a new server-side MachineManager API facade.
+func NewFacadeV7(ctx facade.Context) (*MachineManagerAPIV7, error) {
+	machineManagerAPIv8, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV7{machineManagerAPIv8}, nil
+}
+
+// NewFacadeV8 creates a new server-side MachineManager API facade.
+func NewFacadeV8(ctx facade.Context) (*MachineManagerAPIV8, error) {
+	machineManagerAPIv9, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV8{machineManagerAPIv9}, nil
+}
+
+// NewFacadeV9 creates a new server-side MachineManager API facade.
+func NewFacadeV9(ctx facade.Context) (*MachineManagerAPIV9, error) {
+	machineManagerAPIv10, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV9{machineManagerAPIv10}, nil
+}
+
+// NewFacadeV10 creates a new server-side MachineManager API facade.
+func NewFacadeV10(ctx facade.Context) (*MachineManagerAPIV10, error) {
 	machineManagerAPI, err := NewFacade(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &MachineManagerAPIV6{machineManagerAPI}, nil
+	return &MachineManagerAPIV10{machineManagerAPI}, nil
 }
 
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
@@ -223,6 +284,12 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		p.Nonce = ""
 		p.HardwareCharacteristics = instance.HardwareCharacteristics{}
 		p.Addrs = nil
+		p.SSHProxyJump = ""
+	}
+	if p.SSHProxyJump != "" {
+		if err := network.ValidateSSHProxyJump(p.SSHProxyJump); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	if p.Series == "" {
@@ -290,6 +357,7 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		HardwareCharacteristics: p.HardwareCharacteristics,
 		Addresses:               sAddrs,
 		Placement:               placementDirective,
+		SSHProxyJump:            p.SSHProxyJump,
 	}
 	if p.ContainerType == "" {
 		return mm.st.AddOneMachine(template)
@@ -167,6 +167,42 @@ func (s *MachineManagerSuite) TestNewMachineManagerAPINonClient(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *MachineManagerSuite) TestAddMachinesSSHProxyJump(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	machines, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:       "trusty",
+			Jobs:         []model.MachineJob{model.JobHostUnits},
+			InstanceId:   "manual:10.0.0.3",
+			Nonce:        "nonce",
+			SSHProxyJump: "admin@bastion",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines.Machines, gc.HasLen, 1)
+	c.Assert(s.st.machineTemplates, gc.HasLen, 1)
+	c.Assert(s.st.machineTemplates[0].SSHProxyJump, gc.Equals, "admin@bastion")
+}
+
+func (s *MachineManagerSuite) TestAddMachinesInvalidSSHProxyJump(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	machines, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:       "trusty",
+			Jobs:         []model.MachineJob{model.JobHostUnits},
+			InstanceId:   "manual:10.0.0.3",
+			Nonce:        "nonce",
+			SSHProxyJump: "-oProxyCommand=touch /tmp/pwned",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines.Machines, gc.HasLen, 1)
+	c.Assert(machines.Machines[0].Error, gc.ErrorMatches, `SSH proxy jump "-oProxyCommand=touch /tmp/pwned", expected \[<user>@\]<host> not valid`)
+	c.Assert(s.st.machineTemplates, gc.HasLen, 0)
+}
+
 func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -523,7 +559,7 @@ func (s *MachineManagerSuite) setupUpgradeSeries(c *gc.C) {
 }
 
 func (s *MachineManagerSuite) apiV5() machinemanager.MachineManagerAPIV5 {
-	return machinemanager.MachineManagerAPIV5{MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{s.api}}
+	return machinemanager.MachineManagerAPIV5{MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{&machinemanager.MachineManagerAPIV7{&machinemanager.MachineManagerAPIV8{&machinemanager.MachineManagerAPIV9{&machinemanager.MachineManagerAPIV10{s.api}}}}}}
 }
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateOK(c *gc.C) {
@@ -909,6 +945,7 @@ type mockState struct {
 	calls            int
 	machineTemplates []state.MachineTemplate
 	machines         map[string]*mockMachine
+	model            *mockModel
 	err              error
 	blockMsg         string
 	block            state.BlockType
@@ -978,6 +1015,9 @@ func (st *mockState) ModelTag() names.ModelTag {
 
 func (st *mockState) Model() (machinemanager.Model, error) {
 	st.MethodCall(st, "Model")
+	if st.model != nil {
+		return st.model, nil
+	}
 	return &mockModel{}, nil
 }
 
@@ -1050,7 +1090,9 @@ type mockMachine struct {
 	unitAgentState           status.Status
 	unitState                status.Status
 	isManager                bool
+	isManual                 bool
 	isLockedForSeriesUpgrade bool
+	machineStatus            status.StatusInfo
 
 	unitsF func() ([]machinemanager.Unit, error)
 }
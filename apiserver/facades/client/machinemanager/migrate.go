@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+)
+
+// MigrateMachine isn't on the V9 API.
+func (*MachineManagerAPIV9) MigrateMachine(_, _ struct{}) {}
+
+// MigrateMachine moves the instances of provisioned machines to other
+// members of the cloud's cluster, and records each machine's new
+// availability zone. The result for each machine holds the name of that
+// zone.
+func (mm *MachineManagerAPI) MigrateMachine(args params.MigrateMachinesParams) (params.StringResults, error) {
+	return migrateMachines(mm, environs.GetEnviron, args)
+}
+
+func migrateMachines(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.MigrateMachinesParams,
+) (params.StringResults, error) {
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Machines)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	if len(args.Machines) == 0 {
+		return results, nil
+	}
+
+	env, err := mm.environ(getEnviron)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	migrator, ok := env.(environs.InstanceMigrator)
+	if !ok {
+		return results, errors.NotSupportedf("migrating machines in this cloud")
+	}
+
+	for i, arg := range args.Machines {
+		zone, err := mm.migrateOneMachine(migrator, arg)
+		results.Results[i].Result = zone
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) migrateOneMachine(
+	migrator environs.InstanceMigrator, arg params.MigrateMachineParams,
+) (string, error) {
+	tag, err := names.ParseMachineTag(arg.MachineTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if arg.Member == "" {
+		return "", errors.NotValidf("empty cluster member")
+	}
+	if names.IsContainerMachine(tag.Id()) {
+		return "", errors.NotSupportedf("migrating container %q", tag.Id())
+	}
+	machine, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if machine.IsManager() {
+		return "", errors.Errorf("machine %s is a controller and cannot be migrated", tag.Id())
+	}
+	if manual, err := machine.IsManual(); err != nil {
+		return "", errors.Trace(err)
+	} else if manual {
+		return "", errors.NotSupportedf("migrating manual machine %q", tag.Id())
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	zone, err := migrator.MigrateInstance(mm.callContext, instId, arg.Member)
+	if err != nil {
+		return "", errors.Annotatef(err, "migrating machine %s", tag.Id())
+	}
+	if err := machine.SetAvailabilityZone(zone); err != nil {
+		return "", errors.Trace(err)
+	}
+	return zone, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+)
+
+type migratorEnviron struct {
+	environs.Environ
+	jujutesting.Stub
+}
+
+func (e *migratorEnviron) MigrateInstance(ctx context.ProviderCallContext, id instance.Id, member string) (string, error) {
+	e.MethodCall(e, "MigrateInstance", id, member)
+	return member, e.NextErr()
+}
+
+func (m *mockMachine) SetAvailabilityZone(zone string) error {
+	m.MethodCall(m, "SetAvailabilityZone", zone)
+	return nil
+}
+
+func (s *MachineManagerSuite) migrateMachines(
+	env environs.Environ, args params.MigrateMachinesParams,
+) (params.StringResults, error) {
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	return machinemanager.MigrateMachines(s.api, getEnviron, args)
+}
+
+func (s *MachineManagerSuite) TestMigrateMachine(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	machine := &mockMachine{id: "0"}
+	s.st.machines["0"] = machine
+	s.st.machines["2"] = &mockMachine{id: "2", isManual: true}
+	env := &migratorEnviron{}
+	results, err := s.migrateMachines(env, params.MigrateMachinesParams{
+		Machines: []params.MigrateMachineParams{{
+			MachineTag: "machine-0",
+			Member:     "node02",
+		}, {
+			MachineTag: "machine-1",
+			Member:     "node02",
+		}, {
+			MachineTag: "machine-0-lxd-0",
+			Member:     "node02",
+		}, {
+			MachineTag: "machine-2",
+			Member:     "node02",
+		}, {
+			MachineTag: "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(results.Results, gc.HasLen, 5)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, gc.Equals, "node02")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "machine 1 not found")
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `migrating container "0/lxd/0" not supported`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `migrating manual machine "2" not supported`)
+	c.Assert(results.Results[4].Error, gc.ErrorMatches, "empty cluster member not valid")
+
+	env.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrateInstance", []interface{}{instance.Id("inst-0"), "node02"}},
+	})
+	machine.CheckCallNames(c, "IsManager", "IsManual", "InstanceId", "SetAvailabilityZone")
+	machine.CheckCall(c, 3, "SetAvailabilityZone", "node02")
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineProviderError(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	machine := &mockMachine{id: "0"}
+	s.st.machines["0"] = machine
+	env := &migratorEnviron{}
+	env.SetErrors(errors.New("member offline"))
+	results, err := s.migrateMachines(env, params.MigrateMachinesParams{
+		Machines: []params.MigrateMachineParams{{
+			MachineTag: "machine-0",
+			Member:     "node02",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "migrating machine 0: member offline")
+	machine.CheckCallNames(c, "IsManager", "IsManual", "InstanceId")
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineController(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", isManager: true}
+	env := &migratorEnviron{}
+	results, err := s.migrateMachines(env, params.MigrateMachinesParams{
+		Machines: []params.MigrateMachineParams{{MachineTag: "machine-0", Member: "node02"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "machine 0 is a controller and cannot be migrated")
+	env.CheckNoCalls(c)
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineNotSupported(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.migrateMachines(&mockEnviron{}, params.MigrateMachinesParams{
+		Machines: []params.MigrateMachineParams{{MachineTag: "machine-0", Member: "node02"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "migrating machines in this cloud not supported")
+}
+
+func (s *MachineManagerSuite) TestMigrateMachineBlocked(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.blockMsg = "TestMigrateMachineBlocked"
+	s.st.block = state.ChangeBlock
+	_, err := s.migrateMachines(&migratorEnviron{}, params.MigrateMachinesParams{
+		Machines: []params.MigrateMachineParams{{MachineTag: "machine-0", Member: "node02"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "TestMigrateMachineBlocked")
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// ResizeMachine isn't on the V6 API.
+func (*MachineManagerAPIV6) ResizeMachine(_, _ struct{}) {}
+
+// ResizeMachine changes the hardware of provisioned machines in place,
+// so that it satisfies each machine's constraints overridden by the given
+// ones. The instances are restarted by the provider, and the units on
+// them run their config-changed hooks once the resize is recorded.
+func (mm *MachineManagerAPI) ResizeMachine(args params.ResizeMachinesParams) (params.ResizeMachineResults, error) {
+	return resizeMachines(mm, environs.GetEnviron, args)
+}
+
+func resizeMachines(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.ResizeMachinesParams,
+) (params.ResizeMachineResults, error) {
+	results := params.ResizeMachineResults{
+		Results: make([]params.ResizeMachineResult, len(args.Machines)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	if len(args.Machines) == 0 {
+		return results, nil
+	}
+
+	env, err := mm.environ(getEnviron)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	resizer, ok := env.(environs.InstanceResizer)
+	if !ok {
+		return results, errors.NotSupportedf("resizing machines in this cloud")
+	}
+
+	for i, arg := range args.Machines {
+		hc, err := mm.resizeOneMachine(resizer, arg)
+		results.Results[i].HardwareCharacteristics = hc
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) resizeOneMachine(
+	resizer environs.InstanceResizer, arg params.ResizeMachineParams,
+) (*instance.HardwareCharacteristics, error) {
+	tag, err := names.ParseMachineTag(arg.MachineTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if names.IsContainerMachine(tag.Id()) {
+		return nil, errors.NotSupportedf("resizing container %q", tag.Id())
+	}
+	machine, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if machine.IsManager() {
+		return nil, errors.Errorf("machine %s is a controller and cannot be resized", tag.Id())
+	}
+	machineStatus, err := machine.Status()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if machineStatus.Status == status.Suspended {
+		// Resizing restarts the instance, which would resume it
+		// behind the back of the suspend schedule.
+		return nil, errors.Errorf("machine %s is suspended and cannot be resized", tag.Id())
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := machine.ResizeConstraints(arg.Constraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	hc, err := resizer.ResizeInstance(mm.callContext, instId, cons)
+	if err != nil {
+		return nil, errors.Annotatef(err, "resizing machine %s", tag.Id())
+	}
+	if err := machine.SetResized(cons, *hc); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return hc, nil
+}
+
+// environ returns the environ of the model the facade serves.
+func (mm *MachineManagerAPI) environ(getEnviron environGetFunc) (environs.Environ, error) {
+	model, err := mm.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cloudSpec := func() (environscloudspec.CloudSpec, error) {
+		return stateenvirons.CloudSpecForModel(model)
+	}
+	backend := common.EnvironConfigGetterFuncs{
+		CloudSpecFunc:   cloudSpec,
+		ModelConfigFunc: model.Config,
+	}
+	env, err := getEnviron(backend, environs.New)
+	return env, errors.Trace(err)
+}
@@ -0,0 +1,158 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+)
+
+type resizerEnviron struct {
+	environs.Environ
+	jujutesting.Stub
+}
+
+func (e *resizerEnviron) ResizeInstance(
+	ctx context.ProviderCallContext, id instance.Id, cons constraints.Value,
+) (*instance.HardwareCharacteristics, error) {
+	e.MethodCall(e, "ResizeInstance", id, cons)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	return &instance.HardwareCharacteristics{Mem: cons.Mem}, nil
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	m.MethodCall(m, "InstanceId")
+	return instance.Id("inst-" + m.id), nil
+}
+
+func (m *mockMachine) ResizeConstraints(cons constraints.Value) (constraints.Value, error) {
+	m.MethodCall(m, "ResizeConstraints", cons)
+	return constraints.Merge(constraints.MustParse("cores=2"), cons)
+}
+
+func (m *mockMachine) SetResized(cons constraints.Value, hc instance.HardwareCharacteristics) error {
+	m.MethodCall(m, "SetResized", cons, hc)
+	return nil
+}
+
+func (s *MachineManagerSuite) resizeMachines(
+	env environs.Environ, args params.ResizeMachinesParams,
+) (params.ResizeMachineResults, error) {
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	return machinemanager.ResizeMachines(s.api, getEnviron, args)
+}
+
+func (s *MachineManagerSuite) TestResizeMachine(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	machine := &mockMachine{id: "0"}
+	s.st.machines["0"] = machine
+	env := &resizerEnviron{}
+	results, err := s.resizeMachines(env, params.ResizeMachinesParams{
+		Machines: []params.ResizeMachineParams{{
+			MachineTag:  "machine-0",
+			Constraints: constraints.MustParse("mem=8G"),
+		}, {
+			MachineTag: "machine-1",
+		}, {
+			MachineTag: "machine-0-lxd-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	mem := uint64(8192)
+	hc := instance.HardwareCharacteristics{Mem: &mem}
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].HardwareCharacteristics, jc.DeepEquals, &hc)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "machine 1 not found")
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `resizing container "0/lxd/0" not supported`)
+
+	cons := constraints.MustParse("cores=2 mem=8G")
+	env.CheckCall(c, 0, "ResizeInstance", instance.Id("inst-0"), cons)
+	machine.CheckCallNames(c, "IsManager", "Status", "InstanceId", "ResizeConstraints", "SetResized")
+	machine.CheckCall(c, 4, "SetResized", cons, hc)
+}
+
+func (s *MachineManagerSuite) TestResizeMachineProviderError(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	machine := &mockMachine{id: "0"}
+	s.st.machines["0"] = machine
+	env := &resizerEnviron{}
+	env.SetErrors(errors.New("no capacity"))
+	results, err := s.resizeMachines(env, params.ResizeMachinesParams{
+		Machines: []params.ResizeMachineParams{{
+			MachineTag:  "machine-0",
+			Constraints: constraints.MustParse("mem=8G"),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "resizing machine 0: no capacity")
+	machine.CheckCallNames(c, "IsManager", "Status", "InstanceId", "ResizeConstraints")
+}
+
+func (s *MachineManagerSuite) TestResizeMachineController(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", isManager: true}
+	env := &resizerEnviron{}
+	results, err := s.resizeMachines(env, params.ResizeMachinesParams{
+		Machines: []params.ResizeMachineParams{{MachineTag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "machine 0 is a controller and cannot be resized")
+	env.CheckNoCalls(c)
+}
+
+func (s *MachineManagerSuite) TestResizeMachineSuspended(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{
+		id:            "0",
+		machineStatus: status.StatusInfo{Status: status.Suspended},
+	}
+	env := &resizerEnviron{}
+	results, err := s.resizeMachines(env, params.ResizeMachinesParams{
+		Machines: []params.ResizeMachineParams{{MachineTag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "machine 0 is suspended and cannot be resized")
+	env.CheckNoCalls(c)
+}
+
+func (s *MachineManagerSuite) TestResizeMachineNotSupported(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.resizeMachines(&mockEnviron{}, params.ResizeMachinesParams{
+		Machines: []params.ResizeMachineParams{{MachineTag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "resizing machines in this cloud not supported")
+}
+
+func (s *MachineManagerSuite) TestResizeMachineBlocked(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.blockMsg = "TestResizeMachineBlocked"
+	s.st.block = state.ChangeBlock
+	_, err := s.resizeMachines(&resizerEnviron{}, params.ResizeMachinesParams{
+		Machines: []params.ResizeMachineParams{{MachineTag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "TestResizeMachineBlocked")
+}
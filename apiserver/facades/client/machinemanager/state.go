@@ -11,6 +11,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
@@ -41,6 +42,7 @@ type Model interface {
 	CloudCredential() (state.Credential, bool, error)
 	CloudRegion() string
 	Config() (*config.Config, error)
+	SetSuspendRequested(bool) error
 }
 
 type Machine interface {
@@ -60,6 +62,13 @@ type Machine interface {
 	IsManager() bool
 	IsLockedForSeriesUpgrade() (bool, error)
 	UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error)
+	InstanceId() (instance.Id, error)
+	ResizeConstraints(constraints.Value) (constraints.Value, error)
+	SetResized(constraints.Value, instance.HardwareCharacteristics) error
+	SetAvailabilityZone(string) error
+	IsManual() (bool, error)
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
 }
 
 type stateShim struct {
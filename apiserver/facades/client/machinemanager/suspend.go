@@ -0,0 +1,192 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+)
+
+// SuspendMachine isn't on the V7 API.
+func (*MachineManagerAPIV7) SuspendMachine(_, _ struct{}) {}
+
+// ResumeMachine isn't on the V7 API.
+func (*MachineManagerAPIV7) ResumeMachine(_, _ struct{}) {}
+
+// SuspendMachine powers off the instances of the given machines without
+// removing them from the model. The machines keep their instance IDs,
+// disks and addresses, and get the status "suspended".
+func (mm *MachineManagerAPI) SuspendMachine(args params.Entities) (params.ErrorResults, error) {
+	return suspendMachines(mm, environs.GetEnviron, args, true)
+}
+
+// ResumeMachine powers the instances of suspended machines on again.
+func (mm *MachineManagerAPI) ResumeMachine(args params.Entities) (params.ErrorResults, error) {
+	return suspendMachines(mm, environs.GetEnviron, args, false)
+}
+
+// SuspendModel isn't on the V8 API.
+func (*MachineManagerAPIV8) SuspendModel(_, _ struct{}) {}
+
+// ResumeModel isn't on the V8 API.
+func (*MachineManagerAPIV8) ResumeModel(_, _ struct{}) {}
+
+// SuspendModel asks for all of the model's machines to be suspended.
+// The model suspender worker runs the units' pre-suspend hooks and powers
+// the machines off, in reverse order of the dependencies between their
+// applications.
+func (mm *MachineManagerAPI) SuspendModel() error {
+	return setModelSuspendRequested(mm, environs.GetEnviron, true)
+}
+
+// ResumeModel asks for a suspended model's machines to be resumed, in
+// order of the dependencies between their applications.
+func (mm *MachineManagerAPI) ResumeModel() error {
+	return setModelSuspendRequested(mm, environs.GetEnviron, false)
+}
+
+func setModelSuspendRequested(mm *MachineManagerAPI, getEnviron environGetFunc, suspend bool) error {
+	if err := mm.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	env, err := mm.environ(getEnviron)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := env.(environs.InstanceSuspender); !ok {
+		return errors.NotSupportedf("suspending machines in this cloud")
+	}
+	model, err := mm.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(model.SetSuspendRequested(suspend))
+}
+
+func suspendMachines(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.Entities,
+	suspend bool,
+) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	if len(args.Entities) == 0 {
+		return results, nil
+	}
+
+	env, err := mm.environ(getEnviron)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	suspender, ok := env.(environs.InstanceSuspender)
+	if !ok {
+		return results, errors.NotSupportedf("suspending machines in this cloud")
+	}
+
+	for i, arg := range args.Entities {
+		var err error
+		if suspend {
+			err = mm.suspendOneMachine(suspender, arg.Tag)
+		} else {
+			err = mm.resumeOneMachine(suspender, arg.Tag)
+		}
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// suspendableMachine returns the machine with the given tag and its
+// instance ID, if it is a machine that can be suspended and resumed.
+func (mm *MachineManagerAPI) suspendableMachine(tagString string) (Machine, instance.Id, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if names.IsContainerMachine(tag.Id()) {
+		return nil, "", errors.NotSupportedf("suspending container %q", tag.Id())
+	}
+	machine, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if machine.IsManager() {
+		return nil, "", errors.Errorf("machine %s is a controller and cannot be suspended", tag.Id())
+	}
+	if manual, err := machine.IsManual(); err != nil {
+		return nil, "", errors.Trace(err)
+	} else if manual {
+		return nil, "", errors.NotSupportedf("suspending manual machine %q", tag.Id())
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return machine, instId, nil
+}
+
+func (mm *MachineManagerAPI) suspendOneMachine(suspender environs.InstanceSuspender, tag string) error {
+	machine, instId, err := mm.suspendableMachine(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	oldStatus, err := machine.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if oldStatus.Status == status.Suspended {
+		return nil
+	}
+	// The status is set first, so that the absent agent and stopped
+	// instance are never reported as a fault.
+	if err := machine.SetStatus(status.StatusInfo{Status: status.Suspended}); err != nil {
+		return errors.Trace(err)
+	}
+	if err := suspender.SuspendInstances(mm.callContext, instId); err != nil {
+		if restoreErr := machine.SetStatus(oldStatus); restoreErr != nil {
+			logger.Errorf("cannot restore status of machine %s: %v", machine.Id(), restoreErr)
+		}
+		return errors.Annotatef(err, "suspending machine %s", machine.Id())
+	}
+	return nil
+}
+
+func (mm *MachineManagerAPI) resumeOneMachine(suspender environs.InstanceSuspender, tag string) error {
+	machine, instId, err := mm.suspendableMachine(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	curStatus, err := machine.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if curStatus.Status != status.Suspended {
+		return errors.Errorf("machine %s is not suspended", machine.Id())
+	}
+	if err := suspender.ResumeInstances(mm.callContext, instId); err != nil {
+		return errors.Annotatef(err, "resuming machine %s", machine.Id())
+	}
+	// The machine agent sets the status again once it has started;
+	// until then, the agent's absence is reported.
+	return errors.Trace(machine.SetStatus(status.StatusInfo{
+		Status:  status.Started,
+		Message: "resuming",
+	}))
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+)
+
+type suspenderEnviron struct {
+	environs.Environ
+	jujutesting.Stub
+}
+
+func (e *suspenderEnviron) SuspendInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	e.MethodCall(e, "SuspendInstances", ids)
+	return e.NextErr()
+}
+
+func (e *suspenderEnviron) ResumeInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	e.MethodCall(e, "ResumeInstances", ids)
+	return e.NextErr()
+}
+
+func (m *mockMachine) IsManual() (bool, error) {
+	m.MethodCall(m, "IsManual")
+	return m.isManual, nil
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	m.MethodCall(m, "Status")
+	return m.machineStatus, nil
+}
+
+func (m *mockMachine) SetStatus(sInfo status.StatusInfo) error {
+	m.MethodCall(m, "SetStatus", sInfo)
+	m.machineStatus = sInfo
+	return nil
+}
+
+func (s *MachineManagerSuite) suspendMachines(
+	env environs.Environ, args params.Entities, suspend bool,
+) (params.ErrorResults, error) {
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	return machinemanager.SuspendMachines(s.api, getEnviron, args, suspend)
+}
+
+func (s *MachineManagerSuite) TestSuspendMachine(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", machineStatus: status.StatusInfo{Status: status.Started}}
+	s.st.machines["1"] = &mockMachine{id: "1", isManager: true}
+	s.st.machines["2"] = &mockMachine{id: "2", isManual: true}
+	env := &suspenderEnviron{}
+	results, err := s.suspendMachines(env, params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "machine-2"},
+			{Tag: "machine-3"},
+			{Tag: "machine-0-lxd-0"},
+		},
+	}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 5)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "machine 1 is a controller and cannot be suspended")
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `suspending manual machine "2" not supported`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, "machine 3 not found")
+	c.Assert(results.Results[4].Error, gc.ErrorMatches, `suspending container "0/lxd/0" not supported`)
+
+	env.CheckCalls(c, []jujutesting.StubCall{
+		{"SuspendInstances", []interface{}{[]instance.Id{"inst-0"}}},
+	})
+	c.Assert(s.st.machines["0"].machineStatus.Status, gc.Equals, status.Suspended)
+}
+
+func (s *MachineManagerSuite) TestSuspendMachineRestoresStatusOnError(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	started := status.StatusInfo{Status: status.Started}
+	machine := &mockMachine{id: "0", machineStatus: started}
+	s.st.machines["0"] = machine
+	env := &suspenderEnviron{}
+	env.SetErrors(errors.New("boom"))
+	results, err := s.suspendMachines(env, params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "suspending machine 0: boom")
+	c.Assert(machine.machineStatus, jc.DeepEquals, started)
+}
+
+func (s *MachineManagerSuite) TestResumeMachine(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", machineStatus: status.StatusInfo{Status: status.Suspended}}
+	s.st.machines["1"] = &mockMachine{id: "1", machineStatus: status.StatusInfo{Status: status.Started}}
+	env := &suspenderEnviron{}
+	results, err := s.suspendMachines(env, params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+	}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "machine 1 is not suspended")
+
+	env.CheckCalls(c, []jujutesting.StubCall{
+		{"ResumeInstances", []interface{}{[]instance.Id{"inst-0"}}},
+	})
+	c.Assert(s.st.machines["0"].machineStatus, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Started,
+		Message: "resuming",
+	})
+}
+
+func (s *MachineManagerSuite) TestSuspendMachineNotSupported(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	_, err := s.suspendMachines(&mockEnviron{}, params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	}, true)
+	c.Assert(err, gc.ErrorMatches, "suspending machines in this cloud not supported")
+}
+
+func (s *MachineManagerSuite) TestSuspendMachineBlocked(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.blockMsg = "TestSuspendMachineBlocked"
+	s.st.block = state.ChangeBlock
+	_, err := s.suspendMachines(&suspenderEnviron{}, params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	}, true)
+	c.Assert(err, gc.ErrorMatches, "TestSuspendMachineBlocked")
+}
+
+func (m *mockModel) SetSuspendRequested(suspend bool) error {
+	m.suspendRequested = suspend
+	return nil
+}
+
+func (s *MachineManagerSuite) setModelSuspendRequested(env environs.Environ, suspend bool) error {
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	return machinemanager.SetModelSuspendRequested(s.api, getEnviron, suspend)
+}
+
+func (s *MachineManagerSuite) TestSuspendAndResumeModel(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.model = &mockModel{}
+	err := s.setModelSuspendRequested(&suspenderEnviron{}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.st.model.suspendRequested, jc.IsTrue)
+
+	err = s.setModelSuspendRequested(&suspenderEnviron{}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.st.model.suspendRequested, jc.IsFalse)
+}
+
+func (s *MachineManagerSuite) TestSuspendModelNotSupported(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.model = &mockModel{}
+	err := s.setModelSuspendRequested(&mockEnviron{}, true)
+	c.Assert(err, gc.ErrorMatches, "suspending machines in this cloud not supported")
+	c.Assert(s.st.model.suspendRequested, jc.IsFalse)
+}
+
+func (s *MachineManagerSuite) TestSuspendModelBlocked(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.blockMsg = "TestSuspendModelBlocked"
+	s.st.block = state.ChangeBlock
+	s.st.model = &mockModel{}
+	err := s.setModelSuspendRequested(&suspenderEnviron{}, true)
+	c.Assert(err, gc.ErrorMatches, "TestSuspendModelBlocked")
+	c.Assert(s.st.model.suspendRequested, jc.IsFalse)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/instance"
@@ -291,6 +292,64 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 	})
 }
 
+func (s *modelInfoSuite) setCAASModelWithBroker(c *gc.C, broker *mockCaasBroker, attrs map[string]interface{}) {
+	cfg, err := s.st.model.cfg.Apply(attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.model.cfg = cfg
+	s.st.model.modelType = state.ModelTypeCAAS
+	s.st.model.life = state.Alive
+	s.st.model.cloud.Regions = []cloud.Region{{Name: "some-region"}}
+	newBroker := func(args environs.OpenParams) (caas.Broker, error) {
+		c.Check(args.Cloud.Region, gc.Equals, "some-region")
+		c.Check(args.Config, gc.Equals, s.st.model.cfg)
+		return broker, nil
+	}
+	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, nil, newBroker, &s.authorizer, s.st.model, s.callContext)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelInfoSuite) TestModelInfoResourceQuota(c *gc.C) {
+	broker := &mockCaasBroker{quota: &caas.ResourceQuota{
+		CPULimit:   4000,
+		CPUUsed:    1500,
+		MemoryUsed: 2048,
+	}}
+	s.setCAASModelWithBroker(c, broker, map[string]interface{}{
+		k8sprovider.NamespaceCPULimitKey: "4",
+	})
+	info := s.getModelInfo(c, s.st.model.cfg.UUID())
+	c.Assert(info.ResourceQuota, jc.DeepEquals, &params.ModelResourceQuota{
+		CPULimit:   4000,
+		CPUUsed:    1500,
+		MemoryUsed: 2048,
+	})
+	broker.CheckCallNames(c, "ResourceQuota")
+}
+
+func (s *modelInfoSuite) TestModelInfoNoResourceQuota(c *gc.C) {
+	broker := &mockCaasBroker{}
+	s.setCAASModelWithBroker(c, broker, map[string]interface{}{
+		k8sprovider.NamespaceMemoryLimitKey: "4Gi",
+	})
+	info := s.getModelInfo(c, s.st.model.cfg.UUID())
+	c.Assert(info.ResourceQuota, gc.IsNil)
+	broker.CheckCallNames(c, "ResourceQuota")
+}
+
+func (s *modelInfoSuite) TestModelInfoResourceQuotaNotConfigured(c *gc.C) {
+	s.st.model.modelType = state.ModelTypeCAAS
+	s.st.model.life = state.Alive
+	newBroker := func(args environs.OpenParams) (caas.Broker, error) {
+		c.Fatalf("unexpected broker for model without resource limits")
+		return nil, nil
+	}
+	var err error
+	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, nil, newBroker, &s.authorizer, s.st.model, s.callContext)
+	c.Assert(err, jc.ErrorIsNil)
+	info := s.getModelInfo(c, s.st.model.cfg.UUID())
+	c.Assert(info.ResourceQuota, gc.IsNil)
+}
+
 func (s *modelInfoSuite) assertModelInfo(c *gc.C, got, expected params.ModelInfo) {
 	c.Assert(got, jc.DeepEquals, expected)
 	s.st.model.CheckCalls(c, []gitjujutesting.StubCall{
@@ -617,6 +676,15 @@ type mockCaasBroker struct {
 	caas.Broker
 
 	namespace string
+	quota     *caas.ResourceQuota
+}
+
+func (m *mockCaasBroker) ResourceQuota() (*caas.ResourceQuota, error) {
+	m.MethodCall(m, "ResourceQuota")
+	if m.quota == nil {
+		return nil, errors.NotFoundf("resource quota")
+	}
+	return m.quota, nil
 }
 
 func (m *mockCaasBroker) Create(context.ProviderCallContext, environs.CreateParams) error {
@@ -1085,6 +1153,7 @@ type mockModel struct {
 	isController        bool
 	cloud               cloud.Cloud
 	cred                state.Credential
+	modelType           state.ModelType
 	setCloudCredentialF func(tag names.CloudCredentialTag) (bool, error)
 }
 
@@ -1105,6 +1174,9 @@ func (m *mockModel) ModelTag() names.ModelTag {
 
 func (m *mockModel) Type() state.ModelType {
 	m.MethodCall(m, "Type")
+	if m.modelType != "" {
+		return m.modelType
+	}
 	return state.ModelTypeIAAS
 }
 
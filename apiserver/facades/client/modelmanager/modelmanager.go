@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller/modelmanager"
 	"github.com/juju/juju/core/life"
@@ -1210,6 +1211,9 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 		if agentVersion, exists := cfg.AgentVersion(); exists {
 			info.AgentVersion = &agentVersion
 		}
+		if info.Type == string(state.ModelTypeCAAS) && info.Life == life.Alive {
+			info.ResourceQuota = m.modelResourceQuota(model, cfg)
+		}
 	}
 
 	status, err := model.Status()
@@ -1291,6 +1295,48 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 	return info, nil
 }
 
+// modelResourceQuota returns the limits on, and usage of, the compute
+// resources of a container model, or nil if the model doesn't have a
+// quota. The quota is informational, so failing to get it from the
+// cluster isn't fatal.
+func (m *ModelManagerAPI) modelResourceQuota(model common.Model, cfg *config.Config) *params.ModelResourceQuota {
+	// Only models configured with limits have a quota, so don't go to
+	// the cluster for the others.
+	attrs := cfg.UnknownAttrs()
+	cpuLimit, _ := attrs[k8sprovider.NamespaceCPULimitKey].(string)
+	memoryLimit, _ := attrs[k8sprovider.NamespaceMemoryLimitKey].(string)
+	if cpuLimit == "" && memoryLimit == "" {
+		return nil
+	}
+	cloudSpec, err := stateenvirons.CloudSpecForModel(model)
+	if err != nil {
+		logger.Warningf("cannot get cloud spec for model %q: %v", model.Name(), err)
+		return nil
+	}
+	broker, err := m.getBroker(environs.OpenParams{
+		ControllerUUID: model.ControllerUUID(),
+		Cloud:          cloudSpec,
+		Config:         cfg,
+	})
+	if err != nil {
+		logger.Warningf("cannot open kubernetes client for model %q: %v", model.Name(), err)
+		return nil
+	}
+	quota, err := broker.ResourceQuota()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		logger.Warningf("cannot get resource quota for model %q: %v", model.Name(), err)
+		return nil
+	}
+	return &params.ModelResourceQuota{
+		CPULimit:    quota.CPULimit,
+		CPUUsed:     quota.CPUUsed,
+		MemoryLimit: quota.MemoryLimit,
+		MemoryUsed:  quota.MemoryUsed,
+	}
+}
+
 // ModifyModelAccess changes the model access granted to users.
 func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest) (result params.ErrorResults, _ error) {
 	result = params.ErrorResults{
@@ -203,6 +203,28 @@ func (facade *Facade) PublicKeys(args params.Entities) (params.SSHPublicKeysResu
 	return out, nil
 }
 
+// ProxyJump returns the "[user@]host" of the SSH jump host through which
+// the machine of each given entity is reached, or "" if the machine is
+// reached directly. Machines and units are supported.
+func (facade *Facade) ProxyJump(args params.Entities) (params.StringResults, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	out := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := facade.backend.GetMachineForEntity(entity.Tag)
+		if err != nil {
+			out.Results[i].Error = apiservererrors.ServerError(err)
+		} else {
+			out.Results[i].Result = machine.SSHProxyJump()
+		}
+	}
+	return out, nil
+}
+
 // Proxy returns whether SSH connections should be proxied through the
 // controller hosts for the model associated with the API connection.
 func (facade *Facade) Proxy() (params.SSHProxyResult, error) {
@@ -162,6 +162,27 @@ func (s *facadeSuite) TestPublicKeys(c *gc.C) {
 	})
 }
 
+func (s *facadeSuite) TestProxyJump(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{s.m0}, {s.uOther}, {s.uFoo}},
+	}
+	results, err := s.facade.ProxyJump(args)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: ""},
+			{Error: apiservertesting.NotFoundError("entity")},
+			{Result: "admin@bastion"},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"GetMachineForEntity", []interface{}{s.m0}},
+		{"GetMachineForEntity", []interface{}{s.uOther}},
+		{"GetMachineForEntity", []interface{}{s.uFoo}},
+	})
+}
+
 func (s *facadeSuite) TestProxyTrue(c *gc.C) {
 	s.backend.proxySSH = true
 	result, err := s.facade.Proxy()
@@ -224,6 +245,7 @@ func (backend *mockBackend) GetMachineForEntity(tagString string) (sshclient.SSH
 				"100.100.100.100", // This one will be filtered by provider
 			),
 			allNetworkAddresses: network.NewSpaceAddresses("0.3.2.1", "3.3.3.3", "4.4.4.4"),
+			proxyJump:           "admin@bastion",
 		}, nil
 	}
 	return nil, errors.NotFoundf("entity")
@@ -252,6 +274,7 @@ type mockMachine struct {
 
 	addresses           network.SpaceAddresses
 	allNetworkAddresses network.SpaceAddresses
+	proxyJump           string
 }
 
 func (m *mockMachine) MachineTag() names.MachineTag {
@@ -273,3 +296,7 @@ func (m *mockMachine) AllNetworkAddresses() (network.SpaceAddresses, error) {
 func (m *mockMachine) Addresses() network.SpaceAddresses {
 	return m.addresses
 }
+
+func (m *mockMachine) SSHProxyJump() string {
+	return m.proxyJump
+}
@@ -31,6 +31,7 @@ type SSHMachine interface {
 	PrivateAddress() (network.SpaceAddress, error)
 	Addresses() network.SpaceAddresses
 	AllNetworkAddresses() (network.SpaceAddresses, error)
+	SSHProxyJump() string
 }
 
 type backend struct {
@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -11,24 +12,49 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
 type Facade struct {
 	*common.LifeGetter
 	*common.AgentEntityWatcher
+	*common.ModelWatcher
 	resources facade.Resources
 	state     CAASFirewallerState
 }
 
+// FacadeV1 is the V1 API of the CAAS firewaller facade. It lacks
+// WatchApplicationsRelations, WatchRelationsIngressNetworks,
+// NetworkPolicies and the model config methods.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewStateFacadeV1 provides the signature required for facade registration
+// of the V1 API.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	f, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
 	resources := ctx.Resources()
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return NewFacade(
 		resources,
 		authorizer,
-		stateShim{ctx.State()},
+		stateShim{State: st, model: model},
 	)
 }
 
@@ -54,8 +80,9 @@ func NewFacade(
 			resources,
 			accessApplication,
 		),
-		resources: resources,
-		state:     st,
+		ModelWatcher: common.NewModelWatcher(st, resources, authorizer),
+		resources:    resources,
+		state:        st,
 	}, nil
 }
 
@@ -124,3 +151,199 @@ func (f *Facade) getApplicationConfig(tagString string) (map[string]interface{},
 	}
 	return app.ApplicationConfig()
 }
+
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+//
+// WatchApplicationsRelations, WatchRelationsIngressNetworks,
+// NetworkPolicies, WatchForModelConfigChanges and ModelConfig did not
+// exist prior to v2.
+func (*FacadeV1) WatchApplicationsRelations(_, _ struct{})    {}
+func (*FacadeV1) WatchRelationsIngressNetworks(_, _ struct{}) {}
+func (*FacadeV1) NetworkPolicies(_, _ struct{})               {}
+func (*FacadeV1) WatchForModelConfigChanges(_, _ struct{})    {}
+func (*FacadeV1) ModelConfig(_, _ struct{})                   {}
+
+// WatchApplicationsRelations starts a StringsWatcher to watch the
+// relations of the specified applications.
+func (f *Facade) WatchApplicationsRelations(args params.Entities) (params.StringsWatchResults, error) {
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, changes, err := f.watchApplicationRelations(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].StringsWatcherId = id
+		results.Results[i].Changes = changes
+	}
+	return results, nil
+}
+
+func (f *Facade) watchApplicationRelations(tagString string) (string, []string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	w := app.WatchRelations()
+	if changes, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), changes, nil
+	}
+	return "", nil, watcher.EnsureErr(w)
+}
+
+// WatchRelationsIngressNetworks starts a StringsWatcher to watch the
+// networks a consuming model has asked to be allowed in for each of the
+// specified cross model relations.
+func (f *Facade) WatchRelationsIngressNetworks(args params.Entities) (params.StringsWatchResults, error) {
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, changes, err := f.watchRelationIngressNetworks(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].StringsWatcherId = id
+		results.Results[i].Changes = changes
+	}
+	return results, nil
+}
+
+func (f *Facade) watchRelationIngressNetworks(tagString string) (string, []string, error) {
+	tag, err := names.ParseRelationTag(tagString)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	rel, err := f.state.KeyRelation(tag.Id())
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	w := rel.WatchRelationIngressNetworks()
+	if changes, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), changes, nil
+	}
+	return "", nil, watcher.EnsureErr(w)
+}
+
+// NetworkPolicies returns, for each endpoint of the specified applications,
+// the applications related on that endpoint and the CIDRs allowed to reach
+// it, whether because the endpoint is exposed or because a consuming model
+// asked for them on a cross model relation. Policies are only enforced if
+// enabled in the model config.
+func (f *Facade) NetworkPolicies(args params.Entities) (params.KubernetesNetworkPolicyResults, error) {
+	results := params.KubernetesNetworkPolicyResults{
+		Results: make([]params.KubernetesNetworkPolicyResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		policy, err := f.networkPolicy(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = policy
+	}
+	return results, nil
+}
+
+func (f *Facade) networkPolicy(tagString string) (*params.KubernetesNetworkPolicy, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	endpoints, err := app.Endpoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := f.state.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	enabled, _ := cfg.AllAttrs()[provider.NetworkPolicyKey].(bool)
+	policy := &params.KubernetesNetworkPolicy{
+		Enabled:   enabled,
+		Endpoints: make(map[string]params.KubernetesEndpointIngress),
+	}
+	for _, ep := range endpoints {
+		policy.Endpoints[ep.Name] = params.KubernetesEndpointIngress{}
+	}
+
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		if rel.Life() != state.Alive {
+			continue
+		}
+		ep, err := rel.Endpoint(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Applications in other models don't run in this namespace,
+		// so their pods can't be selected. Instead, allow the networks
+		// the consuming model asked for.
+		if _, isCrossModel, err := rel.RemoteApplication(); err != nil {
+			return nil, errors.Trace(err)
+		} else if isCrossModel {
+			cidrs, err := f.state.RelationIngressNetworks(rel.Tag().Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ingress := policy.Endpoints[ep.Name]
+			ingress.CIDRs = append(ingress.CIDRs, cidrs...)
+			policy.Endpoints[ep.Name] = ingress
+			continue
+		}
+		related, err := rel.RelatedEndpoints(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ingress := policy.Endpoints[ep.Name]
+		for _, relatedEp := range related {
+			// For peer relations this is the application itself.
+			ingress.Applications = append(ingress.Applications, relatedEp.ApplicationName)
+		}
+		policy.Endpoints[ep.Name] = ingress
+	}
+
+	if app.IsExposed() {
+		for endpoint, exposed := range app.ExposedEndpoints() {
+			// The "" endpoint applies to all endpoints.
+			for name, ingress := range policy.Endpoints {
+				if endpoint != "" && endpoint != name {
+					continue
+				}
+				ingress.CIDRs = append(ingress.CIDRs, exposed.ExposeToCIDRs...)
+				policy.Endpoints[name] = ingress
+			}
+		}
+	}
+
+	for name, ingress := range policy.Endpoints {
+		ingress.Applications = uniqueSorted(ingress.Applications)
+		ingress.CIDRs = uniqueSorted(ingress.CIDRs)
+		policy.Endpoints[name] = ingress
+	}
+	return policy, nil
+}
+
+func uniqueSorted(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return set.NewStrings(values...).SortedValues()
+}
@@ -4,6 +4,7 @@
 package caasfirewaller_test
 
 import (
+	"github.com/juju/charm/v8"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v2/workertest"
@@ -27,6 +28,7 @@ type CAASFirewallerSuite struct {
 	st                  *mockState
 	applicationsChanges chan []string
 	appExposedChanges   chan struct{}
+	relationsChanges    chan []string
 
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
@@ -38,17 +40,22 @@ func (s *CAASFirewallerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationsChanges = make(chan []string, 1)
 	s.appExposedChanges = make(chan struct{}, 1)
+	s.relationsChanges = make(chan []string, 1)
 	appExposedWatcher := statetesting.NewMockNotifyWatcher(s.appExposedChanges)
+	relationsWatcher := statetesting.NewMockStringsWatcher(s.relationsChanges)
 	s.st = &mockState{
 		application: mockApplication{
-			life:    state.Alive,
-			watcher: appExposedWatcher,
+			life:             state.Alive,
+			watcher:          appExposedWatcher,
+			relationsWatcher: relationsWatcher,
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		appExposedWatcher:   appExposedWatcher,
+		modelConfig:         coretesting.ModelConfig(c),
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.appExposedWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, relationsWatcher) })
 
 	s.resources = common.NewResources()
 	s.authorizer = &apiservertesting.FakeAuthorizer{
@@ -159,3 +166,159 @@ func (s *CAASFirewallerSuite) TestApplicationConfig(c *gc.C) {
 	})
 	c.Assert(results.Results[0].Config, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
 }
+
+func (s *CAASFirewallerSuite) TestWatchApplicationsRelations(c *gc.C) {
+	s.relationsChanges <- []string{"gitlab:db mysql:server"}
+
+	results, err := s.facade.WatchApplicationsRelations(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].StringsWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[0].Changes, jc.DeepEquals, []string{"gitlab:db mysql:server"})
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.st.application.relationsWatcher)
+}
+
+func (s *CAASFirewallerSuite) TestWatchRelationsIngressNetworks(c *gc.C) {
+	ingressChanges := make(chan []string, 1)
+	ingressWatcher := statetesting.NewMockStringsWatcher(ingressChanges)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, ingressWatcher) })
+	s.st.relations = map[string]caasfirewaller.Relation{
+		"gitlab:website remote-apache:proxy": &mockRelation{
+			key:            "gitlab:website remote-apache:proxy",
+			ingressWatcher: ingressWatcher,
+		},
+	}
+	ingressChanges <- []string{"192.168.1.0/24"}
+
+	results, err := s.facade.WatchRelationsIngressNetworks(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "relation-gitlab.website#remote-apache.proxy"},
+			{Tag: "relation-gitlab.db#mysql.server"},
+			{Tag: "application-gitlab"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].StringsWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[0].Changes, jc.DeepEquals, []string{"192.168.1.0/24"})
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `relation "gitlab:db mysql:server" not found`,
+		Code:    params.CodeNotFound,
+	})
+	c.Assert(results.Results[2].Error, jc.DeepEquals, &params.Error{
+		Message: `"application-gitlab" is not a valid relation tag`,
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, ingressWatcher)
+}
+
+func endpoint(app, name string, role charm.RelationRole) state.Endpoint {
+	return state.Endpoint{
+		ApplicationName: app,
+		Relation:        charm.Relation{Name: name, Role: role, Interface: name},
+	}
+}
+
+func (s *CAASFirewallerSuite) TestNetworkPolicies(c *gc.C) {
+	s.st.application.endpoints = []state.Endpoint{
+		endpoint("gitlab", "db", charm.RoleRequirer),
+		endpoint("gitlab", "website", charm.RoleProvider),
+		endpoint("gitlab", "cluster", charm.RolePeer),
+		endpoint("gitlab", "admin", charm.RoleProvider),
+	}
+	s.st.application.relations = []caasfirewaller.Relation{
+		&mockRelation{life: state.Alive, endpoints: []state.Endpoint{
+			endpoint("gitlab", "db", charm.RoleRequirer), endpoint("mysql", "server", charm.RoleProvider),
+		}},
+		&mockRelation{life: state.Alive, endpoints: []state.Endpoint{
+			endpoint("gitlab", "website", charm.RoleProvider), endpoint("haproxy", "reverseproxy", charm.RoleRequirer),
+		}},
+		&mockRelation{life: state.Alive, endpoints: []state.Endpoint{
+			endpoint("gitlab", "website", charm.RoleProvider), endpoint("squid", "proxy", charm.RoleRequirer),
+		}},
+		&mockRelation{life: state.Alive, endpoints: []state.Endpoint{
+			endpoint("gitlab", "cluster", charm.RolePeer),
+		}},
+		&mockRelation{life: state.Dying, endpoints: []state.Endpoint{
+			endpoint("gitlab", "db", charm.RoleRequirer), endpoint("postgresql", "db", charm.RoleProvider),
+		}},
+		&mockRelation{key: "gitlab:website remote-apache:proxy", life: state.Alive, crossModel: true, endpoints: []state.Endpoint{
+			endpoint("gitlab", "website", charm.RoleProvider), endpoint("remote-apache", "proxy", charm.RoleRequirer),
+		}},
+	}
+	s.st.ingressNetworks = map[string][]string{
+		"gitlab:website remote-apache:proxy": {"192.168.1.0/24"},
+	}
+	s.st.modelConfig = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"network-policy": true,
+	})
+	s.st.application.exposed = true
+	s.st.application.exposedEndpoints = map[string]state.ExposedEndpoint{
+		"":        {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		"website": {ExposeToCIDRs: []string{"0.0.0.0/0"}, ExposeToSpaceIDs: []string{"1"}},
+	}
+
+	results, err := s.facade.NetworkPolicies(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.KubernetesNetworkPolicyResults{
+		Results: []params.KubernetesNetworkPolicyResult{{
+			Result: &params.KubernetesNetworkPolicy{
+				Enabled: true,
+				Endpoints: map[string]params.KubernetesEndpointIngress{
+					"db": {
+						Applications: []string{"mysql"},
+						CIDRs:        []string{"10.0.0.0/8"},
+					},
+					"website": {
+						Applications: []string{"haproxy", "squid"},
+						CIDRs:        []string{"0.0.0.0/0", "10.0.0.0/8", "192.168.1.0/24"},
+					},
+					"cluster": {
+						Applications: []string{"gitlab"},
+						CIDRs:        []string{"10.0.0.0/8"},
+					},
+					"admin": {
+						CIDRs: []string{"10.0.0.0/8"},
+					},
+				},
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+}
+
+func (s *CAASFirewallerSuite) TestNetworkPoliciesNotExposed(c *gc.C) {
+	s.st.application.endpoints = []state.Endpoint{
+		endpoint("gitlab", "db", charm.RoleRequirer),
+	}
+	s.st.application.exposedEndpoints = map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	}
+
+	results, err := s.facade.NetworkPolicies(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.KubernetesNetworkPolicyResult{{
+		Result: &params.KubernetesNetworkPolicy{
+			Endpoints: map[string]params.KubernetesEndpointIngress{"db": {}},
+		},
+	}})
+}
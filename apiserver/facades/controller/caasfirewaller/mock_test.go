@@ -4,11 +4,13 @@
 package caasfirewaller_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/testing"
 
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -18,6 +20,40 @@ type mockState struct {
 	application         mockApplication
 	applicationsWatcher *statetesting.MockStringsWatcher
 	appExposedWatcher   *statetesting.MockNotifyWatcher
+	modelConfigWatcher  *statetesting.MockNotifyWatcher
+	modelConfig         *config.Config
+	relations           map[string]caasfirewaller.Relation
+	ingressNetworks     map[string][]string
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	st.MethodCall(st, "ModelConfig")
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	return st.modelConfig, nil
+}
+
+func (st *mockState) WatchForModelConfigChanges() state.NotifyWatcher {
+	st.MethodCall(st, "WatchForModelConfigChanges")
+	return st.modelConfigWatcher
+}
+
+func (st *mockState) KeyRelation(key string) (caasfirewaller.Relation, error) {
+	st.MethodCall(st, "KeyRelation", key)
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	rel, ok := st.relations[key]
+	if !ok {
+		return nil, errors.NotFoundf("relation %q", key)
+	}
+	return rel, nil
+}
+
+func (st *mockState) RelationIngressNetworks(relationKey string) ([]string, error) {
+	st.MethodCall(st, "RelationIngressNetworks", relationKey)
+	return st.ingressNetworks[relationKey], st.NextErr()
 }
 
 func (st *mockState) WatchApplications() state.StringsWatcher {
@@ -43,9 +79,13 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 
 type mockApplication struct {
 	testing.Stub
-	life    state.Life
-	exposed bool
-	watcher state.NotifyWatcher
+	life             state.Life
+	exposed          bool
+	exposedEndpoints map[string]state.ExposedEndpoint
+	endpoints        []state.Endpoint
+	relations        []caasfirewaller.Relation
+	watcher          state.NotifyWatcher
+	relationsWatcher state.StringsWatcher
 }
 
 func (*mockApplication) Tag() names.Tag {
@@ -62,6 +102,21 @@ func (a *mockApplication) IsExposed() bool {
 	return a.exposed
 }
 
+func (a *mockApplication) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	a.MethodCall(a, "ExposedEndpoints")
+	return a.exposedEndpoints
+}
+
+func (a *mockApplication) Endpoints() ([]state.Endpoint, error) {
+	a.MethodCall(a, "Endpoints")
+	return a.endpoints, a.NextErr()
+}
+
+func (a *mockApplication) Relations() ([]caasfirewaller.Relation, error) {
+	a.MethodCall(a, "Relations")
+	return a.relations, a.NextErr()
+}
+
 func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig")
 	return application.ConfigAttributes{"foo": "bar"}, a.NextErr()
@@ -70,3 +125,50 @@ func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, err
 func (a *mockApplication) Watch() state.NotifyWatcher {
 	return a.watcher
 }
+
+func (a *mockApplication) WatchRelations() state.StringsWatcher {
+	return a.relationsWatcher
+}
+
+type mockRelation struct {
+	key            string
+	life           state.Life
+	endpoints      []state.Endpoint
+	crossModel     bool
+	ingressWatcher state.StringsWatcher
+}
+
+func (r *mockRelation) Tag() names.Tag {
+	return names.NewRelationTag(r.key)
+}
+
+func (r *mockRelation) Life() state.Life {
+	return r.life
+}
+
+func (r *mockRelation) Endpoint(appName string) (state.Endpoint, error) {
+	for _, ep := range r.endpoints {
+		if ep.ApplicationName == appName {
+			return ep, nil
+		}
+	}
+	return state.Endpoint{}, errors.NotFoundf("endpoint for %q", appName)
+}
+
+func (r *mockRelation) RelatedEndpoints(appName string) ([]state.Endpoint, error) {
+	var result []state.Endpoint
+	for _, ep := range r.endpoints {
+		if ep.ApplicationName != appName || len(r.endpoints) == 1 {
+			result = append(result, ep)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockRelation) RemoteApplication() (*state.RemoteApplication, bool, error) {
+	return nil, r.crossModel, nil
+}
+
+func (r *mockRelation) WatchRelationIngressNetworks() state.StringsWatcher {
+	return r.ingressWatcher
+}
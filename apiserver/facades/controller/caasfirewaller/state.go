@@ -4,32 +4,102 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
 // CAASUnitProvisionerState provides the subset of global state
 // required by the CAAS operator facade.
 type CAASFirewallerState interface {
+	state.ModelAccessor
+
 	FindEntity(tag names.Tag) (state.Entity, error)
 	Application(string) (Application, error)
 	WatchApplications() state.StringsWatcher
+	KeyRelation(string) (Relation, error)
+	RelationIngressNetworks(relationKey string) ([]string, error)
 }
 
 // Application provides the subset of application state
 // required by the CAAS operator facade.
 type Application interface {
 	IsExposed() bool
+	ExposedEndpoints() map[string]state.ExposedEndpoint
 	ApplicationConfig() (application.ConfigAttributes, error)
+	Endpoints() ([]state.Endpoint, error)
+	Relations() ([]Relation, error)
 	Watch() state.NotifyWatcher
+	WatchRelations() state.StringsWatcher
+}
+
+// Relation provides the subset of relation state
+// required by the CAAS firewaller facade.
+type Relation interface {
+	Tag() names.Tag
+	Life() state.Life
+	Endpoint(string) (state.Endpoint, error)
+	RelatedEndpoints(string) ([]state.Endpoint, error)
+	RemoteApplication() (*state.RemoteApplication, bool, error)
+	WatchRelationIngressNetworks() state.StringsWatcher
 }
 
 type stateShim struct {
 	*state.State
+	model *state.Model
 }
 
 func (s stateShim) Application(id string) (Application, error) {
-	return s.State.Application(id)
+	app, err := s.State.Application(id)
+	if err != nil {
+		return nil, err
+	}
+	return applicationShim{app}, nil
+}
+
+func (s stateShim) ModelConfig() (*config.Config, error) {
+	return s.model.ModelConfig()
+}
+
+func (s stateShim) WatchForModelConfigChanges() state.NotifyWatcher {
+	return s.model.WatchForModelConfigChanges()
+}
+
+func (s stateShim) KeyRelation(key string) (Relation, error) {
+	rel, err := s.State.KeyRelation(key)
+	if err != nil {
+		return nil, err
+	}
+	return rel, nil
+}
+
+// RelationIngressNetworks returns the networks a consuming model has
+// asked to be allowed in for the cross model relation with the given key.
+func (s stateShim) RelationIngressNetworks(relationKey string) ([]string, error) {
+	networks, err := state.NewRelationIngressNetworks(s.State).Networks(relationKey)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return networks.CIDRS(), nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) Relations() ([]Relation, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Relation, len(relations))
+	for i, rel := range relations {
+		result[i] = rel
+	}
+	return result, nil
 }
@@ -51,6 +51,22 @@ type Facade struct {
 	clock              clock.Clock
 }
 
+// FacadeV1 is the V1 API of the CAAS unit provisioner facade. It lacks
+// WatchApplicationsConfig.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewStateFacadeV1 provides the signature required for facade registration
+// of the V1 API.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	f, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -164,6 +180,46 @@ func (f *Facade) watchApplicationScale(tagString string) (string, error) {
 	return "", watcher.EnsureErr(w)
 }
 
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+//
+// WatchApplicationsConfig did not exist prior to v2.
+func (*FacadeV1) WatchApplicationsConfig(_, _ struct{}) {}
+
+// WatchApplicationsConfig starts a NotifyWatcher to watch changes
+// to the application config of the specified applications.
+func (f *Facade) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (f *Facade) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
 // WatchPodSpec starts a NotifyWatcher to watch changes to the
 // pod spec for specified units in this model.
 func (f *Facade) WatchPodSpec(args params.Entities) (params.NotifyWatchResults, error) {
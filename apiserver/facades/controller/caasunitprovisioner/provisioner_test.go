@@ -45,6 +45,7 @@ type CAASProvisionerSuite struct {
 	applicationsChanges chan []string
 	podSpecChanges      chan struct{}
 	scaleChanges        chan struct{}
+	configChanges       chan struct{}
 
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
@@ -63,13 +64,15 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.applicationsChanges = make(chan []string, 1)
 	s.podSpecChanges = make(chan struct{}, 1)
 	s.scaleChanges = make(chan struct{}, 1)
+	s.configChanges = make(chan struct{}, 1)
 	s.isRawK8sSpec = boolptr(false)
 	s.st = &mockState{
 		application: mockApplication{
-			tag:          names.NewApplicationTag("gitlab"),
-			life:         state.Alive,
-			scaleWatcher: statetesting.NewMockNotifyWatcher(s.scaleChanges),
-			scale:        5,
+			tag:           names.NewApplicationTag("gitlab"),
+			life:          state.Alive,
+			scaleWatcher:  statetesting.NewMockNotifyWatcher(s.scaleChanges),
+			configWatcher: statetesting.NewMockNotifyWatcher(s.configChanges),
+			scale:         5,
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		model: mockModel{
@@ -90,6 +93,7 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.devices = &mockDeviceBackend{}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.scaleWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.configWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.model.podSpecWatcher) })
 
 	s.resources = common.NewResources()
@@ -170,6 +174,27 @@ func (s *CAASProvisionerSuite) TestWatchApplicationsScale(c *gc.C) {
 	c.Assert(resource, gc.Equals, s.st.application.scaleWatcher)
 }
 
+func (s *CAASProvisionerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	s.configChanges <- struct{}{}
+
+	results, err := s.facade.WatchApplicationsConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.configWatcher)
+}
+
 func (s *CAASProvisionerSuite) assertProvisioningInfo(c *gc.C, isRawK8sSpec bool) {
 	s.st.application.units = []caasunitprovisioner.Unit{
 		&mockUnit{name: "gitlab/0", life: state.Dying},
@@ -75,6 +75,7 @@ type Application interface {
 	GetScale() int
 	SetScale(int, int64, bool) error
 	WatchScale() state.NotifyWatcher
+	WatchApplicationConfig() state.NotifyWatcher
 	ApplicationConfig() (application.ConfigAttributes, error)
 	AllUnits() (units []Unit, err error)
 	AddOperation(state.UnitUpdateProperties) *state.AddUnitOperation
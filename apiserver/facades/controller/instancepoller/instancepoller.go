@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -381,6 +382,63 @@ func (a *InstancePollerAPI) SetInstanceStatus(args params.SetStatus) (params.Err
 	return result, nil
 }
 
+// ApplySpotInterruptionPolicy applies the model's spot-interruption-policy
+// to each given machine, whose spot instance has been interrupted by the
+// cloud. Only machine tags are accepted.
+func (a *InstancePollerAPI) ApplySpotInterruptionPolicy(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := a.accessMachine()
+	if err != nil {
+		return result, err
+	}
+	cfg, err := a.st.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	policy := cfg.SpotInterruptionPolicy()
+	for i, arg := range args.Entities {
+		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		if err == nil && policy == config.SpotInterruptionPolicyAddUnit {
+			err = a.addReplacementUnits(machine)
+		}
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+// addReplacementUnits adds a unit, on a new machine, for each principal
+// unit of the given machine. The new machines are started with the
+// applications' constraints, so they are spot instances too if the
+// applications ask for them.
+func (a *InstancePollerAPI) addReplacementUnits(machine StateMachine) error {
+	for _, unitName := range machine.Principals() {
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		app, err := a.st.Application(appName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if app.Life() != state.Alive {
+			continue
+		}
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		if err != nil {
+			return errors.Annotatef(err, "adding unit to replace %q", unitName)
+		}
+		if err := unit.AssignWithPolicy(state.AssignNew); err != nil {
+			return errors.Annotatef(err, "assigning unit %q", unit.Name())
+		}
+		logger.Infof("added unit %q to replace %q on interrupted machine %s", unit.Name(), unitName, machine.Id())
+	}
+	return nil
+}
+
 // AreManuallyProvisioned returns whether each given entity is
 // manually provisioned or not. Only machine tags are accepted.
 func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params.BoolResults, error) {
@@ -401,10 +459,26 @@ func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params
 	return result, nil
 }
 
+// InstancePollerAPIV4 implements the V4 API used by the instance poller
+// worker. Compared to V5, it lacks the ApplySpotInterruptionPolicy method.
+type InstancePollerAPIV4 struct {
+	*InstancePollerAPI
+}
+
 // InstancePollerAPIV3 implements the V3 API used by the instance poller
 // worker. Compared to V4, it lacks the SetProviderNetworkConfig method.
 type InstancePollerAPIV3 struct {
-	*InstancePollerAPI
+	*InstancePollerAPIV4
+}
+
+// NewFacadeV4 creates a new instance of the V4 InstancePoller API.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*InstancePollerAPIV4, error) {
+	api, err := NewFacade(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InstancePollerAPIV4{api}, nil
 }
 
 // NewFacadeV3 creates a new instance of the V3 InstancePoller API.
@@ -419,8 +493,11 @@ func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.
 		return nil, err
 	}
 
-	return &InstancePollerAPIV3{api}, nil
+	return &InstancePollerAPIV3{&InstancePollerAPIV4{api}}, nil
 }
 
 // SetProviderNetworkConfig is not available in V3.
 func (*InstancePollerAPIV3) SetProviderNetworkConfig(_, _ struct{}) {}
+
+// ApplySpotInterruptionPolicy is not available in V4.
+func (*InstancePollerAPIV4) ApplySpotInterruptionPolicy(_, _ struct{}) {}
@@ -745,6 +745,85 @@ func (s *InstancePollerSuite) TestAreManuallyProvisionedFailure(c *gc.C) {
 	s.st.CheckMachineCall(c, 3, "3")
 }
 
+func (s *InstancePollerSuite) setSpotInterruptionPolicy(c *gc.C, policy string) {
+	modelConfig, err := jujutesting.ModelConfig(c).Apply(map[string]interface{}{
+		"spot-interruption-policy": policy,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.SetConfig(c, modelConfig)
+}
+
+func (s *InstancePollerSuite) TestApplySpotInterruptionPolicyNone(c *gc.C) {
+	s.st.SetConfig(c, jujutesting.ModelConfig(c))
+	s.st.SetMachineInfo(c, machineInfo{id: "1", principals: []string{"mysql/0"}})
+	s.st.SetApplication("mysql", state.Alive)
+
+	result, err := s.api.ApplySpotInterruptionPolicy(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.st.CheckCallNames(c, "ModelConfig", "Machine")
+}
+
+func (s *InstancePollerSuite) TestApplySpotInterruptionPolicyAddUnit(c *gc.C) {
+	s.setSpotInterruptionPolicy(c, "add-unit")
+	s.st.SetMachineInfo(c, machineInfo{id: "1", principals: []string{"mysql/0", "wordpress/0", "gone/0"}})
+	s.st.SetApplication("mysql", state.Alive)
+	s.st.SetApplication("wordpress", state.Dying)
+
+	result, err := s.api.ApplySpotInterruptionPolicy(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "machine-42"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+		},
+	})
+
+	// Only the unit of the live application is replaced, on a new machine.
+	s.st.CheckCalls(c, []testing.StubCall{
+		{FuncName: "ModelConfig"},
+		{FuncName: "Machine", Args: []interface{}{"1"}},
+		{FuncName: "Principals"},
+		{FuncName: "Application", Args: []interface{}{"mysql"}},
+		{FuncName: "Life"},
+		{FuncName: "AddUnit", Args: []interface{}{state.AddUnitParams{}}},
+		{FuncName: "AssignWithPolicy", Args: []interface{}{"mysql/1", state.AssignNew}},
+		{FuncName: "Id"},
+		{FuncName: "Application", Args: []interface{}{"wordpress"}},
+		{FuncName: "Life"},
+		{FuncName: "Application", Args: []interface{}{"gone"}},
+		{FuncName: "Machine", Args: []interface{}{"42"}},
+	})
+}
+
+func (s *InstancePollerSuite) TestApplySpotInterruptionPolicyAddUnitFailure(c *gc.C) {
+	s.setSpotInterruptionPolicy(c, "add-unit")
+	s.st.SetMachineInfo(c, machineInfo{id: "1", principals: []string{"mysql/0"}})
+	s.st.SetApplication("mysql", state.Alive)
+	s.st.SetErrors(
+		nil,                // ModelConfig()
+		nil,                // Machine("1")
+		nil,                // Application("mysql")
+		errors.New("boom"), // AddUnit()
+	)
+
+	result, err := s.api.ApplySpotInterruptionPolicy(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ServerError(`adding unit to replace "mysql/0": boom`)},
+		},
+	})
+}
+
 func (s *InstancePollerSuite) TestSetProviderNetworkConfigSuccess(c *gc.C) {
 	s.setDefaultSpaceInfo()
 
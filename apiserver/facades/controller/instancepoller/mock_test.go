@@ -7,6 +7,7 @@
 package instancepoller_test
 
 import (
+	"fmt"
 	"sort"
 	"sync"
 	"time"
@@ -37,16 +38,18 @@ type mockState struct {
 	configWatchers   []*mockConfigWatcher
 	machinesWatchers []*mockMachinesWatcher
 
-	config   *config.Config
-	machines map[string]*mockMachine
+	config       *config.Config
+	machines     map[string]*mockMachine
+	applications map[string]*mockApplication
 
 	spaceInfos network.SpaceInfos
 }
 
 func NewMockState() *mockState {
 	return &mockState{
-		Stub:     &testing.Stub{},
-		machines: make(map[string]*mockMachine),
+		Stub:         &testing.Stub{},
+		machines:     make(map[string]*mockMachine),
+		applications: make(map[string]*mockApplication),
 	}
 }
 
@@ -234,6 +237,35 @@ func (m *mockState) Machine(id string) (instancepoller.StateMachine, error) {
 	return machine, nil
 }
 
+// SetApplication adds a new mockApplication with the given name and life.
+func (m *mockState) SetApplication(name string, life state.Life) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.applications[name] = &mockApplication{
+		Stub: m.Stub, // reuse parent stub.
+		name: name,
+		life: life,
+	}
+}
+
+// Application implements StateInterface.
+func (m *mockState) Application(name string) (instancepoller.StateApplication, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "Application", name)
+
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	app, found := m.applications[name]
+	if !found {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
 // AllSpaceInfos implements network.AllSpaceInfos.
 // This method never throws an error.
 func (m *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
@@ -271,6 +303,7 @@ type machineInfo struct {
 	providerAddresses []network.SpaceAddress
 	life              state.Life
 	isManual          bool
+	principals        []string
 
 	linkLayerDevices []networkingcommon.LinkLayerDevice
 	addresses        []networkingcommon.LinkLayerAddress
@@ -412,6 +445,15 @@ func (m *mockMachine) Status() (status.StatusInfo, error) {
 	return m.status, m.NextErr()
 }
 
+// Principals implements StateMachine.
+func (m *mockMachine) Principals() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "Principals")
+	return m.principals
+}
+
 // AssertAliveOp implements StateMachine.
 func (m *mockMachine) AssertAliveOp() txn.Op {
 	m.mu.Lock()
@@ -421,6 +463,52 @@ func (m *mockMachine) AssertAliveOp() txn.Op {
 	return txn.Op{C: "machine-alive"}
 }
 
+type mockApplication struct {
+	*testing.Stub
+
+	name  string
+	life  state.Life
+	units int
+}
+
+var _ instancepoller.StateApplication = (*mockApplication)(nil)
+
+// Life implements StateApplication.
+func (a *mockApplication) Life() state.Life {
+	a.MethodCall(a, "Life")
+	return a.life
+}
+
+// AddUnit implements StateApplication.
+func (a *mockApplication) AddUnit(args state.AddUnitParams) (instancepoller.StateUnit, error) {
+	a.MethodCall(a, "AddUnit", args)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	a.units++
+	return &mockUnit{
+		Stub: a.Stub,
+		name: fmt.Sprintf("%s/%d", a.name, a.units),
+	}, nil
+}
+
+type mockUnit struct {
+	*testing.Stub
+
+	name string
+}
+
+// Name implements StateUnit.
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+// AssignWithPolicy implements StateUnit.
+func (u *mockUnit) AssignWithPolicy(policy state.AssignmentPolicy) error {
+	u.MethodCall(u, "AssignWithPolicy", u.name, policy)
+	return u.NextErr()
+}
+
 type mockBaseWatcher struct {
 	err error
 
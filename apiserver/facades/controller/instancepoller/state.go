@@ -27,6 +27,19 @@ type StateMachine interface {
 	Life() state.Life
 	Status() (status.StatusInfo, error)
 	IsManual() (bool, error)
+	Principals() []string
+}
+
+// StateApplication represents an application from state package.
+type StateApplication interface {
+	Life() state.Life
+	AddUnit(state.AddUnitParams) (StateUnit, error)
+}
+
+// StateUnit represents a unit from state package.
+type StateUnit interface {
+	Name() string
+	AssignWithPolicy(state.AssignmentPolicy) error
 }
 
 type StateInterface interface {
@@ -36,6 +49,7 @@ type StateInterface interface {
 	network.SpaceLookup
 
 	Machine(id string) (StateMachine, error)
+	Application(name string) (StateApplication, error)
 
 	// ApplyOperation applies a given ModelOperation to the model.
 	ApplyOperation(state.ModelOperation) error
@@ -89,6 +103,38 @@ func (s stateShim) Machine(id string) (StateMachine, error) {
 	return machineShim{m}, nil
 }
 
+func (s stateShim) Application(name string) (StateApplication, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return applicationShim{app, s.State}, nil
+}
+
+type applicationShim struct {
+	*state.Application
+	st *state.State
+}
+
+func (a applicationShim) AddUnit(args state.AddUnitParams) (StateUnit, error) {
+	u, err := a.Application.AddUnit(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return unitShim{u, a.st}, nil
+}
+
+type unitShim struct {
+	*state.Unit
+	st *state.State
+}
+
+func (u unitShim) AssignWithPolicy(policy state.AssignmentPolicy) error {
+	return u.st.AssignUnit(u.Unit, policy)
+}
+
 var getState = func(st *state.State, m *state.Model) StateInterface {
 	return stateShim{st, m}
 }
@@ -0,0 +1,132 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the methods the model suspender facade needs from
+// state.State.
+type Backend interface {
+	// Model returns the model being suspended or resumed.
+	Model() (Model, error)
+
+	// AllRelations returns the endpoints of each of the model's
+	// relations.
+	AllRelations() ([][]state.Endpoint, error)
+
+	// AllMachines returns all of the model's machines.
+	AllMachines() ([]Machine, error)
+
+	// Machine returns the machine with the given ID.
+	Machine(id string) (Machine, error)
+
+	// Unit returns the unit with the given name.
+	Unit(name string) (Unit, error)
+}
+
+// Model defines the methods needed from state.Model.
+type Model interface {
+	SuspendRequested() bool
+	SetSuspendRequested(bool) error
+	ModelConfig() (*config.Config, error)
+	Watch() state.NotifyWatcher
+	WatchForModelConfigChanges() state.NotifyWatcher
+}
+
+// Machine defines the methods needed from state.Machine.
+type Machine interface {
+	Id() string
+	Tag() names.Tag
+	IsContainer() bool
+	IsManager() bool
+	IsManual() (bool, error)
+	InstanceId() (instance.Id, error)
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+	Containers() ([]string, error)
+	Units() ([]Unit, error)
+}
+
+// Unit defines the methods needed from state.Unit.
+type Unit interface {
+	UnitTag() names.UnitTag
+	ApplicationName() string
+	SuspendStatus() model.UnitSuspendStatus
+	SetSuspendStatus(model.UnitSuspendStatus) error
+}
+
+type backendShim struct {
+	*state.State
+}
+
+// Model implements Backend.
+func (b *backendShim) Model() (Model, error) {
+	return b.State.Model()
+}
+
+// AllRelations implements Backend.
+func (b *backendShim) AllRelations() ([][]state.Endpoint, error) {
+	relations, err := b.State.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([][]state.Endpoint, len(relations))
+	for i, rel := range relations {
+		result[i] = rel.Endpoints()
+	}
+	return result, nil
+}
+
+// AllMachines implements Backend.
+func (b *backendShim) AllMachines() ([]Machine, error) {
+	machines, err := b.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = machineShim{m}
+	}
+	return result, nil
+}
+
+// Machine implements Backend.
+func (b *backendShim) Machine(id string) (Machine, error) {
+	m, err := b.State.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machineShim{m}, nil
+}
+
+// Unit implements Backend.
+func (b *backendShim) Unit(name string) (Unit, error) {
+	return b.State.Unit(name)
+}
+
+type machineShim struct {
+	*state.Machine
+}
+
+// Units implements Machine.
+func (m machineShim) Units() ([]Unit, error) {
+	units, err := m.Machine.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, u := range units {
+		result[i] = u
+	}
+	return result, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/facades/controller/modelsuspender"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	model     *mockModel
+	relations [][]state.Endpoint
+	machines  map[string]*mockMachine
+	units     map[string]*mockUnit
+}
+
+func (b *mockBackend) Model() (modelsuspender.Model, error) {
+	b.MethodCall(b, "Model")
+	return b.model, b.NextErr()
+}
+
+func (b *mockBackend) AllRelations() ([][]state.Endpoint, error) {
+	b.MethodCall(b, "AllRelations")
+	return b.relations, b.NextErr()
+}
+
+func (b *mockBackend) AllMachines() ([]modelsuspender.Machine, error) {
+	b.MethodCall(b, "AllMachines")
+	var machines []modelsuspender.Machine
+	for _, m := range b.machines {
+		machines = append(machines, m)
+	}
+	return machines, b.NextErr()
+}
+
+func (b *mockBackend) Machine(id string) (modelsuspender.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if m, ok := b.machines[id]; ok {
+		return m, b.NextErr()
+	}
+	return nil, errors.NotFoundf("machine %s", id)
+}
+
+func (b *mockBackend) Unit(name string) (modelsuspender.Unit, error) {
+	b.MethodCall(b, "Unit", name)
+	if u, ok := b.units[name]; ok {
+		return u, b.NextErr()
+	}
+	return nil, errors.NotFoundf("unit %s", name)
+}
+
+type mockModel struct {
+	testing.Stub
+	suspendRequested bool
+	schedule         string
+	watcher          *mockNotifyWatcher
+	configWatcher    *mockNotifyWatcher
+}
+
+func (m *mockModel) SuspendRequested() bool {
+	m.MethodCall(m, "SuspendRequested")
+	return m.suspendRequested
+}
+
+func (m *mockModel) SetSuspendRequested(suspend bool) error {
+	m.MethodCall(m, "SetSuspendRequested", suspend)
+	m.suspendRequested = suspend
+	return m.NextErr()
+}
+
+func (m *mockModel) ModelConfig() (*config.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	attrs := coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"suspend-schedule": m.schedule,
+	})
+	cfg, err := config.New(config.NoDefaults, attrs)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, m.NextErr()
+}
+
+func (m *mockModel) Watch() state.NotifyWatcher {
+	m.MethodCall(m, "Watch")
+	return m.watcher
+}
+
+func (m *mockModel) WatchForModelConfigChanges() state.NotifyWatcher {
+	m.MethodCall(m, "WatchForModelConfigChanges")
+	return m.configWatcher
+}
+
+type mockMachine struct {
+	testing.Stub
+	id         string
+	isManager  bool
+	isManual   bool
+	instanceId instance.Id
+	status     status.StatusInfo
+	containers []string
+	units      []*mockUnit
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Tag() names.Tag {
+	return names.NewMachineTag(m.id)
+}
+
+func (m *mockMachine) IsContainer() bool {
+	return names.IsContainerMachine(m.id)
+}
+
+func (m *mockMachine) IsManager() bool {
+	return m.isManager
+}
+
+func (m *mockMachine) IsManual() (bool, error) {
+	return m.isManual, nil
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %s", m.id)
+	}
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	return m.status, nil
+}
+
+func (m *mockMachine) SetStatus(sInfo status.StatusInfo) error {
+	m.MethodCall(m, "SetStatus", sInfo)
+	m.status = sInfo
+	return m.NextErr()
+}
+
+func (m *mockMachine) Containers() ([]string, error) {
+	return m.containers, nil
+}
+
+func (m *mockMachine) Units() ([]modelsuspender.Unit, error) {
+	units := make([]modelsuspender.Unit, len(m.units))
+	for i, u := range m.units {
+		units[i] = u
+	}
+	return units, nil
+}
+
+type mockUnit struct {
+	testing.Stub
+	name          string
+	suspendStatus model.UnitSuspendStatus
+}
+
+func (u *mockUnit) UnitTag() names.UnitTag {
+	return names.NewUnitTag(u.name)
+}
+
+func (u *mockUnit) ApplicationName() string {
+	app, _ := names.UnitApplication(u.name)
+	return app
+}
+
+func (u *mockUnit) SuspendStatus() model.UnitSuspendStatus {
+	return u.suspendStatus
+}
+
+func (u *mockUnit) SetSuspendStatus(s model.UnitSuspendStatus) error {
+	u.MethodCall(u, "SetSuspendStatus", s)
+	u.suspendStatus = s
+	return u.NextErr()
+}
+
+type mockNotifyWatcher struct {
+	state.NotifyWatcher
+	changes chan struct{}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	w := &mockNotifyWatcher{changes: make(chan struct{}, 1)}
+	w.changes <- struct{}{}
+	return w
+}
+
+func (w *mockNotifyWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *mockNotifyWatcher) Wait() error {
+	return nil
+}
+
+func (w *mockNotifyWatcher) Stop() error {
+	return nil
+}
+
+func (w *mockNotifyWatcher) Kill() {}
+
+func (w *mockNotifyWatcher) Err() error {
+	return nil
+}
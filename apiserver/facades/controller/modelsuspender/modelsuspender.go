@@ -0,0 +1,238 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/naturalsort"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/watcher"
+)
+
+// API implements the API facade used by the model suspender worker.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewAPI returns a new model suspender API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(&backendShim{ctx.State()}, ctx.Resources(), ctx.Auth())
+}
+
+// WatchModelSuspension returns a NotifyWatcher that triggers when the
+// suspension of the model is requested or cancelled, or when its suspend
+// schedule might have changed.
+func (api *API) WatchModelSuspension() (params.NotifyWatchResult, error) {
+	model, err := api.backend.Model()
+	if err != nil {
+		return params.NotifyWatchResult{}, errors.Trace(err)
+	}
+	w := common.NewMultiNotifyWatcher(
+		model.Watch(),
+		model.WatchForModelConfigChanges(),
+	)
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(w),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(w)
+}
+
+// ModelSuspension returns whether the suspension of the model has been
+// requested, and the model's suspend schedule.
+func (api *API) ModelSuspension() (params.ModelSuspensionResult, error) {
+	model, err := api.backend.Model()
+	if err != nil {
+		return params.ModelSuspensionResult{}, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return params.ModelSuspensionResult{}, errors.Trace(err)
+	}
+	return params.ModelSuspensionResult{
+		Requested: model.SuspendRequested(),
+		Schedule:  cfg.SuspendSchedule(),
+	}, nil
+}
+
+// SetModelSuspendRequested records whether the model should be suspended,
+// as the model's suspend schedule comes into and out of effect.
+func (api *API) SetModelSuspendRequested(arg params.SetModelSuspendRequested) error {
+	model, err := api.backend.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(model.SetSuspendRequested(arg.Requested))
+}
+
+// SuspensionPlan returns the stages in which the model's machines are
+// suspended, along with the units on each machine and its containers.
+// Only provisioned machines that aren't containers, controllers or
+// manually provisioned are included.
+func (api *API) SuspensionPlan() (params.SuspensionPlanResult, error) {
+	relations, err := api.backend.AllRelations()
+	if err != nil {
+		return params.SuspensionPlanResult{}, errors.Trace(err)
+	}
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return params.SuspensionPlanResult{}, errors.Trace(err)
+	}
+	planned := make(map[string]params.SuspensionMachine)
+	machineApps := make(map[string][]string)
+	for _, m := range machines {
+		if m.IsContainer() || m.IsManager() {
+			continue
+		}
+		if manual, err := m.IsManual(); err != nil {
+			return params.SuspensionPlanResult{}, errors.Trace(err)
+		} else if manual {
+			continue
+		}
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return params.SuspensionPlanResult{}, errors.Trace(err)
+		}
+		machineStatus, err := m.Status()
+		if err != nil {
+			return params.SuspensionPlanResult{}, errors.Trace(err)
+		}
+		units, err := api.hostedUnits(m)
+		if err != nil {
+			return params.SuspensionPlanResult{}, errors.Trace(err)
+		}
+		pm := params.SuspensionMachine{
+			Tag:        m.Tag().String(),
+			InstanceId: string(instId),
+			Status:     machineStatus.Status.String(),
+		}
+		apps := make([]string, len(units))
+		unitNames := make([]string, len(units))
+		byName := make(map[string]Unit)
+		for i, u := range units {
+			apps[i] = u.ApplicationName()
+			unitNames[i] = u.UnitTag().Id()
+			byName[unitNames[i]] = u
+		}
+		naturalsort.Sort(unitNames)
+		for _, name := range unitNames {
+			u := byName[name]
+			pm.Units = append(pm.Units, params.SuspensionUnit{
+				Tag:           u.UnitTag().String(),
+				SuspendStatus: u.SuspendStatus(),
+			})
+		}
+		planned[m.Id()] = pm
+		machineApps[m.Id()] = apps
+	}
+
+	stages := suspensionStages(applicationDependencies(relations), machineApps)
+	result := params.SuspensionPlanResult{
+		Stages: make([]params.SuspensionStage, len(stages)),
+	}
+	for i, stage := range stages {
+		for _, id := range stage {
+			result.Stages[i].Machines = append(result.Stages[i].Machines, planned[id])
+		}
+	}
+	return result, nil
+}
+
+// hostedUnits returns the units on the given machine and, recursively,
+// on its containers.
+func (api *API) hostedUnits(m Machine) ([]Unit, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	containers, err := m.Containers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, id := range containers {
+		container, err := api.backend.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		containerUnits, err := api.hostedUnits(container)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units = append(units, containerUnits...)
+	}
+	return units, nil
+}
+
+// SetUnitSuspendStatus records each unit's progress through the
+// suspension or resumption of the model.
+func (api *API) SetUnitSuspendStatus(args params.UnitSuspendStatusParams) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Params)),
+	}
+	for i, arg := range args.Params {
+		result.Results[i].Error = apiservererrors.ServerError(api.setUnitSuspendStatus(arg))
+	}
+	return result, nil
+}
+
+func (api *API) setUnitSuspendStatus(arg params.UnitSuspendStatusParam) error {
+	tag, err := names.ParseUnitTag(arg.Entity.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(unit.SetSuspendStatus(arg.Status))
+}
+
+// SetMachineStatus sets the status of each given machine, as it is
+// suspended or resumed.
+func (api *API) SetMachineStatus(args params.SetStatus) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		result.Results[i].Error = apiservererrors.ServerError(api.setMachineStatus(arg))
+	}
+	return result, nil
+}
+
+func (api *API) setMachineStatus(arg params.EntityStatusArgs) error {
+	tag, err := names.ParseMachineTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machine, err := api.backend.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(machine.SetStatus(status.StatusInfo{
+		Status:  status.Status(arg.Status),
+		Message: arg.Info,
+		Data:    arg.Data,
+	}))
+}
@@ -0,0 +1,166 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender_test
+
+import (
+	"github.com/juju/charm/v8"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/controller/modelsuspender"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type modelSuspenderSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *modelsuspender.API
+}
+
+var _ = gc.Suite(&modelSuspenderSuite{})
+
+func (s *modelSuspenderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		model: &mockModel{schedule: "Mon-Fri 20:00-07:00"},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Controller: true,
+		Tag:        names.NewMachineTag("0"),
+	}
+	api, err := modelsuspender.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *modelSuspenderSuite) TestAuthNonController(c *gc.C) {
+	s.authorizer.Controller = false
+	s.authorizer.Tag = names.NewUserTag("admin")
+	_, err := modelsuspender.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
+func (s *modelSuspenderSuite) TestWatchModelSuspension(c *gc.C) {
+	s.backend.model.watcher = newMockNotifyWatcher()
+	s.backend.model.configWatcher = newMockNotifyWatcher()
+	result, err := s.api.WatchModelSuspension()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	s.backend.model.CheckCallNames(c, "Watch", "WatchForModelConfigChanges")
+}
+
+func (s *modelSuspenderSuite) TestModelSuspension(c *gc.C) {
+	s.backend.model.suspendRequested = true
+	result, err := s.api.ModelSuspension()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelSuspensionResult{
+		Requested: true,
+		Schedule:  "Mon-Fri 20:00-07:00",
+	})
+}
+
+func (s *modelSuspenderSuite) TestSetModelSuspendRequested(c *gc.C) {
+	err := s.api.SetModelSuspendRequested(params.SetModelSuspendRequested{Requested: true})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.model.CheckCall(c, 0, "SetSuspendRequested", true)
+	c.Assert(s.backend.model.suspendRequested, jc.IsTrue)
+}
+
+func (s *modelSuspenderSuite) TestSuspensionPlan(c *gc.C) {
+	mysql0 := &mockUnit{name: "mysql/0"}
+	wordpress0 := &mockUnit{name: "wordpress/0", suspendStatus: model.UnitSuspended}
+	wordpress1 := &mockUnit{name: "wordpress/1"}
+	s.backend.relations = [][]state.Endpoint{{
+		{ApplicationName: "mysql", Relation: charm.Relation{Role: charm.RoleProvider}},
+		{ApplicationName: "wordpress", Relation: charm.Relation{Role: charm.RoleRequirer}},
+	}}
+	s.backend.machines = map[string]*mockMachine{
+		"0": {id: "0", isManager: true, instanceId: "i-0"},
+		"1": {
+			id: "1", instanceId: "i-1",
+			status: status.StatusInfo{Status: status.Started},
+			units:  []*mockUnit{mysql0},
+		},
+		"2": {
+			id: "2", instanceId: "i-2",
+			status:     status.StatusInfo{Status: status.Suspended},
+			units:      []*mockUnit{wordpress1},
+			containers: []string{"2/lxd/0"},
+		},
+		"2/lxd/0": {id: "2/lxd/0", instanceId: "c-0", units: []*mockUnit{wordpress0}},
+		"3":       {id: "3", instanceId: "i-3", isManual: true},
+		"4":       {id: "4"},
+	}
+	result, err := s.api.SuspensionPlan()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SuspensionPlanResult{
+		Stages: []params.SuspensionStage{{
+			Machines: []params.SuspensionMachine{{
+				Tag:        "machine-2",
+				InstanceId: "i-2",
+				Status:     "suspended",
+				Units: []params.SuspensionUnit{
+					{Tag: "unit-wordpress-0", SuspendStatus: model.UnitSuspended},
+					{Tag: "unit-wordpress-1"},
+				},
+			}},
+		}, {
+			Machines: []params.SuspensionMachine{{
+				Tag:        "machine-1",
+				InstanceId: "i-1",
+				Status:     "started",
+				Units:      []params.SuspensionUnit{{Tag: "unit-mysql-0"}},
+			}},
+		}},
+	})
+}
+
+func (s *modelSuspenderSuite) TestSetUnitSuspendStatus(c *gc.C) {
+	unit := &mockUnit{name: "mysql/0"}
+	s.backend.units = map[string]*mockUnit{"mysql/0": unit}
+	result, err := s.api.SetUnitSuspendStatus(params.UnitSuspendStatusParams{
+		Params: []params.UnitSuspendStatusParam{
+			{Entity: params.Entity{Tag: "unit-mysql-0"}, Status: model.UnitSuspending},
+			{Entity: params.Entity{Tag: "unit-mysql-1"}, Status: model.UnitSuspending},
+			{Entity: params.Entity{Tag: "machine-0"}, Status: model.UnitSuspending},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "unit mysql/1 not found")
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid unit tag`)
+	unit.CheckCall(c, 0, "SetSuspendStatus", model.UnitSuspending)
+}
+
+func (s *modelSuspenderSuite) TestSetMachineStatus(c *gc.C) {
+	machine := &mockMachine{id: "1", instanceId: "i-1"}
+	s.backend.machines = map[string]*mockMachine{"1": machine}
+	result, err := s.api.SetMachineStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "machine-1", Status: "suspended"},
+			{Tag: "machine-2", Status: "suspended"},
+			{Tag: "unit-mysql-0", Status: "suspended"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "machine 2 not found")
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid machine tag`)
+	machine.CheckCall(c, 0, "SetStatus", status.StatusInfo{Status: status.Suspended})
+}
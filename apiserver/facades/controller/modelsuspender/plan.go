@@ -0,0 +1,113 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender
+
+import (
+	"sort"
+
+	"github.com/juju/charm/v8"
+	"github.com/juju/naturalsort"
+
+	"github.com/juju/juju/state"
+)
+
+// applicationDependencies returns, for each application in the given
+// relations, the applications providing the endpoints it requires.
+// Peer relations don't order anything and are ignored.
+func applicationDependencies(relations [][]state.Endpoint) map[string][]string {
+	deps := make(map[string][]string)
+	for _, eps := range relations {
+		var providers, requirers []string
+		for _, ep := range eps {
+			switch ep.Role {
+			case charm.RoleProvider:
+				providers = append(providers, ep.ApplicationName)
+			case charm.RoleRequirer:
+				requirers = append(requirers, ep.ApplicationName)
+			}
+		}
+		for _, requirer := range requirers {
+			for _, provider := range providers {
+				if provider != requirer {
+					deps[requirer] = append(deps[requirer], provider)
+				}
+			}
+		}
+	}
+	return deps
+}
+
+// applicationRanks returns the length of the longest chain of providers
+// beneath each application; an application that requires nothing has
+// rank 0. Cycles are broken by ignoring the relation that closes them,
+// visiting applications in name order so the result is deterministic.
+func applicationRanks(deps map[string][]string) map[string]int {
+	ranks := make(map[string]int)
+	visiting := make(map[string]bool)
+	var visit func(app string) int
+	visit = func(app string) int {
+		if rank, ok := ranks[app]; ok {
+			return rank
+		}
+		visiting[app] = true
+		providers := append([]string(nil), deps[app]...)
+		sort.Strings(providers)
+		rank := 0
+		for _, provider := range providers {
+			if visiting[provider] {
+				continue
+			}
+			if r := visit(provider) + 1; r > rank {
+				rank = r
+			}
+		}
+		visiting[app] = false
+		ranks[app] = rank
+		return rank
+	}
+	apps := make([]string, 0, len(deps))
+	for app := range deps {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+	for _, app := range apps {
+		visit(app)
+	}
+	return ranks
+}
+
+// suspensionStages groups the given machines into the stages in which
+// they are suspended, given the applications hosted on each machine.
+// Applications are suspended before the applications they depend on, so
+// the machines hosting the applications with the highest rank go first.
+// A machine is kept running for as long as any application it hosts is
+// needed, so it takes the lowest rank of its applications. Resuming runs
+// through the stages in reverse.
+func suspensionStages(deps map[string][]string, machineApps map[string][]string) [][]string {
+	ranks := applicationRanks(deps)
+	byRank := make(map[int][]string)
+	for machineId, apps := range machineApps {
+		rank := -1
+		for _, app := range apps {
+			if r := ranks[app]; rank == -1 || r < rank {
+				rank = r
+			}
+		}
+		if rank == -1 {
+			rank = 0
+		}
+		byRank[rank] = append(byRank[rank], machineId)
+	}
+	rankOrder := make([]int, 0, len(byRank))
+	for rank := range byRank {
+		rankOrder = append(rankOrder, rank)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(rankOrder)))
+	stages := make([][]string, len(rankOrder))
+	for i, rank := range rankOrder {
+		naturalsort.Sort(byRank[rank])
+		stages[i] = byRank[rank]
+	}
+	return stages
+}
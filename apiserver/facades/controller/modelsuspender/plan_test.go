@@ -0,0 +1,113 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender_test
+
+import (
+	"github.com/juju/charm/v8"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/modelsuspender"
+	"github.com/juju/juju/state"
+)
+
+type planSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&planSuite{})
+
+func endpoint(app string, role charm.RelationRole) state.Endpoint {
+	return state.Endpoint{
+		ApplicationName: app,
+		Relation:        charm.Relation{Role: role},
+	}
+}
+
+// relation returns the endpoints of a relation in which requirer
+// requires an endpoint provided by provider.
+func relation(requirer, provider string) []state.Endpoint {
+	return []state.Endpoint{
+		endpoint(provider, charm.RoleProvider),
+		endpoint(requirer, charm.RoleRequirer),
+	}
+}
+
+func (*planSuite) TestApplicationDependencies(c *gc.C) {
+	deps := modelsuspender.ApplicationDependencies([][]state.Endpoint{
+		relation("wordpress", "mysql"),
+		relation("haproxy", "wordpress"),
+		{endpoint("mysql", charm.RolePeer)},
+	})
+	c.Assert(deps, jc.DeepEquals, map[string][]string{
+		"wordpress": {"mysql"},
+		"haproxy":   {"wordpress"},
+	})
+}
+
+func (*planSuite) TestSuspensionStages(c *gc.C) {
+	deps := modelsuspender.ApplicationDependencies([][]state.Endpoint{
+		relation("wordpress", "mysql"),
+		relation("haproxy", "wordpress"),
+		relation("wordpress", "memcached"),
+	})
+	stages := modelsuspender.SuspensionStages(deps, map[string][]string{
+		"0":  {"mysql"},
+		"1":  {"wordpress"},
+		"2":  {"haproxy"},
+		"3":  {"memcached"},
+		"10": {"wordpress"},
+		"4":  nil,
+	})
+	c.Assert(stages, jc.DeepEquals, [][]string{
+		{"2"},
+		{"1", "10"},
+		{"0", "3", "4"},
+	})
+}
+
+func (*planSuite) TestSuspensionStagesMachineTakesLowestRank(c *gc.C) {
+	deps := modelsuspender.ApplicationDependencies([][]state.Endpoint{
+		relation("wordpress", "mysql"),
+		relation("haproxy", "wordpress"),
+	})
+	stages := modelsuspender.SuspensionStages(deps, map[string][]string{
+		"0": {"haproxy", "mysql"},
+		"1": {"wordpress"},
+	})
+	c.Assert(stages, jc.DeepEquals, [][]string{
+		{"1"},
+		{"0"},
+	})
+}
+
+func (*planSuite) TestSuspensionStagesBreaksCycles(c *gc.C) {
+	deps := modelsuspender.ApplicationDependencies([][]state.Endpoint{
+		relation("a", "b"),
+		relation("b", "c"),
+		relation("c", "a"),
+	})
+	machineApps := map[string][]string{
+		"0": {"a"},
+		"1": {"b"},
+		"2": {"c"},
+	}
+	stages := modelsuspender.SuspensionStages(deps, machineApps)
+	// Visiting "a" first ignores the c->a relation that closes the
+	// cycle, leaving a->b->c.
+	c.Assert(stages, jc.DeepEquals, [][]string{
+		{"0"},
+		{"1"},
+		{"2"},
+	})
+	for i := 0; i < 5; i++ {
+		c.Assert(modelsuspender.SuspensionStages(deps, machineApps), jc.DeepEquals, stages)
+	}
+}
+
+func (*planSuite) TestSuspensionStagesEmpty(c *gc.C) {
+	stages := modelsuspender.SuspensionStages(nil, nil)
+	c.Assert(stages, gc.HasLen, 0)
+}
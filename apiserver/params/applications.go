@@ -54,6 +54,45 @@ type ApplicationDeploy struct {
 	AttachStorage    []string                       `json:"attach-storage,omitempty"`
 	EndpointBindings map[string]string              `json:"endpoint-bindings,omitempty"`
 	Resources        map[string]string              `json:"resources,omitempty"`
+
+	// Charm holds the content of a local charm that has not been
+	// added to the model. It is only used by ValidateDeploy.
+	Charm *DeployCharmContent `json:"charm,omitempty"`
+}
+
+// DeployCharmContent holds the content of the files of a local charm
+// needed to validate a deployment of it without adding it to the model.
+type DeployCharmContent struct {
+	Metadata   string `json:"metadata"`
+	Config     string `json:"config,omitempty"`
+	LXDProfile string `json:"lxd-profile,omitempty"`
+}
+
+// DeployCheck holds the outcome of one of the checks made when
+// validating a deployment.
+type DeployCheck struct {
+	// Name identifies what was checked, such as "storage" or
+	// "lxd-profile".
+	Name string `json:"name"`
+
+	// Error holds the reason the check failed, or nil if it passed.
+	Error *Error `json:"error,omitempty"`
+}
+
+// DeployValidationResult holds the checks made on an application
+// by the ValidateDeploy call.
+type DeployValidationResult struct {
+	ApplicationName string        `json:"application"`
+	Checks          []DeployCheck `json:"checks"`
+
+	// Error is set if the application could not be checked at all,
+	// such as when its charm has not been added to the model.
+	Error *Error `json:"error,omitempty"`
+}
+
+// DeployValidationResults holds the results of the ValidateDeploy call.
+type DeployValidationResults struct {
+	Results []DeployValidationResult `json:"results"`
 }
 
 // ApplicationsDeployV12 holds the parameters for deploying one or more
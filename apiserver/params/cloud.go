@@ -334,6 +334,14 @@ type InvalidateCredentialArg struct {
 	Reason string `json:"reason,omitempty"`
 }
 
+// UpdateModelCredentialArg holds the rotated content of the cloud
+// credential that a model uses.
+type UpdateModelCredentialArg struct {
+	// Credential replaces the content of the model's credential, which
+	// becomes valid.
+	Credential CloudCredential `json:"credential"`
+}
+
 // RevokeCredentialArg contains data needed to revoke credential.
 type RevokeCredentialArg struct {
 	// Tag holds credential tag to revoke.
@@ -798,6 +798,7 @@ type ProvisioningInfoBase struct {
 	ControllerConfig  map[string]interface{}   `json:"controller-config,omitempty"`
 	CloudInitUserData map[string]interface{}   `json:"cloudinit-userdata,omitempty"`
 	CharmLXDProfiles  []string                 `json:"charm-lxd-profiles,omitempty"`
+	ModelOwner        string                   `json:"model-owner,omitempty"`
 }
 
 // ProvisioningInfo holds machine provisioning info returned by
@@ -968,10 +969,12 @@ type ResourceUploadResult struct {
 // UnitRefreshResult is used to return the latest values for attributes
 // on a unit.
 type UnitRefreshResult struct {
-	Life       life.Value
-	Resolved   ResolvedMode
-	Error      *Error
-	ProviderID string `json:"provider-id,omitempty"`
+	Life            life.Value
+	Resolved        ResolvedMode
+	Error           *Error
+	ProviderID      string                  `json:"provider-id,omitempty"`
+	HookKillVersion int                     `json:"hook-kill-version,omitempty"`
+	SuspendStatus   model.UnitSuspendStatus `json:"suspend-status,omitempty"`
 }
 
 // UnitRefreshResults holds the results for any API call which ends
@@ -980,6 +983,28 @@ type UnitRefreshResults struct {
 	Results []UnitRefreshResult
 }
 
+// HookRecord holds the details of a single execution of a charm hook.
+type HookRecord struct {
+	HookName   string        `json:"hook-name"`
+	Relation   string        `json:"relation,omitempty"`
+	RemoteUnit string        `json:"remote-unit,omitempty"`
+	Started    time.Time     `json:"started"`
+	Duration   time.Duration `json:"duration"`
+	ExitCode   int           `json:"exit-code"`
+	Output     string        `json:"output,omitempty"`
+}
+
+// UnitHookRecord holds a hook execution record for a unit.
+type UnitHookRecord struct {
+	Tag    string     `json:"tag"`
+	Record HookRecord `json:"record"`
+}
+
+// UnitHookRecords holds the arguments for the RecordHookRuns call.
+type UnitHookRecords struct {
+	Records []UnitHookRecord `json:"records"`
+}
+
 // EntityString holds an entity tag and a string value.
 type EntityString struct {
 	Tag   string `json:"tag"`
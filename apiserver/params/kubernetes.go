@@ -4,6 +4,8 @@
 package params
 
 import (
+	"time"
+
 	"github.com/juju/version"
 
 	"github.com/juju/juju/core/constraints"
@@ -117,3 +119,121 @@ type KubernetesUpgradeArg struct {
 	AgentTag string         `json:"agent-tag"`
 	Version  version.Number `json:"version"`
 }
+
+// KubernetesEndpointIngress holds the sources allowed to reach an
+// application endpoint.
+type KubernetesEndpointIngress struct {
+	Applications []string `json:"applications,omitempty"`
+	CIDRs        []string `json:"cidrs,omitempty"`
+}
+
+// KubernetesNetworkPolicy holds the sources allowed to reach each endpoint
+// of an application, and whether the policy is enforced.
+type KubernetesNetworkPolicy struct {
+	Enabled   bool                                 `json:"enabled"`
+	Endpoints map[string]KubernetesEndpointIngress `json:"endpoints"`
+}
+
+// KubernetesNetworkPolicyResult holds a network policy or an error.
+type KubernetesNetworkPolicyResult struct {
+	Error  *Error                   `json:"error,omitempty"`
+	Result *KubernetesNetworkPolicy `json:"result,omitempty"`
+}
+
+// KubernetesNetworkPolicyResults holds multiple network policy results.
+type KubernetesNetworkPolicyResults struct {
+	Results []KubernetesNetworkPolicyResult `json:"results"`
+}
+
+// AdoptK8sWorkloadArg holds the parameters to adopt an existing
+// Kubernetes workload as the application of the same name.
+type AdoptK8sWorkloadArg struct {
+	Kind        string       `json:"kind"`
+	Name        string       `json:"name"`
+	CharmURL    string       `json:"charm-url"`
+	CharmOrigin *CharmOrigin `json:"charm-origin,omitempty"`
+	Channel     string       `json:"channel,omitempty"`
+}
+
+// AdoptK8sWorkloadArgs holds the arguments for the AdoptK8sWorkloads call.
+type AdoptK8sWorkloadArgs struct {
+	Args []AdoptK8sWorkloadArg `json:"args"`
+}
+
+// AdoptK8sWorkloadResult holds the names of the units created for the
+// pods of an adopted workload, or an error.
+type AdoptK8sWorkloadResult struct {
+	Units []string `json:"units,omitempty"`
+	Error *Error   `json:"error,omitempty"`
+}
+
+// AdoptK8sWorkloadResults holds the results of the AdoptK8sWorkloads call.
+type AdoptK8sWorkloadResults struct {
+	Results []AdoptK8sWorkloadResult `json:"results"`
+}
+
+// UnitWorkloadRequest holds the parameters to inspect the workload of a
+// unit in a container model.
+type UnitWorkloadRequest struct {
+	Tag      string `json:"tag"`
+	LogLines int    `json:"log-lines,omitempty"`
+}
+
+// UnitWorkloadRequests holds the arguments for the UnitsWorkload call.
+type UnitWorkloadRequests struct {
+	Requests []UnitWorkloadRequest `json:"requests"`
+}
+
+// KubernetesPodCondition holds the state of a pod condition.
+type KubernetesPodCondition struct {
+	Type          string    `json:"type"`
+	Status        string    `json:"status"`
+	Reason        string    `json:"reason,omitempty"`
+	Message       string    `json:"message,omitempty"`
+	LastUpdatedAt time.Time `json:"last-updated-at"`
+}
+
+// KubernetesContainerInfo holds the state and recent logs of a container
+// in a pod.
+type KubernetesContainerInfo struct {
+	Name         string   `json:"name"`
+	Image        string   `json:"image,omitempty"`
+	Init         bool     `json:"init,omitempty"`
+	Ready        bool     `json:"ready"`
+	RestartCount int32    `json:"restart-count"`
+	State        string   `json:"state,omitempty"`
+	Reason       string   `json:"reason,omitempty"`
+	Message      string   `json:"message,omitempty"`
+	Logs         []string `json:"logs,omitempty"`
+}
+
+// KubernetesEvent holds a cluster event recorded against a pod.
+type KubernetesEvent struct {
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason,omitempty"`
+	Message  string    `json:"message,omitempty"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"last-seen"`
+}
+
+// UnitWorkload holds the state of the pod running a unit.
+type UnitWorkload struct {
+	PodName    string                    `json:"pod-name"`
+	Phase      string                    `json:"phase"`
+	Message    string                    `json:"message,omitempty"`
+	Node       string                    `json:"node,omitempty"`
+	Conditions []KubernetesPodCondition  `json:"conditions,omitempty"`
+	Containers []KubernetesContainerInfo `json:"containers,omitempty"`
+	Events     []KubernetesEvent         `json:"events,omitempty"`
+}
+
+// UnitWorkloadResult holds the workload of a unit or an error.
+type UnitWorkloadResult struct {
+	Result *UnitWorkload `json:"result,omitempty"`
+	Error  *Error        `json:"error,omitempty"`
+}
+
+// UnitWorkloadResults holds the results of the UnitsWorkload call.
+type UnitWorkloadResults struct {
+	Results []UnitWorkloadResult `json:"results"`
+}
@@ -180,6 +180,20 @@ type ModelInfo struct {
 
 	// AgentVersion is the agent version for this model.
 	AgentVersion *version.Number `json:"agent-version"`
+
+	// ResourceQuota contains the limits on, and usage of, the compute
+	// resources of a container model. It'll be nil if there isn't a quota.
+	ResourceQuota *ModelResourceQuota `json:"resource-quota,omitempty"`
+}
+
+// ModelResourceQuota holds the limits on, and usage of, the compute
+// resources of a container model. CPU is in millicores and memory in MiB.
+// A zero limit means the resource isn't limited.
+type ModelResourceQuota struct {
+	CPULimit    uint64 `json:"cpu-limit,omitempty"`
+	CPUUsed     uint64 `json:"cpu-used"`
+	MemoryLimit uint64 `json:"memory-limit,omitempty"`
+	MemoryUsed  uint64 `json:"memory-used"`
 }
 
 // ModelSummary holds summary about a Juju model.
@@ -261,6 +261,10 @@ type AddMachineParams struct {
 	Nonce                   string                           `json:"nonce"`
 	HardwareCharacteristics instance.HardwareCharacteristics `json:"hardware-characteristics"`
 	Addrs                   []Address                        `json:"addresses"`
+
+	// SSHProxyJump optionally holds the "[user@]host" of the SSH jump
+	// host through which a manually provisioned machine is reached.
+	SSHProxyJump string `json:"ssh-proxy-jump,omitempty"`
 }
 
 // AddMachines holds the parameters for making the AddMachines call.
@@ -300,6 +304,41 @@ type DestroyMachinesParams struct {
 	MaxWait *time.Duration `json:"max-wait,omitempty"`
 }
 
+// ResizeMachinesParams holds parameters for the ResizeMachine call.
+type ResizeMachinesParams struct {
+	Machines []ResizeMachineParams `json:"machines"`
+}
+
+// ResizeMachineParams holds the machine to resize and the constraints
+// that override the machine's own when choosing its new hardware.
+type ResizeMachineParams struct {
+	MachineTag  string            `json:"machine-tag"`
+	Constraints constraints.Value `json:"constraints"`
+}
+
+// ResizeMachineResults holds the results of a ResizeMachine call.
+type ResizeMachineResults struct {
+	Results []ResizeMachineResult `json:"results"`
+}
+
+// ResizeMachineResult holds the hardware of a resized machine, or an error.
+type ResizeMachineResult struct {
+	HardwareCharacteristics *instance.HardwareCharacteristics `json:"hardware-characteristics,omitempty"`
+	Error                   *Error                            `json:"error,omitempty"`
+}
+
+// MigrateMachinesParams holds parameters for the MigrateMachine call.
+type MigrateMachinesParams struct {
+	Machines []MigrateMachineParams `json:"machines"`
+}
+
+// MigrateMachineParams holds the machine to migrate and the name of the
+// cluster member to move its instance to.
+type MigrateMachineParams struct {
+	MachineTag string `json:"machine-tag"`
+	Member     string `json:"member"`
+}
+
 // UpdateSeriesArg holds the parameters for updating the series for the
 // specified application or machine. For Application, only known by facade
 // version 5 and greater. For MachineManger, only known by facade version
@@ -420,6 +459,29 @@ type UnitsResolved struct {
 	All   bool     `json:"all,omitempty"`
 }
 
+// HookHistoryRequest holds the parameters to query the hook history
+// of a unit.
+type HookHistoryRequest struct {
+	Tag  string `json:"tag"`
+	Size int    `json:"size,omitempty"`
+}
+
+// HookHistoryRequests holds the arguments for the HookHistory call.
+type HookHistoryRequests struct {
+	Requests []HookHistoryRequest `json:"requests"`
+}
+
+// HookHistoryResult holds the hook history of a unit, newest first.
+type HookHistoryResult struct {
+	Records []HookRecord `json:"records,omitempty"`
+	Error   *Error       `json:"error,omitempty"`
+}
+
+// HookHistoryResults holds the results of the HookHistory call.
+type HookHistoryResults struct {
+	Results []HookHistoryResult `json:"results"`
+}
+
 // AddApplicationUnitsResults holds the names of the units added by the
 // AddUnits call.
 type AddApplicationUnitsResults struct {
@@ -1232,6 +1294,60 @@ func (e UpgradeSeriesValidationErrorInfo) AsMap() map[string]interface{} {
 	return serializeToMap(e)
 }
 
+// UnitSuspendStatusParams holds the suspend statuses to set on units.
+type UnitSuspendStatusParams struct {
+	Params []UnitSuspendStatusParam `json:"params"`
+}
+
+// UnitSuspendStatusParam holds a unit and its progress through the
+// suspension or resumption of its model.
+type UnitSuspendStatusParam struct {
+	Entity Entity                  `json:"entity"`
+	Status model.UnitSuspendStatus `json:"status"`
+}
+
+// ModelSuspensionResult holds whether the suspension of a model has
+// been requested, and the model's suspend schedule.
+type ModelSuspensionResult struct {
+	Requested bool   `json:"requested"`
+	Schedule  string `json:"schedule,omitempty"`
+	Error     *Error `json:"error,omitempty"`
+}
+
+// SetModelSuspendRequested holds whether a model's machines should be
+// suspended or resumed.
+type SetModelSuspendRequested struct {
+	Requested bool `json:"requested"`
+}
+
+// SuspensionPlanResult holds the stages in which a model's machines are
+// suspended. Resumption runs through the stages in reverse.
+type SuspensionPlanResult struct {
+	Stages []SuspensionStage `json:"stages"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// SuspensionStage holds machines that are suspended or resumed together.
+type SuspensionStage struct {
+	Machines []SuspensionMachine `json:"machines"`
+}
+
+// SuspensionMachine holds a machine to be suspended or resumed, and the
+// units hosted on it and its containers.
+type SuspensionMachine struct {
+	Tag        string           `json:"tag"`
+	InstanceId string           `json:"instance-id"`
+	Status     string           `json:"status"`
+	Units      []SuspensionUnit `json:"units,omitempty"`
+}
+
+// SuspensionUnit holds a unit and its progress through the suspension or
+// resumption of its model.
+type SuspensionUnit struct {
+	Tag           string                  `json:"tag"`
+	SuspendStatus model.UnitSuspendStatus `json:"suspend-status,omitempty"`
+}
+
 type ProfileArg struct {
 	Entity   Entity `json:"entity"`
 	UnitName string `json:"unit-name"`
@@ -153,6 +153,28 @@ type ServiceParams struct {
 	CharmModifiedVersion int
 }
 
+// NetworkPolicyParams defines the sources of traffic allowed to reach
+// each endpoint of an application.
+type NetworkPolicyParams struct {
+	// Enabled is true if the traffic reaching the application
+	// is to be restricted.
+	Enabled bool
+
+	// Endpoints is keyed on the application's endpoint names.
+	Endpoints map[string]EndpointIngress
+}
+
+// EndpointIngress holds the sources of traffic allowed to reach an
+// application endpoint.
+type EndpointIngress struct {
+	// Applications holds the names of the applications related
+	// on the endpoint.
+	Applications []string
+
+	// CIDRs holds the networks the endpoint is exposed to.
+	CIDRs []string
+}
+
 // OperatorState is returned by the OperatorExists call.
 type OperatorState struct {
 	// Exists is true if the operator exists in the cluster.
@@ -252,6 +274,15 @@ type Broker interface {
 
 	// ClusterVersionGetter provides methods to get cluster version information.
 	ClusterVersionGetter
+
+	// WorkloadInspector provides the API to inspect the workload of a unit.
+	WorkloadInspector
+
+	// WorkloadAdopter provides the API to adopt existing workloads.
+	WorkloadAdopter
+
+	// ResourceQuotaGetter provides the API to get the model's resource quota.
+	ResourceQuotaGetter
 }
 
 // Upgrader provides the API to perform upgrades.
@@ -272,6 +303,32 @@ type ClusterVersionGetter interface {
 	Version() (*version.Number, error)
 }
 
+// WorkloadInspector provides the API to inspect the workload of a unit.
+type WorkloadInspector interface {
+	// UnitWorkload returns the status, recent events and the last
+	// logLines lines of container logs of the pod (name or uid) running
+	// a unit of the specified application.
+	UnitWorkload(appName string, podName string, logLines int) (*UnitWorkload, error)
+}
+
+// WorkloadAdopter provides the API to take ownership of workloads which
+// were deployed to the cluster without Juju.
+type WorkloadAdopter interface {
+	// AdoptWorkload labels the workload of the specified kind and name,
+	// along with its running pods, as belonging to the application of
+	// the same name, and returns the pods as units. No pods are recreated.
+	AdoptWorkload(kind WorkloadKind, name string) ([]Unit, error)
+}
+
+// ResourceQuotaGetter provides the API to get the limits on the compute
+// resources a model may consume.
+type ResourceQuotaGetter interface {
+	// ResourceQuota returns the limits on, and current usage of, the
+	// compute resources of the model. It returns a NotFound error if the
+	// model has no quota.
+	ResourceQuota() (*ResourceQuota, error)
+}
+
 // ServiceGetterSetter provides the API to get/set service.
 type ServiceGetterSetter interface {
 	// EnsureService creates or updates a service for pods with the given params.
@@ -286,6 +343,10 @@ type ServiceGetterSetter interface {
 	// UnexposeService removes external access to the specified service.
 	UnexposeService(appName string) error
 
+	// EnsureNetworkPolicy restricts the traffic reaching the specified
+	// application's pods to the sources allowed by the params.
+	EnsureNetworkPolicy(appName string, params NetworkPolicyParams) error
+
 	// GetService returns the service for the specified application.
 	GetService(appName string, mode DeploymentMode, includeClusterIP bool) (*Service, error)
 }
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	jujuclock "github.com/juju/clock"
 	"github.com/juju/errors"
@@ -220,7 +221,18 @@ func credentialsFromConfig(config *clientcmdapi.Config, credentialName string) (
 		}
 
 		var authType cloud.AuthType
-		if hasClientKeyData {
+		if user.Exec != nil {
+			// auth type used for eks for example.
+			if err := execAttributes(user.Exec, attrs); err != nil {
+				return cred, errors.Annotatef(err, "exec plugin for %q", name)
+			}
+			authType = cloud.ExecAuthType
+		} else if user.AuthProvider != nil && user.AuthProvider.Name == "oidc" {
+			if err := oidcAttributes(user.AuthProvider.Config, attrs); err != nil {
+				return cred, errors.Annotatef(err, "oidc auth provider for %q", name)
+			}
+			authType = cloud.OIDCAuthType
+		} else if hasClientKeyData {
 			// auth type used for aks for example.
 			authType = cloud.OAuth2AuthType
 			if hasCert {
@@ -271,6 +283,60 @@ func credentialsFromConfig(config *clientcmdapi.Config, credentialName string) (
 	return rv, nil
 }
 
+func execAttributes(exec *clientcmdapi.ExecConfig, attrs map[string]string) error {
+	if exec.Command == "" {
+		return errors.NotValidf("missing command")
+	}
+	// The arguments and environment are stored space separated.
+	hasSpace := func(s string) bool {
+		return strings.ContainsAny(s, " \t\n")
+	}
+	var args, env []string
+	for _, arg := range exec.Args {
+		if hasSpace(arg) {
+			return errors.NotSupportedf("argument %q containing whitespace", arg)
+		}
+		args = append(args, arg)
+	}
+	for _, e := range exec.Env {
+		if hasSpace(e.Name) || hasSpace(e.Value) {
+			return errors.NotSupportedf("environment variable %q containing whitespace", e.Name)
+		}
+		env = append(env, e.Name+"="+e.Value)
+	}
+	attrs["ExecCommand"] = exec.Command
+	if len(args) > 0 {
+		attrs["ExecArgs"] = strings.Join(args, " ")
+	}
+	if len(env) > 0 {
+		attrs["ExecEnv"] = strings.Join(env, " ")
+	}
+	if exec.APIVersion != "" {
+		attrs["ExecAPIVersion"] = exec.APIVersion
+	}
+	return nil
+}
+
+func oidcAttributes(cfg map[string]string, attrs map[string]string) error {
+	for key, attr := range map[string]string{
+		"idp-issuer-url": "IssuerURL",
+		"client-id":      "ClientID",
+		"client-secret":  "ClientSecret",
+		"id-token":       "IDToken",
+		"refresh-token":  "RefreshToken",
+	} {
+		if v := cfg[key]; v != "" {
+			attrs[attr] = v
+		}
+	}
+	for _, attr := range []string{"IssuerURL", "ClientID", "IDToken"} {
+		if attrs[attr] == "" {
+			return errors.NotValidf("missing %s", attr)
+		}
+	}
+	return nil
+}
+
 // GetKubeConfigPath - define kubeconfig file path to use
 func GetKubeConfigPath() string {
 	kubeconfig := os.Getenv(clientcmd.RecommendedConfigPathEnvVar)
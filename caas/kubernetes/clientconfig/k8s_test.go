@@ -244,6 +244,33 @@ func (s *k8sConfigSuite) TestConfigErrors(c *gc.C) {
 `,
 			errMatch: `failed to read credentials from kubernetes config: configuration for "the-user" not supported`,
 		},
+		{
+			title: "execArgWithSpaceNotSupportedConfig",
+			configYamlContent: `
+- name: the-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: get-token
+      args:
+      - --name
+      - the cluster
+`,
+			errMatch: `failed to read credentials from kubernetes config: exec plugin for "the-user": argument "the cluster" containing whitespace not supported`,
+		},
+		{
+			title: "oidcMissingIDTokenInvalidConfig",
+			configYamlContent: `
+- name: the-user
+  user:
+    auth-provider:
+      config:
+        idp-issuer-url: https://issuer.example.com
+        client-id: juju
+      name: oidc
+`,
+			errMatch: `failed to read credentials from kubernetes config: oidc auth provider for "the-user": missing IDToken not valid`,
+		},
 	} {
 		v.configYamlFileName = v.title
 		v.configYamlContent = prefixConfigYAML + v.configYamlContent
@@ -251,6 +278,77 @@ func (s *k8sConfigSuite) TestConfigErrors(c *gc.C) {
 	}
 }
 
+func (s *k8sConfigSuite) assertSingleCredential(c *gc.C, title, userYAML string, cred cloud.Credential) {
+	s.assertNewK8sClientConfig(c, newK8sClientConfigTestCase{
+		title:              title,
+		configYamlContent:  prefixConfigYAML + userYAML,
+		configYamlFileName: title,
+		expected: &clientconfig.ClientConfig{
+			Type: "kubernetes",
+			Contexts: map[string]clientconfig.Context{
+				"the-context": {
+					CloudName:      "the-cluster",
+					CredentialName: "the-user"}},
+			CurrentContext: "the-context",
+			Clouds: map[string]clientconfig.CloudConfig{
+				"the-cluster": {
+					Endpoint:   "https://1.1.1.1:8888",
+					Attributes: map[string]interface{}{"CAData": "A"}}},
+			Credentials: map[string]cloud.Credential{
+				"the-user": cred,
+			},
+		},
+	})
+}
+
+func (s *k8sConfigSuite) TestGetExecConfig(c *gc.C) {
+	s.assertSingleCredential(c, "execConfig", `
+- name: the-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1alpha1
+      command: aws
+      args:
+      - eks
+      - get-token
+      - --cluster-name
+      - fred
+      env:
+      - name: AWS_PROFILE
+        value: juju
+`, cloud.NewNamedCredential(
+		"the-user", cloud.ExecAuthType,
+		map[string]string{
+			"ExecCommand":    "aws",
+			"ExecArgs":       "eks get-token --cluster-name fred",
+			"ExecEnv":        "AWS_PROFILE=juju",
+			"ExecAPIVersion": "client.authentication.k8s.io/v1alpha1",
+		}, false))
+}
+
+func (s *k8sConfigSuite) TestGetOIDCConfig(c *gc.C) {
+	s.assertSingleCredential(c, "oidcConfig", `
+- name: the-user
+  user:
+    auth-provider:
+      config:
+        idp-issuer-url: https://issuer.example.com
+        client-id: juju
+        client-secret: secret
+        id-token: id-token
+        refresh-token: refresh-token
+      name: oidc
+`, cloud.NewNamedCredential(
+		"the-user", cloud.OIDCAuthType,
+		map[string]string{
+			"IssuerURL":    "https://issuer.example.com",
+			"ClientID":     "juju",
+			"ClientSecret": "secret",
+			"IDToken":      "id-token",
+			"RefreshToken": "refresh-token",
+		}, false))
+}
+
 func (s *k8sConfigSuite) TestGetMultiConfig(c *gc.C) {
 	firstCred := cloud.NewNamedCredential(
 		"default-user", cloud.UserPassAuthType,
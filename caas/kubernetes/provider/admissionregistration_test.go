@@ -109,6 +109,8 @@ func (s *K8sBrokerSuite) assertMutatingWebhookConfigurations(c *gc.C, cfgs []k8s
 		s.mockStatefulSets.EXPECT().Update(gomock.Any(), statefulSetArg, metav1.UpdateOptions{}).
 			Return(nil, nil),
 	}...)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(assertCalls...)
 
 	params := &caas.ServiceParams{
@@ -400,6 +402,8 @@ func (s *K8sBrokerSuite) assertValidatingWebhookConfigurations(c *gc.C, cfgs []k
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, metav1.CreateOptions{}).
 			Return(nil, nil),
 	}...)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(assertCalls...)
 
 	params := &caas.ServiceParams{
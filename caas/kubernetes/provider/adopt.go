@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+// AdoptWorkload takes ownership of an existing deployment or stateful set
+// by adding the labels and annotations Juju puts on the resources of the
+// application with the same name. Running pods are labelled in place and
+// returned as units; the pod template isn't changed, so no pods are
+// recreated. The workload's selector, which can't be changed, is kept when
+// Juju later updates the workload.
+func (k *kubernetesClient) AdoptWorkload(kind caas.WorkloadKind, name string) ([]caas.Unit, error) {
+	if err := kind.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var selector *v1.LabelSelector
+	switch kind {
+	case caas.WorkloadDeployment:
+		api := k.client().AppsV1().Deployments(k.namespace)
+		deployment, err := api.Get(context.TODO(), name, v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil, errors.NotFoundf("deployment %q", name)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := k.adoptObject(&deployment.ObjectMeta, name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := api.Update(context.TODO(), deployment, v1.UpdateOptions{}); err != nil {
+			return nil, errors.Annotatef(err, "labelling deployment %q", name)
+		}
+		selector = deployment.Spec.Selector
+	case caas.WorkloadStatefulSet:
+		api := k.client().AppsV1().StatefulSets(k.namespace)
+		statefulSet, err := api.Get(context.TODO(), name, v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil, errors.NotFoundf("statefulset %q", name)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := k.adoptObject(&statefulSet.ObjectMeta, name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := api.Update(context.TODO(), statefulSet, v1.UpdateOptions{}); err != nil {
+			return nil, errors.Annotatef(err, "labelling statefulset %q", name)
+		}
+		selector = statefulSet.Spec.Selector
+	}
+
+	podSelector, err := v1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing selector of %s %q", kind, name)
+	}
+	pods := k.client().CoreV1().Pods(k.namespace)
+	podList, err := pods.List(context.TODO(), v1.ListOptions{
+		LabelSelector: podSelector.String(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var units []caas.Unit
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if err := k.adoptObject(&pod.ObjectMeta, name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := pods.Update(context.TODO(), &pod, v1.UpdateOptions{}); err != nil {
+			return nil, errors.Annotatef(err, "labelling pod %q", pod.Name)
+		}
+		units = append(units, caas.Unit{
+			Id:       providerID(&pod),
+			Address:  pod.Status.PodIP,
+			Ports:    podPorts(&pod),
+			Stateful: isStateful(&pod),
+		})
+	}
+	return units, nil
+}
+
+// adoptObject adds the labels and annotations of the specified application
+// to a resource. Resources which belong to a different application can't
+// be adopted.
+func (k *kubernetesClient) adoptObject(meta *v1.ObjectMeta, appName string) error {
+	if owner, ok := meta.Labels[constants.LabelApplication]; ok && owner != appName {
+		return errors.AlreadyExistsf("%q managed by application %q", meta.Name, owner)
+	}
+	meta.Labels = utils.AppendLabels(meta.Labels, utils.LabelsForApp(appName))
+	meta.Annotations = k8sannotations.New(meta.Annotations).Merge(k.annotations).ToMap()
+	return nil
+}
+
+// adoptedPodSelector returns the pod selector and pod template labels of
+// a workload being updated. The selector of a workload can't be changed,
+// so an adopted workload keeps its own selector, and its pods keep the
+// labels it selects as well as being given Juju's labels.
+func adoptedPodSelector(
+	existingSelector *v1.LabelSelector, existingLabels map[string]string,
+	selector *v1.LabelSelector, labels map[string]string,
+) (*v1.LabelSelector, map[string]string) {
+	if existingSelector == nil || reflect.DeepEqual(existingSelector, selector) {
+		return selector, labels
+	}
+	return existingSelector, utils.AppendLabels(nil, existingLabels, labels)
+}
+
+func podPorts(pod *core.Pod) []string {
+	var ports []string
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			ports = append(ports, fmt.Sprintf("%v/%v", p.ContainerPort, p.Protocol))
+		}
+	}
+	return ports
+}
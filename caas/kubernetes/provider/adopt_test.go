@@ -0,0 +1,218 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/testing"
+)
+
+type adoptSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&adoptSuite{})
+
+func (s *adoptSuite) TestAdoptedPodSelector(c *gc.C) {
+	helmSelector := &v1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "mariadb"}}
+	helmLabels := map[string]string{"app.kubernetes.io/name": "mariadb", "app.kubernetes.io/instance": "db"}
+	jujuSelector := &v1.LabelSelector{MatchLabels: map[string]string{"juju-app": "mariadb"}}
+	jujuLabels := map[string]string{"juju-app": "mariadb"}
+
+	selector, labels := provider.AdoptedPodSelector(helmSelector, helmLabels, jujuSelector, jujuLabels)
+	c.Assert(selector, jc.DeepEquals, helmSelector)
+	c.Assert(labels, jc.DeepEquals, map[string]string{
+		"app.kubernetes.io/name":     "mariadb",
+		"app.kubernetes.io/instance": "db",
+		"juju-app":                   "mariadb",
+	})
+	// The labels of the existing workload aren't changed.
+	c.Assert(helmLabels, gc.HasLen, 2)
+}
+
+func (s *adoptSuite) TestAdoptedPodSelectorNotAdopted(c *gc.C) {
+	jujuSelector := &v1.LabelSelector{MatchLabels: map[string]string{"juju-app": "mariadb"}}
+	jujuLabels := map[string]string{"juju-app": "mariadb"}
+	existingLabels := map[string]string{"juju-app": "mariadb", "foo": "bar"}
+
+	selector, labels := provider.AdoptedPodSelector(jujuSelector, existingLabels, jujuSelector, jujuLabels)
+	c.Assert(selector, jc.DeepEquals, jujuSelector)
+	c.Assert(labels, jc.DeepEquals, jujuLabels)
+
+	selector, labels = provider.AdoptedPodSelector(nil, nil, jujuSelector, jujuLabels)
+	c.Assert(selector, jc.DeepEquals, jujuSelector)
+	c.Assert(labels, jc.DeepEquals, jujuLabels)
+}
+
+func adoptedMeta(name string, labels map[string]string) v1.ObjectMeta {
+	return v1.ObjectMeta{
+		Name:   name,
+		Labels: labels,
+		Annotations: map[string]string{
+			"meta.helm.sh/release-name": "mariadb",
+			"juju.io/model":             testing.ModelTag.Id(),
+			"juju.io/controller":        testing.ControllerTag.Id(),
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestAdoptWorkloadDeployment(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	helmLabels := map[string]string{"app.kubernetes.io/name": "mariadb"}
+	deployment := &apps.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "mariadb",
+			Labels:      map[string]string{"app.kubernetes.io/name": "mariadb"},
+			Annotations: map[string]string{"meta.helm.sh/release-name": "mariadb"},
+		},
+		Spec: apps.DeploymentSpec{
+			Selector: &v1.LabelSelector{MatchLabels: helmLabels},
+		},
+	}
+	pod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "mariadb-5d8f9",
+			UID:         types.UID("uuid"),
+			Labels:      map[string]string{"app.kubernetes.io/name": "mariadb"},
+			Annotations: map[string]string{"meta.helm.sh/release-name": "mariadb"},
+		},
+		Spec: core.PodSpec{
+			Containers: []core.Container{{
+				Ports: []core.ContainerPort{{ContainerPort: 3306, Protocol: core.ProtocolTCP}},
+			}},
+		},
+		Status: core.PodStatus{PodIP: "10.1.1.1"},
+	}
+	terminating := core.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "mariadb-x7k2p", DeletionTimestamp: &v1.Time{}},
+	}
+
+	adoptedLabels := map[string]string{"app.kubernetes.io/name": "mariadb", "juju-app": "mariadb"}
+	adoptedDeployment := &apps.Deployment{
+		ObjectMeta: adoptedMeta("mariadb", adoptedLabels),
+		Spec:       deployment.Spec,
+	}
+	adoptedPod := pod
+	adoptedPod.ObjectMeta = adoptedMeta("mariadb-5d8f9", adoptedLabels)
+	adoptedPod.UID = types.UID("uuid")
+
+	gomock.InOrder(
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "mariadb", v1.GetOptions{}).
+			Return(deployment, nil),
+		s.mockDeployments.EXPECT().Update(gomock.Any(), adoptedDeployment, v1.UpdateOptions{}).
+			Return(adoptedDeployment, nil),
+		s.mockPods.EXPECT().List(gomock.Any(), v1.ListOptions{LabelSelector: "app.kubernetes.io/name=mariadb"}).
+			Return(&core.PodList{Items: []core.Pod{pod, terminating}}, nil),
+		s.mockPods.EXPECT().Update(gomock.Any(), &adoptedPod, v1.UpdateOptions{}).
+			Return(&adoptedPod, nil),
+	)
+
+	units, err := s.broker.AdoptWorkload(caas.WorkloadDeployment, "mariadb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []caas.Unit{{
+		Id:      "uuid",
+		Address: "10.1.1.1",
+		Ports:   []string{"3306/TCP"},
+	}})
+}
+
+func (s *K8sBrokerSuite) TestAdoptWorkloadStatefulSet(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	selector := map[string]string{"app": "mariadb"}
+	statefulSet := &apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{Name: "mariadb"},
+		Spec: apps.StatefulSetSpec{
+			Selector: &v1.LabelSelector{MatchLabels: selector},
+		},
+	}
+	pod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:            "mariadb-0",
+			Labels:          map[string]string{"app": "mariadb", "juju-app": "mariadb"},
+			OwnerReferences: []v1.OwnerReference{{Kind: "StatefulSet", Name: "mariadb"}},
+		},
+	}
+	jujuAnnotations := map[string]string{
+		"juju.io/model":      testing.ModelTag.Id(),
+		"juju.io/controller": testing.ControllerTag.Id(),
+	}
+	adoptedStatefulSet := &apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "mariadb",
+			Labels:      map[string]string{"juju-app": "mariadb"},
+			Annotations: jujuAnnotations,
+		},
+		Spec: statefulSet.Spec,
+	}
+	// The pod is already labelled, from an earlier attempt.
+	adoptedPod := pod
+	adoptedPod.Annotations = jujuAnnotations
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "mariadb", v1.GetOptions{}).
+			Return(statefulSet, nil),
+		s.mockStatefulSets.EXPECT().Update(gomock.Any(), adoptedStatefulSet, v1.UpdateOptions{}).
+			Return(adoptedStatefulSet, nil),
+		s.mockPods.EXPECT().List(gomock.Any(), v1.ListOptions{LabelSelector: "app=mariadb"}).
+			Return(&core.PodList{Items: []core.Pod{pod}}, nil),
+		s.mockPods.EXPECT().Update(gomock.Any(), &adoptedPod, v1.UpdateOptions{}).
+			Return(&adoptedPod, nil),
+	)
+
+	units, err := s.broker.AdoptWorkload(caas.WorkloadStatefulSet, "mariadb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []caas.Unit{{
+		Id:       "mariadb-0",
+		Stateful: true,
+	}})
+}
+
+func (s *K8sBrokerSuite) TestAdoptWorkloadOwnedByAnotherApplication(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	deployment := &apps.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "mariadb",
+			Labels: map[string]string{"juju-app": "mysql"},
+		},
+	}
+	s.mockDeployments.EXPECT().Get(gomock.Any(), "mariadb", v1.GetOptions{}).
+		Return(deployment, nil)
+
+	_, err := s.broker.AdoptWorkload(caas.WorkloadDeployment, "mariadb")
+	c.Assert(err, gc.ErrorMatches, `"mariadb" managed by application "mysql" already exists`)
+}
+
+func (s *K8sBrokerSuite) TestAdoptWorkloadNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockStatefulSets.EXPECT().Get(gomock.Any(), "mariadb", v1.GetOptions{}).
+		Return(nil, s.k8sNotFoundError())
+
+	_, err := s.broker.AdoptWorkload(caas.WorkloadStatefulSet, "mariadb")
+	c.Assert(err, gc.ErrorMatches, `statefulset "mariadb" not found`)
+}
+
+func (s *K8sBrokerSuite) TestAdoptWorkloadInvalidKind(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	_, err := s.broker.AdoptWorkload("daemonset", "mariadb")
+	c.Assert(err, gc.ErrorMatches, `workload kind "daemonset" not valid`)
+}
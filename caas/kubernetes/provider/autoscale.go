@@ -0,0 +1,187 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	autoscaling "k8s.io/api/autoscaling/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/core/application"
+)
+
+const (
+	defaultAutoscaleMinUnits  = 1
+	defaultAutoscaleCPUTarget = 80
+)
+
+// AutoscaleConfig holds the horizontal pod autoscaler settings for an
+// application.
+type AutoscaleConfig struct {
+	MinUnits  int
+	MaxUnits  int
+	CPUTarget int
+}
+
+// Enabled returns true if autoscaling is turned on.
+func (c AutoscaleConfig) Enabled() bool {
+	return c.MaxUnits > 0
+}
+
+// Validate returns an error if the autoscale config is not valid.
+func (c AutoscaleConfig) Validate() error {
+	if c.MaxUnits < 0 {
+		return errors.NotValidf("%s %d", AutoscaleMaxUnitsKey, c.MaxUnits)
+	}
+	if !c.Enabled() {
+		return nil
+	}
+	if c.MinUnits < 1 {
+		return errors.NewNotValid(nil, AutoscaleMinUnitsKey+" must be at least 1")
+	}
+	if c.MinUnits > c.MaxUnits {
+		return errors.NewNotValid(nil, AutoscaleMinUnitsKey+" must not be greater than "+AutoscaleMaxUnitsKey)
+	}
+	if c.CPUTarget < 1 || c.CPUTarget > 100 {
+		return errors.NewNotValid(nil, AutoscaleCPUTargetKey+" must be between 1 and 100")
+	}
+	return nil
+}
+
+// ParseAutoscaleConfig returns the autoscale settings held in the
+// application config. It returns nil if autoscaling has never been
+// configured for the application.
+func ParseAutoscaleConfig(config application.ConfigAttributes) (*AutoscaleConfig, error) {
+	maxUnits, ok, err := configInt(config, AutoscaleMaxUnitsKey)
+	if err != nil || !ok {
+		return nil, errors.Trace(err)
+	}
+	result := &AutoscaleConfig{
+		MinUnits:  defaultAutoscaleMinUnits,
+		MaxUnits:  maxUnits,
+		CPUTarget: defaultAutoscaleCPUTarget,
+	}
+	if v, ok, err := configInt(config, AutoscaleMinUnitsKey); err != nil {
+		return nil, errors.Trace(err)
+	} else if ok {
+		result.MinUnits = v
+	}
+	if v, ok, err := configInt(config, AutoscaleCPUTargetKey); err != nil {
+		return nil, errors.Trace(err)
+	} else if ok {
+		result.CPUTarget = v
+	}
+	if err := result.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// configInt returns the integer value of the named config attribute, and
+// whether it was set. Values that have been through a schema coercion are
+// int64, whereas values read back from state may be float64.
+func configInt(config application.ConfigAttributes, name string) (int, bool, error) {
+	v, ok := config[name]
+	if !ok || v == nil {
+		return 0, false, nil
+	}
+	switch v := v.(type) {
+	case int:
+		return v, true, nil
+	case int64:
+		return int(v), true, nil
+	case float64:
+		return int(v), true, nil
+	}
+	return 0, false, errors.NotValidf("%s value %v", name, v)
+}
+
+func (k *kubernetesClient) horizontalPodAutoscalerSpec(
+	appName, deploymentName string, deploymentType caas.DeploymentType, cfg AutoscaleConfig,
+) *autoscaling.HorizontalPodAutoscaler {
+	kind := "Deployment"
+	if deploymentType == caas.DeploymentStateful {
+		kind = "StatefulSet"
+	}
+	minReplicas := int32(cfg.MinUnits)
+	cpuTarget := int32(cfg.CPUTarget)
+	return &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName,
+			Labels: utils.LabelsForApp(appName),
+		},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       deploymentName,
+			},
+			MinReplicas:                    &minReplicas,
+			MaxReplicas:                    int32(cfg.MaxUnits),
+			TargetCPUUtilizationPercentage: &cpuTarget,
+		},
+	}
+}
+
+func (k *kubernetesClient) ensureHorizontalPodAutoscaler(spec *autoscaling.HorizontalPodAutoscaler) error {
+	api := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace)
+	_, err := api.Update(context.TODO(), spec, v1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(context.TODO(), spec, v1.CreateOptions{})
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) getHorizontalPodAutoscaler(name string) (*autoscaling.HorizontalPodAutoscaler, error) {
+	out, err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Get(context.TODO(), name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("horizontal pod autoscaler %q", name)
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteHorizontalPodAutoscaler(name string) error {
+	err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Delete(context.TODO(), name, v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteHorizontalPodAutoscalers(appName string) error {
+	err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).DeleteCollection(context.TODO(), v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: utils.LabelSetToSelector(utils.LabelsForApp(appName)).String(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// autoscaledReplicas returns the number of replicas the autoscaler for the
+// workload currently wants, so that updating the workload does not undo its
+// scaling decisions. If there is no autoscaler yet, numUnits is returned.
+func (k *kubernetesClient) autoscaledReplicas(deploymentName string, numUnits int) (int32, error) {
+	hpa, err := k.getHorizontalPodAutoscaler(deploymentName)
+	if errors.IsNotFound(err) {
+		return int32(numUnits), nil
+	}
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if hpa.Status.DesiredReplicas > 0 {
+		return hpa.Status.DesiredReplicas, nil
+	}
+	return int32(numUnits), nil
+}
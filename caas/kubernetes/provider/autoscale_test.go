@@ -0,0 +1,324 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing"
+)
+
+type autoscaleConfigSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&autoscaleConfigSuite{})
+
+func (s *autoscaleConfigSuite) TestParseAutoscaleConfigNotSet(c *gc.C) {
+	cfg, err := provider.ParseAutoscaleConfig(application.ConfigAttributes{
+		provider.AutoscaleMinUnitsKey: int64(2),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, gc.IsNil)
+}
+
+func (s *autoscaleConfigSuite) TestParseAutoscaleConfigDefaults(c *gc.C) {
+	cfg, err := provider.ParseAutoscaleConfig(application.ConfigAttributes{
+		provider.AutoscaleMaxUnitsKey: int64(5),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, &provider.AutoscaleConfig{MinUnits: 1, MaxUnits: 5, CPUTarget: 80})
+	c.Assert(cfg.Enabled(), jc.IsTrue)
+}
+
+func (s *autoscaleConfigSuite) TestParseAutoscaleConfig(c *gc.C) {
+	cfg, err := provider.ParseAutoscaleConfig(application.ConfigAttributes{
+		provider.AutoscaleMinUnitsKey:  2,
+		provider.AutoscaleMaxUnitsKey:  float64(10),
+		provider.AutoscaleCPUTargetKey: int64(50),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, &provider.AutoscaleConfig{MinUnits: 2, MaxUnits: 10, CPUTarget: 50})
+}
+
+func (s *autoscaleConfigSuite) TestParseAutoscaleConfigDisabled(c *gc.C) {
+	cfg, err := provider.ParseAutoscaleConfig(application.ConfigAttributes{
+		provider.AutoscaleMinUnitsKey: int64(20),
+		provider.AutoscaleMaxUnitsKey: int64(0),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Enabled(), jc.IsFalse)
+}
+
+func (s *autoscaleConfigSuite) TestParseAutoscaleConfigInvalid(c *gc.C) {
+	for i, t := range []struct {
+		config application.ConfigAttributes
+		err    string
+	}{{
+		config: application.ConfigAttributes{provider.AutoscaleMaxUnitsKey: int64(-1)},
+		err:    "kubernetes-autoscale-max-units -1 not valid",
+	}, {
+		config: application.ConfigAttributes{provider.AutoscaleMaxUnitsKey: int64(2), provider.AutoscaleMinUnitsKey: int64(0)},
+		err:    "kubernetes-autoscale-min-units must be at least 1",
+	}, {
+		config: application.ConfigAttributes{provider.AutoscaleMaxUnitsKey: int64(2), provider.AutoscaleMinUnitsKey: int64(3)},
+		err:    "kubernetes-autoscale-min-units must not be greater than kubernetes-autoscale-max-units",
+	}, {
+		config: application.ConfigAttributes{provider.AutoscaleMaxUnitsKey: int64(2), provider.AutoscaleCPUTargetKey: int64(101)},
+		err:    "kubernetes-autoscale-cpu-target must be between 1 and 100",
+	}, {
+		config: application.ConfigAttributes{provider.AutoscaleMaxUnitsKey: "lots"},
+		err:    "kubernetes-autoscale-max-units value lots not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := provider.ParseAutoscaleConfig(t.config)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *K8sBrokerSuite) autoscaleDeploymentArg(c *gc.C, replicas int32) *appsv1.Deployment {
+	workloadSpec, err := provider.PrepareWorkloadSpec("app-name", "app-name", getBasicPodspec(), "operator/image-path")
+	c.Assert(err, jc.ErrorIsNil)
+	return &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju.io/controller":             testing.ControllerTag.Id(),
+				"juju-app-uuid":                  "appuuid",
+				"juju.io/charm-modified-version": "0",
+			}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			RevisionHistoryLimit: int32Ptr(0),
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "app-name-",
+					Labels: map[string]string{
+						"juju-app": "app-name",
+					},
+					Annotations: map[string]string{
+						"apparmor.security.beta.kubernetes.io/pod": "runtime/default",
+						"seccomp.security.beta.kubernetes.io/pod":  "docker/default",
+						"juju.io/controller":                       testing.ControllerTag.Id(),
+						"juju.io/charm-modified-version":           "0",
+					},
+				},
+				Spec: provider.Pod(workloadSpec).PodSpec,
+			},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) autoscaleServiceArg() *core.Service {
+	return &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju.io/controller": testing.ControllerTag.Id(),
+			}},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"juju-app": "app-name"},
+			Type:     "ClusterIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP", Name: "fred"},
+			},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) ensureAutoscaledService(c *gc.C, config application.ConfigAttributes) error {
+	params := &caas.ServiceParams{
+		PodSpec:           getBasicPodspec(),
+		OperatorImagePath: "operator/image-path",
+		ResourceTags: map[string]string{
+			"juju-controller-uuid": testing.ControllerTag.Id(),
+		},
+	}
+	return s.broker.EnsureService("app-name", func(_ string, _ status.Status, _ string, _ map[string]interface{}) error { return nil }, params, 2, config)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceCreatesHorizontalPodAutoscaler(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	deploymentArg := s.autoscaleDeploymentArg(c, 2)
+	serviceArg := s.autoscaleServiceArg()
+	minReplicas, cpuTarget := int32(2), int32(60)
+	hpaArg := &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+		},
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "app-name",
+			},
+			MinReplicas:                    &minReplicas,
+			MaxReplicas:                    5,
+			TargetCPUUtilizationPercentage: &cpuTarget,
+		},
+	}
+
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(gomock.Any(), ociImageSecret, v1.CreateOptions{}).
+			Return(ociImageSecret, nil),
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any(), serviceArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(gomock.Any(), serviceArg, v1.CreateOptions{}).
+			Return(nil, nil),
+		s.mockHorizontalPodScalers.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(gomock.Any(), deploymentArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
+			Return(nil, nil),
+		s.mockHorizontalPodScalers.EXPECT().Update(gomock.Any(), hpaArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockHorizontalPodScalers.EXPECT().Create(gomock.Any(), hpaArg, v1.CreateOptions{}).
+			Return(hpaArg, nil),
+	)
+
+	err := s.ensureAutoscaledService(c, application.ConfigAttributes{
+		provider.AutoscaleMinUnitsKey:  int64(2),
+		provider.AutoscaleMaxUnitsKey:  int64(5),
+		provider.AutoscaleCPUTargetKey: int64(60),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceKeepsAutoscaledReplicas(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	// The autoscaler has scaled the workload to 4 pods; Juju still has 2
+	// units recorded, which must not undo the autoscaler's decision.
+	deploymentArg := s.autoscaleDeploymentArg(c, 4)
+	serviceArg := s.autoscaleServiceArg()
+	existing := &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Status:     autoscalingv1.HorizontalPodAutoscalerStatus{DesiredReplicas: 4},
+	}
+
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(gomock.Any(), ociImageSecret, v1.CreateOptions{}).
+			Return(ociImageSecret, nil),
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any(), serviceArg, v1.UpdateOptions{}).
+			Return(serviceArg, nil),
+		s.mockHorizontalPodScalers.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(existing, nil),
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(gomock.Any(), deploymentArg, v1.UpdateOptions{}).
+			Return(deploymentArg, nil),
+		s.mockHorizontalPodScalers.EXPECT().Update(gomock.Any(), gomock.Any(), v1.UpdateOptions{}).
+			Return(existing, nil),
+	)
+
+	err := s.ensureAutoscaledService(c, application.ConfigAttributes{
+		provider.AutoscaleMaxUnitsKey: int64(5),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceDisablesAutoscaling(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	deploymentArg := s.autoscaleDeploymentArg(c, 2)
+	serviceArg := s.autoscaleServiceArg()
+
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(gomock.Any(), ociImageSecret, v1.CreateOptions{}).
+			Return(ociImageSecret, nil),
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any(), serviceArg, v1.UpdateOptions{}).
+			Return(serviceArg, nil),
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(gomock.Any(), deploymentArg, v1.UpdateOptions{}).
+			Return(deploymentArg, nil),
+		s.mockHorizontalPodScalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.ensureAutoscaledService(c, application.ConfigAttributes{
+		provider.AutoscaleMaxUnitsKey: int64(0),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNoUnitsDeletesHorizontalPodAutoscaler(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	two := int32(2)
+	dc := &appsv1.Deployment{ObjectMeta: v1.ObjectMeta{Name: "app-name"}, Spec: appsv1.DeploymentSpec{Replicas: &two}}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockHorizontalPodScalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(dc, nil),
+		s.mockDeployments.EXPECT().Update(gomock.Any(), gomock.Any(), v1.UpdateOptions{}).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: getBasicPodspec(),
+	}
+	err := s.broker.EnsureService("app-name", func(_ string, _ status.Status, _ string, _ map[string]interface{}) error { return nil }, params, 0, application.ConfigAttributes{
+		provider.AutoscaleMaxUnitsKey: int64(5),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,159 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/caas/specs"
+	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/constraints"
+)
+
+const (
+	// zoneTopologyKey is the node label used for the zones constraint.
+	zoneTopologyKey = "failure-domain.beta.kubernetes.io/zone"
+	hostTopologyKey = "kubernetes.io/hostname"
+
+	// antiAffinityWeight is the weight given to preferred pod anti-affinity.
+	antiAffinityWeight = 100
+)
+
+func topologyKey(scope specs.TopologyScope) string {
+	if scope == specs.HostScope {
+		return hostTopologyKey
+	}
+	return zoneTopologyKey
+}
+
+// processAvailability adds the topology spread constraints and pod
+// anti-affinity asked for in the availability spec to the pod spec.
+// A zones constraint spanning more than one zone implies a preference for
+// spreading the pods across those zones, unless the spec says otherwise.
+func processAvailability(pod *core.PodSpec, appName string, spec *specs.AvailabilitySpec, cons constraints.Value) {
+	if spec == nil {
+		return
+	}
+	selector := &v1.LabelSelector{MatchLabels: utils.LabelsForApp(appName)}
+
+	spreads := spec.TopologySpread
+	if cons.HasZones() && len(*cons.Zones) > 1 && !hasTopologySpread(spreads, specs.ZoneScope) {
+		spreads = append([]specs.TopologySpreadSpec{{Scope: specs.ZoneScope}}, spreads...)
+	}
+	for _, spread := range spreads {
+		maxSkew := spread.MaxSkew
+		if maxSkew == 0 {
+			maxSkew = 1
+		}
+		whenUnsatisfiable := core.ScheduleAnyway
+		if spread.Required {
+			whenUnsatisfiable = core.DoNotSchedule
+		}
+		pod.TopologySpreadConstraints = append(pod.TopologySpreadConstraints, core.TopologySpreadConstraint{
+			MaxSkew:           maxSkew,
+			TopologyKey:       topologyKey(spread.Scope),
+			WhenUnsatisfiable: whenUnsatisfiable,
+			LabelSelector:     selector,
+		})
+	}
+
+	if spec.AntiAffinity == nil {
+		return
+	}
+	term := core.PodAffinityTerm{
+		LabelSelector: selector,
+		TopologyKey:   topologyKey(spec.AntiAffinity.Scope),
+	}
+	antiAffinity := &core.PodAntiAffinity{}
+	if spec.AntiAffinity.Required {
+		antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = []core.PodAffinityTerm{term}
+	} else {
+		antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = []core.WeightedPodAffinityTerm{{
+			Weight:          antiAffinityWeight,
+			PodAffinityTerm: term,
+		}}
+	}
+	if pod.Affinity == nil {
+		pod.Affinity = &core.Affinity{}
+	}
+	pod.Affinity.PodAntiAffinity = antiAffinity
+}
+
+func hasTopologySpread(spreads []specs.TopologySpreadSpec, scope specs.TopologyScope) bool {
+	for _, spread := range spreads {
+		if spread.Scope == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *kubernetesClient) ensurePodDisruptionBudget(
+	appName, deploymentName string, annotations k8sannotations.Annotation, spec specs.DisruptionBudgetSpec,
+) (cleanUps []func(), err error) {
+	pdb := &policy.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      utils.LabelsForApp(appName),
+			Annotations: annotations,
+		},
+		Spec: policy.PodDisruptionBudgetSpec{
+			Selector: &v1.LabelSelector{MatchLabels: utils.LabelsForApp(appName)},
+		},
+	}
+	if spec.MinAvailable != nil {
+		pdb.Spec.MinAvailable = k8sspecs.IntOrStringToK8s(*spec.MinAvailable)
+	}
+	if spec.MaxUnavailable != nil {
+		pdb.Spec.MaxUnavailable = k8sspecs.IntOrStringToK8s(*spec.MaxUnavailable)
+	}
+
+	api := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace)
+	out, err := api.Create(context.TODO(), pdb, v1.CreateOptions{})
+	if err == nil {
+		cleanUps = append(cleanUps, func() { _ = k.deletePodDisruptionBudget(out.GetName(), out.GetUID()) })
+		return cleanUps, nil
+	}
+	if !k8serrors.IsAlreadyExists(err) {
+		return cleanUps, errors.Trace(err)
+	}
+	existing, err := api.Get(context.TODO(), pdb.GetName(), v1.GetOptions{})
+	if err != nil {
+		return cleanUps, errors.Trace(err)
+	}
+	// The resource version is needed to update a pod disruption budget.
+	pdb.SetResourceVersion(existing.GetResourceVersion())
+	_, err = api.Update(context.TODO(), pdb, v1.UpdateOptions{})
+	return cleanUps, errors.Trace(err)
+}
+
+func (k *kubernetesClient) deletePodDisruptionBudget(name string, uid k8stypes.UID) error {
+	err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Delete(context.TODO(), name, utils.NewPreconditionDeleteOptions(uid))
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deletePodDisruptionBudgets(appName string) error {
+	err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).DeleteCollection(context.TODO(), v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: utils.LabelSetToSelector(utils.LabelsForApp(appName)).String(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
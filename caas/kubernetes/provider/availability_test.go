@@ -0,0 +1,280 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/caas/specs"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing"
+)
+
+type availabilitySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&availabilitySuite{})
+
+var appSelector = &v1.LabelSelector{MatchLabels: map[string]string{"juju-app": "app-name"}}
+
+func (s *availabilitySuite) TestProcessAvailabilityNoSpec(c *gc.C) {
+	var pod core.PodSpec
+	provider.ProcessAvailability(&pod, "app-name", nil, constraints.MustParse("zones=a,b"))
+	c.Assert(pod, jc.DeepEquals, core.PodSpec{})
+}
+
+func (s *availabilitySuite) TestProcessAvailability(c *gc.C) {
+	var pod core.PodSpec
+	provider.ProcessAvailability(&pod, "app-name", &specs.AvailabilitySpec{
+		TopologySpread: []specs.TopologySpreadSpec{
+			{Scope: specs.ZoneScope, Required: true},
+			{Scope: specs.HostScope, MaxSkew: 2},
+		},
+		AntiAffinity: &specs.AntiAffinitySpec{Scope: specs.HostScope, Required: true},
+	}, constraints.Value{})
+	c.Assert(pod.TopologySpreadConstraints, jc.DeepEquals, []core.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "failure-domain.beta.kubernetes.io/zone",
+		WhenUnsatisfiable: core.DoNotSchedule,
+		LabelSelector:     appSelector,
+	}, {
+		MaxSkew:           2,
+		TopologyKey:       "kubernetes.io/hostname",
+		WhenUnsatisfiable: core.ScheduleAnyway,
+		LabelSelector:     appSelector,
+	}})
+	c.Assert(pod.Affinity, jc.DeepEquals, &core.Affinity{
+		PodAntiAffinity: &core.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{{
+				LabelSelector: appSelector,
+				TopologyKey:   "kubernetes.io/hostname",
+			}},
+		},
+	})
+}
+
+func (s *availabilitySuite) TestProcessAvailabilityPreferredAntiAffinityKeepsNodeAffinity(c *gc.C) {
+	nodeAffinity := &core.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
+			NodeSelectorTerms: []core.NodeSelectorTerm{{}},
+		},
+	}
+	pod := core.PodSpec{Affinity: &core.Affinity{NodeAffinity: nodeAffinity}}
+	provider.ProcessAvailability(&pod, "app-name", &specs.AvailabilitySpec{
+		AntiAffinity: &specs.AntiAffinitySpec{Scope: specs.ZoneScope},
+	}, constraints.Value{})
+	c.Assert(pod.Affinity, jc.DeepEquals, &core.Affinity{
+		NodeAffinity: nodeAffinity,
+		PodAntiAffinity: &core.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []core.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: core.PodAffinityTerm{
+					LabelSelector: appSelector,
+					TopologyKey:   "failure-domain.beta.kubernetes.io/zone",
+				},
+			}},
+		},
+	})
+}
+
+func (s *availabilitySuite) TestProcessAvailabilityZonesConstraint(c *gc.C) {
+	var pod core.PodSpec
+	provider.ProcessAvailability(&pod, "app-name", &specs.AvailabilitySpec{}, constraints.MustParse("zones=a,b"))
+	c.Assert(pod.TopologySpreadConstraints, jc.DeepEquals, []core.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "failure-domain.beta.kubernetes.io/zone",
+		WhenUnsatisfiable: core.ScheduleAnyway,
+		LabelSelector:     appSelector,
+	}})
+
+	// An explicit zone spread takes precedence over the constraint.
+	pod = core.PodSpec{}
+	provider.ProcessAvailability(&pod, "app-name", &specs.AvailabilitySpec{
+		TopologySpread: []specs.TopologySpreadSpec{{Scope: specs.ZoneScope, MaxSkew: 3, Required: true}},
+	}, constraints.MustParse("zones=a,b"))
+	c.Assert(pod.TopologySpreadConstraints, jc.DeepEquals, []core.TopologySpreadConstraint{{
+		MaxSkew:           3,
+		TopologyKey:       "failure-domain.beta.kubernetes.io/zone",
+		WhenUnsatisfiable: core.DoNotSchedule,
+		LabelSelector:     appSelector,
+	}})
+
+	// A single zone has nothing to spread across.
+	pod = core.PodSpec{}
+	provider.ProcessAvailability(&pod, "app-name", &specs.AvailabilitySpec{}, constraints.MustParse("zones=a"))
+	c.Assert(pod.TopologySpreadConstraints, gc.HasLen, 0)
+}
+
+func (s *K8sBrokerSuite) availabilityPodSpec() *specs.PodSpec {
+	podSpec := getBasicPodspec()
+	maxUnavailable := specs.IntOrString{Type: specs.Int, IntVal: 1}
+	podSpec.Availability = &specs.AvailabilitySpec{
+		DisruptionBudget: &specs.DisruptionBudgetSpec{MaxUnavailable: &maxUnavailable},
+		AntiAffinity:     &specs.AntiAffinitySpec{Scope: specs.HostScope},
+	}
+	return podSpec
+}
+
+func (s *K8sBrokerSuite) ensureServiceWithAvailability(c *gc.C) error {
+	params := &caas.ServiceParams{
+		PodSpec:           s.availabilityPodSpec(),
+		OperatorImagePath: "operator/image-path",
+		ResourceTags: map[string]string{
+			"juju-controller-uuid": testing.ControllerTag.Id(),
+		},
+		Constraints: constraints.MustParse("zones=a,b"),
+	}
+	return s.broker.EnsureService("app-name", func(_ string, _ status.Status, _ string, _ map[string]interface{}) error { return nil }, params, 2, application.ConfigAttributes{})
+}
+
+// expectPodDisruptionBudgetsDeleted expects the pod disruption budgets
+// of an application without one in its spec to be deleted.
+func (s *K8sBrokerSuite) expectPodDisruptionBudgetsDeleted(appName string) *gomock.Call {
+	return s.mockPodDisruptionBudgets.EXPECT().DeleteCollection(gomock.Any(),
+		s.deleteOptions(v1.DeletePropagationForeground, ""),
+		v1.ListOptions{LabelSelector: "juju-app=" + appName},
+	).Return(nil)
+}
+
+func (s *K8sBrokerSuite) availabilityArgs() (*policyv1beta1.PodDisruptionBudget, *core.Service) {
+	maxUnavailable := intstr.FromInt(1)
+	pdbArg := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector:       appSelector,
+		},
+	}
+	return pdbArg, s.autoscaleServiceArg()
+}
+
+func (s *K8sBrokerSuite) availabilityDeploymentArg(c *gc.C) *appsv1.Deployment {
+	deploymentArg := s.autoscaleDeploymentArg(c, 2)
+	podSpec := &deploymentArg.Spec.Template.Spec
+	podSpec.Affinity = &core.Affinity{
+		NodeAffinity: &core.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
+				NodeSelectorTerms: []core.NodeSelectorTerm{{
+					MatchExpressions: []core.NodeSelectorRequirement{{
+						Key:      "failure-domain.beta.kubernetes.io/zone",
+						Operator: core.NodeSelectorOpIn,
+						Values:   []string{"a", "b"},
+					}},
+				}},
+			},
+		},
+		PodAntiAffinity: &core.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []core.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: core.PodAffinityTerm{
+					LabelSelector: appSelector,
+					TopologyKey:   "kubernetes.io/hostname",
+				},
+			}},
+		},
+	}
+	podSpec.TopologySpreadConstraints = []core.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "failure-domain.beta.kubernetes.io/zone",
+		WhenUnsatisfiable: core.ScheduleAnyway,
+		LabelSelector:     appSelector,
+	}}
+	return deploymentArg
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithAvailability(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pdbArg, serviceArg := s.availabilityArgs()
+	deploymentArg := s.availabilityDeploymentArg(c)
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectMonitorsDeleted()
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Create(gomock.Any(), pdbArg, v1.CreateOptions{}).
+			Return(pdbArg, nil),
+		s.mockSecrets.EXPECT().Create(gomock.Any(), ociImageSecret, v1.CreateOptions{}).
+			Return(ociImageSecret, nil),
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any(), serviceArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(gomock.Any(), serviceArg, v1.CreateOptions{}).
+			Return(nil, nil),
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(gomock.Any(), deploymentArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
+			Return(nil, nil),
+	)
+
+	err := s.ensureServiceWithAvailability(c)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceUpdatesPodDisruptionBudget(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pdbArg, serviceArg := s.availabilityArgs()
+	existing := *pdbArg
+	existing.SetResourceVersion("42")
+	updatedArg := *pdbArg
+	updatedArg.SetResourceVersion("42")
+	deploymentArg := s.availabilityDeploymentArg(c)
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectMonitorsDeleted()
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Create(gomock.Any(), pdbArg, v1.CreateOptions{}).
+			Return(nil, s.k8sAlreadyExistsError()),
+		s.mockPodDisruptionBudgets.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(&existing, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Update(gomock.Any(), &updatedArg, v1.UpdateOptions{}).
+			Return(&updatedArg, nil),
+		s.mockSecrets.EXPECT().Create(gomock.Any(), ociImageSecret, v1.CreateOptions{}).
+			Return(ociImageSecret, nil),
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any(), serviceArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(gomock.Any(), serviceArg, v1.CreateOptions{}).
+			Return(nil, nil),
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(gomock.Any(), deploymentArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
+			Return(nil, nil),
+	)
+
+	err := s.ensureServiceWithAvailability(c)
+	c.Assert(err, jc.ErrorIsNil)
+}
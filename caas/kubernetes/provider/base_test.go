@@ -66,6 +66,11 @@ type BaseSuite struct {
 	mockIngressInterface       *mocks.MockIngressInterface
 	mockNodes                  *mocks.MockNodeInterface
 	mockEvents                 *mocks.MockEventInterface
+	mockResourceQuotas         *mocks.MockResourceQuotaInterface
+	mockLimitRanges            *mocks.MockLimitRangeInterface
+	mockHorizontalPodScalers   *mocks.MockHorizontalPodAutoscalerInterface
+	mockPodDisruptionBudgets   *mocks.MockPodDisruptionBudgetInterface
+	mockNetworkPolicies        *mocks.MockNetworkPolicyInterface
 
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
@@ -268,6 +273,12 @@ func (s *BaseSuite) setupK8sRestClient(
 	s.mockEvents = mocks.NewMockEventInterface(ctrl)
 	mockCoreV1.EXPECT().Events(namespace).AnyTimes().Return(s.mockEvents)
 
+	s.mockResourceQuotas = mocks.NewMockResourceQuotaInterface(ctrl)
+	mockCoreV1.EXPECT().ResourceQuotas(namespace).AnyTimes().Return(s.mockResourceQuotas)
+
+	s.mockLimitRanges = mocks.NewMockLimitRangeInterface(ctrl)
+	mockCoreV1.EXPECT().LimitRanges(namespace).AnyTimes().Return(s.mockLimitRanges)
+
 	s.mockApps = mocks.NewMockAppsV1Interface(ctrl)
 	s.mockExtensions = mocks.NewMockExtensionsV1beta1Interface(ctrl)
 	s.mockStatefulSets = mocks.NewMockStatefulSetInterface(ctrl)
@@ -281,6 +292,21 @@ func (s *BaseSuite) setupK8sRestClient(
 	s.mockApps.EXPECT().DaemonSets(namespace).AnyTimes().Return(s.mockDaemonSets)
 	s.mockExtensions.EXPECT().Ingresses(namespace).AnyTimes().Return(s.mockIngressInterface)
 
+	mockAutoscalingV1 := mocks.NewMockAutoscalingV1Interface(ctrl)
+	s.mockHorizontalPodScalers = mocks.NewMockHorizontalPodAutoscalerInterface(ctrl)
+	s.k8sClient.EXPECT().AutoscalingV1().AnyTimes().Return(mockAutoscalingV1)
+	mockAutoscalingV1.EXPECT().HorizontalPodAutoscalers(namespace).AnyTimes().Return(s.mockHorizontalPodScalers)
+
+	mockPolicyV1beta1 := mocks.NewMockPolicyV1beta1Interface(ctrl)
+	s.mockPodDisruptionBudgets = mocks.NewMockPodDisruptionBudgetInterface(ctrl)
+	s.k8sClient.EXPECT().PolicyV1beta1().AnyTimes().Return(mockPolicyV1beta1)
+	mockPolicyV1beta1.EXPECT().PodDisruptionBudgets(namespace).AnyTimes().Return(s.mockPodDisruptionBudgets)
+
+	mockNetworkingV1 := mocks.NewMockNetworkingV1Interface(ctrl)
+	s.mockNetworkPolicies = mocks.NewMockNetworkPolicyInterface(ctrl)
+	s.k8sClient.EXPECT().NetworkingV1().AnyTimes().Return(mockNetworkingV1)
+	mockNetworkingV1.EXPECT().NetworkPolicies(namespace).AnyTimes().Return(s.mockNetworkPolicies)
+
 	s.mockStorage = mocks.NewMockStorageV1Interface(ctrl)
 	s.mockStorageClass = mocks.NewMockStorageClassInterface(ctrl)
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
@@ -15,6 +15,7 @@ const (
 	defaultIngressSSLRedirect    = false
 	defaultIngressSSLPassthrough = false
 	defaultIngressAllowHTTPKey   = false
+	defaultExposeMode            = exposeModeIngress
 
 	ServiceTypeConfigKey               = "kubernetes-service-type"
 	serviceExternalIPsConfigKey        = "kubernetes-service-external-ips"
@@ -28,6 +29,18 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	ExposeModeConfigKey         = "kubernetes-expose-mode"
+	ingressTLSSecretKey         = "kubernetes-ingress-tls-secret"
+	ingressCertIssuerKey        = "kubernetes-ingress-cert-issuer"
+	ingressCertClusterIssuerKey = "kubernetes-ingress-cert-cluster-issuer"
+	ingressEndpointPathsKey     = "kubernetes-ingress-endpoint-paths"
+	gatewayNameConfigKey        = "kubernetes-gateway-name"
+	gatewayNamespaceConfigKey   = "kubernetes-gateway-namespace"
+
+	AutoscaleMinUnitsKey  = "kubernetes-autoscale-min-units"
+	AutoscaleMaxUnitsKey  = "kubernetes-autoscale-max-units"
+	AutoscaleCPUTargetKey = "kubernetes-autoscale-cpu-target"
 )
 
 var configFields = environschema.Fields{
@@ -86,15 +99,75 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	ExposeModeConfigKey: {
+		Description: "how an exposed application is reached from outside the cluster, either ingress or gateway",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressTLSSecretKey: {
+		Description: "the name of the secret holding the TLS certificate for the external hostname",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressCertIssuerKey: {
+		Description: "the cert-manager issuer used to obtain a TLS certificate for the external hostname",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressCertClusterIssuerKey: {
+		Description: "the cert-manager cluster issuer used to obtain a TLS certificate for the external hostname",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressEndpointPathsKey: {
+		Description: "a map of endpoint names to the paths routed to the service ports named after the endpoints",
+		Type:        environschema.Tattrs,
+		Group:       environschema.ProviderGroup,
+	},
+	gatewayNameConfigKey: {
+		Description: "the Gateway API gateway the HTTPRoute attaches to when the expose mode is gateway",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	gatewayNamespaceConfigKey: {
+		Description: "the namespace of the gateway, defaults to the model namespace",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	AutoscaleMinUnitsKey: {
+		Description: "minimum units when autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	AutoscaleMaxUnitsKey: {
+		Description: "maximum units when autoscaling, 0 disables autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	AutoscaleCPUTargetKey: {
+		Description: "target average CPU utilisation percentage when autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
-	ServiceTypeConfigKey:     schema.Omit,
-	serviceAnnotationsKey:    schema.Omit,
-	ingressClassKey:          defaultIngressClass,
-	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
-	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
-	ingressAllowHTTPKey:      defaultIngressAllowHTTPKey,
+	ServiceTypeConfigKey:        schema.Omit,
+	serviceAnnotationsKey:       schema.Omit,
+	ingressClassKey:             defaultIngressClass,
+	ingressSSLRedirectKey:       defaultIngressSSLRedirect,
+	ingressSSLPassthroughKey:    defaultIngressSSLPassthrough,
+	ingressAllowHTTPKey:         defaultIngressAllowHTTPKey,
+	ExposeModeConfigKey:         defaultExposeMode,
+	ingressTLSSecretKey:         schema.Omit,
+	ingressCertIssuerKey:        schema.Omit,
+	ingressCertClusterIssuerKey: schema.Omit,
+	ingressEndpointPathsKey:     schema.Omit,
+	gatewayNameConfigKey:        schema.Omit,
+	gatewayNamespaceConfigKey:   schema.Omit,
+	AutoscaleMinUnitsKey:        schema.Omit,
+	AutoscaleMaxUnitsKey:        schema.Omit,
+	AutoscaleCPUTargetKey:       schema.Omit,
 }
 
 // ConfigSchema returns the configuration schema for
@@ -0,0 +1,97 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/juju/juju/cloud"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+)
+
+var newRotationClient = func(cfg *rest.Config) (kubernetes.Interface, error) {
+	return kubernetes.NewForConfig(cfg)
+}
+
+// RotatedCredential returns the credential of the cloud spec with its
+// tokens replaced by newer ones. The ID and refresh tokens of an oidc
+// credential are replaced by those last refreshed in this process. The
+// service account token of any other credential is replaced by the one
+// in the secret referred to by the credential's token-secret attribute. The secret is only ever read
+// with the credential itself, so the credential must be rotated before
+// its token expires. The rotated credential is checked against the
+// cluster before it's returned.
+//
+// A NotFound error is returned if the oidc credential hasn't been
+// refreshed, or the credential doesn't refer to a secret.
+func RotatedCredential(cloudSpec environscloudspec.CloudSpec) (*cloud.Credential, error) {
+	if cloudSpec.Credential == nil {
+		return nil, errors.NotValidf("missing credential")
+	}
+	if cloudSpec.Credential.AuthType() == cloud.OIDCAuthType {
+		return refreshedOIDCCredential(*cloudSpec.Credential)
+	}
+	attrs := cloudSpec.Credential.Attributes()
+	ref := attrs[CredAttrTokenSecret]
+	if ref == "" {
+		return nil, errors.NotFoundf("token secret")
+	}
+	parts := strings.Split(ref, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.NotValidf("token secret %q", ref)
+	}
+	namespace, name := parts[0], parts[1]
+
+	cfg, err := CloudSpecToK8sRestConfig(cloudSpec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secret, err := readTokenSecret(cfg, namespace, name)
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("secret %q", ref)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "reading token secret %q", ref)
+	}
+	token := string(secret.Data[core.ServiceAccountTokenKey])
+	if token == "" {
+		return nil, errors.NotFoundf("%q in secret %q", core.ServiceAccountTokenKey, ref)
+	}
+
+	newAttrs := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		newAttrs[k] = v
+	}
+	newAttrs[CredAttrToken] = token
+	rotated := cloud.NewNamedCredential(cloudSpec.Credential.Label, cloudSpec.Credential.AuthType(), newAttrs, false)
+
+	// Make sure the new token works before it replaces the old one.
+	cloudSpec.Credential = &rotated
+	if cfg, err = CloudSpecToK8sRestConfig(cloudSpec); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := newRotationClient(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := client.CoreV1().Namespaces().List(context.TODO(), v1.ListOptions{Limit: 1}); err != nil {
+		return nil, errors.Annotatef(err, "checking token from secret %q", ref)
+	}
+	return &rotated, nil
+}
+
+func readTokenSecret(cfg *rest.Config, namespace, name string) (*core.Secret, error) {
+	client, err := newRotationClient(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client.CoreV1().Secrets(namespace).Get(context.TODO(), name, v1.GetOptions{})
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/cloud"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+)
+
+type credentialRotationSuite struct {
+	testing.IsolationSuite
+
+	client *fake.Clientset
+	// tokens records the bearer token of each client made.
+	tokens []string
+}
+
+var _ = gc.Suite(&credentialRotationSuite{})
+
+func (s *credentialRotationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.tokens = nil
+	s.client = fake.NewSimpleClientset(&core.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-token", Namespace: "kube-system"},
+		Data:       map[string][]byte{core.ServiceAccountTokenKey: []byte("rotated")},
+	})
+	s.PatchValue(provider.NewRotationClient, func(cfg *rest.Config) (kubernetes.Interface, error) {
+		s.tokens = append(s.tokens, cfg.BearerToken)
+		return s.client, nil
+	})
+}
+
+func (s *credentialRotationSuite) cloudSpec(attrs map[string]string) environscloudspec.CloudSpec {
+	credAttrs := map[string]string{
+		"ClientCertificateData": "cert-data",
+		"Token":                 "expired",
+		"token-secret":          "kube-system/juju-token",
+	}
+	for k, v := range attrs {
+		credAttrs[k] = v
+	}
+	cred := cloud.NewNamedCredential("fred", cloud.CertificateAuthType, credAttrs, false)
+	return environscloudspec.CloudSpec{Endpoint: "some-host", Credential: &cred}
+}
+
+func (s *credentialRotationSuite) TestRotatedCredential(c *gc.C) {
+	rotated, err := provider.RotatedCredential(s.cloudSpec(nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotated.Label, gc.Equals, "fred")
+	c.Assert(rotated.AuthType(), gc.Equals, cloud.CertificateAuthType)
+	c.Assert(rotated.Attributes(), jc.DeepEquals, map[string]string{
+		"ClientCertificateData": "cert-data",
+		"Token":                 "rotated",
+		"token-secret":          "kube-system/juju-token",
+	})
+	// The secret is read with the old token, and the new one checked.
+	c.Assert(s.tokens, jc.DeepEquals, []string{"expired", "rotated"})
+}
+
+func (s *credentialRotationSuite) TestRotatedCredentialNoTokenSecret(c *gc.C) {
+	_, err := provider.RotatedCredential(s.cloudSpec(map[string]string{"token-secret": ""}))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.tokens, gc.HasLen, 0)
+}
+
+func (s *credentialRotationSuite) TestRotatedCredentialInvalidTokenSecret(c *gc.C) {
+	_, err := provider.RotatedCredential(s.cloudSpec(map[string]string{"token-secret": "juju-token"}))
+	c.Assert(err, gc.ErrorMatches, `token secret "juju-token" not valid`)
+}
+
+func (s *credentialRotationSuite) TestRotatedCredentialSecretNotFound(c *gc.C) {
+	err := s.client.CoreV1().Secrets("kube-system").Delete(context.TODO(), "juju-token", v1.DeleteOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = provider.RotatedCredential(s.cloudSpec(nil))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *credentialRotationSuite) expireToken(token string) {
+	s.client.PrependReactor("*", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if s.tokens[len(s.tokens)-1] == token {
+			return true, nil, k8serrors.NewUnauthorized("token expired")
+		}
+		return false, nil, nil
+	})
+}
+
+func (s *credentialRotationSuite) TestRotatedCredentialExpired(c *gc.C) {
+	s.expireToken("expired")
+	_, err := provider.RotatedCredential(s.cloudSpec(nil))
+	c.Assert(err, gc.ErrorMatches, `reading token secret "kube-system/juju-token": token expired`)
+	// The secret is never read with any other identity.
+	c.Assert(s.tokens, jc.DeepEquals, []string{"expired"})
+}
+
+func (s *credentialRotationSuite) TestRotatedCredentialCheckFails(c *gc.C) {
+	s.expireToken("rotated")
+	_, err := provider.RotatedCredential(s.cloudSpec(nil))
+	c.Assert(err, gc.ErrorMatches, `checking token from secret "kube-system/juju-token": token expired`)
+}
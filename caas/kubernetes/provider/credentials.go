@@ -4,7 +4,14 @@
 package provider
 
 import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // load oidc auth plugin.
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/clientconfig"
@@ -19,6 +26,22 @@ const (
 	CredAttrClientKeyData         = "ClientKeyData"
 	CredAttrToken                 = "Token"
 
+	CredAttrExecCommand    = "ExecCommand"
+	CredAttrExecArgs       = "ExecArgs"
+	CredAttrExecEnv        = "ExecEnv"
+	CredAttrExecAPIVersion = "ExecAPIVersion"
+
+	CredAttrIssuerURL    = "IssuerURL"
+	CredAttrClientID     = "ClientID"
+	CredAttrClientSecret = "ClientSecret"
+	CredAttrIDToken      = "IDToken"
+	CredAttrRefreshToken = "RefreshToken"
+
+	// CredAttrTokenSecret refers to the in-cluster secret, as
+	// "namespace/name", from which a rotated service account token
+	// is read.
+	CredAttrTokenSecret = "token-secret"
+
 	RBACLabelKeyName = "rbac-id"
 )
 
@@ -78,7 +101,276 @@ var k8sCredentialSchemas = map[cloud.AuthType]cloud.CredentialSchema{
 				Description: "the unique ID key name of the rbac resources",
 			},
 		},
+		{
+			Name: CredAttrTokenSecret,
+			CredentialAttr: cloud.CredentialAttr{
+				Optional:    true,
+				Description: "the namespace/name of the secret holding the rotated service account token",
+			},
+		},
+	},
+	cloud.ExecAuthType: {
+		{
+			Name: CredAttrExecCommand,
+			CredentialAttr: cloud.CredentialAttr{
+				Description: "the credential plugin run by the controller to obtain credentials, e.g. aws-iam-authenticator",
+			},
+		},
+		{
+			Name: CredAttrExecArgs,
+			CredentialAttr: cloud.CredentialAttr{
+				Optional:    true,
+				Description: "the space separated arguments of the command",
+			},
+		},
+		{
+			Name: CredAttrExecEnv,
+			CredentialAttr: cloud.CredentialAttr{
+				Optional:    true,
+				Description: "the space separated NAME=value environment of the command",
+			},
+		},
+		{
+			Name: CredAttrExecAPIVersion,
+			CredentialAttr: cloud.CredentialAttr{
+				Optional:    true,
+				Description: "the version of the client authentication API the command implements",
+			},
+		},
 	},
+	cloud.OIDCAuthType: {
+		{
+			Name: CredAttrIssuerURL,
+			CredentialAttr: cloud.CredentialAttr{
+				Description: "the URL of the OpenID Connect issuer",
+			},
+		},
+		{
+			Name: CredAttrClientID,
+			CredentialAttr: cloud.CredentialAttr{
+				Description: "the OpenID Connect client ID",
+			},
+		},
+		{
+			Name: CredAttrClientSecret,
+			CredentialAttr: cloud.CredentialAttr{
+				Optional:    true,
+				Description: "the OpenID Connect client secret",
+				Hidden:      true,
+			},
+		},
+		{
+			Name: CredAttrIDToken,
+			CredentialAttr: cloud.CredentialAttr{
+				Description: "the OpenID Connect ID token",
+				Hidden:      true,
+			},
+		},
+		{
+			Name: CredAttrRefreshToken,
+			CredentialAttr: cloud.CredentialAttr{
+				Optional:    true,
+				Description: "the refresh token, used to obtain a new ID token when it expires",
+				Hidden:      true,
+			},
+		},
+	},
+}
+
+// DefaultExecAPIVersion is the version of the client authentication API
+// used by exec credentials which don't specify one.
+const DefaultExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+// execPlugins are the exec credential plugins which the controller
+// runs. Exec credentials are uploaded by controller admins only, but
+// the command is still limited to known plugins, found on the PATH.
+var execPlugins = set.NewStrings(
+	"aws",
+	"aws-iam-authenticator",
+	"gke-gcloud-auth-plugin",
+	"kubelogin",
+	"doctl",
+)
+
+// execEnvNames are the environment variables which may be passed to an
+// exec credential plugin.
+var execEnvNames = set.NewStrings(
+	"AWS_PROFILE",
+	"AWS_REGION",
+	"AWS_DEFAULT_REGION",
+	"AWS_STS_REGIONAL_ENDPOINTS",
+	"AAD_SERVICE_PRINCIPAL_CLIENT_ID",
+	"AAD_LOGIN_METHOD",
+)
+
+// execConfig returns the exec plugin configuration of an exec credential.
+func execConfig(attrs map[string]string) (*clientcmdapi.ExecConfig, error) {
+	command := attrs[CredAttrExecCommand]
+	if !execPlugins.Contains(command) {
+		return nil, errors.NotValidf("exec credential command %q (expected one of %s)",
+			command, strings.Join(execPlugins.SortedValues(), ", "))
+	}
+	cfg := &clientcmdapi.ExecConfig{
+		Command:    command,
+		Args:       strings.Fields(attrs[CredAttrExecArgs]),
+		APIVersion: attrs[CredAttrExecAPIVersion],
+	}
+	if cfg.APIVersion == "" {
+		cfg.APIVersion = DefaultExecAPIVersion
+	}
+	for _, kv := range strings.Fields(attrs[CredAttrExecEnv]) {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if !execEnvNames.Contains(parts[0]) {
+			return nil, errors.NotValidf("exec credential environment variable %q", parts[0])
+		}
+		cfg.Env = append(cfg.Env, clientcmdapi.ExecEnvVar{Name: parts[0], Value: parts[1]})
+	}
+	return cfg, nil
+}
+
+// oidcConfig returns the oidc auth provider configuration of an oidc
+// credential. If the credential's tokens have already been refreshed in
+// this process, the refreshed tokens are used in its place.
+func oidcConfig(attrs map[string]string) *clientcmdapi.AuthProviderConfig {
+	attrs = oidcTokens.latest(attrs)
+	cfg := map[string]string{}
+	for attr, key := range map[string]string{
+		CredAttrIssuerURL:    "idp-issuer-url",
+		CredAttrClientID:     "client-id",
+		CredAttrClientSecret: "client-secret",
+		CredAttrIDToken:      "id-token",
+		CredAttrRefreshToken: "refresh-token",
+	} {
+		if v := attrs[attr]; v != "" {
+			cfg[key] = v
+		}
+	}
+	return &clientcmdapi.AuthProviderConfig{Name: "oidc", Config: cfg}
+}
+
+// refreshedTokenTTL is how long refreshed oidc tokens are remembered.
+// They are saved to the credential well before then.
+const refreshedTokenTTL = 24 * time.Hour
+
+// oidcTokens records the tokens refreshed by the oidc auth providers of
+// the rest configs made in this process. The oidc auth provider keeps
+// them in memory only; they are saved to the cloud credential by the
+// credential rotator, which is told of each refresh.
+var oidcTokens = &refreshedTokens{
+	refreshed: make(map[string]refreshedToken),
+	changed:   make(chan struct{}),
+}
+
+// OIDCTokensRefreshed returns a channel which is closed the next time
+// the tokens of an oidc credential are refreshed.
+func OIDCTokensRefreshed() <-chan struct{} {
+	return oidcTokens.changes()
+}
+
+type refreshedToken struct {
+	idToken      string
+	refreshToken string
+	when         time.Time
+}
+
+type refreshedTokens struct {
+	mu sync.Mutex
+	// refreshed maps each refresh token used to the tokens which
+	// replaced it.
+	refreshed map[string]refreshedToken
+	changed   chan struct{}
+}
+
+func (t *refreshedTokens) changes() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.changed
+}
+
+// add records the tokens obtained with the given refresh token.
+func (t *refreshedTokens) add(used string, token refreshedToken) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for k, v := range t.refreshed {
+		if token.when.Sub(v.when) > refreshedTokenTTL {
+			delete(t.refreshed, k)
+		}
+	}
+	t.refreshed[used] = token
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// latest returns the given oidc credential attributes with the latest
+// tokens refreshed from them, if any.
+func (t *refreshedTokens) latest(attrs map[string]string) map[string]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	token, ok := t.refreshed[attrs[CredAttrRefreshToken]]
+	if !ok {
+		return attrs
+	}
+	// Follow the chain of refreshes; each refresh token is used once.
+	for i := 0; i < len(t.refreshed); i++ {
+		next, ok := t.refreshed[token.refreshToken]
+		if !ok {
+			break
+		}
+		token = next
+	}
+	result := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		result[k] = v
+	}
+	result[CredAttrIDToken] = token.idToken
+	if token.refreshToken != "" {
+		result[CredAttrRefreshToken] = token.refreshToken
+	}
+	return result
+}
+
+// tokenPersister records the tokens refreshed by an oidc auth provider
+// in oidcTokens.
+type tokenPersister struct {
+	mu           sync.Mutex
+	refreshToken string
+}
+
+// Persist is part of the rest.AuthProviderConfigPersister interface.
+func (p *tokenPersister) Persist(cfg map[string]string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.refreshToken == "" {
+		// Without a refresh token, the tokens can't be told apart
+		// from those of other credentials.
+		return nil
+	}
+	token := refreshedToken{
+		idToken:      cfg["id-token"],
+		refreshToken: cfg["refresh-token"],
+		when:         time.Now(),
+	}
+	oidcTokens.add(p.refreshToken, token)
+	if token.refreshToken != "" {
+		p.refreshToken = token.refreshToken
+	}
+	return nil
+}
+
+// refreshedOIDCCredential returns the credential with its tokens replaced
+// by the latest ones refreshed in this process, or a NotFound error if
+// they have not been refreshed.
+func refreshedOIDCCredential(credential cloud.Credential) (*cloud.Credential, error) {
+	attrs := credential.Attributes()
+	latest := oidcTokens.latest(attrs)
+	if latest[CredAttrIDToken] == attrs[CredAttrIDToken] && latest[CredAttrRefreshToken] == attrs[CredAttrRefreshToken] {
+		return nil, errors.NotFoundf("refreshed tokens")
+	}
+	refreshed := cloud.NewNamedCredential(credential.Label, credential.AuthType(), latest, false)
+	return &refreshed, nil
 }
 
 type environProviderCredentials struct {
@@ -6,15 +6,18 @@ package provider_test
 import (
 	"path/filepath"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2"
 	gc "gopkg.in/check.v1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	envtesting "github.com/juju/juju/environs/testing"
 )
 
@@ -34,7 +37,7 @@ func (s *credentialsSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *credentialsSuite) TestCredentialSchemas(c *gc.C) {
-	envtesting.AssertProviderAuthTypes(c, s.provider, "userpass", "certificate", "oauth2withcert")
+	envtesting.AssertProviderAuthTypes(c, s.provider, "userpass", "certificate", "oauth2withcert", "exec", "oidc")
 }
 
 func (s *credentialsSuite) TestCredentialsValid(c *gc.C) {
@@ -48,6 +51,112 @@ func (s *credentialsSuite) TestHiddenAttributes(c *gc.C) {
 	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "userpass", "password")
 	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "oauth2withcert", "Token", "ClientKeyData")
 	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "certificate", "Token")
+	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "oidc", "ClientSecret", "IDToken", "RefreshToken")
+}
+
+func (s *credentialsSuite) TestExecRestConfig(c *gc.C) {
+	cred := cloud.NewCredential(cloud.ExecAuthType, map[string]string{
+		"ExecCommand": "aws",
+		"ExecArgs":    "eks get-token --cluster-name fred",
+		"ExecEnv":     "AWS_PROFILE=juju",
+	})
+	cfg, err := provider.CloudSpecToK8sRestConfig(environscloudspec.CloudSpec{Endpoint: "some-host", Credential: &cred})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BearerToken, gc.Equals, "")
+	c.Assert(cfg.ExecProvider, jc.DeepEquals, &clientcmdapi.ExecConfig{
+		Command:    "aws",
+		Args:       []string{"eks", "get-token", "--cluster-name", "fred"},
+		Env:        []clientcmdapi.ExecEnvVar{{Name: "AWS_PROFILE", Value: "juju"}},
+		APIVersion: provider.DefaultExecAPIVersion,
+	})
+}
+
+func (s *credentialsSuite) TestExecRestConfigCommandNotAllowed(c *gc.C) {
+	cred := cloud.NewCredential(cloud.ExecAuthType, map[string]string{
+		"ExecCommand": "/bin/sh",
+		"ExecArgs":    "-c id",
+	})
+	_, err := provider.CloudSpecToK8sRestConfig(environscloudspec.CloudSpec{Endpoint: "some-host", Credential: &cred})
+	c.Assert(err, gc.ErrorMatches, `exec credential command "/bin/sh" \(expected one of .*\) not valid`)
+}
+
+func (s *credentialsSuite) TestExecRestConfigEnvNotAllowed(c *gc.C) {
+	cred := cloud.NewCredential(cloud.ExecAuthType, map[string]string{
+		"ExecCommand": "aws",
+		"ExecEnv":     "AWS_CONFIG_FILE=/tmp/config",
+	})
+	_, err := provider.CloudSpecToK8sRestConfig(environscloudspec.CloudSpec{Endpoint: "some-host", Credential: &cred})
+	c.Assert(err, gc.ErrorMatches, `exec credential environment variable "AWS_CONFIG_FILE" not valid`)
+}
+
+func (s *credentialsSuite) TestOIDCRestConfig(c *gc.C) {
+	cred := cloud.NewCredential(cloud.OIDCAuthType, map[string]string{
+		"IssuerURL":    "https://issuer.example.com",
+		"ClientID":     "juju",
+		"IDToken":      "id-token",
+		"RefreshToken": "refresh-token",
+	})
+	cfg, err := provider.CloudSpecToK8sRestConfig(environscloudspec.CloudSpec{Endpoint: "some-host", Credential: &cred})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuthProvider, jc.DeepEquals, &clientcmdapi.AuthProviderConfig{
+		Name: "oidc",
+		Config: map[string]string{
+			"idp-issuer-url": "https://issuer.example.com",
+			"client-id":      "juju",
+			"id-token":       "id-token",
+			"refresh-token":  "refresh-token",
+		},
+	})
+	c.Assert(cfg.AuthConfigPersister, gc.NotNil)
+}
+
+func (s *credentialsSuite) TestOIDCRefreshedTokens(c *gc.C) {
+	cred := cloud.NewNamedCredential("fred", cloud.OIDCAuthType, map[string]string{
+		"IssuerURL":    "https://issuer.example.com",
+		"ClientID":     "juju",
+		"IDToken":      "id-token-0",
+		"RefreshToken": "refresh-token-0",
+	}, false)
+	spec := environscloudspec.CloudSpec{Endpoint: "some-host", Credential: &cred}
+	cfg, err := provider.CloudSpecToK8sRestConfig(spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = provider.RotatedCredential(spec)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// The oidc auth provider persists each refresh.
+	refreshed := provider.OIDCTokensRefreshed()
+	err = cfg.AuthConfigPersister.Persist(map[string]string{"id-token": "id-token-1", "refresh-token": "refresh-token-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = cfg.AuthConfigPersister.Persist(map[string]string{"id-token": "id-token-2", "refresh-token": "refresh-token-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-refreshed:
+	default:
+		c.Fatalf("refresh not reported")
+	}
+
+	rotated, err := provider.RotatedCredential(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotated.Label, gc.Equals, "fred")
+	c.Assert(rotated.Attributes(), jc.DeepEquals, map[string]string{
+		"IssuerURL":    "https://issuer.example.com",
+		"ClientID":     "juju",
+		"IDToken":      "id-token-2",
+		"RefreshToken": "refresh-token-2",
+	})
+
+	// Configs made from the stored credential before it's updated use
+	// the refreshed tokens, rather than the used refresh token.
+	cfg, err = provider.CloudSpecToK8sRestConfig(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuthProvider.Config["id-token"], gc.Equals, "id-token-2")
+	c.Assert(cfg.AuthProvider.Config["refresh-token"], gc.Equals, "refresh-token-2")
+
+	// Once updated, there is nothing more to rotate.
+	spec.Credential = rotated
+	_, err = provider.RotatedCredential(spec)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
 var singleConfigYAML = `
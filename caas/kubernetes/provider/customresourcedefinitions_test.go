@@ -105,6 +105,8 @@ func (s *K8sBrokerSuite) assertCustomerResourceDefinitions(c *gc.C, crds []k8ssp
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, v1.CreateOptions{}).
 			Return(nil, nil),
 	}...)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(assertCalls...)
 
 	params := &caas.ServiceParams{
@@ -429,6 +431,8 @@ func (s *K8sBrokerSuite) assertCustomerResources(c *gc.C, crs map[string][]unstr
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, v1.CreateOptions{}).
 			Return(nil, nil),
 	}...)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(assertCalls...)
 
 	errChan := make(chan error)
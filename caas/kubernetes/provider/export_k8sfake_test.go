@@ -0,0 +1,28 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build k8sfake
+
+package provider
+
+import (
+	"k8s.io/client-go/kubernetes"
+)
+
+var JujudPath = &jujudPath
+
+func FakeClusterClient(endpoint string) kubernetes.Interface {
+	return fakeClusterFor(endpoint).k8sClient
+}
+
+// FakeAgentDataDir returns the data directory of the agent of a pod in
+// the in-memory cluster with the endpoint, if it has one.
+func FakeAgentDataDir(endpoint, namespace, podName string) string {
+	c := fakeClusterFor(endpoint)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if agent := c.agents[agentKey(namespace, podName)]; agent != nil {
+		return agent.dataDir
+	}
+	return ""
+}
@@ -41,6 +41,8 @@ var (
 	Indent                 = indent
 	ProcessSecretData      = processSecretData
 	PushUniqueVolume       = pushUniqueVolume
+	ProcessAvailability    = processAvailability
+	AdoptedPodSelector     = adoptedPodSelector
 
 	CompileK8sCloudCheckers                    = compileK8sCloudCheckers
 	CompileLifecycleApplicationRemovalSelector = compileLifecycleApplicationRemovalSelector
@@ -50,6 +52,8 @@ var (
 	MergeSelectors         = mergeSelectors
 	GetStorageMode         = getStorageMode
 
+	NewRotationClient = &newRotationClient
+
 	UpdateStrategyForDeployment  = updateStrategyForDeployment
 	UpdateStrategyForStatefulSet = updateStrategyForStatefulSet
 	UpdateStrategyForDaemonSet   = updateStrategyForDaemonSet
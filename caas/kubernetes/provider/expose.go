@@ -0,0 +1,241 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/core/application"
+)
+
+const (
+	exposeModeIngress = "ingress"
+	exposeModeGateway = "gateway"
+
+	certManagerIssuerAnnotation        = "cert-manager.io/issuer"
+	certManagerClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+)
+
+// httpRouteResource is the Gateway API HTTPRoute resource. The cluster
+// needs the Gateway API CRDs installed to expose applications through a
+// gateway.
+var httpRouteResource = schema.GroupVersionResource{
+	Group:    "gateway.networking.k8s.io",
+	Version:  "v1beta1",
+	Resource: "httproutes",
+}
+
+// exposeRoute routes requests for a path to a service port.
+type exposeRoute struct {
+	path string
+	// ingressPort is the port used by the ingress backend.
+	ingressPort intstr.IntOrString
+	// port is the service port used by the HTTPRoute backend.
+	port int32
+}
+
+// exposeRoutes returns the routes for an exposed application. With no
+// endpoint paths configured, the application path is routed to the first
+// service port; otherwise each endpoint's path is routed to the service
+// port named after the endpoint.
+func exposeRoutes(appName string, config application.ConfigAttributes, ports []core.ServicePort) ([]exposeRoute, error) {
+	endpointPaths, err := config.GetStringMap(ingressEndpointPathsKey, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "unexpected endpoint paths: %#v", config.Get(ingressEndpointPathsKey, nil))
+	}
+	if len(endpointPaths) == 0 {
+		httpPath := config.GetString(caas.JujuApplicationPath, caas.JujuDefaultApplicationPath)
+		if httpPath == "$appname" {
+			httpPath = appName
+		}
+		return []exposeRoute{{
+			path:        absolutePath(httpPath),
+			ingressPort: ports[0].TargetPort,
+			port:        ports[0].Port,
+		}}, nil
+	}
+
+	// Sort for stable ordering.
+	var endpoints []string
+	for endpoint := range endpointPaths {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	var routes []exposeRoute
+	for _, endpoint := range endpoints {
+		port, ok := servicePortNamed(endpoint, ports)
+		if !ok {
+			return nil, errors.NotValidf("path for endpoint %q without a service port of the same name", endpoint)
+		}
+		routes = append(routes, exposeRoute{
+			path:        absolutePath(endpointPaths[endpoint]),
+			ingressPort: intstr.FromString(port.Name),
+			port:        port.Port,
+		})
+	}
+	return routes, nil
+}
+
+func servicePortNamed(name string, ports []core.ServicePort) (core.ServicePort, bool) {
+	for _, p := range ports {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return core.ServicePort{}, false
+}
+
+func absolutePath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path
+	}
+	return path
+}
+
+// exposeIngress returns the ingress resource used to expose an application.
+// A TLS secret or cert-manager issuer in the config adds TLS termination
+// for the external hostname.
+func exposeIngress(
+	name, serviceName, host string, labels map[string]string, routes []exposeRoute, config application.ConfigAttributes,
+) (*v1beta1.Ingress, error) {
+	issuer := config.GetString(ingressCertIssuerKey, "")
+	clusterIssuer := config.GetString(ingressCertClusterIssuerKey, "")
+	if issuer != "" && clusterIssuer != "" {
+		return nil, errors.NewNotValid(nil, ingressCertIssuerKey+" and "+ingressCertClusterIssuerKey+" cannot both be set")
+	}
+
+	annotations := map[string]string{
+		"ingress.kubernetes.io/rewrite-target":  "",
+		"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(config.GetBool(ingressSSLRedirectKey, defaultIngressSSLRedirect)),
+		"kubernetes.io/ingress.class":           config.GetString(ingressClassKey, defaultIngressClass),
+		"kubernetes.io/ingress.allow-http":      strconv.FormatBool(config.GetBool(ingressAllowHTTPKey, defaultIngressAllowHTTPKey)),
+		"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(config.GetBool(ingressSSLPassthroughKey, defaultIngressSSLPassthrough)),
+	}
+	if issuer != "" {
+		annotations[certManagerIssuerAnnotation] = issuer
+	}
+	if clusterIssuer != "" {
+		annotations[certManagerClusterIssuerAnnotation] = clusterIssuer
+	}
+
+	var paths []v1beta1.HTTPIngressPath
+	for _, route := range routes {
+		paths = append(paths, v1beta1.HTTPIngressPath{
+			Path: route.path,
+			Backend: v1beta1.IngressBackend{
+				ServiceName: serviceName,
+				ServicePort: route.ingressPort,
+			},
+		})
+	}
+	ingress := &v1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: v1beta1.IngressSpec{
+			Rules: []v1beta1.IngressRule{{
+				Host: host,
+				IngressRuleValue: v1beta1.IngressRuleValue{
+					HTTP: &v1beta1.HTTPIngressRuleValue{Paths: paths},
+				},
+			}},
+		},
+	}
+
+	secretName := config.GetString(ingressTLSSecretKey, "")
+	if secretName == "" && (issuer != "" || clusterIssuer != "") {
+		// cert-manager stores the certificate it obtains in this secret.
+		secretName = name + "-tls"
+	}
+	if secretName != "" {
+		ingress.Spec.TLS = []v1beta1.IngressTLS{{
+			Hosts:      []string{host},
+			SecretName: secretName,
+		}}
+	}
+	return ingress, nil
+}
+
+// exposeHTTPRoute returns the Gateway API HTTPRoute used to expose an
+// application through the gateway named in the config. TLS for a gateway
+// is configured on the gateway's listeners rather than on the route.
+func exposeHTTPRoute(
+	name, serviceName, host string, labels map[string]string, routes []exposeRoute, config application.ConfigAttributes,
+) (*unstructured.Unstructured, error) {
+	gateway := config.GetString(gatewayNameConfigKey, "")
+	if gateway == "" {
+		return nil, errors.NewNotValid(nil, gatewayNameConfigKey+" is required to expose through a gateway")
+	}
+	parentRef := map[string]interface{}{"name": gateway}
+	if namespace := config.GetString(gatewayNamespaceConfigKey, ""); namespace != "" {
+		parentRef["namespace"] = namespace
+	}
+
+	var rules []interface{}
+	for _, route := range routes {
+		rules = append(rules, map[string]interface{}{
+			"matches": []interface{}{
+				map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":  "PathPrefix",
+						"value": route.path,
+					},
+				},
+			},
+			"backendRefs": []interface{}{
+				map[string]interface{}{
+					"name": serviceName,
+					"port": int64(route.port),
+				},
+			},
+		})
+	}
+
+	httpRoute := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{parentRef},
+			"hostnames":  []interface{}{host},
+			"rules":      rules,
+		},
+	}}
+	httpRoute.SetAPIVersion(httpRouteResource.GroupVersion().String())
+	httpRoute.SetKind("HTTPRoute")
+	httpRoute.SetName(name)
+	httpRoute.SetLabels(labels)
+	return httpRoute, nil
+}
+
+func (k *kubernetesClient) ensureHTTPRoute(route *unstructured.Unstructured) error {
+	api := k.dynamicClient().Resource(httpRouteResource).Namespace(k.namespace)
+	_, _, err := ensureCustomResource(api, route)
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteHTTPRoute(name string) error {
+	err := k.dynamicClient().Resource(httpRouteResource).Namespace(k.namespace).Delete(context.TODO(), name, v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	})
+	// The Gateway API CRDs may not be installed at all.
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
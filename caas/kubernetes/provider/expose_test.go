@@ -0,0 +1,251 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/core/application"
+)
+
+var httpRouteResource = schema.GroupVersionResource{
+	Group:    "gateway.networking.k8s.io",
+	Version:  "v1beta1",
+	Resource: "httproutes",
+}
+
+func exposeTestService() *core.Service {
+	return &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{
+				{Name: "website", Port: 80, TargetPort: intstr.FromInt(8080)},
+				{Name: "api", Port: 9000, TargetPort: intstr.FromInt(9000)},
+			},
+		},
+	}
+}
+
+func exposeTestIngress(paths []v1beta1.HTTPIngressPath) *v1beta1.Ingress {
+	return &v1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "app-name",
+			Labels: map[string]string{
+				"juju-app":        "app-name",
+				"juju-model-uuid": "deadbeef",
+			},
+			Annotations: map[string]string{
+				"ingress.kubernetes.io/rewrite-target":  "",
+				"ingress.kubernetes.io/ssl-redirect":    "false",
+				"kubernetes.io/ingress.class":           "nginx",
+				"kubernetes.io/ingress.allow-http":      "false",
+				"ingress.kubernetes.io/ssl-passthrough": "false",
+			},
+		},
+		Spec: v1beta1.IngressSpec{
+			Rules: []v1beta1.IngressRule{{
+				Host: "example.com",
+				IngressRuleValue: v1beta1.IngressRuleValue{
+					HTTP: &v1beta1.HTTPIngressRuleValue{Paths: paths},
+				},
+			}},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestExposeService(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ingress := exposeTestIngress([]v1beta1.HTTPIngressPath{{
+		Path:    "/",
+		Backend: v1beta1.IngressBackend{ServiceName: "app-name", ServicePort: intstr.FromInt(8080)},
+	}})
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(exposeTestService(), nil),
+		s.mockIngressInterface.EXPECT().Create(gomock.Any(), ingress, v1.CreateOptions{}).
+			Return(ingress, nil),
+		s.mockDynamicClient.EXPECT().Resource(httpRouteResource).
+			Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.ExposeService("app-name", map[string]string{"juju-model-uuid": "deadbeef"}, application.ConfigAttributes{
+		"juju-external-hostname": "example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceEndpointPathsWithTLS(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ingress := exposeTestIngress([]v1beta1.HTTPIngressPath{{
+		Path:    "/api",
+		Backend: v1beta1.IngressBackend{ServiceName: "app-name", ServicePort: intstr.FromString("api")},
+	}, {
+		Path:    "/",
+		Backend: v1beta1.IngressBackend{ServiceName: "app-name", ServicePort: intstr.FromString("website")},
+	}})
+	ingress.Annotations["cert-manager.io/cluster-issuer"] = "letsencrypt"
+	ingress.Spec.TLS = []v1beta1.IngressTLS{{
+		Hosts:      []string{"example.com"},
+		SecretName: "app-name-tls",
+	}}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(exposeTestService(), nil),
+		s.mockIngressInterface.EXPECT().Create(gomock.Any(), ingress, v1.CreateOptions{}).
+			Return(nil, s.k8sAlreadyExistsError()),
+		s.mockIngressInterface.EXPECT().Update(gomock.Any(), ingress, v1.UpdateOptions{}).
+			Return(ingress, nil),
+		s.mockDynamicClient.EXPECT().Resource(httpRouteResource).
+			Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.ExposeService("app-name", map[string]string{"juju-model-uuid": "deadbeef"}, application.ConfigAttributes{
+		"juju-external-hostname":                 "example.com",
+		"kubernetes-ingress-cert-cluster-issuer": "letsencrypt",
+		"kubernetes-ingress-endpoint-paths":      map[string]interface{}{"website": "/", "api": "api"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceEndpointPathWithoutPort(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(exposeTestService(), nil),
+	)
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname":            "example.com",
+		"kubernetes-ingress-endpoint-paths": map[string]interface{}{"admin": "/admin"},
+	})
+	c.Assert(err, gc.ErrorMatches, `path for endpoint "admin" without a service port of the same name not valid`)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceGateway(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1beta1",
+		"kind":       "HTTPRoute",
+		"metadata": map[string]interface{}{
+			"name": "app-name",
+			"labels": map[string]interface{}{
+				"juju-app":        "app-name",
+				"juju-model-uuid": "deadbeef",
+			},
+		},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"name": "public", "namespace": "gateways"},
+			},
+			"hostnames": []interface{}{"example.com"},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"matches": []interface{}{
+						map[string]interface{}{
+							"path": map[string]interface{}{"type": "PathPrefix", "value": "/app-name"},
+						},
+					},
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": "app-name", "port": int64(80)},
+					},
+				},
+			},
+		},
+	}}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(exposeTestService(), nil),
+		s.mockDynamicClient.EXPECT().Resource(httpRouteResource).
+			Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Create(gomock.Any(), route, v1.CreateOptions{}).
+			Return(route, nil),
+		s.mockIngressInterface.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.ExposeService("app-name", map[string]string{"juju-model-uuid": "deadbeef"}, application.ConfigAttributes{
+		"juju-external-hostname":       "example.com",
+		"juju-application-path":        "$appname",
+		"kubernetes-expose-mode":       "gateway",
+		"kubernetes-gateway-name":      "public",
+		"kubernetes-gateway-namespace": "gateways",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceGatewayRequiresName(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(exposeTestService(), nil),
+	)
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname": "example.com",
+		"kubernetes-expose-mode": "gateway",
+	})
+	c.Assert(err, gc.ErrorMatches, `kubernetes-gateway-name is required to expose through a gateway`)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceInvalidMode(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname": "example.com",
+		"kubernetes-expose-mode": "loadbalancer",
+	})
+	c.Assert(err, gc.ErrorMatches, `expose mode "loadbalancer" not valid`)
+}
+
+func (s *K8sBrokerSuite) TestUnexposeService(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+		s.mockDynamicClient.EXPECT().Resource(httpRouteResource).
+			Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.UnexposeService("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+}
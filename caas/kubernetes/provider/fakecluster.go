@@ -0,0 +1,570 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build k8sfake
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	apiextensionsscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const (
+	fakeNodeName         = "fake-node"
+	fakeStorageClassName = "fake-storage"
+	fakeProvisioner      = "juju.is/fake"
+)
+
+var (
+	podsResource        = core.SchemeGroupVersion.WithResource("pods")
+	podKind             = core.SchemeGroupVersion.WithKind("Pod")
+	pvcsResource        = core.SchemeGroupVersion.WithResource("persistentvolumeclaims")
+	pvsResource         = core.SchemeGroupVersion.WithResource("persistentvolumes")
+	deploymentResource  = apps.SchemeGroupVersion.WithResource("deployments")
+	deploymentKind      = apps.SchemeGroupVersion.WithKind("Deployment")
+	statefulSetResource = apps.SchemeGroupVersion.WithResource("statefulsets")
+	statefulSetKind     = apps.SchemeGroupVersion.WithKind("StatefulSet")
+	daemonSetResource   = apps.SchemeGroupVersion.WithResource("daemonsets")
+	daemonSetKind       = apps.SchemeGroupVersion.WithKind("DaemonSet")
+)
+
+// fakeCluster is an in-memory Kubernetes cluster built on the client-go
+// fake clientsets. There is no scheduler or kubelet; instead, the cluster
+// simulates them, so that workloads get running pods, services get
+// addresses and volume claims get bound as soon as they're created.
+type fakeCluster struct {
+	k8sClient           *fake.Clientset
+	apiextensionsClient *apiextensionsfake.Clientset
+	dynamicClient       *dynamicfake.FakeDynamicClient
+
+	// mu guards the counters used to name pods and allocate addresses,
+	// and the agents of the pods.
+	mu         sync.Mutex
+	nextPod    int
+	nextPodIP  int
+	nextSvcIP  int
+	nextVolume int
+	agents     map[string]*fakeAgent
+}
+
+var fakeClusters = struct {
+	sync.Mutex
+	clusters map[string]*fakeCluster
+}{clusters: make(map[string]*fakeCluster)}
+
+// fakeClusterFor returns the in-memory cluster with the specified endpoint,
+// creating it if necessary. Every broker opened on the same endpoint within
+// a process shares the cluster.
+func fakeClusterFor(endpoint string) *fakeCluster {
+	fakeClusters.Lock()
+	defer fakeClusters.Unlock()
+	if c, ok := fakeClusters.clusters[endpoint]; ok {
+		return c
+	}
+	c := newFakeCluster()
+	fakeClusters.clusters[endpoint] = c
+	return c
+}
+
+// ResetFakeClusters discards all the in-memory clusters used by the
+// k8s-fake provider, stopping the agents of their pods.
+func ResetFakeClusters() {
+	fakeClusters.Lock()
+	defer fakeClusters.Unlock()
+	for _, c := range fakeClusters.clusters {
+		c.stopAgents()
+	}
+	fakeClusters.clusters = make(map[string]*fakeCluster)
+}
+
+func newFakeCluster() *fakeCluster {
+	c := &fakeCluster{
+		k8sClient: fake.NewSimpleClientset(
+			&core.Node{
+				ObjectMeta: v1.ObjectMeta{
+					Name:   fakeNodeName,
+					Labels: map[string]string{"kubernetes.io/hostname": fakeNodeName},
+				},
+				Status: core.NodeStatus{
+					Conditions: []core.NodeCondition{{Type: core.NodeReady, Status: core.ConditionTrue}},
+				},
+			},
+			&storagev1.StorageClass{
+				ObjectMeta: v1.ObjectMeta{
+					Name: fakeStorageClassName,
+					Annotations: map[string]string{
+						"storageclass.kubernetes.io/is-default-class": "true",
+					},
+				},
+				Provisioner: fakeProvisioner,
+			},
+		),
+		apiextensionsClient: apiextensionsfake.NewSimpleClientset(),
+		agents:              make(map[string]*fakeAgent),
+	}
+	// The fake clientsets don't implement deleting collections.
+	c.k8sClient.PrependReactor("delete-collection", "*",
+		deleteCollectionReaction(c.k8sClient.Tracker(), schemeKindFor(k8sscheme.Scheme)))
+	c.apiextensionsClient.PrependReactor("delete-collection", "*",
+		deleteCollectionReaction(c.apiextensionsClient.Tracker(), schemeKindFor(apiextensionsscheme.Scheme)))
+
+	// The fake dynamic client doesn't expose its tracker, so it's given
+	// one which can be used to delete collections.
+	dynamicScheme := runtime.NewScheme()
+	c.dynamicClient = dynamicfake.NewSimpleDynamicClient(dynamicScheme)
+	dynamicTracker := k8stesting.NewObjectTracker(dynamicScheme, serializer.NewCodecFactory(dynamicScheme).UniversalDecoder())
+	c.dynamicClient.PrependReactor("*", "*", k8stesting.ObjectReaction(dynamicTracker))
+	c.dynamicClient.PrependReactor("delete-collection", "*", deleteCollectionReaction(dynamicTracker, unstructuredKindFor))
+	c.dynamicClient.PrependWatchReactor("*", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		w, err := dynamicTracker.Watch(action.GetResource(), action.GetNamespace())
+		return err == nil, w, err
+	})
+
+	for _, resource := range []string{"deployments", "statefulsets", "daemonsets"} {
+		c.k8sClient.PrependReactor("*", resource, c.reactWorkload)
+	}
+	c.k8sClient.PrependReactor("create", "services", c.reactService)
+	c.k8sClient.PrependReactor("create", "persistentvolumeclaims", c.reactVolumeClaim)
+	c.k8sClient.PrependReactor("delete", "pods", c.reactPodDeleted)
+	return c
+}
+
+func (c *fakeCluster) tracker() k8stesting.ObjectTracker {
+	return c.k8sClient.Tracker()
+}
+
+// reactWorkload applies a change to a deployment, stateful set or daemon
+// set, then brings the pods of the namespace in line with the workloads.
+func (c *fakeCluster) reactWorkload(action k8stesting.Action) (bool, runtime.Object, error) {
+	switch action.GetVerb() {
+	case "get", "list", "watch":
+		return false, nil, nil
+	}
+	if a, ok := action.(k8stesting.CreateAction); ok {
+		setWorkloadStatus(a.GetObject())
+	} else if a, ok := action.(k8stesting.UpdateAction); ok {
+		setWorkloadStatus(a.GetObject())
+	}
+	react := k8stesting.ObjectReaction(c.tracker())
+	if action.GetVerb() == "delete-collection" {
+		react = deleteCollectionReaction(c.tracker(), schemeKindFor(k8sscheme.Scheme))
+	}
+	handled, obj, err := react(action)
+	if err != nil {
+		return handled, obj, err
+	}
+	return handled, obj, c.syncPods(action.GetNamespace())
+}
+
+// deleteCollectionReaction returns a reaction which deletes the objects
+// matching the label selector of a delete collection action.
+func deleteCollectionReaction(
+	tracker k8stesting.ObjectTracker,
+	kindFor func(schema.GroupVersionResource) schema.GroupVersionKind,
+) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		deleteAction, ok := action.(k8stesting.DeleteCollectionAction)
+		if !ok {
+			return false, nil, nil
+		}
+		gvr := action.GetResource()
+		list, err := tracker.List(gvr, kindFor(gvr), action.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return true, nil, err
+		}
+		selector := deleteAction.GetListRestrictions().Labels
+		for _, item := range items {
+			objMeta, err := meta.Accessor(item)
+			if err != nil {
+				return true, nil, err
+			}
+			if selector != nil && !selector.Matches(k8slabels.Set(objMeta.GetLabels())) {
+				continue
+			}
+			err = tracker.Delete(gvr, objMeta.GetNamespace(), objMeta.GetName())
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return true, nil, err
+			}
+		}
+		return true, nil, nil
+	}
+}
+
+// schemeKindFor returns a function which finds the kind of the objects of
+// a resource known to the scheme.
+func schemeKindFor(scheme *runtime.Scheme) func(schema.GroupVersionResource) schema.GroupVersionKind {
+	return func(gvr schema.GroupVersionResource) schema.GroupVersionKind {
+		for gvk := range scheme.AllKnownTypes() {
+			if gvk.GroupVersion() != gvr.GroupVersion() {
+				continue
+			}
+			if plural, _ := meta.UnsafeGuessKindToResource(gvk); plural == gvr {
+				return gvk
+			}
+		}
+		return schema.GroupVersionKind{}
+	}
+}
+
+// unstructuredKindFor returns the kind the fake dynamic client uses when
+// listing custom resources.
+func unstructuredKindFor(schema.GroupVersionResource) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "fake-dynamic-client-group", Version: "v1"}
+}
+
+// setWorkloadStatus reports every replica of a workload as ready.
+func setWorkloadStatus(obj runtime.Object) {
+	switch w := obj.(type) {
+	case *apps.Deployment:
+		replicas := workloadReplicas(w.Spec.Replicas)
+		w.Status.Replicas, w.Status.ReadyReplicas = replicas, replicas
+		w.Status.AvailableReplicas, w.Status.UpdatedReplicas = replicas, replicas
+	case *apps.StatefulSet:
+		replicas := workloadReplicas(w.Spec.Replicas)
+		w.Status.Replicas, w.Status.ReadyReplicas = replicas, replicas
+		w.Status.CurrentReplicas, w.Status.UpdatedReplicas = replicas, replicas
+	case *apps.DaemonSet:
+		w.Status.CurrentNumberScheduled, w.Status.DesiredNumberScheduled = 1, 1
+		w.Status.NumberReady, w.Status.NumberAvailable = 1, 1
+	}
+}
+
+func workloadReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// fakeWorkload is what the simulated controllers need to know about a
+// deployment, stateful set or daemon set.
+type fakeWorkload struct {
+	owner    v1.OwnerReference
+	replicas int
+	ordered  bool
+	template core.PodTemplateSpec
+	claims   []core.PersistentVolumeClaim
+}
+
+func (c *fakeCluster) listWorkloads(namespace string) (map[string]fakeWorkload, error) {
+	workloads := make(map[string]fakeWorkload)
+	owner := func(kind schema.GroupVersionKind, meta v1.ObjectMeta) v1.OwnerReference {
+		controller := true
+		return v1.OwnerReference{
+			APIVersion: kind.GroupVersion().String(),
+			Kind:       kind.Kind,
+			Name:       meta.Name,
+			UID:        meta.UID,
+			Controller: &controller,
+		}
+	}
+
+	obj, err := c.tracker().List(deploymentResource, deploymentKind, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range obj.(*apps.DeploymentList).Items {
+		w := fakeWorkload{
+			owner:    owner(deploymentKind, d.ObjectMeta),
+			replicas: int(workloadReplicas(d.Spec.Replicas)),
+			template: d.Spec.Template,
+		}
+		workloads[ownerKey(w.owner)] = w
+	}
+
+	obj, err = c.tracker().List(statefulSetResource, statefulSetKind, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range obj.(*apps.StatefulSetList).Items {
+		w := fakeWorkload{
+			owner:    owner(statefulSetKind, s.ObjectMeta),
+			replicas: int(workloadReplicas(s.Spec.Replicas)),
+			ordered:  true,
+			template: s.Spec.Template,
+			claims:   s.Spec.VolumeClaimTemplates,
+		}
+		workloads[ownerKey(w.owner)] = w
+	}
+
+	obj, err = c.tracker().List(daemonSetResource, daemonSetKind, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range obj.(*apps.DaemonSetList).Items {
+		w := fakeWorkload{
+			owner:    owner(daemonSetKind, d.ObjectMeta),
+			replicas: 1,
+			template: d.Spec.Template,
+		}
+		workloads[ownerKey(w.owner)] = w
+	}
+	return workloads, nil
+}
+
+func ownerKey(owner v1.OwnerReference) string {
+	return owner.Kind + "/" + owner.Name
+}
+
+// syncPods creates and deletes the pods in the namespace so that every
+// workload has as many running pods as it has replicas.
+func (c *fakeCluster) syncPods(namespace string) error {
+	workloads, err := c.listWorkloads(namespace)
+	if err != nil {
+		return err
+	}
+	obj, err := c.tracker().List(podsResource, podKind, namespace)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string][]string)
+	for _, pod := range obj.(*core.PodList).Items {
+		ref := v1.GetControllerOf(&pod)
+		if ref == nil {
+			continue
+		}
+		key := ownerKey(*ref)
+		if _, ok := workloads[key]; !ok {
+			// The workload has been deleted.
+			if err := c.deletePod(namespace, pod.Name); err != nil {
+				return err
+			}
+			continue
+		}
+		existing[key] = append(existing[key], pod.Name)
+	}
+
+	for key, w := range workloads {
+		pods := existing[key]
+		sort.Strings(pods)
+		if w.ordered {
+			// Stateful sets have a pod for each ordinal.
+			have := make(map[string]bool)
+			for _, name := range pods {
+				have[name] = true
+			}
+			for i := 0; i < w.replicas; i++ {
+				name := fmt.Sprintf("%s-%d", w.owner.Name, i)
+				if have[name] {
+					delete(have, name)
+					continue
+				}
+				if err := c.createPod(namespace, name, w); err != nil {
+					return err
+				}
+			}
+			for name := range have {
+				if err := c.deletePod(namespace, name); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		for i := len(pods); i < w.replicas; i++ {
+			if err := c.createPod(namespace, fmt.Sprintf("%s-%s", w.owner.Name, c.podSuffix()), w); err != nil {
+				return err
+			}
+		}
+		for i := w.replicas; i < len(pods); i++ {
+			if err := c.deletePod(namespace, pods[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deletePod deletes a pod, stopping its agent.
+func (c *fakeCluster) deletePod(namespace, name string) error {
+	c.stopAgent(namespace, name)
+	return c.tracker().Delete(podsResource, namespace, name)
+}
+
+// reactPodDeleted stops the agent of a pod being deleted.
+func (c *fakeCluster) reactPodDeleted(action k8stesting.Action) (bool, runtime.Object, error) {
+	c.stopAgent(action.GetNamespace(), action.(k8stesting.DeleteAction).GetName())
+	return false, nil, nil
+}
+
+func (c *fakeCluster) podSuffix() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextPod++
+	return fmt.Sprintf("%05x", c.nextPod)
+}
+
+func (c *fakeCluster) allocate(counter *int, format string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*counter++
+	return fmt.Sprintf(format, *counter/250, *counter%250+1)
+}
+
+// createPod creates a pod of a workload as if it had been scheduled and
+// all its containers had started, and runs its agent.
+func (c *fakeCluster) createPod(namespace, name string, w fakeWorkload) error {
+	pod := &core.Pod{
+		ObjectMeta: *w.template.ObjectMeta.DeepCopy(),
+		Spec:       *w.template.Spec.DeepCopy(),
+	}
+	pod.Name = name
+	pod.GenerateName = ""
+	pod.Namespace = namespace
+	pod.UID = k8stypes.UID(namespace + "-" + name)
+	pod.OwnerReferences = []v1.OwnerReference{w.owner}
+	pod.Spec.NodeName = fakeNodeName
+
+	// Like the stateful set controller, create a claim for each of the
+	// volume claim templates.
+	for _, template := range w.claims {
+		claimName := fmt.Sprintf("%s-%s", template.Name, name)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, core.Volume{
+			Name: template.Name,
+			VolumeSource: core.VolumeSource{
+				PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			},
+		})
+		if _, err := c.tracker().Get(pvcsResource, namespace, claimName); err == nil {
+			continue
+		}
+		claim := template.DeepCopy()
+		claim.Name = claimName
+		claim.Namespace = namespace
+		if err := c.bindVolumeClaim(claim); err != nil {
+			return err
+		}
+		if err := c.tracker().Create(pvcsResource, claim, namespace); err != nil {
+			return err
+		}
+	}
+
+	now := v1.Now()
+	pod.Status = core.PodStatus{
+		Phase:     core.PodRunning,
+		HostIP:    "10.0.0.1",
+		PodIP:     c.allocate(&c.nextPodIP, "10.1.%d.%d"),
+		StartTime: &now,
+		Conditions: []core.PodCondition{
+			{Type: core.PodScheduled, Status: core.ConditionTrue},
+			{Type: core.PodInitialized, Status: core.ConditionTrue},
+			{Type: core.ContainersReady, Status: core.ConditionTrue},
+			{Type: core.PodReady, Status: core.ConditionTrue},
+		},
+	}
+	for _, container := range pod.Spec.Containers {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, core.ContainerStatus{
+			Name:  container.Name,
+			Image: container.Image,
+			Ready: true,
+			State: core.ContainerState{
+				Running: &core.ContainerStateRunning{StartedAt: now},
+			},
+		})
+	}
+	if err := c.tracker().Create(podsResource, pod, namespace); err != nil {
+		return err
+	}
+	return c.startAgent(pod)
+}
+
+// reactService allocates addresses to a new service.
+func (c *fakeCluster) reactService(action k8stesting.Action) (bool, runtime.Object, error) {
+	svc, ok := action.(k8stesting.CreateAction).GetObject().(*core.Service)
+	if !ok {
+		return false, nil, nil
+	}
+	if svc.Spec.ClusterIP == "" {
+		svc.Spec.ClusterIP = c.allocate(&c.nextSvcIP, "10.152.%d.%d")
+	}
+	if svc.Spec.Type == core.ServiceTypeLoadBalancer && len(svc.Status.LoadBalancer.Ingress) == 0 {
+		svc.Status.LoadBalancer.Ingress = []core.LoadBalancerIngress{{
+			IP: c.allocate(&c.nextSvcIP, "10.153.%d.%d"),
+		}}
+	}
+	return false, nil, nil
+}
+
+// reactVolumeClaim provisions a volume for a new claim.
+func (c *fakeCluster) reactVolumeClaim(action k8stesting.Action) (bool, runtime.Object, error) {
+	claim, ok := action.(k8stesting.CreateAction).GetObject().(*core.PersistentVolumeClaim)
+	if !ok {
+		return false, nil, nil
+	}
+	return false, nil, c.bindVolumeClaim(claim)
+}
+
+// bindVolumeClaim creates a volume satisfying the claim, and binds the
+// claim to it.
+func (c *fakeCluster) bindVolumeClaim(claim *core.PersistentVolumeClaim) error {
+	if claim.Spec.VolumeName != "" {
+		return nil
+	}
+	c.mu.Lock()
+	c.nextVolume++
+	volumeName := fmt.Sprintf("pvc-%05x", c.nextVolume)
+	c.mu.Unlock()
+
+	storageClass := fakeStorageClassName
+	if claim.Spec.StorageClassName != nil {
+		storageClass = *claim.Spec.StorageClassName
+	}
+	capacity := core.ResourceList{
+		core.ResourceStorage: resource.MustParse("1Gi"),
+	}
+	if size, ok := claim.Spec.Resources.Requests[core.ResourceStorage]; ok {
+		capacity[core.ResourceStorage] = size
+	}
+	volume := &core.PersistentVolume{
+		ObjectMeta: v1.ObjectMeta{Name: volumeName},
+		Spec: core.PersistentVolumeSpec{
+			Capacity:         capacity,
+			AccessModes:      claim.Spec.AccessModes,
+			StorageClassName: storageClass,
+			ClaimRef: &core.ObjectReference{
+				Kind:      "PersistentVolumeClaim",
+				Namespace: claim.Namespace,
+				Name:      claim.Name,
+			},
+			PersistentVolumeSource: core.PersistentVolumeSource{
+				HostPath: &core.HostPathVolumeSource{Path: "/fake/" + volumeName},
+			},
+		},
+		Status: core.PersistentVolumeStatus{Phase: core.VolumeBound},
+	}
+	if err := c.tracker().Create(pvsResource, volume, ""); err != nil {
+		return err
+	}
+	claim.Spec.VolumeName = volumeName
+	claim.Status = core.PersistentVolumeClaimStatus{
+		Phase:       core.ClaimBound,
+		AccessModes: claim.Spec.AccessModes,
+		Capacity:    capacity,
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build k8sfake
+
+package provider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+	core "k8s.io/api/core/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	jujunames "github.com/juju/juju/juju/names"
+)
+
+var configMapsResource = core.SchemeGroupVersion.WithResource("configmaps")
+
+// jujudPath returns the path of the jujud binary which runs the agents
+// of operator pods.
+var jujudPath = func() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if filepath.Base(path) == jujunames.Jujud {
+		return path, nil
+	}
+	// Clients opening brokers have jujud alongside them, if at all.
+	path = filepath.Join(filepath.Dir(path), jujunames.Jujud)
+	if _, err := os.Stat(path); err != nil {
+		return "", errors.NotFoundf("jujud binary %q", path)
+	}
+	return path, nil
+}
+
+// fakeAgent is a jujud process running the agent of a pod.
+type fakeAgent struct {
+	cmd     *exec.Cmd
+	dataDir string
+	done    chan struct{}
+}
+
+// stop kills the agent, and removes its data directory once it has
+// exited.
+func (a *fakeAgent) stop() {
+	_ = a.cmd.Process.Kill()
+	<-a.done
+	_ = os.RemoveAll(a.dataDir)
+}
+
+func agentKey(namespace, podName string) string {
+	return namespace + "/" + podName
+}
+
+// startAgent runs the agent of an operator pod, as the kubelet would run
+// the pod's container. The container's config map mounts are written to
+// a data directory of the agent's own, and the agent is run from a jujud
+// binary on this machine. Other pods run no agent.
+func (c *fakeCluster) startAgent(pod *core.Pod) error {
+	if _, ok := pod.Labels[constants.LabelOperator]; !ok || len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+	jujud, err := jujudPath()
+	if errors.IsNotFound(err) {
+		logger.Debugf("not running the agent of pod %q: %v", pod.Name, err)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	container := pod.Spec.Containers[0]
+	env := podEnv(pod, container)
+	appName := env["JUJU_APPLICATION"]
+	if appName == "" {
+		return errors.NotValidf("operator pod %q without application", pod.Name)
+	}
+	dataDir, err := ioutil.TempDir("", "k8s-fake-"+pod.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	agent := &fakeAgent{dataDir: dataDir, done: make(chan struct{})}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(dataDir)
+		}
+	}()
+	if err = c.writeConfigMapMounts(pod, container, dataDir); err != nil {
+		return errors.Annotatef(err, "writing config of pod %q", pod.Name)
+	}
+
+	// Like the operator's start up script, run the agent from a copy
+	// of jujud in the tools directory, which the hook tools link to.
+	toolsDir := filepath.Join(dataDir, "tools")
+	if err = os.MkdirAll(toolsDir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	agentJujud := filepath.Join(toolsDir, jujunames.Jujud)
+	if err = os.Symlink(jujud, agentJujud); err != nil {
+		return errors.Trace(err)
+	}
+	logFile, err := os.Create(filepath.Join(dataDir, "agent.log"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	agent.cmd = exec.Command(agentJujud,
+		"caasoperator", "--application-name="+appName, "--data-dir", dataDir, "--debug")
+	agent.cmd.Env = append(os.Environ(), "JUJU_DATA_DIR="+dataDir, "JUJU_TOOLS_DIR="+toolsDir)
+	for name, value := range env {
+		agent.cmd.Env = append(agent.cmd.Env, name+"="+value)
+	}
+	agent.cmd.Stdout = logFile
+	agent.cmd.Stderr = logFile
+	if err = agent.cmd.Start(); err != nil {
+		_ = logFile.Close()
+		return errors.Annotatef(err, "starting the agent of pod %q", pod.Name)
+	}
+	go func() {
+		defer close(agent.done)
+		defer logFile.Close()
+		if err := agent.cmd.Wait(); err != nil {
+			logger.Debugf("agent of pod %q exited: %v", pod.Name, err)
+		}
+	}()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.agents[agentKey(pod.Namespace, pod.Name)] = agent
+	return nil
+}
+
+// stopAgent stops the agent of a pod, if it has one.
+func (c *fakeCluster) stopAgent(namespace, podName string) {
+	key := agentKey(namespace, podName)
+	c.mu.Lock()
+	agent := c.agents[key]
+	delete(c.agents, key)
+	c.mu.Unlock()
+	if agent != nil {
+		agent.stop()
+	}
+}
+
+// stopAgents stops the agents of all the pods in the cluster.
+func (c *fakeCluster) stopAgents() {
+	c.mu.Lock()
+	agents := c.agents
+	c.agents = make(map[string]*fakeAgent)
+	c.mu.Unlock()
+	for _, agent := range agents {
+		agent.stop()
+	}
+}
+
+// podEnv returns the environment of a pod's container, with the fields
+// of the pod it refers to resolved.
+func podEnv(pod *core.Pod, container core.Container) map[string]string {
+	env := make(map[string]string)
+	for _, e := range container.Env {
+		value := e.Value
+		if e.ValueFrom != nil && e.ValueFrom.FieldRef != nil {
+			switch e.ValueFrom.FieldRef.FieldPath {
+			case "metadata.name":
+				value = pod.Name
+			case "metadata.namespace":
+				value = pod.Namespace
+			case "status.podIP":
+				value = pod.Status.PodIP
+			case "status.hostIP":
+				value = pod.Status.HostIP
+			}
+		}
+		env[e.Name] = value
+	}
+	return env
+}
+
+// writeConfigMapMounts writes the config map items mounted in the
+// container's data directory to the same place in dataDir. Agent config
+// files are changed to use dataDir in place of the container's paths.
+func (c *fakeCluster) writeConfigMapMounts(pod *core.Pod, container core.Container, dataDir string) error {
+	volumes := make(map[string]*core.ConfigMapVolumeSource)
+	for _, vol := range pod.Spec.Volumes {
+		if vol.ConfigMap != nil {
+			volumes[vol.Name] = vol.ConfigMap
+		}
+	}
+	for _, mount := range container.VolumeMounts {
+		source := volumes[mount.Name]
+		if source == nil {
+			continue
+		}
+		rel, err := filepath.Rel(container.WorkingDir, mount.MountPath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return errors.NotValidf("mount path %q outside %q", mount.MountPath, container.WorkingDir)
+		}
+		// Pods are created while the client is reacting to a change,
+		// so the tracker has to be used rather than the client.
+		obj, err := c.tracker().Get(configMapsResource, pod.Namespace, source.Name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cm := obj.(*core.ConfigMap)
+		key := mount.SubPath
+		for _, item := range source.Items {
+			if item.Path == mount.SubPath {
+				key = item.Key
+			}
+		}
+		data, ok := cm.Data[key]
+		if !ok {
+			return errors.NotFoundf("key %q in config map %q", key, cm.Name)
+		}
+		content := []byte(data)
+		if mount.SubPath == constants.TemplateFileNameAgentConf {
+			if content, err = localAgentConf(content, dataDir); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		path := filepath.Join(dataDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return errors.Trace(err)
+		}
+		if err := ioutil.WriteFile(path, content, 0600); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// localAgentConf returns the agent config with its directories moved
+// into dataDir.
+func localAgentConf(conf []byte, dataDir string) ([]byte, error) {
+	parts := strings.SplitN(string(conf), "\n", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "# format ") {
+		return nil, errors.NotValidf("agent config without format")
+	}
+	var attrs yaml.MapSlice
+	if err := yaml.Unmarshal([]byte(parts[1]), &attrs); err != nil {
+		return nil, errors.Annotate(err, "reading agent config")
+	}
+	dirs := map[string]string{
+		"datadir":           dataDir,
+		"transient-datadir": filepath.Join(dataDir, "transient"),
+		"logdir":            filepath.Join(dataDir, "log"),
+		"metricsspooldir":   filepath.Join(dataDir, "metricspool"),
+	}
+	for i, item := range attrs {
+		if dir, ok := dirs[fmt.Sprint(item.Key)]; ok {
+			attrs[i].Value = dir
+			delete(dirs, fmt.Sprint(item.Key))
+		}
+	}
+	for _, key := range []string{"datadir", "transient-datadir", "logdir", "metricsspooldir"} {
+		if dir, ok := dirs[key]; ok {
+			attrs = append(attrs, yaml.MapItem{Key: key, Value: dir})
+		}
+	}
+	out, err := yaml.Marshal(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append([]byte(parts[0]+"\n"), out...), nil
+}
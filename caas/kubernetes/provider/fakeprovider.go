@@ -0,0 +1,161 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build k8sfake
+
+package provider
+
+import (
+	jujuclock "github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/juju/juju/caas"
+	k8swatcher "github.com/juju/juju/caas/kubernetes/provider/watcher"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+)
+
+// FakeProviderType is the provider type of the in-memory Kubernetes
+// cluster, for exercising the CAAS workers without a real cluster.
+const FakeProviderType = cloud.CloudTypeCAASFake
+
+func init() {
+	caas.RegisterContainerProvider(FakeProviderType, fakeEnvironProvider{})
+}
+
+// fakeEnvironProvider opens brokers on in-memory clusters, which live in
+// the process opening them. Each endpoint of a k8s-fake cloud identifies a
+// separate cluster; for example:
+//
+//	clouds:
+//	  fake:
+//	    type: k8s-fake
+//	    auth-types: [empty]
+//	    endpoint: fake://cluster
+//
+// Models on the cloud can be added to a controller, and applications
+// deployed to them. The agents of their operator pods are run as jujud
+// processes on the machine hosting the cluster.
+//
+// A controller can also be bootstrapped in the cluster, which creates the
+// controller stack as on any other cluster, with its pod reported as
+// running. The controller pod needs a mongod, so no agent is run for it.
+//
+// The provider is only registered in binaries built with the k8sfake tag:
+//
+//	go install -tags k8sfake github.com/juju/juju/cmd/juju github.com/juju/juju/cmd/jujud
+type fakeEnvironProvider struct{}
+
+var (
+	_ caas.ContainerEnvironProvider = fakeEnvironProvider{}
+	_ environs.CloudFinalizer         = fakeEnvironProvider{}
+)
+
+// Version is part of the EnvironProvider interface.
+func (fakeEnvironProvider) Version() int {
+	return 0
+}
+
+// CloudSchema is part of the EnvironProvider interface.
+func (fakeEnvironProvider) CloudSchema() *jsonschema.Schema {
+	return nil
+}
+
+// Ping is part of the EnvironProvider interface.
+func (fakeEnvironProvider) Ping(context.ProviderCallContext, string) error {
+	return nil
+}
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (fakeEnvironProvider) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{cloud.EmptyAuthType: {}}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+func (fakeEnvironProvider) DetectCredentials() (*cloud.CloudCredential, error) {
+	return cloud.NewEmptyCloudCredential(), nil
+}
+
+// FinalizeCredential is part of the environs.ProviderCredentials interface.
+func (fakeEnvironProvider) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
+
+// FinalizeCloud is part of the environs.CloudFinalizer interface.
+func (fakeEnvironProvider) FinalizeCloud(_ environs.FinalizeCloudContext, cld cloud.Cloud) (cloud.Cloud, error) {
+	// Bootstrapping requires the cloud hosting the cluster, which
+	// for an in-memory cluster is the fake cloud itself.
+	if cld.HostCloudRegion == "" {
+		cld.HostCloudRegion = FakeProviderType
+	}
+	return cld, nil
+}
+
+// PrepareConfig is part of the EnvironProvider interface.
+func (fakeEnvironProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := args.Cloud.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return args.Config.Apply(map[string]interface{}{
+		config.StorageDefaultBlockSourceKey:      K8s_ProviderType,
+		config.StorageDefaultFilesystemSourceKey: K8s_ProviderType,
+		OperatorStorageKey:                       fakeStorageClassName,
+		WorkloadStorageKey:                       fakeStorageClassName,
+	})
+}
+
+// Validate is part of the config.Validator interface.
+func (fakeEnvironProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	return providerInstance.Validate(cfg, old)
+}
+
+// Schema returns the configuration schema for the provider.
+func (fakeEnvironProvider) Schema() environschema.Fields {
+	return providerInstance.Schema()
+}
+
+// ConfigSchema returns extra config attributes specific to the provider.
+func (fakeEnvironProvider) ConfigSchema() schema.Fields {
+	return providerInstance.ConfigSchema()
+}
+
+// ConfigDefaults returns the default values for the provider specific
+// config attributes.
+func (fakeEnvironProvider) ConfigDefaults() schema.Defaults {
+	return providerInstance.ConfigDefaults()
+}
+
+// Open is part of the ContainerEnvironProvider interface.
+func (fakeEnvironProvider) Open(args environs.OpenParams) (caas.Broker, error) {
+	if err := args.Cloud.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	if args.Cloud.Endpoint == "" {
+		return nil, errors.NotValidf("missing endpoint")
+	}
+	cluster := fakeClusterFor(args.Cloud.Endpoint)
+	newClient := func(*rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, dynamic.Interface, error) {
+		return cluster.k8sClient, cluster.apiextensionsClient, cluster.dynamicClient, nil
+	}
+	broker, err := newK8sBroker(
+		args.ControllerUUID, &rest.Config{Host: args.Cloud.Endpoint}, args.Config, args.Config.Name(),
+		newClient, newFakeRestClient, k8swatcher.NewKubernetesNotifyWatcher, k8swatcher.NewKubernetesStringsWatcher,
+		randomPrefix, jujuclock.WallClock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return broker, nil
+}
+
+func newFakeRestClient(*rest.Config) (rest.Interface, error) {
+	return nil, errors.NotSupportedf("raw kubernetes resources on %s clouds", FakeProviderType)
+}
@@ -0,0 +1,295 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build k8sfake
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/testing"
+	"github.com/juju/version"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/caas/specs"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/cloudconfig/podcfg"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	envcontext "github.com/juju/juju/environs/context"
+	envtesting "github.com/juju/juju/environs/testing"
+	"github.com/juju/juju/mongo"
+	coretesting "github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type fakeProviderSuite struct {
+	testing.IsolationSuite
+	broker caas.Broker
+}
+
+var _ = gc.Suite(&fakeProviderSuite{})
+
+func (s *fakeProviderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	provider.ResetFakeClusters()
+	s.AddCleanup(func(*gc.C) { provider.ResetFakeClusters() })
+
+	s.broker = s.openBroker(c, "fake://cluster")
+	err := s.broker.Create(envcontext.NewCloudCallContext(), environs.CreateParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *fakeProviderSuite) openBroker(c *gc.C, endpoint string) caas.Broker {
+	p, err := environs.Provider("k8s-fake")
+	c.Assert(err, jc.ErrorIsNil)
+	cred := cloud.NewEmptyCredential()
+	cloudSpec := environscloudspec.CloudSpec{
+		Type:       "k8s-fake",
+		Name:       "fake",
+		Endpoint:   endpoint,
+		Credential: &cred,
+	}
+	cfg, err := p.PrepareConfig(environs.PrepareConfigParams{
+		Cloud:  cloudSpec,
+		Config: fakeConfig(c, coretesting.Attrs{"type": "k8s-fake"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	broker, err := p.(caas.ContainerEnvironProvider).Open(environs.OpenParams{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		Cloud:          cloudSpec,
+		Config:         cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return broker
+}
+
+func (s *fakeProviderSuite) TestCAASCloudType(c *gc.C) {
+	c.Assert(cloud.CloudTypeIsCAAS("k8s-fake"), jc.IsTrue)
+}
+
+func (s *fakeProviderSuite) TestBootstrap(c *gc.C) {
+	// Bootstrap on a cluster of its own, without the model of the suite.
+	broker := s.openBroker(c, "fake://bootstrap")
+	ctx := envtesting.BootstrapContext(c)
+	err := broker.PrepareForBootstrap(ctx, "fake")
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := broker.Bootstrap(ctx, envcontext.NewCloudCallContext(), environs.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerCfg := coretesting.FakeControllerConfig()
+	pcfg, err := podcfg.NewBootstrapControllerPodConfig(controllerCfg, "fake", "bionic", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	pcfg.JujuVersion = jujuversion.Current
+	pcfg.APIInfo = &api.Info{Password: "password", CACert: coretesting.CACert, ModelTag: coretesting.ModelTag}
+	pcfg.Controller.MongoInfo = &mongo.MongoInfo{Password: "password", Info: mongo.Info{CACert: coretesting.CACert}}
+	pcfg.Bootstrap.ControllerModelConfig = fakeConfig(c, coretesting.Attrs{"type": "k8s-fake"})
+	pcfg.Bootstrap.BootstrapMachineInstanceId = "instance-id"
+	pcfg.Bootstrap.Timeout = coretesting.LongWait
+	pcfg.Bootstrap.StateServingInfo = controller.StateServingInfo{
+		Cert:         coretesting.ServerCert,
+		PrivateKey:   coretesting.ServerKey,
+		CAPrivateKey: coretesting.CAKey,
+		StatePort:    123,
+		APIPort:      456,
+	}
+	pcfg.Bootstrap.ControllerConfig = controllerCfg
+	p, err := environs.Provider("k8s-fake")
+	c.Assert(err, jc.ErrorIsNil)
+	pcfg.Bootstrap.ControllerCloud, err = p.(environs.CloudFinalizer).FinalizeCloud(nil, cloud.Cloud{
+		Name: "fake", Type: "k8s-fake", Endpoint: "fake://bootstrap",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = result.CaasBootstrapFinalizer(ctx, pcfg, environs.BootstrapDialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := provider.FakeClusterClient("fake://bootstrap")
+	pod, err := client.CoreV1().Pods("controller-fake").Get(context.TODO(), "controller-0", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Status.Phase, gc.Equals, core.PodRunning)
+	svc, err := client.CoreV1().Services("controller-fake").Get(context.TODO(), "controller-service", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Spec.ClusterIP, gc.Not(gc.Equals), "")
+}
+
+func (s *fakeProviderSuite) ensureService(c *gc.C, deploymentType caas.DeploymentType, numUnits int) {
+	podSpec := &specs.PodSpec{}
+	podSpec.Containers = []specs.ContainerSpec{{
+		Name:         "mariadb",
+		Ports:        []specs.ContainerPort{{ContainerPort: 3306, Protocol: "TCP", Name: "db"}},
+		ImageDetails: specs.ImageDetails{ImagePath: "mariadb/latest"},
+	}}
+	params := &caas.ServiceParams{
+		PodSpec:    podSpec,
+		Deployment: caas.DeploymentParams{DeploymentType: deploymentType},
+	}
+	err := s.broker.EnsureService("mariadb", func(string, status.Status, string, map[string]interface{}) error { return nil },
+		params, numUnits, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *fakeProviderSuite) unitIds(c *gc.C) []string {
+	units, err := s.broker.Units("mariadb", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, u := range units {
+		c.Check(u.Status.Status, gc.Equals, status.Running)
+		c.Check(u.Address, gc.Not(gc.Equals), "")
+		ids = append(ids, u.Id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (s *fakeProviderSuite) TestStatelessWorkload(c *gc.C) {
+	s.ensureService(c, caas.DeploymentStateless, 2)
+	c.Assert(s.unitIds(c), gc.HasLen, 2)
+
+	svc, err := s.broker.GetService("mariadb", caas.ModeWorkload, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Addresses, gc.HasLen, 1)
+	c.Assert(svc.Status.Status, gc.Equals, status.Active)
+
+	s.ensureService(c, caas.DeploymentStateless, 1)
+	c.Assert(s.unitIds(c), gc.HasLen, 1)
+
+	err = s.broker.DeleteService("mariadb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unitIds(c), gc.HasLen, 0)
+}
+
+func (s *fakeProviderSuite) TestStatefulWorkload(c *gc.C) {
+	s.ensureService(c, caas.DeploymentStateful, 3)
+	c.Assert(s.unitIds(c), jc.DeepEquals, []string{"mariadb-0", "mariadb-1", "mariadb-2"})
+
+	s.ensureService(c, caas.DeploymentStateful, 1)
+	c.Assert(s.unitIds(c), jc.DeepEquals, []string{"mariadb-0"})
+}
+
+func (s *fakeProviderSuite) TestVolumeClaimsBound(c *gc.C) {
+	client := provider.FakeClusterClient("fake://cluster")
+	replicas := int32(1)
+	claimTemplate := core.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: "data"},
+		Spec: core.PersistentVolumeClaimSpec{
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+	}
+	_, err := client.AppsV1().StatefulSets("test").Create(context.TODO(), &apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{Name: "db"},
+		Spec: apps.StatefulSetSpec{
+			Replicas: &replicas,
+			Template: core.PodTemplateSpec{
+				Spec: core.PodSpec{Containers: []core.Container{{Name: "db"}}},
+			},
+			VolumeClaimTemplates: []core.PersistentVolumeClaim{claimTemplate},
+		},
+	}, v1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pod, err := client.CoreV1().Pods("test").Get(context.TODO(), "db-0", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Status.Phase, gc.Equals, core.PodRunning)
+	c.Assert(pod.Spec.Volumes, gc.HasLen, 1)
+	c.Assert(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName, gc.Equals, "data-db-0")
+
+	claim, err := client.CoreV1().PersistentVolumeClaims("test").Get(context.TODO(), "data-db-0", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claim.Status.Phase, gc.Equals, core.ClaimBound)
+	volume, err := client.CoreV1().PersistentVolumes().Get(context.TODO(), claim.Spec.VolumeName, v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	size := volume.Spec.Capacity[core.ResourceStorage]
+	c.Assert(size.String(), gc.Equals, "5Gi")
+}
+
+const fakeOperatorAgentConf = `# format 2.0
+tag: application-mariadb
+controller: controller-deadbeef-1bad-500d-9000-4b1d0d06f00d
+model: model-deadbeef-0bad-400d-8000-4b1d0d06f00d
+datadir: /var/lib/juju
+logdir: /var/log/juju
+upgradedToVersion: 2.9.0
+`
+
+func (s *fakeProviderSuite) TestOperatorAgentRuns(c *gc.C) {
+	// The fake jujud records how it was run, then waits to be killed.
+	binDir := c.MkDir()
+	jujud := filepath.Join(binDir, "jujud")
+	err := ioutil.WriteFile(jujud, []byte(`#!/bin/sh
+env > "$JUJU_DATA_DIR/env"
+echo "$@" > "$JUJU_DATA_DIR/args"
+exec sleep 600
+`), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(provider.JujudPath, func() (string, error) { return jujud, nil })
+
+	err = s.broker.EnsureOperator("mariadb", "/var/lib/juju", &caas.OperatorConfig{
+		OperatorImagePath: "jujusolutions/jujud-operator",
+		Version:           version.MustParse("2.9.0"),
+		AgentConf:         []byte(fakeOperatorAgentConf),
+		OperatorInfo:      []byte("operator info"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	namespace := s.broker.GetCurrentNamespace()
+	dataDir := provider.FakeAgentDataDir("fake://cluster", namespace, "mariadb-operator-0")
+	c.Assert(dataDir, gc.Not(gc.Equals), "")
+	var args []byte
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if args, err = ioutil.ReadFile(filepath.Join(dataDir, "args")); err == nil && len(args) > 0 {
+			break
+		}
+	}
+	c.Assert(string(args), gc.Equals,
+		fmt.Sprintf("caasoperator --application-name=mariadb --data-dir %s --debug\n", dataDir))
+	env, err := ioutil.ReadFile(filepath.Join(dataDir, "env"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(env), jc.Contains, "JUJU_OPERATOR_NAMESPACE="+namespace+"\n")
+	c.Assert(string(env), jc.Contains, "JUJU_OPERATOR_POD_IP=10.1.")
+	c.Assert(string(env), jc.Contains, "JUJU_OPERATOR_SERVICE_IP=10.152.")
+
+	agentDir := filepath.Join(dataDir, "agents", "application-mariadb")
+	info, err := ioutil.ReadFile(filepath.Join(agentDir, "operator.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(info), gc.Equals, "operator info")
+	conf, err := agent.ReadConfig(filepath.Join(agentDir, "template-agent.conf"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.DataDir(), gc.Equals, dataDir)
+	c.Assert(conf.LogDir(), gc.Equals, filepath.Join(dataDir, "log"))
+
+	err = s.broker.DeleteOperator("mariadb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.FakeAgentDataDir("fake://cluster", namespace, "mariadb-operator-0"), gc.Equals, "")
+	_, err = os.Stat(dataDir)
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *fakeProviderSuite) TestWorkloadPodsRunNoAgent(c *gc.C) {
+	s.PatchValue(provider.JujudPath, func() (string, error) {
+		c.Fatalf("unexpected agent")
+		return "", nil
+	})
+	s.ensureService(c, caas.DeploymentStateful, 1)
+	c.Assert(s.unitIds(c), jc.DeepEquals, []string{"mariadb-0"})
+}
@@ -81,6 +81,8 @@ func (s *K8sBrokerSuite) assertIngressResources(c *gc.C, IngressResources []k8ss
 	ociImageSecret := s.getOCIImageSecret(c, nil)
 	if expectedErrString == "" {
 		// no error expected, so continue to check following assertions.
+		s.expectPodDisruptionBudgetsDeleted("app-name")
+		s.expectMonitorsDeleted()
 		assertCalls = append(assertCalls, []*gomock.Call{
 			s.mockSecrets.EXPECT().Create(gomock.Any(), ociImageSecret, v1.CreateOptions{}).
 				Return(ociImageSecret, nil),
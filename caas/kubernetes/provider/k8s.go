@@ -26,7 +26,6 @@ import (
 	"github.com/kr/pretty"
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
-	"k8s.io/api/extensions/v1beta1"
 	k8sstorage "k8s.io/api/storage/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -139,8 +138,11 @@ type kubernetesClient struct {
 // run "go generate" from the package directory.
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface,DaemonSetInterface
-//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 EventInterface,CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 EventInterface,CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface,ResourceQuotaInterface,LimitRangeInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/autoscalingv1_mock.go k8s.io/client-go/kubernetes/typed/autoscaling/v1 AutoscalingV1Interface,HorizontalPodAutoscalerInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/policyv1beta1_mock.go k8s.io/client-go/kubernetes/typed/policy/v1beta1 PolicyV1beta1Interface,PodDisruptionBudgetInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/networkingv1_mock.go k8s.io/client-go/kubernetes/typed/networking/v1 NetworkingV1Interface,NetworkPolicyInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,ClusterRoleBindingInterface,ClusterRoleInterface,RoleInterface,RoleBindingInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/apiextensions_mock.go k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1 ApiextensionsV1beta1Interface,CustomResourceDefinitionInterface
@@ -293,12 +295,20 @@ func (k *kubernetesClient) k8sConfig() *rest.Config {
 // SetConfig is specified in the Environ interface.
 func (k *kubernetesClient) SetConfig(cfg *config.Config) error {
 	k.lock.Lock()
-	defer k.lock.Unlock()
 	newCfg, err := providerInstance.newConfig(cfg)
 	if err != nil {
+		k.lock.Unlock()
 		return errors.Trace(err)
 	}
+	oldQuota := namespaceQuotaFromConfig(k.envCfgUnlocked)
 	k.envCfgUnlocked = newCfg.Config
+	k.lock.Unlock()
+
+	// Reapply any quota in case it was changed while the broker wasn't
+	// running, and remove it once it's no longer configured.
+	if newQuota := namespaceQuotaFromConfig(newCfg.Config); newQuota != oldQuota || !newQuota.empty() {
+		return errors.Annotate(k.ensureNamespaceQuota(newQuota), "updating namespace quota")
+	}
 	return nil
 }
 
@@ -365,7 +375,13 @@ Please bootstrap again and choose a different controller name.`, controllerName)
 // Create implements environs.BootstrapEnviron.
 func (k *kubernetesClient) Create(envcontext.ProviderCallContext, environs.CreateParams) error {
 	// must raise errors.AlreadyExistsf if it's already exist.
-	return k.createNamespace(k.namespace)
+	if err := k.createNamespace(k.namespace); err != nil {
+		return err
+	}
+	if quota := namespaceQuotaFromConfig(k.Config()); !quota.empty() {
+		return errors.Annotate(k.ensureNamespaceQuota(quota), "creating namespace quota")
+	}
+	return nil
 }
 
 // Bootstrap deploys controller with mongoDB together into k8s cluster.
@@ -759,6 +775,18 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteDaemonSets(appName); err != nil {
 		return errors.Trace(err)
 	}
+
+	if err := k.deleteHorizontalPodAutoscalers(appName); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := k.deletePodDisruptionBudgets(appName); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := k.deleteNetworkPolicies(appName); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -845,7 +873,7 @@ func processConstraints(pod *core.PodSpec, appName string, cons constraints.Valu
 		nodeSelector := &affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
 		nodeSelector.MatchExpressions = append(nodeSelector.MatchExpressions,
 			core.NodeSelectorRequirement{
-				Key:      "failure-domain.beta.kubernetes.io/zone",
+				Key:      zoneTopologyKey,
 				Operator: core.NodeSelectorOpIn,
 				Values:   zones,
 			})
@@ -914,6 +942,14 @@ func (k *kubernetesClient) EnsureService(
 		return errors.Errorf("number of units must be >= 0")
 	}
 	if numUnits == 0 {
+		// The autoscaler would otherwise scale the workload straight back up.
+		if autoscale, err := ParseAutoscaleConfig(config); err != nil {
+			return errors.Trace(err)
+		} else if autoscale != nil {
+			if err := k.deleteHorizontalPodAutoscaler(deploymentName); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		return k.deleteAllPods(appName, deploymentName)
 	}
 
@@ -1040,6 +1076,52 @@ func (k *kubernetesClient) ensureService(
 		logger.Debugf("created/updated ingress resources for %q.", appName)
 	}
 
+	// ensure pod disruption budget.
+	if workloadSpec.Availability != nil && workloadSpec.Availability.DisruptionBudget != nil {
+		pdbCleanUps, err := k.ensurePodDisruptionBudget(appName, deploymentName, annotations, *workloadSpec.Availability.DisruptionBudget)
+		cleanups = append(cleanups, pdbCleanUps...)
+		if err != nil {
+			return errors.Annotate(err, "creating or updating pod disruption budget")
+		}
+		logger.Debugf("created/updated pod disruption budget for %q.", appName)
+	} else if err := k.deletePodDisruptionBudgets(appName); err != nil {
+		// The budget may have been removed from the spec.
+		return errors.Annotate(err, "deleting pod disruption budget")
+	}
+
+	// The service exposes the container ports of the workload.
+	hasService := !params.PodSpec.OmitServiceFrontend && !params.Deployment.ServiceType.IsOmit()
+	var servicePorts []core.ContainerPort
+	if hasService {
+		for _, c := range workloadSpec.Pod.Containers {
+			for _, p := range c.Ports {
+				if p.ContainerPort == 0 {
+					continue
+				}
+				servicePorts = append(servicePorts, p)
+			}
+		}
+	}
+
+	// ensure prometheus monitor.
+	if workloadSpec.Monitoring != nil {
+		servicePortNames := set.NewStrings()
+		for _, p := range servicePorts {
+			if p.Name != "" {
+				servicePortNames.Add(p.Name)
+			}
+		}
+		monitorCleanUps, err := k.ensureMonitor(appName, annotations, *workloadSpec.Monitoring, servicePortNames)
+		cleanups = append(cleanups, monitorCleanUps...)
+		if err != nil {
+			return errors.Annotate(err, "creating or updating prometheus monitor")
+		}
+		logger.Debugf("created/updated prometheus monitor for %q.", appName)
+	} else if err := k.deleteMonitors(appName); err != nil {
+		// The monitoring may have been removed from the spec.
+		return errors.Annotate(err, "deleting prometheus monitor")
+	}
+
 	for _, sa := range workloadSpec.ServiceAccounts {
 		saCleanups, err := k.ensureServiceAccountForApp(appName, annotations, sa)
 		cleanups = append(cleanups, saCleanups...)
@@ -1056,6 +1138,7 @@ func (k *kubernetesClient) ensureService(
 	if err := processConstraints(&workloadSpec.Pod.PodSpec, appName, params.Constraints); err != nil {
 		return errors.Trace(err)
 	}
+	processAvailability(&workloadSpec.Pod.PodSpec, appName, workloadSpec.Availability, params.Constraints)
 
 	for _, c := range params.PodSpec.Containers {
 		if c.ImageDetails.Password == "" {
@@ -1093,18 +1176,16 @@ func (k *kubernetesClient) ensureService(
 		return errors.Trace(err)
 	}
 
-	hasService := !params.PodSpec.OmitServiceFrontend && !params.Deployment.ServiceType.IsOmit()
+	autoscale, err := ParseAutoscaleConfig(config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if autoscale != nil && autoscale.Enabled() && params.Deployment.DeploymentType == caas.DeploymentDaemon {
+		return errors.NewNotValid(nil, fmt.Sprintf("autoscaling is not supported for %s applications", caas.DeploymentDaemon))
+	}
+
 	if hasService {
-		var ports []core.ContainerPort
-		for _, c := range workloadSpec.Pod.Containers {
-			for _, p := range c.Ports {
-				if p.ContainerPort == 0 {
-					continue
-				}
-				ports = append(ports, p)
-			}
-		}
-		if len(ports) == 0 {
+		if len(servicePorts) == 0 {
 			return errors.Errorf("ports are required for kubernetes service %q", appName)
 		}
 
@@ -1121,12 +1202,19 @@ func (k *kubernetesClient) ensureService(
 		serviceAnnotations.Merge(k8sannotations.New(deployAnnotations))
 
 		config[serviceAnnotationsKey] = serviceAnnotations.ToMap()
-		if err := k.configureService(appName, deploymentName, ports, params, config); err != nil {
+		if err := k.configureService(appName, deploymentName, servicePorts, params, config); err != nil {
 			return errors.Annotatef(err, "creating or updating service for %v", appName)
 		}
 	}
 
 	numPods := int32(numUnits)
+	if autoscale != nil && autoscale.Enabled() {
+		// Keep the replica count chosen by the autoscaler rather than
+		// fighting it; the autoscaler keeps it within the configured bounds.
+		if numPods, err = k.autoscaledReplicas(deploymentName, numUnits); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	workloadResourceAnnotations := annotations.Copy().
 		// To solve https://bugs.launchpad.net/juju/+bug/1875481/comments/23 (`jujud caas-unit-init --upgrade`
 		// does NOT work on containers are not using root as default USER),
@@ -1161,6 +1249,17 @@ func (k *kubernetesClient) ensureService(
 		// This should never happened because we have validated both in this method and in `charm.v6`.
 		return errors.NotSupportedf("deployment type %q", params.Deployment.DeploymentType)
 	}
+
+	if autoscale == nil {
+		return nil
+	}
+	if !autoscale.Enabled() {
+		return errors.Annotate(k.deleteHorizontalPodAutoscaler(deploymentName), "deleting horizontal pod autoscaler")
+	}
+	hpa := k.horizontalPodAutoscalerSpec(appName, deploymentName, params.Deployment.DeploymentType, *autoscale)
+	if err := k.ensureHorizontalPodAutoscaler(hpa); err != nil {
+		return errors.Annotate(err, "creating or updating horizontal pod autoscaler")
+	}
 	return nil
 }
 
@@ -1566,8 +1665,10 @@ func (k *kubernetesClient) configureDeployment(
 		return cleanUps, errors.Trace(err)
 	}
 
+	var existing *apps.Deployment
 	storageUniqueID, err := k.getStorageUniqPrefix(func() (annotationGetter, error) {
-		return k.getDeployment(deploymentName)
+		existing, err = k.getDeployment(deploymentName)
+		return existing, err
 	})
 	if err != nil {
 		return cleanUps, errors.Trace(err)
@@ -1597,6 +1698,12 @@ func (k *kubernetesClient) configureDeployment(
 			},
 		},
 	}
+	if existing != nil {
+		deployment.Spec.Selector, deployment.Spec.Template.Labels = adoptedPodSelector(
+			existing.Spec.Selector, existing.Spec.Template.Labels,
+			deployment.Spec.Selector, deployment.Spec.Template.Labels,
+		)
+	}
 	if workloadSpec.Service != nil && workloadSpec.Service.UpdateStrategy != nil {
 		if deployment.Spec.Strategy, err = updateStrategyForDeployment(*workloadSpec.Service.UpdateStrategy); err != nil {
 			return cleanUps, errors.Trace(err)
@@ -1832,22 +1939,13 @@ func (k *kubernetesClient) configureHeadlessService(
 
 // ExposeService sets up external access to the specified application.
 func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error {
-	logger.Debugf("creating/updating ingress resource for %s", appName)
-
 	host := config.GetString(caas.JujuExternalHostNameKey, "")
 	if host == "" {
 		return errors.Errorf("external hostname required")
 	}
-	ingressClass := config.GetString(ingressClassKey, defaultIngressClass)
-	ingressSSLRedirect := config.GetBool(ingressSSLRedirectKey, defaultIngressSSLRedirect)
-	ingressSSLPassthrough := config.GetBool(ingressSSLPassthroughKey, defaultIngressSSLPassthrough)
-	ingressAllowHTTP := config.GetBool(ingressAllowHTTPKey, defaultIngressAllowHTTPKey)
-	httpPath := config.GetString(caas.JujuApplicationPath, caas.JujuDefaultApplicationPath)
-	if httpPath == "$appname" {
-		httpPath = appName
-	}
-	if !strings.HasPrefix(httpPath, "/") {
-		httpPath = "/" + httpPath
+	mode := config.GetString(ExposeModeConfigKey, defaultExposeMode)
+	if mode != exposeModeIngress && mode != exposeModeGateway {
+		return errors.NotValidf("expose mode %q", mode)
 	}
 
 	deploymentName := k.deploymentName(appName, true)
@@ -1858,42 +1956,47 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	if len(svc.Spec.Ports) == 0 {
 		return errors.Errorf("cannot create ingress rule for service %q without a port", svc.Name)
 	}
-	spec := &v1beta1.Ingress{
-		ObjectMeta: v1.ObjectMeta{
-			Name:   deploymentName,
-			Labels: k8slabels.Merge(resourceTags, k.getIngressLabels(appName)),
-			Annotations: map[string]string{
-				"ingress.kubernetes.io/rewrite-target":  "",
-				"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
-				"kubernetes.io/ingress.class":           ingressClass,
-				"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
-				"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
-			},
-		},
-		Spec: v1beta1.IngressSpec{
-			Rules: []v1beta1.IngressRule{{
-				Host: host,
-				IngressRuleValue: v1beta1.IngressRuleValue{
-					HTTP: &v1beta1.HTTPIngressRuleValue{
-						Paths: []v1beta1.HTTPIngressPath{{
-							Path: httpPath,
-							Backend: v1beta1.IngressBackend{
-								ServiceName: svc.Name, ServicePort: svc.Spec.Ports[0].TargetPort},
-						}}},
-				}}},
-		},
+	routes, err := exposeRoutes(appName, config, svc.Spec.Ports)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	labels := k8slabels.Merge(resourceTags, k.getIngressLabels(appName))
+
+	if mode == exposeModeGateway {
+		logger.Debugf("creating/updating http route for %s", appName)
+		route, err := exposeHTTPRoute(deploymentName, svc.Name, host, labels, routes, config)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := k.ensureHTTPRoute(route); err != nil {
+			return errors.Trace(err)
+		}
+		// Remove any ingress left from exposing through an ingress before.
+		return errors.Trace(k.deleteIngress(deploymentName, ""))
+	}
+
+	logger.Debugf("creating/updating ingress resource for %s", appName)
+	spec, err := exposeIngress(deploymentName, svc.Name, host, labels, routes, config)
+	if err != nil {
+		return errors.Trace(err)
 	}
 	// TODO(caas): refactor juju expose to solve potential conflict with ingress definition in podspec.
 	// https://bugs.launchpad.net/juju/+bug/1854123
-	_, err = k.ensureIngress(appName, spec, true)
-	return errors.Trace(err)
+	if _, err = k.ensureIngress(appName, spec, true); err != nil {
+		return errors.Trace(err)
+	}
+	// Remove any http route left from exposing through a gateway before.
+	return errors.Trace(k.deleteHTTPRoute(deploymentName))
 }
 
 // UnexposeService removes external access to the specified service.
 func (k *kubernetesClient) UnexposeService(appName string) error {
 	logger.Debugf("deleting ingress resource for %s", appName)
 	deploymentName := k.deploymentName(appName, true)
-	return errors.Trace(k.deleteIngress(deploymentName, ""))
+	if err := k.deleteIngress(deploymentName, ""); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteHTTPRoute(deploymentName))
 }
 
 func operatorSelector(appName string) string {
@@ -1913,30 +2016,9 @@ func applicationSelector(appName string, mode caas.DeploymentMode) string {
 
 // AnnotateUnit annotates the specified pod (name or uid) with a unit tag.
 func (k *kubernetesClient) AnnotateUnit(appName string, mode caas.DeploymentMode, podName string, unit names.UnitTag) error {
-	pods := k.client().CoreV1().Pods(k.namespace)
-
-	pod, err := pods.Get(context.TODO(), podName, v1.GetOptions{})
+	pod, err := k.getAppPod(appName, mode, podName)
 	if err != nil {
-		if !k8serrors.IsNotFound(err) {
-			return errors.Trace(err)
-		}
-		pods, err := pods.List(context.TODO(), v1.ListOptions{
-			LabelSelector: applicationSelector(appName, mode),
-		})
-		// TODO(caas): remove getting pod by Id (a bit expensive) once we started to store podName in cloudContainer doc.
-		if err != nil {
-			return errors.Trace(err)
-		}
-		for _, v := range pods.Items {
-			if string(v.GetUID()) == podName {
-				p := v
-				pod = &p
-				break
-			}
-		}
-	}
-	if pod == nil {
-		return errors.NotFoundf("pod %q", podName)
+		return errors.Trace(err)
 	}
 
 	if pod.Annotations == nil {
@@ -1948,13 +2030,41 @@ func (k *kubernetesClient) AnnotateUnit(appName string, mode caas.DeploymentMode
 	}
 	pod.Annotations[constants.AnnotationUnit] = unitID
 
-	_, err = pods.Update(context.TODO(), pod, v1.UpdateOptions{})
+	_, err = k.client().CoreV1().Pods(k.namespace).Update(context.TODO(), pod, v1.UpdateOptions{})
 	if k8serrors.IsNotFound(err) {
 		return errors.NotFoundf("pod %q", podName)
 	}
 	return errors.Trace(err)
 }
 
+// getAppPod returns the pod of the specified application with the given
+// name or uid.
+func (k *kubernetesClient) getAppPod(appName string, mode caas.DeploymentMode, podName string) (*core.Pod, error) {
+	pods := k.client().CoreV1().Pods(k.namespace)
+
+	pod, err := pods.Get(context.TODO(), podName, v1.GetOptions{})
+	if err == nil {
+		return pod, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	podList, err := pods.List(context.TODO(), v1.ListOptions{
+		LabelSelector: applicationSelector(appName, mode),
+	})
+	// TODO(caas): remove getting pod by Id (a bit expensive) once we started to store podName in cloudContainer doc.
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, v := range podList.Items {
+		if string(v.GetUID()) == podName {
+			p := v
+			return &p, nil
+		}
+	}
+	return nil, errors.NotFoundf("pod %q", podName)
+}
+
 // WatchUnits returns a watcher which notifies when there
 // are changes to units of the specified application.
 func (k *kubernetesClient) WatchUnits(appName string, mode caas.DeploymentMode) (watcher.NotifyWatcher, error) {
@@ -2106,12 +2216,6 @@ func (k *kubernetesClient) Units(appName string, mode caas.DeploymentMode) ([]ca
 	var units []caas.Unit
 	now := k.clock.Now()
 	for _, p := range podsList.Items {
-		var ports []string
-		for _, c := range p.Spec.Containers {
-			for _, p := range c.Ports {
-				ports = append(ports, fmt.Sprintf("%v/%v", p.ContainerPort, p.Protocol))
-			}
-		}
 		terminated := p.DeletionTimestamp != nil
 		statusMessage, unitStatus, since, err := k.getPODStatus(p, now)
 		if err != nil {
@@ -2120,7 +2224,7 @@ func (k *kubernetesClient) Units(appName string, mode caas.DeploymentMode) ([]ca
 		unitInfo := caas.Unit{
 			Id:       providerID(&p),
 			Address:  p.Status.PodIP,
-			Ports:    ports,
+			Ports:    podPorts(&p),
 			Dying:    terminated,
 			Stateful: isStateful(&p),
 			Status: status.StatusInfo{
@@ -2346,6 +2450,8 @@ type workloadSpec struct {
 	MutatingWebhookConfigurations   []k8sspecs.K8sMutatingWebhookSpec
 	ValidatingWebhookConfigurations []k8sspecs.K8sValidatingWebhookSpec
 	IngressResources                []k8sspecs.K8sIngressSpec
+	Availability                    *specs.AvailabilitySpec
+	Monitoring                      *specs.MonitoringSpec
 }
 
 func processContainers(deploymentName string, podSpec *specs.PodSpec, spec *core.PodSpec) error {
@@ -2401,6 +2507,8 @@ func prepareWorkloadSpec(appName, deploymentName string, podSpec *specs.PodSpec,
 
 	spec.Service = podSpec.Service
 	spec.ConfigMaps = podSpec.ConfigMaps
+	spec.Availability = podSpec.Availability
+	spec.Monitoring = podSpec.Monitoring
 	if podSpec.ServiceAccount != nil {
 		// Use application name for the prime service account name.
 		podSpec.ServiceAccount.SetName(appName)
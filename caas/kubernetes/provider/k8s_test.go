@@ -2026,6 +2026,24 @@ func (s *K8sBrokerSuite) TestDeleteServiceForApplication(c *gc.C) {
 			s.deleteOptions(v1.DeletePropagationForeground, ""),
 			v1.ListOptions{LabelSelector: "juju-app=test"},
 		).Return(nil),
+
+		// delete all horizontal pod autoscalers.
+		s.mockHorizontalPodScalers.EXPECT().DeleteCollection(gomock.Any(),
+			s.deleteOptions(v1.DeletePropagationForeground, ""),
+			v1.ListOptions{LabelSelector: "juju-app=test"},
+		).Return(nil),
+
+		// delete all pod disruption budgets.
+		s.mockPodDisruptionBudgets.EXPECT().DeleteCollection(gomock.Any(),
+			s.deleteOptions(v1.DeletePropagationForeground, ""),
+			v1.ListOptions{LabelSelector: "juju-app=test"},
+		).Return(nil),
+
+		// delete all network policies.
+		s.mockNetworkPolicies.EXPECT().DeleteCollection(gomock.Any(),
+			s.deleteOptions(v1.DeletePropagationForeground, ""),
+			v1.ListOptions{LabelSelector: "juju-app=test"},
+		).Return(nil),
 	)
 
 	err := s.broker.DeleteService("test")
@@ -2131,6 +2149,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -2253,6 +2273,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithUpdateStrategy(c *gc.
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -2302,6 +2324,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceStatelessWithScalePolicyInvalid(c *gc.
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -2524,6 +2548,8 @@ password: shhhh`[1:],
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -2776,6 +2802,8 @@ password: shhhh`[1:],
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -3249,6 +3277,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 	serviceArg := *basicServiceArg
 	serviceArg.Spec.Type = core.ServiceTypeClusterIP
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -3333,6 +3363,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceCustomType(c *gc.C) {
 	serviceArg := *basicServiceArg
 	serviceArg.Spec.Type = core.ServiceTypeExternalName
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -3380,6 +3412,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceServiceWithoutPortsNotValid(c *gc.C) {
 	serviceArg := *basicServiceArg
 	serviceArg.Spec.Type = core.ServiceTypeExternalName
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -3538,6 +3572,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewRoleCreate(c *gc.
 	}
 
 	secretArg := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -3701,6 +3737,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewRoleUpdate(c *gc.
 	rbUID := rb.GetUID()
 
 	secretArg := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -3900,6 +3938,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewClusterRoleCreate
 	}
 
 	secretArg := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -4079,6 +4119,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewClusterRoleUpdate
 	crbUID := crb.GetUID()
 
 	secretArg := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -4343,6 +4385,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountAndK8sServiceAccount
 	}
 
 	secretArg := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -4615,6 +4659,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountAndK8sServiceAccount
 	}
 
 	secretArg := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -4935,6 +4981,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountAndK8sServiceAccount
 	}
 
 	secretArg := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -5021,6 +5069,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 	statefulSetArg.Spec.Template.Annotations["foo"] = "baz"
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -5124,6 +5174,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithUpdateStrategy(c *gc
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -5236,6 +5288,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 		},
 	}
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -5363,6 +5417,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithStorageCreate(c *gc.C
 		},
 	}
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -5510,6 +5566,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithStorageUpdate(c *gc.C
 		},
 	}
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -5685,6 +5743,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithStorageCreate(c *gc.C)
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -5867,6 +5927,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithUpdateStrategy(c *gc.C
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -6033,6 +6095,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithStorageUpdate(c *gc.C)
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -6176,6 +6240,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithDevicesAndConstraintsC
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -6293,6 +6359,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithDevicesAndConstraintsU
 	}
 
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -6368,6 +6436,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 	}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -6449,6 +6519,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 	}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -6537,6 +6609,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithNodeAffinity(c *gc.C) {
 	}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -6617,6 +6691,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 	}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	s.expectMonitorsDeleted()
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: k8s.io/client-go/kubernetes/typed/core/v1 (interfaces: EventInterface,CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface)
+// Source: k8s.io/client-go/kubernetes/typed/core/v1 (interfaces: EventInterface,CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface,ResourceQuotaInterface,LimitRangeInterface)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -1845,3 +1845,310 @@ func (mr *MockNodeInterfaceMockRecorder) Watch(arg0, arg1 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockNodeInterface)(nil).Watch), arg0, arg1)
 }
+
+// MockResourceQuotaInterface is a mock of ResourceQuotaInterface interface
+type MockResourceQuotaInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceQuotaInterfaceMockRecorder
+}
+
+// MockResourceQuotaInterfaceMockRecorder is the mock recorder for MockResourceQuotaInterface
+type MockResourceQuotaInterfaceMockRecorder struct {
+	mock *MockResourceQuotaInterface
+}
+
+// NewMockResourceQuotaInterface creates a new mock instance
+func NewMockResourceQuotaInterface(ctrl *gomock.Controller) *MockResourceQuotaInterface {
+	mock := &MockResourceQuotaInterface{ctrl: ctrl}
+	mock.recorder = &MockResourceQuotaInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockResourceQuotaInterface) EXPECT() *MockResourceQuotaInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockResourceQuotaInterface) Create(arg0 context.Context, arg1 *v1.ResourceQuota, arg2 v10.CreateOptions) (*v1.ResourceQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.ResourceQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockResourceQuotaInterfaceMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockResourceQuotaInterface)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method
+func (m *MockResourceQuotaInterface) Delete(arg0 context.Context, arg1 string, arg2 v10.DeleteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockResourceQuotaInterfaceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockResourceQuotaInterface)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DeleteCollection mocks base method
+func (m *MockResourceQuotaInterface) DeleteCollection(arg0 context.Context, arg1 v10.DeleteOptions, arg2 v10.ListOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection
+func (mr *MockResourceQuotaInterfaceMockRecorder) DeleteCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockResourceQuotaInterface)(nil).DeleteCollection), arg0, arg1, arg2)
+}
+
+// Get mocks base method
+func (m *MockResourceQuotaInterface) Get(arg0 context.Context, arg1 string, arg2 v10.GetOptions) (*v1.ResourceQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.ResourceQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockResourceQuotaInterfaceMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockResourceQuotaInterface)(nil).Get), arg0, arg1, arg2)
+}
+
+// List mocks base method
+func (m *MockResourceQuotaInterface) List(arg0 context.Context, arg1 v10.ListOptions) (*v1.ResourceQuotaList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ResourceQuotaList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockResourceQuotaInterfaceMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockResourceQuotaInterface)(nil).List), arg0, arg1)
+}
+
+// Patch mocks base method
+func (m *MockResourceQuotaInterface) Patch(arg0 context.Context, arg1 string, arg2 types.PatchType, arg3 []byte, arg4 v10.PatchOptions, arg5 ...string) (*v1.ResourceQuota, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4}
+	for _, a := range arg5 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(*v1.ResourceQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch
+func (mr *MockResourceQuotaInterfaceMockRecorder) Patch(arg0, arg1, arg2, arg3, arg4 interface{}, arg5 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4}, arg5...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockResourceQuotaInterface)(nil).Patch), varargs...)
+}
+
+// Update mocks base method
+func (m *MockResourceQuotaInterface) Update(arg0 context.Context, arg1 *v1.ResourceQuota, arg2 v10.UpdateOptions) (*v1.ResourceQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.ResourceQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockResourceQuotaInterfaceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockResourceQuotaInterface)(nil).Update), arg0, arg1, arg2)
+}
+
+// UpdateStatus mocks base method
+func (m *MockResourceQuotaInterface) UpdateStatus(arg0 context.Context, arg1 *v1.ResourceQuota, arg2 v10.UpdateOptions) (*v1.ResourceQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.ResourceQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus
+func (mr *MockResourceQuotaInterfaceMockRecorder) UpdateStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockResourceQuotaInterface)(nil).UpdateStatus), arg0, arg1, arg2)
+}
+
+// Watch mocks base method
+func (m *MockResourceQuotaInterface) Watch(arg0 context.Context, arg1 v10.ListOptions) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch
+func (mr *MockResourceQuotaInterfaceMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockResourceQuotaInterface)(nil).Watch), arg0, arg1)
+}
+
+// MockLimitRangeInterface is a mock of LimitRangeInterface interface
+type MockLimitRangeInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitRangeInterfaceMockRecorder
+}
+
+// MockLimitRangeInterfaceMockRecorder is the mock recorder for MockLimitRangeInterface
+type MockLimitRangeInterfaceMockRecorder struct {
+	mock *MockLimitRangeInterface
+}
+
+// NewMockLimitRangeInterface creates a new mock instance
+func NewMockLimitRangeInterface(ctrl *gomock.Controller) *MockLimitRangeInterface {
+	mock := &MockLimitRangeInterface{ctrl: ctrl}
+	mock.recorder = &MockLimitRangeInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLimitRangeInterface) EXPECT() *MockLimitRangeInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockLimitRangeInterface) Create(arg0 context.Context, arg1 *v1.LimitRange, arg2 v10.CreateOptions) (*v1.LimitRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.LimitRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockLimitRangeInterfaceMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLimitRangeInterface)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method
+func (m *MockLimitRangeInterface) Delete(arg0 context.Context, arg1 string, arg2 v10.DeleteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockLimitRangeInterfaceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLimitRangeInterface)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DeleteCollection mocks base method
+func (m *MockLimitRangeInterface) DeleteCollection(arg0 context.Context, arg1 v10.DeleteOptions, arg2 v10.ListOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection
+func (mr *MockLimitRangeInterfaceMockRecorder) DeleteCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockLimitRangeInterface)(nil).DeleteCollection), arg0, arg1, arg2)
+}
+
+// Get mocks base method
+func (m *MockLimitRangeInterface) Get(arg0 context.Context, arg1 string, arg2 v10.GetOptions) (*v1.LimitRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.LimitRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockLimitRangeInterfaceMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLimitRangeInterface)(nil).Get), arg0, arg1, arg2)
+}
+
+// List mocks base method
+func (m *MockLimitRangeInterface) List(arg0 context.Context, arg1 v10.ListOptions) (*v1.LimitRangeList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*v1.LimitRangeList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockLimitRangeInterfaceMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLimitRangeInterface)(nil).List), arg0, arg1)
+}
+
+// Patch mocks base method
+func (m *MockLimitRangeInterface) Patch(arg0 context.Context, arg1 string, arg2 types.PatchType, arg3 []byte, arg4 v10.PatchOptions, arg5 ...string) (*v1.LimitRange, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4}
+	for _, a := range arg5 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(*v1.LimitRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch
+func (mr *MockLimitRangeInterfaceMockRecorder) Patch(arg0, arg1, arg2, arg3, arg4 interface{}, arg5 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4}, arg5...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockLimitRangeInterface)(nil).Patch), varargs...)
+}
+
+// Update mocks base method
+func (m *MockLimitRangeInterface) Update(arg0 context.Context, arg1 *v1.LimitRange, arg2 v10.UpdateOptions) (*v1.LimitRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.LimitRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockLimitRangeInterfaceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLimitRangeInterface)(nil).Update), arg0, arg1, arg2)
+}
+
+// Watch mocks base method
+func (m *MockLimitRangeInterface) Watch(arg0 context.Context, arg1 v10.ListOptions) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch
+func (mr *MockLimitRangeInterfaceMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockLimitRangeInterface)(nil).Watch), arg0, arg1)
+}
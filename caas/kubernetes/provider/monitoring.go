@@ -0,0 +1,147 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/caas/specs"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+const (
+	// The custom resources of the Prometheus operator which configure
+	// the scraping of services and pods.
+	serviceMonitorCRDName = "servicemonitors.monitoring.coreos.com"
+	podMonitorCRDName     = "podmonitors.monitoring.coreos.com"
+	monitorVersion        = "v1"
+)
+
+// monitorResource returns the ServiceMonitor or PodMonitor custom resource
+// which scrapes the metrics endpoints of the application.
+func (k *kubernetesClient) monitorResource(appName string, spec specs.MonitoringSpec) *unstructured.Unstructured {
+	kind, endpointsKey := "ServiceMonitor", "endpoints"
+	if spec.Kind == specs.PodMonitor {
+		kind, endpointsKey = "PodMonitor", "podMetricsEndpoints"
+	}
+	var endpoints []interface{}
+	for _, e := range spec.Endpoints {
+		endpoint := map[string]interface{}{"port": e.Port}
+		for key, value := range map[string]string{
+			"path":     e.Path,
+			"scheme":   e.Scheme,
+			"interval": e.Interval,
+		} {
+			if value != "" {
+				endpoint[key] = value
+			}
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	matchLabels := make(map[string]interface{})
+	for key, value := range utils.LabelsForApp(appName) {
+		matchLabels[key] = value
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/" + monitorVersion,
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name": appName,
+			},
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{
+					"matchLabels": matchLabels,
+				},
+				"namespaceSelector": map[string]interface{}{
+					"matchNames": []interface{}{k.namespace},
+				},
+				endpointsKey: endpoints,
+			},
+		},
+	}
+}
+
+// monitorClient returns the client of the ServiceMonitor or PodMonitor
+// custom resources, and the resources' definition. A NotFound error is
+// returned if the Prometheus operator's custom resource definitions
+// aren't installed in the cluster.
+func (k *kubernetesClient) monitorClient(crdName string) (*apiextensionsv1beta1.CustomResourceDefinition, dynamic.ResourceInterface, error) {
+	crd, err := k.getCustomResourceDefinition(crdName)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	crdClient, err := k.getCustomResourceDefinitionClient(crd, monitorVersion)
+	if errors.IsNotValid(err) {
+		return nil, nil, errors.NewNotFound(err, "")
+	}
+	return crd, crdClient, errors.Trace(err)
+}
+
+// ensureMonitor creates or updates the ServiceMonitor or PodMonitor for an
+// application, and deletes the monitor of the other kind. Nothing is
+// created if the Prometheus operator's custom resource definitions aren't
+// installed in the cluster, since the monitor would have no effect without
+// it. The ports of a ServiceMonitor have to be named ports of the
+// application's service.
+func (k *kubernetesClient) ensureMonitor(
+	appName string,
+	annotations map[string]string,
+	spec specs.MonitoringSpec,
+	servicePorts set.Strings,
+) (cleanUps []func(), _ error) {
+	crdName, otherCRDName := serviceMonitorCRDName, podMonitorCRDName
+	if spec.Kind == specs.PodMonitor {
+		crdName, otherCRDName = podMonitorCRDName, serviceMonitorCRDName
+	} else {
+		for _, e := range spec.Endpoints {
+			if !servicePorts.Contains(e.Port) {
+				return cleanUps, errors.NotValidf("service monitor endpoint port %q which is not a port of the service", e.Port)
+			}
+		}
+	}
+	if err := k.deleteMonitor(appName, otherCRDName); err != nil {
+		return cleanUps, errors.Trace(err)
+	}
+	crd, crdClient, err := k.monitorClient(crdName)
+	if errors.IsNotFound(err) {
+		logger.Warningf("not monitoring %q: %v", appName, err)
+		return cleanUps, nil
+	} else if err != nil {
+		return cleanUps, errors.Trace(err)
+	}
+
+	cr := k.monitorResource(appName, spec)
+	cr.SetLabels(k8slabels.Merge(spec.Labels, k.getCRLabels(appName, crd.Spec.Scope)))
+	cr.SetAnnotations(k8sannotations.New(annotations).ToMap())
+	_, cleanUps, err = ensureCustomResource(crdClient, cr)
+	return cleanUps, errors.Annotatef(err, "ensuring %s %q", cr.GetKind(), cr.GetName())
+}
+
+// deleteMonitors deletes the ServiceMonitor and PodMonitor of an
+// application, for when its spec no longer declares its metrics endpoints.
+func (k *kubernetesClient) deleteMonitors(appName string) error {
+	for _, crdName := range []string{serviceMonitorCRDName, podMonitorCRDName} {
+		if err := k.deleteMonitor(appName, crdName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (k *kubernetesClient) deleteMonitor(appName, crdName string) error {
+	_, crdClient, err := k.monitorClient(crdName)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(deleteCustomResourceDefinition(crdClient, appName, ""))
+}
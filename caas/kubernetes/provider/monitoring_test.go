@@ -0,0 +1,260 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/specs"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing"
+)
+
+func (s *K8sBrokerSuite) ensureServiceWithMonitoring(c *gc.C, kind specs.MonitorKind) error {
+	podSpec := getBasicPodspec()
+	podSpec.Monitoring = &specs.MonitoringSpec{
+		Kind:      kind,
+		Endpoints: []specs.MetricsEndpointSpec{{Port: "fred", Path: "/stats", Interval: "30s"}},
+		Labels:    map[string]string{"release": "prometheus"},
+	}
+	params := &caas.ServiceParams{
+		PodSpec:           podSpec,
+		OperatorImagePath: "operator/image-path",
+		ResourceTags: map[string]string{
+			"juju-controller-uuid": testing.ControllerTag.Id(),
+		},
+	}
+	return s.broker.EnsureService("app-name", func(_ string, _ status.Status, _ string, _ map[string]interface{}) error { return nil }, params, 2, application.ConfigAttributes{})
+}
+
+func (s *K8sBrokerSuite) monitorCRD(plural, kind string) *apiextensionsv1beta1.CustomResourceDefinition {
+	return &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: plural + ".monitoring.coreos.com"},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Group: "monitoring.coreos.com",
+			Versions: []apiextensionsv1beta1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true},
+			},
+			Scope: "Namespaced",
+			Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+				Kind:   kind,
+				Plural: plural,
+			},
+		},
+	}
+}
+
+// expectMonitorsDeleted expects the monitors of an application without
+// monitoring in its spec to be deleted, in a cluster without the
+// Prometheus operator.
+func (s *K8sBrokerSuite) expectMonitorsDeleted() {
+	s.mockCustomResourceDefinition.EXPECT().Get(gomock.Any(), "servicemonitors.monitoring.coreos.com", v1.GetOptions{}).
+		Return(nil, s.k8sNotFoundError())
+	s.mockCustomResourceDefinition.EXPECT().Get(gomock.Any(), "podmonitors.monitoring.coreos.com", v1.GetOptions{}).
+		Return(nil, s.k8sNotFoundError())
+}
+
+func (s *K8sBrokerSuite) monitorArg(kind, endpointsKey string) *unstructured.Unstructured {
+	cr := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name": "app-name",
+			},
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"juju-app": "app-name"},
+				},
+				"namespaceSelector": map[string]interface{}{
+					"matchNames": []interface{}{"test"},
+				},
+				endpointsKey: []interface{}{
+					map[string]interface{}{"port": "fred", "path": "/stats", "interval": "30s"},
+				},
+			},
+		},
+	}
+	cr.SetLabels(map[string]string{"juju-app": "app-name", "release": "prometheus"})
+	cr.SetAnnotations(map[string]string{"juju.io/controller": testing.ControllerTag.Id()})
+	return cr
+}
+
+func (s *K8sBrokerSuite) assertEnsureServiceWithMonitoring(c *gc.C, kind specs.MonitorKind, monitorCalls ...*gomock.Call) {
+	deploymentArg := s.autoscaleDeploymentArg(c, 2)
+	serviceArg := s.autoscaleServiceArg()
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+
+	calls := []*gomock.Call{
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	}
+	calls = append(calls, monitorCalls...)
+	calls = append(calls,
+		s.mockSecrets.EXPECT().Create(gomock.Any(), ociImageSecret, v1.CreateOptions{}).
+			Return(ociImageSecret, nil),
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any(), serviceArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(gomock.Any(), serviceArg, v1.CreateOptions{}).
+			Return(nil, nil),
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(gomock.Any(), deploymentArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
+			Return(nil, nil),
+	)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	gomock.InOrder(calls...)
+
+	err := s.ensureServiceWithMonitoring(c, kind)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceCreatesServiceMonitor(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	crd := s.monitorCRD("servicemonitors", "ServiceMonitor")
+	monitorArg := s.monitorArg("ServiceMonitor", "endpoints")
+	s.assertEnsureServiceWithMonitoring(c, "",
+		s.mockCustomResourceDefinition.EXPECT().Get(gomock.Any(), "podmonitors.monitoring.coreos.com", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinition.EXPECT().Get(gomock.Any(), "servicemonitors.monitoring.coreos.com", v1.GetOptions{}).
+			Return(crd, nil),
+		s.mockDynamicClient.EXPECT().Resource(schema.GroupVersionResource{
+			Group:    "monitoring.coreos.com",
+			Version:  "v1",
+			Resource: "servicemonitors",
+		}).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Create(gomock.Any(), monitorArg, v1.CreateOptions{}).
+			Return(monitorArg, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceUpdatesPodMonitor(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	crd := s.monitorCRD("podmonitors", "PodMonitor")
+	monitorArg := s.monitorArg("PodMonitor", "podMetricsEndpoints")
+	existing := monitorArg.DeepCopy()
+	existing.SetResourceVersion("42")
+	updatedArg := monitorArg.DeepCopy()
+	updatedArg.SetResourceVersion("42")
+	s.assertEnsureServiceWithMonitoring(c, specs.PodMonitor,
+		s.mockCustomResourceDefinition.EXPECT().Get(gomock.Any(), "servicemonitors.monitoring.coreos.com", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinition.EXPECT().Get(gomock.Any(), "podmonitors.monitoring.coreos.com", v1.GetOptions{}).
+			Return(crd, nil),
+		s.mockDynamicClient.EXPECT().Resource(schema.GroupVersionResource{
+			Group:    "monitoring.coreos.com",
+			Version:  "v1",
+			Resource: "podmonitors",
+		}).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Create(gomock.Any(), monitorArg, v1.CreateOptions{}).
+			Return(nil, s.k8sAlreadyExistsError()),
+		s.mockResourceClient.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(existing, nil),
+		s.mockResourceClient.EXPECT().Update(gomock.Any(), updatedArg, v1.UpdateOptions{}).
+			Return(updatedArg, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceMonitoringWithoutPrometheusOperator(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	// The monitor is skipped, but the application is still deployed.
+	s.assertEnsureServiceWithMonitoring(c, specs.ServiceMonitor,
+		s.mockCustomResourceDefinition.EXPECT().Get(gomock.Any(), "podmonitors.monitoring.coreos.com", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinition.EXPECT().Get(gomock.Any(), "servicemonitors.monitoring.coreos.com", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceDeletesMonitor(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	deploymentArg := s.autoscaleDeploymentArg(c, 2)
+	serviceArg := s.autoscaleServiceArg()
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinition.EXPECT().Get(gomock.Any(), "servicemonitors.monitoring.coreos.com", v1.GetOptions{}).
+			Return(s.monitorCRD("servicemonitors", "ServiceMonitor"), nil),
+		s.mockDynamicClient.EXPECT().Resource(schema.GroupVersionResource{
+			Group:    "monitoring.coreos.com",
+			Version:  "v1",
+			Resource: "servicemonitors",
+		}).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+		s.mockCustomResourceDefinition.EXPECT().Get(gomock.Any(), "podmonitors.monitoring.coreos.com", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(gomock.Any(), ociImageSecret, v1.CreateOptions{}).
+			Return(ociImageSecret, nil),
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any(), serviceArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(gomock.Any(), serviceArg, v1.CreateOptions{}).
+			Return(nil, nil),
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(gomock.Any(), deploymentArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec:           getBasicPodspec(),
+		OperatorImagePath: "operator/image-path",
+		ResourceTags: map[string]string{
+			"juju-controller-uuid": testing.ControllerTag.Id(),
+		},
+	}
+	err := s.broker.EnsureService("app-name", func(_ string, _ status.Status, _ string, _ map[string]interface{}) error { return nil }, params, 2, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceMonitorPortNotServicePort(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	podSpec := getBasicPodspec()
+	podSpec.Monitoring = &specs.MonitoringSpec{
+		Endpoints: []specs.MetricsEndpointSpec{{Port: "fred"}},
+	}
+	params := &caas.ServiceParams{
+		PodSpec:           podSpec,
+		OperatorImagePath: "operator/image-path",
+		Deployment:        caas.DeploymentParams{ServiceType: caas.ServiceOmit},
+	}
+	s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+		Return(nil, s.k8sNotFoundError())
+	s.expectPodDisruptionBudgetsDeleted("app-name")
+
+	err := s.broker.EnsureService("app-name", func(_ string, _ status.Status, _ string, _ map[string]interface{}) error { return nil }, params, 2, application.ConfigAttributes{})
+	c.Assert(err, gc.ErrorMatches, `creating or updating prometheus monitor: service monitor endpoint port "fred" which is not a port of the service not valid`)
+}
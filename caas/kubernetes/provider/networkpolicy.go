@@ -0,0 +1,179 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/core/network/firewall"
+)
+
+// EnsureNetworkPolicy restricts the traffic reaching the specified
+// application's pods to the sources allowed by the params. Each endpoint
+// only accepts traffic from the applications related on it and from the
+// networks it is exposed to.
+//
+// Service ports named after an endpoint are taken to belong to that
+// endpoint; an endpoint without a port of its own is allowed on the
+// service ports which don't belong to another endpoint. Traffic is only
+// allowed on ports declared by the service.
+//
+// If the policy is not enabled, any policy previously created for the
+// application is removed.
+func (k *kubernetesClient) EnsureNetworkPolicy(appName string, params caas.NetworkPolicyParams) error {
+	deploymentName := k.deploymentName(appName, true)
+	if !params.Enabled {
+		return errors.Trace(k.deleteNetworkPolicy(deploymentName))
+	}
+
+	var ports []core.ServicePort
+	svc, err := k.client().CoreV1().Services(k.namespace).Get(context.TODO(), deploymentName, v1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if err == nil {
+		ports = svc.Spec.Ports
+	}
+
+	policy := &networking.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName,
+			Labels: utils.LabelsForApp(appName),
+		},
+		Spec: networking.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{MatchLabels: utils.LabelsForApp(appName)},
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+			Ingress:     networkPolicyIngressRules(params, ports),
+		},
+	}
+	logger.Debugf("creating/updating network policy for %s", appName)
+	return errors.Trace(k.ensureNetworkPolicy(policy))
+}
+
+func networkPolicyIngressRules(params caas.NetworkPolicyParams, ports []core.ServicePort) []networking.NetworkPolicyIngressRule {
+	// Sort for stable ordering.
+	var endpoints []string
+	for name := range params.Endpoints {
+		endpoints = append(endpoints, name)
+	}
+	sort.Strings(endpoints)
+
+	var rules []networking.NetworkPolicyIngressRule
+	for _, endpoint := range endpoints {
+		ingress := params.Endpoints[endpoint]
+		endpointPorts := networkPolicyPorts(endpoint, params.Endpoints, ports)
+		if len(endpointPorts) == 0 {
+			// A rule without any ports admits traffic on all ports.
+			continue
+		}
+
+		var (
+			from       []networking.NetworkPolicyPeer
+			allSources bool
+		)
+		for _, app := range ingress.Applications {
+			from = append(from, networking.NetworkPolicyPeer{
+				PodSelector: &v1.LabelSelector{MatchLabels: utils.LabelsForApp(app)},
+			})
+		}
+		for _, cidr := range ingress.CIDRs {
+			if cidr == firewall.AllNetworksIPV4CIDR || cidr == firewall.AllNetworksIPV6CIDR {
+				allSources = true
+				continue
+			}
+			from = append(from, networking.NetworkPolicyPeer{
+				IPBlock: &networking.IPBlock{CIDR: cidr},
+			})
+		}
+		if allSources {
+			// A rule without any peers admits traffic from anywhere.
+			from = nil
+		} else if len(from) == 0 {
+			continue
+		}
+		rules = append(rules, networking.NetworkPolicyIngressRule{
+			Ports: endpointPorts,
+			From:  from,
+		})
+	}
+	return rules
+}
+
+// networkPolicyPorts returns the pod ports behind the service ports named
+// after the endpoint or, if there are none, behind the service ports not
+// named after any endpoint.
+func networkPolicyPorts(
+	endpoint string, endpoints map[string]caas.EndpointIngress, ports []core.ServicePort,
+) []networking.NetworkPolicyPort {
+	var own, unclaimed []core.ServicePort
+	for _, p := range ports {
+		if p.Name == endpoint {
+			own = append(own, p)
+		} else if _, ok := endpoints[p.Name]; !ok {
+			unclaimed = append(unclaimed, p)
+		}
+	}
+	if len(own) == 0 {
+		own = unclaimed
+	}
+
+	var result []networking.NetworkPolicyPort
+	for _, p := range own {
+		target := p.TargetPort
+		if target.Type == intstr.Int && target.IntVal == 0 {
+			target = intstr.FromInt(int(p.Port))
+		}
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = core.ProtocolTCP
+		}
+		result = append(result, networking.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &target,
+		})
+	}
+	return result
+}
+
+func (k *kubernetesClient) ensureNetworkPolicy(spec *networking.NetworkPolicy) error {
+	api := k.client().NetworkingV1().NetworkPolicies(k.namespace)
+	_, err := api.Update(context.TODO(), spec, v1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(context.TODO(), spec, v1.CreateOptions{})
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteNetworkPolicy(name string) error {
+	err := k.client().NetworkingV1().NetworkPolicies(k.namespace).Delete(context.TODO(), name, v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteNetworkPolicies(appName string) error {
+	err := k.client().NetworkingV1().NetworkPolicies(k.namespace).DeleteCollection(context.TODO(), v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: utils.LabelSetToSelector(utils.LabelsForApp(appName)).String(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
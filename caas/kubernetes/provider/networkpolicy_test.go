@@ -0,0 +1,139 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+)
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyDisabled(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Delete(gomock.Any(), "app-name",
+			s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("app-name", caas.NetworkPolicyParams{
+		Endpoints: map[string]caas.EndpointIngress{
+			"db": {Applications: []string{"wordpress"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicy(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{
+				{Name: "db", Port: 3306, TargetPort: intstr.FromInt(3306), Protocol: core.ProtocolTCP},
+				{Name: "website", Port: 80},
+				{Name: "other", Port: 9000},
+			},
+		},
+	}
+	tcp := core.ProtocolTCP
+	dbPort := intstr.FromInt(3306)
+	websitePort := intstr.FromInt(80)
+	otherPort := intstr.FromInt(9000)
+	policyArg := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{MatchLabels: map[string]string{"juju-app": "app-name"}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				// The admin endpoint has no port of its own, so it gets
+				// the port which doesn't belong to any endpoint.
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &otherPort}},
+				From: []networkingv1.NetworkPolicyPeer{{
+					IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"},
+				}},
+			}, {
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &dbPort}},
+				From: []networkingv1.NetworkPolicyPeer{{
+					PodSelector: &v1.LabelSelector{MatchLabels: map[string]string{"juju-app": "mediawiki"}},
+				}, {
+					PodSelector: &v1.LabelSelector{MatchLabels: map[string]string{"juju-app": "wordpress"}},
+				}},
+			}, {
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &websitePort}},
+			}},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(svc, nil),
+		s.mockNetworkPolicies.EXPECT().Update(gomock.Any(), policyArg, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Create(gomock.Any(), policyArg, v1.CreateOptions{}).
+			Return(policyArg, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("app-name", caas.NetworkPolicyParams{
+		Enabled: true,
+		Endpoints: map[string]caas.EndpointIngress{
+			"db":      {Applications: []string{"mediawiki", "wordpress"}},
+			"website": {Applications: []string{"haproxy"}, CIDRs: []string{"0.0.0.0/0"}},
+			"admin":   {CIDRs: []string{"10.0.0.0/8"}},
+			"unused":  {},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyWithoutService(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	policyArg := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{MatchLabels: map[string]string{"juju-app": "app-name"}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Update(gomock.Any(), policyArg, v1.UpdateOptions{}).
+			Return(policyArg, nil),
+	)
+
+	// No ports are declared, so no traffic is allowed in.
+	err := s.broker.EnsureNetworkPolicy("app-name", caas.NetworkPolicyParams{
+		Enabled: true,
+		Endpoints: map[string]caas.EndpointIngress{
+			"db": {Applications: []string{"wordpress"}, CIDRs: []string{"0.0.0.0/0"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
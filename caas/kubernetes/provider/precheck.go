@@ -45,6 +45,9 @@ func (k *kubernetesClient) PrecheckInstance(ctx context.ProviderCallContext, par
 	if params.Placement != "" {
 		return errors.NotValidf("placement directive %q", params.Placement)
 	}
+	if err := k.precheckResourceQuota(params.Constraints, params.NumUnits); err != nil {
+		return errors.Trace(err)
+	}
 	if params.Constraints.Tags == nil {
 		return nil
 	}
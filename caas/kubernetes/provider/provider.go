@@ -95,7 +95,7 @@ func CloudSpecToK8sRestConfig(cloudSpec environscloudspec.CloudSpec) (*rest.Conf
 	}
 
 	credentialAttrs := cloudSpec.Credential.Attributes()
-	return &rest.Config{
+	cfg := &rest.Config{
 		Host:        cloudSpec.Endpoint,
 		Username:    credentialAttrs[CredAttrUsername],
 		Password:    credentialAttrs[CredAttrPassword],
@@ -105,7 +105,24 @@ func CloudSpecToK8sRestConfig(cloudSpec environscloudspec.CloudSpec) (*rest.Conf
 			KeyData:  []byte(credentialAttrs[CredAttrClientKeyData]),
 			CAData:   CAData,
 		},
-	}, nil
+	}
+	switch cloudSpec.Credential.AuthType() {
+	case cloud.ExecAuthType:
+		// The exec plugin is run again whenever the credentials it
+		// returned expire.
+		execProvider, err := execConfig(credentialAttrs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cfg.ExecProvider = execProvider
+	case cloud.OIDCAuthType:
+		// The oidc auth provider refreshes the ID token when it expires.
+		cfg.AuthProvider = oidcConfig(credentialAttrs)
+		cfg.AuthConfigPersister = &tokenPersister{
+			refreshToken: cfg.AuthProvider.Config["refresh-token"],
+		}
+	}
+	return cfg, nil
 }
 
 func newRestClient(cfg *rest.Config) (rest.Interface, error) {
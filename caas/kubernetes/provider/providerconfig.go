@@ -6,9 +6,11 @@ package provider
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/version"
 	"gopkg.in/juju/environschema.v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/juju/juju/environs/config"
 )
@@ -21,6 +23,18 @@ const (
 	// OperatorStorageKey is the model config attribute used to specify
 	// the storage class for provisioning operator storage.
 	OperatorStorageKey = "operator-storage"
+
+	// NetworkPolicyKey is the model config attribute used to restrict
+	// the traffic reaching workload pods to related and exposed sources.
+	NetworkPolicyKey = "network-policy"
+
+	// NamespaceCPULimitKey is the model config attribute used to limit
+	// the total CPU which the pods in the model namespace may use.
+	NamespaceCPULimitKey = "k8s-namespace-cpu-limit"
+
+	// NamespaceMemoryLimitKey is the model config attribute used to limit
+	// the total memory which the pods in the model namespace may use.
+	NamespaceMemoryLimitKey = "k8s-namespace-memory-limit"
 )
 
 var (
@@ -47,6 +61,21 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	NetworkPolicyKey: {
+		Description: "Whether to create NetworkPolicies which only allow traffic to an application from the applications related to it and the networks it is exposed to.",
+		Type:        environschema.Tbool,
+		Group:       environschema.AccountGroup,
+	},
+	NamespaceCPULimitKey: {
+		Description: "The total CPU which the pods in the model may be limited to, as a Kubernetes quantity such as 4 or 2500m.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	NamespaceMemoryLimitKey: {
+		Description: "The total memory which the pods in the model may be limited to, as a Kubernetes quantity such as 8Gi.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
 }
 
 var providerConfigFields = func() schema.Fields {
@@ -60,6 +89,10 @@ var providerConfigFields = func() schema.Fields {
 var providerConfigDefaults = schema.Defaults{
 	WorkloadStorageKey: "",
 	OperatorStorageKey: "",
+	NetworkPolicyKey:   schema.Omit,
+
+	NamespaceCPULimitKey:    schema.Omit,
+	NamespaceMemoryLimitKey: schema.Omit,
 }
 
 type brokerConfig struct {
@@ -122,6 +155,16 @@ func validateConfig(cfg, old *config.Config) (*brokerConfig, error) {
 		return nil, err
 	}
 
+	for _, key := range []string{NamespaceCPULimitKey, NamespaceMemoryLimitKey} {
+		value, _ := validated[key].(string)
+		if value == "" {
+			continue
+		}
+		if q, err := resource.ParseQuantity(value); err != nil || q.Sign() <= 0 {
+			return nil, errors.NotValidf("%s %q", key, value)
+		}
+	}
+
 	bcfg := &brokerConfig{cfg, validated}
 	return bcfg, nil
 }
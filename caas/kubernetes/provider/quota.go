@@ -0,0 +1,255 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+)
+
+const (
+	// resourceQuotaName is the name of the ResourceQuota and LimitRange
+	// which enforce the resource limits of a model on its namespace.
+	resourceQuotaName = "juju-model"
+
+	// Once a namespace has a quota on CPU or memory limits, every
+	// container must declare those limits. Containers which don't are
+	// given these, capped to the quota itself.
+	defaultContainerCPULimit      = "500m"
+	defaultContainerCPURequest    = "100m"
+	defaultContainerMemoryLimit   = "512Mi"
+	defaultContainerMemoryRequest = "128Mi"
+)
+
+// namespaceQuota holds the limits on the compute resources of a model, as
+// Kubernetes quantities. An empty limit means the resource isn't limited.
+type namespaceQuota struct {
+	cpu    string
+	memory string
+}
+
+func (q namespaceQuota) empty() bool {
+	return q.cpu == "" && q.memory == ""
+}
+
+func namespaceQuotaFromConfig(cfg *config.Config) namespaceQuota {
+	attrs := cfg.UnknownAttrs()
+	cpu, _ := attrs[NamespaceCPULimitKey].(string)
+	memory, _ := attrs[NamespaceMemoryLimitKey].(string)
+	return namespaceQuota{cpu: cpu, memory: memory}
+}
+
+// ensureNamespaceQuota creates or updates the ResourceQuota and LimitRange
+// which enforce the specified quota on the model namespace, or removes
+// them if the quota is empty.
+func (k *kubernetesClient) ensureNamespaceQuota(quota namespaceQuota) error {
+	if quota.empty() {
+		return errors.Trace(k.deleteNamespaceQuota())
+	}
+
+	hard := core.ResourceList{}
+	defaults := core.ResourceList{}
+	defaultRequests := core.ResourceList{}
+	for _, r := range []struct {
+		name           core.ResourceName
+		quotaName      core.ResourceName
+		value          string
+		defaultLimit   string
+		defaultRequest string
+	}{
+		{core.ResourceCPU, core.ResourceLimitsCPU, quota.cpu, defaultContainerCPULimit, defaultContainerCPURequest},
+		{core.ResourceMemory, core.ResourceLimitsMemory, quota.memory, defaultContainerMemoryLimit, defaultContainerMemoryRequest},
+	} {
+		if r.value == "" {
+			continue
+		}
+		limit, err := resource.ParseQuantity(r.value)
+		if err != nil {
+			return errors.Annotatef(err, "parsing %s limit %q", r.name, r.value)
+		}
+		hard[r.quotaName] = limit
+		defaults[r.name] = minQuantity(resource.MustParse(r.defaultLimit), limit)
+		defaultRequests[r.name] = minQuantity(resource.MustParse(r.defaultRequest), limit)
+	}
+
+	meta := v1.ObjectMeta{
+		Name:        resourceQuotaName,
+		Labels:      utils.LabelsForModel(k.CurrentModel()),
+		Annotations: k.annotations,
+	}
+	if err := k.ensureResourceQuota(&core.ResourceQuota{
+		ObjectMeta: meta,
+		Spec:       core.ResourceQuotaSpec{Hard: hard},
+	}); err != nil {
+		return errors.Annotate(err, "ensuring resource quota")
+	}
+	err := k.ensureLimitRange(&core.LimitRange{
+		ObjectMeta: meta,
+		Spec: core.LimitRangeSpec{
+			Limits: []core.LimitRangeItem{{
+				Type:           core.LimitTypeContainer,
+				Default:        defaults,
+				DefaultRequest: defaultRequests,
+			}},
+		},
+	})
+	return errors.Annotate(err, "ensuring limit range")
+}
+
+func (k *kubernetesClient) ensureResourceQuota(spec *core.ResourceQuota) error {
+	api := k.client().CoreV1().ResourceQuotas(k.namespace)
+	_, err := api.Update(context.TODO(), spec, v1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(context.TODO(), spec, v1.CreateOptions{})
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureLimitRange(spec *core.LimitRange) error {
+	api := k.client().CoreV1().LimitRanges(k.namespace)
+	_, err := api.Update(context.TODO(), spec, v1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(context.TODO(), spec, v1.CreateOptions{})
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteNamespaceQuota() error {
+	err := k.client().CoreV1().ResourceQuotas(k.namespace).Delete(context.TODO(), resourceQuotaName, v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	err = k.client().CoreV1().LimitRanges(k.namespace).Delete(context.TODO(), resourceQuotaName, v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// ResourceQuota returns the limits on, and current usage of, the compute
+// resources of the model, as enforced by the quota on its namespace.
+func (k *kubernetesClient) ResourceQuota() (*caas.ResourceQuota, error) {
+	quota, err := k.client().CoreV1().ResourceQuotas(k.namespace).Get(context.TODO(), resourceQuotaName, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("resource quota for namespace %q", k.namespace)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The status isn't filled in until the quota has been processed.
+	hard := quota.Status.Hard
+	if len(hard) == 0 {
+		hard = quota.Spec.Hard
+	}
+	used := quota.Status.Used
+	result := &caas.ResourceQuota{}
+	if q, ok := hard[core.ResourceLimitsCPU]; ok {
+		result.CPULimit = milliValue(q)
+	}
+	if q, ok := used[core.ResourceLimitsCPU]; ok {
+		result.CPUUsed = milliValue(q)
+	}
+	if q, ok := hard[core.ResourceLimitsMemory]; ok {
+		result.MemoryLimit = mebibytes(q)
+	}
+	if q, ok := used[core.ResourceLimitsMemory]; ok {
+		result.MemoryUsed = mebibytes(q)
+	}
+	return result, nil
+}
+
+// precheckResourceQuota returns an error if the specified number of units
+// deployed with the specified constraints won't fit within what's left of
+// the model's resource quota.
+func (k *kubernetesClient) precheckResourceQuota(cons constraints.Value, numUnits int) error {
+	configured := namespaceQuotaFromConfig(k.Config())
+	if configured.empty() {
+		return nil
+	}
+	quota, err := k.ResourceQuota()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if numUnits < 1 {
+		numUnits = 1
+	}
+
+	if quota.CPULimit > 0 {
+		cpu := minUint64(milliValue(resource.MustParse(defaultContainerCPULimit)), quota.CPULimit)
+		if cons.CpuPower != nil {
+			cpu = *cons.CpuPower
+		}
+		if quota.CPUUsed+cpu*uint64(numUnits) > quota.CPULimit {
+			return errors.QuotaLimitExceededf(
+				"%s limited to %dm cpu would exceed the model's resource quota (%dm of %dm used)",
+				unitsDescription(numUnits), cpu, quota.CPUUsed, quota.CPULimit,
+			)
+		}
+	}
+	if quota.MemoryLimit > 0 {
+		memory := minUint64(mebibytes(resource.MustParse(defaultContainerMemoryLimit)), quota.MemoryLimit)
+		if cons.Mem != nil {
+			memory = *cons.Mem
+		}
+		if quota.MemoryUsed+memory*uint64(numUnits) > quota.MemoryLimit {
+			return errors.QuotaLimitExceededf(
+				"%s limited to %dMi memory would exceed the model's resource quota (%dMi of %dMi used)",
+				unitsDescription(numUnits), memory, quota.MemoryUsed, quota.MemoryLimit,
+			)
+		}
+	}
+	return nil
+}
+
+func unitsDescription(numUnits int) string {
+	if numUnits == 1 {
+		return "a unit"
+	}
+	return fmt.Sprintf("%d units each", numUnits)
+}
+
+func minQuantity(a, b resource.Quantity) resource.Quantity {
+	if a.Cmp(b) > 0 {
+		return b
+	}
+	return a
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a > b {
+		return b
+	}
+	return a
+}
+
+func milliValue(q resource.Quantity) uint64 {
+	if q.Sign() <= 0 {
+		return 0
+	}
+	return uint64(q.MilliValue())
+}
+
+func mebibytes(q resource.Quantity) uint64 {
+	if q.Sign() <= 0 {
+		return 0
+	}
+	return uint64(q.Value()) / (1024 * 1024)
+}
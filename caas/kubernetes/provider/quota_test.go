@@ -0,0 +1,194 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/testing"
+)
+
+func (s *K8sBrokerSuite) quotaMeta() v1.ObjectMeta {
+	return v1.ObjectMeta{
+		Name:   "juju-model",
+		Labels: map[string]string{"juju-model": "test"},
+		Annotations: map[string]string{
+			"juju.io/model":      s.cfg.UUID(),
+			"juju.io/controller": testing.ControllerTag.Id(),
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestSetConfigNamespaceQuota(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	cfg, err := s.cfg.Apply(map[string]interface{}{
+		provider.NamespaceCPULimitKey:    "4",
+		provider.NamespaceMemoryLimitKey: "256Mi",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	quota := &core.ResourceQuota{
+		ObjectMeta: s.quotaMeta(),
+		Spec: core.ResourceQuotaSpec{
+			Hard: core.ResourceList{
+				core.ResourceLimitsCPU:    resource.MustParse("4"),
+				core.ResourceLimitsMemory: resource.MustParse("256Mi"),
+			},
+		},
+	}
+	limitRange := &core.LimitRange{
+		ObjectMeta: s.quotaMeta(),
+		Spec: core.LimitRangeSpec{
+			Limits: []core.LimitRangeItem{{
+				Type: core.LimitTypeContainer,
+				Default: core.ResourceList{
+					core.ResourceCPU: resource.MustParse("500m"),
+					// The default limit is capped to the quota.
+					core.ResourceMemory: resource.MustParse("256Mi"),
+				},
+				DefaultRequest: core.ResourceList{
+					core.ResourceCPU:    resource.MustParse("100m"),
+					core.ResourceMemory: resource.MustParse("128Mi"),
+				},
+			}},
+		},
+	}
+	gomock.InOrder(
+		s.mockResourceQuotas.EXPECT().Update(gomock.Any(), quota, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockResourceQuotas.EXPECT().Create(gomock.Any(), quota, v1.CreateOptions{}).
+			Return(quota, nil),
+		s.mockLimitRanges.EXPECT().Update(gomock.Any(), limitRange, v1.UpdateOptions{}).
+			Return(limitRange, nil),
+	)
+	err = s.broker.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Removing the limits removes the quota.
+	gomock.InOrder(
+		s.mockResourceQuotas.EXPECT().Delete(gomock.Any(), "juju-model", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+		s.mockLimitRanges.EXPECT().Delete(gomock.Any(), "juju-model", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+	err = s.broker.SetConfig(s.cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestSetConfigInvalidNamespaceQuota(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	cfg, err := s.cfg.Apply(map[string]interface{}{
+		provider.NamespaceCPULimitKey: "lots",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.SetConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: k8s-namespace-cpu-limit "lots" not valid`)
+}
+
+func (s *K8sBrokerSuite) TestResourceQuota(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockResourceQuotas.EXPECT().Get(gomock.Any(), "juju-model", v1.GetOptions{}).
+		Return(&core.ResourceQuota{
+			ObjectMeta: s.quotaMeta(),
+			Status: core.ResourceQuotaStatus{
+				Hard: core.ResourceList{
+					core.ResourceLimitsCPU:    resource.MustParse("4"),
+					core.ResourceLimitsMemory: resource.MustParse("8Gi"),
+				},
+				Used: core.ResourceList{
+					core.ResourceLimitsCPU:    resource.MustParse("1500m"),
+					core.ResourceLimitsMemory: resource.MustParse("2Gi"),
+				},
+			},
+		}, nil)
+
+	quota, err := s.broker.ResourceQuota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, jc.DeepEquals, &caas.ResourceQuota{
+		CPULimit:    4000,
+		CPUUsed:     1500,
+		MemoryLimit: 8192,
+		MemoryUsed:  2048,
+	})
+}
+
+func (s *K8sBrokerSuite) TestResourceQuotaNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockResourceQuotas.EXPECT().Get(gomock.Any(), "juju-model", v1.GetOptions{}).
+		Return(nil, s.k8sNotFoundError())
+
+	_, err := s.broker.ResourceQuota()
+	c.Assert(err, gc.ErrorMatches, `resource quota for namespace "test" not found`)
+}
+
+func (s *K8sBrokerSuite) assertPrecheckResourceQuota(c *gc.C, cons string, numUnits int) error {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	cfg, err := s.cfg.Apply(map[string]interface{}{
+		provider.NamespaceMemoryLimitKey: "4Gi",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockResourceQuotas.EXPECT().Update(gomock.Any(), gomock.Any(), v1.UpdateOptions{}).Return(nil, nil)
+	s.mockLimitRanges.EXPECT().Update(gomock.Any(), gomock.Any(), v1.UpdateOptions{}).Return(nil, nil)
+	err = s.broker.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.mockResourceQuotas.EXPECT().Get(gomock.Any(), "juju-model", v1.GetOptions{}).
+		Return(&core.ResourceQuota{
+			ObjectMeta: s.quotaMeta(),
+			Status: core.ResourceQuotaStatus{
+				Hard: core.ResourceList{core.ResourceLimitsMemory: resource.MustParse("4Gi")},
+				Used: core.ResourceList{core.ResourceLimitsMemory: resource.MustParse("3Gi")},
+			},
+		}, nil)
+	return s.broker.PrecheckInstance(context.NewCloudCallContext(), environs.PrecheckInstanceParams{
+		Series:      "kubernetes",
+		Constraints: constraints.MustParse(cons),
+		NumUnits:    numUnits,
+	})
+}
+
+func (s *K8sBrokerSuite) TestPrecheckResourceQuota(c *gc.C) {
+	err := s.assertPrecheckResourceQuota(c, "mem=1G", 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestPrecheckResourceQuotaDefaultLimit(c *gc.C) {
+	err := s.assertPrecheckResourceQuota(c, "", 0)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestPrecheckResourceQuotaExceeded(c *gc.C) {
+	err := s.assertPrecheckResourceQuota(c, "mem=2G", 1)
+	c.Assert(err, gc.ErrorMatches, `a unit limited to 2048Mi memory would exceed the model's resource quota \(3072Mi of 4096Mi used\)`)
+}
+
+func (s *K8sBrokerSuite) TestPrecheckResourceQuotaNumUnits(c *gc.C) {
+	err := s.assertPrecheckResourceQuota(c, "mem=256M", 4)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestPrecheckResourceQuotaNumUnitsExceeded(c *gc.C) {
+	err := s.assertPrecheckResourceQuota(c, "mem=512M", 3)
+	c.Assert(err, gc.ErrorMatches, `3 units each limited to 512Mi memory would exceed the model's resource quota \(3072Mi of 4096Mi used\)`)
+}
@@ -47,6 +47,8 @@ func (p podSpecV3) ToLatest() *specs.PodSpec {
 	pSpec.Service = p.caaSSpecV3.Service
 	pSpec.ConfigMaps = p.caaSSpecV3.ConfigMaps
 	pSpec.ServiceAccount = p.caaSSpecV3.ServiceAccount
+	pSpec.Availability = p.caaSSpecV3.Availability
+	pSpec.Monitoring = p.caaSSpecV3.Monitoring
 	pSpec.ProviderPod = &p.K8sPodSpecV3
 	return pSpec
 }
@@ -1107,6 +1107,145 @@ serviceAccount:
 	c.Assert(err, gc.ErrorMatches, `roles is required`)
 }
 
+func (s *v3SpecsSuite) TestParseAvailability(c *gc.C) {
+	specStr := version3Header + `
+containers:
+  - name: mariadb
+    image: mariadb/latest
+availability:
+  disruptionBudget:
+    maxUnavailable: 1
+  topologySpread:
+    - scope: zone
+      required: true
+    - scope: host
+      maxSkew: 2
+  antiAffinity:
+    scope: host
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Availability, jc.DeepEquals, &specs.AvailabilitySpec{
+		DisruptionBudget: &specs.DisruptionBudgetSpec{
+			MaxUnavailable: &specs.IntOrString{Type: specs.Int, IntVal: 1},
+		},
+		TopologySpread: []specs.TopologySpreadSpec{
+			{Scope: specs.ZoneScope, Required: true},
+			{Scope: specs.HostScope, MaxSkew: 2},
+		},
+		AntiAffinity: &specs.AntiAffinitySpec{Scope: specs.HostScope},
+	})
+}
+
+func (s *v3SpecsSuite) TestValidateAvailability(c *gc.C) {
+	specStr := version3Header + `
+containers:
+  - name: mariadb
+    image: mariadb/latest
+availability:
+  disruptionBudget:
+    minAvailable: 50%
+    maxUnavailable: 1
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `availability: disruptionBudget: exactly one of minAvailable or maxUnavailable is required`)
+
+	specStr = version3Header + `
+containers:
+  - name: mariadb
+    image: mariadb/latest
+availability:
+  antiAffinity:
+    scope: rack
+`[1:]
+
+	_, err = k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `availability: antiAffinity: topology scope "rack" not supported`)
+}
+
+func (s *v3SpecsSuite) TestParseMonitoring(c *gc.C) {
+	specStr := version3Header + `
+containers:
+  - name: mariadb
+    image: mariadb/latest
+    ports:
+    - name: metrics
+      containerPort: 9104
+      protocol: TCP
+monitoring:
+  kind: pod
+  endpoints:
+    - port: metrics
+      path: /stats
+      interval: 30s
+  labels:
+    release: prometheus
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Monitoring, jc.DeepEquals, &specs.MonitoringSpec{
+		Kind: specs.PodMonitor,
+		Endpoints: []specs.MetricsEndpointSpec{
+			{Port: "metrics", Path: "/stats", Interval: "30s"},
+		},
+		Labels: map[string]string{"release": "prometheus"},
+	})
+}
+
+func (s *v3SpecsSuite) TestValidateMonitoring(c *gc.C) {
+	specStr := version3Header + `
+containers:
+  - name: mariadb
+    image: mariadb/latest
+    ports:
+    - name: metrics
+      containerPort: 9104
+      protocol: TCP
+monitoring:
+  endpoints:
+    - port: prometheus
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `monitoring: service port "prometheus" not found`)
+
+	specStr = version3Header + `
+containers:
+  - name: mariadb
+    image: mariadb/latest
+  - name: init
+    init: true
+    image: mariadb/latest
+    ports:
+    - name: metrics
+      containerPort: 9104
+      protocol: TCP
+monitoring:
+  kind: pod
+  endpoints:
+    - port: metrics
+`[1:]
+
+	_, err = k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `monitoring: container port "metrics" not found`)
+
+	specStr = version3Header + `
+containers:
+  - name: mariadb
+    image: mariadb/latest
+monitoring:
+  kind: node
+  endpoints:
+    - port: metrics
+`[1:]
+
+	_, err = k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `monitoring: monitor kind "node" not supported`)
+}
+
 func (s *v3SpecsSuite) TestValidateCustomResourceDefinitions(c *gc.C) {
 	specStr := version3Header + `
 containers:
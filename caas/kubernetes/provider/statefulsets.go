@@ -144,6 +144,10 @@ func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPod
 	existing.Spec.Replicas = spec.Spec.Replicas
 	existing.Spec.UpdateStrategy = spec.Spec.UpdateStrategy
 	existing.Spec.Template.SetAnnotations(spec.Spec.Template.GetAnnotations())
+	_, existing.Spec.Template.Labels = adoptedPodSelector(
+		existing.Spec.Selector, existing.Spec.Template.Labels,
+		spec.Spec.Selector, spec.Spec.Template.Labels,
+	)
 	// TODO(caas) - allow storage `request` configurable - currently we only allow `limit`.
 	existing.Spec.Template.Spec.Containers = existingPodSpec.Containers
 	existing.Spec.Template.Spec.ServiceAccountName = existingPodSpec.ServiceAccountName
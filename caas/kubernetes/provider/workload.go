@@ -0,0 +1,143 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/juju/juju/caas"
+)
+
+const (
+	containerStateWaiting    = "waiting"
+	containerStateRunning    = "running"
+	containerStateTerminated = "terminated"
+)
+
+// UnitWorkload returns the status, recent events and the last logLines
+// lines of container logs of the pod (name or uid) running a unit of the
+// specified application.
+func (k *kubernetesClient) UnitWorkload(appName string, podName string, logLines int) (*caas.UnitWorkload, error) {
+	pod, err := k.getAppPod(appName, caas.ModeWorkload, podName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := &caas.UnitWorkload{
+		PodName: pod.Name,
+		Phase:   string(pod.Status.Phase),
+		Message: pod.Status.Message,
+		Node:    pod.Spec.NodeName,
+	}
+	for _, cond := range pod.Status.Conditions {
+		result.Conditions = append(result.Conditions, caas.WorkloadCondition{
+			Type:          string(cond.Type),
+			Status:        string(cond.Status),
+			Reason:        cond.Reason,
+			Message:       cond.Message,
+			LastUpdatedAt: cond.LastTransitionTime.Time,
+		})
+	}
+
+	containers, err := k.workloadContainers(pod, pod.Status.InitContainerStatuses, true, logLines)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.Containers = append(result.Containers, containers...)
+	if containers, err = k.workloadContainers(pod, pod.Status.ContainerStatuses, false, logLines); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.Containers = append(result.Containers, containers...)
+
+	events, err := k.getEvents(pod.Name, "Pod")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Oldest first, as kubectl describe shows them.
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventLastSeen(events[i]).Before(eventLastSeen(events[j]))
+	})
+	for _, evt := range events {
+		result.Events = append(result.Events, caas.WorkloadEvent{
+			Type:     evt.Type,
+			Reason:   evt.Reason,
+			Message:  evt.Message,
+			Count:    evt.Count,
+			LastSeen: eventLastSeen(evt),
+		})
+	}
+	return result, nil
+}
+
+func (k *kubernetesClient) workloadContainers(
+	pod *core.Pod, statuses []core.ContainerStatus, init bool, logLines int,
+) ([]caas.WorkloadContainer, error) {
+	var result []caas.WorkloadContainer
+	for _, cs := range statuses {
+		container := caas.WorkloadContainer{
+			Name:         cs.Name,
+			Image:        cs.Image,
+			Init:         init,
+			Ready:        cs.Ready,
+			RestartCount: cs.RestartCount,
+		}
+		switch {
+		case cs.State.Waiting != nil:
+			container.State = containerStateWaiting
+			container.Reason = cs.State.Waiting.Reason
+			container.Message = cs.State.Waiting.Message
+		case cs.State.Running != nil:
+			container.State = containerStateRunning
+		case cs.State.Terminated != nil:
+			container.State = containerStateTerminated
+			container.Reason = cs.State.Terminated.Reason
+			container.Message = cs.State.Terminated.Message
+		}
+		if logLines > 0 {
+			logs, err := k.containerLogs(pod.Name, cs.Name, logLines)
+			if err != nil {
+				return nil, errors.Annotatef(err, "getting logs for container %q", cs.Name)
+			}
+			container.Logs = logs
+		}
+		result = append(result, container)
+	}
+	return result, nil
+}
+
+// containerLogs returns the last lines logged by a container. A container
+// which hasn't started yet has no logs.
+func (k *kubernetesClient) containerLogs(podName, containerName string, lines int) ([]string, error) {
+	tailLines := int64(lines)
+	raw, err := k.client().CoreV1().Pods(k.namespace).GetLogs(podName, &core.PodLogOptions{
+		Container: containerName,
+		TailLines: &tailLines,
+	}).Do(context.TODO()).Raw()
+	if k8serrors.IsBadRequest(err) {
+		logger.Debugf("no logs for container %q of pod %q: %v", containerName, podName, err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logs := strings.TrimRight(string(raw), "\n")
+	if logs == "" {
+		return nil, nil
+	}
+	return strings.Split(logs, "\n"), nil
+}
+
+func eventLastSeen(evt core.Event) time.Time {
+	if !evt.LastTimestamp.IsZero() {
+		return evt.LastTimestamp.Time
+	}
+	return evt.FirstTimestamp.Time
+}
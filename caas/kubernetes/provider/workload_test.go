@@ -0,0 +1,213 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/rest/fake"
+
+	"github.com/juju/juju/caas"
+)
+
+// logsRequest returns a request which responds with the given status
+// code and body when run.
+func logsRequest(statusCode int, body string) *rest.Request {
+	client := fake.CreateHTTPClient(func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{"Content-Type": []string{"text/plain"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	return rest.NewRequestWithClient(
+		&url.URL{Scheme: "https", Host: "1.1.1.1"},
+		"v1",
+		rest.ClientContentConfig{
+			GroupVersion: core.SchemeGroupVersion,
+			Negotiator:   runtime.NewClientNegotiator(scheme.Codecs.WithoutConversion(), core.SchemeGroupVersion),
+		},
+		client,
+	)
+}
+
+func (s *K8sBrokerSuite) TestUnitWorkload(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	earlier := time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Minute)
+	pod := &core.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name-0"},
+		Spec:       core.PodSpec{NodeName: "node-1"},
+		Status: core.PodStatus{
+			Phase: core.PodPending,
+			Conditions: []core.PodCondition{{
+				Type:               core.PodScheduled,
+				Status:             core.ConditionTrue,
+				LastTransitionTime: v1.NewTime(earlier),
+			}, {
+				Type:    core.ContainersReady,
+				Status:  core.ConditionFalse,
+				Reason:  "ContainersNotReady",
+				Message: "containers with unready status: [app-name]",
+			}},
+			InitContainerStatuses: []core.ContainerStatus{{
+				Name:  "juju-pod-init",
+				Image: "jujusolutions/jujud-operator",
+				State: core.ContainerState{Terminated: &core.ContainerStateTerminated{Reason: "Completed"}},
+			}},
+			ContainerStatuses: []core.ContainerStatus{{
+				Name:         "app-name",
+				Image:        "mysql:8",
+				RestartCount: 3,
+				State: core.ContainerState{Waiting: &core.ContainerStateWaiting{
+					Reason:  "CrashLoopBackOff",
+					Message: "back-off 40s restarting failed container",
+				}},
+			}},
+		},
+	}
+	events := &core.EventList{Items: []core.Event{{
+		Type:          core.EventTypeWarning,
+		Reason:        "BackOff",
+		Message:       "Back-off restarting failed container",
+		Count:         5,
+		LastTimestamp: v1.NewTime(later),
+	}, {
+		Type:           core.EventTypeNormal,
+		Reason:         "Scheduled",
+		Message:        "Successfully assigned test/app-name-0 to node-1",
+		Count:          1,
+		FirstTimestamp: v1.NewTime(earlier),
+	}}}
+	tailLines := int64(2)
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get(gomock.Any(), "app-name-0", v1.GetOptions{}).Return(pod, nil),
+		s.mockPods.EXPECT().GetLogs("app-name-0", &core.PodLogOptions{Container: "juju-pod-init", TailLines: &tailLines}).
+			Return(logsRequest(http.StatusOK, "initialised\n")),
+		s.mockPods.EXPECT().GetLogs("app-name-0", &core.PodLogOptions{Container: "app-name", TailLines: &tailLines}).
+			Return(logsRequest(http.StatusOK, "starting mysqld\nerror: no datadir\n")),
+		s.mockEvents.EXPECT().List(gomock.Any(),
+			listOptionsFieldSelectorMatcher("involvedObject.name=app-name-0,involvedObject.kind=Pod"),
+		).Return(events, nil),
+	)
+
+	workload, err := s.broker.UnitWorkload("app-name", "app-name-0", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(workload, jc.DeepEquals, &caas.UnitWorkload{
+		PodName: "app-name-0",
+		Phase:   "Pending",
+		Node:    "node-1",
+		Conditions: []caas.WorkloadCondition{{
+			Type:          "PodScheduled",
+			Status:        "True",
+			LastUpdatedAt: v1.NewTime(earlier).Time,
+		}, {
+			Type:    "ContainersReady",
+			Status:  "False",
+			Reason:  "ContainersNotReady",
+			Message: "containers with unready status: [app-name]",
+		}},
+		Containers: []caas.WorkloadContainer{{
+			Name:   "juju-pod-init",
+			Image:  "jujusolutions/jujud-operator",
+			Init:   true,
+			State:  "terminated",
+			Reason: "Completed",
+			Logs:   []string{"initialised"},
+		}, {
+			Name:         "app-name",
+			Image:        "mysql:8",
+			RestartCount: 3,
+			State:        "waiting",
+			Reason:       "CrashLoopBackOff",
+			Message:      "back-off 40s restarting failed container",
+			Logs:         []string{"starting mysqld", "error: no datadir"},
+		}},
+		Events: []caas.WorkloadEvent{{
+			Type:     "Normal",
+			Reason:   "Scheduled",
+			Message:  "Successfully assigned test/app-name-0 to node-1",
+			Count:    1,
+			LastSeen: v1.NewTime(earlier).Time,
+		}, {
+			Type:     "Warning",
+			Reason:   "BackOff",
+			Message:  "Back-off restarting failed container",
+			Count:    5,
+			LastSeen: v1.NewTime(later).Time,
+		}},
+	})
+}
+
+func (s *K8sBrokerSuite) TestUnitWorkloadByUIDWithoutLogs(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	podList := &core.PodList{
+		Items: []core.Pod{{
+			ObjectMeta: v1.ObjectMeta{Name: "app-name-xyz", UID: types.UID("uuid")},
+			Status: core.PodStatus{
+				Phase: core.PodPending,
+				ContainerStatuses: []core.ContainerStatus{{
+					Name:  "app-name",
+					State: core.ContainerState{Waiting: &core.ContainerStateWaiting{Reason: "ContainerCreating"}},
+				}},
+			},
+		}},
+	}
+	tailLines := int64(10)
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get(gomock.Any(), "uuid", v1.GetOptions{}).Return(nil, s.k8sNotFoundError()),
+		s.mockPods.EXPECT().List(gomock.Any(), v1.ListOptions{LabelSelector: "juju-app=app-name"}).Return(podList, nil),
+		// A container which hasn't started has no logs.
+		s.mockPods.EXPECT().GetLogs("app-name-xyz", &core.PodLogOptions{Container: "app-name", TailLines: &tailLines}).
+			Return(logsRequest(http.StatusBadRequest, `container "app-name" is waiting to start`)),
+		s.mockEvents.EXPECT().List(gomock.Any(),
+			listOptionsFieldSelectorMatcher("involvedObject.name=app-name-xyz,involvedObject.kind=Pod"),
+		).Return(&core.EventList{}, nil),
+	)
+
+	workload, err := s.broker.UnitWorkload("app-name", "uuid", 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(workload, jc.DeepEquals, &caas.UnitWorkload{
+		PodName: "app-name-xyz",
+		Phase:   "Pending",
+		Containers: []caas.WorkloadContainer{{
+			Name:   "app-name",
+			State:  "waiting",
+			Reason: "ContainerCreating",
+		}},
+	})
+}
+
+func (s *K8sBrokerSuite) TestUnitWorkloadNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get(gomock.Any(), "app-name-0", v1.GetOptions{}).Return(nil, s.k8sNotFoundError()),
+		s.mockPods.EXPECT().List(gomock.Any(), v1.ListOptions{LabelSelector: "juju-app=app-name"}).
+			Return(&core.PodList{}, nil),
+	)
+
+	_, err := s.broker.UnitWorkload("app-name", "app-name-0", 10)
+	c.Assert(err, gc.ErrorMatches, `pod "app-name-0" not found`)
+}
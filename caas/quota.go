@@ -0,0 +1,15 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+// ResourceQuota describes the limits on, and usage of, the compute
+// resources of a model. CPU is measured in millicores and memory in MiB,
+// matching the cpu-power and mem constraints. A zero limit means the
+// resource isn't limited.
+type ResourceQuota struct {
+	CPULimit    uint64
+	CPUUsed     uint64
+	MemoryLimit uint64
+	MemoryUsed  uint64
+}
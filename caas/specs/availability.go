@@ -0,0 +1,139 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+)
+
+// TopologyScope defines the failure domain that pods are spread across or
+// kept apart in.
+type TopologyScope string
+
+const (
+	// ZoneScope spreads pods across availability zones. The zones available
+	// are those allowed by the application's zones constraint.
+	ZoneScope TopologyScope = "zone"
+
+	// HostScope spreads pods across nodes.
+	HostScope TopologyScope = "host"
+)
+
+// Validate returns an error if the scope is not valid.
+func (s TopologyScope) Validate() error {
+	switch s {
+	case ZoneScope, HostScope:
+		return nil
+	}
+	return errors.NotSupportedf("topology scope %q", s)
+}
+
+// DisruptionBudgetSpec limits how many of an application's pods may be
+// taken down at once by voluntary disruptions, such as a node drain.
+type DisruptionBudgetSpec struct {
+	MinAvailable   *IntOrString `json:"minAvailable,omitempty" yaml:"minAvailable,omitempty"`
+	MaxUnavailable *IntOrString `json:"maxUnavailable,omitempty" yaml:"maxUnavailable,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (s DisruptionBudgetSpec) Validate() error {
+	if (s.MinAvailable == nil) == (s.MaxUnavailable == nil) {
+		return errors.New("exactly one of minAvailable or maxUnavailable is required")
+	}
+	if s.MinAvailable != nil {
+		return errors.Annotate(validateCountOrPercent(*s.MinAvailable), "minAvailable")
+	}
+	return errors.Annotate(validateCountOrPercent(*s.MaxUnavailable), "maxUnavailable")
+}
+
+func validateCountOrPercent(v IntOrString) error {
+	if v.Type == Int {
+		if v.IntVal < 0 {
+			return errors.NotValidf("negative value %d", v.IntVal)
+		}
+		return nil
+	}
+	if !strings.HasSuffix(v.StrVal, "%") {
+		return errors.NotValidf("value %q, expected an integer or a percentage", v.StrVal)
+	}
+	percent, err := strconv.Atoi(strings.TrimSuffix(v.StrVal, "%"))
+	if err != nil || percent < 0 || percent > 100 {
+		return errors.NotValidf("percentage %q", v.StrVal)
+	}
+	return nil
+}
+
+// TopologySpreadSpec asks for an application's pods to be spread evenly
+// across a failure domain.
+type TopologySpreadSpec struct {
+	Scope TopologyScope `json:"scope" yaml:"scope"`
+	// MaxSkew is the largest allowed difference in the number of pods
+	// between any two domains. Defaults to 1.
+	MaxSkew int32 `json:"maxSkew,omitempty" yaml:"maxSkew,omitempty"`
+	// Required stops pods being scheduled when the spread cannot be
+	// satisfied; otherwise the spread is only a preference.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (s TopologySpreadSpec) Validate() error {
+	if err := s.Scope.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if s.MaxSkew < 0 {
+		return errors.NotValidf("negative maxSkew %d", s.MaxSkew)
+	}
+	return nil
+}
+
+// AntiAffinitySpec asks for no two of an application's pods to be placed
+// in the same failure domain.
+type AntiAffinitySpec struct {
+	Scope TopologyScope `json:"scope" yaml:"scope"`
+	// Required stops pods being scheduled when no free domain is left;
+	// otherwise the anti-affinity is only a preference.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (s AntiAffinitySpec) Validate() error {
+	return errors.Trace(s.Scope.Validate())
+}
+
+// AvailabilitySpec defines how an application's pods are kept available
+// through disruptions and spread across failure domains.
+type AvailabilitySpec struct {
+	DisruptionBudget *DisruptionBudgetSpec `json:"disruptionBudget,omitempty" yaml:"disruptionBudget,omitempty"`
+	TopologySpread   []TopologySpreadSpec  `json:"topologySpread,omitempty" yaml:"topologySpread,omitempty"`
+	AntiAffinity     *AntiAffinitySpec     `json:"antiAffinity,omitempty" yaml:"antiAffinity,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (s AvailabilitySpec) Validate() error {
+	if s.DisruptionBudget != nil {
+		if err := s.DisruptionBudget.Validate(); err != nil {
+			return errors.Annotate(err, "disruptionBudget")
+		}
+	}
+	scopes := set.NewStrings()
+	for _, spread := range s.TopologySpread {
+		if err := spread.Validate(); err != nil {
+			return errors.Annotate(err, "topologySpread")
+		}
+		if scopes.Contains(string(spread.Scope)) {
+			return errors.NotValidf("duplicated topologySpread scope %q", spread.Scope)
+		}
+		scopes.Add(string(spread.Scope))
+	}
+	if s.AntiAffinity != nil {
+		if err := s.AntiAffinity.Validate(); err != nil {
+			return errors.Annotate(err, "antiAffinity")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/specs"
+)
+
+func (s *typesSuite) TestDisruptionBudgetSpecValidate(c *gc.C) {
+	for i, t := range []struct {
+		spec specs.DisruptionBudgetSpec
+		err  string
+	}{{
+		spec: specs.DisruptionBudgetSpec{MinAvailable: &intVal},
+	}, {
+		spec: specs.DisruptionBudgetSpec{MaxUnavailable: &strVal},
+	}, {
+		spec: specs.DisruptionBudgetSpec{},
+		err:  `exactly one of minAvailable or maxUnavailable is required`,
+	}, {
+		spec: specs.DisruptionBudgetSpec{MinAvailable: &intVal, MaxUnavailable: &intVal},
+		err:  `exactly one of minAvailable or maxUnavailable is required`,
+	}, {
+		spec: specs.DisruptionBudgetSpec{MinAvailable: &specs.IntOrString{Type: specs.Int, IntVal: -1}},
+		err:  `minAvailable: negative value -1 not valid`,
+	}, {
+		spec: specs.DisruptionBudgetSpec{MaxUnavailable: &specs.IntOrString{Type: specs.String, StrVal: "two"}},
+		err:  `maxUnavailable: value "two", expected an integer or a percentage not valid`,
+	}, {
+		spec: specs.DisruptionBudgetSpec{MaxUnavailable: &specs.IntOrString{Type: specs.String, StrVal: "120%"}},
+		err:  `maxUnavailable: percentage "120%" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := t.spec.Validate()
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *typesSuite) TestAvailabilitySpecValidate(c *gc.C) {
+	spec := specs.AvailabilitySpec{
+		DisruptionBudget: &specs.DisruptionBudgetSpec{MinAvailable: &intVal},
+		TopologySpread: []specs.TopologySpreadSpec{
+			{Scope: specs.ZoneScope, MaxSkew: 2, Required: true},
+			{Scope: specs.HostScope},
+		},
+		AntiAffinity: &specs.AntiAffinitySpec{Scope: specs.HostScope},
+	}
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+
+	spec.TopologySpread = append(spec.TopologySpread, specs.TopologySpreadSpec{Scope: specs.ZoneScope})
+	c.Assert(spec.Validate(), gc.ErrorMatches, `duplicated topologySpread scope "zone" not valid`)
+
+	spec.TopologySpread = []specs.TopologySpreadSpec{{Scope: "rack"}}
+	c.Assert(spec.Validate(), gc.ErrorMatches, `topologySpread: topology scope "rack" not supported`)
+
+	spec.TopologySpread = []specs.TopologySpreadSpec{{Scope: specs.ZoneScope, MaxSkew: -1}}
+	c.Assert(spec.Validate(), gc.ErrorMatches, `topologySpread: negative maxSkew -1 not valid`)
+
+	spec.TopologySpread = nil
+	spec.AntiAffinity = &specs.AntiAffinitySpec{}
+	c.Assert(spec.Validate(), gc.ErrorMatches, `antiAffinity: topology scope "" not supported`)
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// MonitorKind defines how Prometheus finds the metrics endpoints of an
+// application.
+type MonitorKind string
+
+const (
+	// ServiceMonitor scrapes the metrics endpoints through the
+	// application's service.
+	ServiceMonitor MonitorKind = "service"
+
+	// PodMonitor scrapes the metrics endpoints of each pod directly, for
+	// applications without a service.
+	PodMonitor MonitorKind = "pod"
+)
+
+// Validate returns an error if the kind is not valid.
+func (k MonitorKind) Validate() error {
+	switch k {
+	case ServiceMonitor, PodMonitor:
+		return nil
+	}
+	return errors.NotSupportedf("monitor kind %q", k)
+}
+
+// MetricsEndpointSpec defines a port on which an application exposes
+// metrics to be scraped.
+type MetricsEndpointSpec struct {
+	// Port is the name of a port of the application's service for a
+	// service monitor, or of a container port for a pod monitor.
+	Port string `json:"port" yaml:"port"`
+	// Path defaults to /metrics.
+	Path   string `json:"path,omitempty" yaml:"path,omitempty"`
+	Scheme string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	// Interval defaults to the scrape interval of Prometheus itself.
+	Interval string `json:"interval,omitempty" yaml:"interval,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (s MetricsEndpointSpec) Validate() error {
+	if s.Port == "" {
+		return errors.New("port is required")
+	}
+	if s.Path != "" && !strings.HasPrefix(s.Path, "/") {
+		return errors.NotValidf("path %q", s.Path)
+	}
+	switch s.Scheme {
+	case "", "http", "https":
+	default:
+		return errors.NotSupportedf("scheme %q", s.Scheme)
+	}
+	if s.Interval != "" {
+		if d, err := time.ParseDuration(s.Interval); err != nil || d <= 0 {
+			return errors.NotValidf("interval %q", s.Interval)
+		}
+	}
+	return nil
+}
+
+// MonitoringSpec declares the metrics endpoints of an application, so
+// that a Prometheus operator running in the cluster can scrape them.
+type MonitoringSpec struct {
+	// Kind defaults to service.
+	Kind      MonitorKind           `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Endpoints []MetricsEndpointSpec `json:"endpoints" yaml:"endpoints"`
+	// Labels are added to the monitor, so that it's picked up by the
+	// monitor selector of a Prometheus instance.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (s MonitoringSpec) Validate() error {
+	if s.Kind != "" {
+		if err := s.Kind.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(s.Endpoints) == 0 {
+		return errors.New("at least one endpoint is required")
+	}
+	for _, e := range s.Endpoints {
+		if err := e.Validate(); err != nil {
+			return errors.Annotate(err, "endpoints")
+		}
+	}
+	return nil
+}
+
+// validatePorts returns an error if an endpoint refers to a port which
+// isn't a named port of one of the workload containers. The ports of a
+// service monitor are those of the application's service, which only
+// exposes the ports with a number.
+func (s MonitoringSpec) validatePorts(containers []ContainerSpec) error {
+	serviceMonitor := s.Kind != PodMonitor
+	ports := make(map[string]bool)
+	for _, c := range containers {
+		if c.Init {
+			continue
+		}
+		for _, p := range c.Ports {
+			if p.Name == "" || (serviceMonitor && p.ContainerPort == 0) {
+				continue
+			}
+			ports[p.Name] = true
+		}
+	}
+	for _, e := range s.Endpoints {
+		if ports[e.Port] {
+			continue
+		}
+		if serviceMonitor {
+			return errors.NotFoundf("service port %q", e.Port)
+		}
+		return errors.NotFoundf("container port %q", e.Port)
+	}
+	return nil
+}
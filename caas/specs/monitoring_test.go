@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/specs"
+)
+
+func (s *typesSuite) TestMetricsEndpointSpecValidate(c *gc.C) {
+	for i, t := range []struct {
+		spec specs.MetricsEndpointSpec
+		err  string
+	}{{
+		spec: specs.MetricsEndpointSpec{Port: "metrics"},
+	}, {
+		spec: specs.MetricsEndpointSpec{Port: "metrics", Path: "/stats", Scheme: "https", Interval: "30s"},
+	}, {
+		spec: specs.MetricsEndpointSpec{},
+		err:  `port is required`,
+	}, {
+		spec: specs.MetricsEndpointSpec{Port: "metrics", Path: "stats"},
+		err:  `path "stats" not valid`,
+	}, {
+		spec: specs.MetricsEndpointSpec{Port: "metrics", Scheme: "ftp"},
+		err:  `scheme "ftp" not supported`,
+	}, {
+		spec: specs.MetricsEndpointSpec{Port: "metrics", Interval: "often"},
+		err:  `interval "often" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := t.spec.Validate()
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *typesSuite) TestMonitoringSpecValidate(c *gc.C) {
+	spec := specs.MonitoringSpec{
+		Endpoints: []specs.MetricsEndpointSpec{{Port: "metrics"}},
+	}
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+
+	spec.Kind = specs.PodMonitor
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+
+	spec.Kind = "node"
+	c.Assert(spec.Validate(), gc.ErrorMatches, `monitor kind "node" not supported`)
+
+	spec.Kind = specs.ServiceMonitor
+	spec.Endpoints = nil
+	c.Assert(spec.Validate(), gc.ErrorMatches, `at least one endpoint is required`)
+
+	spec.Endpoints = []specs.MetricsEndpointSpec{{Port: "metrics", Interval: "-1s"}}
+	c.Assert(spec.Validate(), gc.ErrorMatches, `endpoints: interval "-1s" not valid`)
+}
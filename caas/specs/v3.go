@@ -12,6 +12,8 @@ import (
 type PodSpecV3 struct {
 	podSpecBase    `json:",inline" yaml:",inline"`
 	ServiceAccount *PrimeServiceAccountSpecV3 `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+	Availability   *AvailabilitySpec          `json:"availability,omitempty" yaml:"availability,omitempty"`
+	Monitoring     *MonitoringSpec            `json:"monitoring,omitempty" yaml:"monitoring,omitempty"`
 }
 
 // Version3 defines the version number for pod spec version 3.
@@ -24,7 +26,20 @@ func (spec *PodSpecV3) Validate() error {
 	}
 	if spec.ServiceAccount != nil {
 		// TODO: do we want to restrict the prime sa can only have 1 role/clusterrole???????
-		return errors.Trace(spec.ServiceAccount.Validate())
+		if err := spec.ServiceAccount.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if spec.Availability != nil {
+		if err := spec.Availability.Validate(); err != nil {
+			return errors.Annotate(err, "availability")
+		}
+	}
+	if spec.Monitoring != nil {
+		if err := spec.Monitoring.Validate(); err != nil {
+			return errors.Annotate(err, "monitoring")
+		}
+		return errors.Annotate(spec.Monitoring.validatePorts(spec.Containers), "monitoring")
 	}
 	return nil
 }
@@ -0,0 +1,76 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// WorkloadKind is the kind of cluster resource which runs the pods of an
+// adopted workload.
+type WorkloadKind string
+
+const (
+	// WorkloadDeployment is a workload run by a deployment.
+	WorkloadDeployment WorkloadKind = "deployment"
+
+	// WorkloadStatefulSet is a workload run by a stateful set.
+	WorkloadStatefulSet WorkloadKind = "statefulset"
+)
+
+// Validate returns an error if the workload kind can't be adopted.
+func (k WorkloadKind) Validate() error {
+	switch k {
+	case WorkloadDeployment, WorkloadStatefulSet:
+		return nil
+	}
+	return errors.NotValidf("workload kind %q", k)
+}
+
+// UnitWorkload describes the pod running a unit, as an operator
+// would see it when diagnosing a unit which isn't starting.
+type UnitWorkload struct {
+	PodName    string
+	Phase      string
+	Message    string
+	Node       string
+	Conditions []WorkloadCondition
+	Containers []WorkloadContainer
+	Events     []WorkloadEvent
+}
+
+// WorkloadCondition is a condition of a unit's pod.
+type WorkloadCondition struct {
+	Type          string
+	Status        string
+	Reason        string
+	Message       string
+	LastUpdatedAt time.Time
+}
+
+// WorkloadContainer describes a container in a unit's pod.
+type WorkloadContainer struct {
+	Name         string
+	Image        string
+	Init         bool
+	Ready        bool
+	RestartCount int32
+	// State is one of waiting, running or terminated.
+	State   string
+	Reason  string
+	Message string
+	// Logs holds the last lines logged by the container.
+	Logs []string
+}
+
+// WorkloadEvent is an event recorded for a unit's pod.
+type WorkloadEvent struct {
+	Type     string
+	Reason   string
+	Message  string
+	Count    int32
+	LastSeen time.Time
+}
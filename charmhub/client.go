@@ -18,6 +18,7 @@ package charmhub
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -189,3 +190,9 @@ func (c *Client) Refresh(ctx context.Context, config RefreshConfig) ([]transport
 func (c *Client) Download(ctx context.Context, resourceURL *url.URL, archivePath string) (*charm.CharmArchive, error) {
 	return c.downloadClient.Download(ctx, resourceURL, archivePath)
 }
+
+// DownloadResource returns a reader for a charm resource retrieved directly
+// from the given URL.
+func (c *Client) DownloadResource(ctx context.Context, resourceURL *url.URL) (io.ReadCloser, error) {
+	return c.downloadClient.DownloadResource(ctx, resourceURL)
+}
@@ -85,6 +85,16 @@ func (c *DownloadClient) Download(ctx context.Context, resourceURL *url.URL, arc
 	return charm.ReadCharmArchive(archivePath)
 }
 
+// DownloadResource returns a reader for the resource retrieved from the
+// given URL. It is the callee's responsibility to close the reader.
+func (c *DownloadClient) DownloadResource(ctx context.Context, resourceURL *url.URL) (io.ReadCloser, error) {
+	r, err := c.downloadFromURL(ctx, resourceURL)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot retrieve %q", resourceURL)
+	}
+	return r, nil
+}
+
 func (c *DownloadClient) downloadFromURL(ctx context.Context, resourceURL *url.URL) (r io.ReadCloser, err error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", resourceURL.String(), nil)
 	if err != nil {
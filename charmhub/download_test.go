@@ -119,6 +119,30 @@ func (s *DownloadSuite) TestDownloadWithFailedStatusCode(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot retrieve "http://meshuggah.rocks": unable to locate archive`)
 }
 
+func (s *DownloadSuite) TestDownloadResource(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	transport := NewMockTransport(ctrl)
+	transport.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("resource-data")),
+		}, nil
+	})
+
+	serverURL, err := url.Parse("http://meshuggah.rocks")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := NewDownloadClient(transport, NewMockFileSystem(ctrl), &FakeLogger{})
+	r, err := client.DownloadResource(context.TODO(), serverURL)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { _ = r.Close() }()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "resource-data")
+}
+
 func (s *DownloadSuite) createCharmArchieve(c *gc.C) []byte {
 	tmpDir, err := ioutil.TempDir("", "charm")
 	c.Assert(err, jc.ErrorIsNil)
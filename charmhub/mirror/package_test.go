@@ -0,0 +1,86 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+func Test(t *testing.T) {
+	gc.TestingT(t)
+}
+
+const resourceData = "resource-data"
+
+// resourceHash is the SHA256 of resourceData.
+var resourceHash = func() string {
+	h := sha256.Sum256([]byte(resourceData))
+	return hex.EncodeToString(h[:])
+}()
+
+func channelMap(channel string, revision int, hash string) transport.ChannelMap {
+	return transport.ChannelMap{
+		Channel: transport.Channel{
+			Name:       channel,
+			ReleasedAt: "2020-10-01T10:00:00Z",
+			Platform: transport.Platform{
+				Architecture: "amd64",
+				OS:           "ubuntu",
+				Series:       "focal",
+			},
+		},
+		Revision: transport.Revision{
+			Revision: revision,
+			Version:  "1.0",
+			Download: transport.Download{
+				HashSHA265: hash,
+				URL:        fmt.Sprintf("https://api.charmhub.io/api/v1/charms/download/abc_%d.charm", revision),
+			},
+			MetadataYAML: "name: dummy\nsummary: dummy\ndescription: dummy\n",
+		},
+		Resources: []transport.ResourceRevision{{
+			Name:     "data",
+			Type:     "file",
+			Filename: "data.tar",
+			Revision: revision,
+			Download: transport.Download{
+				HashSHA265: resourceHash,
+				URL:        fmt.Sprintf("https://api.charmhub.io/api/v1/resources/download/abc_data_%d", revision),
+			},
+		}},
+	}
+}
+
+func dummyInfo(hash string) transport.InfoResponse {
+	return transport.InfoResponse{
+		Type: "charm",
+		ID:   "abc",
+		Name: "dummy",
+		Entity: transport.Entity{
+			Summary: "A dummy charm",
+			License: "Apache-2.0",
+		},
+		ChannelMap: []transport.ChannelMap{
+			channelMap("latest/stable", 1, hash),
+			channelMap("latest/edge", 2, hash),
+		},
+		DefaultRelease: channelMap("latest/stable", 1, hash),
+	}
+}
+
+func fileHash(c *gc.C, path string) string {
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
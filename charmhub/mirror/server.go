@@ -0,0 +1,453 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmizerany/pat"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/charmhub/transport"
+	corecharm "github.com/juju/juju/core/charm"
+)
+
+// The following are the error codes returned by the mirror, they match the
+// codes returned by CharmHub for the same conditions.
+const (
+	ErrorCodeNotFound         = "not-found"
+	ErrorCodeRevisionNotFound = "revision-not-found"
+	ErrorCodeInvalidRequest   = "invalid-request"
+	ErrorCodeInternal         = "internal-server-error"
+)
+
+const (
+	apiPrefix      = "/v2/charms"
+	downloadPrefix = "/download"
+)
+
+// ServerConfig holds the configuration for a mirror server.
+type ServerConfig struct {
+	// Store holds the mirrored charms and resources to serve.
+	Store *Store
+
+	// PublicURL is the URL the mirror is reachable on by clients, with no
+	// trailing slash. It is used to construct the download URLs handed out
+	// in responses. If empty, the URL is derived from each request.
+	PublicURL string
+}
+
+// Validate ensures that the config is valid.
+func (c ServerConfig) Validate() error {
+	if c.Store == nil {
+		return errors.NotValidf("nil Store")
+	}
+	return nil
+}
+
+// Server is a http.Handler serving the CharmHub info, find, refresh and
+// download endpoints from a Store.
+type Server struct {
+	config ServerConfig
+	mux    *pat.PatternServeMux
+}
+
+// NewServer returns a new mirror Server.
+func NewServer(config ServerConfig) (*Server, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &Server{
+		config: config,
+		mux:    pat.New(),
+	}
+	s.mux.Get(apiPrefix+"/info/:name", http.HandlerFunc(s.serveInfo))
+	s.mux.Get(apiPrefix+"/find", http.HandlerFunc(s.serveFind))
+	s.mux.Post(apiPrefix+"/refresh", http.HandlerFunc(s.serveRefresh))
+	s.mux.Get(downloadPrefix+"/charm/:name/:revision", http.HandlerFunc(s.serveCharm))
+	s.mux.Get(downloadPrefix+"/resource/:name/:resource/:revision", http.HandlerFunc(s.serveResource))
+	return s, nil
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mux.ServeHTTP(w, req)
+}
+
+func (s *Server) serveInfo(w http.ResponseWriter, req *http.Request) {
+	name := req.URL.Query().Get(":name")
+	info, err := s.info(req, name)
+	if errors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, fmt.Sprintf("No charm or bundle with name %q.", name))
+		return
+	} else if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+func (s *Server) serveFind(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query().Get("q")
+	names, err := s.config.Store.Names()
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	resp := transport.FindResponses{
+		Results: []transport.FindResponse{},
+	}
+	for _, name := range names {
+		if !strings.Contains(name, query) {
+			continue
+		}
+		info, err := s.info(req, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			writeInternalError(w, err)
+			return
+		}
+		resp.Results = append(resp.Results, transport.FindResponse{
+			Type:           info.Type,
+			ID:             info.ID,
+			Name:           info.Name,
+			Entity:         info.Entity,
+			DefaultRelease: info.DefaultRelease,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) serveRefresh(w http.ResponseWriter, req *http.Request) {
+	var request transport.RefreshRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidRequest, err.Error())
+		return
+	}
+
+	contexts := make(map[string]transport.RefreshRequestContext, len(request.Context))
+	for _, ctx := range request.Context {
+		contexts[ctx.InstanceKey] = ctx
+	}
+
+	resp := transport.RefreshResponses{
+		Results: []transport.RefreshResponse{},
+	}
+	for _, action := range request.Actions {
+		result, err := s.refreshAction(req, action, contexts)
+		if err != nil {
+			writeInternalError(w, err)
+			return
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) serveCharm(w http.ResponseWriter, req *http.Request) {
+	name := req.URL.Query().Get(":name")
+	revision, err := strconv.Atoi(req.URL.Query().Get(":revision"))
+	if err != nil || !s.config.Store.HasCharm(name, revision) {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeFile(w, req, s.config.Store.CharmPath(name, revision))
+}
+
+func (s *Server) serveResource(w http.ResponseWriter, req *http.Request) {
+	name := req.URL.Query().Get(":name")
+	resource := req.URL.Query().Get(":resource")
+	revision, err := strconv.Atoi(req.URL.Query().Get(":revision"))
+	if err != nil || !s.config.Store.HasResource(name, resource, revision) {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeFile(w, req, s.config.Store.ResourcePath(name, resource, revision))
+}
+
+// info returns the mirrored info for the named charm, limited to the
+// revisions that are available in the store along with their resources, and
+// with download URLs pointing at the mirror.
+func (s *Server) info(req *http.Request, name string) (transport.InfoResponse, error) {
+	info, err := s.config.Store.Info(name)
+	if errors.IsNotValid(err) {
+		return transport.InfoResponse{}, errors.NotFoundf("charm %q", name)
+	} else if err != nil {
+		return transport.InfoResponse{}, errors.Trace(err)
+	}
+
+	baseURL := s.baseURL(req)
+	var channelMaps []transport.ChannelMap
+	for _, cm := range info.ChannelMap {
+		if cm, ok := s.mirroredChannelMap(baseURL, info.Name, cm); ok {
+			channelMaps = append(channelMaps, cm)
+		}
+	}
+	info.ChannelMap = channelMaps
+
+	if cm, ok := s.mirroredChannelMap(baseURL, info.Name, info.DefaultRelease); ok {
+		info.DefaultRelease = cm
+	} else if len(channelMaps) > 0 {
+		info.DefaultRelease = channelMaps[0]
+	} else {
+		info.DefaultRelease = transport.ChannelMap{}
+	}
+	return info, nil
+}
+
+// mirroredChannelMap returns the channel map entry with its charm and
+// resource download URLs pointing at the mirror. False is returned if the
+// charm revision or any of its resources is not available in the store.
+func (s *Server) mirroredChannelMap(baseURL, name string, cm transport.ChannelMap) (transport.ChannelMap, bool) {
+	if !s.config.Store.HasCharm(name, cm.Revision.Revision) {
+		return transport.ChannelMap{}, false
+	}
+	cm.Revision.Download.URL = charmURL(baseURL, name, cm.Revision.Revision)
+	resources := make([]transport.ResourceRevision, len(cm.Resources))
+	for i, res := range cm.Resources {
+		if !s.config.Store.HasResource(name, res.Name, res.Revision) {
+			return transport.ChannelMap{}, false
+		}
+		res.Download.URL = resourceURL(baseURL, name, res.Name, res.Revision)
+		resources[i] = res
+	}
+	if len(resources) > 0 {
+		cm.Resources = resources
+	}
+	return cm, true
+}
+
+// refreshAction resolves a single refresh request action against the store.
+// Failures to resolve the action are reported in the response, in the same
+// way as CharmHub does.
+func (s *Server) refreshAction(
+	req *http.Request,
+	action transport.RefreshRequestAction,
+	contexts map[string]transport.RefreshRequestContext,
+) (transport.RefreshResponse, error) {
+	result := transport.RefreshResponse{
+		InstanceKey: action.InstanceKey,
+		Result:      "error",
+	}
+	fail := func(code, format string, args ...interface{}) (transport.RefreshResponse, error) {
+		result.Error = &transport.APIError{
+			Code:    code,
+			Message: fmt.Sprintf(format, args...),
+		}
+		return result, nil
+	}
+
+	var (
+		id, name string
+		channel  string
+		revision *int
+		platform *transport.RefreshRequestPlatform
+	)
+	switch action.Action {
+	case "install", "download":
+		if action.ID != nil {
+			id = *action.ID
+		}
+		if action.Name != nil {
+			name = *action.Name
+		}
+		if action.Channel != nil {
+			channel = *action.Channel
+		}
+		revision = action.Revision
+		platform = action.Platform
+	case "refresh":
+		ctx, ok := contexts[action.InstanceKey]
+		if !ok {
+			return fail(ErrorCodeInvalidRequest, "no context for instance key %q", action.InstanceKey)
+		}
+		id = ctx.ID
+		channel = ctx.TrackingChannel
+		platform = &ctx.Platform
+	default:
+		return fail(ErrorCodeInvalidRequest, "unknown action %q", action.Action)
+	}
+
+	info, err := s.lookup(req, id, name)
+	if errors.IsNotFound(err) {
+		if name == "" {
+			name = id
+		}
+		return fail(ErrorCodeNotFound, "No charm or bundle with name %q.", name)
+	} else if err != nil {
+		return transport.RefreshResponse{}, errors.Trace(err)
+	}
+	result.ID = info.ID
+	result.Name = info.Name
+
+	cm, err := selectChannelMap(info, channel, revision, platform)
+	if errors.IsNotValid(err) {
+		return fail(ErrorCodeInvalidRequest, "%v", err)
+	} else if err != nil {
+		return fail(ErrorCodeRevisionNotFound, "No revision was found in the mirror for %q: %v.", info.Name, err)
+	}
+
+	result.Result = action.Action
+	result.EffectiveChannel = cm.Channel.Name
+	if releasedAt, err := time.Parse(time.RFC3339, cm.Channel.ReleasedAt); err == nil {
+		result.ReleasedAt = releasedAt
+	}
+	result.Entity = transport.RefreshEntity{
+		CreatedAt: cm.Revision.CreatedAt,
+		Download:  cm.Revision.Download,
+		ID:        info.ID,
+		License:   info.Entity.License,
+		Name:      info.Name,
+		Publisher: info.Entity.Publisher,
+		Resources: cm.Resources,
+		Summary:   info.Entity.Summary,
+		Version:   cm.Revision.Version,
+	}
+	return result, nil
+}
+
+// lookup returns the info for a charm, by ID if one is supplied, otherwise
+// by name.
+func (s *Server) lookup(req *http.Request, id, name string) (transport.InfoResponse, error) {
+	if id == "" {
+		return s.info(req, name)
+	}
+	names, err := s.config.Store.Names()
+	if err != nil {
+		return transport.InfoResponse{}, errors.Trace(err)
+	}
+	for _, name := range names {
+		info, err := s.info(req, name)
+		if err != nil {
+			return transport.InfoResponse{}, errors.Trace(err)
+		}
+		if info.ID == id {
+			return info, nil
+		}
+	}
+	return transport.InfoResponse{}, errors.NotFoundf("charm with id %q", id)
+}
+
+func (s *Server) baseURL(req *http.Request) string {
+	if s.config.PublicURL != "" {
+		return strings.TrimRight(s.config.PublicURL, "/")
+	}
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, req.Host)
+}
+
+// selectChannelMap picks the channel map entry that satisfies the requested
+// channel, revision and platform. With neither a channel nor a revision,
+// the default release is used.
+func selectChannelMap(
+	info transport.InfoResponse,
+	channel string,
+	revision *int,
+	platform *transport.RefreshRequestPlatform,
+) (transport.ChannelMap, error) {
+	var wanted string
+	if channel != "" {
+		ch, err := corecharm.ParseChannel(channel)
+		if err != nil {
+			return transport.ChannelMap{}, errors.NotValidf("channel %q", channel)
+		}
+		wanted = ch.String()
+	}
+	if wanted == "" && revision == nil {
+		if info.DefaultRelease.Revision.Download.URL == "" {
+			return transport.ChannelMap{}, errors.NotFoundf("default release")
+		}
+		return info.DefaultRelease, nil
+	}
+	for _, cm := range info.ChannelMap {
+		if wanted != "" && !matchChannel(wanted, cm.Channel.Name) {
+			continue
+		}
+		if revision != nil && cm.Revision.Revision != *revision {
+			continue
+		}
+		if !matchPlatform(platform, cm) {
+			continue
+		}
+		return cm, nil
+	}
+	switch {
+	case wanted != "" && revision != nil:
+		return transport.ChannelMap{}, errors.NotFoundf("revision %d in channel %q", *revision, channel)
+	case wanted != "":
+		return transport.ChannelMap{}, errors.NotFoundf("channel %q", channel)
+	default:
+		return transport.ChannelMap{}, errors.NotFoundf("revision %d", *revision)
+	}
+}
+
+func matchChannel(wanted, name string) bool {
+	ch, err := corecharm.ParseChannel(name)
+	if err != nil {
+		return false
+	}
+	return ch.String() == wanted
+}
+
+func matchPlatform(platform *transport.RefreshRequestPlatform, cm transport.ChannelMap) bool {
+	if platform == nil || platform.Series == "" || platform.Series == "all" {
+		return true
+	}
+	if cm.Channel.Platform.Series == platform.Series || cm.Channel.Platform.Series == "all" {
+		return true
+	}
+	for _, p := range cm.Revision.Platforms {
+		if p.Series == platform.Series || p.Series == "all" {
+			return true
+		}
+	}
+	return false
+}
+
+func charmURL(baseURL, name string, revision int) string {
+	return fmt.Sprintf("%s%s/charm/%s/%d", baseURL, downloadPrefix, name, revision)
+}
+
+func resourceURL(baseURL, name, resource string, revision int) string {
+	return fmt.Sprintf("%s%s/resource/%s/%s/%d", baseURL, downloadPrefix, name, resource, revision)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		logger.Errorf("cannot marshal response: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, struct {
+		ErrorList transport.APIErrors `json:"error-list"`
+	}{
+		ErrorList: transport.APIErrors{{
+			Code:    code,
+			Message: message,
+		}},
+	})
+}
+
+func writeInternalError(w http.ResponseWriter, err error) {
+	logger.Errorf("%v", errors.ErrorStack(err))
+	writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err.Error())
+}
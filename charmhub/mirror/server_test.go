@@ -0,0 +1,241 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/testcharms"
+)
+
+type ServerSuite struct {
+	testing.IsolationSuite
+
+	store  *mirror.Store
+	server *httptest.Server
+	client *charmhub.Client
+}
+
+var _ = gc.Suite(&ServerSuite{})
+
+func (s *ServerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.store = mirror.NewStore(c.MkDir())
+	archivePath := testcharms.Repo.CharmArchivePath(c.MkDir(), "dummy")
+	data, err := ioutil.ReadFile(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	// Only revision 1 is mirrored, revision 2 is known about in the info
+	// but its archive is missing.
+	err = s.store.SetInfo(dummyInfo(""))
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(s.store.CharmPath("dummy", 1), data, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.AddResource("dummy", "data", 1, strings.NewReader(resourceData), resourceHash)
+	c.Assert(err, jc.ErrorIsNil)
+
+	server, err := mirror.NewServer(mirror.ServerConfig{Store: s.store})
+	c.Assert(err, jc.ErrorIsNil)
+	s.server = httptest.NewServer(server)
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+
+	config, err := charmhub.CharmHubConfigFromURL(s.server.URL, loggo.GetLogger("test"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.client, err = charmhub.NewClient(config)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ServerSuite) TestNewServerValidates(c *gc.C) {
+	_, err := mirror.NewServer(mirror.ServerConfig{})
+	c.Assert(err, gc.ErrorMatches, "nil Store not valid")
+}
+
+func (s *ServerSuite) TestInfo(c *gc.C) {
+	info, err := s.client.Info(context.TODO(), "dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ID, gc.Equals, "abc")
+	c.Assert(info.Entity.Summary, gc.Equals, "A dummy charm")
+	c.Assert(info.ChannelMap, gc.HasLen, 1)
+	c.Assert(info.ChannelMap[0].Channel.Name, gc.Equals, "latest/stable")
+	c.Assert(info.ChannelMap[0].Revision.Download.URL, gc.Equals, s.server.URL+"/download/charm/dummy/1")
+	c.Assert(info.DefaultRelease.Revision.Download.URL, gc.Equals, s.server.URL+"/download/charm/dummy/1")
+	c.Assert(info.ChannelMap[0].Resources, gc.HasLen, 1)
+	c.Assert(info.ChannelMap[0].Resources[0].Download.URL, gc.Equals, s.server.URL+"/download/resource/dummy/data/1")
+	c.Assert(info.DefaultRelease.Resources[0].Download.URL, gc.Equals, s.server.URL+"/download/resource/dummy/data/1")
+}
+
+func (s *ServerSuite) TestInfoMissingResource(c *gc.C) {
+	// A revision is only served if all of its resources are mirrored.
+	err := os.Remove(s.store.ResourcePath("dummy", "data", 1))
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := s.client.Info(context.TODO(), "dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ChannelMap, gc.HasLen, 0)
+}
+
+func (s *ServerSuite) TestInfoNotFound(c *gc.C) {
+	_, err := s.client.Info(context.TODO(), "missing")
+	c.Assert(err, gc.ErrorMatches, `No charm or bundle with name "missing".`)
+}
+
+func (s *ServerSuite) TestInfoPublicURL(c *gc.C) {
+	server, err := mirror.NewServer(mirror.ServerConfig{
+		Store:     s.store,
+		PublicURL: "https://mirror.example.com/",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	config, err := charmhub.CharmHubConfigFromURL(srv.URL, loggo.GetLogger("test"))
+	c.Assert(err, jc.ErrorIsNil)
+	client, err := charmhub.NewClient(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := client.Info(context.TODO(), "dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.DefaultRelease.Revision.Download.URL, gc.Equals, "https://mirror.example.com/download/charm/dummy/1")
+}
+
+func (s *ServerSuite) TestFind(c *gc.C) {
+	results, err := s.client.Find(context.TODO(), "dum")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Name, gc.Equals, "dummy")
+	c.Assert(results[0].DefaultRelease.Revision.Revision, gc.Equals, 1)
+
+	results, err = s.client.Find(context.TODO(), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+}
+
+func (s *ServerSuite) TestRefreshInstallFromChannel(c *gc.C) {
+	config, err := charmhub.InstallOneFromChannel("dummy", "stable", "ubuntu", "focal")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.client.Refresh(context.TODO(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[0].Result, gc.Equals, "install")
+	c.Assert(results[0].EffectiveChannel, gc.Equals, "latest/stable")
+	c.Assert(results[0].Entity.ID, gc.Equals, "abc")
+	c.Assert(results[0].Entity.Download.URL, gc.Equals, s.server.URL+"/download/charm/dummy/1")
+	c.Assert(results[0].Entity.Resources, gc.HasLen, 1)
+	c.Assert(results[0].Entity.Resources[0].Name, gc.Equals, "data")
+	c.Assert(results[0].Entity.Resources[0].Download.URL, gc.Equals, s.server.URL+"/download/resource/dummy/data/1")
+}
+
+func (s *ServerSuite) TestRefreshInstallFromRevision(c *gc.C) {
+	config, err := charmhub.InstallOneFromRevision("dummy", 1, "ubuntu", "focal")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.client.Refresh(context.TODO(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[0].Entity.Download.URL, gc.Equals, s.server.URL+"/download/charm/dummy/1")
+}
+
+func (s *ServerSuite) TestRefreshInstallMissingRevision(c *gc.C) {
+	// Revision 2 is in the edge channel upstream, but was not mirrored.
+	config, err := charmhub.InstallOneFromChannel("dummy", "edge", "ubuntu", "focal")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.client.Refresh(context.TODO(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.NotNil)
+	c.Assert(results[0].Error.Code, gc.Equals, mirror.ErrorCodeRevisionNotFound)
+}
+
+func (s *ServerSuite) TestRefreshInstallWrongSeries(c *gc.C) {
+	config, err := charmhub.InstallOneFromChannel("dummy", "stable", "ubuntu", "bionic")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.client.Refresh(context.TODO(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.NotNil)
+	c.Assert(results[0].Error.Code, gc.Equals, mirror.ErrorCodeRevisionNotFound)
+}
+
+func (s *ServerSuite) TestRefreshUnknownCharm(c *gc.C) {
+	config, err := charmhub.InstallOneFromChannel("mysql", "stable", "ubuntu", "focal")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.client.Refresh(context.TODO(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.NotNil)
+	c.Assert(results[0].Error.Code, gc.Equals, mirror.ErrorCodeNotFound)
+}
+
+func (s *ServerSuite) TestRefreshByID(c *gc.C) {
+	config, err := charmhub.RefreshOne("abc", 1, "stable", "ubuntu", "focal")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.client.Refresh(context.TODO(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[0].Result, gc.Equals, "refresh")
+	c.Assert(results[0].Name, gc.Equals, "dummy")
+}
+
+func (s *ServerSuite) TestDownload(c *gc.C) {
+	config, err := charmhub.InstallOneFromChannel("dummy", "stable", "ubuntu", "focal")
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.client.Refresh(context.TODO(), config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	downloadURL, err := url.Parse(results[0].Entity.Download.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	archive, err := s.client.Download(context.TODO(), downloadURL, filepath.Join(c.MkDir(), "dummy.charm"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archive.Meta().Name, gc.Equals, "dummy")
+}
+
+func (s *ServerSuite) TestDownloadMissing(c *gc.C) {
+	resp, err := http.Get(s.server.URL + "/download/charm/dummy/2")
+	c.Assert(err, jc.ErrorIsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusNotFound)
+}
+
+func (s *ServerSuite) TestDownloadResource(c *gc.C) {
+	config, err := charmhub.InstallOneFromChannel("dummy", "stable", "ubuntu", "focal")
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.client.Refresh(context.TODO(), config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	downloadURL, err := url.Parse(results[0].Entity.Resources[0].Download.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	r, err := s.client.DownloadResource(context.TODO(), downloadURL)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, resourceData)
+}
+
+func (s *ServerSuite) TestDownloadResourceMissing(c *gc.C) {
+	resp, err := http.Get(s.server.URL + "/download/resource/dummy/data/2")
+	c.Assert(err, jc.ErrorIsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusNotFound)
+}
@@ -0,0 +1,195 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package mirror implements a local mirror of the CharmHub API, serving the
+// info, find, refresh and download endpoints from a directory of mirrored
+// charms and resources. It allows controllers without Internet access to
+// deploy charms by pointing their charm-hub-url at the mirror.
+package mirror
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/v2"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+var logger = loggo.GetLogger("juju.charmhub.mirror")
+
+const (
+	infoFile     = "info.json"
+	charmSuffix  = ".charm"
+	resourcesDir = "resources"
+)
+
+// Store represents a directory of mirrored charms and resources.
+//
+// The layout of the directory is as follows:
+//
+//	<dir>/<charm-name>/info.json
+//	<dir>/<charm-name>/<revision>.charm
+//	<dir>/<charm-name>/resources/<resource-name>/<revision>
+//
+// The info.json file holds the CharmHub info response for the charm, limited
+// to the channels that have been mirrored.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a Store backed by the given directory.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the directory backing the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Names returns the names of all the charms held in the store, in sorted
+// order.
+func (s *Store) Names() ([]string, error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(s.dir, entry.Name(), infoFile)); err != nil {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Info returns the mirrored info for the named charm. A NotFound error is
+// returned if the charm has not been mirrored.
+func (s *Store) Info(name string) (transport.InfoResponse, error) {
+	if err := checkName(name); err != nil {
+		return transport.InfoResponse{}, errors.Trace(err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, name, infoFile))
+	if os.IsNotExist(err) {
+		return transport.InfoResponse{}, errors.NotFoundf("charm %q", name)
+	} else if err != nil {
+		return transport.InfoResponse{}, errors.Trace(err)
+	}
+	var info transport.InfoResponse
+	if err := json.Unmarshal(data, &info); err != nil {
+		return transport.InfoResponse{}, errors.Annotatef(err, "reading info for charm %q", name)
+	}
+	return info, nil
+}
+
+// SetInfo records the info for the named charm.
+func (s *Store) SetInfo(info transport.InfoResponse) error {
+	if err := checkName(info.Name); err != nil {
+		return errors.Trace(err)
+	}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dir := filepath.Join(s.dir, info.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(filepath.Join(dir, infoFile), data, 0644))
+}
+
+// CharmPath returns the path of the archive for the given charm revision.
+func (s *Store) CharmPath(name string, revision int) string {
+	return filepath.Join(s.dir, name, strconv.Itoa(revision)+charmSuffix)
+}
+
+// HasCharm reports whether the archive for the given charm revision has
+// been mirrored.
+func (s *Store) HasCharm(name string, revision int) bool {
+	if checkName(name) != nil {
+		return false
+	}
+	info, err := os.Stat(s.CharmPath(name, revision))
+	return err == nil && info.Mode().IsRegular()
+}
+
+// ResourcePath returns the path of the given revision of the named resource
+// for the given charm.
+func (s *Store) ResourcePath(charmName, resourceName string, revision int) string {
+	return filepath.Join(s.dir, charmName, resourcesDir, resourceName, strconv.Itoa(revision))
+}
+
+// HasResource reports whether the given revision of the named resource has
+// been mirrored.
+func (s *Store) HasResource(charmName, resourceName string, revision int) bool {
+	if checkName(charmName) != nil || checkName(resourceName) != nil {
+		return false
+	}
+	info, err := os.Stat(s.ResourcePath(charmName, resourceName, revision))
+	return err == nil && info.Mode().IsRegular()
+}
+
+// AddResource streams the resource revision read from r into the store. If
+// hash is not empty, the resource is only added if its SHA256 matches.
+func (s *Store) AddResource(charmName, resourceName string, revision int, r io.Reader, hash string) error {
+	if err := checkName(charmName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkName(resourceName); err != nil {
+		return errors.Trace(err)
+	}
+	path := s.ResourcePath(charmName, resourceName, revision)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Write to a temporary file first, so that a failed copy never leaves
+	// a partial resource in the store.
+	f, err := ioutil.TempFile(filepath.Dir(path), strconv.Itoa(revision)+".partial")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); hash != "" && actual != hash {
+		return errors.Errorf("hash mismatch for resource %q: expected %q, got %q", resourceName, hash, actual)
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(f.Name(), path))
+}
+
+// checkName ensures that a charm or resource name can be safely used as a
+// single path element within the store.
+func checkName(name string) error {
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return errors.NotValidf("name %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/charm/v8"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/charmhub/transport"
+	corecharm "github.com/juju/juju/core/charm"
+)
+
+// Manifest describes the charms to be mirrored.
+type Manifest struct {
+	Charms []ManifestCharm `yaml:"charms"`
+}
+
+// ManifestCharm describes a single charm to be mirrored.
+type ManifestCharm struct {
+	// Name is the name of the charm in CharmHub.
+	Name string `yaml:"name"`
+
+	// Channels holds the channels to mirror. If empty, only the charm's
+	// default release is mirrored. The resources of each mirrored revision
+	// are mirrored with it.
+	Channels []string `yaml:"channels,omitempty"`
+}
+
+// ParseManifest reads a manifest from the YAML held in data.
+func ParseManifest(data []byte) (Manifest, error) {
+	var manifest Manifest
+	if err := yaml.UnmarshalStrict(data, &manifest); err != nil {
+		return Manifest{}, errors.Annotate(err, "cannot parse manifest")
+	}
+	if err := manifest.Validate(); err != nil {
+		return Manifest{}, errors.Trace(err)
+	}
+	return manifest, nil
+}
+
+// ReadManifest reads a manifest from the named file.
+func ReadManifest(path string) (Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Manifest{}, errors.Trace(err)
+	}
+	manifest, err := ParseManifest(data)
+	return manifest, errors.Trace(err)
+}
+
+// Validate ensures that the manifest is valid.
+func (m Manifest) Validate() error {
+	if len(m.Charms) == 0 {
+		return errors.NotValidf("manifest with no charms")
+	}
+	seen := make(map[string]bool)
+	for _, ch := range m.Charms {
+		if err := checkName(ch.Name); err != nil {
+			return errors.Annotate(err, "charm")
+		}
+		if seen[ch.Name] {
+			return errors.NotValidf("duplicate charm %q", ch.Name)
+		}
+		seen[ch.Name] = true
+		for _, channel := range ch.Channels {
+			if _, err := corecharm.ParseChannel(channel); err != nil {
+				return errors.Annotatef(err, "charm %q", ch.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Client describes the CharmHub API methods required to populate a mirror.
+// It is satisfied by a *charmhub.Client.
+type Client interface {
+	Info(ctx context.Context, name string) (transport.InfoResponse, error)
+	Download(ctx context.Context, resourceURL *url.URL, archivePath string) (*charm.CharmArchive, error)
+	DownloadResource(ctx context.Context, resourceURL *url.URL) (io.ReadCloser, error)
+}
+
+// Sync populates the store with the charms described by the manifest, and
+// their resources, fetching them using the client. Revisions that are
+// already held in the store are not downloaded again.
+func Sync(ctx context.Context, client Client, store *Store, manifest Manifest) error {
+	for _, ch := range manifest.Charms {
+		if err := syncCharm(ctx, client, store, ch); err != nil {
+			return errors.Annotatef(err, "syncing charm %q", ch.Name)
+		}
+	}
+	return nil
+}
+
+func syncCharm(ctx context.Context, client Client, store *Store, ch ManifestCharm) error {
+	info, err := client.Info(ctx, ch.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	channelMaps, err := selectChannelMaps(info, ch.Channels)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, cm := range channelMaps {
+		if err := syncRevision(ctx, client, store, info.Name, cm); err != nil {
+			return errors.Annotatef(err, "revision %d", cm.Revision.Revision)
+		}
+		for _, res := range cm.Resources {
+			if err := syncResource(ctx, client, store, info.Name, res); err != nil {
+				return errors.Annotatef(err, "resource %q revision %d", res.Name, res.Revision)
+			}
+		}
+	}
+
+	// Channels mirrored by earlier syncs are kept, so that the manifest can
+	// be changed without dropping what is already in the store.
+	stored, err := store.Info(info.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	info.ChannelMap = mergeChannelMaps(stored.ChannelMap, channelMaps)
+	if !containsRevision(info.ChannelMap, info.DefaultRelease.Revision.Revision) {
+		info.DefaultRelease = channelMaps[0]
+	}
+	return errors.Trace(store.SetInfo(info))
+}
+
+// mergeChannelMaps returns the stored channel map entries updated with the
+// synced ones. Entries are matched by channel and platform.
+func mergeChannelMaps(stored, synced []transport.ChannelMap) []transport.ChannelMap {
+	key := func(cm transport.ChannelMap) transport.Channel {
+		return transport.Channel{Name: cm.Channel.Name, Platform: cm.Channel.Platform}
+	}
+	result := append([]transport.ChannelMap(nil), stored...)
+	index := make(map[transport.Channel]int, len(result))
+	for i, cm := range result {
+		index[key(cm)] = i
+	}
+	for _, cm := range synced {
+		if i, ok := index[key(cm)]; ok {
+			result[i] = cm
+			continue
+		}
+		index[key(cm)] = len(result)
+		result = append(result, cm)
+	}
+	return result
+}
+
+// selectChannelMaps returns the channel map entries that match the requested
+// channels. If no channels are requested, the default release is returned.
+func selectChannelMaps(info transport.InfoResponse, channels []string) ([]transport.ChannelMap, error) {
+	if len(channels) == 0 {
+		if info.DefaultRelease.Revision.Download.URL == "" {
+			return nil, errors.NotFoundf("default release")
+		}
+		return []transport.ChannelMap{info.DefaultRelease}, nil
+	}
+
+	var result []transport.ChannelMap
+	for _, channel := range channels {
+		ch, err := corecharm.ParseChannel(channel)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var found bool
+		for _, cm := range info.ChannelMap {
+			if matchChannel(ch.String(), cm.Channel.Name) {
+				result = append(result, cm)
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.NotFoundf("channel %q", channel)
+		}
+	}
+	return result, nil
+}
+
+func syncRevision(ctx context.Context, client Client, store *Store, name string, cm transport.ChannelMap) error {
+	revision := cm.Revision.Revision
+	path := store.CharmPath(name, revision)
+	if store.HasCharm(name, revision) {
+		if err := verifyHash(path, cm.Revision.Download.HashSHA265); err == nil {
+			logger.Debugf("%q revision %d already mirrored", name, revision)
+			return nil
+		}
+	}
+
+	downloadURL, err := url.Parse(cm.Revision.Download.URL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Trace(err)
+	}
+
+	logger.Infof("downloading %q revision %d (%s)", name, revision, cm.Channel.Name)
+	// Download to a temporary file first, so that an interrupted sync
+	// never leaves a partial archive in the store.
+	tmpPath := path + ".partial"
+	defer func() { _ = os.Remove(tmpPath) }()
+	if _, err := client.Download(ctx, downloadURL, tmpPath); err != nil {
+		return errors.Trace(err)
+	}
+	if err := verifyHash(tmpPath, cm.Revision.Download.HashSHA265); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(tmpPath, path))
+}
+
+// verifyHash checks the SHA256 of the file at path against the expected
+// hash. An empty expected hash always matches.
+func verifyHash(path, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return errors.Trace(err)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return errors.Errorf("hash mismatch for %s: expected %q, got %q", filepath.Base(path), expected, actual)
+	}
+	return nil
+}
+
+func syncResource(ctx context.Context, client Client, store *Store, charmName string, res transport.ResourceRevision) error {
+	if store.HasResource(charmName, res.Name, res.Revision) {
+		path := store.ResourcePath(charmName, res.Name, res.Revision)
+		if err := verifyHash(path, res.Download.HashSHA265); err == nil {
+			logger.Debugf("%q resource %q revision %d already mirrored", charmName, res.Name, res.Revision)
+			return nil
+		}
+	}
+
+	downloadURL, err := url.Parse(res.Download.URL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("downloading %q resource %q revision %d", charmName, res.Name, res.Revision)
+	r, err := client.DownloadResource(ctx, downloadURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = r.Close() }()
+	return errors.Trace(store.AddResource(charmName, res.Name, res.Revision, r, res.Download.HashSHA265))
+}
+
+func containsRevision(channelMaps []transport.ChannelMap, revision int) bool {
+	for _, cm := range channelMaps {
+		if cm.Revision.Revision == revision {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/charm/v8"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/charmhub/transport"
+	"github.com/juju/juju/testcharms"
+)
+
+type SyncSuite struct {
+	testing.IsolationSuite
+
+	archivePath string
+	store       *mirror.Store
+	client      *fakeClient
+}
+
+var _ = gc.Suite(&SyncSuite{})
+
+func (s *SyncSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.archivePath = testcharms.Repo.CharmArchivePath(c.MkDir(), "dummy")
+	s.store = mirror.NewStore(c.MkDir())
+	s.client = &fakeClient{
+		info:        dummyInfo(fileHash(c, s.archivePath)),
+		archivePath: s.archivePath,
+	}
+}
+
+func (s *SyncSuite) TestParseManifest(c *gc.C) {
+	manifest, err := mirror.ParseManifest([]byte(`
+charms:
+  - name: dummy
+    channels: [stable, latest/edge]
+  - name: mysql
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest, jc.DeepEquals, mirror.Manifest{
+		Charms: []mirror.ManifestCharm{{
+			Name:     "dummy",
+			Channels: []string{"stable", "latest/edge"},
+		}, {
+			Name: "mysql",
+		}},
+	})
+}
+
+func (s *SyncSuite) TestParseManifestInvalid(c *gc.C) {
+	for i, test := range []struct {
+		manifest string
+		err      string
+	}{{
+		manifest: `charms: []`,
+		err:      "manifest with no charms not valid",
+	}, {
+		manifest: "charms:\n  - name: dummy\n  - name: dummy",
+		err:      `duplicate charm "dummy" not valid`,
+	}, {
+		manifest: "charms:\n  - name: ../dummy",
+		err:      `charm: name "../dummy" not valid`,
+	}, {
+		manifest: "charms:\n  - name: dummy\n    channels: [latest/foo]",
+		err:      `charm "dummy": risk in channel "latest/foo" not valid`,
+	}, {
+		manifest: "charms:\n  - name: dummy\n    revision: 1",
+		err:      `(?s)cannot parse manifest: .*field revision not found.*`,
+	}, {
+		manifest: "charms:\n  - name: dummy\n    resources:\n      data: ./data.tar",
+		err:      `(?s)cannot parse manifest: .*field resources not found.*`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := mirror.ParseManifest([]byte(test.manifest))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SyncSuite) TestReadManifest(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "manifest.yaml")
+	err := ioutil.WriteFile(path, []byte("charms:\n  - name: dummy\n    channels: [edge]\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	manifest, err := mirror.ReadManifest(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest.Charms, jc.DeepEquals, []mirror.ManifestCharm{{Name: "dummy", Channels: []string{"edge"}}})
+}
+
+func (s *SyncSuite) TestSyncDefaultRelease(c *gc.C) {
+	err := mirror.Sync(context.TODO(), s.client, s.store, mirror.Manifest{
+		Charms: []mirror.ManifestCharm{{Name: "dummy"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.downloads, gc.Equals, 1)
+	c.Assert(s.store.HasCharm("dummy", 1), jc.IsTrue)
+	c.Assert(s.store.HasCharm("dummy", 2), jc.IsFalse)
+
+	info, err := s.store.Info("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ChannelMap, gc.HasLen, 1)
+	c.Assert(info.ChannelMap[0].Channel.Name, gc.Equals, "latest/stable")
+}
+
+func (s *SyncSuite) TestSyncChannels(c *gc.C) {
+	manifest := mirror.Manifest{
+		Charms: []mirror.ManifestCharm{{
+			Name:     "dummy",
+			Channels: []string{"stable", "edge"},
+		}},
+	}
+	err := mirror.Sync(context.TODO(), s.client, s.store, manifest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.downloads, gc.Equals, 2)
+	c.Assert(s.client.resourceDownloads, gc.Equals, 2)
+	c.Assert(s.store.HasCharm("dummy", 1), jc.IsTrue)
+	c.Assert(s.store.HasCharm("dummy", 2), jc.IsTrue)
+
+	for _, revision := range []int{1, 2} {
+		data, err := ioutil.ReadFile(s.store.ResourcePath("dummy", "data", revision))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(string(data), gc.Equals, resourceData)
+	}
+
+	// Syncing again does not download revisions already mirrored.
+	err = mirror.Sync(context.TODO(), s.client, s.store, manifest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.downloads, gc.Equals, 2)
+	c.Assert(s.client.resourceDownloads, gc.Equals, 2)
+}
+
+func (s *SyncSuite) TestSyncKeepsMirroredChannels(c *gc.C) {
+	err := mirror.Sync(context.TODO(), s.client, s.store, mirror.Manifest{
+		Charms: []mirror.ManifestCharm{{Name: "dummy", Channels: []string{"edge"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = mirror.Sync(context.TODO(), s.client, s.store, mirror.Manifest{
+		Charms: []mirror.ManifestCharm{{Name: "dummy", Channels: []string{"stable"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := s.store.Info("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ChannelMap, gc.HasLen, 2)
+	c.Assert(info.ChannelMap[0].Channel.Name, gc.Equals, "latest/edge")
+	c.Assert(info.ChannelMap[1].Channel.Name, gc.Equals, "latest/stable")
+	c.Assert(info.DefaultRelease.Revision.Revision, gc.Equals, 1)
+}
+
+func (s *SyncSuite) TestSyncResourceHashMismatch(c *gc.C) {
+	s.client.info.DefaultRelease.Resources[0].Download.HashSHA265 = "deadbeef"
+	err := mirror.Sync(context.TODO(), s.client, s.store, mirror.Manifest{
+		Charms: []mirror.ManifestCharm{{Name: "dummy"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `syncing charm "dummy": resource "data" revision 1: hash mismatch for resource "data": .*`)
+	c.Assert(s.store.HasResource("dummy", "data", 1), jc.IsFalse)
+}
+
+func (s *SyncSuite) TestSyncMissingChannel(c *gc.C) {
+	err := mirror.Sync(context.TODO(), s.client, s.store, mirror.Manifest{
+		Charms: []mirror.ManifestCharm{{Name: "dummy", Channels: []string{"beta"}}},
+	})
+	c.Assert(err, gc.ErrorMatches, `syncing charm "dummy": channel "beta" not found`)
+}
+
+func (s *SyncSuite) TestSyncHashMismatch(c *gc.C) {
+	s.client.info = dummyInfo("deadbeef")
+	err := mirror.Sync(context.TODO(), s.client, s.store, mirror.Manifest{
+		Charms: []mirror.ManifestCharm{{Name: "dummy"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `syncing charm "dummy": revision 1: hash mismatch for 1.charm.partial: .*`)
+	c.Assert(s.store.HasCharm("dummy", 1), jc.IsFalse)
+}
+
+type fakeClient struct {
+	info              transport.InfoResponse
+	archivePath       string
+	downloads         int
+	resourceDownloads int
+}
+
+func (f *fakeClient) Info(_ context.Context, name string) (transport.InfoResponse, error) {
+	if name != f.info.Name {
+		return transport.InfoResponse{}, errors.NotFoundf("charm %q", name)
+	}
+	return f.info, nil
+}
+
+func (f *fakeClient) Download(_ context.Context, _ *url.URL, archivePath string) (*charm.CharmArchive, error) {
+	f.downloads++
+	data, err := ioutil.ReadFile(f.archivePath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(archivePath, data, 0644); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charm.ReadCharmArchive(archivePath)
+}
+
+func (f *fakeClient) DownloadResource(_ context.Context, _ *url.URL) (io.ReadCloser, error) {
+	f.resourceDownloads++
+	return ioutil.NopCloser(strings.NewReader(resourceData)), nil
+}
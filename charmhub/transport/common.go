@@ -7,8 +7,9 @@ package transport
 // a given store.
 
 type ChannelMap struct {
-	Channel  Channel  `json:"channel,omitempty"`
-	Revision Revision `json:"revision,omitempty"`
+	Channel   Channel            `json:"channel,omitempty"`
+	Resources []ResourceRevision `json:"resources,omitempty"`
+	Revision  Revision           `json:"revision,omitempty"`
 }
 
 type Channel struct {
@@ -35,6 +36,15 @@ type Revision struct {
 	Version      string     `json:"version"`
 }
 
+type ResourceRevision struct {
+	Download    Download `json:"download"`
+	Description string   `json:"description"`
+	Filename    string   `json:"filename"`
+	Name        string   `json:"name"`
+	Revision    int      `json:"revision"`
+	Type        string   `json:"type"`
+}
+
 type Download struct {
 	HashSHA265 string `json:"hash-sha-265"`
 	Size       int    `json:"size"`
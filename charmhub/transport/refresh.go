@@ -59,12 +59,13 @@ type RefreshResponse struct {
 }
 
 type RefreshEntity struct {
-	CreatedAt string            `json:"created-at"`
-	Download  Download          `json:"download"`
-	ID        string            `json:"id"`
-	License   string            `json:"license"`
-	Name      string            `json:"name"`
-	Publisher map[string]string `json:"publisher,omitempty"`
-	Summary   string            `json:"summary"`
-	Version   string            `json:"version"`
+	CreatedAt string             `json:"created-at"`
+	Download  Download           `json:"download"`
+	ID        string             `json:"id"`
+	License   string             `json:"license"`
+	Name      string             `json:"name"`
+	Publisher map[string]string  `json:"publisher,omitempty"`
+	Resources []ResourceRevision `json:"resources,omitempty"`
+	Summary   string             `json:"summary"`
+	Version   string             `json:"version"`
 }
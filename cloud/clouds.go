@@ -72,6 +72,18 @@ const (
 	// https://tools.ietf.org/html/draft-cavage-http-signatures-06
 	HTTPSigAuthType AuthType = "httpsig"
 
+	// ExecAuthType is an authentication type which runs a command to
+	// obtain short lived credentials, such as a kubeconfig exec plugin.
+	ExecAuthType AuthType = "exec"
+
+	// OIDCAuthType is an authentication type using an OpenID Connect ID
+	// token, which is refreshed with a refresh token when it expires.
+	OIDCAuthType AuthType = "oidc"
+
+	// SSHKeyAuthType is an authentication type using an SSH private key,
+	// and the known host key of the server connected to.
+	SSHKeyAuthType AuthType = "ssh-key"
+
 	// InteractiveAuthType is a credential auth-type provided as an option to
 	// "juju add-credential", which takes the user through the process of
 	// adding credentials.  e.g. for lxd: generating a certificate credential.
@@ -260,11 +272,16 @@ type region struct {
 // CloudTypeCAAS is the kubernetes cloud type.
 const CloudTypeCAAS = "kubernetes"
 
+// CloudTypeCAASFake is the type of the in-memory kubernetes cluster used
+// for testing.
+const CloudTypeCAASFake = "k8s-fake"
+
 // DefaultCloudRegion is the name of the default region that Juju creates for clouds that do not define a region.
 const DefaultCloudRegion = "default"
 
 var caasCloudTypes = map[string]bool{
-	CloudTypeCAAS: true,
+	CloudTypeCAAS:     true,
+	CloudTypeCAASFake: true,
 }
 
 // CloudIsCAAS checks if cloud is a CAAS cloud.
@@ -411,6 +428,7 @@ var defaultCloudDescription = map[string]string{
 	"openstack":   "Openstack Cloud",
 	"oracle":      "Oracle Compute Cloud Service",
 	"kubernetes":  "A Kubernetes Cluster",
+	"k8s-fake":    "An in-memory Kubernetes Cluster for testing",
 }
 
 // WritePublicCloudMetadata marshals to YAML and writes the cloud metadata
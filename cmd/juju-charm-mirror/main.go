@@ -0,0 +1,78 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/charmhub/mirror"
+)
+
+const usage = `
+Serve a local mirror of CharmHub
+
+usage: juju-charm-mirror [ -listen <address> ] [ -url <public-url> ] [ -tls-cert <file> -tls-key <file> ] <directory>
+
+The directory is populated using "juju sync-charms". Controllers use the mirror
+by setting charm-hub-url to its public URL when the model is created, e.g.
+
+  $ juju-charm-mirror -listen :8080 /srv/charms
+  $ juju bootstrap --model-default charm-hub-url=http://mirror.internal:8080 ...
+
+Options:
+`
+
+func main() {
+	listen := flag.String("listen", ":8080", "address to listen on")
+	publicURL := flag.String("url", "", "public URL of the mirror, used in download links (defaults to the request host)")
+	tlsCert := flag.String("tls-cert", "", "TLS certificate file")
+	tlsKey := flag.String("tls-key", "", "TLS private key file")
+	debug := flag.Bool("debug", false, "enable debug logging")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 1 || (*tlsCert == "") != (*tlsKey == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *debug {
+		loggo.GetLogger("juju.charmhub.mirror").SetLogLevel(loggo.DEBUG)
+	} else {
+		loggo.GetLogger("juju.charmhub.mirror").SetLogLevel(loggo.INFO)
+	}
+
+	dir := args[0]
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "error: %q is not a directory\n", dir)
+		os.Exit(1)
+	}
+
+	server, err := mirror.NewServer(mirror.ServerConfig{
+		Store:     mirror.NewStore(dir),
+		PublicURL: *publicURL,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("serving charms from %s on %s\n", dir, *listen)
+	if *tlsCert != "" {
+		err = http.ListenAndServeTLS(*listen, *tlsCert, *tlsKey, server)
+	} else {
+		err = http.ListenAndServe(*listen, server)
+	}
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,230 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/charm/v8"
+	csparams "github.com/juju/charmrepo/v6/csclient/params"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	apicharms "github.com/juju/juju/api/charms"
+	commoncharm "github.com/juju/juju/api/common/charm"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/charmstore"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/application/utils"
+	"github.com/juju/juju/cmd/modelcmd"
+	corecharm "github.com/juju/juju/core/charm"
+	coreseries "github.com/juju/juju/core/series"
+)
+
+var adoptK8sHelpSummary = `
+Brings an existing Kubernetes workload under the management of Juju.`[1:]
+
+var adoptK8sHelpDetails = `
+The named deployment or stateful set, which must be in the namespace of the
+current model, is labelled and annotated as belonging to an application of
+the same name. The application is created using the specified charm, and a
+unit is added for each of the workload's running pods.
+
+The pod template isn't changed, so no pods are recreated and the workload
+keeps running throughout. Adopting a workload which has already been
+partially adopted completes the adoption.
+
+Only charms from a store can be used; local charms aren't supported.
+
+Examples:
+    juju adopt-k8s deployment/mariadb --charm mariadb-k8s
+    juju adopt-k8s statefulset/postgresql --charm postgresql-k8s --channel edge
+
+See also:
+    deploy
+    show-k8s-workload`
+
+// NewAdoptK8sCommand returns a command which adopts an existing Kubernetes
+// workload as a Juju application.
+func NewAdoptK8sCommand() cmd.Command {
+	cmd := &adoptK8sCommand{}
+	cmd.newAPIFunc = func() (AdoptK8sAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &adoptK8sAPIAdapter{
+			Client:       application.NewClient(root),
+			charmsClient: apicharms.NewClient(root),
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// AdoptK8sAPI defines the API methods that the adopt-k8s command uses.
+type AdoptK8sAPI interface {
+	Close() error
+	BestAPIVersion() int
+	ResolveCharms([]apicharms.CharmToResolve) ([]apicharms.ResolvedCharm, error)
+	AddCharm(*charm.URL, commoncharm.Origin, bool, string) (commoncharm.Origin, error)
+	AdoptK8sWorkload(application.AdoptK8sWorkloadArgs) ([]string, error)
+}
+
+// adoptK8sAPIAdapter combines the application and charms clients, which
+// share the same API connection.
+type adoptK8sAPIAdapter struct {
+	*application.Client
+	charmsClient *apicharms.Client
+}
+
+// ResolveCharms is part of the AdoptK8sAPI interface.
+func (a *adoptK8sAPIAdapter) ResolveCharms(charms []apicharms.CharmToResolve) ([]apicharms.ResolvedCharm, error) {
+	return a.charmsClient.ResolveCharms(charms)
+}
+
+// AddCharm is part of the AdoptK8sAPI interface.
+func (a *adoptK8sAPIAdapter) AddCharm(curl *charm.URL, origin commoncharm.Origin, force bool, series string) (commoncharm.Origin, error) {
+	return a.charmsClient.AddCharm(curl, origin, force, series)
+}
+
+type adoptK8sCommand struct {
+	modelcmd.ModelCommandBase
+
+	kind       caas.WorkloadKind
+	name       string
+	charmName  string
+	channelStr string
+	channel    corecharm.Channel
+
+	newAPIFunc func() (AdoptK8sAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *adoptK8sCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "adopt-k8s",
+		Args:    "<deployment|statefulset>/<name>",
+		Purpose: adoptK8sHelpSummary,
+		Doc:     adoptK8sHelpDetails,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *adoptK8sCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.charmName, "charm", "", "The charm to manage the workload with")
+	f.StringVar(&c.channelStr, "channel", "", "Channel to use when getting the charm from the store")
+}
+
+// Init implements Command.Init.
+func (c *adoptK8sCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.Errorf("no workload specified")
+	case 1:
+	default:
+		return errors.Errorf("unexpected arguments after workload")
+	}
+	parts := strings.SplitN(args[0], "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return errors.Errorf("workload %q must be of the form <deployment|statefulset>/<name>", args[0])
+	}
+	c.kind, c.name = caas.WorkloadKind(parts[0]), parts[1]
+	if err := c.kind.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if !names.IsValidApplication(c.name) {
+		return errors.Errorf("workload name %q is not a valid application name", c.name)
+	}
+	if c.charmName == "" {
+		return errors.Errorf("no charm specified; use --charm")
+	}
+	if c.channelStr != "" {
+		var err error
+		if c.channel, err = corecharm.ParseChannel(c.channelStr); err != nil {
+			return errors.Annotate(err, "error in --channel")
+		}
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *adoptK8sCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 17 {
+		return errors.New("adopting workloads is not supported by this version of Juju")
+	}
+
+	curl, err := charm.ParseURL(c.charmName)
+	if err != nil {
+		return errors.Annotatef(err, "charm %q", c.charmName)
+	}
+	if curl.Schema == "local" {
+		return errors.NotSupportedf("adopting with a local charm")
+	}
+	origin, err := utils.DeduceOrigin(curl, c.channel)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resolved, err := client.ResolveCharms([]apicharms.CharmToResolve{{URL: curl, Origin: origin}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(resolved) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(resolved))
+	}
+	if err := resolved[0].Error; err != nil {
+		return errors.Trace(err)
+	}
+	curl, origin = resolved[0].URL, resolved[0].Origin
+	if !supportsKubernetes(resolved[0].SupportedSeries) {
+		return errors.Errorf("charm %q does not support kubernetes", curl.Name)
+	}
+
+	series := coreseries.Kubernetes.String()
+	if origin, err = client.AddCharm(curl, origin, false, series); err != nil {
+		return errors.Annotatef(err, "adding charm %q", curl)
+	}
+	ctx.Infof("Located charm %q.", curl)
+
+	units, err := client.AdoptK8sWorkload(application.AdoptK8sWorkloadArgs{
+		Kind: string(c.kind),
+		Name: c.name,
+		CharmID: charmstore.CharmID{
+			URL:     curl,
+			Channel: csparams.Channel(origin.Risk),
+		},
+		CharmOrigin: origin,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(units) == 0 {
+		ctx.Infof("Adopted %s %q as application %q with no units.", c.kind, c.name, c.name)
+		return nil
+	}
+	ctx.Infof("Adopted %s %q as application %q with units: %s", c.kind, c.name, c.name, strings.Join(units, ", "))
+	return nil
+}
+
+func supportsKubernetes(supportedSeries []string) bool {
+	// Older charms don't declare any series, which doesn't rule
+	// kubernetes out.
+	if len(supportedSeries) == 0 {
+		return true
+	}
+	for _, s := range supportedSeries {
+		if s == coreseries.Kubernetes.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/charm/v8"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiapplication "github.com/juju/juju/api/application"
+	apicharms "github.com/juju/juju/api/charms"
+	commoncharm "github.com/juju/juju/api/common/charm"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type AdoptK8sSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockAdoptK8sAPI
+}
+
+var _ = gc.Suite(&AdoptK8sSuite{})
+
+func (s *AdoptK8sSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockAdoptK8sAPI{
+		Stub:    &testing.Stub{},
+		version: 17,
+		resolved: apicharms.ResolvedCharm{
+			URL:             charm.MustParseURL("ch:mariadb-k8s-3"),
+			Origin:          commoncharm.Origin{Source: commoncharm.OriginCharmHub, Risk: "edge"},
+			SupportedSeries: []string{"kubernetes"},
+		},
+		units: []string{"mariadb/0", "mariadb/1"},
+	}
+}
+
+func (s *AdoptK8sSuite) runAdoptK8s(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewAdoptK8sCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *AdoptK8sSuite) TestInvalidArguments(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no workload specified",
+	}, {
+		args: []string{"deployment/mariadb", "extra"},
+		err:  "unexpected arguments after workload",
+	}, {
+		args: []string{"mariadb", "--charm", "mariadb-k8s"},
+		err:  `workload "mariadb" must be of the form <deployment\|statefulset>/<name>`,
+	}, {
+		args: []string{"daemonset/mariadb", "--charm", "mariadb-k8s"},
+		err:  `workload kind "daemonset" not valid`,
+	}, {
+		args: []string{"deployment/maria_db", "--charm", "mariadb-k8s"},
+		err:  `workload name "maria_db" is not a valid application name`,
+	}, {
+		args: []string{"deployment/mariadb"},
+		err:  "no charm specified; use --charm",
+	}, {
+		args: []string{"deployment/mariadb", "--charm", "mariadb-k8s", "--channel", "latest/bogus"},
+		err:  `error in --channel: risk in channel "latest/bogus" not valid`,
+	}} {
+		c.Logf("args: %v", t.args)
+		_, err := s.runAdoptK8s(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *AdoptK8sSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 16
+	_, err := s.runAdoptK8s(c, "deployment/mariadb", "--charm", "mariadb-k8s")
+	c.Assert(err, gc.ErrorMatches, "adopting workloads is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *AdoptK8sSuite) TestAdopt(c *gc.C) {
+	ctx, err := s.runAdoptK8s(c, "statefulset/mariadb", "--charm", "ch:mariadb-k8s", "--channel", "edge")
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl := charm.MustParseURL("ch:mariadb-k8s")
+	resolvedURL := charm.MustParseURL("ch:mariadb-k8s-3")
+	origin := commoncharm.Origin{Source: commoncharm.OriginCharmHub, Risk: "edge"}
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"ResolveCharms", []interface{}{[]apicharms.CharmToResolve{{URL: curl, Origin: origin}}}},
+		{"AddCharm", []interface{}{resolvedURL, origin, false, "kubernetes"}},
+		{"AdoptK8sWorkload", []interface{}{apiapplication.AdoptK8sWorkloadArgs{
+			Kind:        "statefulset",
+			Name:        "mariadb",
+			CharmID:     charmstore.CharmID{URL: resolvedURL, Channel: "edge"},
+			CharmOrigin: origin,
+		}}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"Located charm \"mariadb-k8s-3\".\n"+
+		"Adopted statefulset \"mariadb\" as application \"mariadb\" with units: mariadb/0, mariadb/1\n")
+}
+
+func (s *AdoptK8sSuite) TestCharmWithoutKubernetes(c *gc.C) {
+	s.mockAPI.resolved.SupportedSeries = []string{"focal"}
+	_, err := s.runAdoptK8s(c, "deployment/mariadb", "--charm", "mariadb-k8s")
+	c.Assert(err, gc.ErrorMatches, `charm "mariadb-k8s" does not support kubernetes`)
+	s.mockAPI.CheckCallNames(c, "ResolveCharms", "Close")
+}
+
+func (s *AdoptK8sSuite) TestLocalCharm(c *gc.C) {
+	_, err := s.runAdoptK8s(c, "deployment/mariadb", "--charm", "local:mariadb-k8s")
+	c.Assert(err, gc.ErrorMatches, "adopting with a local charm not supported")
+}
+
+func (s *AdoptK8sSuite) TestFail(c *gc.C) {
+	s.mockAPI.SetErrors(nil, nil, errors.New("boom"))
+	_, err := s.runAdoptK8s(c, "deployment/mariadb", "--charm", "mariadb-k8s")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockAdoptK8sAPI struct {
+	*testing.Stub
+	version  int
+	resolved apicharms.ResolvedCharm
+	units    []string
+}
+
+func (s *mockAdoptK8sAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s *mockAdoptK8sAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockAdoptK8sAPI) ResolveCharms(charms []apicharms.CharmToResolve) ([]apicharms.ResolvedCharm, error) {
+	s.MethodCall(s, "ResolveCharms", charms)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return []apicharms.ResolvedCharm{s.resolved}, nil
+}
+
+func (s *mockAdoptK8sAPI) AddCharm(curl *charm.URL, origin commoncharm.Origin, force bool, series string) (commoncharm.Origin, error) {
+	s.MethodCall(s, "AddCharm", curl, origin, force, series)
+	return origin, s.NextErr()
+}
+
+func (s *mockAdoptK8sAPI) AdoptK8sWorkload(args apiapplication.AdoptK8sWorkloadArgs) ([]string, error) {
+	s.MethodCall(s, "AdoptK8sWorkload", args)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.units, nil
+}
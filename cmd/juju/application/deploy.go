@@ -4,6 +4,7 @@
 package application
 
 import (
+	"io/ioutil"
 	"strconv"
 	"strings"
 
@@ -22,6 +23,7 @@ import (
 	apicharms "github.com/juju/juju/api/charms"
 	commoncharm "github.com/juju/juju/api/common/charm"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/api/modelconfig"
 	"github.com/juju/juju/api/spaces"
 	apiparams "github.com/juju/juju/apiserver/params"
@@ -34,6 +36,7 @@ import (
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/series"
 	"github.com/juju/juju/resource/resourceadapters"
@@ -108,6 +111,10 @@ type deployAPIAdapter struct {
 	*plansClient
 	*offerClient
 	*spacesClient
+
+	// machineManagerClient is not embedded, as it has methods of
+	// the same names as those of the other clients.
+	machineManagerClient *machinemanager.Client
 }
 
 func (a *deployAPIAdapter) Client() *api.Client {
@@ -123,14 +130,29 @@ func (a *deployAPIAdapter) WatchAll() (api.AllWatch, error) {
 }
 
 func (a *deployAPIAdapter) Deploy(args application.DeployArgs) error {
-	for i, p := range args.Placement {
+	a.resolvePlacementScope(args.Placement)
+	return errors.Trace(a.applicationClient.Deploy(args))
+}
+
+func (a *deployAPIAdapter) ValidateDeploy(args application.DeployArgs) ([]apiparams.DeployCheck, error) {
+	a.resolvePlacementScope(args.Placement)
+	checks, err := a.applicationClient.ValidateDeploy(args)
+	return checks, errors.Trace(err)
+}
+
+// resolvePlacementScope replaces the "model-uuid" placement scope with
+// the UUID of the model being deployed to.
+func (a *deployAPIAdapter) resolvePlacementScope(placement []*instance.Placement) {
+	for i, p := range placement {
 		if p.Scope == "model-uuid" {
 			p.Scope = a.applicationClient.ModelUUID()
 		}
-		args.Placement[i] = p
+		placement[i] = p
 	}
+}
 
-	return errors.Trace(a.applicationClient.Deploy(args))
+func (a *deployAPIAdapter) InstanceTypes(cons []constraints.Value) ([]apiparams.InstanceTypesResult, error) {
+	return a.machineManagerClient.InstanceTypes(cons)
 }
 
 func (a *deployAPIAdapter) SetAnnotation(annotations map[string]map[string]string) ([]apiparams.ErrorResult, error) {
@@ -193,16 +215,17 @@ func newDeployCommand() *DeployCommand {
 			return nil, errors.Trace(err)
 		}
 		return &deployAPIAdapter{
-			Connection:        apiRoot,
-			apiClient:         &apiClient{Client: apiRoot.Client()},
-			charmsClient:      &charmsClient{Client: apicharms.NewClient(apiRoot)},
-			charmsAPIVersion:  apiRoot.BestFacadeVersion("Charms"),
-			applicationClient: &applicationClient{Client: application.NewClient(apiRoot)},
-			modelConfigClient: &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
-			annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
-			plansClient:       &plansClient{planURL: mURL},
-			offerClient:       &offerClient{Client: applicationoffers.NewClient(controllerAPIRoot)},
-			spacesClient:      &spacesClient{API: spaces.NewAPI(apiRoot)},
+			Connection:           apiRoot,
+			apiClient:            &apiClient{Client: apiRoot.Client()},
+			charmsClient:         &charmsClient{Client: apicharms.NewClient(apiRoot)},
+			charmsAPIVersion:     apiRoot.BestFacadeVersion("Charms"),
+			applicationClient:    &applicationClient{Client: application.NewClient(apiRoot)},
+			modelConfigClient:    &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
+			annotationsClient:    &annotationsClient{Client: annotations.NewClient(apiRoot)},
+			plansClient:          &plansClient{planURL: mURL},
+			offerClient:          &offerClient{Client: applicationoffers.NewClient(controllerAPIRoot)},
+			spacesClient:         &spacesClient{API: spaces.NewAPI(apiRoot)},
+			machineManagerClient: machinemanager.NewClient(apiRoot),
 		}, nil
 	}
 	deployCmd.NewConsumeDetailsAPI = func(url *charm.OfferURL) (deployer.ConsumeDetails, error) {
@@ -244,10 +267,20 @@ type DeployCommand struct {
 	// running an unsupported series.
 	Force bool
 
-	// DryRun is used to specify that the bundle shouldn't actually be
-	// deployed but just output the changes.
+	// DryRun is used to specify that the charm or bundle shouldn't
+	// actually be deployed. For a charm, the deployment is validated
+	// by the controller; for a bundle, the changes are output.
 	DryRun bool
 
+	// EstimateCost is used to specify that the charm or bundle
+	// shouldn't be deployed; instead, the monthly cost of the
+	// machines and storage it would add is estimated.
+	EstimateCost bool
+
+	// PriceSheetPath is the path of a YAML file of prices to use
+	// when estimating costs.
+	PriceSheetPath string
+
 	ApplicationName string
 	ConfigOptions   common.ConfigFlag
 	ConstraintsStr  string
@@ -487,6 +520,38 @@ for security purposes by allowing only certain configurations and devices. Use
 the '--force' option to bypass this check. Doing so is not recommended as it
 can lead to unexpected behaviour.
 
+Use the '--dry-run' option to check whether a charm can be deployed without
+deploying it. Nothing is added to the model: a charm from the store is read
+by the controller without being added, and the files of a local charm are
+sent with the request rather than uploaded. The controller makes all of the
+checks it would make when deploying the charm, including those on placement,
+LXD profiles, storage, devices, series, constraints and endpoint bindings, and
+reports the outcome of each. For bundles, '--dry-run' shows the changes that
+deploying the bundle would make.
+
+Use the '--estimate-cost' option to estimate the monthly cost of the machines
+and storage that a charm or bundle would add to the model, without deploying
+it. As with '--dry-run', nothing is added to the model; a store charm that is
+not already in the model is estimated as a principal charm, as its metadata is
+not read from the store. The constraints of each new machine, merged with the
+model's constraints, are resolved to the cheapest instance type that satisfies
+them, as they are when the machine is provisioned, and priced with the cloud's
+price data, where the provider has any. Units placed on existing machines add
+no machines. Storage is priced by the size of its storage directives, or 1GiB
+where no size is given.
+
+For private clouds, or to override the cloud's prices, use '--price-sheet' to
+supply a YAML file of prices:
+
+    currency: USD
+    instance-types:     # hourly price of each instance type
+      m1.small: 0.02
+    cpu-core: 0.01      # hourly prices for machines without an
+    memory-gib: 0.005   # instance type price
+    storage-pools:      # monthly price of a GiB in each pool
+      ceph: 0.05
+      default: 0.1      # storage with no pool
+
 Further reading: https://jaas.ai/docs/deploying-applications
 
 Examples:
@@ -536,6 +601,14 @@ Deploy to a machine that is in the 'dmz' network space but not in either the
 
     juju deploy haproxy -n 2 --constraints spaces=dmz,^cms,^database
 
+Check that a charm could be deployed with the given storage and bindings:
+
+    juju deploy postgresql --storage pgdata=ebs,10G --bind db=dmz --dry-run
+
+Estimate the monthly cost of deploying a bundle, using a price sheet:
+
+    juju deploy ./mybundle.yaml --estimate-cost --price-sheet prices.yaml
+
 Deploy a k8s charm that requires a single Nvidia GPU:
 
     juju deploy mycharm --device miner=1,nvidia.com/gpu
@@ -580,7 +653,9 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.BundleOverlayFile), "overlay", "Bundles to overlay on the primary bundle, applied in order")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
-	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the deploy would do, without deploying")
+	f.BoolVar(&c.EstimateCost, "estimate-cost", false, "Estimate the monthly cost of the deployment, without deploying")
+	f.StringVar(&c.PriceSheetPath, "price-sheet", "", "Path to a YAML file of prices to use with --estimate-cost")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm/bundle to be deployed which bypasses checks such as supported series or LXD profile allow list")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(devicesFlag{&c.Devices, &c.BundleDevices}, "device", "Charm device constraints")
@@ -629,6 +704,13 @@ func (c *DeployCommand) Init(args []string) error {
 	c.UseExisting = useExisting
 	c.BundleMachines = mapping
 
+	if c.EstimateCost && c.DryRun {
+		return errors.New("--estimate-cost and --dry-run cannot be used together")
+	}
+	if c.PriceSheetPath != "" && !c.EstimateCost {
+		return errors.New("--price-sheet can only be used with --estimate-cost")
+	}
+
 	if err := c.UnitCommandBase.Init(args); err != nil {
 		return err
 	}
@@ -725,6 +807,16 @@ func (c *DeployCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	var priceSheet *deployer.PriceSheet
+	if c.PriceSheetPath != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(c.PriceSheetPath))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if priceSheet, err = deployer.ParsePriceSheet(data); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	cstoreAPI, err := c.NewCharmRepo()
 	if err != nil {
 		return errors.Trace(err)
@@ -746,6 +838,7 @@ func (c *DeployCommand) Run(ctx *cmd.Context) error {
 	charmAdapter := c.NewResolver(cstoreAPI, apiRoot.BestFacadeVersion("Charms"), apicharms.NewClient(apiRoot))
 
 	factory, cfg := c.getDeployerFactory()
+	cfg.PriceSheet = priceSheet
 	deploy, err := factory.GetDeployer(cfg, apiRoot, charmAdapter)
 	if err != nil {
 		return errors.Trace(err)
@@ -809,6 +902,7 @@ func (c *DeployCommand) getDeployerFactory() (deployer.DeployerFactory, deployer
 		Constraints:       c.Constraints,
 		Devices:           c.Devices,
 		DryRun:            c.DryRun,
+		EstimateCost:      c.EstimateCost,
 		FlagSet:           c.flagSet,
 		Force:             c.Force,
 		NumUnits:          c.NumUnits,
@@ -271,6 +271,12 @@ var initErrorTests = []struct {
 	}, {
 		args: []string{"bundle", "--map-machines", "foo"},
 		err:  `error in --map-machines: expected "existing" or "<bundle-id>=<machine-id>", got "foo"`,
+	}, {
+		args: []string{"charm", "--estimate-cost", "--dry-run"},
+		err:  `--estimate-cost and --dry-run cannot be used together`,
+	}, {
+		args: []string{"charm", "--price-sheet", "prices.yaml"},
+		err:  `--price-sheet can only be used with --estimate-cost`,
 	},
 }
 
@@ -1336,7 +1342,7 @@ func (s *DeploySuite) TestDeployFlags(c *gc.C) {
 	c.Assert(command.flagSet, jc.DeepEquals, flagSet)
 	// Add to the slice below if a new flag is introduced which is valid for
 	// both charms and bundles.
-	charmAndBundleFlags := []string{"channel", "storage", "device", "force", "trust"}
+	charmAndBundleFlags := []string{"channel", "storage", "device", "force", "trust", "dry-run", "estimate-cost", "price-sheet"}
 	var allFlags []string
 	flagSet.VisitAll(func(flag *gnuflag.Flag) {
 		allFlags = append(allFlags, flag.Name)
@@ -2556,6 +2562,15 @@ func (f *fakeDeployAPI) Deploy(args application.DeployArgs) error {
 	return jujutesting.TypeAssertError(results[0])
 }
 
+func (f *fakeDeployAPI) ValidateDeploy(args application.DeployArgs) ([]params.DeployCheck, error) {
+	results := f.MethodCall(f, "ValidateDeploy", args)
+	if len(results) != 2 {
+		return nil, errors.Errorf("expected 2 results, got %d: %v", len(results), results)
+	}
+	checks, _ := results[0].([]params.DeployCheck)
+	return checks, jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) ListSpaces() ([]params.Space, error) {
 	results := f.MethodCall(f, "ListSpaces")
 	return results[0].([]params.Space), jujutesting.TypeAssertError(results[1])
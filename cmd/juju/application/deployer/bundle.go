@@ -24,9 +24,11 @@ type deployBundle struct {
 	model ModelCommand
 	steps []DeployStep
 
-	dryRun bool
-	force  bool
-	trust  bool
+	dryRun       bool
+	estimateCost bool
+	priceSheet   *PriceSheet
+	force        bool
+	trust        bool
 
 	bundleDataSource  charm.BundleDataSource
 	bundleDir         string
@@ -93,6 +95,17 @@ func (d *deployBundle) deploy(
 	}
 	d.bundleDir = d.bundleDataSource.BasePath()
 
+	if d.estimateCost {
+		if err := checkCostEstimateModel(d.model); err != nil {
+			return errors.Trace(err)
+		}
+		items, err := bundleCostItems(bundleData, d.bundleStorage, d.bundleMachines)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(estimateCost(ctx, deployAPI, d.priceSheet, items))
+	}
+
 	// Short-circuit trust checks if the operator specifies '--force'
 	if !d.trust {
 		if tl := appsRequiringTrust(bundleData.Applications); len(tl) != 0 && !d.force {
@@ -19,12 +19,15 @@ import (
 	"gopkg.in/yaml.v2"
 
 	applicationapi "github.com/juju/juju/api/application"
+	apicharms "github.com/juju/juju/api/charms"
 	commoncharm "github.com/juju/juju/api/common/charm"
 	app "github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/juju/application/store"
 	"github.com/juju/juju/cmd/juju/application/utils"
 	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/output"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
@@ -42,6 +45,9 @@ type deployCharm struct {
 	csMac           *macaroon.Macaroon
 	devices         map[string]devices.Constraints
 	deployResources resourceadapters.DeployResourcesFunc
+	dryRun          bool
+	dryRunCharm     charm.Charm
+	estimateCost    bool
 	force           bool
 	id              charmstore.CharmID
 	flagSet         *gnuflag.FlagSet
@@ -50,6 +56,7 @@ type deployCharm struct {
 	origin          commoncharm.Origin
 	placement       []*instance.Placement
 	placementSpec   string
+	priceSheet      *PriceSheet
 	resources       map[string]string
 	series          string
 	steps           []DeployStep
@@ -67,9 +74,22 @@ func (d *deployCharm) deploy(
 	deployAPI DeployerAPI,
 ) (rErr error) {
 	id := d.id
-	charmInfo, err := deployAPI.CharmInfo(id.URL.String())
-	if err != nil {
-		return err
+	var charmInfo *apicharms.CharmInfo
+	if d.dryRunCharm != nil {
+		charmInfo = &apicharms.CharmInfo{Meta: d.dryRunCharm.Meta()}
+	} else {
+		var err error
+		charmInfo, err = deployAPI.CharmInfo(id.URL.String())
+		if (d.dryRun || d.estimateCost) && params.IsCodeNotFound(err) {
+			// A dry run or cost estimate does not add a store charm to
+			// the model, so its metadata is not available here. The
+			// controller reads the charm from the store when validating
+			// the deployment, and a cost estimate needs only its name.
+			charmInfo, err = &apicharms.CharmInfo{Meta: &charm.Meta{Name: id.URL.Name}}, nil
+		}
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(d.attachStorage) > 0 && deployAPI.BestFacadeVersion("Application") < 5 {
@@ -102,6 +122,13 @@ func (d *deployCharm) deploy(
 	if applicationName == "" {
 		applicationName = charmInfo.Meta.Name
 	}
+	if d.estimateCost {
+		if err := checkCostEstimateModel(d.model); err != nil {
+			return errors.Trace(err)
+		}
+		item := charmCostItem(applicationName, numUnits, d.placement, d.constraints, d.storage)
+		return errors.Trace(estimateCost(ctx, deployAPI, d.priceSheet, []costItem{item}))
+	}
 
 	// Process the --config args.
 	// We may have a single file arg specified, in which case
@@ -175,6 +202,32 @@ func (d *deployCharm) deploy(
 		}
 	}
 
+	if len(appConfig) == 0 {
+		appConfig = nil
+	}
+
+	args := applicationapi.DeployArgs{
+		CharmID:          id,
+		CharmOrigin:      d.origin,
+		Cons:             d.constraints,
+		ApplicationName:  applicationName,
+		Series:           d.series,
+		NumUnits:         numUnits,
+		ConfigYAML:       string(configYAML),
+		Config:           appConfig,
+		Placement:        d.placement,
+		Storage:          d.storage,
+		Devices:          d.devices,
+		AttachStorage:    d.attachStorage,
+		EndpointBindings: d.bindings,
+		LocalCharm:       d.dryRunCharm,
+	}
+	if d.dryRun {
+		// The deploy steps and resources are skipped, as they
+		// would make changes to the model.
+		return errors.Trace(validateDeploy(ctx, deployAPI, args))
+	}
+
 	bakeryClient, err := d.model.BakeryClient()
 	if err != nil {
 		return errors.Trace(err)
@@ -226,34 +279,47 @@ func (d *deployCharm) deploy(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	args.Resources = ids
+	return errors.Trace(deployAPI.Deploy(args))
+}
 
-	if len(appConfig) == 0 {
-		appConfig = nil
+// validateDeploy has the controller make the checks it would make when
+// deploying the application described by args, and writes a report of
+// the outcome of each check. An error is returned if any check failed.
+func validateDeploy(ctx *cmd.Context, deployAPI DeployerAPI, args applicationapi.DeployArgs) error {
+	checks, err := deployAPI.ValidateDeploy(args)
+	if errors.IsNotSupported(err) {
+		return errors.New("--dry-run for charms is not supported by this version of Juju")
+	}
+	if err != nil {
+		return errors.Trace(err)
 	}
 
-	args := applicationapi.DeployArgs{
-		CharmID:          id,
-		CharmOrigin:      d.origin,
-		Cons:             d.constraints,
-		ApplicationName:  applicationName,
-		Series:           d.series,
-		NumUnits:         numUnits,
-		ConfigYAML:       string(configYAML),
-		Config:           appConfig,
-		Placement:        d.placement,
-		Storage:          d.storage,
-		Devices:          d.devices,
-		AttachStorage:    d.attachStorage,
-		Resources:        ids,
-		EndpointBindings: d.bindings,
+	var failed int
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{tw}
+	w.Println("Check", "Result")
+	for _, check := range checks {
+		result := "ok"
+		if check.Error != nil {
+			failed++
+			result = "failed: " + check.Error.Message
+		}
+		w.Println(check.Name, result)
 	}
-	return errors.Trace(deployAPI.Deploy(args))
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d checks failed, %q would not be deployed", failed, len(checks), args.ApplicationName)
+	}
+	ctx.Infof("All checks passed, %q can be deployed", args.ApplicationName)
+	return nil
 }
 
 var (
-	// TODO(thumper): support dry-run for apps as well as bundles.
 	BundleOnlyFlags = []string{
-		"overlay", "dry-run", "map-machines",
+		"overlay", "map-machines",
 	}
 )
 
@@ -335,9 +401,18 @@ func (l *localCharm) PrepareAndDeploy(ctx *cmd.Context, deployAPI DeployerAPI, _
 		return errors.Trace(err)
 	}
 
-	curl, err := deployAPI.AddLocalCharm(l.curl, l.ch, l.force)
-	if err != nil {
-		return errors.Trace(err)
+	curl := l.curl
+	if l.dryRun || l.estimateCost {
+		// The charm is sent to the controller with the deployment to
+		// be validated, or read here to estimate its cost, rather than
+		// being added to the model.
+		l.dryRunCharm = l.ch
+	} else {
+		var err error
+		curl, err = deployAPI.AddLocalCharm(l.curl, l.ch, l.force)
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	l.id = charmstore.CharmID{
@@ -345,6 +420,7 @@ func (l *localCharm) PrepareAndDeploy(ctx *cmd.Context, deployAPI DeployerAPI, _
 		// Local charms don't need a channel.
 	}
 	l.series = l.curl.Series
+	var err error
 	l.origin, err = utils.DeduceOrigin(curl, corecharm.Channel{})
 	if err != nil {
 		return err
@@ -439,13 +515,19 @@ func (c *charmStoreCharm) PrepareAndDeploy(ctx *cmd.Context, deployAPI DeployerA
 		return errors.Trace(validationErr)
 	}
 
-	// Store the charm in the controller
-	curl, csMac, csOrigin, err := store.AddCharmWithAuthorizationFromURL(deployAPI, macaroonGetter, storeCharmOrBundleURL, c.origin, c.force, series)
-	if err != nil {
-		if termErr, ok := errors.Cause(err).(*common.TermsRequiredError); ok {
-			return errors.Trace(termErr.UserErr())
+	// Store the charm in the controller, unless this is a dry run or a
+	// cost estimate. For a dry run the controller reads the charm from
+	// the store when validating the deployment.
+	curl, csOrigin := storeCharmOrBundleURL, c.origin
+	var csMac *macaroon.Macaroon
+	if !c.dryRun && !c.estimateCost {
+		curl, csMac, csOrigin, err = store.AddCharmWithAuthorizationFromURL(deployAPI, macaroonGetter, storeCharmOrBundleURL, c.origin, c.force, series)
+		if err != nil {
+			if termErr, ok := errors.Cause(err).(*common.TermsRequiredError); ok {
+				return errors.Trace(termErr.UserErr())
+			}
+			return errors.Annotatef(err, "storing charm for URL %q", storeCharmOrBundleURL)
 		}
-		return errors.Annotatef(err, "storing charm for URL %q", storeCharmOrBundleURL)
 	}
 	formattedCharmURL := curl.String()
 	ctx.Infof("Located charm %q.", formattedCharmURL)
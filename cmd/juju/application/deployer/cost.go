@@ -0,0 +1,417 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/charm/v8"
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+	"gopkg.in/yaml.v2"
+
+	apiparams "github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/storage"
+)
+
+// hoursPerMonth is the average number of hours in a month, used to turn
+// hourly prices into monthly ones.
+const hoursPerMonth = 730
+
+// defaultStorageSize is the size, in MiB, of storage for which no size
+// is specified.
+const defaultStorageSize = 1024
+
+// CostEstimateAPI represents the methods of the API the deploy command
+// needs to estimate the cost of a deployment.
+type CostEstimateAPI interface {
+	GetModelConstraints() (constraints.Value, error)
+	InstanceTypes([]constraints.Value) ([]apiparams.InstanceTypesResult, error)
+}
+
+// PriceSheet holds user-supplied prices, used to estimate the cost of
+// deployments to clouds whose providers have no price data, or to
+// override the providers' prices.
+type PriceSheet struct {
+	// Currency is the currency of the prices.
+	Currency string `yaml:"currency"`
+
+	// InstanceTypes holds the hourly price of each instance type.
+	InstanceTypes map[string]float64 `yaml:"instance-types"`
+
+	// CPUCore and MemoryGiB hold the hourly prices of a CPU core and
+	// of a GiB of memory. They price machines whose instance types
+	// have no price.
+	CPUCore   float64 `yaml:"cpu-core"`
+	MemoryGiB float64 `yaml:"memory-gib"`
+
+	// StoragePools holds the monthly price of a GiB of storage in each
+	// storage pool. Storage with no pool is priced as the "default" pool.
+	StoragePools map[string]float64 `yaml:"storage-pools"`
+}
+
+// ParsePriceSheet parses a YAML price sheet.
+func ParsePriceSheet(data []byte) (*PriceSheet, error) {
+	var sheet PriceSheet
+	if err := yaml.UnmarshalStrict(data, &sheet); err != nil {
+		return nil, errors.Annotate(err, "parsing price sheet")
+	}
+	if sheet.CPUCore < 0 || sheet.MemoryGiB < 0 {
+		return nil, errors.NotValidf("negative price")
+	}
+	for _, prices := range []map[string]float64{sheet.InstanceTypes, sheet.StoragePools} {
+		for name, price := range prices {
+			if price < 0 {
+				return nil, errors.NotValidf("negative price for %q", name)
+			}
+		}
+	}
+	return &sheet, nil
+}
+
+// costItem describes the machines and storage that deploying an
+// application, or one of a bundle's machines, would add to a model.
+type costItem struct {
+	// name is the name of the application or machine.
+	name string
+
+	// machines is the number of new machines, which are given
+	// constraints merged with the model's constraints.
+	machines    int
+	constraints constraints.Value
+
+	// units is the number of units, each of which is given the
+	// storage described by storage.
+	units   int
+	storage map[string]storage.Constraints
+}
+
+// charmCostItem returns the costItem for deploying numUnits units of a
+// charm. Units placed on existing machines, or in containers on them,
+// need no new machines.
+func charmCostItem(
+	name string, numUnits int, placement []*instance.Placement,
+	cons constraints.Value, stor map[string]storage.Constraints,
+) costItem {
+	machines := numUnits
+	for i, p := range placement {
+		if i == numUnits {
+			break
+		}
+		_, err := instance.ParseContainerType(p.Scope)
+		if p.Scope == instance.MachineScope || (err == nil && p.Directive != "") {
+			machines--
+		}
+	}
+	return costItem{
+		name:        name,
+		machines:    machines,
+		constraints: cons,
+		units:       numUnits,
+		storage:     stor,
+	}
+}
+
+// bundleCostItems returns the costItems for deploying a bundle: one for
+// each application and one for each machine in the bundle that is not
+// mapped to an existing machine. Units placed on "new" machines need new
+// machines of their own.
+func bundleCostItems(
+	data *charm.BundleData,
+	bundleStorage map[string]map[string]storage.Constraints,
+	bundleMachines map[string]string,
+) ([]costItem, error) {
+	var items []costItem
+	appNames := make([]string, 0, len(data.Applications))
+	for name := range data.Applications {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+	for _, name := range appNames {
+		spec := data.Applications[name]
+		cons, err := constraints.Parse(spec.Constraints)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", name)
+		}
+		stor := make(map[string]storage.Constraints)
+		for storageName, s := range spec.Storage {
+			if stor[storageName], err = storage.ParseConstraints(s); err != nil {
+				return nil, errors.Annotatef(err, "application %q storage %q", name, storageName)
+			}
+		}
+		for storageName, s := range bundleStorage[name] {
+			stor[storageName] = s
+		}
+		machines := 0
+		for i := 0; i < spec.NumUnits; i++ {
+			to := "new"
+			if i < len(spec.To) {
+				to = spec.To[i]
+			} else if len(spec.To) > 0 {
+				to = spec.To[len(spec.To)-1]
+			}
+			placement, err := charm.ParsePlacement(to)
+			if err != nil {
+				return nil, errors.Annotatef(err, "application %q", name)
+			}
+			if placement.Machine == "new" {
+				machines++
+			}
+		}
+		items = append(items, costItem{
+			name:        name,
+			machines:    machines,
+			constraints: cons,
+			units:       spec.NumUnits,
+			storage:     stor,
+		})
+	}
+
+	machineIds := make([]string, 0, len(data.Machines))
+	for id := range data.Machines {
+		if _, ok := bundleMachines[id]; !ok {
+			machineIds = append(machineIds, id)
+		}
+	}
+	utils.SortStringsNaturally(machineIds)
+	for _, id := range machineIds {
+		var cons constraints.Value
+		if spec := data.Machines[id]; spec != nil {
+			var err error
+			if cons, err = constraints.Parse(spec.Constraints); err != nil {
+				return nil, errors.Annotatef(err, "machine %q", id)
+			}
+		}
+		items = append(items, costItem{
+			name:        "machine " + id,
+			machines:    1,
+			constraints: cons,
+		})
+	}
+	return items, nil
+}
+
+// checkCostEstimateModel returns an error if the cost of deployments to
+// the model cannot be estimated.
+func checkCostEstimateModel(m ModelCommand) error {
+	modelType, err := m.ModelType()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if modelType != model.IAAS {
+		return errors.NotSupportedf("--estimate-cost on %s models", modelType)
+	}
+	return nil
+}
+
+// costEstimator prices the machines and storage of costItems.
+type costEstimator struct {
+	sheet *PriceSheet
+
+	// currency is the currency of the estimate, taken from the
+	// price sheet or else from the first provider price used.
+	currency string
+
+	// unpriced holds descriptions of the things that could not be
+	// priced, and so are excluded from the estimate.
+	unpriced set.Strings
+}
+
+// estimateCost writes a table of the estimated monthly cost of each of
+// the given items, and of all of them.
+func estimateCost(ctx *cmd.Context, api CostEstimateAPI, sheet *PriceSheet, items []costItem) error {
+	if sheet == nil {
+		sheet = &PriceSheet{}
+	}
+	e := &costEstimator{sheet: sheet, currency: sheet.Currency, unpriced: set.NewStrings()}
+
+	modelCons, err := api.GetModelConstraints()
+	if err != nil {
+		return errors.Annotate(err, "getting model constraints")
+	}
+	// The items' constraints take precedence over the model's, as they
+	// do when the machines are provisioned.
+	validator := constraints.NewValidator()
+	validator.RegisterConflicts(
+		[]string{constraints.InstanceType},
+		[]string{constraints.Mem, constraints.Cores, constraints.CpuPower})
+	var cons []constraints.Value
+	for i, item := range items {
+		if item.machines == 0 {
+			continue
+		}
+		if items[i].constraints, err = validator.Merge(modelCons, item.constraints); err != nil {
+			return errors.Trace(err)
+		}
+		cons = append(cons, items[i].constraints)
+	}
+	var results []apiparams.InstanceTypesResult
+	if len(cons) > 0 {
+		if results, err = api.InstanceTypes(cons); err != nil {
+			return errors.Annotate(err, "getting instance types")
+		}
+	}
+
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{tw}
+	w.Println("Name", "Machines", "Instance type", "Storage", "Monthly cost")
+	var totalMachines int
+	var total float64
+	for _, item := range items {
+		if item.machines == 0 && (item.units == 0 || len(item.storage) == 0) {
+			continue
+		}
+		var cost float64
+		instanceType := "-"
+		if item.machines > 0 {
+			result := results[0]
+			results = results[1:]
+			name, hourly, ok := e.machinePrice(ctx, item, result)
+			if name != "" {
+				instanceType = name
+			}
+			if ok {
+				cost += hourly * hoursPerMonth * float64(item.machines)
+			}
+		}
+		storageSizes, storageCost := e.storageCost(item)
+		cost += storageCost
+
+		totalMachines += item.machines
+		total += cost
+		w.Println(item.name, item.machines, instanceType, storageSizes, fmt.Sprintf("%.2f", cost))
+	}
+	w.Println("Total", totalMachines, "", "", strings.TrimSpace(fmt.Sprintf("%.2f %s", total, e.currency)))
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, what := range e.unpriced.SortedValues() {
+		ctx.Warningf("no price for %s, it is excluded from the estimate", what)
+	}
+	return nil
+}
+
+// machinePrice returns the instance type of the item's machines, if
+// known, and the hourly price of each machine. The cheapest of the
+// instance types satisfying the machines' constraints is chosen, as it
+// is when provisioning them.
+func (e *costEstimator) machinePrice(
+	ctx *cmd.Context, item costItem, result apiparams.InstanceTypesResult,
+) (string, float64, bool) {
+	var itype *apiparams.InstanceType
+	switch {
+	case result.Error == nil && len(result.InstanceTypes) > 0:
+		itype = &result.InstanceTypes[0]
+	case result.Error == nil:
+	case apiparams.IsCodeNotImplemented(result.Error), apiparams.IsCodeNotSupported(result.Error):
+		// The cloud has no instance types, so the machines can
+		// only be priced by their constraints.
+	default:
+		ctx.Warningf("cannot get instance types for %s: %v", item.name, result.Error)
+	}
+
+	cores, mem := item.constraints.CpuCores, item.constraints.Mem
+	if itype != nil {
+		if price, ok := e.sheet.InstanceTypes[itype.Name]; ok {
+			return itype.Name, price, true
+		}
+		if price, ok := e.providerPrice(*itype, result); ok {
+			return itype.Name, price, true
+		}
+		itypeCores, itypeMem := uint64(itype.CPUCores), uint64(itype.Memory)
+		cores, mem = &itypeCores, &itypeMem
+	}
+
+	sheet := e.sheet
+	known := (sheet.CPUCore > 0 || sheet.MemoryGiB > 0) &&
+		(sheet.CPUCore == 0 || cores != nil) &&
+		(sheet.MemoryGiB == 0 || mem != nil)
+	if !known {
+		if itype != nil {
+			e.unpriced.Add(fmt.Sprintf("instance type %q", itype.Name))
+			return itype.Name, 0, false
+		}
+		e.unpriced.Add(fmt.Sprintf("the machines of %s", item.name))
+		return "", 0, false
+	}
+	var price float64
+	if cores != nil {
+		price += float64(*cores) * sheet.CPUCore
+	}
+	if mem != nil {
+		price += float64(*mem) / 1024 * sheet.MemoryGiB
+	}
+	if itype != nil {
+		return itype.Name, price, true
+	}
+	return "", price, true
+}
+
+// providerPrice returns the hourly price of the instance type from the
+// provider's price data, if it has any in the estimate's currency.
+func (e *costEstimator) providerPrice(itype apiparams.InstanceType, result apiparams.InstanceTypesResult) (float64, bool) {
+	if itype.Cost <= 0 || result.CostCurrency == "" || !strings.HasSuffix(result.CostUnit, "/hour") {
+		return 0, false
+	}
+	if e.currency == "" {
+		e.currency = result.CostCurrency
+	} else if e.currency != result.CostCurrency {
+		return 0, false
+	}
+	price := float64(itype.Cost)
+	if result.CostDivisor > 0 {
+		price /= float64(result.CostDivisor)
+	}
+	return price, true
+}
+
+// storageCost returns a description of the total size of the item's
+// storage in each pool, and its monthly price.
+func (e *costEstimator) storageCost(item costItem) (string, float64) {
+	sizes := make(map[string]uint64)
+	for _, s := range item.storage {
+		size, count := s.Size, s.Count
+		if size == 0 {
+			size = defaultStorageSize
+		}
+		if count == 0 {
+			count = 1
+		}
+		pool := s.Pool
+		if pool == "" {
+			pool = "default"
+		}
+		sizes[pool] += size * count * uint64(item.units)
+	}
+	if len(sizes) == 0 {
+		return "-", 0
+	}
+	pools := make([]string, 0, len(sizes))
+	for pool := range sizes {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+	var cost float64
+	descriptions := make([]string, len(pools))
+	for i, pool := range pools {
+		gib := float64(sizes[pool]) / 1024
+		descriptions[i] = pool + ":" + strconv.FormatFloat(gib, 'f', -1, 64) + "GiB"
+		price, ok := e.sheet.StoragePools[pool]
+		if !ok {
+			e.unpriced.Add(fmt.Sprintf("storage pool %q", pool))
+			continue
+		}
+		cost += gib * price
+	}
+	return strings.Join(descriptions, ","), cost
+}
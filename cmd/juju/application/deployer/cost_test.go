@@ -0,0 +1,377 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer
+
+import (
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/charm/v8"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/gnuflag"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apicharms "github.com/juju/juju/api/charms"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/cmd/juju/application/deployer/mocks"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testcharms"
+)
+
+type costSuite struct {
+	testing.IsolationSuite
+
+	deployerAPI  *mocks.MockDeployerAPI
+	modelCommand *mocks.MockModelCommand
+}
+
+var _ = gc.Suite(&costSuite{})
+
+func (s *costSuite) setupMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.deployerAPI = mocks.NewMockDeployerAPI(ctrl)
+	s.modelCommand = mocks.NewMockModelCommand(ctrl)
+	return ctrl
+}
+
+func (s *costSuite) TestParsePriceSheet(c *gc.C) {
+	sheet, err := ParsePriceSheet([]byte(`
+currency: EUR
+instance-types:
+  small: 0.02
+cpu-core: 0.01
+memory-gib: 0.005
+storage-pools:
+  default: 0.1
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sheet, jc.DeepEquals, &PriceSheet{
+		Currency:      "EUR",
+		InstanceTypes: map[string]float64{"small": 0.02},
+		CPUCore:       0.01,
+		MemoryGiB:     0.005,
+		StoragePools:  map[string]float64{"default": 0.1},
+	})
+}
+
+func (s *costSuite) TestParsePriceSheetInvalid(c *gc.C) {
+	_, err := ParsePriceSheet([]byte("cpu-cores: 0.01"))
+	c.Assert(err, gc.ErrorMatches, `(?s)parsing price sheet: .*field cpu-cores not found.*`)
+
+	_, err = ParsePriceSheet([]byte("memory-gib: -1"))
+	c.Assert(err, gc.ErrorMatches, "negative price not valid")
+
+	_, err = ParsePriceSheet([]byte("storage-pools: {ebs: -0.1}"))
+	c.Assert(err, gc.ErrorMatches, `negative price for "ebs" not valid`)
+}
+
+func (s *costSuite) TestCharmCostItem(c *gc.C) {
+	placement := []*instance.Placement{
+		{Scope: instance.MachineScope, Directive: "1"},
+		{Scope: string(instance.LXD), Directive: "2"},
+		{Scope: string(instance.LXD)},
+		{Scope: "zone", Directive: "us-east-1a"},
+	}
+	cons := constraints.MustParse("mem=4G")
+	item := charmCostItem("mysql", 5, placement, cons, nil)
+	c.Assert(item, jc.DeepEquals, costItem{
+		name:        "mysql",
+		machines:    3,
+		constraints: cons,
+		units:       5,
+	})
+}
+
+func (s *costSuite) TestBundleCostItems(c *gc.C) {
+	data, err := charm.ReadBundleData(strings.NewReader(`
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 2
+    constraints: mem=4G
+    storage:
+      data: ebs,10G
+  wordpress:
+    charm: cs:wordpress
+    num_units: 3
+    to: ["0", "new"]
+  ntp:
+    charm: cs:ntp
+machines:
+  "0":
+    constraints: cores=2
+  "1":
+  "10":
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	bundleStorage := map[string]map[string]storage.Constraints{
+		"mysql": {"logs": {Pool: "ebs", Size: 1024, Count: 1}},
+	}
+	items, err := bundleCostItems(data, bundleStorage, map[string]string{"1": "4"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(items, jc.DeepEquals, []costItem{{
+		name:        "mysql",
+		machines:    2,
+		constraints: constraints.MustParse("mem=4G"),
+		units:       2,
+		storage: map[string]storage.Constraints{
+			"data": {Pool: "ebs", Size: 10240, Count: 1},
+			"logs": {Pool: "ebs", Size: 1024, Count: 1},
+		},
+	}, {
+		name:    "ntp",
+		storage: map[string]storage.Constraints{},
+	}, {
+		name:     "wordpress",
+		machines: 2,
+		units:    3,
+		storage:  map[string]storage.Constraints{},
+	}, {
+		name:        "machine 0",
+		machines:    1,
+		constraints: constraints.MustParse("cores=2"),
+	}, {
+		name:     "machine 10",
+		machines: 1,
+	}})
+}
+
+func (s *costSuite) TestEstimateCostProviderPrices(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.deployerAPI.EXPECT().GetModelConstraints().Return(constraints.Value{}, nil)
+	s.deployerAPI.EXPECT().InstanceTypes([]constraints.Value{
+		constraints.MustParse("mem=4G"),
+		{},
+	}).Return([]params.InstanceTypesResult{{
+		InstanceTypes: []params.InstanceType{{Name: "m5.large", CPUCores: 2, Memory: 8192, Cost: 96}},
+		CostUnit:      "$USD/hour",
+		CostCurrency:  "USD",
+		CostDivisor:   1000,
+	}, {
+		InstanceTypes: []params.InstanceType{{Name: "t3.micro", CPUCores: 2, Memory: 1024, Cost: 10}},
+		CostUnit:      "$USD/hour",
+		CostCurrency:  "USD",
+		CostDivisor:   1000,
+	}}, nil)
+
+	items := []costItem{{
+		name:        "mysql",
+		machines:    2,
+		constraints: constraints.MustParse("mem=4G"),
+		units:       2,
+		storage: map[string]storage.Constraints{
+			"data": {Pool: "ebs", Size: 10240, Count: 1},
+		},
+	}, {
+		name:     "wordpress",
+		machines: 1,
+		units:    1,
+	}, {
+		name: "ntp",
+	}}
+	sheet := &PriceSheet{StoragePools: map[string]float64{"ebs": 0.1}}
+
+	ctx := cmdtesting.Context(c)
+	err := estimateCost(ctx, s.deployerAPI, sheet, items)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Name       Machines  Instance type  Storage    Monthly cost
+mysql      2         m5.large       ebs:20GiB  142.16
+wordpress  1         t3.micro       -          7.30
+Total      3                                   149.46 USD
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+}
+
+func (s *costSuite) TestEstimateCostPriceSheet(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.deployerAPI.EXPECT().GetModelConstraints().Return(constraints.MustParse("cores=2 mem=1G"), nil)
+	s.deployerAPI.EXPECT().InstanceTypes(gomock.Any()).DoAndReturn(
+		func(cons []constraints.Value) ([]params.InstanceTypesResult, error) {
+			// The items' constraints override the model's.
+			c.Assert(cons, gc.HasLen, 2)
+			c.Check(cons[0].String(), gc.Equals, "cores=2 mem=4096M")
+			c.Check(cons[1].String(), gc.Equals, "cores=4 mem=1024M")
+			return []params.InstanceTypesResult{{
+				Error: &params.Error{Code: params.CodeNotImplemented, Message: "not implemented"},
+			}, {
+				InstanceTypes: []params.InstanceType{{Name: "large", CPUCores: 4, Memory: 16384, Cost: 500}},
+				CostUnit:      "$USD/hour",
+				CostCurrency:  "USD",
+			}}, nil
+		})
+
+	items := []costItem{{
+		name:        "mysql",
+		machines:    1,
+		constraints: constraints.MustParse("mem=4G"),
+		units:       1,
+	}, {
+		name:        "machine 0",
+		machines:    1,
+		constraints: constraints.MustParse("cores=4"),
+	}}
+	sheet := &PriceSheet{
+		Currency:      "EUR",
+		InstanceTypes: map[string]float64{"large": 0.2},
+		CPUCore:       0.01,
+		MemoryGiB:     0.005,
+	}
+
+	ctx := cmdtesting.Context(c)
+	err := estimateCost(ctx, s.deployerAPI, sheet, items)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Name       Machines  Instance type  Storage  Monthly cost
+mysql      1         -              -        29.20
+machine 0  1         large          -        146.00
+Total      2                                 175.20 EUR
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+}
+
+func (s *costSuite) TestEstimateCostUnpriced(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.deployerAPI.EXPECT().GetModelConstraints().Return(constraints.Value{}, nil)
+	s.deployerAPI.EXPECT().InstanceTypes(gomock.Any()).Return([]params.InstanceTypesResult{{
+		InstanceTypes: []params.InstanceType{{Name: "a1", CPUCores: 1, Memory: 2048}},
+	}, {
+		Error: &params.Error{Message: "boom"},
+	}}, nil)
+
+	items := []costItem{{
+		name:     "mysql",
+		machines: 1,
+		units:    1,
+		storage: map[string]storage.Constraints{
+			"data": {Count: 2},
+		},
+	}, {
+		name:     "wordpress",
+		machines: 1,
+		units:    1,
+	}}
+
+	ctx := cmdtesting.Context(c)
+	err := estimateCost(ctx, s.deployerAPI, nil, items)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Name       Machines  Instance type  Storage       Monthly cost
+mysql      1         a1             default:2GiB  0.00
+wordpress  1         -              -             0.00
+Total      2                                      0.00
+`[1:])
+	c.Assert(c.GetTestLog(), jc.Contains, "cannot get instance types for wordpress: boom")
+	for _, what := range []string{`instance type "a1"`, `storage pool "default"`, "the machines of wordpress"} {
+		c.Check(c.GetTestLog(), jc.Contains, "no price for "+what+", it is excluded from the estimate")
+	}
+}
+
+func (s *costSuite) TestDeployCharmEstimateCost(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.modelCommand.EXPECT().ModelType().Return(model.IAAS, nil)
+	s.deployerAPI.EXPECT().CharmInfo("cs:mysql-42").Return(&apicharms.CharmInfo{
+		Meta: &charm.Meta{Name: "mysql"},
+	}, nil)
+	s.deployerAPI.EXPECT().GetModelConstraints().Return(constraints.Value{}, nil)
+	s.deployerAPI.EXPECT().InstanceTypes([]constraints.Value{{}}).Return([]params.InstanceTypesResult{{
+		InstanceTypes: []params.InstanceType{{Name: "small", CPUCores: 1, Memory: 2048}},
+	}}, nil)
+
+	d := deployCharm{
+		id:           charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-42")},
+		estimateCost: true,
+		model:        s.modelCommand,
+		numUnits:     2,
+		priceSheet:   &PriceSheet{Currency: "GBP", InstanceTypes: map[string]float64{"small": 0.01}},
+	}
+	ctx := cmdtesting.Context(c)
+	err := d.deploy(ctx, s.deployerAPI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Name   Machines  Instance type  Storage  Monthly cost
+mysql  2         small          -        14.60
+Total  2                                 14.60 GBP
+`[1:])
+}
+
+func (s *costSuite) TestDeployCharmEstimateCostStoreCharmNotAdded(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.modelCommand.EXPECT().ModelType().Return(model.IAAS, nil)
+	s.deployerAPI.EXPECT().CharmInfo("cs:mysql-42").Return(nil, &params.Error{Code: params.CodeNotFound})
+	s.deployerAPI.EXPECT().GetModelConstraints().Return(constraints.Value{}, nil)
+	s.deployerAPI.EXPECT().InstanceTypes([]constraints.Value{{}}).Return([]params.InstanceTypesResult{{
+		InstanceTypes: []params.InstanceType{{Name: "small", CPUCores: 1, Memory: 2048}},
+	}}, nil)
+
+	d := deployCharm{
+		id:           charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-42")},
+		estimateCost: true,
+		model:        s.modelCommand,
+		numUnits:     1,
+		priceSheet:   &PriceSheet{Currency: "GBP", InstanceTypes: map[string]float64{"small": 0.01}},
+	}
+	ctx := cmdtesting.Context(c)
+	err := d.deploy(ctx, s.deployerAPI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Name   Machines  Instance type  Storage  Monthly cost
+mysql  1         small          -        7.30
+Total  1                                 7.30 GBP
+`[1:])
+}
+
+func (s *costSuite) TestLocalCharmEstimateCost(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.modelCommand.EXPECT().ModelType().Return(model.IAAS, nil)
+	s.deployerAPI.EXPECT().GetModelConstraints().Return(constraints.Value{}, nil)
+	s.deployerAPI.EXPECT().InstanceTypes([]constraints.Value{{}}).Return([]params.InstanceTypesResult{{
+		InstanceTypes: []params.InstanceType{{Name: "small", CPUCores: 1, Memory: 2048}},
+	}}, nil)
+
+	d := &localCharm{
+		deployCharm: deployCharm{
+			estimateCost: true,
+			model:        s.modelCommand,
+			numUnits:     1,
+			priceSheet:   &PriceSheet{Currency: "GBP", InstanceTypes: map[string]float64{"small": 0.01}},
+			flagSet:      gnuflag.NewFlagSet("deploy", gnuflag.ContinueOnError),
+		},
+		curl: charm.MustParseURL("local:quantal/dummy-1"),
+		ch:   testcharms.Repo.CharmDir("dummy"),
+	}
+	// The charm is not added to the model, so AddLocalCharm is not
+	// expected.
+	ctx := cmdtesting.Context(c)
+	err := d.PrepareAndDeploy(ctx, s.deployerAPI, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Name   Machines  Instance type  Storage  Monthly cost
+dummy  1         small          -        7.30
+Total  1                                 7.30 GBP
+`[1:])
+}
+
+func (s *costSuite) TestDeployCharmEstimateCostNotIAAS(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.modelCommand.EXPECT().ModelType().Return(model.CAAS, nil)
+	s.deployerAPI.EXPECT().CharmInfo("cs:mysql-42").Return(&apicharms.CharmInfo{
+		Meta: &charm.Meta{Name: "mysql"},
+	}, nil)
+
+	d := deployCharm{
+		id:           charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-42")},
+		estimateCost: true,
+		model:        s.modelCommand,
+		numUnits:     1,
+	}
+	err := d.deploy(cmdtesting.Context(c), s.deployerAPI)
+	c.Assert(err, gc.ErrorMatches, "--estimate-cost on caas models not supported")
+}
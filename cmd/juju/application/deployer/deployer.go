@@ -85,6 +85,8 @@ func (d *factory) setConfig(cfg DeployerConfig) {
 	d.series = cfg.Series
 	d.force = cfg.Force
 	d.dryRun = cfg.DryRun
+	d.estimateCost = cfg.EstimateCost
+	d.priceSheet = cfg.PriceSheet
 	d.applicationName = cfg.ApplicationName
 	d.configOptions = cfg.ConfigOptions
 	d.constraints = cfg.Constraints
@@ -130,12 +132,14 @@ type DeployerConfig struct {
 	Devices              map[string]devices.Constraints
 	DeployResources      resourceadapters.DeployResourcesFunc
 	DryRun               bool
+	EstimateCost         bool
 	FlagSet              *gnuflag.FlagSet
 	Force                bool
 	NewConsumeDetailsAPI func(url *charm.OfferURL) (ConsumeDetails, error)
 	NumUnits             int
 	PlacementSpec        string
 	Placement            []*instance.Placement
+	PriceSheet           *PriceSheet
 	Resources            map[string]string
 	Series               string
 	Storage              map[string]storage.Constraints
@@ -160,6 +164,8 @@ type factory struct {
 	series            string
 	force             bool
 	dryRun            bool
+	estimateCost      bool
+	priceSheet        *PriceSheet
 	applicationName   string
 	configOptions     common.ConfigFlag
 	constraints       constraints.Value
@@ -209,12 +215,15 @@ func (d *factory) newDeployCharm() deployCharm {
 		constraints:     d.constraints,
 		devices:         d.devices,
 		deployResources: d.deployResources,
+		dryRun:          d.dryRun,
+		estimateCost:    d.estimateCost,
 		flagSet:         d.flagSet,
 		force:           d.force,
 		model:           d.model,
 		numUnits:        d.numUnits,
 		placement:       d.placement,
 		placementSpec:   d.placementSpec,
+		priceSheet:      d.priceSheet,
 		resources:       d.resources,
 		steps:           d.steps,
 		storage:         d.storage,
@@ -260,6 +269,8 @@ func (d *factory) newDeployBundle(ds charm.BundleDataSource) deployBundle {
 		model:                d.model,
 		steps:                d.steps,
 		dryRun:               d.dryRun,
+		estimateCost:         d.estimateCost,
+		priceSheet:           d.priceSheet,
 		force:                d.force,
 		trust:                d.trust,
 		bundleDataSource:     ds,
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/charm/v8"
 	charmresource "github.com/juju/charm/v8/resource"
 	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	"github.com/juju/featureflag"
 	"github.com/juju/gnuflag"
@@ -22,8 +23,11 @@ import (
 	"gopkg.in/macaroon-bakery.v2/httpbakery"
 	"gopkg.in/macaroon.v2"
 
+	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/api/base"
+	apicharms "github.com/juju/juju/api/charms"
 	commoncharm "github.com/juju/juju/api/common/charm"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/juju/application/deployer/mocks"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -240,6 +244,116 @@ func setFeatureFlags(flags string) {
 	featureflag.SetFlagsFromEnvironment(osenv.JujuFeatureFlagEnvKey)
 }
 
+func (s *deployerSuite) newDryRunDeployCharm() deployCharm {
+	return deployCharm{
+		id:              charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-42")},
+		applicationName: "db",
+		dryRun:          true,
+		model:           s.modelCommand,
+		numUnits:        1,
+		steps:           []DeployStep{s.deployStep},
+	}
+}
+
+func (s *deployerSuite) expectDryRunCharmInfo() {
+	s.deployerAPI.EXPECT().CharmInfo("cs:mysql-42").Return(&apicharms.CharmInfo{
+		Meta: &charm.Meta{Name: "mysql"},
+	}, nil)
+	s.deployerAPI.EXPECT().BestFacadeVersion("Application").Return(15)
+}
+
+func (s *deployerSuite) TestDeployCharmDryRun(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.expectDryRunCharmInfo()
+	s.deployerAPI.EXPECT().ValidateDeploy(application.DeployArgs{
+		CharmID:         charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-42")},
+		ApplicationName: "db",
+		NumUnits:        1,
+	}).Return([]params.DeployCheck{{Name: "charm"}, {Name: "storage"}}, nil)
+
+	ctx := cmdtesting.Context(c)
+	d := s.newDryRunDeployCharm()
+	err := d.deploy(ctx, s.deployerAPI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Check    Result
+charm    ok
+storage  ok
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "All checks passed, \"db\" can be deployed\n")
+}
+
+func (s *deployerSuite) TestDeployCharmDryRunFailedChecks(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.expectDryRunCharmInfo()
+	s.deployerAPI.EXPECT().ValidateDeploy(gomock.Any()).Return([]params.DeployCheck{
+		{Name: "charm"},
+		{Name: "storage", Error: &params.Error{Message: `pool "fast" not found`}},
+		{Name: "placement", Error: &params.Error{Message: `machine "42" not found`}},
+	}, nil)
+
+	ctx := cmdtesting.Context(c)
+	d := s.newDryRunDeployCharm()
+	err := d.deploy(ctx, s.deployerAPI)
+	c.Assert(err, gc.ErrorMatches, `2 of 3 checks failed, "db" would not be deployed`)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Check      Result
+charm      ok
+storage    failed: pool "fast" not found
+placement  failed: machine "42" not found
+`[1:])
+}
+
+func (s *deployerSuite) TestDeployCharmDryRunNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.expectDryRunCharmInfo()
+	s.deployerAPI.EXPECT().ValidateDeploy(gomock.Any()).Return(nil, errors.NotSupportedf("validating a deployment"))
+
+	d := s.newDryRunDeployCharm()
+	err := d.deploy(cmdtesting.Context(c), s.deployerAPI)
+	c.Assert(err, gc.ErrorMatches, "--dry-run for charms is not supported by this version of Juju")
+}
+
+func (s *deployerSuite) TestDeployCharmDryRunStoreCharmNotAdded(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.deployerAPI.EXPECT().CharmInfo("cs:mysql-42").Return(nil, &params.Error{Code: params.CodeNotFound})
+	s.deployerAPI.EXPECT().BestFacadeVersion("Application").Return(15)
+	s.deployerAPI.EXPECT().ValidateDeploy(application.DeployArgs{
+		CharmID:         charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-42")},
+		ApplicationName: "mysql",
+		NumUnits:        1,
+	}).Return([]params.DeployCheck{{Name: "charm"}}, nil)
+
+	d := s.newDryRunDeployCharm()
+	d.applicationName = ""
+	err := d.deploy(cmdtesting.Context(c), s.deployerAPI)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *deployerSuite) TestLocalCharmDryRun(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	ch := testcharms.Repo.CharmDir("dummy")
+	curl := charm.MustParseURL("local:quantal/dummy-1")
+	s.deployerAPI.EXPECT().BestFacadeVersion("Application").Return(15)
+	s.deployerAPI.EXPECT().ValidateDeploy(gomock.Any()).DoAndReturn(func(args application.DeployArgs) ([]params.DeployCheck, error) {
+		c.Check(args.CharmID.URL, gc.Equals, curl)
+		c.Check(args.ApplicationName, gc.Equals, "dummy")
+		c.Check(args.LocalCharm, gc.Equals, ch)
+		return []params.DeployCheck{{Name: "charm"}}, nil
+	})
+
+	d := &localCharm{
+		deployCharm: s.newDryRunDeployCharm(),
+		curl:        curl,
+		ch:          ch,
+	}
+	d.applicationName = ""
+	d.flagSet = gnuflag.NewFlagSet("deploy", gnuflag.ContinueOnError)
+	// The charm is not added to the model.
+	err := d.PrepareAndDeploy(cmdtesting.Context(c), s.deployerAPI, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *deployerSuite) makeBundleDir(c *gc.C, content string) string {
 	bundlePath := filepath.Join(c.MkDir(), "example")
 	c.Assert(os.Mkdir(bundlePath, 0777), jc.ErrorIsNil)
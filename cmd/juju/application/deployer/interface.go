@@ -107,8 +107,10 @@ type DeployerAPI interface {
 	CharmDeployAPI
 	ModelAPI
 	OfferAPI
+	CostEstimateAPI
 
 	Deploy(application.DeployArgs) error
+	ValidateDeploy(application.DeployArgs) ([]apiparams.DeployCheck, error)
 	Status(patterns []string) (*apiparams.FullStatus, error)
 	WatchAll() (api.AllWatch, error)
 }
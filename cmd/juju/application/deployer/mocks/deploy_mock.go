@@ -349,6 +349,21 @@ func (mr *MockDeployerAPIMockRecorder) GetConstraints(arg0 ...interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConstraints", reflect.TypeOf((*MockDeployerAPI)(nil).GetConstraints), arg0...)
 }
 
+// GetModelConstraints mocks base method
+func (m *MockDeployerAPI) GetModelConstraints() (constraints.Value, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModelConstraints")
+	ret0, _ := ret[0].(constraints.Value)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModelConstraints indicates an expected call of GetModelConstraints
+func (mr *MockDeployerAPIMockRecorder) GetModelConstraints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModelConstraints", reflect.TypeOf((*MockDeployerAPI)(nil).GetModelConstraints))
+}
+
 // GrantOffer mocks base method
 func (m *MockDeployerAPI) GrantOffer(arg0, arg1 string, arg2 ...string) error {
 	m.ctrl.T.Helper()
@@ -383,6 +398,21 @@ func (mr *MockDeployerAPIMockRecorder) HTTPClient() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HTTPClient", reflect.TypeOf((*MockDeployerAPI)(nil).HTTPClient))
 }
 
+// InstanceTypes mocks base method
+func (m *MockDeployerAPI) InstanceTypes(arg0 []constraints.Value) ([]params.InstanceTypesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstanceTypes", arg0)
+	ret0, _ := ret[0].([]params.InstanceTypesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstanceTypes indicates an expected call of InstanceTypes
+func (mr *MockDeployerAPIMockRecorder) InstanceTypes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceTypes", reflect.TypeOf((*MockDeployerAPI)(nil).InstanceTypes), arg0)
+}
+
 // IsMetered mocks base method
 func (m *MockDeployerAPI) IsMetered(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -588,6 +618,21 @@ func (mr *MockDeployerAPIMockRecorder) Update(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeployerAPI)(nil).Update), arg0)
 }
 
+// ValidateDeploy mocks base method
+func (m *MockDeployerAPI) ValidateDeploy(arg0 application.DeployArgs) ([]params.DeployCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDeploy", arg0)
+	ret0, _ := ret[0].([]params.DeployCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateDeploy indicates an expected call of ValidateDeploy
+func (mr *MockDeployerAPIMockRecorder) ValidateDeploy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDeploy", reflect.TypeOf((*MockDeployerAPI)(nil).ValidateDeploy), arg0)
+}
+
 // WatchAll mocks base method
 func (m *MockDeployerAPI) WatchAll() (api.AllWatch, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +171,42 @@ func NewSuspendRelationCommandForTest(api SetRelationSuspendedAPI, store jujucli
 	return modelcmd.Wrap(cmd)
 }
 
+// NewKillHookCommandForTest returns a KillHookCommand with the api provided as specified.
+func NewKillHookCommandForTest(api KillHooksAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &killHookCommand{newAPIFunc: func() (KillHooksAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowHookHistoryCommandForTest returns a ShowHookHistoryCommand with the api provided as specified.
+func NewShowHookHistoryCommandForTest(api HookHistoryAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &showHookHistoryCommand{newAPIFunc: func() (HookHistoryAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowK8sWorkloadCommandForTest returns a ShowK8sWorkloadCommand with the api provided as specified.
+func NewShowK8sWorkloadCommandForTest(api UnitWorkloadAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &showK8sWorkloadCommand{newAPIFunc: func() (UnitWorkloadAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewAdoptK8sCommandForTest returns an AdoptK8sCommand with the api provided as specified.
+func NewAdoptK8sCommandForTest(api AdoptK8sAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &adoptK8sCommand{newAPIFunc: func() (AdoptK8sAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewResumeRelationCommandForTest returns a ResumeRelationCommand with the api provided as specified.
 func NewResumeRelationCommandForTest(api SetRelationSuspendedAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &resumeRelationCommand{newAPIFunc: func() (SetRelationSuspendedAPI, error) {
@@ -198,6 +234,14 @@ func NewScaleCommandForTest(api scaleApplicationAPI, store jujuclient.ClientStor
 	return modelcmd.Wrap(cmd)
 }
 
+// NewSetAutoscaleCommandForTest returns a set-autoscale command with the api
+// provided as specified.
+func NewSetAutoscaleCommandForTest(api applicationAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &setAutoscaleCommand{configCommand: configCommand{api: api}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewBundleDiffCommandForTest(api base.APICallCloser, charmStore BundleResolver, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &bundleDiffCommand{
 		_apiRoot:    api,
@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var killHookHelpSummary = `
+Kills the hook currently running on one or more units.`[1:]
+
+var killHookHelpDetails = `
+The hook process (and any processes it started) running on each of the
+specified units is killed. The hook is treated as failed: the unit is put
+into an error state, with a status message recording that the hook was
+killed, and the hook can be retried with "juju resolved".
+
+Hooks may also be killed automatically after running for longer than the
+"hook-timeout" model config value, or the charm's own "hook-timeout" config
+option if the charm declares one.
+
+Examples:
+    juju kill-hook mysql/0
+    juju kill-hook mysql/0 wordpress/1
+
+See also:
+    model-config
+    resolved
+    show-status-log`
+
+// NewKillHookCommand returns a command to kill running hooks.
+func NewKillHookCommand() cmd.Command {
+	cmd := &killHookCommand{}
+	cmd.newAPIFunc = func() (KillHooksAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// killHookCommand kills the hook currently running on units.
+type killHookCommand struct {
+	modelcmd.ModelCommandBase
+	unitNames  []string
+	newAPIFunc func() (KillHooksAPI, error)
+}
+
+func (c *killHookCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "kill-hook",
+		Args:    "<unit> [<unit> ...]",
+		Purpose: killHookHelpSummary,
+		Doc:     killHookHelpDetails,
+	})
+}
+
+func (c *killHookCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no unit specified")
+	}
+	for _, u := range args {
+		if !names.IsValidUnit(u) {
+			return errors.NotValidf("unit name %q", u)
+		}
+	}
+	c.unitNames = args
+	return nil
+}
+
+// KillHooksAPI defines the API methods that the kill-hook command uses.
+type KillHooksAPI interface {
+	Close() error
+	BestAPIVersion() int
+	KillHooks(units []string) error
+}
+
+func (c *killHookCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 14 {
+		return errors.New("killing hooks is not supported by this version of Juju")
+	}
+	err = client.KillHooks(c.unitNames)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
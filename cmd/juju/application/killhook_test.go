@@ -0,0 +1,90 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type KillHookSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockKillHooksAPI
+}
+
+func (s *KillHookSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockKillHooksAPI{Stub: &testing.Stub{}, version: 14}
+}
+
+var _ = gc.Suite(&KillHookSuite{})
+
+func (s *KillHookSuite) runKillHook(c *gc.C, args ...string) error {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, application.NewKillHookCommandForTest(s.mockAPI, store), args...)
+	return err
+}
+
+func (s *KillHookSuite) TestKillHookInvalidArguments(c *gc.C) {
+	err := s.runKillHook(c)
+	c.Assert(err, gc.ErrorMatches, "no unit specified")
+
+	err = s.runKillHook(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+}
+
+func (s *KillHookSuite) TestKillHookOldServer(c *gc.C) {
+	s.mockAPI.version = 13
+	err := s.runKillHook(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "killing hooks is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *KillHookSuite) TestKillHookSuccess(c *gc.C) {
+	err := s.runKillHook(c, "mysql/0", "wordpress/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "KillHooks", []string{"mysql/0", "wordpress/1"})
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *KillHookSuite) TestKillHookFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	err := s.runKillHook(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.mockAPI.CheckCall(c, 0, "KillHooks", []string{"mysql/0"})
+}
+
+func (s *KillHookSuite) TestKillHookBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(apiservererrors.OperationBlockedError("TestKillHookBlocked"))
+	err := s.runKillHook(c, "mysql/0")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestKillHookBlocked.*")
+	s.mockAPI.CheckCall(c, 0, "KillHooks", []string{"mysql/0"})
+}
+
+type mockKillHooksAPI struct {
+	*testing.Stub
+	version int
+}
+
+func (s mockKillHooksAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockKillHooksAPI) KillHooks(units []string) error {
+	s.MethodCall(s, "KillHooks", units)
+	return s.NextErr()
+}
+
+func (s mockKillHooksAPI) BestAPIVersion() int {
+	return s.version
+}
@@ -45,6 +45,9 @@ Scale a k8s application by specifying how many units there should be.
 The new number of units can be greater or less than the current number, thus
 allowing both scale up and scale down.
 
+Applications that are autoscaled with set-autoscale cannot be scaled
+manually; run "juju set-autoscale <application> --disable" first.
+
 Examples:
 
     juju scale-application mariadb 2
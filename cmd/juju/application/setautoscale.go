@@ -0,0 +1,131 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	setAutoscaleSummary = `Configures horizontal pod autoscaling for a k8s application.`
+	setAutoscaleDetails = `
+Sets the bounds and CPU target used by a Kubernetes HorizontalPodAutoscaler
+to scale the application's pods. The autoscaler is created and owned by Juju;
+as it adds or removes pods, Juju adds or removes units to match.
+
+While autoscaling is enabled the application cannot be scaled with
+scale-application. Use --disable to stop autoscaling; the application keeps
+its current number of units.
+
+The settings are stored in the application config as
+kubernetes-autoscale-min-units, kubernetes-autoscale-max-units and
+kubernetes-autoscale-cpu-target, and so can also be viewed and changed
+with the config command.
+
+Examples:
+    juju set-autoscale mariadb --min 2 --max 10
+    juju set-autoscale mariadb --cpu-target 60
+    juju set-autoscale mariadb --disable
+
+See also:
+    config
+    scale-application
+`
+)
+
+// NewSetAutoscaleCommand returns a command which configures autoscaling
+// for a k8s application.
+func NewSetAutoscaleCommand() cmd.Command {
+	return modelcmd.Wrap(&setAutoscaleCommand{})
+}
+
+type setAutoscaleCommand struct {
+	configCommand
+	modelcmd.CAASOnlyCommand
+
+	minUnits  int
+	maxUnits  int
+	cpuTarget int
+	disable   bool
+}
+
+// Info is part of the cmd.Command interface.
+func (c *setAutoscaleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-autoscale",
+		Args:    "<application name>",
+		Purpose: setAutoscaleSummary,
+		Doc:     setAutoscaleDetails,
+	})
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *setAutoscaleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.minUnits, "min", 0, "The minimum number of units")
+	f.IntVar(&c.maxUnits, "max", 0, "The maximum number of units")
+	f.IntVar(&c.cpuTarget, "cpu-target", 0, "The target average CPU utilisation, as a percentage")
+	f.BoolVar(&c.disable, "disable", false, "Turn off autoscaling")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *setAutoscaleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.validateGeneration(); err != nil {
+		return errors.Trace(err)
+	}
+
+	var settings []string
+	if c.disable {
+		if c.minUnits != 0 || c.maxUnits != 0 || c.cpuTarget != 0 {
+			return errors.New("--disable cannot be used with --min, --max or --cpu-target")
+		}
+		settings = append(settings, fmt.Sprintf("%s=0", k8sprovider.AutoscaleMaxUnitsKey))
+		return c.parseSet(settings)
+	}
+
+	if c.minUnits == 0 && c.maxUnits == 0 && c.cpuTarget == 0 {
+		return errors.New("specify at least one of --min, --max, --cpu-target or --disable")
+	}
+	if c.minUnits < 0 {
+		return errors.New("--min must be at least 1")
+	}
+	if c.maxUnits < 0 {
+		return errors.New("--max must be at least 1, use --disable to turn off autoscaling")
+	}
+	if c.minUnits > 0 && c.maxUnits > 0 && c.minUnits > c.maxUnits {
+		return errors.New("--min must not be greater than --max")
+	}
+	if c.cpuTarget < 0 || c.cpuTarget > 100 {
+		return errors.New("--cpu-target must be between 1 and 100")
+	}
+	if c.minUnits > 0 {
+		settings = append(settings, fmt.Sprintf("%s=%d", k8sprovider.AutoscaleMinUnitsKey, c.minUnits))
+	}
+	if c.maxUnits > 0 {
+		settings = append(settings, fmt.Sprintf("%s=%d", k8sprovider.AutoscaleMaxUnitsKey, c.maxUnits))
+	}
+	if c.cpuTarget > 0 {
+		settings = append(settings, fmt.Sprintf("%s=%d", k8sprovider.AutoscaleCPUTargetKey, c.cpuTarget))
+	}
+	return c.parseSet(settings)
+}
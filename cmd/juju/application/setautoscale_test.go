@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type setAutoscaleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+
+	fake  *fakeApplicationAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&setAutoscaleSuite{})
+
+func (s *setAutoscaleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeApplicationAPI{
+		branchName: model.GenerationMaster,
+		name:       "mariadb",
+		charmName:  "mariadb",
+		appValues:  map[string]interface{}{},
+		version:    13,
+	}
+	s.store = jujuclienttesting.MinimalStore()
+	s.store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: model.CAAS,
+		}},
+	}
+}
+
+func (s *setAutoscaleSuite) run(c *gc.C, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, application.NewSetAutoscaleCommandForTest(s.fake, s.store), args...)
+	return err
+}
+
+func (s *setAutoscaleSuite) TestSetAutoscale(c *gc.C) {
+	err := s.run(c, "mariadb", "--min", "2", "--max", "10", "--cpu-target", "60")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.appValues, jc.DeepEquals, map[string]interface{}{
+		"kubernetes-autoscale-min-units":  "2",
+		"kubernetes-autoscale-max-units":  "10",
+		"kubernetes-autoscale-cpu-target": "60",
+	})
+}
+
+func (s *setAutoscaleSuite) TestSetAutoscaleOnlyGivenValues(c *gc.C) {
+	err := s.run(c, "mariadb", "--cpu-target", "50")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.appValues, jc.DeepEquals, map[string]interface{}{
+		"kubernetes-autoscale-cpu-target": "50",
+	})
+}
+
+func (s *setAutoscaleSuite) TestDisable(c *gc.C) {
+	err := s.run(c, "mariadb", "--disable")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.appValues, jc.DeepEquals, map[string]interface{}{
+		"kubernetes-autoscale-max-units": "0",
+	})
+}
+
+func (s *setAutoscaleSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no application name specified",
+	}, {
+		args: []string{"mariadb"},
+		err:  "specify at least one of --min, --max, --cpu-target or --disable",
+	}, {
+		args: []string{"mariadb", "extra", "--max", "2"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mariadb", "--disable", "--max", "2"},
+		err:  "--disable cannot be used with --min, --max or --cpu-target",
+	}, {
+		args: []string{"mariadb", "--min", "3", "--max", "2"},
+		err:  "--min must not be greater than --max",
+	}, {
+		args: []string{"mariadb", "--max", "-1"},
+		err:  "--max must be at least 1, use --disable to turn off autoscaling",
+	}, {
+		args: []string{"mariadb", "--cpu-target", "101"},
+		err:  "--cpu-target must be between 1 and 100",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *setAutoscaleSuite) TestIAASModel(c *gc.C) {
+	s.store.Models["arthur"].Models["king/sword"] = jujuclient.ModelDetails{ModelType: model.IAAS}
+	err := s.run(c, "mariadb", "--max", "2")
+	c.Assert(err, gc.ErrorMatches, `Juju command "set-autoscale" not supported on non-container models`)
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/osenv"
+)
+
+var showHookHistoryHelpSummary = `
+Shows the hooks recently run on a unit.`[1:]
+
+var showHookHistoryHelpDetails = `
+Each hook run on a unit is recorded on the controller, including the
+relation and remote unit that triggered it, when it started, how long it
+ran for, its exit code and the tail of its error output. The most recent
+hook runs are shown first.
+
+Hook history is pruned along with status history, according to the
+"max-status-history-age" and "max-status-history-size" model config
+values.
+
+Examples:
+    juju show-hook-history mysql/0
+    juju show-hook-history mysql/0 -n 100
+    juju show-hook-history mysql/0 --format yaml
+
+See also:
+    kill-hook
+    show-status-log`
+
+// NewShowHookHistoryCommand returns a command that displays the hook
+// history of a unit.
+func NewShowHookHistoryCommand() cmd.Command {
+	cmd := &showHookHistoryCommand{}
+	cmd.newAPIFunc = func() (HookHistoryAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// HookHistoryAPI defines the API methods that the show-hook-history
+// command uses.
+type HookHistoryAPI interface {
+	Close() error
+	BestAPIVersion() int
+	HookHistory(unit string, size int) ([]params.HookRecord, error)
+}
+
+type showHookHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	unitName string
+	size     int
+	isoTime  bool
+
+	newAPIFunc func() (HookHistoryAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *showHookHistoryCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-hook-history",
+		Args:    "<unit>",
+		Purpose: showHookHistoryHelpSummary,
+		Doc:     showHookHistoryHelpDetails,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showHookHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.size, "n", 20, "Show the last N hook runs (0 shows all recorded hook runs)")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showHookHistoryCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.Errorf("no unit specified")
+	case 1:
+		c.unitName = args[0]
+	default:
+		return errors.Errorf("unexpected arguments after unit name")
+	}
+	if !names.IsValidUnit(c.unitName) {
+		return errors.NotValidf("unit name %q", c.unitName)
+	}
+	if c.size < 0 {
+		return errors.Errorf("-n must not be negative")
+	}
+	// If use of ISO time not specified on command line, check env var.
+	if !c.isoTime {
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			var err error
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showHookHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 14 {
+		return errors.New("hook history is not supported by this version of Juju")
+	}
+
+	records, err := client.HookHistory(c.unitName, c.size)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(records) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No hook history available for %s", c.unitName)
+		return nil
+	}
+	history := make([]hookRun, len(records))
+	for i, record := range records {
+		history[i] = hookRun{
+			Hook:       record.HookName,
+			Relation:   record.Relation,
+			RemoteUnit: record.RemoteUnit,
+			Started:    common.FormatTime(&record.Started, c.isoTime),
+			Duration:   record.Duration.Round(time.Millisecond).String(),
+			ExitCode:   record.ExitCode,
+			Output:     record.Output,
+		}
+	}
+	return c.out.Write(ctx, history)
+}
+
+// hookRun is the serialisation format of a hook history record.
+type hookRun struct {
+	Hook       string `yaml:"hook" json:"hook"`
+	Relation   string `yaml:"relation,omitempty" json:"relation,omitempty"`
+	RemoteUnit string `yaml:"remote-unit,omitempty" json:"remote-unit,omitempty"`
+	Started    string `yaml:"started" json:"started"`
+	Duration   string `yaml:"duration" json:"duration"`
+	ExitCode   int    `yaml:"exit-code" json:"exit-code"`
+	Output     string `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// formatTabular writes the hook history in tabular format. Only the
+// last line of each hook's output is shown.
+func (c *showHookHistoryCommand) formatTabular(writer io.Writer, value interface{}) error {
+	history, ok := value.([]hookRun)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", history, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Started", "Hook", "Relation", "Remote unit", "Duration", "Exit code", "Message")
+	for _, run := range history {
+		w.Println(
+			run.Started, run.Hook, run.Relation, run.RemoteUnit,
+			run.Duration, run.ExitCode, lastLine(run.Output),
+		)
+	}
+	return tw.Flush()
+}
+
+// lastLine returns the last non-empty line of s.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	return lines[len(lines)-1]
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ShowHookHistorySuite struct {
+	testing.IsolationSuite
+	mockAPI *mockHookHistoryAPI
+}
+
+var _ = gc.Suite(&ShowHookHistorySuite{})
+
+func (s *ShowHookHistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	started := time.Date(2020, 7, 1, 10, 30, 0, 0, time.UTC)
+	s.mockAPI = &mockHookHistoryAPI{
+		Stub:    &testing.Stub{},
+		version: 14,
+		records: []params.HookRecord{{
+			HookName:   "db-relation-changed",
+			Relation:   "wordpress:db mysql:server",
+			RemoteUnit: "wordpress/0",
+			Started:    started,
+			Duration:   1500 * time.Millisecond,
+			ExitCode:   1,
+			Output:     "starting\nno database configured\n",
+		}, {
+			HookName: "config-changed",
+			Started:  started.Add(-time.Minute),
+			Duration: 250 * time.Millisecond,
+		}},
+	}
+}
+
+func (s *ShowHookHistorySuite) runShowHookHistory(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewShowHookHistoryCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *ShowHookHistorySuite) TestInvalidArguments(c *gc.C) {
+	_, err := s.runShowHookHistory(c)
+	c.Assert(err, gc.ErrorMatches, "no unit specified")
+
+	_, err = s.runShowHookHistory(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+
+	_, err = s.runShowHookHistory(c, "mysql/0", "mysql/1")
+	c.Assert(err, gc.ErrorMatches, "unexpected arguments after unit name")
+
+	_, err = s.runShowHookHistory(c, "mysql/0", "-n", "-1")
+	c.Assert(err, gc.ErrorMatches, "-n must not be negative")
+}
+
+func (s *ShowHookHistorySuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 13
+	_, err := s.runShowHookHistory(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "hook history is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *ShowHookHistorySuite) TestTabular(c *gc.C) {
+	ctx, err := s.runShowHookHistory(c, "mysql/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "HookHistory", "mysql/0", 20)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Started               Hook                 Relation                   Remote unit  Duration  Exit code  Message\n"+
+		"2020-07-01 10:30:00Z  db-relation-changed  wordpress:db mysql:server  wordpress/0  1.5s      1          no database configured\n"+
+		"2020-07-01 10:29:00Z  config-changed                                               250ms     0          \n"+
+		"\n")
+}
+
+func (s *ShowHookHistorySuite) TestYaml(c *gc.C) {
+	ctx, err := s.runShowHookHistory(c, "mysql/0", "--utc", "-n", "5", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "HookHistory", "mysql/0", 5)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- hook: db-relation-changed
+  relation: wordpress:db mysql:server
+  remote-unit: wordpress/0
+  started: 2020-07-01 10:30:00Z
+  duration: 1.5s
+  exit-code: 1
+  output: |
+    starting
+    no database configured
+- hook: config-changed
+  started: 2020-07-01 10:29:00Z
+  duration: 250ms
+  exit-code: 0
+`[1:])
+}
+
+func (s *ShowHookHistorySuite) TestNoHistory(c *gc.C) {
+	s.mockAPI.records = nil
+	ctx, err := s.runShowHookHistory(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No hook history available for mysql/0\n")
+}
+
+func (s *ShowHookHistorySuite) TestFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runShowHookHistory(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockHookHistoryAPI struct {
+	*testing.Stub
+	version int
+	records []params.HookRecord
+}
+
+func (s *mockHookHistoryAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s *mockHookHistoryAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockHookHistoryAPI) HookHistory(unit string, size int) ([]params.HookRecord, error) {
+	s.MethodCall(s, "HookHistory", unit, size)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.records, nil
+}
@@ -0,0 +1,285 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/osenv"
+)
+
+var showK8sWorkloadHelpSummary = `
+Shows the state of the pod running a unit in a Kubernetes model.`[1:]
+
+var showK8sWorkloadHelpDetails = `
+The controller queries the cluster on behalf of the user, so no cluster
+credentials are needed beyond read access to the model. The pod's phase
+and conditions are shown, along with the state and restart count of each
+of its containers, the events recorded against the pod (oldest first) and
+the last lines logged by each container.
+
+Examples:
+    juju show-k8s-workload mariadb/0
+    juju show-k8s-workload mariadb/0 -n 100
+    juju show-k8s-workload mariadb/0 -n 0 --format yaml
+
+See also:
+    show-unit
+    show-hook-history
+    debug-log`
+
+// NewShowK8sWorkloadCommand returns a command that displays the pod
+// running a unit in a Kubernetes model.
+func NewShowK8sWorkloadCommand() cmd.Command {
+	cmd := &showK8sWorkloadCommand{}
+	cmd.newAPIFunc = func() (UnitWorkloadAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// UnitWorkloadAPI defines the API methods that the show-k8s-workload
+// command uses.
+type UnitWorkloadAPI interface {
+	Close() error
+	BestAPIVersion() int
+	UnitWorkload(unit string, logLines int) (*params.UnitWorkload, error)
+}
+
+type showK8sWorkloadCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	unitName string
+	logLines int
+	isoTime  bool
+
+	newAPIFunc func() (UnitWorkloadAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *showK8sWorkloadCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-k8s-workload",
+		Args:    "<unit>",
+		Purpose: showK8sWorkloadHelpSummary,
+		Doc:     showK8sWorkloadHelpDetails,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showK8sWorkloadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.logLines, "n", 20, "Show the last N log lines of each container (0 shows no logs)")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showK8sWorkloadCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.Errorf("no unit specified")
+	case 1:
+		c.unitName = args[0]
+	default:
+		return errors.Errorf("unexpected arguments after unit name")
+	}
+	if !names.IsValidUnit(c.unitName) {
+		return errors.NotValidf("unit name %q", c.unitName)
+	}
+	if c.logLines < 0 {
+		return errors.Errorf("-n must not be negative")
+	}
+	// If use of ISO time not specified on command line, check env var.
+	if !c.isoTime {
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			var err error
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showK8sWorkloadCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 16 {
+		return errors.New("inspecting unit workloads is not supported by this version of Juju")
+	}
+
+	workload, err := client.UnitWorkload(c.unitName, c.logLines)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result := unitWorkload{
+		Unit:    c.unitName,
+		Pod:     workload.PodName,
+		Phase:   workload.Phase,
+		Message: workload.Message,
+		Node:    workload.Node,
+	}
+	for _, cond := range workload.Conditions {
+		result.Conditions = append(result.Conditions, workloadCondition{
+			Type:    cond.Type,
+			Status:  cond.Status,
+			Reason:  cond.Reason,
+			Message: cond.Message,
+			Since:   c.formatTime(cond.LastUpdatedAt),
+		})
+	}
+	for _, ctr := range workload.Containers {
+		result.Containers = append(result.Containers, workloadContainer{
+			Name:     ctr.Name,
+			Image:    ctr.Image,
+			Init:     ctr.Init,
+			Ready:    ctr.Ready,
+			Restarts: ctr.RestartCount,
+			State:    ctr.State,
+			Reason:   ctr.Reason,
+			Message:  ctr.Message,
+			Logs:     ctr.Logs,
+		})
+	}
+	for _, evt := range workload.Events {
+		result.Events = append(result.Events, workloadEvent{
+			Type:     evt.Type,
+			Reason:   evt.Reason,
+			Message:  evt.Message,
+			Count:    evt.Count,
+			LastSeen: c.formatTime(evt.LastSeen),
+		})
+	}
+	return c.out.Write(ctx, result)
+}
+
+func (c *showK8sWorkloadCommand) formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return common.FormatTime(&t, c.isoTime)
+}
+
+// unitWorkload is the serialisation format of a unit's pod.
+type unitWorkload struct {
+	Unit       string              `yaml:"unit" json:"unit"`
+	Pod        string              `yaml:"pod" json:"pod"`
+	Phase      string              `yaml:"phase" json:"phase"`
+	Message    string              `yaml:"message,omitempty" json:"message,omitempty"`
+	Node       string              `yaml:"node,omitempty" json:"node,omitempty"`
+	Conditions []workloadCondition `yaml:"conditions,omitempty" json:"conditions,omitempty"`
+	Containers []workloadContainer `yaml:"containers,omitempty" json:"containers,omitempty"`
+	Events     []workloadEvent     `yaml:"events,omitempty" json:"events,omitempty"`
+}
+
+type workloadCondition struct {
+	Type    string `yaml:"type" json:"type"`
+	Status  string `yaml:"status" json:"status"`
+	Reason  string `yaml:"reason,omitempty" json:"reason,omitempty"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	Since   string `yaml:"since,omitempty" json:"since,omitempty"`
+}
+
+type workloadContainer struct {
+	Name     string   `yaml:"name" json:"name"`
+	Image    string   `yaml:"image,omitempty" json:"image,omitempty"`
+	Init     bool     `yaml:"init,omitempty" json:"init,omitempty"`
+	Ready    bool     `yaml:"ready" json:"ready"`
+	Restarts int32    `yaml:"restarts" json:"restarts"`
+	State    string   `yaml:"state,omitempty" json:"state,omitempty"`
+	Reason   string   `yaml:"reason,omitempty" json:"reason,omitempty"`
+	Message  string   `yaml:"message,omitempty" json:"message,omitempty"`
+	Logs     []string `yaml:"logs,omitempty" json:"logs,omitempty"`
+}
+
+type workloadEvent struct {
+	Type     string `yaml:"type" json:"type"`
+	Reason   string `yaml:"reason,omitempty" json:"reason,omitempty"`
+	Message  string `yaml:"message,omitempty" json:"message,omitempty"`
+	Count    int32  `yaml:"count" json:"count"`
+	LastSeen string `yaml:"last-seen,omitempty" json:"last-seen,omitempty"`
+}
+
+// formatTabular writes the workload as a series of tables, followed by
+// the logs of each container.
+func (c *showK8sWorkloadCommand) formatTabular(writer io.Writer, value interface{}) error {
+	workload, ok := value.(unitWorkload)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", workload, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Unit", "Pod", "Phase", "Node", "Message")
+	w.Println(workload.Unit, workload.Pod, workload.Phase, workload.Node, workload.Message)
+
+	if len(workload.Conditions) > 0 {
+		w.Println()
+		w.Println("Condition", "Status", "Since", "Reason", "Message")
+		for _, cond := range workload.Conditions {
+			w.Println(cond.Type, cond.Status, cond.Since, cond.Reason, cond.Message)
+		}
+	}
+	if len(workload.Containers) > 0 {
+		w.Println()
+		w.Println("Container", "Image", "Ready", "Restarts", "State", "Reason")
+		for _, ctr := range workload.Containers {
+			name := ctr.Name
+			if ctr.Init {
+				name += " (init)"
+			}
+			w.Println(name, ctr.Image, ctr.Ready, ctr.Restarts, ctr.State, ctr.Reason)
+		}
+	}
+	if len(workload.Events) > 0 {
+		w.Println()
+		w.Println("Last seen", "Type", "Reason", "Count", "Message")
+		for _, evt := range workload.Events {
+			w.Println(evt.LastSeen, evt.Type, evt.Reason, evt.Count, evt.Message)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, ctr := range workload.Containers {
+		if len(ctr.Logs) == 0 {
+			continue
+		}
+		fmt.Fprintf(writer, "\nLogs from %s:\n", ctr.Name)
+		for _, line := range ctr.Logs {
+			fmt.Fprintf(writer, "  %s\n", line)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ShowK8sWorkloadSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockUnitWorkloadAPI
+}
+
+var _ = gc.Suite(&ShowK8sWorkloadSuite{})
+
+func (s *ShowK8sWorkloadSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	seen := time.Date(2020, 7, 1, 10, 30, 0, 0, time.UTC)
+	s.mockAPI = &mockUnitWorkloadAPI{
+		Stub:    &testing.Stub{},
+		version: 16,
+		workload: &params.UnitWorkload{
+			PodName: "mariadb-0",
+			Phase:   "Running",
+			Node:    "node-1",
+			Conditions: []params.KubernetesPodCondition{{
+				Type:          "Ready",
+				Status:        "False",
+				Reason:        "ContainersNotReady",
+				LastUpdatedAt: seen,
+			}},
+			Containers: []params.KubernetesContainerInfo{{
+				Name:  "juju-pod-init",
+				Image: "jujud-operator",
+				Init:  true,
+				State: "terminated",
+			}, {
+				Name:         "mariadb",
+				Image:        "mariadb:10",
+				RestartCount: 4,
+				State:        "waiting",
+				Reason:       "CrashLoopBackOff",
+				Logs:         []string{"starting", "no datadir"},
+			}},
+			Events: []params.KubernetesEvent{{
+				Type:     "Warning",
+				Reason:   "BackOff",
+				Message:  "Back-off restarting failed container",
+				Count:    4,
+				LastSeen: seen,
+			}},
+		},
+	}
+}
+
+func (s *ShowK8sWorkloadSuite) runShowK8sWorkload(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewShowK8sWorkloadCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *ShowK8sWorkloadSuite) TestInvalidArguments(c *gc.C) {
+	_, err := s.runShowK8sWorkload(c)
+	c.Assert(err, gc.ErrorMatches, "no unit specified")
+
+	_, err = s.runShowK8sWorkload(c, "mariadb")
+	c.Assert(err, gc.ErrorMatches, `unit name "mariadb" not valid`)
+
+	_, err = s.runShowK8sWorkload(c, "mariadb/0", "mariadb/1")
+	c.Assert(err, gc.ErrorMatches, "unexpected arguments after unit name")
+
+	_, err = s.runShowK8sWorkload(c, "mariadb/0", "-n", "-1")
+	c.Assert(err, gc.ErrorMatches, "-n must not be negative")
+}
+
+func (s *ShowK8sWorkloadSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 15
+	_, err := s.runShowK8sWorkload(c, "mariadb/0")
+	c.Assert(err, gc.ErrorMatches, "inspecting unit workloads is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *ShowK8sWorkloadSuite) TestTabular(c *gc.C) {
+	ctx, err := s.runShowK8sWorkload(c, "mariadb/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "UnitWorkload", "mariadb/0", 20)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Unit       Pod        Phase    Node    Message\n"+
+		"mariadb/0  mariadb-0  Running  node-1  \n"+
+		"\n"+
+		"Condition  Status  Since                 Reason              Message\n"+
+		"Ready      False   2020-07-01 10:30:00Z  ContainersNotReady  \n"+
+		"\n"+
+		"Container             Image           Ready  Restarts  State       Reason\n"+
+		"juju-pod-init (init)  jujud-operator  false  0         terminated  \n"+
+		"mariadb               mariadb:10      false  4         waiting     CrashLoopBackOff\n"+
+		"\n"+
+		"Last seen             Type     Reason   Count  Message\n"+
+		"2020-07-01 10:30:00Z  Warning  BackOff  4      Back-off restarting failed container\n"+
+		"\n"+
+		"Logs from mariadb:\n"+
+		"  starting\n"+
+		"  no datadir\n"+
+		"\n")
+}
+
+func (s *ShowK8sWorkloadSuite) TestYaml(c *gc.C) {
+	s.mockAPI.workload.Containers = s.mockAPI.workload.Containers[1:]
+	s.mockAPI.workload.Containers[0].Logs = nil
+	ctx, err := s.runShowK8sWorkload(c, "mariadb/0", "--utc", "-n", "0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "UnitWorkload", "mariadb/0", 0)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+unit: mariadb/0
+pod: mariadb-0
+phase: Running
+node: node-1
+conditions:
+- type: Ready
+  status: "False"
+  reason: ContainersNotReady
+  since: 2020-07-01 10:30:00Z
+containers:
+- name: mariadb
+  image: mariadb:10
+  ready: false
+  restarts: 4
+  state: waiting
+  reason: CrashLoopBackOff
+events:
+- type: Warning
+  reason: BackOff
+  message: Back-off restarting failed container
+  count: 4
+  last-seen: 2020-07-01 10:30:00Z
+`[1:])
+}
+
+func (s *ShowK8sWorkloadSuite) TestFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runShowK8sWorkload(c, "mariadb/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockUnitWorkloadAPI struct {
+	*testing.Stub
+	version  int
+	workload *params.UnitWorkload
+}
+
+func (s *mockUnitWorkloadAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s *mockUnitWorkloadAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockUnitWorkloadAPI) UnitWorkload(unit string, logLines int) (*params.UnitWorkload, error) {
+	s.MethodCall(s, "UnitWorkload", unit, logLines)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.workload, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/loggo"
+
+	charmhubclient "github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/mirror"
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+var logger = loggo.GetLogger("juju.cmd.juju.charmhub")
+
+const (
+	syncCharmsSummary = "Copies charms from CharmHub into a local charm mirror."
+	syncCharmsDoc     = `
+The sync-charms command downloads the charms listed in a manifest from
+CharmHub into a local directory. The directory can then be moved to a site
+without Internet access and served by juju-charm-mirror. Controllers use the
+mirror when models are created with charm-hub-url pointing at it.
+
+The manifest is a YAML file listing the charms to mirror and the channels to
+mirror for each (the default release is used if none are given). The
+resources of each mirrored revision are downloaded from CharmHub with it:
+
+    charms:
+      - name: postgresql
+        channels: [stable, edge]
+      - name: mediawiki
+
+Revisions already held in the local directory are not downloaded again, so the
+command can be re-run to update an existing mirror. Channels mirrored by
+earlier runs are kept.
+
+Examples:
+    juju sync-charms manifest.yaml --local-dir /srv/charms
+    juju-charm-mirror -listen :8080 /srv/charms
+    juju bootstrap --model-default charm-hub-url=http://mirror.internal:8080 ...
+
+See also:
+    find
+    info
+`
+)
+
+// NewSyncCharmsCommand returns a command that populates a local charm mirror.
+func NewSyncCharmsCommand() cmd.Command {
+	return &syncCharmsCommand{
+		newClient: newCharmHubClient,
+	}
+}
+
+// syncCharmsCommand supplies the "sync-charms" CLI command used to populate
+// a local charm mirror from CharmHub.
+type syncCharmsCommand struct {
+	cmd.CommandBase
+
+	newClient func(url string) (mirror.Client, error)
+
+	manifestPath string
+	localDir     string
+	charmHubURL  string
+}
+
+// Info returns help related info about the command, it implements
+// part of the cmd.Command interface.
+func (c *syncCharmsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "sync-charms",
+		Args:    "<manifest>",
+		Purpose: syncCharmsSummary,
+		Doc:     syncCharmsDoc,
+	})
+}
+
+// SetFlags defines flags which can be used with the sync-charms command.
+// It implements part of the cmd.Command interface.
+func (c *syncCharmsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.localDir, "local-dir", "", "Local destination directory")
+	f.StringVar(&c.charmHubURL, "charm-hub-url", charmhubclient.CharmHubServerURL, "The CharmHub to copy charms from")
+}
+
+// Init initializes the sync-charms command, including validating the
+// provided flags. It implements part of the cmd.Command interface.
+func (c *syncCharmsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no manifest specified")
+	}
+	c.manifestPath = args[0]
+	if c.localDir == "" {
+		return errors.Errorf("--local-dir is required")
+	}
+	if c.charmHubURL == "" {
+		return errors.Errorf("--charm-hub-url cannot be empty")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is the business logic of the sync-charms command. It implements the
+// meaty part of the cmd.Command interface.
+func (c *syncCharmsCommand) Run(ctx *cmd.Context) error {
+	// Register writer for output on screen.
+	writer := loggo.NewMinimumLevelWriter(
+		cmd.NewCommandLogWriter("juju.charmhub.mirror", ctx.Stdout, ctx.Stderr),
+		loggo.INFO)
+	if err := loggo.RegisterWriter("synccharms", writer); err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _, _ = loggo.RemoveWriter("synccharms") }()
+
+	manifest, err := mirror.ReadManifest(ctx.AbsPath(c.manifestPath))
+	if err != nil {
+		return errors.Annotatef(err, "reading manifest")
+	}
+
+	localDir := ctx.AbsPath(c.localDir)
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.newClient(c.charmHubURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := mirror.Sync(context.TODO(), client, mirror.NewStore(localDir), manifest); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Synced %d charm(s) to %s", len(manifest.Charms), localDir)
+	return nil
+}
+
+func newCharmHubClient(url string) (mirror.Client, error) {
+	config, err := charmhubclient.CharmHubConfigFromURL(url, logger.Child("client"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := charmhubclient.NewClient(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
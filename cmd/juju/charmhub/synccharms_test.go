@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+
+	"github.com/juju/charm/v8"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/charmhub/transport"
+	"github.com/juju/juju/testcharms"
+)
+
+type syncCharmsSuite struct{}
+
+var _ = gc.Suite(&syncCharmsSuite{})
+
+func (s *syncCharmsSuite) TestInitNoManifest(c *gc.C) {
+	err := cmdtesting.InitCommand(NewSyncCharmsCommand(), []string{"--local-dir", "/tmp"})
+	c.Assert(err, gc.ErrorMatches, "no manifest specified")
+}
+
+func (s *syncCharmsSuite) TestInitNoLocalDir(c *gc.C) {
+	err := cmdtesting.InitCommand(NewSyncCharmsCommand(), []string{"manifest.yaml"})
+	c.Assert(err, gc.ErrorMatches, "--local-dir is required")
+}
+
+func (s *syncCharmsSuite) TestInitTooManyArgs(c *gc.C) {
+	err := cmdtesting.InitCommand(NewSyncCharmsCommand(), []string{"manifest.yaml", "extra", "--local-dir", "/tmp"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *syncCharmsSuite) TestRun(c *gc.C) {
+	dir := c.MkDir()
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+	err := ioutil.WriteFile(manifestPath, []byte("charms:\n  - name: dummy\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := &fakeMirrorClient{
+		archivePath: testcharms.Repo.CharmArchivePath(c.MkDir(), "dummy"),
+	}
+	var clientURL string
+	command := &syncCharmsCommand{
+		newClient: func(url string) (mirror.Client, error) {
+			clientURL = url
+			return client, nil
+		},
+	}
+	localDir := filepath.Join(dir, "mirror")
+	ctx, err := cmdtesting.RunCommand(c, command, manifestPath, "--local-dir", localDir, "--charm-hub-url", "https://charmhub.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clientURL, gc.Equals, "https://charmhub.example.com")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Synced 1 charm(s) to "+localDir+"\n")
+
+	store := mirror.NewStore(localDir)
+	c.Assert(store.HasCharm("dummy", 3), jc.IsTrue)
+}
+
+func (s *syncCharmsSuite) TestRunCharmNotFound(c *gc.C) {
+	dir := c.MkDir()
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+	err := ioutil.WriteFile(manifestPath, []byte("charms:\n  - name: mysql\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	command := &syncCharmsCommand{
+		newClient: func(string) (mirror.Client, error) {
+			return &fakeMirrorClient{}, nil
+		},
+	}
+	_, err = cmdtesting.RunCommand(c, command, manifestPath, "--local-dir", filepath.Join(dir, "mirror"))
+	c.Assert(err, gc.ErrorMatches, `syncing charm "mysql": charm "mysql" not found`)
+}
+
+type fakeMirrorClient struct {
+	archivePath string
+}
+
+func (f *fakeMirrorClient) Info(_ context.Context, name string) (transport.InfoResponse, error) {
+	if name != "dummy" {
+		return transport.InfoResponse{}, errors.NotFoundf("charm %q", name)
+	}
+	release := transport.ChannelMap{
+		Channel: transport.Channel{Name: "latest/stable"},
+		Revision: transport.Revision{
+			Revision: 3,
+			Download: transport.Download{URL: "https://charmhub.example.com/download/dummy_3.charm"},
+		},
+	}
+	return transport.InfoResponse{
+		Type:           "charm",
+		ID:             "dummy-id",
+		Name:           "dummy",
+		ChannelMap:     []transport.ChannelMap{release},
+		DefaultRelease: release,
+	}, nil
+}
+
+func (f *fakeMirrorClient) Download(_ context.Context, _ *url.URL, archivePath string) (*charm.CharmArchive, error) {
+	data, err := ioutil.ReadFile(f.archivePath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(archivePath, data, 0644); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charm.ReadCharmArchive(archivePath)
+}
+
+func (f *fakeMirrorClient) DownloadResource(_ context.Context, _ *url.URL) (io.ReadCloser, error) {
+	return nil, errors.NotSupportedf("resources")
+}
@@ -52,7 +52,7 @@ func (c *debugCodeCommand) Init(args []string) error {
 }
 
 func (c *debugCodeCommand) SetFlags(f *gnuflag.FlagSet) {
-	c.debugHooksCommand.SetFlags(f)
+	c.sshCommand.SetFlags(f)
 	f.StringVar(&c.debugAt, "at", "all",
 		"interpreted by the charm for where you want to stop, defaults to 'all'")
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/action"
@@ -32,7 +33,8 @@ func newDebugHooksCommand(hostChecker ssh.ReachableChecker) cmd.Command {
 // debugHooksCommand is responsible for launching a ssh shell on a given unit or machine.
 type debugHooksCommand struct {
 	sshCommand
-	hooks []string
+	hooks      []string
+	replayLast bool
 
 	actionsAPI
 	charmRelationsAPI
@@ -41,8 +43,21 @@ type debugHooksCommand struct {
 const debugHooksDoc = `
 Interactively debug hooks or actions remotely on an application unit.
 
+With --replay-last, a tmux session is opened in the context of the
+unit's last failed hook rather than waiting for future hooks. The
+environment the hook ran with is restored, and the relation settings of
+the local and remote units at the time of the failure are made available.
+The hook can then be rerun, with working hook tools, for the same
+relation and remote unit as often as needed.
+
 See the "juju help ssh" for information about SSH related options
 accepted by the debug-hooks command.
+
+Examples:
+
+    juju debug-hooks mysql/0
+    juju debug-hooks mysql/0 install config-changed
+    juju debug-hooks mysql/0 --replay-last
 `
 
 func (c *debugHooksCommand) Info() *cmd.Info {
@@ -55,6 +70,11 @@ func (c *debugHooksCommand) Info() *cmd.Info {
 	})
 }
 
+func (c *debugHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.sshCommand.SetFlags(f)
+	f.BoolVar(&c.replayLast, "replay-last", false, "Replay the unit's last failed hook in its original context")
+}
+
 func (c *debugHooksCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.Errorf("no unit name specified")
@@ -68,6 +88,10 @@ func (c *debugHooksCommand) Init(args []string) error {
 		return errors.Errorf("%q is not a valid unit name", c.provider.getTarget())
 	}
 
+	if c.replayLast && len(args) > 1 {
+		return errors.New("hook or action names cannot be specified with --replay-last")
+	}
+
 	// If any of the hooks is "*", then debug all hooks.
 	c.hooks = append([]string{}, args[1:]...)
 	for _, h := range c.hooks {
@@ -211,11 +235,7 @@ func (c *debugHooksCommand) commonRun(
 		return err
 	}
 	debugctx := unitdebug.NewHooksContext(target)
-	clientScript := unitdebug.ClientScript(debugctx, hooks, debugAt)
-	b64Script := base64.StdEncoding.EncodeToString([]byte(clientScript))
-	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, b64Script)
-	args := []string{fmt.Sprintf(c.decideEntryPoint(ctx), innercmd)}
-	c.provider.setArgs(args)
+	c.setRemoteScript(ctx, unitdebug.ClientScript(debugctx, hooks, debugAt))
 	return c.sshCommand.Run(ctx)
 }
 
@@ -223,9 +243,28 @@ func (c *debugHooksCommand) commonRun(
 // and connects to it via SSH to execute the debug-hooks
 // script.
 func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
+	if c.replayLast {
+		return c.replayLastFailedHook(ctx, c.provider.getTarget())
+	}
 	if err := c.initAPIs(); err != nil {
 		return err
 	}
 	defer c.closeAPIs()
 	return c.commonRun(ctx, c.provider.getTarget(), c.hooks, "")
 }
+
+// replayLastFailedHook connects to the unit via SSH and opens a tmux
+// session in the context of the unit's last failed hook.
+func (c *debugHooksCommand) replayLastFailedHook(ctx *cmd.Context, target string) error {
+	debugctx := unitdebug.NewHooksContext(target)
+	c.setRemoteScript(ctx, unitdebug.ClientReplayScript(debugctx))
+	return c.sshCommand.Run(ctx)
+}
+
+// setRemoteScript sets the script to be run on the unit over SSH.
+func (c *debugHooksCommand) setRemoteScript(ctx *cmd.Context, script string) {
+	b64Script := base64.StdEncoding.EncodeToString([]byte(script))
+	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, b64Script)
+	args := []string{fmt.Sprintf(c.decideEntryPoint(ctx), innercmd)}
+	c.provider.setArgs(args)
+}
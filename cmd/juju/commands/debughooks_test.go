@@ -15,6 +15,7 @@ import (
 	goyaml "gopkg.in/yaml.v2"
 
 	jujussh "github.com/juju/juju/network/ssh"
+	unitdebug "github.com/juju/juju/worker/uniter/runner/debug"
 )
 
 var _ = gc.Suite(&DebugHooksSuite{})
@@ -125,6 +126,10 @@ var debugHooksTests = []struct {
 	info:  `invalid hook`,
 	args:  []string{"mysql/0", "invalid-hook"},
 	error: `unit "mysql/0" contains neither hook nor action "invalid-hook", valid actions are [anotherfakeaction fakeaction] and valid hooks are [collect-metrics config-changed install juju-info-relation-broken juju-info-relation-changed juju-info-relation-created juju-info-relation-departed juju-info-relation-joined leader-deposed leader-elected leader-settings-changed meter-status-changed metrics-client-relation-broken metrics-client-relation-changed metrics-client-relation-created metrics-client-relation-departed metrics-client-relation-joined post-series-upgrade pre-series-upgrade remove server-admin-relation-broken server-admin-relation-changed server-admin-relation-created server-admin-relation-departed server-admin-relation-joined server-relation-broken server-relation-changed server-relation-created server-relation-departed server-relation-joined start stop update-status upgrade-charm]`,
+}, {
+	info:  `hooks with replay-last`,
+	args:  []string{"--replay-last", "mysql/0", "install"},
+	error: `hook or action names cannot be specified with --replay-last`,
 }, {
 	info:  `no args at all`,
 	args:  nil,
@@ -192,3 +197,21 @@ func (s *DebugHooksSuite) TestDebugHooksArgFormatting(c *gc.C) {
 		"hooks": []interface{}{"install", "start"},
 	})
 }
+
+func (s *DebugHooksSuite) TestDebugHooksReplayLast(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Skipping on windows for now")
+	}
+	s.setupModel(c)
+	s.setHostChecker(validAddresses("0.public"))
+	ctx, err := cmdtesting.RunCommand(c, newDebugHooksCommand(s.hostChecker),
+		"mysql/0", "--replay-last")
+	c.Assert(err, jc.ErrorIsNil)
+	base64Regex := regexp.MustCompile("echo ([A-Za-z0-9+/]+=*) \\| base64")
+	matches := base64Regex.FindStringSubmatch(cmdtesting.Stdout(ctx))
+	c.Assert(matches, gc.HasLen, 2)
+	scriptContent, err := base64.StdEncoding.DecodeString(matches[1])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(scriptContent), gc.Equals,
+		unitdebug.ClientReplayScript(unitdebug.NewHooksContext("mysql/0")))
+}
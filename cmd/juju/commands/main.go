@@ -340,6 +340,9 @@ func registerCommands(r commandRegistry) {
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil, nil))
 	r.Register(application.NewResolvedCommand())
+	r.Register(application.NewKillHookCommand())
+	r.Register(application.NewShowHookHistoryCommand())
+	r.Register(application.NewShowK8sWorkloadCommand())
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
 	r.Register(newDebugCodeCommand(nil))
@@ -397,11 +400,18 @@ func registerCommands(r commandRegistry) {
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
 	r.Register(machine.NewUpgradeSeriesCommand())
+	r.Register(machine.NewResizeCommand())
+	r.Register(machine.NewMigrateCommand())
+	r.Register(machine.NewRotateKeysCommand())
+	r.Register(machine.NewSuspendCommand())
+	r.Register(machine.NewResumeCommand())
 
 	// Manage model
 	r.Register(model.NewConfigCommand())
 	r.Register(model.NewDefaultsCommand())
 	r.Register(model.NewRetryProvisioningCommand())
+	r.Register(model.NewSuspendCommand())
+	r.Register(model.NewResumeCommand())
 	r.Register(model.NewDestroyCommand())
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
@@ -448,6 +458,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(application.NewAddUnitCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
+	r.Register(application.NewAdoptK8sCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewApplicationGetConstraintsCommand())
@@ -528,6 +539,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(caas.NewUpdateCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewRemoveCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(application.NewScaleApplicationCommand())
+	r.Register(application.NewSetAutoscaleCommand())
 
 	// Manage Application Credential Access
 	r.Register(application.NewTrustCommand())
@@ -561,6 +573,7 @@ func registerCommands(r commandRegistry) {
 	if featureflag.Enabled(feature.CharmHubIntegration) {
 		r.Register(charmhub.NewInfoCommand())
 		r.Register(charmhub.NewFindCommand())
+		r.Register(charmhub.NewSyncCharmsCommand())
 	}
 
 	// Commands registered elsewhere.
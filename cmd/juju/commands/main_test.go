@@ -314,6 +314,7 @@ var commandNames = []string{
 	"add-subnet",
 	"add-unit",
 	"add-user",
+	"adopt-k8s",
 	"agree",
 	"agreements",
 	"attach",
@@ -376,6 +377,7 @@ var commandNames = []string{
 	"import-filesystem",
 	"import-ssh-key",
 	"kill-controller",
+	"kill-hook",
 	"list-actions",
 	"list-agreements",
 	"list-backups",
@@ -405,6 +407,7 @@ var commandNames = []string{
 	"machines",
 	"metrics",
 	"migrate",
+	"migrate-machine",
 	"model-config",
 	"model-default",
 	"model-defaults",
@@ -437,17 +440,22 @@ var commandNames = []string{
 	"remove-unit",
 	"remove-user",
 	"rename-space",
+	"resize-machine",
 	"resolved",
 	"resolve",
 	"resources",
 	"restore-backup",
+	"resume-machine",
+	"resume-model",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
 	"revoke-cloud",
+	"rotate-machine-keys",
 	"run",
 	"scale-application",
 	"scp",
+	"set-autoscale",
 	"set-credential",
 	"set-constraints",
 	"set-default-credential",
@@ -462,6 +470,8 @@ var commandNames = []string{
 	"show-application",
 	"show-backup",
 	"show-cloud",
+	"show-hook-history",
+	"show-k8s-workload",
 	"show-controller",
 	"show-credential",
 	"show-credentials",
@@ -483,6 +493,8 @@ var commandNames = []string{
 	"storage",
 	"storage-pools",
 	"subnets",
+	"suspend-machine",
+	"suspend-model",
 	"suspend-relation",
 	"switch",
 	"sync-agent-binaries",
@@ -519,7 +531,7 @@ var optionalFeatures = []string{
 // These are the commands that are behind the `devFeatures`.
 var commandNamesBehindFlags = set.NewStrings(
 	"run", "show-task", "operations", "list-operations", "show-operation",
-	"info", "find",
+	"info", "find", "sync-charms",
 )
 
 func (s *MainSuite) TestHelpCommands(c *gc.C) {
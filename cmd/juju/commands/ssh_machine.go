@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/api/sshclient"
 	"github.com/juju/juju/apiserver/params"
 	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/network"
 	jujussh "github.com/juju/juju/network/ssh"
 )
@@ -52,6 +53,7 @@ type sshAPIClient interface {
 	AllAddresses(target string) ([]string, error)
 	PublicKeys(target string) ([]string, error)
 	Proxy() (bool, error)
+	ProxyJump(target string) (string, error)
 	Close() error
 }
 
@@ -59,6 +61,10 @@ type resolvedTarget struct {
 	user   string
 	entity string
 	host   string
+
+	// proxyJump holds the "[user@]host" of the jump host through
+	// which the target is reached, if any.
+	proxyJump string
 }
 
 func (t *resolvedTarget) userHost() string {
@@ -220,6 +226,10 @@ func (c *sshMachine) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (
 		if err := c.setProxyCommand(&options); err != nil {
 			return nil, err
 		}
+	} else if proxyJump, err := targetsProxyJump(targets); err != nil {
+		return nil, err
+	} else if proxyJump != "" {
+		options.SetProxyCommand(manual.ProxyJumpCommand(proxyJump)...)
 	}
 
 	return &options, nil
@@ -322,6 +332,20 @@ func (c *sshMachine) generateKnownHosts(targets []*resolvedTarget) (string, erro
 	return c.knownHostsPath, nil
 }
 
+// targetsProxyJump returns the jump host shared by the given targets.
+// A single ssh invocation has only one proxy command, so targets reached
+// through different jump hosts cannot be mixed.
+func targetsProxyJump(targets []*resolvedTarget) (string, error) {
+	var proxyJump string
+	for i, target := range targets {
+		if i > 0 && target.proxyJump != proxyJump {
+			return "", errors.New("targets reached through different jump hosts cannot be mixed")
+		}
+		proxyJump = target.proxyJump
+	}
+	return proxyJump, nil
+}
+
 // proxySSH returns false if both c.proxy and the proxy-ssh model
 // configuration are false -- otherwise it returns true.
 func (c *sshMachine) proxySSH() (bool, error) {
@@ -411,6 +435,18 @@ func (c *sshMachine) resolveTarget(target string) (*resolvedTarget, error) {
 		// internal/private address.
 		logger.Debugf("proxy-ssh enabled so not doing reachability scan")
 		getAddress = c.legacyAddressGetter
+	} else if c.apiClient.BestAPIVersion() >= 3 {
+		proxyJump, err := c.apiClient.ProxyJump(out.entity)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if proxyJump != "" {
+			// The machine's addresses are only reachable from the
+			// jump host, so a reachability scan from here is futile.
+			logger.Debugf("reaching %q through jump host %q", out.entity, proxyJump)
+			out.proxyJump = proxyJump
+			getAddress = c.legacyAddressGetter
+		}
 	}
 
 	return c.resolveWithRetry(*out, getAddress)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2/ssh"
 	gc "gopkg.in/check.v1"
@@ -280,3 +281,36 @@ func (s *SSHMachineSuite) setKeys(c *gc.C, m *state.Machine) {
 	err := s.State.SetSSHHostKeys(m.MachineTag(), keys)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+type sshProxyJumpSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&sshProxyJumpSuite{})
+
+func (s *sshProxyJumpSuite) TestGetSSHOptionsProxyJump(c *gc.C) {
+	m := &sshMachine{noHostKeyChecks: true}
+	options, err := m.getSSHOptions(false,
+		&resolvedTarget{user: "ubuntu", entity: "0", host: "10.0.0.1", proxyJump: "admin@bastion"},
+		&resolvedTarget{user: "ubuntu", entity: "1", host: "10.0.0.2", proxyJump: "admin@bastion"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(options, jc.DeepEquals, expectedProxyJumpOptions("admin@bastion"))
+}
+
+func (s *sshProxyJumpSuite) TestGetSSHOptionsMixedProxyJump(c *gc.C) {
+	m := &sshMachine{noHostKeyChecks: true}
+	_, err := m.getSSHOptions(false,
+		&resolvedTarget{user: "ubuntu", entity: "0", host: "10.0.0.1", proxyJump: "admin@bastion"},
+		&resolvedTarget{user: "ubuntu", entity: "1", host: "10.0.0.2"},
+	)
+	c.Assert(err, gc.ErrorMatches, "targets reached through different jump hosts cannot be mixed")
+}
+
+func expectedProxyJumpOptions(proxyJump string) *ssh.Options {
+	var options ssh.Options
+	options.SetStrictHostKeyChecking(ssh.StrictHostChecksNo)
+	options.SetKnownHostsFile(os.DevNull)
+	options.SetProxyCommand("ssh", "-W", "%h:%p", "--", proxyJump)
+	return &options
+}
@@ -4,6 +4,7 @@
 package common
 
 import (
+	"fmt"
 	"reflect"
 	"time"
 
@@ -36,6 +37,7 @@ type ModelInfo struct {
 	Status         *ModelStatus                `json:"status,omitempty" yaml:"status,omitempty"`
 	Users          map[string]ModelUserInfo    `json:"users,omitempty" yaml:"users,omitempty"`
 	Machines       map[string]ModelMachineInfo `json:"machines,omitempty" yaml:"machines,omitempty"`
+	ResourceQuota  *ModelResourceQuota         `json:"resource-quota,omitempty" yaml:"resource-quota,omitempty"`
 	SLA            string                      `json:"sla,omitempty" yaml:"sla,omitempty"`
 	SLAOwner       string                      `json:"sla-owner,omitempty" yaml:"sla-owner,omitempty"`
 	AgentVersion   string                      `json:"agent-version,omitempty" yaml:"agent-version,omitempty"`
@@ -49,6 +51,19 @@ type ModelMachineInfo struct {
 	Cores uint64 `json:"cores" yaml:"cores"`
 }
 
+// ModelResourceQuota contains the limits on, and usage of, the compute
+// resources of a container model.
+type ModelResourceQuota struct {
+	CPU    *ModelResourceUsage `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory *ModelResourceUsage `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+// ModelResourceUsage contains how much of a limited resource is used.
+type ModelResourceUsage struct {
+	Used  string `json:"used" yaml:"used"`
+	Limit string `json:"limit" yaml:"limit"`
+}
+
 // ModelStatus contains the current status of a model.
 type ModelStatus struct {
 	Current        status.Status `json:"current,omitempty" yaml:"current,omitempty"`
@@ -140,6 +155,9 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 	if len(info.Machines) != 0 {
 		modelInfo.Machines = ModelMachineInfoFromParams(info.Machines)
 	}
+	if info.ResourceQuota != nil {
+		modelInfo.ResourceQuota = ModelResourceQuotaFromParams(info.ResourceQuota)
+	}
 	if info.SLA != nil {
 		modelInfo.SLA = ModelSLAFromParams(info.SLA)
 		modelInfo.SLAOwner = ModelSLAOwnerFromParams(info.SLA)
@@ -175,6 +193,25 @@ func ModelMachineInfoFromParams(machines []params.ModelMachineInfo) map[string]M
 	return output
 }
 
+// ModelResourceQuotaFromParams translates a params.ModelResourceQuota to a
+// ModelResourceQuota, showing only the resources which are limited.
+func ModelResourceQuotaFromParams(quota *params.ModelResourceQuota) *ModelResourceQuota {
+	output := &ModelResourceQuota{}
+	if quota.CPULimit > 0 {
+		output.CPU = &ModelResourceUsage{
+			Used:  fmt.Sprintf("%dm", quota.CPUUsed),
+			Limit: fmt.Sprintf("%dm", quota.CPULimit),
+		}
+	}
+	if quota.MemoryLimit > 0 {
+		output.Memory = &ModelResourceUsage{
+			Used:  fmt.Sprintf("%dMi", quota.MemoryUsed),
+			Limit: fmt.Sprintf("%dMi", quota.MemoryLimit),
+		}
+	}
+	return output
+}
+
 // ModelUserInfoFromParams translates []params.ModelUserInfo to a map of
 // user names to ModelUserInfo.
 func ModelUserInfoFromParams(users []params.ModelUserInfo, now time.Time) map[string]ModelUserInfo {
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/environs/manual/sshprovisioner"
@@ -77,6 +78,11 @@ Manual provisioning is the process of installing Juju on an existing machine
 and bringing it under Juju's management. The Juju controller must be able to
 access the new machine over the network.
 
+If the machine can only be reached over SSH through a jump host, name the
+jump host with the --via option. The jump host is recorded against the
+machine, and 'juju ssh' and 'juju scp' connect through it as well. The jump
+host is not carried over when the model is migrated to another controller.
+
 
 Container creation
 
@@ -122,6 +128,9 @@ Examples:
 	# Allocate a machine to the model via SSH
 	juju add-machine ssh:user@10.10.0.3
 
+	# Allocate a machine to the model via SSH, through a jump host
+	juju add-machine ssh:user@10.10.0.3 --via admin@bastion.example.com
+
 	# Allocate a machine to the model via WinRM
 	juju add-machine winrm:user@10.10.0.3
 
@@ -162,6 +171,9 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// Via is the "[user@]host" of the jump host through which a
+	// machine is manually provisioned over SSH.
+	Via string
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -179,6 +191,7 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Machine constraints that overwrite those available from 'juju get-model-constraints' and provider's defaults")
 	f.Var(disksFlag{&c.Disks}, "disks", "Storage constraints for disks to attach to the machine(s)")
+	f.StringVar(&c.Via, "via", "", "The [<user>@]<host> of an SSH jump host through which to reach an ssh: machine")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -200,6 +213,24 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.Via != "" {
+		if c.Placement == nil || c.Placement.Scope != sshScope {
+			return errors.New("--via can only be used when provisioning a machine via SSH")
+		}
+		if err := validateVia(c.Via); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// validateVia returns an error if via is not a "[user@]host" that can be
+// safely passed to ssh. In particular, a leading "-" would be taken as an
+// option rather than a host.
+func validateVia(via string) error {
+	if err := network.ValidateSSHProxyJump(via); err != nil {
+		return errors.NotValidf("--via %q, expected [<user>@]<host>", via)
+	}
 	return nil
 }
 
@@ -412,6 +443,7 @@ func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Con
 		Stdout:         ctx.Stdout,
 		Stderr:         ctx.Stderr,
 		AuthorizedKeys: authKeys,
+		ProxyJump:      c.Via,
 		UpdateBehavior: &params.UpdateBehavior{
 			EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
 			EnableOSUpgrade:       config.EnableOSUpgrade(),
@@ -71,10 +71,39 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"ssh:user@10.10.0.3"},
 			count:     1,
 			placement: "ssh:user@10.10.0.3",
+		}, {
+			args:      []string{"ssh:user@10.10.0.3", "--via", "admin@bastion"},
+			count:     1,
+			placement: "ssh:user@10.10.0.3",
 		}, {
 			args:      []string{"winrm:user@10.10.0.3"},
 			count:     1,
 			placement: "winrm:user@10.10.0.3",
+		}, {
+			args:        []string{"winrm:user@10.10.0.3", "--via", "admin@bastion"},
+			errorString: "--via can only be used when provisioning a machine via SSH",
+		}, {
+			args:        []string{"--via", "admin@bastion"},
+			errorString: "--via can only be used when provisioning a machine via SSH",
+		}, {
+			args:      []string{"ssh:user@10.10.0.3", "--via", "bastion"},
+			count:     1,
+			placement: "ssh:user@10.10.0.3",
+		}, {
+			args:        []string{"ssh:user@10.10.0.3", "--via", "-oProxyCommand=evil"},
+			errorString: `--via "-oProxyCommand=evil", expected \[<user>@\]<host> not valid`,
+		}, {
+			args:        []string{"ssh:user@10.10.0.3", "--via", "-admin@bastion"},
+			errorString: `--via "-admin@bastion", expected \[<user>@\]<host> not valid`,
+		}, {
+			args:        []string{"ssh:user@10.10.0.3", "--via", "admin@"},
+			errorString: `--via "admin@", expected \[<user>@\]<host> not valid`,
+		}, {
+			args:        []string{"ssh:user@10.10.0.3", "--via", "@bastion"},
+			errorString: `--via "@bastion", expected \[<user>@\]<host> not valid`,
+		}, {
+			args:        []string{"ssh:user@10.10.0.3", "--via", "admin@bastion host"},
+			errorString: `--via "admin@bastion host", expected \[<user>@\]<host> not valid`,
 		}, {
 			args:      []string{"zone=us-east-1a"},
 			count:     1,
@@ -144,6 +173,20 @@ func (s *AddMachineSuite) TestSSHPlacement(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "created machine 42\n")
 }
 
+func (s *AddMachineSuite) TestSSHPlacementVia(c *gc.C) {
+	var provisionArgs manual.ProvisionMachineArgs
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		provisionArgs = args
+		return "42", nil
+	})
+	context, err := s.run(c, "ssh:user@10.1.2.3", "--via", "admin@bastion")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "created machine 42\n")
+	c.Assert(provisionArgs.User, gc.Equals, "user")
+	c.Assert(provisionArgs.Host, gc.Equals, "10.1.2.3")
+	c.Assert(provisionArgs.ProxyJump, gc.Equals, "admin@bastion")
+}
+
 func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
 		return "", errors.New("failed to initialize warp core")
@@ -14,7 +14,8 @@ import (
 )
 
 var (
-	SSHProvisioner = &sshProvisioner
+	SSHProvisioner          = &sshProvisioner
+	ReplaceProvisioningKeys = &replaceProvisioningKeys
 )
 
 type AddCommand struct {
@@ -73,6 +74,50 @@ func NewUpgradeSeriesCommandForTest(upgradeAPI UpgradeMachineSeriesAPI) cmd.Comm
 	return modelcmd.Wrap(command)
 }
 
+type ResizeCommand struct {
+	*resizeCommand
+}
+
+// NewResizeCommandForTest returns a ResizeCommand with the api provided as specified.
+func NewResizeCommandForTest(api ResizeMachineAPI) (cmd.Command, *ResizeCommand) {
+	command := &resizeCommand{api: api}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command), &ResizeCommand{command}
+}
+
+type MigrateCommand struct {
+	*migrateCommand
+}
+
+// NewMigrateCommandForTest returns a MigrateCommand with the api provided as specified.
+func NewMigrateCommandForTest(api MigrateMachineAPI) (cmd.Command, *MigrateCommand) {
+	command := &migrateCommand{api: api}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command), &MigrateCommand{command}
+}
+
+type RotateKeysCommand struct {
+	*rotateKeysCommand
+}
+
+// NewRotateKeysCommandForTest returns a RotateKeysCommand with the apis provided as specified.
+func NewRotateKeysCommandForTest(statusAPI statusAPI, sshAPI ProxyJumpAPI) (cmd.Command, *RotateKeysCommand) {
+	command := &rotateKeysCommand{statusAPI: statusAPI, sshAPI: sshAPI}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command), &RotateKeysCommand{command}
+}
+
+type SuspendCommand struct {
+	*suspendCommand
+}
+
+// NewSuspendCommandForTest returns a SuspendCommand with the api provided as specified.
+func NewSuspendCommandForTest(api SuspendMachineAPI, suspend bool) (cmd.Command, *SuspendCommand) {
+	command := &suspendCommand{api: api, suspend: suspend}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command), &SuspendCommand{command}
+}
+
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
@@ -0,0 +1,130 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/machinemanager"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewMigrateCommand returns a command used to move a machine to another
+// member of the cloud's cluster.
+func NewMigrateCommand() cmd.Command {
+	return modelcmd.Wrap(&migrateCommand{})
+}
+
+// MigrateMachineAPI defines the API methods used by the migrate-machine
+// command.
+type MigrateMachineAPI interface {
+	MigrateMachine(machineId, member string) (string, error)
+	Close() error
+}
+
+// migrateCommand moves an existing machine between cluster members.
+type migrateCommand struct {
+	baseMachinesCommand
+	api MigrateMachineAPI
+
+	MachineId string
+	Member    string
+}
+
+const migrateMachineDoc = `
+Moves the cloud instance of a machine to another member of the cloud's
+cluster. The machine keeps its identity, units and storage; only the host
+that runs it changes, and the machine's availability zone is updated to
+the name of the new member.
+
+Where the cloud supports it, a running instance is moved with a live
+migration. Otherwise the instance is stopped, moved and started again,
+and units on the machine see this as a reboot.
+
+Only LXD clusters currently support this command. To place a new machine
+on a particular member, use the "lxd-member" placement directive.
+
+Examples:
+
+    juju migrate-machine 3 --to-member node3
+    juju add-machine --to lxd-member=node2
+
+See also:
+    add-machine
+    show-machine
+`
+
+// Info implements Command.Info.
+func (c *migrateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "migrate-machine",
+		Args:    "<machine number>",
+		Purpose: "Moves a machine to another member of the cloud's cluster.",
+		Doc:     migrateMachineDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Member, "to-member", "", "The cluster member to move the machine to")
+}
+
+// Init implements Command.Init.
+func (c *migrateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no machine specified")
+	}
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+	id := args[0]
+	if !names.IsValidMachine(id) {
+		return errors.Errorf("invalid machine id %q", id)
+	}
+	if names.IsContainerMachine(id) {
+		return errors.Errorf("cannot migrate container %q", id)
+	}
+	if c.Member == "" {
+		return errors.Errorf("no cluster member specified, use --to-member")
+	}
+	c.MachineId = id
+	return nil
+}
+
+func (c *migrateCommand) getAPI() (MigrateMachineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if root.BestFacadeVersion("MachineManager") < 10 {
+		_ = root.Close()
+		return nil, errors.New("this version of Juju doesn't support migrate-machine")
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *migrateCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	zone, err := client.MigrateMachine(c.MachineId, c.Member)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+	ctx.Infof("migrated machine %s to %s", c.MachineId, zone)
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type MigrateMachineSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeMigrateMachineAPI
+}
+
+var _ = gc.Suite(&MigrateMachineSuite{})
+
+func (s *MigrateMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeMigrateMachineAPI{}
+}
+
+func (s *MigrateMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	migrate, _ := machine.NewMigrateCommandForTest(s.fake)
+	return cmdtesting.RunCommand(c, migrate, args...)
+}
+
+func (s *MigrateMachineSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		machine     string
+		member      string
+		errorString string
+	}{
+		{
+			errorString: "no machine specified",
+		}, {
+			args:        []string{"1"},
+			errorString: "no cluster member specified, use --to-member",
+		}, {
+			args:    []string{"1", "--to-member", "node3"},
+			machine: "1",
+			member:  "node3",
+		}, {
+			args:        []string{"1", "2", "--to-member", "node3"},
+			errorString: `unrecognized args: \["2"\]`,
+		}, {
+			args:        []string{"lxd", "--to-member", "node3"},
+			errorString: `invalid machine id "lxd"`,
+		}, {
+			args:        []string{"1/lxd/2", "--to-member", "node3"},
+			errorString: `cannot migrate container "1/lxd/2"`,
+		},
+	} {
+		c.Logf("test %d", i)
+		wrappedCommand, migrateCmd := machine.NewMigrateCommandForTest(s.fake)
+		err := cmdtesting.InitCommand(wrappedCommand, test.args)
+		if test.errorString != "" {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(migrateCmd.MachineId, gc.Equals, test.machine)
+		c.Check(migrateCmd.Member, gc.Equals, test.member)
+	}
+}
+
+func (s *MigrateMachineSuite) TestMigrate(c *gc.C) {
+	ctx, err := s.run(c, "3", "--to-member", "node3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "migrated machine 3 to node3\n")
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrateMachine", []interface{}{"3", "node3"}},
+		{"Close", nil},
+	})
+}
+
+func (s *MigrateMachineSuite) TestMigrateError(c *gc.C) {
+	s.fake.SetErrors(errors.New("member offline"))
+	_, err := s.run(c, "3", "--to-member", "node3")
+	c.Assert(err, gc.ErrorMatches, "member offline")
+}
+
+func (s *MigrateMachineSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "TestBlockedError"})
+	_, err := s.run(c, "3", "--to-member", "node3")
+	c.Assert(err, gc.ErrorMatches, `(?s)TestBlockedError.*`)
+}
+
+type fakeMigrateMachineAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeMigrateMachineAPI) MigrateMachine(machineId, member string) (string, error) {
+	f.MethodCall(f, "MigrateMachine", machineId, member)
+	return member, f.NextErr()
+}
+
+func (f *fakeMigrateMachineAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
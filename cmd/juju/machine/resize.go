@@ -0,0 +1,132 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/machinemanager"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+)
+
+// NewResizeCommand returns a command used to resize a machine in place.
+func NewResizeCommand() cmd.Command {
+	return modelcmd.Wrap(&resizeCommand{})
+}
+
+// ResizeMachineAPI defines the API methods used by the resize-machine
+// command.
+type ResizeMachineAPI interface {
+	ResizeMachine(machineId string, cons constraints.Value) (*instance.HardwareCharacteristics, error)
+	Close() error
+}
+
+// resizeCommand changes the hardware of an existing machine.
+type resizeCommand struct {
+	baseMachinesCommand
+	api ResizeMachineAPI
+
+	MachineId   string
+	Constraints constraints.Value
+}
+
+const resizeMachineDoc = `
+Resizes the cloud instance of a machine, so that it satisfies the
+machine's constraints overridden by those given. Unlike set-constraints,
+which only affects new machines, this changes the hardware of the
+existing instance; its disks and addresses are kept.
+
+The instance is stopped while it is resized, and then started again.
+Once the resize is done, units on the machine run their config-changed
+hooks, so charms can react to the new hardware.
+
+Only providers that can resize instances support this command,
+and the architecture of a machine cannot be changed.
+
+Examples:
+
+    juju resize-machine 3 --constraints mem=16G
+    juju resize-machine 0 --constraints "cores=8 mem=32G"
+    juju resize-machine 5 --constraints instance-type=m5.2xlarge
+
+See also:
+    set-constraints
+    show-machine
+`
+
+// Info implements Command.Info.
+func (c *resizeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resize-machine",
+		Args:    "<machine number>",
+		Purpose: "Changes the hardware of a machine in place.",
+		Doc:     resizeMachineDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *resizeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(constraints.ConstraintsValue{Target: &c.Constraints}, "constraints", "Constraints for the machine's new hardware")
+}
+
+// Init implements Command.Init.
+func (c *resizeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no machine specified")
+	}
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+	id := args[0]
+	if !names.IsValidMachine(id) {
+		return errors.Errorf("invalid machine id %q", id)
+	}
+	if names.IsContainerMachine(id) {
+		return errors.Errorf("cannot resize container %q", id)
+	}
+	if constraints.IsEmpty(&c.Constraints) {
+		return errors.Errorf("no constraints specified")
+	}
+	c.MachineId = id
+	return nil
+}
+
+func (c *resizeCommand) getAPI() (ResizeMachineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if root.BestFacadeVersion("MachineManager") < 7 {
+		_ = root.Close()
+		return nil, errors.New("this version of Juju doesn't support resize-machine")
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *resizeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	hc, err := client.ResizeMachine(c.MachineId, c.Constraints)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+	ctx.Infof("resized machine %s: %s", c.MachineId, hc)
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/testing"
+)
+
+type ResizeMachineSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeResizeMachineAPI
+}
+
+var _ = gc.Suite(&ResizeMachineSuite{})
+
+func (s *ResizeMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeResizeMachineAPI{}
+}
+
+func (s *ResizeMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	resize, _ := machine.NewResizeCommandForTest(s.fake)
+	return cmdtesting.RunCommand(c, resize, args...)
+}
+
+func (s *ResizeMachineSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		machine     string
+		cons        string
+		errorString string
+	}{
+		{
+			errorString: "no machine specified",
+		}, {
+			args:        []string{"1"},
+			errorString: "no constraints specified",
+		}, {
+			args:    []string{"1", "--constraints", "mem=8G cores=4"},
+			machine: "1",
+			cons:    "mem=8G cores=4",
+		}, {
+			args:        []string{"1", "2", "--constraints", "mem=8G"},
+			errorString: `unrecognized args: \["2"\]`,
+		}, {
+			args:        []string{"lxd", "--constraints", "mem=8G"},
+			errorString: `invalid machine id "lxd"`,
+		}, {
+			args:        []string{"1/lxd/2", "--constraints", "mem=8G"},
+			errorString: `cannot resize container "1/lxd/2"`,
+		},
+	} {
+		c.Logf("test %d", i)
+		wrappedCommand, resizeCmd := machine.NewResizeCommandForTest(s.fake)
+		err := cmdtesting.InitCommand(wrappedCommand, test.args)
+		if test.errorString != "" {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(resizeCmd.MachineId, gc.Equals, test.machine)
+		c.Check(resizeCmd.Constraints, jc.DeepEquals, constraints.MustParse(test.cons))
+	}
+}
+
+func (s *ResizeMachineSuite) TestResize(c *gc.C) {
+	ctx, err := s.run(c, "3", "--constraints", "mem=8G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resized machine 3: mem=8192M\n")
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"ResizeMachine", []interface{}{"3", constraints.MustParse("mem=8G")}},
+		{"Close", nil},
+	})
+}
+
+func (s *ResizeMachineSuite) TestResizeError(c *gc.C) {
+	s.fake.SetErrors(errors.New("no capacity"))
+	_, err := s.run(c, "3", "--constraints", "mem=8G")
+	c.Assert(err, gc.ErrorMatches, "no capacity")
+}
+
+func (s *ResizeMachineSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "TestBlockedError"})
+	_, err := s.run(c, "3", "--constraints", "mem=8G")
+	c.Assert(err, gc.ErrorMatches, `(?s)TestBlockedError.*`)
+}
+
+type fakeResizeMachineAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeResizeMachineAPI) ResizeMachine(machineId string, cons constraints.Value) (*instance.HardwareCharacteristics, error) {
+	f.MethodCall(f, "ResizeMachine", machineId, cons)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return &instance.HardwareCharacteristics{Mem: cons.Mem}, nil
+}
+
+func (f *fakeResizeMachineAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/sshclient"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/environs/manual/sshprovisioner"
+)
+
+var replaceProvisioningKeys = sshprovisioner.ReplaceProvisioningKeys
+
+// NewRotateKeysCommand returns a command used to replace the SSH keys
+// authorised for manual provisioning on manually provisioned machines.
+func NewRotateKeysCommand() cmd.Command {
+	return modelcmd.Wrap(&rotateKeysCommand{})
+}
+
+// ProxyJumpAPI defines the SSH client API methods used by the
+// rotate-machine-keys command.
+type ProxyJumpAPI interface {
+	BestAPIVersion() int
+	ProxyJump(target string) (string, error)
+	Close() error
+}
+
+// rotateKeysCommand replaces the provisioning keys of manual machines.
+type rotateKeysCommand struct {
+	baseMachinesCommand
+	statusAPI statusAPI
+	sshAPI    ProxyJumpAPI
+
+	KeysPath   string
+	MachineIds []string
+}
+
+const rotateMachineKeysDoc = `
+Replaces the SSH public keys that were authorised for the "ubuntu" user
+when machines were manually provisioned with "juju add-machine ssh:...".
+All keys not managed by Juju are removed from the machine and replaced
+with the Juju client's public keys plus those in the file named by --keys
+(by default ~/.ssh/id_rsa.pub and the other standard public key files).
+Keys added with "juju add-ssh-key" are left untouched.
+
+If no machines are given, the keys of all manually provisioned machines in
+the model are replaced. Machines that were provisioned through a jump host
+are reached through it.
+
+Examples:
+
+    juju rotate-machine-keys
+    juju rotate-machine-keys 2 3 --keys ~/.ssh/new_key.pub
+
+See also:
+    add-machine
+    add-ssh-key
+`
+
+// Info implements Command.Info.
+func (c *rotateKeysCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "rotate-machine-keys",
+		Args:    "[<machine number> ...]",
+		Purpose: "Replaces the SSH keys used to provision manual machines.",
+		Doc:     rotateMachineKeysDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *rotateKeysCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.KeysPath, "keys", "", "Path to a file of SSH public keys to authorise")
+}
+
+// Init implements Command.Init.
+func (c *rotateKeysCommand) Init(args []string) error {
+	for _, id := range args {
+		if !names.IsValidMachine(id) {
+			return errors.Errorf("invalid machine id %q", id)
+		}
+		if names.IsContainerMachine(id) {
+			return errors.Errorf("cannot rotate keys of container %q", id)
+		}
+	}
+	c.MachineIds = args
+	return nil
+}
+
+func (c *rotateKeysCommand) getAPIs() (statusAPI, ProxyJumpAPI, error) {
+	if c.statusAPI != nil && c.sshAPI != nil {
+		return c.statusAPI, c.sshAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return root.Client(), sshclient.NewFacade(root), nil
+}
+
+// Run implements Command.Run.
+func (c *rotateKeysCommand) Run(ctx *cmd.Context) error {
+	statusClient, sshClient, err := c.getAPIs()
+	if err != nil {
+		return err
+	}
+	defer statusClient.Close()
+	defer sshClient.Close()
+
+	hosts, err := c.manualMachineHosts(statusClient)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(hosts) == 0 {
+		ctx.Infof("no manually provisioned machines to rotate keys on")
+		return nil
+	}
+	authKeys, err := common.ReadAuthorizedKeys(ctx, c.KeysPath)
+	if err != nil {
+		return errors.Annotate(err, "reading authorized-keys")
+	}
+
+	ids := make([]string, 0, len(hosts))
+	for id := range hosts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		// Jump hosts were introduced with version 3 of the facade,
+		// so older controllers know of none.
+		var proxyJump string
+		if sshClient.BestAPIVersion() >= 3 {
+			if proxyJump, err = sshClient.ProxyJump(id); err != nil {
+				return errors.Annotatef(err, "getting jump host of machine %s", id)
+			}
+		}
+		if err := replaceProvisioningKeys(hosts[id], authKeys, proxyJump); err != nil {
+			return errors.Annotatef(err, "rotating keys on machine %s", id)
+		}
+		ctx.Infof("rotated keys on machine %s", id)
+	}
+	return nil
+}
+
+// manualMachineHosts returns the hosts of the manually provisioned
+// machines to rotate keys on, keyed by machine ID.
+func (c *rotateKeysCommand) manualMachineHosts(client statusAPI) (map[string]string, error) {
+	status, err := client.Status(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	hosts := make(map[string]string)
+	for id, m := range status.Machines {
+		host := strings.TrimPrefix(string(m.InstanceId), manual.ManualInstancePrefix)
+		// The controller of a manual cloud has no host in its
+		// instance ID; its keys are managed when bootstrapping.
+		if host == string(m.InstanceId) || host == "" {
+			continue
+		}
+		hosts[id] = host
+	}
+	if len(c.MachineIds) == 0 {
+		return hosts, nil
+	}
+	selected := make(map[string]string)
+	for _, id := range c.MachineIds {
+		if _, ok := status.Machines[id]; !ok {
+			return nil, errors.NotFoundf("machine %s", id)
+		}
+		host, ok := hosts[id]
+		if !ok {
+			return nil, errors.Errorf("machine %s was not manually provisioned", id)
+		}
+		selected[id] = host
+	}
+	return selected, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type RotateKeysSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	statusAPI *fakeRotateKeysStatusAPI
+	sshAPI    *fakeProxyJumpAPI
+	keysPath  string
+	replaced  jujutesting.Stub
+}
+
+var _ = gc.Suite(&RotateKeysSuite{})
+
+func (s *RotateKeysSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.statusAPI = &fakeRotateKeysStatusAPI{
+		status: &params.FullStatus{
+			Machines: map[string]params.MachineStatus{
+				"0": {InstanceId: "i-0"},
+				"1": {InstanceId: "manual:10.0.0.1"},
+				"2": {InstanceId: "manual:10.0.0.2"},
+			},
+		},
+	}
+	s.sshAPI = &fakeProxyJumpAPI{
+		version:    3,
+		proxyJumps: map[string]string{"2": "admin@bastion"},
+	}
+	s.keysPath = filepath.Join(c.MkDir(), "new_key.pub")
+	err := ioutil.WriteFile(s.keysPath, []byte("ssh-rsa new-key user@host\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.replaced = jujutesting.Stub{}
+	s.PatchValue(machine.ReplaceProvisioningKeys, func(host, authorizedKeys, proxyJump string) error {
+		s.replaced.AddCall("ReplaceProvisioningKeys", host, authorizedKeys, proxyJump)
+		return s.replaced.NextErr()
+	})
+}
+
+func (s *RotateKeysSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	rotate, _ := machine.NewRotateKeysCommandForTest(s.statusAPI, s.sshAPI)
+	return cmdtesting.RunCommand(c, rotate, append(args, "--keys", s.keysPath)...)
+}
+
+func (s *RotateKeysSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		machines    []string
+		errorString string
+	}{
+		{}, {
+			args:     []string{"1", "2"},
+			machines: []string{"1", "2"},
+		}, {
+			args:        []string{"lxd"},
+			errorString: `invalid machine id "lxd"`,
+		}, {
+			args:        []string{"1/lxd/2"},
+			errorString: `cannot rotate keys of container "1/lxd/2"`,
+		},
+	} {
+		c.Logf("test %d", i)
+		wrappedCommand, rotateCmd := machine.NewRotateKeysCommandForTest(s.statusAPI, s.sshAPI)
+		err := cmdtesting.InitCommand(wrappedCommand, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(rotateCmd.MachineIds, jc.DeepEquals, test.machines)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *RotateKeysSuite) TestRotateAllManualMachines(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "rotated keys on machine 1\nrotated keys on machine 2\n")
+	s.replaced.CheckCalls(c, []jujutesting.StubCall{
+		{"ReplaceProvisioningKeys", []interface{}{"10.0.0.1", "ssh-rsa new-key user@host\n", ""}},
+		{"ReplaceProvisioningKeys", []interface{}{"10.0.0.2", "ssh-rsa new-key user@host\n", "admin@bastion"}},
+	})
+	c.Assert(s.statusAPI.closed, jc.IsTrue)
+	c.Assert(s.sshAPI.closed, jc.IsTrue)
+}
+
+func (s *RotateKeysSuite) TestRotateSelectedMachine(c *gc.C) {
+	_, err := s.run(c, "2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.replaced.CheckCalls(c, []jujutesting.StubCall{
+		{"ReplaceProvisioningKeys", []interface{}{"10.0.0.2", "ssh-rsa new-key user@host\n", "admin@bastion"}},
+	})
+}
+
+func (s *RotateKeysSuite) TestRotateOlderController(c *gc.C) {
+	s.sshAPI.version = 2
+	_, err := s.run(c, "2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.replaced.CheckCalls(c, []jujutesting.StubCall{
+		{"ReplaceProvisioningKeys", []interface{}{"10.0.0.2", "ssh-rsa new-key user@host\n", ""}},
+	})
+}
+
+func (s *RotateKeysSuite) TestRotateNotManualMachine(c *gc.C) {
+	_, err := s.run(c, "0")
+	c.Assert(err, gc.ErrorMatches, "machine 0 was not manually provisioned")
+	s.replaced.CheckNoCalls(c)
+}
+
+func (s *RotateKeysSuite) TestRotateMachineNotFound(c *gc.C) {
+	_, err := s.run(c, "42")
+	c.Assert(err, gc.ErrorMatches, "machine 42 not found")
+	s.replaced.CheckNoCalls(c)
+}
+
+func (s *RotateKeysSuite) TestRotateNoManualMachines(c *gc.C) {
+	delete(s.statusAPI.status.Machines, "1")
+	delete(s.statusAPI.status.Machines, "2")
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "no manually provisioned machines to rotate keys on\n")
+	s.replaced.CheckNoCalls(c)
+}
+
+func (s *RotateKeysSuite) TestRotateError(c *gc.C) {
+	s.replaced.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "rotating keys on machine 1: boom")
+}
+
+type fakeRotateKeysStatusAPI struct {
+	status *params.FullStatus
+	closed bool
+}
+
+func (f *fakeRotateKeysStatusAPI) Status(pattern []string) (*params.FullStatus, error) {
+	return f.status, nil
+}
+
+func (f *fakeRotateKeysStatusAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+type fakeProxyJumpAPI struct {
+	version    int
+	proxyJumps map[string]string
+	closed     bool
+}
+
+func (f *fakeProxyJumpAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeProxyJumpAPI) ProxyJump(target string) (string, error) {
+	return f.proxyJumps[target], nil
+}
+
+func (f *fakeProxyJumpAPI) Close() error {
+	f.closed = true
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSuspendCommand returns a command used to power off machines without
+// removing them from the model.
+func NewSuspendCommand() cmd.Command {
+	return modelcmd.Wrap(&suspendCommand{suspend: true})
+}
+
+// NewResumeCommand returns a command used to power suspended machines
+// on again.
+func NewResumeCommand() cmd.Command {
+	return modelcmd.Wrap(&suspendCommand{})
+}
+
+// SuspendMachineAPI defines the API methods used by the suspend-machine
+// and resume-machine commands.
+type SuspendMachineAPI interface {
+	SuspendMachines(machines ...string) ([]params.ErrorResult, error)
+	ResumeMachines(machines ...string) ([]params.ErrorResult, error)
+	Close() error
+}
+
+// suspendCommand suspends or resumes machines.
+type suspendCommand struct {
+	baseMachinesCommand
+	api     SuspendMachineAPI
+	suspend bool
+
+	MachineIds []string
+}
+
+const suspendMachineDoc = `
+Powers off the cloud instances of machines without removing them from
+the model. A suspended machine keeps its instance ID, disks and
+addresses, and has the status "suspended"; its agents are not reported
+as down or lost while it is suspended.
+
+Controller machines, containers and manually provisioned machines
+cannot be suspended, and only providers that can power instances off
+and on support this command.
+
+Examples:
+
+    juju suspend-machine 3
+    juju suspend-machine 1 2
+
+See also:
+    resume-machine
+    remove-machine
+`
+
+const resumeMachineDoc = `
+Powers the cloud instances of suspended machines on again. The machine
+agents report the status "started" once they have restarted.
+
+Examples:
+
+    juju resume-machine 3
+    juju resume-machine 1 2
+
+See also:
+    suspend-machine
+`
+
+// Info implements Command.Info.
+func (c *suspendCommand) Info() *cmd.Info {
+	if c.suspend {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "suspend-machine",
+			Args:    "<machine number> ...",
+			Purpose: "Powers off machines without removing them from the model.",
+			Doc:     suspendMachineDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resume-machine",
+		Args:    "<machine number> ...",
+		Purpose: "Powers suspended machines on again.",
+		Doc:     resumeMachineDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *suspendCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no machines specified")
+	}
+	for _, id := range args {
+		if !names.IsValidMachine(id) {
+			return errors.Errorf("invalid machine id %q", id)
+		}
+		if names.IsContainerMachine(id) {
+			return errors.Errorf("cannot suspend or resume container %q", id)
+		}
+	}
+	c.MachineIds = args
+	return nil
+}
+
+func (c *suspendCommand) getAPI() (SuspendMachineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if root.BestFacadeVersion("MachineManager") < 8 {
+		_ = root.Close()
+		return nil, errors.Errorf("this version of Juju doesn't support %s", c.Info().Name)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *suspendCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var results []params.ErrorResult
+	action := "resuming"
+	if c.suspend {
+		action = "suspending"
+		results, err = client.SuspendMachines(c.MachineIds...)
+	} else {
+		results, err = client.ResumeMachines(c.MachineIds...)
+	}
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+
+	anyFailed := false
+	for i, id := range c.MachineIds {
+		if err := results[i].Error; err != nil {
+			anyFailed = true
+			ctx.Infof("%s machine %s failed: %s", action, id, err)
+			continue
+		}
+		ctx.Infof("%s machine %s", action, id)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type SuspendMachineSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSuspendMachineAPI
+}
+
+var _ = gc.Suite(&SuspendMachineSuite{})
+
+func (s *SuspendMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSuspendMachineAPI{}
+}
+
+func (s *SuspendMachineSuite) run(c *gc.C, suspend bool, args ...string) (*cmd.Context, error) {
+	command, _ := machine.NewSuspendCommandForTest(s.fake, suspend)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *SuspendMachineSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		machines    []string
+		errorString string
+	}{
+		{
+			errorString: "no machines specified",
+		}, {
+			args:     []string{"1"},
+			machines: []string{"1"},
+		}, {
+			args:     []string{"1", "2"},
+			machines: []string{"1", "2"},
+		}, {
+			args:        []string{"1", "lxd"},
+			errorString: `invalid machine id "lxd"`,
+		}, {
+			args:        []string{"1/lxd/2"},
+			errorString: `cannot suspend or resume container "1/lxd/2"`,
+		},
+	} {
+		c.Logf("test %d", i)
+		wrappedCommand, suspendCmd := machine.NewSuspendCommandForTest(s.fake, true)
+		err := cmdtesting.InitCommand(wrappedCommand, test.args)
+		if test.errorString != "" {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(suspendCmd.MachineIds, jc.DeepEquals, test.machines)
+	}
+}
+
+func (s *SuspendMachineSuite) TestSuspend(c *gc.C) {
+	ctx, err := s.run(c, true, "1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "suspending machine 1\nsuspending machine 2\n")
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"SuspendMachines", []interface{}{[]string{"1", "2"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SuspendMachineSuite) TestResume(c *gc.C) {
+	ctx, err := s.run(c, false, "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resuming machine 3\n")
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"ResumeMachines", []interface{}{[]string{"3"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SuspendMachineSuite) TestSuspendFailure(c *gc.C) {
+	s.fake.results = []params.ErrorResult{
+		{Error: &params.Error{Message: "machine 1 is a controller and cannot be suspended"}},
+		{},
+	}
+	ctx, err := s.run(c, true, "1", "2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"suspending machine 1 failed: machine 1 is a controller and cannot be suspended\n"+
+		"suspending machine 2\n")
+}
+
+func (s *SuspendMachineSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "TestBlockedError"})
+	_, err := s.run(c, true, "1")
+	c.Assert(err, gc.ErrorMatches, `(?s)TestBlockedError.*`)
+}
+
+type fakeSuspendMachineAPI struct {
+	jujutesting.Stub
+	results []params.ErrorResult
+}
+
+func (f *fakeSuspendMachineAPI) SuspendMachines(machines ...string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "SuspendMachines", machines)
+	return f.result(machines)
+}
+
+func (f *fakeSuspendMachineAPI) ResumeMachines(machines ...string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "ResumeMachines", machines)
+	return f.result(machines)
+}
+
+func (f *fakeSuspendMachineAPI) result(machines []string) ([]params.ErrorResult, error) {
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	if f.results != nil {
+		return f.results, nil
+	}
+	return make([]params.ErrorResult, len(machines)), nil
+}
+
+func (f *fakeSuspendMachineAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
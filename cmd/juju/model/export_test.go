@@ -44,6 +44,17 @@ func NewRetryProvisioningCommandForTest(api RetryProvisioningAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewSuspendCommandForTest returns a suspend-model or resume-model
+// command with the api provided as specified.
+func NewSuspendCommandForTest(api SuspendModelAPI, suspend bool) cmd.Command {
+	cmd := &suspendCommand{
+		api:     api,
+		suspend: suspend,
+	}
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(cmd)
+}
+
 // NewShowCommandForTest returns a ShowCommand with the api provided as specified.
 func NewShowCommandForTest(api ShowModelAPI, refreshFunc func(jujuclient.ClientStore, string) error, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showModelCommand{api: api}
@@ -542,6 +542,38 @@ func (s *ShowCommandSuite) TestShowBasicWithMachinesIncompleteModelsJson(c *gc.C
 	s.assertShowOutput(c, "json")
 }
 
+func (s *ShowCommandSuite) TestShowBasicWithResourceQuotaYaml(c *gc.C) {
+	basicAndQuotaInfo := createBasicModelInfo()
+	basicAndQuotaInfo.Type = "caas"
+	basicAndQuotaInfo.ResourceQuota = &params.ModelResourceQuota{
+		CPULimit:   4000,
+		CPUUsed:    1500,
+		MemoryUsed: 2048,
+	}
+	s.fake.infos = []params.ModelInfoResult{
+		{Result: basicAndQuotaInfo},
+	}
+	s.expectedDisplay = `
+basic-model:
+  name: owner/basic-model
+  short-name: basic-model
+  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  model-type: caas
+  controller-uuid: deadbeef-1bad-500d-9000-4b1d0d06f00d
+  controller-name: testing
+  is-controller: false
+  owner: owner
+  cloud: altostratus
+  region: mid-level
+  life: dead
+  resource-quota:
+    cpu:
+      used: 1500m
+      limit: 4000m
+`[1:]
+	s.assertShowOutput(c, "yaml")
+}
+
 func (s *ShowCommandSuite) TestShowBasicWithSLAIncompleteModelsYaml(c *gc.C) {
 	basicAndSLAInfo := createBasicModelInfo()
 	basicAndSLAInfo.SLA = &params.ModelSLAInfo{
@@ -0,0 +1,148 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/machinemanager"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSuspendCommand returns a command used to power off all of a
+// model's machines.
+func NewSuspendCommand() cmd.Command {
+	return modelcmd.Wrap(&suspendCommand{suspend: true})
+}
+
+// NewResumeCommand returns a command used to power a suspended model's
+// machines on again.
+func NewResumeCommand() cmd.Command {
+	return modelcmd.Wrap(&suspendCommand{})
+}
+
+// SuspendModelAPI defines the API methods used by the suspend-model and
+// resume-model commands.
+type SuspendModelAPI interface {
+	SuspendModel() error
+	ResumeModel() error
+	Close() error
+}
+
+// suspendCommand requests the suspension or resumption of a model.
+type suspendCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	api     SuspendModelAPI
+	suspend bool
+}
+
+const suspendModelDoc = `
+Powers off the cloud instances of all of the model's machines, without
+removing them from the model. Before a machine is powered off, the units
+on it run their pre-suspend hook so that they can drain cleanly.
+
+Machines are suspended in stages derived from the model's relations: the
+machines of applications that require others are suspended before the
+machines of the applications they require. Controller machines and
+manually provisioned machines are left running.
+
+The model's "suspend-schedule" configuration suspends and resumes the
+model automatically. A schedule is a list of "<days> <start>-<end>"
+windows separated by semicolons, with times in UTC; for example,
+"Mon-Fri 20:00-07:00; Sat,Sun 00:00-24:00". suspend-model and
+resume-model override the schedule until its next change.
+
+Examples:
+
+    juju suspend-model
+    juju suspend-model -m mymodel
+    juju model-config suspend-schedule="Mon-Fri 20:00-07:00"
+
+See also:
+    resume-model
+    suspend-machine
+`
+
+const resumeModelDoc = `
+Powers a suspended model's machines on again. Machines are resumed in the
+reverse of the order in which they were suspended, and the units on each
+run their post-resume hook before the next machines are resumed.
+
+Examples:
+
+    juju resume-model
+    juju resume-model -m mymodel
+
+See also:
+    suspend-model
+    resume-machine
+`
+
+// Info implements Command.Info.
+func (c *suspendCommand) Info() *cmd.Info {
+	if c.suspend {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "suspend-model",
+			Purpose: "Powers off all of a model's machines.",
+			Doc:     suspendModelDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resume-model",
+		Purpose: "Powers a suspended model's machines on again.",
+		Doc:     resumeModelDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *suspendCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *suspendCommand) getAPI() (SuspendModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if root.BestFacadeVersion("MachineManager") < 9 {
+		_ = root.Close()
+		return nil, errors.Errorf("this version of Juju doesn't support %s", c.Info().Name)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *suspendCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	modelName, err := c.ModelIdentifier()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.suspend {
+		err = client.SuspendModel()
+	} else {
+		err = client.ResumeModel()
+	}
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+	if c.suspend {
+		ctx.Infof("suspending model %q", modelName)
+	} else {
+		ctx.Infof("resuming model %q", modelName)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type SuspendModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSuspendModelAPI
+}
+
+var _ = gc.Suite(&SuspendModelSuite{})
+
+func (s *SuspendModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSuspendModelAPI{}
+}
+
+func (s *SuspendModelSuite) run(c *gc.C, suspend bool, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewSuspendCommandForTest(s.fake, suspend), args...)
+}
+
+func (s *SuspendModelSuite) TestInit(c *gc.C) {
+	_, err := s.run(c, true, "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *SuspendModelSuite) TestSuspend(c *gc.C) {
+	ctx, err := s.run(c, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "suspending model \"king/sword\"\n")
+	s.fake.CheckCallNames(c, "SuspendModel", "Close")
+}
+
+func (s *SuspendModelSuite) TestResume(c *gc.C) {
+	ctx, err := s.run(c, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resuming model \"king/sword\"\n")
+	s.fake.CheckCallNames(c, "ResumeModel", "Close")
+}
+
+func (s *SuspendModelSuite) TestSuspendError(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Message: "suspending machines in this cloud not supported"})
+	_, err := s.run(c, true)
+	c.Assert(err, gc.ErrorMatches, "suspending machines in this cloud not supported")
+}
+
+func (s *SuspendModelSuite) TestBlockedError(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "TestBlockedError"})
+	_, err := s.run(c, true)
+	c.Assert(err, gc.ErrorMatches, `(?s)TestBlockedError.*`)
+}
+
+type fakeSuspendModelAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeSuspendModelAPI) SuspendModel() error {
+	f.MethodCall(f, "SuspendModel")
+	return f.NextErr()
+}
+
+func (f *fakeSuspendModelAPI) ResumeModel() error {
+	f.MethodCall(f, "ResumeModel")
+	return f.NextErr()
+}
+
+func (f *fakeSuspendModelAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/apiserverhttp"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
@@ -30,6 +31,7 @@ import (
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
 	"github.com/juju/juju/worker/caasbroker"
+	"github.com/juju/juju/worker/caascredentialrotator"
 	"github.com/juju/juju/worker/caasenvironupgrader"
 	"github.com/juju/juju/worker/caasfirewaller"
 	"github.com/juju/juju/worker/caasmodeloperator"
@@ -54,6 +56,7 @@ import (
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationmaster"
+	"github.com/juju/juju/worker/modelsuspender"
 	"github.com/juju/juju/worker/modelupgrader"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
@@ -417,6 +420,13 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Logger:                       config.LoggingContext.GetLogger("juju.worker.instancepoller"),
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 		}))),
+		modelSuspenderName: ifNotMigrating(ifCredentialValid(modelsuspender.Manifold(modelsuspender.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			ClockName:                    clockName,
+			Logger:                       config.LoggingContext.GetLogger("juju.worker.modelsuspender"),
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+		}))),
 		metricWorkerName: ifNotMigrating(metricworker.Manifold(metricworker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.metricworker"),
@@ -478,6 +488,19 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Logger:                 config.LoggingContext.GetLogger("juju.worker.caas"),
 		})),
 
+		// The credential rotator replaces the model's service account
+		// token with the one in the cluster secret it refers to, so it
+		// must keep running when the credential is invalid.
+		caasCredentialRotatorName: ifNotMigrating(caascredentialrotator.Manifold(caascredentialrotator.ManifoldConfig{
+			APICallerName:    apiCallerName,
+			Clock:            config.Clock,
+			NewFacade:        caascredentialrotator.NewFacade,
+			NewWorker:        caascredentialrotator.NewWorker,
+			RotateCredential: k8sprovider.RotatedCredential,
+			Refreshed:        k8sprovider.OIDCTokensRefreshed,
+			Logger:           config.LoggingContext.GetLogger("juju.worker.caascredentialrotator"),
+		})),
+
 		caasFirewallerName: ifNotMigrating(caasfirewaller.Manifold(
 			caasfirewaller.ManifoldConfig{
 				APICallerName:  apiCallerName,
@@ -656,12 +679,14 @@ const (
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
 	machineUndertakerName    = "machine-undertaker"
+	modelSuspenderName       = "model-suspender"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
 	loggingConfigUpdaterName = "logging-config-updater"
 	instanceMutaterName      = "instance-mutater"
 
 	caasAdmissionName           = "caas-admission"
+	caasCredentialRotatorName   = "caas-credential-rotator"
 	caasFirewallerName          = "caas-firewaller"
 	caasModelOperatorName       = "caas-model-operator"
 	caasOperatorProvisionerName = "caas-operator-provisioner"
@@ -54,6 +54,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
+		"model-suspender",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
 		"model-upgrader",
@@ -86,6 +87,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"caas-broker-tracker",
+		"caas-credential-rotator",
 		"caas-firewaller",
 		"caas-model-operator",
 		"caas-operator-provisioner",
@@ -218,6 +220,16 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 	"caas-broker-tracker": {"agent", "api-caller", "is-responsible-flag"},
 
+	"caas-credential-rotator": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"caas-firewaller": {
 		"agent",
 		"api-caller",
@@ -549,6 +561,20 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"model-suspender": {
+		"agent",
+		"api-caller",
+		"clock",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"model-upgrade-gate": {},
 
 	"model-upgraded-flag": {"model-upgrade-gate"},
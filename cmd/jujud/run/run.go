@@ -50,6 +50,7 @@ type RunCommand struct {
 	relationId            string
 	remoteUnitName        string
 	remoteApplicationName string
+	relationSettings      string
 	operator              bool
 }
 
@@ -108,6 +109,7 @@ func (c *RunCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.remoteApplicationName, "remote-app", "", "run the commands for a specific remote application in a relation context on a unit")
 	f.BoolVar(&c.operator, "operator", false, "run the commands on the operator instead of the workload. Only supported on k8s workload charms")
 	f.BoolVar(&c.forceRemoteUnit, "force-remote-unit", false, "run the commands for a specific relation context, bypassing the remote unit check")
+	f.StringVar(&c.relationSettings, "relation-settings", "", "a YAML file of unit relation settings, keyed on unit name, that the commands see in place of the current ones")
 	f.StringVar(&c.unitName, "u", "-", "explicit unit-name, all other arguments are commands. if -u is passed an empty string, unit-name is inferred from state")
 }
 
@@ -293,6 +295,20 @@ func (c *RunCommand) executeInUnitContext() (*exec.ExecResponse, error) {
 		return nil, errors.Errorf("remote app: %s, provided without a relation", c.remoteApplicationName)
 	}
 
+	var relationSettings map[string]map[string]string
+	if c.relationSettings != "" {
+		if relationId == -1 {
+			return nil, errors.Errorf("relation settings: %s, provided without a relation", c.relationSettings)
+		}
+		data, err := ioutil.ReadFile(c.relationSettings)
+		if err != nil {
+			return nil, errors.Annotate(err, "reading relation settings")
+		}
+		if err := yaml.Unmarshal(data, &relationSettings); err != nil {
+			return nil, errors.Annotate(err, "parsing relation settings")
+		}
+	}
+
 	// juju-run on k8s uses an operator yaml file
 	infoFilePath := filepath.Join(unitDir, caas.OperatorClientInfoFile)
 	infoFileBytes, err := ioutil.ReadFile(infoFilePath)
@@ -327,6 +343,7 @@ func (c *RunCommand) executeInUnitContext() (*exec.ExecResponse, error) {
 		RemoteUnitName:        c.remoteUnitName,
 		RemoteApplicationName: c.remoteApplicationName,
 		ForceRemoteUnit:       c.forceRemoteUnit,
+		RelationSettings:      relationSettings,
 		Operator:              c.operator,
 	}
 	if operatorClientInfo != nil {
@@ -5,6 +5,7 @@ package run
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -323,6 +324,32 @@ func (s *RunTestSuite) TestRunningRemoteUnitNoRelation(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "remote unit: remote/0, provided without a relation")
 }
 
+func (s *RunTestSuite) TestRunningRelationSettings(c *gc.C) {
+	loggo.GetLogger("worker.uniter").SetLogLevel(loggo.TRACE)
+	runner := s.runListenerForAgent(c, "unit-foo-1")
+	settingsFile := filepath.Join(c.MkDir(), "relation-settings.yaml")
+	err := ioutil.WriteFile(settingsFile, []byte("foo/1:\n  key: value\nremote/0:\n  other: thing\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, s.runCommand(), "--relation", "db:1", "--relation-settings", settingsFile, "foo/1", "bar")
+	c.Check(cmd.IsRcPassthroughError(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 42")
+	c.Assert(runner.args.RelationId, gc.Equals, 1)
+	c.Assert(runner.args.RelationSettings, jc.DeepEquals, map[string]map[string]string{
+		"foo/1":    {"key": "value"},
+		"remote/0": {"other": "thing"},
+	})
+}
+
+func (s *RunTestSuite) TestRunningRelationSettingsNoRelation(c *gc.C) {
+	loggo.GetLogger("worker.uniter").SetLogLevel(loggo.TRACE)
+	s.runListenerForAgent(c, "unit-foo-1")
+
+	_, err := cmdtesting.RunCommand(c, s.runCommand(), "--relation-settings", "settings.yaml", "foo/1", "bar")
+	c.Check(cmd.IsRcPassthroughError(err), jc.IsFalse)
+	c.Assert(err, gc.ErrorMatches, "relation settings: settings.yaml, provided without a relation")
+}
+
 func (s *RunTestSuite) TestSkipCheckAndRemoteUnit(c *gc.C) {
 	loggo.GetLogger("worker.uniter").SetLogLevel(loggo.TRACE)
 	s.runListenerForAgent(c, "unit-foo-1")
@@ -372,7 +399,7 @@ func (s *RunTestSuite) TestCheckRelationIdValid(c *gc.C) {
 	}
 }
 
-func (s *RunTestSuite) runListenerForAgent(c *gc.C, agent string) {
+func (s *RunTestSuite) runListenerForAgent(c *gc.C, agent string) *mockRunner {
 	agentDir := filepath.Join(config.DataDir, "agents", agent)
 	err := os.MkdirAll(agentDir, 0755)
 	c.Assert(err, jc.ErrorIsNil)
@@ -386,20 +413,24 @@ func (s *RunTestSuite) runListenerForAgent(c *gc.C, agent string) {
 	}
 	listener, err := uniter.NewRunListener(socket, loggo.GetLogger("test"))
 	c.Assert(err, jc.ErrorIsNil)
-	listener.RegisterRunner("foo/1", &mockRunner{c})
+	runner := &mockRunner{c: c}
+	listener.RegisterRunner("foo/1", runner)
 	s.AddCleanup(func(*gc.C) {
 		c.Assert(listener.Close(), jc.ErrorIsNil)
 	})
+	return runner
 }
 
 type mockRunner struct {
-	c *gc.C
+	c    *gc.C
+	args uniter.RunCommandsArgs
 }
 
 var _ uniter.CommandRunner = (*mockRunner)(nil)
 
 func (r *mockRunner) RunCommands(args uniter.RunCommandsArgs) (results *exec.ExecResponse, err error) {
 	r.c.Log("mock runner: " + args.Commands)
+	r.args = args
 	return &exec.ExecResponse{
 		Code:   42,
 		Stdout: []byte(args.Commands + " stdout"),
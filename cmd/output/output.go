@@ -107,6 +107,7 @@ var statusColors = map[status.Status]*ansiterm.Context{
 	status.Pending:     WarningHighlight,
 	status.Rebooting:   WarningHighlight,
 	status.Stopped:     WarningHighlight,
+	status.Suspended:   WarningHighlight,
 	status.Unknown:     WarningHighlight,
 	status.Detaching:   WarningHighlight,
 	status.Detached:    WarningHighlight,
@@ -3,6 +3,11 @@
 
 package lxd
 
+import (
+	"github.com/juju/errors"
+	"github.com/lxc/lxd/shared/api"
+)
+
 func (s *Server) ClusterSupported() bool {
 	return s.clusterAPISupport
 }
@@ -14,3 +19,59 @@ func (s Server) UseTargetServer(name string) (*Server, error) {
 	logger.Debugf("creating LXD server for cluster node %q", name)
 	return NewServer(s.UseTarget(name))
 }
+
+// MoveContainer moves the container with the input name to the cluster
+// member with the input name.
+// A running container is first migrated live. If LXD cannot do that,
+// for example because CRIU is not available, the container is stopped,
+// moved and started again on its new member.
+func (s *Server) MoveContainer(name, member string) error {
+	if !s.clustered {
+		return errors.NotSupportedf("moving containers on a server that is not clustered")
+	}
+	state, _, err := s.GetInstanceState(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	running := state.StatusCode == api.Running
+
+	if running {
+		err := s.migrateContainer(name, member, true)
+		if err == nil {
+			return nil
+		}
+		logger.Infof("cannot migrate container %q live, moving it stopped: %v", name, err)
+		if err := s.StopContainer(name); err != nil {
+			return errors.Annotatef(err, "stopping container %q", name)
+		}
+	}
+
+	if err := s.migrateContainer(name, member, false); err != nil {
+		if running {
+			// Don't leave the container stopped where it was.
+			if startErr := s.StartContainer(name); startErr != nil {
+				logger.Errorf("cannot restart container %q: %v", name, startErr)
+			}
+		}
+		return errors.Annotatef(err, "moving container %q to %q", name, member)
+	}
+	if running {
+		return errors.Annotatef(s.StartContainer(name), "starting container %q", name)
+	}
+	return nil
+}
+
+// migrateContainer asks the cluster to move the container with the input
+// name to the input member.
+func (s *Server) migrateContainer(name, member string, live bool) error {
+	req := api.InstancePost{
+		Name:      name,
+		Migration: true,
+		Live:      live,
+	}
+	op, err := s.UseTarget(member).MigrateInstance(name, req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(op.Wait())
+}
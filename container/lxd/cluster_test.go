@@ -5,12 +5,13 @@ package lxd_test
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/container/lxd"
 	lxdtesting "github.com/juju/juju/container/lxd/testing"
-	"github.com/pkg/errors"
 )
 
 type clusterSuite struct {
@@ -51,3 +52,98 @@ func (s *imageSuite) TestUseTargetBadNode(c *gc.C) {
 	_, err = jujuSvr.UseTargetServer("cluster-2")
 	c.Assert(err, gc.ErrorMatches, "not a cluster member")
 }
+
+func (s *clusterSuite) TestMoveContainerLive(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	c1Svr := s.NewMockServerClustered(ctrl, "cluster-1")
+	c2Svr := lxdtesting.NewMockContainerServer(ctrl)
+
+	migrateOp := lxdtesting.NewMockOperation(ctrl)
+	migrateOp.EXPECT().Wait().Return(nil)
+
+	req := api.InstancePost{Name: "c1", Migration: true, Live: true}
+	gomock.InOrder(
+		c1Svr.EXPECT().GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Running}, lxdtesting.ETag, nil),
+		c1Svr.EXPECT().UseTarget("cluster-2").Return(c2Svr),
+		c2Svr.EXPECT().MigrateInstance("c1", req).Return(migrateOp, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(c1Svr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.MoveContainer("c1", "cluster-2")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clusterSuite) TestMoveContainerStopped(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	c1Svr := s.NewMockServerClustered(ctrl, "cluster-1")
+	c2Svr := lxdtesting.NewMockContainerServer(ctrl)
+
+	stopReq := api.InstanceStatePut{Action: "stop", Timeout: -1}
+	startReq := api.InstanceStatePut{Action: "start", Timeout: -1}
+	stopOp := lxdtesting.NewMockOperation(ctrl)
+	stopOp.EXPECT().Wait().Return(nil)
+	migrateOp := lxdtesting.NewMockOperation(ctrl)
+	migrateOp.EXPECT().Wait().Return(nil)
+	startOp := lxdtesting.NewMockOperation(ctrl)
+	startOp.EXPECT().Wait().Return(nil)
+
+	// Live migration is refused, so the container is moved stopped.
+	gomock.InOrder(
+		c1Svr.EXPECT().GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Running}, lxdtesting.ETag, nil),
+		c1Svr.EXPECT().UseTarget("cluster-2").Return(c2Svr),
+		c2Svr.EXPECT().MigrateInstance("c1", api.InstancePost{Name: "c1", Migration: true, Live: true}).Return(
+			nil, errors.New("CRIU not available")),
+		c1Svr.EXPECT().UpdateInstanceState("c1", stopReq, "").Return(stopOp, nil),
+		c1Svr.EXPECT().UseTarget("cluster-2").Return(c2Svr),
+		c2Svr.EXPECT().MigrateInstance("c1", api.InstancePost{Name: "c1", Migration: true}).Return(migrateOp, nil),
+		c1Svr.EXPECT().UpdateInstanceState("c1", startReq, "").Return(startOp, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(c1Svr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.MoveContainer("c1", "cluster-2")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clusterSuite) TestMoveContainerFailedRestarts(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	c1Svr := s.NewMockServerClustered(ctrl, "cluster-1")
+	c2Svr := lxdtesting.NewMockContainerServer(ctrl)
+
+	stopOp := lxdtesting.NewMockOperation(ctrl)
+	stopOp.EXPECT().Wait().Return(nil)
+	startOp := lxdtesting.NewMockOperation(ctrl)
+	startOp.EXPECT().Wait().Return(nil)
+
+	c1Svr.EXPECT().GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Running}, lxdtesting.ETag, nil)
+	c1Svr.EXPECT().UseTarget("cluster-2").Return(c2Svr).Times(2)
+	c2Svr.EXPECT().MigrateInstance("c1", gomock.Any()).Return(nil, errors.New("no space left")).Times(2)
+	c1Svr.EXPECT().UpdateInstanceState("c1", api.InstanceStatePut{Action: "stop", Timeout: -1}, "").Return(stopOp, nil)
+	c1Svr.EXPECT().UpdateInstanceState("c1", api.InstanceStatePut{Action: "start", Timeout: -1}, "").Return(startOp, nil)
+
+	jujuSvr, err := lxd.NewServer(c1Svr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.MoveContainer("c1", "cluster-2")
+	c.Assert(err, gc.ErrorMatches, `moving container "c1" to "cluster-2": no space left`)
+}
+
+func (s *clusterSuite) TestMoveContainerNotClustered(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	jujuSvr, err := lxd.NewServer(s.NewMockServer(ctrl))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.MoveContainer("c1", "cluster-2")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/lxc/lxd/shared/version"
 
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/network"
 )
@@ -38,6 +39,10 @@ type ContainerSpec struct {
 	Config       map[string]string
 	Profiles     []string
 	InstanceType string
+
+	// VirtType is the type of LXD instance to create.
+	// If empty, a container is created.
+	VirtType instance.VirtType
 }
 
 // minMiBVersion is the minimum LXD version that we are sure will recognise the
@@ -49,10 +54,30 @@ var minMiBVersion = &version.DottedVersion{Major: 3, Minor: 10}
 // Note that we pass these through as supplied. If an instance type constraint
 // has been specified along with specific cores/mem constraints,
 // LXD behaviour is to override with the specific ones even when lower.
+// A root-disk constraint adds a root disk device of that size; the storage
+// pool is filled in from the instance's profiles when it is created.
 func (c *ContainerSpec) ApplyConstraints(serverVersion string, cons constraints.Value) {
 	if cons.HasInstanceType() {
 		c.InstanceType = *cons.InstanceType
 	}
+	if cons.HasVirtType() {
+		virtType, err := instance.ParseVirtType(*cons.VirtType)
+		if err != nil {
+			logger.Errorf("ignoring virt-type constraint: %v", err)
+		} else {
+			c.VirtType = virtType
+		}
+	}
+	if cons.HasRootDisk() {
+		if c.Devices == nil {
+			c.Devices = make(map[string]device)
+		}
+		c.Devices[rootDiskDevice] = device{
+			"type": "disk",
+			"path": "/",
+			"size": fmt.Sprintf("%dMiB", *cons.RootDisk),
+		}
+	}
 	if cons.HasCpuCores() {
 		c.Config["limits.cpu"] = fmt.Sprintf("%d", *cons.CpuCores)
 	}
@@ -68,9 +93,18 @@ func (c *ContainerSpec) ApplyConstraints(serverVersion string, cons constraints.
 	}
 }
 
-// Container extends the upstream LXD container type.
+// rootDiskDevice is the name of the device holding an instance's root
+// file system.
+const rootDiskDevice = "root"
+
+// configDriveDevice is the name of the device used to present cloud-init
+// data to virtual machines, which cannot read it from the instance config.
+const configDriveDevice = "config"
+
+// Container extends the upstream LXD instance type.
+// Despite the name, it represents both containers and virtual machines.
 type Container struct {
-	api.Container
+	api.Instance
 }
 
 // Metadata returns the value from container config for the input key.
@@ -84,6 +118,32 @@ func (c *Container) Arch() string {
 	return arch.NormaliseArch(c.Architecture)
 }
 
+// VirtType returns the type of the LXD instance.
+// Servers without virtual machine support do not report a type,
+// in which case the instance is a container.
+func (c *Container) VirtType() instance.VirtType {
+	if c.Type == "" {
+		return instance.DefaultInstanceType
+	}
+	return instance.VirtType(c.Type)
+}
+
+// RootDisk returns the size in MiB of the container's root disk device,
+// if one with a size is configured directly on the instance.
+// If unset, zero is returned.
+func (c *Container) RootDisk() uint64 {
+	v := c.Devices[rootDiskDevice]["size"]
+	if v == "" {
+		return 0
+	}
+	bytes, err := units.ParseByteSizeString(v)
+	if err != nil {
+		logger.Errorf("failed to parse %q into bytes, ignoring err: %s", v, err)
+		return 0
+	}
+	return uint64(bytes) / (1024 * 1024)
+}
+
 // CPUs returns the configured limit for number of container CPU cores.
 // If unset, zero is returned.
 func (c *Container) CPUs() uint {
@@ -164,7 +224,7 @@ func (s *Server) AliveContainers(prefix string) ([]Container, error) {
 // FilterContainers retrieves the list of containers from the server and filters
 // them based on the input namespace prefix and any supplied statuses.
 func (s *Server) FilterContainers(prefix string, statuses ...string) ([]Container, error) {
-	containers, err := s.GetContainers()
+	containers, err := s.GetInstances(api.InstanceTypeAny)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -185,7 +245,7 @@ func (s *Server) FilterContainers(prefix string, statuses ...string) ([]Containe
 // ContainerAddresses gets usable network addresses for the container
 // identified by the input name.
 func (s *Server) ContainerAddresses(name string) ([]corenetwork.ProviderAddress, error) {
-	state, _, err := s.GetContainerState(name)
+	state, _, err := s.GetInstanceState(name)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -217,19 +277,29 @@ func (s *Server) ContainerAddresses(name string) ([]corenetwork.ProviderAddress,
 // If the container fails to be started, it is removed.
 // Upon successful creation and start, the container is returned.
 func (s *Server) CreateContainerFromSpec(spec ContainerSpec) (*Container, error) {
-	logger.Infof("starting new container %q (image %q)", spec.Name, spec.Image.Image.Filename)
+	virtType := spec.VirtType
+	if virtType == "" {
+		virtType = instance.DefaultInstanceType
+	}
+	logger.Infof("starting new %s %q (image %q)", virtType, spec.Name, spec.Image.Image.Filename)
 	logger.Debugf("new container has profiles %v", spec.Profiles)
-	req := api.ContainersPost{
+
+	devices, err := s.instanceDevices(spec, virtType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req := api.InstancesPost{
 		Name:         spec.Name,
 		InstanceType: spec.InstanceType,
-		ContainerPut: api.ContainerPut{
+		Type:         api.InstanceType(virtType),
+		InstancePut: api.InstancePut{
 			Profiles:  spec.Profiles,
-			Devices:   spec.Devices,
+			Devices:   devices,
 			Config:    spec.Config,
 			Ephemeral: false,
 		},
 	}
-	op, err := s.CreateContainerFromImage(spec.Image.LXDServer, *spec.Image.Image, req)
+	op, err := s.CreateInstanceFromImage(spec.Image.LXDServer, *spec.Image.Image, req)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -254,7 +324,7 @@ func (s *Server) CreateContainerFromSpec(spec ContainerSpec) (*Container, error)
 		return nil, errors.Trace(err)
 	}
 
-	container, _, err := s.GetContainer(spec.Name)
+	container, _, err := s.GetInstance(spec.Name)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -262,15 +332,92 @@ func (s *Server) CreateContainerFromSpec(spec ContainerSpec) (*Container, error)
 	return &c, nil
 }
 
+// instanceDevices returns the devices with which to create the instance
+// described by the input spec.
+// Virtual machines are given a config drive from which cloud-init reads
+// its data, and a root disk device sized by constraint is placed in the
+// storage pool of the root disk from the instance's profiles.
+func (s *Server) instanceDevices(spec ContainerSpec, virtType instance.VirtType) (map[string]device, error) {
+	devices := make(map[string]device, len(spec.Devices)+1)
+	for name, dev := range spec.Devices {
+		devices[name] = dev
+	}
+	if virtType == instance.InstanceTypeVM {
+		if _, ok := devices[configDriveDevice]; !ok {
+			devices[configDriveDevice] = device{
+				"type":   "disk",
+				"source": "cloud-init:config",
+			}
+		}
+	}
+
+	root, ok := devices[rootDiskDevice]
+	if !ok || root["pool"] != "" {
+		return devices, nil
+	}
+	pool, err := s.profilesRootDiskPool(spec.Profiles)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if pool == "" {
+		return nil, errors.Errorf("no storage pool found for root disk of %q", spec.Name)
+	}
+	withPool := make(device, len(root)+1)
+	for k, v := range root {
+		withPool[k] = v
+	}
+	withPool["pool"] = pool
+	devices[rootDiskDevice] = withPool
+	return devices, nil
+}
+
+// profilesRootDiskPool returns the storage pool of the root disk device
+// defined by the input profiles. As with LXD, later profiles take
+// precedence over earlier ones, and no profiles means the default one.
+func (s *Server) profilesRootDiskPool(profiles []string) (string, error) {
+	if len(profiles) == 0 {
+		profiles = []string{"default"}
+	}
+	for i := len(profiles) - 1; i >= 0; i-- {
+		profile, _, err := s.GetProfile(profiles[i])
+		if err != nil {
+			return "", errors.Annotatef(err, "getting profile %q", profiles[i])
+		}
+		for _, dev := range profile.Devices {
+			if dev["type"] == "disk" && dev["path"] == "/" && dev["pool"] != "" {
+				return dev["pool"], nil
+			}
+		}
+	}
+	return "", nil
+}
+
 // StartContainer starts the extant container identified by the input name.
 func (s *Server) StartContainer(name string) error {
-	req := api.ContainerStatePut{
+	req := api.InstanceStatePut{
 		Action:   "start",
 		Timeout:  -1,
 		Force:    false,
 		Stateful: false,
 	}
-	op, err := s.UpdateContainerState(name, req, "")
+	op, err := s.UpdateInstanceState(name, req, "")
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	return errors.Trace(op.Wait())
+}
+
+// StopContainer stops the extant container identified by the input name,
+// allowing it to shut down cleanly.
+func (s *Server) StopContainer(name string) error {
+	req := api.InstanceStatePut{
+		Action:   "stop",
+		Timeout:  -1,
+		Force:    false,
+		Stateful: false,
+	}
+	op, err := s.UpdateInstanceState(name, req, "")
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -301,19 +448,19 @@ func (s *Server) RemoveContainers(names []string) error {
 // Remove container first ensures that the container is stopped,
 // then deletes it.
 func (s *Server) RemoveContainer(name string) error {
-	state, eTag, err := s.GetContainerState(name)
+	state, eTag, err := s.GetInstanceState(name)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
 	if state.StatusCode != api.Stopped {
-		req := api.ContainerStatePut{
+		req := api.InstanceStatePut{
 			Action:   "stop",
 			Timeout:  -1,
 			Force:    true,
 			Stateful: false,
 		}
-		op, err := s.UpdateContainerState(name, req, eTag)
+		op, err := s.UpdateInstanceState(name, req, eTag)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -339,7 +486,7 @@ func (s *Server) RemoveContainer(name string) error {
 			return errors.IsBadRequest(err)
 		},
 		Func: func() error {
-			op, err := s.DeleteContainer(name)
+			op, err := s.DeleteInstance(name)
 			if err != nil {
 				// sigh, LXD not found container - it's been deleted so, we
 				// just need to return nil.
@@ -362,7 +509,7 @@ func (s *Server) RemoveContainer(name string) error {
 // WriteContainer writes the current representation of the input container to
 // the server.
 func (s *Server) WriteContainer(c *Container) error {
-	resp, err := s.UpdateContainer(c.Name, c.Writable(), "")
+	resp, err := s.UpdateInstance(c.Name, c.Writable(), "")
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -381,7 +528,7 @@ func (s *Server) Clock() clock.Clock {
 
 // containerHasStatus returns true if the input container has a status
 // matching one from the input list.
-func containerHasStatus(container api.Container, statuses []string) bool {
+func containerHasStatus(container api.Instance, statuses []string) bool {
 	for _, status := range statuses {
 		if container.StatusCode.String() == status {
 			return true
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/container/lxd/mocks"
 	lxdtesting "github.com/juju/juju/container/lxd/testing"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/network"
@@ -58,6 +59,24 @@ func (s *containerSuite) TestContainerMem(c *gc.C) {
 	c.Check(int(container.Mem()), gc.Equals, 2048)
 }
 
+func (s *containerSuite) TestContainerVirtType(c *gc.C) {
+	container := lxd.Container{}
+	c.Check(container.VirtType(), gc.Equals, instance.InstanceTypeContainer)
+
+	container.Type = "virtual-machine"
+	c.Check(container.VirtType(), gc.Equals, instance.InstanceTypeVM)
+}
+
+func (s *containerSuite) TestContainerRootDisk(c *gc.C) {
+	container := lxd.Container{}
+	c.Check(container.RootDisk(), gc.Equals, uint64(0))
+
+	container.Devices = map[string]map[string]string{
+		"root": {"type": "disk", "path": "/", "size": "20GiB"},
+	}
+	c.Check(container.RootDisk(), gc.Equals, uint64(20480))
+}
+
 func (s *containerSuite) TestContainerAddDiskNoDevices(c *gc.C) {
 	container := lxd.Container{}
 	err := container.AddDisk("root", "/", "source", "default", true)
@@ -86,7 +105,7 @@ func (s *containerSuite) TestFilterContainers(c *gc.C) {
 	defer ctrl.Finish()
 	cSvr := s.NewMockServer(ctrl)
 
-	matching := []api.Container{
+	matching := []api.Instance{
 		{
 			Name:       "prefix-c1",
 			StatusCode: api.Starting,
@@ -96,7 +115,7 @@ func (s *containerSuite) TestFilterContainers(c *gc.C) {
 			StatusCode: api.Stopped,
 		},
 	}
-	ret := append(matching, []api.Container{
+	ret := append(matching, []api.Instance{
 		{
 			Name:       "prefix-c3",
 			StatusCode: api.Started,
@@ -106,7 +125,7 @@ func (s *containerSuite) TestFilterContainers(c *gc.C) {
 			StatusCode: api.Stopped,
 		},
 	}...)
-	cSvr.EXPECT().GetContainers().Return(ret, nil)
+	cSvr.EXPECT().GetInstances(api.InstanceTypeAny).Return(ret, nil)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -127,7 +146,7 @@ func (s *containerSuite) TestAliveContainers(c *gc.C) {
 	defer ctrl.Finish()
 	cSvr := s.NewMockServer(ctrl)
 
-	matching := []api.Container{
+	matching := []api.Instance{
 		{
 			Name:       "c1",
 			StatusCode: api.Starting,
@@ -141,11 +160,11 @@ func (s *containerSuite) TestAliveContainers(c *gc.C) {
 			StatusCode: api.Running,
 		},
 	}
-	ret := append(matching, api.Container{
+	ret := append(matching, api.Instance{
 		Name:       "c4",
 		StatusCode: api.Frozen,
 	})
-	cSvr.EXPECT().GetContainers().Return(ret, nil)
+	cSvr.EXPECT().GetInstances(api.InstanceTypeAny).Return(ret, nil)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -165,10 +184,10 @@ func (s *containerSuite) TestContainerAddresses(c *gc.C) {
 	defer ctrl.Finish()
 	cSvr := s.NewMockServer(ctrl)
 
-	state := api.ContainerState{
-		Network: map[string]api.ContainerStateNetwork{
+	state := api.InstanceState{
+		Network: map[string]api.InstanceStateNetwork{
 			"eth0": {
-				Addresses: []api.ContainerStateNetworkAddress{
+				Addresses: []api.InstanceStateNetworkAddress{
 					{
 						Family:  "inet",
 						Address: "10.0.8.173",
@@ -184,7 +203,7 @@ func (s *containerSuite) TestContainerAddresses(c *gc.C) {
 				},
 			},
 			"lo": {
-				Addresses: []api.ContainerStateNetworkAddress{
+				Addresses: []api.InstanceStateNetworkAddress{
 					{
 						Family:  "inet",
 						Address: "127.0.0.1",
@@ -200,7 +219,7 @@ func (s *containerSuite) TestContainerAddresses(c *gc.C) {
 				},
 			},
 			"lxcbr0": {
-				Addresses: []api.ContainerStateNetworkAddress{
+				Addresses: []api.InstanceStateNetworkAddress{
 					{
 						Family:  "inet",
 						Address: "10.0.5.12",
@@ -216,7 +235,7 @@ func (s *containerSuite) TestContainerAddresses(c *gc.C) {
 				},
 			},
 			"lxdbr0": {
-				Addresses: []api.ContainerStateNetworkAddress{
+				Addresses: []api.InstanceStateNetworkAddress{
 					{
 						Family:  "inet",
 						Address: "10.0.6.17",
@@ -233,7 +252,7 @@ func (s *containerSuite) TestContainerAddresses(c *gc.C) {
 			},
 		},
 	}
-	cSvr.EXPECT().GetContainerState("c1").Return(&state, lxdtesting.ETag, nil)
+	cSvr.EXPECT().GetInstanceState("c1").Return(&state, lxdtesting.ETag, nil)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -281,9 +300,10 @@ func (s *containerSuite) TestCreateContainerFromSpecSuccess(c *gc.C) {
 		},
 	}
 
-	createReq := api.ContainersPost{
+	createReq := api.InstancesPost{
 		Name: spec.Name,
-		ContainerPut: api.ContainerPut{
+		Type: api.InstanceTypeContainer,
+		InstancePut: api.InstancePut{
 			Profiles:  spec.Profiles,
 			Devices:   spec.Devices,
 			Config:    spec.Config,
@@ -291,7 +311,7 @@ func (s *containerSuite) TestCreateContainerFromSpecSuccess(c *gc.C) {
 		},
 	}
 
-	startReq := api.ContainerStatePut{
+	startReq := api.InstanceStatePut{
 		Action:   "start",
 		Timeout:  -1,
 		Force:    false,
@@ -301,9 +321,9 @@ func (s *containerSuite) TestCreateContainerFromSpecSuccess(c *gc.C) {
 	// Container created, started and returned.
 	exp := cSvr.EXPECT()
 	gomock.InOrder(
-		exp.CreateContainerFromImage(cSvr, image, createReq).Return(createOp, nil),
-		exp.UpdateContainerState(spec.Name, startReq, "").Return(startOp, nil),
-		exp.GetContainer(spec.Name).Return(&api.Container{}, lxdtesting.ETag, nil),
+		exp.CreateInstanceFromImage(cSvr, image, createReq).Return(createOp, nil),
+		exp.UpdateInstanceState(spec.Name, startReq, "").Return(startOp, nil),
+		exp.GetInstance(spec.Name).Return(&api.Instance{}, lxdtesting.ETag, nil),
 	)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
@@ -314,6 +334,83 @@ func (s *containerSuite) TestCreateContainerFromSpecSuccess(c *gc.C) {
 	c.Check(container, gc.NotNil)
 }
 
+func (s *containerSuite) TestCreateContainerFromSpecVirtualMachine(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	// Operation arrangements.
+	createOp := lxdtesting.NewMockRemoteOperation(ctrl)
+	createOp.EXPECT().Wait().Return(nil)
+	createOp.EXPECT().GetTarget().Return(&api.Operation{StatusCode: api.Success}, nil)
+
+	startOp := lxdtesting.NewMockOperation(ctrl)
+	startOp.EXPECT().Wait().Return(nil)
+
+	// Request data.
+	image := api.Image{Filename: "vm-image", Type: "virtual-machine"}
+	spec := lxd.ContainerSpec{
+		Name: "c1",
+		Image: lxd.SourcedImage{
+			Image:     &image,
+			LXDServer: cSvr,
+		},
+		Profiles: []string{"default", "juju-model"},
+		Config:   map[string]string{},
+	}
+	spec.ApplyConstraints("4.0.0", constraints.MustParse("virt-type=virtual-machine root-disk=20G"))
+
+	createReq := api.InstancesPost{
+		Name: spec.Name,
+		Type: api.InstanceTypeVM,
+		InstancePut: api.InstancePut{
+			Profiles: spec.Profiles,
+			Devices: map[string]map[string]string{
+				"config": {
+					"type":   "disk",
+					"source": "cloud-init:config",
+				},
+				"root": {
+					"type": "disk",
+					"path": "/",
+					"pool": "fast",
+					"size": "20480MiB",
+				},
+			},
+			Config:    spec.Config,
+			Ephemeral: false,
+		},
+	}
+
+	startReq := api.InstanceStatePut{
+		Action:   "start",
+		Timeout:  -1,
+		Force:    false,
+		Stateful: false,
+	}
+
+	// The root disk pool comes from the last profile that has one.
+	exp := cSvr.EXPECT()
+	gomock.InOrder(
+		exp.GetProfile("juju-model").Return(&api.Profile{}, lxdtesting.ETag, nil),
+		exp.GetProfile("default").Return(&api.Profile{ProfilePut: api.ProfilePut{
+			Devices: map[string]map[string]string{
+				"root": {"type": "disk", "path": "/", "pool": "fast"},
+			},
+		}}, lxdtesting.ETag, nil),
+		exp.CreateInstanceFromImage(cSvr, image, createReq).Return(createOp, nil),
+		exp.UpdateInstanceState(spec.Name, startReq, "").Return(startOp, nil),
+		exp.GetInstance(spec.Name).Return(&api.Instance{Type: "virtual-machine"}, lxdtesting.ETag, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	container, err := jujuSvr.CreateContainerFromSpec(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(container.VirtType(), gc.Equals, instance.InstanceTypeVM)
+}
+
 func (s *containerSuite) TestCreateContainerFromSpecStartFailed(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -348,9 +445,10 @@ func (s *containerSuite) TestCreateContainerFromSpecStartFailed(c *gc.C) {
 		},
 	}
 
-	createReq := api.ContainersPost{
+	createReq := api.InstancesPost{
 		Name: spec.Name,
-		ContainerPut: api.ContainerPut{
+		Type: api.InstanceTypeContainer,
+		InstancePut: api.InstancePut{
 			Profiles:  spec.Profiles,
 			Devices:   spec.Devices,
 			Config:    spec.Config,
@@ -358,7 +456,7 @@ func (s *containerSuite) TestCreateContainerFromSpecStartFailed(c *gc.C) {
 		},
 	}
 
-	startReq := api.ContainerStatePut{
+	startReq := api.InstanceStatePut{
 		Action:   "start",
 		Timeout:  -1,
 		Force:    false,
@@ -368,11 +466,11 @@ func (s *containerSuite) TestCreateContainerFromSpecStartFailed(c *gc.C) {
 	// Container created, starting fails, container state checked, container deleted.
 	exp := cSvr.EXPECT()
 	gomock.InOrder(
-		exp.CreateContainerFromImage(cSvr, image, createReq).Return(createOp, nil),
-		exp.UpdateContainerState(spec.Name, startReq, "").Return(nil, errors.New("start failed")),
-		exp.GetContainerState(spec.Name).Return(
-			&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil),
-		exp.DeleteContainer(spec.Name).Return(deleteOp, nil),
+		exp.CreateInstanceFromImage(cSvr, image, createReq).Return(createOp, nil),
+		exp.UpdateInstanceState(spec.Name, startReq, "").Return(nil, errors.New("start failed")),
+		exp.GetInstanceState(spec.Name).Return(
+			&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil),
+		exp.DeleteInstance(spec.Name).Return(deleteOp, nil),
 	)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
@@ -383,6 +481,29 @@ func (s *containerSuite) TestCreateContainerFromSpecStartFailed(c *gc.C) {
 	c.Check(container, gc.IsNil)
 }
 
+func (s *containerSuite) TestStopContainer(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	stopOp := lxdtesting.NewMockOperation(ctrl)
+	stopOp.EXPECT().Wait().Return(nil)
+
+	stopReq := api.InstanceStatePut{
+		Action:   "stop",
+		Timeout:  -1,
+		Force:    false,
+		Stateful: false,
+	}
+	cSvr.EXPECT().UpdateInstanceState("c1", stopReq, "").Return(stopOp, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.StopContainer("c1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *containerSuite) TestRemoveContainersSuccess(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -394,7 +515,7 @@ func (s *containerSuite) TestRemoveContainersSuccess(c *gc.C) {
 	deleteOp := lxdtesting.NewMockOperation(ctrl)
 	deleteOp.EXPECT().Wait().Return(nil).Times(2)
 
-	stopReq := api.ContainerStatePut{
+	stopReq := api.InstanceStatePut{
 		Action:   "stop",
 		Timeout:  -1,
 		Force:    true,
@@ -403,11 +524,11 @@ func (s *containerSuite) TestRemoveContainersSuccess(c *gc.C) {
 
 	// Container c1 is already stopped. Container c2 is started and stopped before deletion.
 	exp := cSvr.EXPECT()
-	exp.GetContainerState("c1").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c1").Return(deleteOp, nil)
-	exp.GetContainerState("c2").Return(&api.ContainerState{StatusCode: api.Started}, lxdtesting.ETag, nil)
-	exp.UpdateContainerState("c2", stopReq, lxdtesting.ETag).Return(stopOp, nil)
-	exp.DeleteContainer("c2").Return(deleteOp, nil)
+	exp.GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c1").Return(deleteOp, nil)
+	exp.GetInstanceState("c2").Return(&api.InstanceState{StatusCode: api.Started}, lxdtesting.ETag, nil)
+	exp.UpdateInstanceState("c2", stopReq, lxdtesting.ETag).Return(stopOp, nil)
+	exp.DeleteInstance("c2").Return(deleteOp, nil)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -427,7 +548,7 @@ func (s *containerSuite) TestRemoveContainersSuccessWithNotFound(c *gc.C) {
 	deleteOp := lxdtesting.NewMockOperation(ctrl)
 	deleteOp.EXPECT().Wait().Return(nil)
 
-	stopReq := api.ContainerStatePut{
+	stopReq := api.InstanceStatePut{
 		Action:   "stop",
 		Timeout:  -1,
 		Force:    true,
@@ -436,11 +557,11 @@ func (s *containerSuite) TestRemoveContainersSuccessWithNotFound(c *gc.C) {
 
 	// Container c1 is already stopped. Container c2 is started and stopped before deletion.
 	exp := cSvr.EXPECT()
-	exp.GetContainerState("c1").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c1").Return(deleteOp, nil)
-	exp.GetContainerState("c2").Return(&api.ContainerState{StatusCode: api.Started}, lxdtesting.ETag, nil)
-	exp.UpdateContainerState("c2", stopReq, lxdtesting.ETag).Return(stopOp, nil)
-	exp.DeleteContainer("c2").Return(deleteOp, errors.New("not found"))
+	exp.GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c1").Return(deleteOp, nil)
+	exp.GetInstanceState("c2").Return(&api.InstanceState{StatusCode: api.Started}, lxdtesting.ETag, nil)
+	exp.UpdateInstanceState("c2", stopReq, lxdtesting.ETag).Return(stopOp, nil)
+	exp.DeleteInstance("c2").Return(deleteOp, errors.New("not found"))
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -460,7 +581,7 @@ func (s *containerSuite) TestRemoveContainersPartialFailure(c *gc.C) {
 	deleteOp := lxdtesting.NewMockOperation(ctrl)
 	deleteOp.EXPECT().Wait().Return(nil)
 
-	stopReq := api.ContainerStatePut{
+	stopReq := api.InstanceStatePut{
 		Action:   "stop",
 		Timeout:  -1,
 		Force:    true,
@@ -469,13 +590,13 @@ func (s *containerSuite) TestRemoveContainersPartialFailure(c *gc.C) {
 
 	// Container c1, c2 already stopped, but delete fails. Container c2 is started and stopped before deletion.
 	exp := cSvr.EXPECT()
-	exp.GetContainerState("c1").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c1").Return(nil, errors.New("deletion failed"))
-	exp.GetContainerState("c2").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c2").Return(nil, errors.New("deletion failed"))
-	exp.GetContainerState("c3").Return(&api.ContainerState{StatusCode: api.Started}, lxdtesting.ETag, nil)
-	exp.UpdateContainerState("c3", stopReq, lxdtesting.ETag).Return(stopOp, nil)
-	exp.DeleteContainer("c3").Return(deleteOp, nil)
+	exp.GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c1").Return(nil, errors.New("deletion failed"))
+	exp.GetInstanceState("c2").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c2").Return(nil, errors.New("deletion failed"))
+	exp.GetInstanceState("c3").Return(&api.InstanceState{StatusCode: api.Started}, lxdtesting.ETag, nil)
+	exp.UpdateInstanceState("c3", stopReq, lxdtesting.ETag).Return(stopOp, nil)
+	exp.DeleteInstance("c3").Return(deleteOp, nil)
 
 	jujuSvr, err := lxd.NewServer(cSvr)
 	c.Assert(err, jc.ErrorIsNil)
@@ -499,12 +620,12 @@ func (s *containerSuite) TestDeleteContainersPartialFailure(c *gc.C) {
 
 	// Container c1, c2 already stopped, but delete fails. Container c2 is started and stopped before deletion.
 	exp := cSvr.EXPECT()
-	exp.GetContainerState("c1").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c1").Return(deleteOpFail, nil)
-	exp.DeleteContainer("c1").Return(deleteOpSuccess, nil)
+	exp.GetInstanceState("c1").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c1").Return(deleteOpFail, nil)
+	exp.DeleteInstance("c1").Return(deleteOpSuccess, nil)
 
-	exp.GetContainerState("c2").Return(&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
-	exp.DeleteContainer("c2").Return(deleteOpFail, nil).Times(retries)
+	exp.GetInstanceState("c2").Return(&api.InstanceState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	exp.DeleteInstance("c2").Return(deleteOpFail, nil).Times(retries)
 
 	clock := mocks.NewMockClock(ctrl)
 	ch := make(chan time.Time)
@@ -557,3 +678,18 @@ func (s *managerSuite) TestSpecApplyConstraints(c *gc.C) {
 	c.Check(spec.Config, gc.DeepEquals, exp)
 	c.Check(spec.InstanceType, gc.Equals, instType)
 }
+
+func (s *managerSuite) TestSpecApplyConstraintsVirtTypeAndRootDisk(c *gc.C) {
+	spec := lxd.ContainerSpec{
+		Config: map[string]string{},
+	}
+	spec.ApplyConstraints("4.0.0", constraints.MustParse("virt-type=virtual-machine root-disk=10G"))
+	c.Check(spec.VirtType, gc.Equals, instance.InstanceTypeVM)
+	c.Check(spec.Devices, gc.DeepEquals, map[string]map[string]string{
+		"root": {
+			"type": "disk",
+			"path": "/",
+			"size": "10240MiB",
+		},
+	})
+}
@@ -14,6 +14,7 @@ import (
 	lxd "github.com/lxc/lxd/client"
 	"github.com/lxc/lxd/shared/api"
 
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 )
@@ -28,15 +29,17 @@ type SourcedImage struct {
 }
 
 // FindImage searches the input sources in supplied order, looking for an OS
-// image matching the supplied series and architecture.
+// image matching the supplied series, architecture and virt type.
 // If found, the image and the server from which it was acquired are returned.
 // If the server is remote the image will be cached by LXD when used to create
 // a container.
 // Supplying true for copyLocal will copy the image to the local cache.
-// Copied images will have the juju/series/arch alias added to them.
+// Copied images will have the juju/series/arch alias added to them, with a
+// "/vm" suffix for virtual machine images.
 // The callback argument is used to report copy progress.
 func (s *Server) FindImage(
 	series, arch string,
+	virtType instance.VirtType,
 	sources []ServerSpec,
 	copyLocal bool,
 	callback environs.StatusCallbackFunc,
@@ -46,7 +49,7 @@ func (s *Server) FindImage(
 	}
 
 	// First we check if we have the image locally.
-	localAlias := seriesLocalAlias(series, arch)
+	localAlias := seriesLocalAlias(series, arch, virtType)
 	var target string
 	entry, _, err := s.GetImageAlias(localAlias)
 	if err != nil && !IsLXDNotFound(err) {
@@ -76,6 +79,12 @@ func (s *Server) FindImage(
 	if err != nil {
 		return sourced, errors.Trace(err)
 	}
+	// An empty image type matches images of any type, which is what
+	// servers that predate virtual machine support expect.
+	var imageType string
+	if virtType == instance.InstanceTypeVM {
+		imageType = string(virtType)
+	}
 	for _, remote := range sources {
 		source, err := ConnectImageRemote(remote)
 		if err != nil {
@@ -84,7 +93,7 @@ func (s *Server) FindImage(
 			continue
 		}
 		for _, alias := range aliases {
-			if result, _, err := source.GetImageAlias(alias); err == nil && result != nil && result.Target != "" {
+			if result, _, err := source.GetImageAliasType(imageType, alias); err == nil && result != nil && result.Target != "" {
 				target = result.Target
 				break
 			}
@@ -173,7 +182,12 @@ func (s *Server) CopyRemoteImage(
 // seriesLocalAlias returns the alias to assign to images for the
 // specified series. The alias is juju-specific, to support the
 // user supplying a customised image (e.g. CentOS with cloud-init).
-func seriesLocalAlias(series, arch string) string {
+// LXD aliases are not scoped by image type, so virtual machine images
+// get their own alias.
+func seriesLocalAlias(series, arch string, virtType instance.VirtType) string {
+	if virtType == instance.InstanceTypeVM {
+		return fmt.Sprintf("juju/%s/%s/vm", series, arch)
+	}
 	return fmt.Sprintf("juju/%s/%s", series, arch)
 }
 
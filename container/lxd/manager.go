@@ -163,12 +163,19 @@ func (m *containerManager) getContainerSpec(
 		return ContainerSpec{}, errors.Trace(err)
 	}
 
+	virtType := instance.DefaultInstanceType
+	if cons.HasVirtType() {
+		if virtType, err = instance.ParseVirtType(*cons.VirtType); err != nil {
+			return ContainerSpec{}, errors.Trace(err)
+		}
+	}
+
 	// Lock around finding an image.
 	// The provisioner works concurrently to create containers.
 	// If an image needs to be copied from a remote, we don't want many
 	// goroutines attempting to do it at once.
 	m.imageMutex.Lock()
-	found, err := m.server.FindImage(series, jujuarch.HostArch(), imageSources, true, callback)
+	found, err := m.server.FindImage(series, jujuarch.HostArch(), virtType, imageSources, true, callback)
 	m.imageMutex.Unlock()
 	if err != nil {
 		return ContainerSpec{}, errors.Annotatef(err, "acquiring LXD image")
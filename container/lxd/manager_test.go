@@ -140,12 +140,12 @@ func (s *managerSuite) TestContainerCreateDestroy(c *gc.C) {
 
 	// Arrangements for the container creation.
 	s.expectCreateContainer(ctrl)
-	exp.UpdateContainerState(hostName, lxdapi.ContainerStatePut{Action: "start", Timeout: -1}, "").Return(s.startOp, nil)
+	exp.UpdateInstanceState(hostName, lxdapi.InstanceStatePut{Action: "start", Timeout: -1}, "").Return(s.startOp, nil)
 
-	exp.GetContainerState(hostName).Return(
-		&lxdapi.ContainerState{
+	exp.GetInstanceState(hostName).Return(
+		&lxdapi.InstanceState{
 			StatusCode: lxdapi.Running,
-			Network: map[string]lxdapi.ContainerStateNetwork{
+			Network: map[string]lxdapi.InstanceStateNetwork{
 				"fan0": {
 					Type: "fan",
 				},
@@ -156,18 +156,18 @@ func (s *managerSuite) TestContainerCreateDestroy(c *gc.C) {
 			},
 		}, lxdtesting.ETag, nil).Times(2)
 
-	exp.GetContainer(hostName).Return(&lxdapi.Container{Name: hostName}, lxdtesting.ETag, nil)
+	exp.GetInstance(hostName).Return(&lxdapi.Instance{Name: hostName}, lxdtesting.ETag, nil)
 
 	// Arrangements for the container destruction.
-	stopReq := lxdapi.ContainerStatePut{
+	stopReq := lxdapi.InstanceStatePut{
 		Action:   "stop",
 		Timeout:  -1,
 		Stateful: false,
 		Force:    true,
 	}
 	gomock.InOrder(
-		exp.UpdateContainerState(hostName, stopReq, lxdtesting.ETag).Return(s.stopOp, nil),
-		exp.DeleteContainer(hostName).Return(s.deleteOp, nil),
+		exp.UpdateInstanceState(hostName, stopReq, lxdtesting.ETag).Return(s.stopOp, nil),
+		exp.DeleteInstance(hostName).Return(s.deleteOp, nil),
 	)
 
 	instance, hc, err := s.manager.CreateContainer(
@@ -213,8 +213,8 @@ func (s *managerSuite) TestContainerCreateUpdateIPv4Network(c *gc.C) {
 	s.expectCreateContainer(ctrl)
 	s.expectStartOp(ctrl)
 
-	exp.UpdateContainerState(hostName, lxdapi.ContainerStatePut{Action: "start", Timeout: -1}, "").Return(s.startOp, nil)
-	exp.GetContainer(hostName).Return(&lxdapi.Container{Name: hostName}, lxdtesting.ETag, nil)
+	exp.UpdateInstanceState(hostName, lxdapi.InstanceStatePut{Action: "start", Timeout: -1}, "").Return(s.startOp, nil)
+	exp.GetInstance(hostName).Return(&lxdapi.Instance{Name: hostName}, lxdtesting.ETag, nil)
 
 	// Supplying config for a single device with default bridge and without a
 	// CIDR will cause the default bridge to be updated with IPv4 config.
@@ -230,6 +230,68 @@ func (s *managerSuite) TestContainerCreateUpdateIPv4Network(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *managerSuite) TestCreateVirtualMachine(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+	s.patch()
+	s.makeManager(c)
+
+	iCfg := prepInstanceConfig(c)
+	hostName, err := s.manager.Namespace().Hostname(iCfg.MachineId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.expectCreateRemoteOp(ctrl, &lxdapi.Operation{StatusCode: lxdapi.Success})
+	s.expectStartOp(ctrl)
+
+	target := "foo-target"
+	alias := &lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: target}}
+	image := lxdapi.Image{Filename: "this-is-our-vm-image", Type: "virtual-machine"}
+
+	var req lxdapi.InstancesPost
+	exp := s.cSvr.EXPECT()
+	gomock.InOrder(
+		exp.GetImageAlias("juju/xenial/"+s.Arch()+"/vm").Return(alias, lxdtesting.ETag, nil),
+		exp.GetImage(target).Return(&image, lxdtesting.ETag, nil),
+		exp.CreateInstanceFromImage(s.cSvr, image, gomock.Any()).DoAndReturn(
+			func(_ lxdclient.ImageServer, _ lxdapi.Image, r lxdapi.InstancesPost) (lxdclient.RemoteOperation, error) {
+				req = r
+				return s.createRemoteOp, nil
+			}),
+		exp.UpdateInstanceState(hostName, lxdapi.InstanceStatePut{Action: "start", Timeout: -1}, "").Return(s.startOp, nil),
+		exp.GetInstance(hostName).Return(&lxdapi.Instance{Name: hostName, Type: "virtual-machine"}, lxdtesting.ETag, nil),
+	)
+
+	_, _, err = s.manager.CreateContainer(
+		iCfg,
+		constraints.MustParse("virt-type=virtual-machine"),
+		"xenial",
+		prepNetworkConfig(),
+		&container.StorageConfig{},
+		lxdtesting.NoOpCallback,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(req.Type, gc.Equals, lxdapi.InstanceTypeVM)
+	c.Check(req.Devices["config"], gc.DeepEquals, map[string]string{
+		"type":   "disk",
+		"source": "cloud-init:config",
+	})
+}
+
+func (s *managerSuite) TestCreateContainerInvalidVirtType(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.makeManager(c)
+
+	_, _, err := s.manager.CreateContainer(
+		prepInstanceConfig(c),
+		constraints.MustParse("virt-type=kvm"),
+		"xenial",
+		prepNetworkConfig(),
+		&container.StorageConfig{},
+		lxdtesting.NoOpCallback,
+	)
+	c.Assert(err, gc.ErrorMatches, `virt type "kvm" not valid`)
+}
+
 func (s *managerSuite) TestCreateContainerCreateFailed(c *gc.C) {
 	ctrl := s.setup(c)
 	defer ctrl.Finish()
@@ -240,7 +302,7 @@ func (s *managerSuite) TestCreateContainerCreateFailed(c *gc.C) {
 	s.expectGetImage(image, nil)
 
 	exp := s.cSvr.EXPECT()
-	exp.CreateContainerFromImage(s.cSvr, image, gomock.Any()).Return(s.createRemoteOp, nil)
+	exp.CreateInstanceFromImage(s.cSvr, image, gomock.Any()).Return(s.createRemoteOp, nil)
 
 	s.makeManager(c)
 	_, _, err := s.manager.CreateContainer(
@@ -293,10 +355,10 @@ func (s *managerSuite) TestCreateContainerStartFailed(c *gc.C) {
 
 	exp := s.cSvr.EXPECT()
 	gomock.InOrder(
-		exp.UpdateContainerState(
-			hostName, lxdapi.ContainerStatePut{Action: "start", Timeout: -1}, "").Return(s.updateOp, nil),
-		exp.GetContainerState(hostName).Return(&lxdapi.ContainerState{StatusCode: lxdapi.Stopped}, lxdtesting.ETag, nil),
-		exp.DeleteContainer(hostName).Return(s.deleteOp, nil),
+		exp.UpdateInstanceState(
+			hostName, lxdapi.InstanceStatePut{Action: "start", Timeout: -1}, "").Return(s.updateOp, nil),
+		exp.GetInstanceState(hostName).Return(&lxdapi.InstanceState{StatusCode: lxdapi.Stopped}, lxdtesting.ETag, nil),
+		exp.DeleteInstance(hostName).Return(s.deleteOp, nil),
 	)
 
 	_, _, err = s.manager.CreateContainer(
@@ -317,7 +379,7 @@ func (s *managerSuite) TestListContainers(c *gc.C) {
 	prefix := s.manager.Namespace().Prefix()
 	wrongPrefix := prefix[:len(prefix)-1] + "j"
 
-	containers := []lxdapi.Container{
+	containers := []lxdapi.Instance{
 		{Name: "foobar"},
 		{Name: "definitely-not-a-juju-container"},
 		{Name: wrongPrefix + "-0"},
@@ -327,7 +389,7 @@ func (s *managerSuite) TestListContainers(c *gc.C) {
 		{Name: "nothing-to-see-here-please"},
 	}
 
-	s.cSvr.EXPECT().GetContainers().Return(containers, nil)
+	s.cSvr.EXPECT().GetInstances(lxdapi.InstanceTypeAny).Return(containers, nil)
 
 	result, err := s.manager.ListContainers()
 	c.Assert(err, jc.ErrorIsNil)
@@ -604,7 +666,7 @@ func (s *managerSuite) expectCreateContainer(ctrl *gomock.Controller) {
 	s.expectGetImage(image, nil)
 
 	exp := s.cSvr.EXPECT()
-	exp.CreateContainerFromImage(s.cSvr, image, gomock.Any()).Return(s.createRemoteOp, nil)
+	exp.CreateInstanceFromImage(s.cSvr, image, gomock.Any()).Return(s.createRemoteOp, nil)
 }
 
 // expectCreateRemoteOp is a convenience function for the expectations
@@ -673,17 +735,17 @@ func (s *managerSuite) expectUpdateContainerProfiles(old, new string, newProfile
 		cExp.GetProfileNames().Return(oldProfiles, nil),
 		cExp.CreateProfile(post).Return(nil),
 		cExp.GetProfile(post.Name).Return(&expProfile, "etag", nil),
-		cExp.GetContainer(instId).Return(
-			&lxdapi.Container{
-				ContainerPut: lxdapi.ContainerPut{
+		cExp.GetInstance(instId).Return(
+			&lxdapi.Instance{
+				InstancePut: lxdapi.InstancePut{
 					Profiles: oldProfiles,
 				},
 			}, "", nil),
-		cExp.UpdateContainer(instId, gomock.Any(), gomock.Any()).Return(s.updateOp, nil),
+		cExp.UpdateInstance(instId, gomock.Any(), gomock.Any()).Return(s.updateOp, nil),
 		cExp.DeleteProfile(old).Return(nil),
-		cExp.GetContainer(instId).Return(
-			&lxdapi.Container{
-				ContainerPut: lxdapi.ContainerPut{
+		cExp.GetInstance(instId).Return(
+			&lxdapi.Instance{
+				InstancePut: lxdapi.InstancePut{
 					Profiles: newProfiles,
 				},
 			}, "", nil),
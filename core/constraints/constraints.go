@@ -35,6 +35,8 @@ const (
 	VirtType         = "virt-type"
 	Zones            = "zones"
 	AllocatePublicIP = "allocate-public-ip"
+	Spot             = "spot"
+	MaxPrice         = "max-price"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -104,6 +106,19 @@ type Value struct {
 	// The default behaviour if the value is not specified is to allocate
 	// a public IP so that public cloud behaviour works out of the box.
 	AllocatePublicIP *bool `json:"allocate-public-ip,omitempty" yaml:"allocate-public-ip,omitempty"`
+
+	// Spot, if true, signals that machines should be started as spot
+	// (or preemptible) instances. These are cheaper than on-demand
+	// instances, but the cloud may interrupt them at any time. The
+	// machines of interrupted instances are given the interrupted
+	// instance status, and the model's spot-interruption-policy
+	// decides whether their units are replaced.
+	Spot *bool `json:"spot,omitempty" yaml:"spot,omitempty"`
+
+	// MaxPrice, if not nil, is the highest hourly price to pay for a spot
+	// instance, in the cloud's currency. If not specified, the on-demand
+	// price of the instance type is the limit. Ignored unless Spot is true.
+	MaxPrice *string `json:"max-price,omitempty" yaml:"max-price,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -220,6 +235,17 @@ func (v *Value) HasAllocatePublicIP() bool {
 	return v.AllocatePublicIP != nil
 }
 
+// HasSpot returns true if the constraints.Value requests spot instances.
+func (v *Value) HasSpot() bool {
+	return v.Spot != nil && *v.Spot
+}
+
+// HasMaxPrice returns true if the constraints.Value specifies a maximum
+// price for spot instances.
+func (v *Value) HasMaxPrice() bool {
+	return v.MaxPrice != nil && *v.MaxPrice != ""
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -274,6 +300,12 @@ func (v Value) String() string {
 	if v.AllocatePublicIP != nil {
 		strs = append(strs, "allocate-public-ip="+boolStr(*v.AllocatePublicIP))
 	}
+	if v.Spot != nil {
+		strs = append(strs, "spot="+boolStr(*v.Spot))
+	}
+	if v.MaxPrice != nil {
+		strs = append(strs, "max-price="+(*v.MaxPrice))
+	}
 
 	// Ensure constraint values with spaces are properly escaped
 	for i := 0; i < len(strs); i++ {
@@ -329,6 +361,12 @@ func (v Value) GoString() string {
 	if v.AllocatePublicIP != nil {
 		values = append(values, fmt.Sprintf("AllocatePublicIP: %v", *v.AllocatePublicIP))
 	}
+	if v.Spot != nil {
+		values = append(values, fmt.Sprintf("Spot: %v", *v.Spot))
+	}
+	if v.MaxPrice != nil {
+		values = append(values, fmt.Sprintf("MaxPrice: %q", *v.MaxPrice))
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -500,6 +538,10 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setZones(str)
 	case AllocatePublicIP:
 		err = v.setAllocatePublicIP(str)
+	case Spot:
+		err = v.setSpot(str)
+	case MaxPrice:
+		err = v.setMaxPrice(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -569,6 +611,12 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			v.Zones, err = parseYamlStrings("zones", val)
 		case AllocatePublicIP:
 			v.AllocatePublicIP, err = parseBool(vstr)
+		case Spot:
+			v.Spot, err = parseBool(vstr)
+		case MaxPrice:
+			if err = validatePrice(vstr); err == nil {
+				v.MaxPrice = &vstr
+			}
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -720,6 +768,41 @@ func (v *Value) setAllocatePublicIP(str string) (err error) {
 	return
 }
 
+func (v *Value) setSpot(str string) (err error) {
+	if str == "" {
+		return nil
+	}
+	if v.Spot != nil {
+		return errors.Errorf("already set")
+	}
+	v.Spot, err = parseBool(str)
+	return
+}
+
+func (v *Value) setMaxPrice(str string) error {
+	if v.MaxPrice != nil {
+		return errors.Errorf("already set")
+	}
+	if err := validatePrice(str); err != nil {
+		return err
+	}
+	v.MaxPrice = &str
+	return nil
+}
+
+// validatePrice checks that str is a positive decimal number. Prices are
+// kept as strings so they're passed to the cloud exactly as given.
+func validatePrice(str string) error {
+	if str == "" {
+		return nil
+	}
+	val, err := strconv.ParseFloat(str, 64)
+	if err != nil || !(val > 0) || math.IsInf(val, 0) || strings.ContainsAny(str, "eE") {
+		return errors.Errorf("must be a positive decimal number")
+	}
+	return nil
+}
+
 func parseBool(str string) (*bool, error) {
 	var value bool
 	if str != "" {
@@ -366,6 +366,49 @@ var parseConstraintsTests = []struct {
 		err:     `bad "allocate-public-ip" constraint: already set`,
 	},
 
+	// Spot
+	{
+		summary: "set spot",
+		args:    []string{"spot=true"},
+	}, {
+		summary: "set nonsense spot",
+		args:    []string{"spot=fred"},
+		err:     `bad "spot" constraint: must be 'true' or 'false'`,
+	}, {
+		summary: "try to set spot twice",
+		args:    []string{"spot=true spot=false"},
+		err:     `bad "spot" constraint: already set`,
+	},
+
+	// MaxPrice
+	{
+		summary: "set max-price",
+		args:    []string{"max-price=0.05"},
+	}, {
+		summary: "set empty max-price",
+		args:    []string{"max-price="},
+	}, {
+		summary: "set nonsense max-price",
+		args:    []string{"max-price=cheap"},
+		err:     `bad "max-price" constraint: must be a positive decimal number`,
+	}, {
+		summary: "set zero max-price",
+		args:    []string{"max-price=0"},
+		err:     `bad "max-price" constraint: must be a positive decimal number`,
+	}, {
+		summary: "set exponent max-price",
+		args:    []string{"max-price=1e-2"},
+		err:     `bad "max-price" constraint: must be a positive decimal number`,
+	}, {
+		summary: "set infinite max-price",
+		args:    []string{"max-price=inf"},
+		err:     `bad "max-price" constraint: must be a positive decimal number`,
+	}, {
+		summary: "try to set max-price twice",
+		args:    []string{"max-price=0.1 max-price=0.2"},
+		err:     `bad "max-price" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -579,6 +622,30 @@ func (s *ConstraintsSuite) TestHasAllocatePublicIP(c *gc.C) {
 	c.Check(con.HasAllocatePublicIP(), jc.IsFalse)
 }
 
+func (s *ConstraintsSuite) TestHasSpot(c *gc.C) {
+	con := constraints.MustParse("spot=true")
+	c.Check(con.HasSpot(), jc.IsTrue)
+
+	con = constraints.MustParse("spot=false")
+	c.Assert(con.Spot, gc.Not(gc.IsNil))
+	c.Check(con.HasSpot(), jc.IsFalse)
+
+	con = constraints.MustParse("spot=")
+	c.Check(con.HasSpot(), jc.IsFalse)
+}
+
+func (s *ConstraintsSuite) TestHasMaxPrice(c *gc.C) {
+	con := constraints.MustParse("spot=true max-price=0.02")
+	c.Check(con.HasMaxPrice(), jc.IsTrue)
+	c.Check(*con.MaxPrice, gc.Equals, "0.02")
+
+	con = constraints.MustParse("max-price=")
+	c.Check(con.HasMaxPrice(), jc.IsFalse)
+
+	con = constraints.MustParse("spot=true")
+	c.Check(con.HasMaxPrice(), jc.IsFalse)
+}
+
 func (s *ConstraintsSuite) TestHasRootDiskSource(c *gc.C) {
 	con := constraints.MustParse("root-disk-source=pilgrim")
 	c.Check(con.HasRootDiskSource(), jc.IsTrue)
@@ -684,6 +751,11 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Zones3", constraints.Value{Zones: &[]string{"az1", "az2"}}},
 	{"AllocatePublicIP1", constraints.Value{AllocatePublicIP: nil}},
 	{"AllocatePublicIP2", constraints.Value{AllocatePublicIP: boolp(true)}},
+	{"Spot1", constraints.Value{Spot: nil}},
+	{"Spot2", constraints.Value{Spot: boolp(true)}},
+	{"Spot3", constraints.Value{Spot: boolp(false)}},
+	{"MaxPrice1", constraints.Value{MaxPrice: nil}},
+	{"MaxPrice2", constraints.Value{MaxPrice: strp("0.125")}},
 	{"All", constraints.Value{
 		Arch:             strp("i386"),
 		Container:        ctypep("lxd"),
@@ -697,6 +769,8 @@ var constraintsRoundtripTests = []roundTrip{
 		InstanceType:     strp("foo"),
 		Zones:            &[]string{"az1", "az2"},
 		AllocatePublicIP: boolp(true),
+		Spot:             boolp(true),
+		MaxPrice:         strp("0.5"),
 	}},
 }
 
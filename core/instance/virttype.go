@@ -0,0 +1,47 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instance
+
+import (
+	"github.com/juju/errors"
+)
+
+// VirtType represents the type of virtualisation used by an instance,
+// as selected by the virt-type constraint.
+type VirtType string
+
+// Known virt types.
+const (
+	InstanceTypeContainer VirtType = "container"
+	InstanceTypeVM        VirtType = "virtual-machine"
+
+	// DefaultInstanceType is used when no virt-type is specified.
+	DefaultInstanceType = InstanceTypeContainer
+)
+
+// VirtTypes is the list of virt types understood by the LXD provider
+// and container manager.
+var VirtTypes = []VirtType{
+	InstanceTypeContainer,
+	InstanceTypeVM,
+}
+
+// ParseVirtType converts the specified string into a supported VirtType.
+// An empty string yields DefaultInstanceType.
+func ParseVirtType(value string) (VirtType, error) {
+	if value == "" {
+		return DefaultInstanceType, nil
+	}
+	for _, virtType := range VirtTypes {
+		if VirtType(value) == virtType {
+			return virtType, nil
+		}
+	}
+	return "", errors.NotValidf("virt type %q", value)
+}
+
+// String returns the string representation of the virt type.
+func (v VirtType) String() string {
+	return string(v)
+}
@@ -0,0 +1,33 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instance_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+)
+
+type VirtTypeSuite struct{}
+
+var _ = gc.Suite(&VirtTypeSuite{})
+
+func (s *VirtTypeSuite) TestParseVirtType(c *gc.C) {
+	for _, test := range []struct {
+		value    string
+		expected instance.VirtType
+	}{
+		{"", instance.InstanceTypeContainer},
+		{"container", instance.InstanceTypeContainer},
+		{"virtual-machine", instance.InstanceTypeVM},
+	} {
+		virtType, err := instance.ParseVirtType(test.value)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(virtType, gc.Equals, test.expected)
+	}
+
+	_, err := instance.ParseVirtType("kvm")
+	c.Assert(err, gc.ErrorMatches, `virt type "kvm" not valid`)
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// UnitSuspendStatus is the progress of a unit through the suspension and
+// resumption of its model.
+type UnitSuspendStatus string
+
+func (s UnitSuspendStatus) String() string {
+	return string(s)
+}
+
+const (
+	// UnitSuspendNotStarted is the status of units in models that are
+	// not being suspended or resumed.
+	UnitSuspendNotStarted UnitSuspendStatus = ""
+
+	// UnitSuspending is set by the controller when the unit's machine is
+	// about to be suspended; the unit runs its pre-suspend hook.
+	UnitSuspending UnitSuspendStatus = "suspending"
+
+	// UnitSuspended is set by the unit once its pre-suspend hook has
+	// completed, after which its machine can be powered off.
+	UnitSuspended UnitSuspendStatus = "suspended"
+
+	// UnitResuming is set by the controller when the unit's machine has
+	// been resumed; the unit runs its post-resume hook and then resets
+	// its status to UnitSuspendNotStarted.
+	UnitResuming UnitSuspendStatus = "resuming"
+)
+
+// ValidateUnitSuspendStatus returns an error if the status is not a valid
+// unit suspend status.
+func ValidateUnitSuspendStatus(status UnitSuspendStatus) error {
+	switch status {
+	case UnitSuspendNotStarted, UnitSuspending, UnitSuspended, UnitResuming:
+		return nil
+	}
+	return errors.NotValidf("unit suspend status %q", status)
+}
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+var weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// suspendWindow is a period of the week, in minutes since the start of
+// Monday, during which a model is suspended. The end may be beyond the end
+// of the week, in which case the window wraps around to the next week.
+type suspendWindow struct {
+	start, end int
+}
+
+// SuspendSchedule describes when a model is suspended, as a set of weekly
+// windows in UTC.
+type SuspendSchedule struct {
+	windows []suspendWindow
+}
+
+// ParseSuspendSchedule parses a suspend schedule. A schedule is a list of
+// entries separated by semicolons, each of which is a set of days and a
+// time range, for example "Mon-Fri 20:00-07:00; Sat,Sun 00:00-24:00".
+// Days are given as three letter names, ranges of them or lists of both.
+// Times are in UTC; a range that ends before it starts continues into
+// the following day. An empty schedule parses as nil.
+func ParseSuspendSchedule(schedule string) (*SuspendSchedule, error) {
+	if strings.TrimSpace(schedule) == "" {
+		return nil, nil
+	}
+	var result SuspendSchedule
+	for _, entry := range strings.Split(schedule, ";") {
+		fields := strings.Fields(entry)
+		if len(fields) != 2 {
+			return nil, errors.NotValidf("suspend schedule entry %q", strings.TrimSpace(entry))
+		}
+		days, err := parseDays(fields[0])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		start, end, err := parseTimeRange(fields[1])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if end <= start {
+			end += minutesPerDay
+		}
+		for _, day := range days {
+			result.windows = append(result.windows, suspendWindow{
+				start: day*minutesPerDay + start,
+				end:   day*minutesPerDay + end,
+			})
+		}
+	}
+	return &result, nil
+}
+
+func parseDay(day string) (int, error) {
+	for i, name := range weekdays {
+		if strings.ToLower(day) == name {
+			return i, nil
+		}
+	}
+	return 0, errors.NotValidf("day %q", day)
+}
+
+// parseDays returns the days, counted from Monday, in a comma separated
+// list of days and day ranges.
+func parseDays(spec string) ([]int, error) {
+	seen := make(map[int]bool)
+	var days []int
+	for _, part := range strings.Split(spec, ",") {
+		bounds := strings.Split(part, "-")
+		if len(bounds) > 2 {
+			return nil, errors.NotValidf("day range %q", part)
+		}
+		first, err := parseDay(bounds[0])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = parseDay(bounds[1]); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		// Ranges may wrap around the end of the week, as in Fri-Mon.
+		for day := first; ; day = (day + 1) % 7 {
+			if !seen[day] {
+				seen[day] = true
+				days = append(days, day)
+			}
+			if day == last {
+				break
+			}
+		}
+	}
+	sort.Ints(days)
+	return days, nil
+}
+
+// parseTimeRange parses a range such as "20:00-07:00", returning the
+// start and end in minutes since midnight.
+func parseTimeRange(spec string) (int, int, error) {
+	bounds := strings.Split(spec, "-")
+	if len(bounds) != 2 {
+		return 0, 0, errors.NotValidf("time range %q", spec)
+	}
+	start, err := parseTimeOfDay(bounds[0])
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	if start == minutesPerDay {
+		return 0, 0, errors.NotValidf("start time %q", bounds[0])
+	}
+	end, err := parseTimeOfDay(bounds[1])
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	if start == end {
+		return 0, 0, errors.NotValidf("empty time range %q", spec)
+	}
+	return start, end, nil
+}
+
+// parseTimeOfDay parses a time such as "07:30", returning the minutes
+// since midnight. "24:00" is accepted as the end of the day.
+func parseTimeOfDay(spec string) (int, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) != 2 || len(parts[1]) != 2 {
+		return 0, errors.NotValidf("time %q", spec)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 || hours > 24 {
+		return 0, errors.NotValidf("time %q", spec)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 || (hours == 24 && minutes != 0) {
+		return 0, errors.NotValidf("time %q", spec)
+	}
+	return hours*60 + minutes, nil
+}
+
+// minuteOfWeek returns the minutes since the start of the UTC week,
+// which starts on Monday, and the start of that week.
+func minuteOfWeek(t time.Time) (int, time.Time) {
+	t = t.UTC()
+	day := (int(t.Weekday()) + 6) % 7
+	minute := day*minutesPerDay + t.Hour()*60 + t.Minute()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return minute, midnight.AddDate(0, 0, -day)
+}
+
+func (s *SuspendSchedule) suspendedAt(minute int) bool {
+	for _, w := range s.windows {
+		if (minute >= w.start && minute < w.end) || minute+minutesPerWeek < w.end {
+			return true
+		}
+	}
+	return false
+}
+
+// Suspended returns whether the schedule has the model suspended at the
+// given time.
+func (s *SuspendSchedule) Suspended(t time.Time) bool {
+	minute, _ := minuteOfWeek(t)
+	return s.suspendedAt(minute)
+}
+
+// NextChange returns the first time after t at which the model is to be
+// suspended or resumed. The zero time is returned if the schedule never
+// changes.
+func (s *SuspendSchedule) NextChange(t time.Time) time.Time {
+	minute, weekStart := minuteOfWeek(t)
+	var boundaries []int
+	for _, w := range s.windows {
+		boundaries = append(boundaries, w.start, w.end%minutesPerWeek)
+	}
+	sort.Ints(boundaries)
+	suspended := s.suspendedAt(minute)
+	// Look a week ahead, so boundaries before this minute are
+	// considered in the next week.
+	for _, week := range []int{0, minutesPerWeek} {
+		for _, b := range boundaries {
+			at := b + week
+			if at <= minute {
+				continue
+			}
+			if s.suspendedAt(at%minutesPerWeek) != suspended {
+				return weekStart.Add(time.Duration(at) * time.Minute)
+			}
+		}
+	}
+	return time.Time{}
+}
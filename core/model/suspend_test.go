@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/testing"
+)
+
+type suspendSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&suspendSuite{})
+
+func (*suspendSuite) TestValidateUnitSuspendStatus(c *gc.C) {
+	for _, status := range []model.UnitSuspendStatus{
+		model.UnitSuspendNotStarted,
+		model.UnitSuspending,
+		model.UnitSuspended,
+		model.UnitResuming,
+	} {
+		c.Check(model.ValidateUnitSuspendStatus(status), jc.ErrorIsNil)
+	}
+	err := model.ValidateUnitSuspendStatus("sleeping")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (*suspendSuite) TestParseSuspendScheduleEmpty(c *gc.C) {
+	schedule, err := model.ParseSuspendSchedule(" ")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule, gc.IsNil)
+}
+
+func (*suspendSuite) TestParseSuspendScheduleErrors(c *gc.C) {
+	for _, test := range []struct {
+		schedule string
+		err      string
+	}{
+		{"Mon-Fri", `suspend schedule entry "Mon-Fri" not valid`},
+		{"Mon-Fri 20:00-07:00 extra", `suspend schedule entry "Mon-Fri 20:00-07:00 extra" not valid`},
+		{"Mon-Fri 20:00-07:00;", `suspend schedule entry "" not valid`},
+		{"Mon-Fry 20:00-07:00", `day "Fry" not valid`},
+		{"Mon-Wed-Fri 20:00-07:00", `day range "Mon-Wed-Fri" not valid`},
+		{"Mon 20:00", `time range "20:00" not valid`},
+		{"Mon 25:00-07:00", `time "25:00" not valid`},
+		{"Mon 20:60-07:00", `time "20:60" not valid`},
+		{"Mon 20:0-07:00", `time "20:0" not valid`},
+		{"Mon 24:00-07:00", `start time "24:00" not valid`},
+		{"Mon 07:00-07:00", `empty time range "07:00-07:00" not valid`},
+	} {
+		c.Logf("schedule %q", test.schedule)
+		_, err := model.ParseSuspendSchedule(test.schedule)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+// at returns the given time in the week of 2020-10-05, a Monday.
+func at(day int, hour, minute int) time.Time {
+	return time.Date(2020, 10, 5+day, hour, minute, 0, 0, time.UTC)
+}
+
+func (*suspendSuite) TestSuspended(c *gc.C) {
+	schedule, err := model.ParseSuspendSchedule("Mon-Fri 20:00-07:00; sat,SUN 00:00-24:00")
+	c.Assert(err, jc.ErrorIsNil)
+	for _, test := range []struct {
+		t         time.Time
+		suspended bool
+	}{
+		{at(0, 6, 59), false},
+		{at(0, 12, 0), false},
+		{at(0, 20, 0), true},
+		{at(1, 6, 59), true},
+		{at(1, 7, 0), false},
+		{at(4, 23, 0), true},
+		{at(5, 12, 0), true},
+		{at(6, 23, 59), true},
+		// Sunday's window ends at midnight, and the next is
+		// Monday evening.
+		{at(7, 0, 0), false},
+	} {
+		c.Check(schedule.Suspended(test.t), gc.Equals, test.suspended, gc.Commentf("%v", test.t))
+	}
+}
+
+func (*suspendSuite) TestSuspendedWrapsWeek(c *gc.C) {
+	schedule, err := model.ParseSuspendSchedule("Sun 22:00-02:00")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Suspended(at(6, 23, 0)), jc.IsTrue)
+	c.Check(schedule.Suspended(at(0, 1, 0)), jc.IsTrue)
+	c.Check(schedule.Suspended(at(0, 2, 0)), jc.IsFalse)
+}
+
+func (*suspendSuite) TestSuspendedDayRangeWraps(c *gc.C) {
+	schedule, err := model.ParseSuspendSchedule("Sat-Mon 01:00-02:00")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Suspended(at(5, 1, 30)), jc.IsTrue)
+	c.Check(schedule.Suspended(at(6, 1, 30)), jc.IsTrue)
+	c.Check(schedule.Suspended(at(0, 1, 30)), jc.IsTrue)
+	c.Check(schedule.Suspended(at(1, 1, 30)), jc.IsFalse)
+}
+
+func (*suspendSuite) TestNextChange(c *gc.C) {
+	schedule, err := model.ParseSuspendSchedule("Mon-Fri 20:00-07:00; Sat,Sun 00:00-24:00")
+	c.Assert(err, jc.ErrorIsNil)
+	for _, test := range []struct {
+		t    time.Time
+		next time.Time
+	}{
+		{at(0, 12, 0), at(0, 20, 0)},
+		{at(0, 20, 0), at(1, 7, 0)},
+		{at(2, 3, 30), at(2, 7, 0)},
+		// Friday night runs into the weekend, which ends at
+		// midnight on Monday.
+		{at(4, 21, 0), at(7, 0, 0)},
+		{at(6, 12, 0), at(7, 0, 0)},
+	} {
+		c.Check(schedule.NextChange(test.t), gc.Equals, test.next, gc.Commentf("%v", test.t))
+	}
+}
+
+func (*suspendSuite) TestNextChangeAlwaysSuspended(c *gc.C) {
+	schedule, err := model.ParseSuspendSchedule("Mon-Sun 00:00-24:00")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Suspended(at(3, 12, 0)), jc.IsTrue)
+	c.Check(schedule.NextChange(at(3, 12, 0)).IsZero(), jc.IsTrue)
+}
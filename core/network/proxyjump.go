@@ -0,0 +1,36 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ValidateSSHProxyJump returns an error if proxyJump is not a
+// "[user@]host" that can be safely passed to ssh as a jump host. In
+// particular, a leading "-" would be taken as an option rather than a
+// host, and whitespace would split it into several arguments.
+func ValidateSSHProxyJump(proxyJump string) error {
+	user, host := "", proxyJump
+	if at := strings.Index(proxyJump, "@"); at != -1 {
+		user, host = proxyJump[:at], proxyJump[at+1:]
+		if !validProxyJumpPart(user) {
+			return errors.NotValidf("SSH proxy jump %q, expected [<user>@]<host>", proxyJump)
+		}
+	}
+	if !validProxyJumpPart(host) || strings.Contains(host, "@") {
+		return errors.NotValidf("SSH proxy jump %q, expected [<user>@]<host>", proxyJump)
+	}
+	return nil
+}
+
+func validProxyJumpPart(s string) bool {
+	return s != "" && !strings.HasPrefix(s, "-") && strings.IndexFunc(s, isSpaceOrControl) == -1
+}
+
+func isSpaceOrControl(r rune) bool {
+	return r <= ' ' || r == 0x7f
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/network"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ProxyJumpSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ProxyJumpSuite{})
+
+func (s *ProxyJumpSuite) TestValidateSSHProxyJump(c *gc.C) {
+	for _, valid := range []string{
+		"bastion",
+		"ubuntu@bastion.example.com",
+		"ubuntu@10.0.0.1",
+	} {
+		c.Check(network.ValidateSSHProxyJump(valid), jc.ErrorIsNil, gc.Commentf("%q", valid))
+	}
+}
+
+func (s *ProxyJumpSuite) TestValidateSSHProxyJumpInvalid(c *gc.C) {
+	for _, invalid := range []string{
+		"",
+		"-oProxyCommand=touch /tmp/pwned",
+		"-oProxyCommand=id",
+		"ubuntu@-oProxyCommand=id",
+		"-ubuntu@bastion",
+		"ubuntu@",
+		"@bastion",
+		"ubuntu@bastion@other",
+		"bastion other",
+		"ubuntu@bastion\tother",
+		"bastion\n",
+	} {
+		err := network.ValidateSSHProxyJump(invalid)
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("%q", invalid))
+	}
+}
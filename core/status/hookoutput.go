@@ -0,0 +1,19 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+// MaxHookOutputSize is the maximum number of bytes of a hook's output
+// that is kept in a unit's hook history. Longer output is truncated,
+// keeping the end of the output as that is where errors are usually
+// reported.
+const MaxHookOutputSize = 4096
+
+// TruncateHookOutput returns at most the last MaxHookOutputSize bytes
+// of a hook's output.
+func TruncateHookOutput(output string) string {
+	if len(output) <= MaxHookOutputSize {
+		return output
+	}
+	return output[len(output)-MaxHookOutputSize:]
+}
@@ -211,7 +211,8 @@ const (
 	Suspending Status = "suspending"
 
 	// Suspended is used to signify that a relation is temporarily broken pending
-	// action to resume it.
+	// action to resume it. It is also set on machines whose instances have
+	// been powered off with suspend-machine.
 	Suspended Status = "suspended"
 )
 
@@ -230,6 +231,11 @@ const (
 	Provisioning      Status = "allocating"
 	Running           Status = "running"
 	ProvisioningError Status = "provisioning error"
+
+	// Interrupted indicates that the cloud has reclaimed a spot or
+	// preemptible instance. The model's spot-interruption-policy
+	// decides whether the machine's units are replaced.
+	Interrupted Status = "interrupted"
 )
 
 // ModificationStatus
@@ -266,6 +272,7 @@ func (status Status) KnownInstanceStatus() bool {
 		ProvisioningError,
 		Allocating,
 		Running,
+		Interrupted,
 		Error,
 		Unknown:
 		return true
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/tools"
 )
@@ -38,6 +39,12 @@ type StartInstanceParams struct {
 	// InstanceConfig describes the machine's configuration.
 	InstanceConfig *instancecfg.InstanceConfig
 
+	// ResourceTagVars holds the values of the variables in the model's
+	// resource tags which apply to all of its resources, for tagging
+	// the resources other than the instance, such as security groups,
+	// which are created when starting it.
+	ResourceTagVars tags.TemplateVars
+
 	// Placement, if non-empty, contains an environment-specific
 	// placement directive that may be used to decide how the
 	// instance should be started.
@@ -175,6 +182,38 @@ type InstanceBroker interface {
 	MaintainInstance(ctx context.ProviderCallContext, args StartInstanceParams) error
 }
 
+// InstanceResizer is an optional interface implemented by InstanceBrokers
+// that can change the hardware of an existing instance in place.
+type InstanceResizer interface {
+	// ResizeInstance stops the instance with the given ID, changes its
+	// hardware to satisfy the given constraints and starts it again.
+	// It returns the hardware characteristics of the resized instance.
+	ResizeInstance(
+		ctx context.ProviderCallContext, id instance.Id, cons constraints.Value,
+	) (*instance.HardwareCharacteristics, error)
+}
+
+// InstanceSuspender is an optional interface implemented by InstanceBrokers
+// that can power instances off and on again without terminating them.
+// A suspended instance keeps its ID, disks and addresses.
+type InstanceSuspender interface {
+	// SuspendInstances powers off the instances with the given IDs.
+	SuspendInstances(ctx context.ProviderCallContext, ids ...instance.Id) error
+
+	// ResumeInstances powers on the suspended instances with the
+	// given IDs.
+	ResumeInstances(ctx context.ProviderCallContext, ids ...instance.Id) error
+}
+
+// InstanceMigrator is an optional interface implemented by InstanceBrokers
+// whose instances can be moved between the hosts of a cluster.
+type InstanceMigrator interface {
+	// MigrateInstance moves the instance with the given ID to the named
+	// cluster member, keeping its ID, and returns the name of the
+	// availability zone the instance is now in.
+	MigrateInstance(ctx context.ProviderCallContext, id instance.Id, member string) (string, error)
+}
+
 // LXDProfiler defines an interface for dealing with lxd profiles used to
 // deploy juju machines and containers.
 type LXDProfiler interface {
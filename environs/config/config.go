@@ -25,6 +25,7 @@ import (
 
 	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
@@ -226,6 +227,20 @@ const (
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
+	// HookTimeout is the maximum time a charm hook may run for before the
+	// uniter kills it and puts the unit into an error state, eg "30m".
+	// A zero value means hooks may run indefinitely. A charm may
+	// override it with a hook-timeout option in its own config.
+	HookTimeout = "hook-timeout"
+
+	// SuspendSchedule is a weekly schedule, in UTC, of when the model's
+	// machines are powered down, eg "Mon-Fri 20:00-07:00".
+	SuspendSchedule = "suspend-schedule"
+
+	// SpotInterruptionPolicy is what Juju does when the cloud interrupts
+	// a spot or preemptible instance, eg "add-unit".
+	SpotInterruptionPolicy = "spot-interruption-policy"
+
 	// EgressSubnets are the source addresses from which traffic from this model
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
@@ -423,6 +438,18 @@ const (
 	// DefaultUpdateStatusHookInterval is the default value for UpdateStatusHookInterval
 	DefaultUpdateStatusHookInterval = "5m"
 
+	// DefaultHookTimeout is the default value for HookTimeout; hooks
+	// are not timed out unless the operator asks for it.
+	DefaultHookTimeout = "0s"
+
+	// SpotInterruptionPolicyNone leaves the units of interrupted
+	// instances to the operator.
+	SpotInterruptionPolicyNone = "none"
+
+	// SpotInterruptionPolicyAddUnit adds a unit, on a new machine, in
+	// place of each principal unit of an interrupted instance.
+	SpotInterruptionPolicyAddUnit = "add-unit"
+
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
@@ -467,6 +494,9 @@ var defaultConfigValues = map[string]interface{}{
 	"test-mode":                   false,
 	TransmitVendorMetricsKey:      true,
 	UpdateStatusHookInterval:      DefaultUpdateStatusHookInterval,
+	HookTimeout:                   DefaultHookTimeout,
+	SuspendSchedule:               "",
+	SpotInterruptionPolicy:        SpotInterruptionPolicyNone,
 	EgressSubnets:                 "",
 	FanConfig:                     "",
 	CloudInitUserDataKey:          "",
@@ -666,6 +696,20 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[HookTimeout].(string); ok && v != "" {
+		if f, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid hook timeout in model configuration")
+		} else if f < 0 {
+			return errors.Errorf("hook timeout %v cannot be negative", f)
+		}
+	}
+
+	if v, ok := cfg.defined[SuspendSchedule].(string); ok && v != "" {
+		if _, err := model.ParseSuspendSchedule(v); err != nil {
+			return errors.Annotate(err, "invalid suspend schedule in model configuration")
+		}
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -1354,6 +1398,7 @@ func (c *Config) StorageDefaultFilesystemSource() (string, bool) {
 // that Juju creates and manages, if the provider supports them. These
 // tags have no special meaning to Juju, but may be used for existing
 // chargeback accounting schemes or other identification purposes.
+// The tag values may contain variables; see tags.TemplateVars.
 func (c *Config) ResourceTags() (map[string]string, bool) {
 	tags, err := c.resourceTags()
 	if err != nil {
@@ -1367,10 +1412,13 @@ func (c *Config) resourceTags() (map[string]string, error) {
 	if !ok {
 		return nil, nil
 	}
-	for k := range v {
+	for k, value := range v {
 		if strings.HasPrefix(k, tags.JujuTagPrefix) {
 			return nil, errors.Errorf("tag %q uses reserved prefix %q", k, tags.JujuTagPrefix)
 		}
+		if err := tags.ValidateTemplate(value); err != nil {
+			return nil, errors.Annotatef(err, "tag %q", k)
+		}
 	}
 	return v, nil
 }
@@ -1419,6 +1467,33 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// HookTimeout is the maximum time a charm hook may run before it is
+// killed. A zero duration means no timeout is enforced.
+func (c *Config) HookTimeout() time.Duration {
+	raw := c.asString(HookTimeout)
+	if raw == "" {
+		return 0
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
+// SuspendSchedule returns the weekly schedule of when the model is
+// suspended, or "" if it is only suspended on request.
+func (c *Config) SuspendSchedule() string {
+	return c.asString(SuspendSchedule)
+}
+
+// SpotInterruptionPolicy returns what is done with the units of
+// machines whose spot instances are interrupted.
+func (c *Config) SpotInterruptionPolicy() string {
+	if policy := c.asString(SpotInterruptionPolicy); policy != "" {
+		return policy
+	}
+	return SpotInterruptionPolicyNone
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1588,6 +1663,9 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsAge:           schema.Omit,
 	MaxActionResultsSize:          schema.Omit,
 	UpdateStatusHookInterval:      schema.Omit,
+	HookTimeout:                   schema.Omit,
+	SuspendSchedule:               schema.Omit,
+	SpotInterruptionPolicy:        schema.Omit,
 	EgressSubnets:                 schema.Omit,
 	FanConfig:                     schema.Omit,
 	CloudInitUserDataKey:          schema.Omit,
@@ -1962,7 +2040,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	ResourceTagsKey: {
-		Description: "resource tags",
+		Description: `A space-separated list of key=value tags to set on the cloud resources Juju creates. Values may use the variables ${model}, ${owner}, ${application} and ${unit}`,
 		Type:        environschema.Tattrs,
 		Group:       environschema.EnvironGroup,
 	},
@@ -2071,6 +2149,22 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	HookTimeout: {
+		Description: "The maximum time a charm hook may run before it is killed, in human-readable time format (default 0s, meaning no timeout)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SuspendSchedule: {
+		Description: `A weekly schedule, in UTC, of when the model's machines are powered down, eg "Mon-Fri 20:00-07:00; Sat,Sun 00:00-24:00"`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SpotInterruptionPolicy: {
+		Description: `What to do when the cloud interrupts a spot instance: "none" leaves its units to the operator, "add-unit" adds a unit on a new machine in place of each of its units (default none)`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{SpotInterruptionPolicyNone, SpotInterruptionPolicyAddUnit},
+		Group:       environschema.EnvironGroup,
+	},
 	EgressSubnets: {
 		Description: "Source address(es) for traffic originating from this model",
 		Type:        environschema.Tstring,
@@ -469,6 +469,13 @@ var configTests = []configTest{
 			"resource-tags": []string{"a"},
 		}),
 		err: `resource-tags: expected "key=value", got "a"`,
+	}, {
+		about:       "Resource tags with unknown template variable",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"resource-tags": "project=${team}",
+		}),
+		err: `validating resource tags: tag "project": unknown variable \$\{team\}, expected one of .*`,
 	}, {
 		about:       "Invalid syslog ca cert format",
 		useDefaults: config.UseDefaults,
@@ -603,6 +610,34 @@ var configTests = []configTest{
 			"charm-hub-url": "meshuggah",
 		}),
 		err: `charm-hub url "meshuggah" not valid`,
+	}, {
+		about:       "Invalid hook-timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "forever",
+		}),
+		err: `invalid hook timeout in model configuration: time: invalid duration "?forever"?`,
+	}, {
+		about:       "Negative hook-timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "-5m",
+		}),
+		err: `hook timeout -5m0s cannot be negative`,
+	}, {
+		about:       "Invalid suspend-schedule",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"suspend-schedule": "Mon-Fri 20:00",
+		}),
+		err: `invalid suspend schedule in model configuration: time range "20:00" not valid`,
+	}, {
+		about:       "Invalid spot-interruption-policy",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"spot-interruption-policy": "replace-everything",
+		}),
+		err: `spot-interruption-policy: expected one of \[none add-unit\], got "replace-everything"`,
 	},
 }
 
@@ -1181,6 +1216,18 @@ func (s *ConfigSuite) TestCharmHubURLSettingValue(c *gc.C) {
 	c.Assert(chURL, gc.Equals, url)
 }
 
+func (s *ConfigSuite) TestResourceTagsTemplates(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"resource-tags": "owner=${owner} project=${model}-${application}",
+	})
+	resourceTags, ok := config.ResourceTags()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(resourceTags, jc.DeepEquals, map[string]string{
+		"owner":   "${owner}",
+		"project": "${model}-${application}",
+	})
+}
+
 func (s *ConfigSuite) TestNoBothProxy(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{
 		"http-proxy":  "http://user@10.0.0.1",
@@ -1385,6 +1432,42 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestHookTimeoutConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestHookTimeoutConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"hook-timeout": "30m",
+	})
+	c.Assert(cfg.HookTimeout(), gc.Equals, 30*time.Minute)
+}
+
+func (s *ConfigSuite) TestSuspendScheduleConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.SuspendSchedule(), gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestSuspendScheduleConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"suspend-schedule": "Mon-Fri 20:00-07:00",
+	})
+	c.Assert(cfg.SuspendSchedule(), gc.Equals, "Mon-Fri 20:00-07:00")
+}
+
+func (s *ConfigSuite) TestSpotInterruptionPolicyConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.SpotInterruptionPolicy(), gc.Equals, config.SpotInterruptionPolicyNone)
+}
+
+func (s *ConfigSuite) TestSpotInterruptionPolicyConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"spot-interruption-policy": "add-unit",
+	})
+	c.Assert(cfg.SpotInterruptionPolicy(), gc.Equals, config.SpotInterruptionPolicyAddUnit)
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
@@ -150,6 +150,21 @@ func MatchingInstanceTypes(allInstanceTypes []InstanceType, region string, cons
 	return nil, fmt.Errorf("no instance types in %s matching constraints %q", region, origCons)
 }
 
+// ResizeInstanceType returns the cheapest instance type in region that
+// satisfies cons and supports arch, the architecture of the instance being
+// resized; resizing an instance cannot change its architecture.
+func ResizeInstanceType(allInstanceTypes []InstanceType, region, arch string, cons constraints.Value) (InstanceType, error) {
+	if cons.Arch != nil && *cons.Arch != arch {
+		return InstanceType{}, fmt.Errorf("cannot change architecture from %q to %q", arch, *cons.Arch)
+	}
+	cons.Arch = &arch
+	itypes, err := MatchingInstanceTypes(allInstanceTypes, region, cons)
+	if err != nil {
+		return InstanceType{}, err
+	}
+	return itypes[0], nil
+}
+
 // tagsMatch returns if the tags in wanted all exist in have.
 // Note that duplicates of tags are disregarded in both lists
 func tagsMatch(wanted, have []string) bool {
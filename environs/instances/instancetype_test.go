@@ -271,6 +271,24 @@ func (s *instanceTypeSuite) TestGetMatchingInstanceTypesErrors(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `no instance types in test matching constraints "instance-type=dep.medium mem=8192M"`)
 }
 
+func (s *instanceTypeSuite) TestResizeInstanceType(c *gc.C) {
+	itype, err := ResizeInstanceType(instanceTypes, "test", "armhf", constraints.MustParse("mem=2G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(itype.Name, gc.Equals, "m1.medium")
+
+	itype, err = ResizeInstanceType(instanceTypes, "test", "amd64", constraints.MustParse("cores=4"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(itype.Name, gc.Equals, "m1.xlarge")
+}
+
+func (s *instanceTypeSuite) TestResizeInstanceTypeErrors(c *gc.C) {
+	_, err := ResizeInstanceType(instanceTypes, "test", "amd64", constraints.MustParse("arch=armhf"))
+	c.Check(err, gc.ErrorMatches, `cannot change architecture from "amd64" to "armhf"`)
+
+	_, err = ResizeInstanceType(instanceTypes, "test", "armhf", constraints.MustParse("cores=4"))
+	c.Check(err, gc.ErrorMatches, `no instance types in test matching constraints "arch=armhf cores=4"`)
+}
+
 var instanceTypeMatchTests = []struct {
 	cons   string
 	itype  string
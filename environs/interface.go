@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/storage"
 )
 
@@ -443,6 +444,15 @@ type InstanceLister interface {
 	Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error)
 }
 
+// InterruptedInstanceLister is implemented by environs that can start
+// interruptible (spot or preemptible) instances.
+type InterruptedInstanceLister interface {
+	// InterruptedInstances returns the instances, of those with the
+	// given ids, that the cloud has reclaimed. Each is mapped to a
+	// message describing the interruption.
+	InterruptedInstances(ctx context.ProviderCallContext, ids []instance.Id) (map[instance.Id]string, error)
+}
+
 // PrecheckInstanceParams contains the parameters for
 // InstancePrechecker.PrecheckInstance.
 type PrecheckInstanceParams struct {
@@ -455,6 +465,11 @@ type PrecheckInstanceParams struct {
 	// Placement contains the machine placement directive, if any.
 	Placement string
 
+	// NumUnits is the number of units which will be started with the
+	// constraints, for providers where each unit gets its own instance.
+	// Zero is treated as one.
+	NumUnits int
+
 	// VolumeAttachments contains the parameters for attaching existing
 	// volumes to the instance. The PrecheckInstance method should not
 	// expect the attachment's Machine field to be set, as PrecheckInstance
@@ -499,6 +514,20 @@ type InstanceTagger interface {
 	TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error
 }
 
+// ResourceRetagger is an interface that can be used for updating the
+// tags of a model's resources when its resource tags change.
+type ResourceRetagger interface {
+	// RetagResources sets the model's resource tags on the resources
+	// created for the model other than its instances, such as security
+	// groups and volumes. The tag values are expanded with vars, and
+	// with the applications and units each resource serves.
+	//
+	// The tags named in removed, which are no longer in the model's
+	// resource tags, are deleted from all of the model's resources,
+	// including its instances.
+	RetagResources(ctx context.ProviderCallContext, vars tags.TemplateVars, removed []string) error
+}
+
 // InstanceTypesFetcher is an interface that allows for instance information from
 // a provider to be obtained.
 type InstanceTypesFetcher interface {
@@ -7,6 +7,7 @@ import (
 	"net"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/v2/ssh"
 
 	"github.com/juju/juju/apiserver/params"
 )
@@ -15,6 +16,24 @@ var netLookupHost = net.LookupHost
 
 const ManualInstancePrefix = "manual:"
 
+// ProxyJumpCommand returns the SSH proxy command used to reach a host
+// through the given "[user@]host" jump host. It is equivalent to
+// OpenSSH's ProxyJump option, but also works with the Go SSH client.
+// The jump host follows "--", so that it is never taken as an option.
+func ProxyJumpCommand(proxyJump string) []string {
+	return []string{"ssh", "-W", "%h:%p", "--", proxyJump}
+}
+
+// SSHOptions returns the options used for SSH connections to a manually
+// provisioned host, which go through proxyJump if it is not empty.
+func SSHOptions(proxyJump string) *ssh.Options {
+	var options ssh.Options
+	if proxyJump != "" {
+		options.SetProxyCommand(ProxyJumpCommand(proxyJump)...)
+	}
+	return &options
+}
+
 // RecordMachineInState records and saves into the state machine the provisioned machine
 func RecordMachineInState(client ProvisioningClientAPI, machineParams params.AddMachineParams) (machineId string, err error) {
 	results, err := client.AddMachines([]params.AddMachineParams{machineParams})
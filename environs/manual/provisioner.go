@@ -30,6 +30,10 @@ type ProvisionMachineArgs struct {
 	Host string
 	User string
 
+	// ProxyJump, if non-empty, is the "[user@]host" of an SSH jump host
+	// through which Host is reached. It is not supported over WinRM.
+	ProxyJump string
+
 	// DataDir is the root directory for juju data.
 	// If left blank, the default location "/var/lib/juju" will be used.
 	DataDir string
@@ -5,5 +5,6 @@
 package sshprovisioner
 
 const (
-	DetectionScript = detectionScript
+	DetectionScript   = detectionScript
+	ReplaceKeysScript = replaceKeysScript
 )
@@ -5,9 +5,13 @@
 package sshprovisioner_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/v2"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/environs/manual/sshprovisioner"
@@ -29,7 +33,7 @@ func (s *initialisationSuite) TestDetectSeries(c *gc.C) {
 		"processor: 0",
 	}, "\n")
 	defer installFakeSSH(c, sshprovisioner.DetectionScript, response, 0)()
-	_, series, err := sshprovisioner.DetectSeriesAndHardwareCharacteristics("whatever")
+	_, series, err := sshprovisioner.DetectSeriesAndHardwareCharacteristics("whatever", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(series, gc.Equals, "edgy")
 }
@@ -44,11 +48,11 @@ func (s *initialisationSuite) TestDetectionError(c *gc.C) {
 	// if the script fails for whatever reason, then checkProvisioned
 	// will return an error. stderr will be included in the error message.
 	defer installFakeSSH(c, sshprovisioner.DetectionScript, []string{scriptResponse, "oh noes"}, 33)()
-	hc, _, err := sshprovisioner.DetectSeriesAndHardwareCharacteristics("hostname")
+	hc, _, err := sshprovisioner.DetectSeriesAndHardwareCharacteristics("hostname", "")
 	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 33 \\(oh noes\\)")
 	// if the script doesn't fail, stderr is simply ignored.
 	defer installFakeSSH(c, sshprovisioner.DetectionScript, []string{scriptResponse, "non-empty-stderr"}, 0)()
-	hc, _, err = sshprovisioner.DetectSeriesAndHardwareCharacteristics("hostname")
+	hc, _, err = sshprovisioner.DetectSeriesAndHardwareCharacteristics("hostname", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(hc.String(), gc.Equals, "arch=armhf cores=1 mem=4M")
 }
@@ -120,7 +124,7 @@ func (s *initialisationSuite) TestDetectHardwareCharacteristics(c *gc.C) {
 		c.Logf("test %d: %s", i, test.summary)
 		scriptResponse := strings.Join(test.scriptResponse, "\n")
 		defer installFakeSSH(c, sshprovisioner.DetectionScript, scriptResponse, 0)()
-		hc, _, err := sshprovisioner.DetectSeriesAndHardwareCharacteristics("hostname")
+		hc, _, err := sshprovisioner.DetectSeriesAndHardwareCharacteristics("hostname", "")
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(hc.String(), gc.Equals, test.expectedHc)
 	}
@@ -129,48 +133,83 @@ func (s *initialisationSuite) TestDetectHardwareCharacteristics(c *gc.C) {
 func (s *initialisationSuite) TestCheckProvisioned(c *gc.C) {
 	listCmd := service.ListServicesScript()
 	defer installFakeSSH(c, listCmd, "", 0)()
-	provisioned, err := sshprovisioner.CheckProvisioned("example.com")
+	provisioned, err := sshprovisioner.CheckProvisioned("example.com", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(provisioned, jc.IsFalse)
 
 	defer installFakeSSH(c, listCmd, "snap.juju.fetch-oci", 0)()
-	provisioned, err = sshprovisioner.CheckProvisioned("example.com")
+	provisioned, err = sshprovisioner.CheckProvisioned("example.com", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(provisioned, jc.IsFalse)
 
 	defer installFakeSSH(c, listCmd, "jujud-machine-42", 0)()
-	provisioned, err = sshprovisioner.CheckProvisioned("example.com")
+	provisioned, err = sshprovisioner.CheckProvisioned("example.com", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(provisioned, jc.IsTrue)
 
 	// stderr should not affect result.
 	defer installFakeSSH(c, listCmd, []string{"", "non-empty-stderr"}, 0)()
-	provisioned, err = sshprovisioner.CheckProvisioned("example.com")
+	provisioned, err = sshprovisioner.CheckProvisioned("example.com", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(provisioned, jc.IsFalse)
 
 	// if the script fails for whatever reason, then checkProvisioned
 	// will return an error. stderr will be included in the error message.
 	defer installFakeSSH(c, listCmd, []string{"non-empty-stdout", "non-empty-stderr"}, 255)()
-	_, err = sshprovisioner.CheckProvisioned("example.com")
+	_, err = sshprovisioner.CheckProvisioned("example.com", "")
 	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 255 \\(non-empty-stderr\\)")
 }
 
 func (s *initialisationSuite) TestInitUbuntuUserNonExisting(c *gc.C) {
 	defer installFakeSSH(c, "", "", 0)() // successful creation of ubuntu user
 	defer installFakeSSH(c, "", "", 1)() // simulate failure of ubuntu@ login
-	err := sshprovisioner.InitUbuntuUser("testhost", "testuser", "", nil, nil)
+	err := sshprovisioner.InitUbuntuUser("testhost", "testuser", "", "", nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *initialisationSuite) TestInitUbuntuUserExisting(c *gc.C) {
 	defer installFakeSSH(c, "", nil, 0)()
-	sshprovisioner.InitUbuntuUser("testhost", "testuser", "", nil, nil)
+	sshprovisioner.InitUbuntuUser("testhost", "testuser", "", "", nil, nil)
 }
 
 func (s *initialisationSuite) TestInitUbuntuUserError(c *gc.C) {
 	defer installFakeSSH(c, "", []string{"", "failed to create ubuntu user"}, 123)()
 	defer installFakeSSH(c, "", "", 1)() // simulate failure of ubuntu@ login
-	err := sshprovisioner.InitUbuntuUser("testhost", "testuser", "", nil, nil)
+	err := sshprovisioner.InitUbuntuUser("testhost", "testuser", "", "", nil, nil)
 	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 123 \\(failed to create ubuntu user\\)")
 }
+
+func (s *initialisationSuite) TestCheckProvisionedProxyJump(c *gc.C) {
+	// Record the arguments passed to ssh.
+	fakebin := c.MkDir()
+	argsFile := filepath.Join(fakebin, "args")
+	script := fmt.Sprintf("#!/bin/bash --norc\necho \"$@\" > %s\nhead >/dev/null\n", argsFile)
+	err := ioutil.WriteFile(filepath.Join(fakebin, "ssh"), []byte(script), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchEnvPathPrepend(fakebin)
+
+	_, err = sshprovisioner.CheckProvisioned("example.com", "admin@bastion")
+	c.Assert(err, jc.ErrorIsNil)
+	args, err := ioutil.ReadFile(argsFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(args), jc.Contains, `-o ProxyCommand ssh -W %h:%p admin@bastion`)
+	c.Assert(string(args), jc.Contains, "ubuntu@example.com")
+}
+
+func (s *initialisationSuite) TestReplaceProvisioningKeys(c *gc.C) {
+	expected := fmt.Sprintf(sshprovisioner.ReplaceKeysScript, utils.ShQuote("ssh-rsa new-key"))
+	defer installFakeSSH(c, expected, "", 0)()
+	err := sshprovisioner.ReplaceProvisioningKeys("testhost", "ssh-rsa new-key", "")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *initialisationSuite) TestReplaceProvisioningKeysError(c *gc.C) {
+	defer installFakeSSH(c, nil, []string{"", "permission denied"}, 255)()
+	err := sshprovisioner.ReplaceProvisioningKeys("testhost", "ssh-rsa new-key", "")
+	c.Assert(err, gc.ErrorMatches, `subprocess encountered error code 255 \(permission denied\)`)
+}
+
+func (s *initialisationSuite) TestReplaceProvisioningKeysEmpty(c *gc.C) {
+	err := sshprovisioner.ReplaceProvisioningKeys("testhost", "\n", "")
+	c.Assert(err, gc.ErrorMatches, "empty authorized keys not valid")
+}
@@ -39,7 +39,10 @@ import (
 //
 // authorizedKeys may be empty, in which case the file
 // will be created and left empty.
-func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io.Writer) error {
+//
+// If proxyJump is not empty, the host is reached through
+// that "[user@]host" SSH jump host.
+func InitUbuntuUser(host, login, authorizedKeys, proxyJump string, read io.Reader, write io.Writer) error {
 	logger.Infof("initialising %q, user %q", host, login)
 
 	// To avoid unnecessary prompting for the specified login,
@@ -49,7 +52,7 @@ func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io
 	//
 	// Note that we explicitly do not allocate a PTY, so we
 	// get a failure if sudo prompts.
-	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, manual.SSHOptions(proxyJump))
 	if cmd.Run() == nil {
 		logger.Infof("ubuntu user is already initialised")
 		return nil
@@ -61,10 +64,10 @@ func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io
 		host = login + "@" + host
 	}
 	script := fmt.Sprintf(initUbuntuScript, utils.ShQuote(authorizedKeys))
-	var options ssh.Options
+	options := manual.SSHOptions(proxyJump)
 	options.AllowPasswordAuthentication()
 	options.EnablePTY()
-	cmd = ssh.Command(host, []string{"sudo", "/bin/bash -c " + utils.ShQuote(script)}, &options)
+	cmd = ssh.Command(host, []string{"sudo", "/bin/bash -c " + utils.ShQuote(script)}, options)
 	var stderr bytes.Buffer
 	cmd.Stdin = read
 	cmd.Stdout = write
@@ -93,14 +96,47 @@ if [ ! -z "$authorized_keys" ]; then
     su ubuntu -c 'printf "%%s\n" "$authorized_keys" >> ~/.ssh/authorized_keys'
 fi`
 
+// ReplaceProvisioningKeys replaces the keys in the ubuntu user's
+// ~/.ssh/authorized_keys on the host that were not added by Juju,
+// such as those added by InitUbuntuUser, with authorizedKeys. Keys
+// managed by Juju, whose comments have the "Juju:" prefix, are kept.
+func ReplaceProvisioningKeys(host, authorizedKeys, proxyJump string) error {
+	if strings.TrimSpace(authorizedKeys) == "" {
+		return errors.NotValidf("empty authorized keys")
+	}
+	logger.Infof("replacing authorized keys on %q", host)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, manual.SSHOptions(proxyJump))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdin = strings.NewReader(fmt.Sprintf(replaceKeysScript, utils.ShQuote(authorizedKeys)))
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() != 0 {
+			err = fmt.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
+		}
+		return err
+	}
+	return nil
+}
+
+const replaceKeysScript = `
+set -e
+umask 0077
+keys=~/.ssh/authorized_keys
+temp=$(mktemp)
+(grep ' Juju:' $keys || true) > $temp
+export authorized_keys=%s
+printf "%%s\n" "$authorized_keys" >> $temp
+install -m 0600 $temp $keys
+rm $temp`
+
 // DetectSeriesAndHardwareCharacteristics detects the OS
 // series and hardware characteristics of the remote machine
 // by connecting to the machine and executing a bash script.
 var DetectSeriesAndHardwareCharacteristics = detectSeriesAndHardwareCharacteristics
 
-func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCharacteristics, series string, err error) {
+func detectSeriesAndHardwareCharacteristics(host, proxyJump string) (hc instance.HardwareCharacteristics, series string, err error) {
 	logger.Infof("Detecting series and characteristics on %s", host)
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, manual.SSHOptions(proxyJump))
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -163,12 +199,12 @@ func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCh
 // exist on the host machine.
 var CheckProvisioned = checkProvisioned
 
-func checkProvisioned(host string) (bool, error) {
+func checkProvisioned(host, proxyJump string) (bool, error) {
 	logger.Infof("Checking if %s is already provisioned", host)
 
 	script := service.ListServicesScript()
 
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, manual.SSHOptions(proxyJump))
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -210,7 +246,7 @@ cat /proc/cpuinfo`
 // The hostname supplied should not include a username.
 // If we can, we will reverse lookup the hostname by its IP address, and use
 // the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
+func gatherMachineParams(hostname, proxyJump string) (*params.AddMachineParams, error) {
 
 	// Generate a unique nonce for the machine.
 	uuid, err := utils.NewUUID()
@@ -223,7 +259,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, errors.Annotatef(err, "failed to compute public address for %q", hostname)
 	}
 
-	provisioned, err := checkProvisioned(hostname)
+	provisioned, err := checkProvisioned(hostname, proxyJump)
 	if err != nil {
 		return nil, errors.Annotatef(err, "error checking if provisioned")
 	}
@@ -231,7 +267,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, manual.ErrProvisioned
 	}
 
-	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname)
+	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname, proxyJump)
 	if err != nil {
 		return nil, errors.Annotatef(err, "error detecting linux hardware characteristics")
 	}
@@ -250,13 +286,15 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		Nonce:                   nonce,
 		Addrs:                   params.FromProviderAddresses(addr),
 		Jobs:                    []model.MachineJob{model.JobHostUnits},
+		SSHProxyJump:            proxyJump,
 	}
 	return machineParams, nil
 }
 
-func runProvisionScript(script, host string, progressWriter io.Writer) error {
+func runProvisionScript(script, host, proxyJump string, progressWriter io.Writer) error {
 	params := sshinit.ConfigureParams{
 		Host:           "ubuntu@" + host,
+		SSHOptions:     manual.SSHOptions(proxyJump),
 		ProgressWriter: progressWriter,
 	}
 	return sshinit.RunConfigureScript(script, params)
@@ -51,11 +51,12 @@ type ResourceTagger interface {
 }
 
 // ResourceTags returns tags to set on an infrastructure resource
-// for the specified Juju environment.
+// for the specified Juju environment. Variables in the values of
+// the taggers' tags are expanded; see WithTemplateVars.
 func ResourceTags(modelTag names.ModelTag, controllerTag names.ControllerTag, taggers ...ResourceTagger) map[string]string {
 	allTags := make(map[string]string)
 	for _, tagger := range taggers {
-		tags, ok := tagger.ResourceTags()
+		tags, ok := ExpandedResourceTags(tagger)
 		if !ok {
 			continue
 		}
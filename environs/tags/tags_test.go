@@ -71,3 +71,86 @@ type resourceTagger func() (map[string]string, bool)
 func (r resourceTagger) ResourceTags() (map[string]string, bool) {
 	return r()
 }
+
+func (*tagsSuite) TestResourceTagsTemplates(c *gc.C) {
+	tagger := resourceTagger(func() (map[string]string, bool) {
+		return map[string]string{
+			"owner":       "${owner}",
+			"project":     "${model}-${application}",
+			"unit":        "${unit}",
+			"cost-center": "cc-1",
+		}, true
+	})
+	testResourceTags(c, testing.ControllerTag, testing.ModelTag, []tags.ResourceTagger{
+		tags.WithTemplateVars(tags.WithTemplateVars(tagger, tags.TemplateVars{
+			Model: "mymodel",
+			Owner: "bob",
+		}), tags.TemplateVars{
+			Application: "mysql",
+			Unit:        "mysql/0",
+		}),
+	}, map[string]string{
+		"juju-model-uuid":      testing.ModelTag.Id(),
+		"juju-controller-uuid": testing.ControllerTag.Id(),
+		"owner":                "bob",
+		"project":              "mymodel-mysql",
+		"unit":                 "mysql/0",
+		"cost-center":          "cc-1",
+	})
+}
+
+func (*tagsSuite) TestResourceTagsTemplatesModelName(c *gc.C) {
+	testResourceTags(c, testing.ControllerTag, testing.ModelTag, []tags.ResourceTagger{
+		namedResourceTagger{"mymodel", map[string]string{
+			"project": "${model}",
+			"owner":   "${owner}",
+		}},
+	}, map[string]string{
+		"juju-model-uuid":      testing.ModelTag.Id(),
+		"juju-controller-uuid": testing.ControllerTag.Id(),
+		"project":              "mymodel",
+		"owner":                "",
+	})
+}
+
+func (*tagsSuite) TestValidateTemplate(c *gc.C) {
+	for _, value := range []string{"", "plain", "${model}", "$owner-${application}/${unit}"} {
+		c.Check(tags.ValidateTemplate(value), jc.ErrorIsNil)
+	}
+	err := tags.ValidateTemplate("${model}-${team}")
+	c.Assert(err, gc.ErrorMatches, `unknown variable \${team}, expected one of \${model}, \${owner}, \${application}, \${unit}`)
+}
+
+func (*tagsSuite) TestResourceTemplateVars(c *gc.C) {
+	vars := tags.ResourceTemplateVars(map[string]string{
+		tags.JujuUnitsDeployed: "wordpress/0 mysql/1 wordpress/1",
+	})
+	c.Assert(vars, jc.DeepEquals, tags.TemplateVars{
+		Application: "mysql wordpress",
+		Unit:        "wordpress/0 mysql/1 wordpress/1",
+	})
+
+	vars = tags.ResourceTemplateVars(map[string]string{
+		tags.JujuStorageOwner:  "postgresql/2",
+		tags.JujuUnitsDeployed: "wordpress/0",
+	})
+	c.Assert(vars, jc.DeepEquals, tags.TemplateVars{Application: "postgresql", Unit: "postgresql/2"})
+
+	vars = tags.ResourceTemplateVars(map[string]string{tags.JujuStorageOwner: "postgresql"})
+	c.Assert(vars, jc.DeepEquals, tags.TemplateVars{Application: "postgresql"})
+
+	c.Assert(tags.ResourceTemplateVars(nil), jc.DeepEquals, tags.TemplateVars{})
+}
+
+type namedResourceTagger struct {
+	name string
+	tags map[string]string
+}
+
+func (r namedResourceTagger) Name() string {
+	return r.name
+}
+
+func (r namedResourceTagger) ResourceTags() (map[string]string, bool) {
+	return r.tags, true
+}
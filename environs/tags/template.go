@@ -0,0 +1,159 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tags
+
+import (
+	"os"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+)
+
+// TemplateVars holds the values of the variables that may be used in
+// the values of user-specified resource tags, such as "${model}".
+// Variables with no value expand to the empty string.
+type TemplateVars struct {
+	// Model is the name of the model, substituted for ${model}.
+	Model string
+
+	// Owner is the name of the model's owner, substituted
+	// for ${owner}.
+	Owner string
+
+	// Application is the space-separated names of the applications
+	// a resource serves, substituted for ${application}.
+	Application string
+
+	// Unit is the space-separated names of the units a resource
+	// serves, substituted for ${unit}.
+	Unit string
+}
+
+var templateVarNames = []string{"model", "owner", "application", "unit"}
+
+func (v TemplateVars) lookup(name string) (string, bool) {
+	switch name {
+	case "model":
+		return v.Model, true
+	case "owner":
+		return v.Owner, true
+	case "application":
+		return v.Application, true
+	case "unit":
+		return v.Unit, true
+	}
+	return "", false
+}
+
+// merge returns v with its empty fields set from other.
+func (v TemplateVars) merge(other TemplateVars) TemplateVars {
+	if v.Model == "" {
+		v.Model = other.Model
+	}
+	if v.Owner == "" {
+		v.Owner = other.Owner
+	}
+	if v.Application == "" {
+		v.Application = other.Application
+	}
+	if v.Unit == "" {
+		v.Unit = other.Unit
+	}
+	return v
+}
+
+// ResourceTemplateVars returns the values of the application and unit
+// variables for an existing resource, from the Juju tags recording the
+// owner of its storage or the units deployed to it.
+func ResourceTemplateVars(resourceTags map[string]string) TemplateVars {
+	if owner := resourceTags[JujuStorageOwner]; owner != "" {
+		if applicationName, err := names.UnitApplication(owner); err == nil {
+			return TemplateVars{Application: applicationName, Unit: owner}
+		}
+		return TemplateVars{Application: owner}
+	}
+	unitNames := strings.Fields(resourceTags[JujuUnitsDeployed])
+	applicationNames := set.NewStrings()
+	for _, unitName := range unitNames {
+		if applicationName, err := names.UnitApplication(unitName); err == nil {
+			applicationNames.Add(applicationName)
+		}
+	}
+	return TemplateVars{
+		Application: strings.Join(applicationNames.SortedValues(), " "),
+		Unit:        strings.Join(unitNames, " "),
+	}
+}
+
+// ValidateTemplate returns an error if the given resource tag value
+// refers to a variable that is not defined by TemplateVars.
+func ValidateTemplate(value string) error {
+	var unknown []string
+	os.Expand(value, func(name string) string {
+		if _, ok := (TemplateVars{}).lookup(name); !ok {
+			unknown = append(unknown, name)
+		}
+		return ""
+	})
+	if len(unknown) > 0 {
+		return errors.Errorf(
+			"unknown variable ${%s}, expected one of ${%s}",
+			unknown[0], strings.Join(templateVarNames, "}, ${"),
+		)
+	}
+	return nil
+}
+
+// ExpandTemplate returns the given resource tag value with its
+// variables replaced by their values in vars.
+func ExpandTemplate(value string, vars TemplateVars) string {
+	return os.Expand(value, func(name string) string {
+		v, _ := vars.lookup(name)
+		return v
+	})
+}
+
+// WithTemplateVars returns a ResourceTagger whose tags are expanded
+// with the given variables by ResourceTags, in addition to the model
+// name if the tagger is a model config.
+func WithTemplateVars(tagger ResourceTagger, vars TemplateVars) ResourceTagger {
+	if t, ok := tagger.(templateTagger); ok {
+		return templateTagger{t.ResourceTagger, vars.merge(t.vars)}
+	}
+	return templateTagger{tagger, vars}
+}
+
+type templateTagger struct {
+	ResourceTagger
+	vars TemplateVars
+}
+
+// modelNamer is implemented by model configs.
+type modelNamer interface {
+	Name() string
+}
+
+// ExpandedResourceTags returns the tagger's tags with any variables
+// in their values expanded. Unlike ResourceTags, the Juju tags are
+// not added.
+func ExpandedResourceTags(tagger ResourceTagger) (map[string]string, bool) {
+	var vars TemplateVars
+	if t, ok := tagger.(templateTagger); ok {
+		tagger, vars = t.ResourceTagger, t.vars
+	}
+	resourceTags, ok := tagger.ResourceTags()
+	if !ok {
+		return nil, false
+	}
+	if namer, ok := tagger.(modelNamer); ok && vars.Model == "" {
+		vars.Model = namer.Name()
+	}
+	expanded := make(map[string]string, len(resourceTags))
+	for k, v := range resourceTags {
+		expanded[k] = ExpandTemplate(v, vars)
+	}
+	return expanded, true
+}
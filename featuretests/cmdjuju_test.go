@@ -167,18 +167,61 @@ application-config:
     source: user
     type: string
     value: ext-host
+  kubernetes-autoscale-cpu-target:
+    description: target average CPU utilisation percentage when autoscaling
+    source: unset
+    type: int
+  kubernetes-autoscale-max-units:
+    description: maximum units when autoscaling, 0 disables autoscaling
+    source: unset
+    type: int
+  kubernetes-autoscale-min-units:
+    description: minimum units when autoscaling
+    source: unset
+    type: int
+  kubernetes-expose-mode:
+    default: ingress
+    description: how an exposed application is reached from outside the cluster, either
+      ingress or gateway
+    source: default
+    type: string
+    value: ingress
+  kubernetes-gateway-name:
+    description: the Gateway API gateway the HTTPRoute attaches to when the expose
+      mode is gateway
+    source: unset
+    type: string
+  kubernetes-gateway-namespace:
+    description: the namespace of the gateway, defaults to the model namespace
+    source: unset
+    type: string
   kubernetes-ingress-allow-http:
     default: false
     description: whether to allow HTTP traffic to the ingress controller
     source: default
     type: bool
     value: false
+  kubernetes-ingress-cert-cluster-issuer:
+    description: the cert-manager cluster issuer used to obtain a TLS certificate
+      for the external hostname
+    source: unset
+    type: string
+  kubernetes-ingress-cert-issuer:
+    description: the cert-manager issuer used to obtain a TLS certificate for the
+      external hostname
+    source: unset
+    type: string
   kubernetes-ingress-class:
     default: nginx
     description: the class of the ingress controller to be used by the ingress resource
     source: default
     type: string
     value: nginx
+  kubernetes-ingress-endpoint-paths:
+    description: a map of endpoint names to the paths routed to the service ports
+      named after the endpoints
+    source: unset
+    type: attrs
   kubernetes-ingress-ssl-passthrough:
     default: false
     description: whether to passthrough SSL traffic to the ingress controller
@@ -191,6 +234,11 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-tls-secret:
+    description: the name of the secret holding the TLS certificate for the external
+      hostname
+    source: unset
+    type: string
   kubernetes-service-annotations:
     description: a space separated set of annotations to add to the service
     source: unset
@@ -556,6 +556,9 @@ func (env *azureEnviron) StartInstance(ctx context.ProviderCallContext, args env
 	// the Juju machine name. We tag all resources related to the
 	// machine with this.
 	vmTags[jujuMachineNameTag] = vmName
+	if args.Constraints.HasSpot() {
+		vmTags[jujuSpotTag] = "true"
+	}
 
 	// Use a public IP by default unless a constraint
 	// explicitly forbids it.
@@ -803,26 +806,36 @@ func (env *azureEnviron) createVirtualMachine(
 		})
 	}
 
+	properties := &compute.VirtualMachineProperties{
+		HardwareProfile: &compute.HardwareProfile{
+			VMSize: compute.VirtualMachineSizeTypes(
+				instanceSpec.InstanceType.Name,
+			),
+		},
+		StorageProfile: storageProfile,
+		OsProfile:      osProfile,
+		NetworkProfile: &compute.NetworkProfile{
+			&nics,
+		},
+		AvailabilitySet: availabilitySetSubResource,
+	}
+	vmAPIVersion := computeAPIVersion
+	var vmProperties interface{} = properties
+	if args.Constraints.HasSpot() {
+		vmAPIVersion = spotComputeAPIVersion
+		vmProperties, err = newSpotVirtualMachineProperties(properties, args.Constraints)
+		if err != nil {
+			return common.ZoneIndependentError(err)
+		}
+	}
 	resources = append(resources, armtemplates.Resource{
-		APIVersion: computeAPIVersion,
+		APIVersion: vmAPIVersion,
 		Type:       "Microsoft.Compute/virtualMachines",
 		Name:       vmName,
 		Location:   env.location,
 		Tags:       vmTags,
-		Properties: &compute.VirtualMachineProperties{
-			HardwareProfile: &compute.HardwareProfile{
-				VMSize: compute.VirtualMachineSizeTypes(
-					instanceSpec.InstanceType.Name,
-				),
-			},
-			StorageProfile: storageProfile,
-			OsProfile:      osProfile,
-			NetworkProfile: &compute.NetworkProfile{
-				&nics,
-			},
-			AvailabilitySet: availabilitySetSubResource,
-		},
-		DependsOn: vmDependsOn,
+		Properties: vmProperties,
+		DependsOn:  vmDependsOn,
 	})
 
 	// On Windows and CentOS, we must add the CustomScript VM
@@ -673,6 +673,34 @@ func (s *environSuite) assertStartInstance(c *gc.C, wantedRootDisk *int, publicI
 	})
 }
 
+func (s *environSuite) TestStartInstanceSpot(c *gc.C) {
+	s.assertStartInstanceSpot(c, "spot=true", -1)
+}
+
+func (s *environSuite) TestStartInstanceSpotMaxPrice(c *gc.C) {
+	s.assertStartInstanceSpot(c, "spot=true max-price=0.025", 0.025)
+}
+
+func (s *environSuite) assertStartInstanceSpot(c *gc.C, cons string, maxPrice float64) {
+	env := s.openEnviron(c)
+	s.sender = s.startInstanceSenders(false)
+	s.requests = nil
+	args := makeStartInstanceParams(c, s.controllerUUID, "bionic")
+	args.Constraints = constraints.MustParse(cons)
+	_, err := env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertStartInstanceRequests(c, s.requests, assertStartInstanceRequestsParams{
+		imageReference: &xenialImageReference,
+		diskSizeGB:     32,
+		osProfile:      &s.linuxOsProfile,
+		instanceType:   "Standard_A1",
+		publicIP:       true,
+		spot:           true,
+		maxPrice:       maxPrice,
+	})
+}
+
 func (s *environSuite) TestStartInstanceNoAuthorizedKeys(c *gc.C) {
 	env := s.openEnviron(c)
 	cfg, err := env.Config().Remove([]string{"authorized-keys"})
@@ -1076,6 +1104,8 @@ type assertStartInstanceRequestsParams struct {
 	existingNetwork     string
 	subnets             []string
 	placementSubnet     string
+	spot                bool
+	maxPrice            float64
 }
 
 func (s *environSuite) assertStartInstanceRequests(
@@ -1083,6 +1113,14 @@ func (s *environSuite) assertStartInstanceRequests(
 	requests []*http.Request,
 	args assertStartInstanceRequestsParams,
 ) startInstanceRequests {
+	vmTags := s.vmTags
+	if args.spot {
+		vmTags = make(map[string]*string)
+		for k, v := range s.vmTags {
+			vmTags[k] = v
+		}
+		vmTags["juju-spot"] = to.StringPtr("true")
+	}
 	nsgId := `[resourceId('Microsoft.Network/networkSecurityGroups', 'juju-internal-nsg')]`
 	securityRules := []network.SecurityRule{{
 		Name: to.StringPtr("SSHInbound"),
@@ -1326,7 +1364,7 @@ func (s *environSuite) assertStartInstanceRequests(
 			Type:       "Microsoft.Network/networkInterfaces",
 			Name:       "machine-0-" + name,
 			Location:   "westus",
-			Tags:       to.StringMap(s.vmTags),
+			Tags:       to.StringMap(vmTags),
 			Properties: &network.InterfacePropertiesFormat{
 				IPConfigurations: &ipConfigurations,
 			},
@@ -1356,7 +1394,7 @@ func (s *environSuite) assertStartInstanceRequests(
 			Type:       "Microsoft.Network/publicIPAddresses",
 			Name:       "machine-0-public-ip",
 			Location:   "westus",
-			Tags:       to.StringMap(s.vmTags),
+			Tags:       to.StringMap(vmTags),
 			Properties: &network.PublicIPAddressPropertiesFormat{
 				PublicIPAllocationMethod: network.Static,
 				PublicIPAddressVersion:   "IPv4",
@@ -1365,25 +1403,42 @@ func (s *environSuite) assertStartInstanceRequests(
 		})
 	}
 	templateResources = append(templateResources, nicResources...)
+	vmAPIVersion := computeAPIVersion
+	var vmProperties interface{} = &compute.VirtualMachineProperties{
+		HardwareProfile: &compute.HardwareProfile{
+			VMSize: compute.VirtualMachineSizeTypes(args.instanceType),
+		},
+		StorageProfile: &compute.StorageProfile{
+			ImageReference: args.imageReference,
+			OsDisk:         osDisk,
+		},
+		OsProfile:       args.osProfile,
+		NetworkProfile:  &compute.NetworkProfile{&nics},
+		AvailabilitySet: availabilitySetSubResource,
+	}
+	if args.spot {
+		vmAPIVersion = "2019-07-01"
+		vmProperties = map[string]interface{}{
+			"hardwareProfile": map[string]interface{}{"vmSize": args.instanceType},
+			"storageProfile": &compute.StorageProfile{
+				ImageReference: args.imageReference,
+				OsDisk:         osDisk,
+			},
+			"osProfile":      args.osProfile,
+			"networkProfile": &compute.NetworkProfile{&nics},
+			"priority":       "Spot",
+			"evictionPolicy": "Deallocate",
+			"billingProfile": map[string]interface{}{"maxPrice": args.maxPrice},
+		}
+	}
 	templateResources = append(templateResources, []armtemplates.Resource{{
-		APIVersion: computeAPIVersion,
+		APIVersion: vmAPIVersion,
 		Type:       "Microsoft.Compute/virtualMachines",
 		Name:       "machine-0",
 		Location:   "westus",
-		Tags:       to.StringMap(s.vmTags),
-		Properties: &compute.VirtualMachineProperties{
-			HardwareProfile: &compute.HardwareProfile{
-				VMSize: compute.VirtualMachineSizeTypes(args.instanceType),
-			},
-			StorageProfile: &compute.StorageProfile{
-				ImageReference: args.imageReference,
-				OsDisk:         osDisk,
-			},
-			OsProfile:       args.osProfile,
-			NetworkProfile:  &compute.NetworkProfile{&nics},
-			AvailabilitySet: availabilitySetSubResource,
-		},
-		DependsOn: vmDependsOn,
+		Tags:       to.StringMap(vmTags),
+		Properties: vmProperties,
+		DependsOn:  vmDependsOn,
 	}}...)
 	if args.vmExtension != nil {
 		templateResources = append(templateResources, armtemplates.Resource{
@@ -1391,7 +1446,7 @@ func (s *environSuite) assertStartInstanceRequests(
 			Type:       "Microsoft.Compute/virtualMachines/extensions",
 			Name:       "machine-0/JujuCustomScriptExtension",
 			Location:   "westus",
-			Tags:       to.StringMap(s.vmTags),
+			Tags:       to.StringMap(vmTags),
 			Properties: args.vmExtension,
 			DependsOn:  []string{"Microsoft.Compute/virtualMachines/machine-0"},
 		})
@@ -1873,6 +1928,111 @@ func (s *environSuite) TestStopInstancesNoSecurityGroup(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *environSuite) TestResizeInstance(c *gc.C) {
+	env := s.openEnviron(c)
+	vm := compute.VirtualMachine{
+		Name: to.StringPtr("machine-0"),
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			HardwareProfile: &compute.HardwareProfile{VMSize: "Standard_A1"},
+		},
+	}
+	s.sender = azuretesting.Senders{
+		makeSender(".*/virtualMachines/machine-0", vm),             // GET
+		s.resourceSkusSender(),                                     // GET
+		makeSender(".*/virtualMachines/machine-0/deallocate", nil), // POST
+		makeSender(".*/virtualMachines/machine-0", nil),            // PATCH
+		makeSender(".*/virtualMachines/machine-0/start", nil),      // POST
+	}
+	s.requests = nil
+
+	hc, err := env.(environs.InstanceResizer).ResizeInstance(
+		s.callCtx, "machine-0", constraints.MustParse("cores=2"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hc.String(), gc.Equals, "arch=amd64 cores=2 mem=7168M")
+
+	c.Assert(s.requests, gc.HasLen, 5)
+	c.Assert(s.requests[2].Method, gc.Equals, "POST")
+	c.Assert(s.requests[3].Method, gc.Equals, "PATCH")
+	var update compute.VirtualMachineUpdate
+	unmarshalRequestBody(c, s.requests[3], &update)
+	c.Assert(update.HardwareProfile.VMSize, gc.Equals, compute.VirtualMachineSizeTypes("Standard_D2"))
+	c.Assert(s.requests[4].Method, gc.Equals, "POST")
+}
+
+func (s *environSuite) TestResizeInstanceSameSize(c *gc.C) {
+	env := s.openEnviron(c)
+	vm := compute.VirtualMachine{
+		Name: to.StringPtr("machine-0"),
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			HardwareProfile: &compute.HardwareProfile{VMSize: "Standard_D2"},
+		},
+	}
+	s.sender = azuretesting.Senders{
+		makeSender(".*/virtualMachines/machine-0", vm),
+		s.resourceSkusSender(),
+	}
+	s.requests = nil
+
+	hc, err := env.(environs.InstanceResizer).ResizeInstance(
+		s.callCtx, "machine-0", constraints.MustParse("cores=2"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hc.String(), gc.Equals, "arch=amd64 cores=2 mem=7168M")
+	c.Assert(s.requests, gc.HasLen, 2)
+}
+
+func (s *environSuite) TestResizeInstanceArchitecture(c *gc.C) {
+	env := s.openEnviron(c)
+	vm := compute.VirtualMachine{
+		Name: to.StringPtr("machine-0"),
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			HardwareProfile: &compute.HardwareProfile{VMSize: "Standard_A1"},
+		},
+	}
+	s.sender = azuretesting.Senders{
+		makeSender(".*/virtualMachines/machine-0", vm),
+		s.resourceSkusSender(),
+	}
+
+	_, err := env.(environs.InstanceResizer).ResizeInstance(
+		s.callCtx, "machine-0", constraints.MustParse("arch=arm64"),
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot change architecture from "amd64" to "arm64"`)
+}
+
+func (s *environSuite) TestTagInstance(c *gc.C) {
+	env := s.openEnviron(c)
+	vm := compute.VirtualMachine{
+		Name: to.StringPtr("machine-0"),
+		Tags: map[string]*string{
+			"juju-model-uuid": to.StringPtr("model-uuid"),
+			"owner":           to.StringPtr("alice"),
+		},
+	}
+	s.sender = azuretesting.Senders{
+		makeSender(".*/virtualMachines/machine-0", vm),  // GET
+		makeSender(".*/virtualMachines/machine-0", nil), // PATCH
+	}
+	s.requests = nil
+
+	err := env.(environs.InstanceTagger).TagInstance(s.callCtx, "machine-0", map[string]string{
+		"owner":       "bob",
+		"cost-center": "cc-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[1].Method, gc.Equals, "PATCH")
+	var update compute.VirtualMachineUpdate
+	unmarshalRequestBody(c, s.requests[1], &update)
+	c.Assert(to.StringMap(update.Tags), jc.DeepEquals, map[string]string{
+		"juju-model-uuid": "model-uuid",
+		"owner":           "bob",
+		"cost-center":     "cc-1",
+	})
+}
+
 func (s *environSuite) TestStopInstances(c *gc.C) {
 	env := s.openEnviron(c)
 
@@ -2294,6 +2454,112 @@ func (s *environSuite) TestAdoptResources(c *gc.C) {
 	c.Check(gTags[tags.JujuModel], gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
 }
 
+func (s *environSuite) TestRetagResources(c *gc.C) {
+	modelUUID := "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	group := makeResourceGroupResult()
+	group.Tags["team"] = to.StringPtr("a")
+	providers := []resources.Provider{{
+		Namespace: to.StringPtr("Microsoft.Compute"),
+		ResourceTypes: &[]resources.ProviderResourceType{{
+			ResourceType: to.StringPtr("virtualMachines"),
+			APIVersions:  &[]string{"2018-10-01"},
+		}, {
+			ResourceType: to.StringPtr("disks"),
+			APIVersions:  &[]string{"2018-09-30"},
+		}},
+	}, {
+		Namespace: to.StringPtr("Microsoft.Network"),
+		ResourceTypes: &[]resources.ProviderResourceType{{
+			ResourceType: to.StringPtr("networkInterfaces"),
+			APIVersions:  &[]string{"2018-08-01"},
+		}},
+	}}
+	makeResource := func(resourceType, name string, resourceTags map[string]string) resources.GenericResourceExpanded {
+		return resources.GenericResourceExpanded{
+			ID:       to.StringPtr("/subscriptions/foo/resourcegroups/bar/providers/" + resourceType + "/" + name),
+			Name:     to.StringPtr(name),
+			Type:     to.StringPtr(resourceType),
+			Location: to.StringPtr("westus"),
+			Tags:     *to.StringMapPtr(resourceTags),
+		}
+	}
+	vm := makeResource("Microsoft.Compute/virtualMachines", "machine-0", map[string]string{
+		tags.JujuModel:         modelUUID,
+		"juju-machine-name":    "machine-0",
+		tags.JujuUnitsDeployed: "mysql/0",
+		"team":                 "a",
+	})
+	nic := makeResource("Microsoft.Network/networkInterfaces", "machine-0-primary", map[string]string{
+		tags.JujuModel:      modelUUID,
+		"juju-machine-name": "machine-0",
+		"team":              "a",
+	})
+	disk := makeResource("Microsoft.Compute/disks", "volume-0", map[string]string{
+		tags.JujuModel:        modelUUID,
+		tags.JujuStorageOwner: "wordpress/1",
+	})
+	other := makeResource("Microsoft.Compute/disks", "other", map[string]string{
+		"team": "a",
+	})
+	resourcesResult := resources.ListResult{Value: &[]resources.GenericResourceExpanded{vm, nic, disk, other}}
+
+	env := s.openEnviron(c, testing.Attrs{"resource-tags": "owner=${owner} app=${application}"})
+	s.sender = azuretesting.Senders{
+		makeSender(".*/resourcegroups/juju-testmodel-.*", group),
+		makeSender(".*/resourcegroups/juju-testmodel-.*", nil),
+		makeSender(".*/providers", resources.ProviderListResult{Value: &providers}),
+		makeSender(".*/resourceGroups/juju-testmodel-.*/resources", resourcesResult),
+		makeSender(".*/virtualMachines/machine-0", vm),
+		makeSender(".*/virtualMachines/machine-0", vm),
+		makeSender(".*/networkInterfaces/machine-0-primary", nic),
+		makeSender(".*/networkInterfaces/machine-0-primary", nic),
+		makeSender(".*/disks/volume-0", disk),
+		makeSender(".*/disks/volume-0", disk),
+	}
+
+	err := env.(environs.ResourceRetagger).RetagResources(s.callCtx, tags.TemplateVars{Owner: "bob"}, []string{"team"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 10)
+
+	var updatedGroup resources.Group
+	unmarshalRequestBody(c, s.requests[1], &updatedGroup)
+	c.Check(to.StringMap(updatedGroup.Tags), jc.DeepEquals, map[string]string{
+		tags.JujuController: "old-controller",
+		tags.JujuModel:      modelUUID,
+		"something else":    "good",
+		"owner":             "bob",
+		"app":               "",
+	})
+
+	checkTags := func(ix int, apiVersion string, expected map[string]string) {
+		req := s.requests[ix]
+		c.Check(req.Method, gc.Equals, "PUT")
+		c.Check(req.URL.Query().Get("api-version"), gc.Equals, apiVersion)
+		var resource resources.GenericResource
+		unmarshalRequestBody(c, req, &resource)
+		c.Check(to.StringMap(resource.Tags), jc.DeepEquals, expected)
+	}
+	checkTags(5, "2018-10-01", map[string]string{
+		tags.JujuModel:         modelUUID,
+		"juju-machine-name":    "machine-0",
+		tags.JujuUnitsDeployed: "mysql/0",
+		"owner":                "bob",
+		"app":                  "mysql",
+	})
+	checkTags(7, "2018-08-01", map[string]string{
+		tags.JujuModel:      modelUUID,
+		"juju-machine-name": "machine-0",
+		"owner":             "bob",
+		"app":               "mysql",
+	})
+	checkTags(9, "2018-09-30", map[string]string{
+		tags.JujuModel:        modelUUID,
+		tags.JujuStorageOwner: "wordpress/1",
+		"owner":               "bob",
+		"app":                 "wordpress",
+	})
+}
+
 func makeProvidersResult() resources.ProviderListResult {
 	providers := []resources.Provider{{
 		Namespace: to.StringPtr("Beck.Replica"),
@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	stdcontext "context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2/arch"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/azure/internal/errorutils"
+)
+
+var _ environs.InstanceResizer = (*azureEnviron)(nil)
+
+// ResizeInstance is part of the environs.InstanceResizer interface.
+// The virtual machine is deallocated so that it can move to hardware
+// that supports the new size, resized and then started again; its
+// managed disks are kept.
+func (env *azureEnviron) ResizeInstance(
+	ctx context.ProviderCallContext, id instance.Id, cons constraints.Value,
+) (*instance.HardwareCharacteristics, error) {
+	client := compute.VirtualMachinesClient{env.compute}
+	sdkCtx := stdcontext.Background()
+	vmName := string(id)
+	vm, err := client.Get(sdkCtx, env.resourceGroup, vmName, "")
+	if err != nil {
+		if isNotFoundResult(vm.Response) {
+			return nil, errors.NotFoundf("instance %q", id)
+		}
+		return nil, errorutils.HandleCredentialError(errors.Annotatef(err, "getting virtual machine %q", id), ctx)
+	}
+	var currentSize string
+	if vm.VirtualMachineProperties != nil && vm.HardwareProfile != nil {
+		currentSize = string(vm.HardwareProfile.VMSize)
+	}
+
+	instanceTypesMap, err := env.getInstanceTypes(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instanceTypes := make([]instances.InstanceType, 0, len(instanceTypesMap))
+	for _, instanceType := range instanceTypesMap {
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	itype, err := instances.ResizeInstanceType(instanceTypes, env.location, arch.AMD64, cons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if itype.Name != currentSize {
+		logger.Infof("resizing virtual machine %q from %s to %s", id, currentSize, itype.Name)
+		deallocateFuture, err := client.Deallocate(sdkCtx, env.resourceGroup, vmName)
+		if err == nil {
+			err = deallocateFuture.WaitForCompletionRef(sdkCtx, client.Client)
+		}
+		if err != nil {
+			return nil, errorutils.HandleCredentialError(errors.Annotatef(err, "deallocating virtual machine %q", id), ctx)
+		}
+		updateFuture, err := client.Update(sdkCtx, env.resourceGroup, vmName, compute.VirtualMachineUpdate{
+			VirtualMachineProperties: &compute.VirtualMachineProperties{
+				HardwareProfile: &compute.HardwareProfile{
+					VMSize: compute.VirtualMachineSizeTypes(itype.Name),
+				},
+			},
+		})
+		if err == nil {
+			err = updateFuture.WaitForCompletionRef(sdkCtx, client.Client)
+		}
+		if err != nil {
+			// Don't leave the virtual machine deallocated because
+			// the new size is not available.
+			if startErr := env.startVirtualMachine(sdkCtx, client, vmName); startErr != nil {
+				logger.Errorf("cannot restart virtual machine %q: %v", id, startErr)
+			}
+			return nil, errorutils.HandleCredentialError(errors.Annotatef(err, "resizing virtual machine %q", id), ctx)
+		}
+		if err := env.startVirtualMachine(sdkCtx, client, vmName); err != nil {
+			return nil, errorutils.HandleCredentialError(errors.Annotatef(err, "starting virtual machine %q", id), ctx)
+		}
+	}
+
+	instArch := arch.AMD64
+	hc := &instance.HardwareCharacteristics{
+		Arch:     &instArch,
+		Mem:      &itype.Mem,
+		CpuCores: &itype.CpuCores,
+	}
+	if vm.Zones != nil && len(*vm.Zones) > 0 {
+		hc.AvailabilityZone = to.StringPtr((*vm.Zones)[0])
+	}
+	return hc, nil
+}
+
+func (env *azureEnviron) startVirtualMachine(
+	sdkCtx stdcontext.Context, client compute.VirtualMachinesClient, vmName string,
+) error {
+	future, err := client.Start(sdkCtx, env.resourceGroup, vmName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(future.WaitForCompletionRef(sdkCtx, client.Client))
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	stdcontext "context"
+	"strconv"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/azure/internal/errorutils"
+)
+
+const (
+	// spotComputeAPIVersion is the first compute API version that
+	// supports spot virtual machines.
+	spotComputeAPIVersion = "2019-07-01"
+
+	// jujuSpotTag is set to "true" on spot virtual machines.
+	jujuSpotTag = tags.JujuTagPrefix + "spot"
+
+	// deallocatedPowerState is the instance view status code of
+	// virtual machines that have been deallocated, which is what
+	// happens to spot virtual machines when they're evicted.
+	deallocatedPowerState = "PowerState/deallocated"
+)
+
+var _ environs.InterruptedInstanceLister = (*azureEnviron)(nil)
+
+// spotVirtualMachineProperties adds the spot properties, which the
+// compute API version used for everything else doesn't have, to a
+// virtual machine's properties.
+type spotVirtualMachineProperties struct {
+	*compute.VirtualMachineProperties
+	Priority       string               `json:"priority"`
+	EvictionPolicy string               `json:"evictionPolicy"`
+	BillingProfile spotVMBillingProfile `json:"billingProfile"`
+}
+
+type spotVMBillingProfile struct {
+	// MaxPrice is the most to pay per hour for the virtual machine,
+	// or -1 to pay up to the on-demand price.
+	MaxPrice float64 `json:"maxPrice"`
+}
+
+// newSpotVirtualMachineProperties returns the properties of a spot virtual
+// machine, deallocated when evicted, with the given constraints.
+func newSpotVirtualMachineProperties(
+	properties *compute.VirtualMachineProperties, cons constraints.Value,
+) (*spotVirtualMachineProperties, error) {
+	maxPrice := -1.0
+	if cons.HasMaxPrice() {
+		var err error
+		if maxPrice, err = strconv.ParseFloat(*cons.MaxPrice, 64); err != nil {
+			return nil, errors.NotValidf("max-price %q", *cons.MaxPrice)
+		}
+	}
+	return &spotVirtualMachineProperties{
+		VirtualMachineProperties: properties,
+		Priority:                 "Spot",
+		EvictionPolicy:           "Deallocate",
+		BillingProfile:           spotVMBillingProfile{MaxPrice: maxPrice},
+	}, nil
+}
+
+// InterruptedInstances is part of the environs.InterruptedInstanceLister
+// interface.
+func (env *azureEnviron) InterruptedInstances(ctx context.ProviderCallContext, ids []instance.Id) (map[instance.Id]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	wanted := make(map[instance.Id]bool)
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	// Only spot virtual machines are checked, as getting
+	// each one's instance view is a separate request.
+	client := compute.VirtualMachinesClient{env.compute}
+	sdkCtx := stdcontext.Background()
+	vms, err := client.ListComplete(sdkCtx, env.resourceGroup)
+	if err != nil {
+		return nil, errorutils.HandleCredentialError(errors.Annotate(err, "listing virtual machines"), ctx)
+	}
+	var spot []instance.Id
+	for ; vms.NotDone(); err = vms.NextWithContext(sdkCtx) {
+		if err != nil {
+			return nil, errors.Annotate(err, "listing virtual machines")
+		}
+		vm := vms.Value()
+		id := instance.Id(to.String(vm.Name))
+		if wanted[id] && to.String(vm.Tags[jujuSpotTag]) == "true" {
+			spot = append(spot, id)
+		}
+	}
+
+	result := make(map[instance.Id]string)
+	for _, id := range spot {
+		view, err := client.InstanceView(sdkCtx, env.resourceGroup, string(id))
+		if isNotFoundResult(view.Response) {
+			continue
+		} else if err != nil {
+			return nil, errorutils.HandleCredentialError(
+				errors.Annotatef(err, "getting instance view of %q", id), ctx,
+			)
+		}
+		if view.Statuses == nil {
+			continue
+		}
+		for _, s := range *view.Statuses {
+			if to.String(s.Code) == deallocatedPowerState {
+				result[id] = "spot virtual machine evicted"
+				break
+			}
+		}
+	}
+	return result, nil
+}
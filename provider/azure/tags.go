@@ -0,0 +1,195 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	stdcontext "context"
+	"reflect"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/azure/internal/errorutils"
+)
+
+var (
+	_ environs.InstanceTagger   = (*azureEnviron)(nil)
+	_ environs.ResourceRetagger = (*azureEnviron)(nil)
+)
+
+// TagInstance is part of the environs.InstanceTagger interface.
+// Updating a virtual machine replaces all of its tags, so the given
+// tags are merged with the existing ones first.
+func (env *azureEnviron) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	client := compute.VirtualMachinesClient{env.compute}
+	sdkCtx := stdcontext.Background()
+	vmName := string(id)
+	vm, err := client.Get(sdkCtx, env.resourceGroup, vmName, "")
+	if err != nil {
+		if isNotFoundResult(vm.Response) {
+			return errors.NotFoundf("instance %q", id)
+		}
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "getting virtual machine %q", id), ctx)
+	}
+	vmTags := make(map[string]*string)
+	for k, v := range vm.Tags {
+		vmTags[k] = v
+	}
+	for k, v := range tags {
+		vmTags[k] = to.StringPtr(v)
+	}
+	future, err := client.Update(sdkCtx, env.resourceGroup, vmName, compute.VirtualMachineUpdate{
+		Tags: vmTags,
+	})
+	if err == nil {
+		err = future.WaitForCompletionRef(sdkCtx, client.Client)
+	}
+	if err != nil {
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "tagging virtual machine %q", id), ctx)
+	}
+	return nil
+}
+
+// RetagResources is part of the environs.ResourceRetagger interface.
+// The model's resource group and the resources in it are retagged.
+// The resources created for a machine serve the units deployed to its
+// virtual machine.
+func (env *azureEnviron) RetagResources(ctx context.ProviderCallContext, vars tags.TemplateVars, removed []string) error {
+	env.mu.Lock()
+	modelUUID := env.config.Config.UUID()
+	tagger := tags.WithTemplateVars(env.config.Config, vars)
+	env.mu.Unlock()
+
+	sdkCtx := stdcontext.Background()
+	groupClient := resources.GroupsClient{env.resources}
+	group, err := groupClient.Get(sdkCtx, env.resourceGroup)
+	if err != nil {
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "getting resource group %q", env.resourceGroup), ctx)
+	}
+	if groupTags, changed := retaggedTags(group.Tags, modelUUID, tagger, tags.TemplateVars{}, removed); changed {
+		group.Tags = groupTags
+		// The Azure API forbids specifying ProvisioningState on the update.
+		if group.Properties != nil {
+			(*group.Properties).ProvisioningState = nil
+		}
+		if _, err := groupClient.CreateOrUpdate(sdkCtx, env.resourceGroup, group); err != nil {
+			return errorutils.HandleCredentialError(errors.Annotatef(err, "tagging resource group %q", env.resourceGroup), ctx)
+		}
+	}
+
+	apiVersions, err := collectAPIVersions(ctx, sdkCtx, resources.ProvidersClient{env.resources})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resourceClient := resources.Client{env.resources}
+	res, err := resourceClient.ListByResourceGroupComplete(sdkCtx, env.resourceGroup, "", "", nil)
+	if err != nil {
+		return errorutils.HandleCredentialError(errors.Annotate(err, "listing resources"), ctx)
+	}
+	var stubResources []resources.GenericResourceExpanded
+	machineVars := make(map[string]tags.TemplateVars)
+	for ; res.NotDone(); err = res.NextWithContext(sdkCtx) {
+		if err != nil {
+			return errors.Annotate(err, "listing resources")
+		}
+		resource := res.Value()
+		if to.String(resource.Type) == "Microsoft.Compute/virtualMachines" {
+			machineVars[to.String(resource.Name)] = tags.ResourceTemplateVars(to.StringMap(resource.Tags))
+		}
+		stubResources = append(stubResources, resource)
+	}
+
+	var failed []string
+	for _, stubResource := range stubResources {
+		stubTags := to.StringMap(stubResource.Tags)
+		resourceVars, ok := machineVars[stubTags[jujuMachineNameTag]]
+		if !ok {
+			resourceVars = tags.ResourceTemplateVars(stubTags)
+		}
+		resourceTags, changed := retaggedTags(stubResource.Tags, modelUUID, tagger, resourceVars, removed)
+		if !changed {
+			continue
+		}
+		err := env.updateResourceTags(
+			ctx,
+			sdkCtx,
+			resourceClient,
+			stubResource, resourceTags,
+			apiVersions[to.String(stubResource.Type)],
+		)
+		if err != nil {
+			name := to.String(stubResource.Name)
+			logger.Errorf("error updating resource tags for %q: %v", name, err)
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to retag some resources: %v", failed)
+	}
+	return nil
+}
+
+// retaggedTags returns the given tags with the removed tags deleted
+// and the model's resource tags set, expanded with vars, and whether
+// they differ from the given tags. The tags of a resource which doesn't
+// belong to the model are left alone.
+func retaggedTags(
+	azureTags map[string]*string,
+	modelUUID string,
+	tagger tags.ResourceTagger,
+	vars tags.TemplateVars,
+	removed []string,
+) (map[string]*string, bool) {
+	current := to.StringMap(azureTags)
+	if current[tags.JujuModel] != modelUUID {
+		return nil, false
+	}
+	result := make(map[string]string, len(current))
+	for k, v := range current {
+		result[k] = v
+	}
+	for _, k := range removed {
+		delete(result, k)
+	}
+	resourceTags, _ := tags.ExpandedResourceTags(tags.WithTemplateVars(tagger, vars))
+	for k, v := range resourceTags {
+		result[k] = v
+	}
+	if reflect.DeepEqual(result, current) {
+		return nil, false
+	}
+	return *to.StringMapPtr(result), true
+}
+
+func (env *azureEnviron) updateResourceTags(
+	ctx context.ProviderCallContext,
+	sdkCtx stdcontext.Context,
+	client resources.Client,
+	stubResource resources.GenericResourceExpanded,
+	resourceTags map[string]*string,
+	apiVersion string,
+) error {
+	// Need to get the resource individually to ensure that the
+	// properties are populated.
+	resource, err := client.GetByID(sdkCtx, to.String(stubResource.ID), apiVersion)
+	if err != nil {
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "getting full resource %q", to.String(stubResource.Name)), ctx)
+	}
+
+	logger.Debugf("updating %s tags", to.String(stubResource.ID))
+	resource.Tags = resourceTags
+	_, err = client.CreateOrUpdateByID(
+		sdkCtx,
+		to.String(stubResource.ID),
+		apiVersion,
+		resource,
+	)
+	return errorutils.HandleCredentialError(errors.Annotatef(err, "updating tags for %q", to.String(resource.Name)), ctx)
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/tags"
 	coretools "github.com/juju/juju/tools"
 )
 
@@ -147,7 +148,12 @@ func BootstrapInstance(
 	instanceConfig.EnableOSUpgrade = env.Config().EnableOSUpgrade()
 	instanceConfig.NetBondReconfigureDelay = env.Config().NetBondReconfigureDelay()
 
-	instanceConfig.Tags = instancecfg.InstanceTags(envCfg.UUID(), args.ControllerConfig.ControllerUUID(), envCfg, instanceConfig.Jobs)
+	// The controller model is owned by the admin user.
+	resourceTagVars := tags.TemplateVars{Owner: environs.AdminUser}
+	instanceConfig.Tags = instancecfg.InstanceTags(
+		envCfg.UUID(), args.ControllerConfig.ControllerUUID(),
+		tags.WithTemplateVars(envCfg, resourceTagVars), instanceConfig.Jobs,
+	)
 	maybeSetBridge := func(icfg *instancecfg.InstanceConfig) {
 		// If we need to override the default bridge name, do it now. When
 		// args.ContainerBridgeName is empty, the default names for LXC
@@ -203,6 +209,7 @@ func BootstrapInstance(
 		Constraints:     args.BootstrapConstraints,
 		Tools:           availableTools,
 		InstanceConfig:  instanceConfig,
+		ResourceTagVars: resourceTagVars,
 		Placement:       args.Placement,
 		ImageMetadata:   imageMetadata,
 		StatusCallback:  instanceStatus,
@@ -86,6 +86,7 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.InstanceTagger = (*environ)(nil)
 
 func (e *environ) Config() *config.Config {
 	return e.ecfg().Config
@@ -512,7 +513,10 @@ func (e *environ) StartInstance(
 	}
 
 	_ = callback(status.Allocating, "Setting up groups", nil)
-	groups, err := e.setUpGroups(ctx, args.ControllerUUID, args.InstanceConfig.MachineId, apiPorts)
+	// The resources created for the instance serve the units deployed
+	// to it, as well as the model.
+	instanceTagVars := tags.ResourceTemplateVars(args.InstanceConfig.Tags)
+	groups, err := e.setUpGroups(ctx, args.ControllerUUID, args.InstanceConfig.MachineId, apiPorts, args.ResourceTagVars, instanceTagVars)
 	if err != nil {
 		return nil, annotateWrapError(err, "cannot set up groups")
 	}
@@ -553,7 +557,15 @@ func (e *environ) StartInstance(
 	_ = callback(status.Allocating,
 		fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
 
-	instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	if args.Constraints.HasSpot() {
+		var maxPrice string
+		if args.Constraints.HasMaxPrice() {
+			maxPrice = *args.Constraints.MaxPrice
+		}
+		instResp, err = runSpotInstances(e.ec2, ec2Session, ctx, runArgs, maxPrice, callback)
+	} else {
+		instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	}
 	if err != nil {
 		if !isZoneOrSubnetConstrainedError(err) {
 			err = annotateWrapError(err, "cannot run instances")
@@ -592,7 +604,7 @@ func (e *environ) StartInstance(
 		tags := tags.ResourceTags(
 			names.NewModelTag(cfg.UUID()),
 			names.NewControllerTag(args.ControllerUUID),
-			cfg,
+			tags.WithTemplateVars(tags.WithTemplateVars(cfg, args.ResourceTagVars), instanceTagVars),
 		)
 		tags[tagName] = instanceName + "-root"
 		if err := tagRootDisk(e.ec2, ctx, tags, inst.Instance); err != nil {
@@ -954,6 +966,12 @@ func tagResources(e *ec2.EC2, ctx context.ProviderCallContext, tags map[string]s
 	return maybeConvertCredentialError(err, ctx)
 }
 
+// TagInstance implements environs.InstanceTagger.
+func (e *environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	err := tagResources(e.ec2, ctx, tags, string(id))
+	return errors.Annotate(err, "tagging instance")
+}
+
 func tagRootDisk(e *ec2.EC2, ctx context.ProviderCallContext, tags map[string]string, inst *ec2.Instance) error {
 	if len(tags) == 0 {
 		return nil
@@ -2046,7 +2064,13 @@ func (e *environ) jujuGroupName() string {
 // other instances that might be running on the same EC2 account.  In
 // addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
-func (e *environ) setUpGroups(ctx context.ProviderCallContext, controllerUUID, machineId string, apiPorts []int) ([]ec2.SecurityGroup, error) {
+//
+// The groups are tagged with the model's resource tags, expanded with
+// vars; a machine's group is also expanded with machineVars.
+func (e *environ) setUpGroups(
+	ctx context.ProviderCallContext, controllerUUID, machineId string, apiPorts []int,
+	vars, machineVars tags.TemplateVars,
+) ([]ec2.SecurityGroup, error) {
 	perms := []ec2.IPPerm{{
 		Protocol:  "tcp",
 		FromPort:  22,
@@ -2074,18 +2098,25 @@ func (e *environ) setUpGroups(ctx context.ProviderCallContext, controllerUUID, m
 		FromPort: -1,
 		ToPort:   -1,
 	})
+	cfg := e.Config()
+	modelTag := names.NewModelTag(cfg.UUID())
+	controllerTag := names.NewControllerTag(controllerUUID)
+	tagger := tags.WithTemplateVars(cfg, vars)
+	groupTags := tags.ResourceTags(modelTag, controllerTag, tagger)
+
 	// Ensure there's a global group for Juju-related traffic.
-	jujuGroup, err := e.ensureGroup(ctx, controllerUUID, e.jujuGroupName(), perms)
+	jujuGroup, err := e.ensureGroup(ctx, groupTags, e.jujuGroupName(), perms)
 	if err != nil {
 		return nil, err
 	}
 
 	var machineGroup ec2.SecurityGroup
-	switch e.Config().FirewallMode() {
+	switch cfg.FirewallMode() {
 	case config.FwInstance:
-		machineGroup, err = e.ensureGroup(ctx, controllerUUID, e.machineGroupName(machineId), nil)
+		machineGroupTags := tags.ResourceTags(modelTag, controllerTag, tags.WithTemplateVars(tagger, machineVars))
+		machineGroup, err = e.ensureGroup(ctx, machineGroupTags, e.machineGroupName(machineId), nil)
 	case config.FwGlobal:
-		machineGroup, err = e.ensureGroup(ctx, controllerUUID, e.globalGroupName(), nil)
+		machineGroup, err = e.ensureGroup(ctx, groupTags, e.globalGroupName(), nil)
 	}
 	if err != nil {
 		return nil, err
@@ -2119,8 +2150,8 @@ func (e *environ) securityGroupsByNameOrID(groupName string) (*ec2.SecurityGroup
 // If a group with name does not exist, one will be created.
 // If it exists, its permissions are set to perms.
 // Any entries in perms without SourceIPs will be granted for
-// the named group only.
-func (e *environ) ensureGroup(ctx context.ProviderCallContext, controllerUUID, name string, perms []ec2.IPPerm) (g ec2.SecurityGroup, err error) {
+// the named group only. A new group is tagged with groupTags.
+func (e *environ) ensureGroup(ctx context.ProviderCallContext, groupTags map[string]string, name string, perms []ec2.IPPerm) (g ec2.SecurityGroup, err error) {
 	// Due to parallelization of the provisioner, it's possible that we try
 	// to create the model security group a second time before the first time
 	// is complete causing failures.
@@ -2145,13 +2176,7 @@ func (e *environ) ensureGroup(ctx context.ProviderCallContext, controllerUUID, n
 	if err == nil {
 		g = resp.SecurityGroup
 		// Tag the created group with the model and controller UUIDs.
-		cfg := e.Config()
-		tags := tags.ResourceTags(
-			names.NewModelTag(cfg.UUID()),
-			names.NewControllerTag(controllerUUID),
-			cfg,
-		)
-		if err := tagResources(e.ec2, ctx, tags, g.Id); err != nil {
+		if err := tagResources(e.ec2, ctx, groupTags, g.Id); err != nil {
 			return g, errors.Annotate(err, "tagging security group")
 		}
 		logger.Debugf("created security group %q with ID %q%s", name, g.Id, inVPCLogSuffix)
@@ -13,6 +13,8 @@ import (
 	"strconv"
 	"strings"
 
+	sdkaws "github.com/aws/aws-sdk-go/aws"
+	sdkec2 "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
@@ -753,6 +755,194 @@ func (t *localServerSuite) TestStartInstanceHardwareCharacteristics(c *gc.C) {
 	c.Check(*hc.CpuCores, gc.Equals, uint64(2))
 }
 
+func (t *localServerSuite) TestStartInstanceSpot(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	// The test server doesn't know about spot instances, so
+	// check the arguments and start an on-demand instance.
+	var maxPrices []string
+	t.PatchValue(ec2.RunSpotInstances, func(
+		e *amzec2.EC2, _ ec2iface.EC2API, ctx context.ProviderCallContext,
+		ri *amzec2.RunInstances, maxPrice string, c environs.StatusCallbackFunc,
+	) (*amzec2.RunInstancesResp, error) {
+		maxPrices = append(maxPrices, maxPrice)
+		return e.RunInstances(ri)
+	})
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Constraints:    constraints.MustParse("spot=true max-price=0.05"),
+	}
+	_, err := testing.StartInstanceWithParams(env, t.callCtx, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Without spot, max-price is ignored.
+	params.Constraints = constraints.MustParse("max-price=0.05")
+	_, err = testing.StartInstanceWithParams(env, t.callCtx, "2", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maxPrices, jc.DeepEquals, []string{"0.05"})
+}
+
+type interruptedEC2Session struct {
+	mockEC2Session
+	input *sdkec2.DescribeInstancesInput
+}
+
+func (s *interruptedEC2Session) DescribeInstancesPages(
+	input *sdkec2.DescribeInstancesInput, fn func(*sdkec2.DescribeInstancesOutput, bool) bool,
+) error {
+	s.input = input
+	fn(&sdkec2.DescribeInstancesOutput{
+		Reservations: []*sdkec2.Reservation{{
+			Instances: []*sdkec2.Instance{{
+				InstanceId: sdkaws.String("i-running"),
+			}, {
+				InstanceId: sdkaws.String("i-reclaimed"),
+				StateReason: &sdkec2.StateReason{
+					Code:    sdkaws.String("Server.SpotInstanceTermination"),
+					Message: sdkaws.String("Server.SpotInstanceTermination: Spot instance termination"),
+				},
+			}},
+		}},
+	}, true)
+	return nil
+}
+
+func (t *localServerSuite) TestInterruptedInstances(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	session := &interruptedEC2Session{}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+
+	interrupted, err := env.(environs.InterruptedInstanceLister).InterruptedInstances(
+		t.callCtx, []instance.Id{"i-running", "i-reclaimed"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interrupted, jc.DeepEquals, map[instance.Id]string{
+		"i-reclaimed": "spot instance interrupted: Server.SpotInstanceTermination: Spot instance termination",
+	})
+	c.Assert(session.input.Filters, jc.DeepEquals, []*sdkec2.Filter{{
+		Name:   sdkaws.String("instance-id"),
+		Values: []*string{sdkaws.String("i-running"), sdkaws.String("i-reclaimed")},
+	}, {
+		Name:   sdkaws.String("instance-lifecycle"),
+		Values: []*string{sdkaws.String("spot")},
+	}})
+}
+
+type resizeEC2Session struct {
+	mockEC2Session
+	lifecycle string
+	calls     []string
+	newType   string
+}
+
+func (s *resizeEC2Session) DescribeInstances(input *sdkec2.DescribeInstancesInput) (*sdkec2.DescribeInstancesOutput, error) {
+	inst := &sdkec2.Instance{
+		InstanceId:   input.InstanceIds[0],
+		InstanceType: sdkaws.String("t3a.micro"),
+		Architecture: sdkaws.String("x86_64"),
+		Placement:    &sdkec2.Placement{AvailabilityZone: sdkaws.String("test-available")},
+	}
+	if s.lifecycle != "" {
+		inst.InstanceLifecycle = sdkaws.String(s.lifecycle)
+	}
+	return &sdkec2.DescribeInstancesOutput{
+		Reservations: []*sdkec2.Reservation{{Instances: []*sdkec2.Instance{inst}}},
+	}, nil
+}
+
+func (s *resizeEC2Session) StopInstances(*sdkec2.StopInstancesInput) (*sdkec2.StopInstancesOutput, error) {
+	s.calls = append(s.calls, "StopInstances")
+	return &sdkec2.StopInstancesOutput{}, nil
+}
+
+func (s *resizeEC2Session) WaitUntilInstanceStopped(*sdkec2.DescribeInstancesInput) error {
+	s.calls = append(s.calls, "WaitUntilInstanceStopped")
+	return nil
+}
+
+func (s *resizeEC2Session) ModifyInstanceAttribute(input *sdkec2.ModifyInstanceAttributeInput) (*sdkec2.ModifyInstanceAttributeOutput, error) {
+	s.calls = append(s.calls, "ModifyInstanceAttribute")
+	s.newType = sdkaws.StringValue(input.InstanceType.Value)
+	return &sdkec2.ModifyInstanceAttributeOutput{}, nil
+}
+
+func (s *resizeEC2Session) StartInstances(*sdkec2.StartInstancesInput) (*sdkec2.StartInstancesOutput, error) {
+	s.calls = append(s.calls, "StartInstances")
+	return &sdkec2.StartInstancesOutput{}, nil
+}
+
+func (s *resizeEC2Session) WaitUntilInstanceRunning(*sdkec2.DescribeInstancesInput) error {
+	s.calls = append(s.calls, "WaitUntilInstanceRunning")
+	return nil
+}
+
+func (t *localServerSuite) TestResizeInstance(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	session := &resizeEC2Session{}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+
+	hc, err := env.(environs.InstanceResizer).ResizeInstance(t.callCtx, "i-0", constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(session.calls, jc.DeepEquals, []string{
+		"StopInstances",
+		"WaitUntilInstanceStopped",
+		"ModifyInstanceAttribute",
+		"StartInstances",
+		"WaitUntilInstanceRunning",
+	})
+	c.Check(session.newType, gc.Equals, "t3a.medium")
+	c.Check(hc.String(), gc.Equals, "arch=amd64 cores=2 cpu-power=700 mem=4096M availability-zone=test-available")
+}
+
+func (t *localServerSuite) TestResizeInstanceSameInstanceType(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	session := &resizeEC2Session{}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+
+	_, err := env.(environs.InstanceResizer).ResizeInstance(t.callCtx, "i-0", constraints.MustParse("instance-type=t3a.micro"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(session.calls, gc.HasLen, 0)
+}
+
+func (t *localServerSuite) TestResizeSpotInstance(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	session := &resizeEC2Session{lifecycle: "spot"}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+
+	_, err := env.(environs.InstanceResizer).ResizeInstance(t.callCtx, "i-0", constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(session.calls, gc.HasLen, 0)
+}
+
+func (t *localServerSuite) TestSuspendAndResumeInstances(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	session := &resizeEC2Session{}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+
+	suspender := env.(environs.InstanceSuspender)
+	err := suspender.SuspendInstances(t.callCtx, "i-0", "i-1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = suspender.ResumeInstances(t.callCtx, "i-0", "i-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(session.calls, jc.DeepEquals, []string{
+		"StopInstances",
+		"WaitUntilInstanceStopped",
+		"StartInstances",
+		"WaitUntilInstanceRunning",
+	})
+}
+
 func (t *localServerSuite) TestStartInstanceAvailZone(c *gc.C) {
 	inst, err := t.testStartInstanceAvailZone(c, "test-available")
 	c.Assert(err, jc.ErrorIsNil)
@@ -1859,6 +2049,31 @@ func (t *localServerSuite) TestInstanceTags(c *gc.C) {
 	})
 }
 
+func (t *localServerSuite) TestTagInstance(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	instances, err := env.AllRunningInstances(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 1)
+
+	err = env.(environs.InstanceTagger).TagInstance(t.callCtx, instances[0].Id(), map[string]string{
+		"juju-is-controller": "true",
+		"cost-center":        "cc-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	instances, err = env.AllRunningInstances(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	ec2Inst := ec2.InstanceEC2(instances[0])
+	c.Assert(ec2Inst.Tags, jc.SameContents, []amzec2.Tag{
+		{"Name", "juju-sample-machine-0"},
+		{"juju-model-uuid", coretesting.ModelTag.Id()},
+		{"juju-controller-uuid", t.ControllerUUID},
+		{"juju-is-controller", "true"},
+		{"cost-center", "cc-1"},
+	})
+}
+
 func (t *localServerSuite) TestRootDiskTags(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 
@@ -1886,6 +2101,63 @@ func (t *localServerSuite) TestRootDiskTags(c *gc.C) {
 	})
 }
 
+type deleteTagsEC2Session struct {
+	mockEC2Session
+	input *sdkec2.DeleteTagsInput
+}
+
+func (s *deleteTagsEC2Session) DeleteTags(input *sdkec2.DeleteTagsInput) (*sdkec2.DeleteTagsOutput, error) {
+	s.input = input
+	return &sdkec2.DeleteTagsOutput{}, nil
+}
+
+func (t *localServerSuite) TestRetagResources(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	session := &deleteTagsEC2Session{}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+
+	cfg, err := env.Config().Apply(map[string]interface{}{
+		"resource-tags": "owner=${owner} project=${model}",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.(environs.ResourceRetagger).RetagResources(t.callCtx, tags.TemplateVars{Owner: "bob"}, []string{"team"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	instances, err := env.AllRunningInstances(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 1)
+
+	ec2conn := ec2.EnvironEC2(env)
+	filter := amzec2.NewFilter()
+	filter.Add("tag:"+tags.JujuModel, coretesting.ModelTag.Id())
+	volumes, err := ec2conn.Volumes(nil, filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes.Volumes, gc.HasLen, 1)
+	c.Assert(volumes.Volumes[0].Tags, jc.SameContents, []amzec2.Tag{
+		{"Name", "juju-sample-machine-0-root"},
+		{"juju-model-uuid", coretesting.ModelTag.Id()},
+		{"juju-controller-uuid", t.ControllerUUID},
+		{"owner", "bob"},
+		{"project", "sample"},
+	})
+
+	c.Assert(session.input, gc.NotNil)
+	c.Assert(session.input.Tags, jc.DeepEquals, []*sdkec2.Tag{{Key: sdkaws.String("team")}})
+	resourceIds := sdkaws.StringValueSlice(session.input.Resources)
+	groups, err := ec2conn.SecurityGroups(nil, filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups.Groups, gc.Not(gc.HasLen), 0)
+	expectedIds := []string{string(instances[0].Id()), volumes.Volumes[0].Id}
+	for _, g := range groups.Groups {
+		expectedIds = append(expectedIds, g.Id)
+	}
+	c.Assert(resourceIds, jc.SameContents, expectedIds)
+}
+
 func (s *localServerSuite) TestBootstrapInstanceConstraints(c *gc.C) {
 	env := s.prepareAndBootstrap(c)
 	inst, err := env.AllRunningInstances(s.callCtx)
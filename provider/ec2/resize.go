@@ -0,0 +1,90 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2/arch"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+)
+
+var _ environs.InstanceResizer = (*environ)(nil)
+
+// ResizeInstance is part of the environs.InstanceResizer interface.
+// The instance is stopped, its instance type changed and then started
+// again; its EBS volumes, including the root disk, are kept.
+func (e *environ) ResizeInstance(
+	ctx context.ProviderCallContext, id instance.Id, cons constraints.Value,
+) (*instance.HardwareCharacteristics, error) {
+	ec2Session := EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
+	ids := []*string{aws.String(string(id))}
+	resp, err := ec2Session.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: ids})
+	if err != nil {
+		return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "getting instance %q", id)
+	}
+	if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		return nil, errors.NotFoundf("instance %q", id)
+	}
+	inst := resp.Reservations[0].Instances[0]
+	if aws.StringValue(inst.InstanceLifecycle) == spotInstanceLifecycle {
+		// One-time spot instances cannot be stopped.
+		return nil, errors.NotSupportedf("resizing spot instance %q", id)
+	}
+
+	instanceTypes, err := e.supportedInstanceTypes(ec2Session, ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instArch := arch.NormaliseArch(aws.StringValue(inst.Architecture))
+	itype, err := instances.ResizeInstanceType(instanceTypes, e.cloud.Region, instArch, cons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if itype.Name != aws.StringValue(inst.InstanceType) {
+		logger.Infof("resizing instance %q from %s to %s", id, aws.StringValue(inst.InstanceType), itype.Name)
+		describe := &ec2.DescribeInstancesInput{InstanceIds: ids}
+		if _, err := ec2Session.StopInstances(&ec2.StopInstancesInput{InstanceIds: ids}); err != nil {
+			return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "stopping instance %q", id)
+		}
+		if err := ec2Session.WaitUntilInstanceStopped(describe); err != nil {
+			return nil, errors.Annotatef(err, "waiting for instance %q to stop", id)
+		}
+		if _, err := ec2Session.ModifyInstanceAttribute(&ec2.ModifyInstanceAttributeInput{
+			InstanceId:   inst.InstanceId,
+			InstanceType: &ec2.AttributeValue{Value: aws.String(itype.Name)},
+		}); err != nil {
+			// Don't leave the instance stopped because the cloud
+			// refused the new instance type.
+			if _, startErr := ec2Session.StartInstances(&ec2.StartInstancesInput{InstanceIds: ids}); startErr != nil {
+				logger.Errorf("cannot restart instance %q: %v", id, startErr)
+			}
+			return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "changing instance type of %q", id)
+		}
+		if _, err := ec2Session.StartInstances(&ec2.StartInstancesInput{InstanceIds: ids}); err != nil {
+			return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "starting instance %q", id)
+		}
+		if err := ec2Session.WaitUntilInstanceRunning(describe); err != nil {
+			return nil, errors.Annotatef(err, "waiting for instance %q to start", id)
+		}
+	}
+
+	hc := &instance.HardwareCharacteristics{
+		Arch:     &instArch,
+		Mem:      &itype.Mem,
+		CpuCores: &itype.CpuCores,
+		CpuPower: itype.CpuPower,
+	}
+	if inst.Placement != nil && inst.Placement.AvailabilityZone != nil {
+		hc.AvailabilityZone = inst.Placement.AvailabilityZone
+	}
+	return hc, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/juju/errors"
+	amzec2 "gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+)
+
+var _ environs.ResourceRetagger = (*environ)(nil)
+
+// RetagResources is part of the environs.ResourceRetagger interface.
+// The model's security groups and EBS volumes are retagged. A machine's
+// security group, and a volume which isn't Juju storage, serve the units
+// deployed to the instance they belong to.
+func (e *environ) RetagResources(ctx context.ProviderCallContext, vars tags.TemplateVars, removed []string) error {
+	insts, err := e.AllInstances(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelGroups := map[string]bool{
+		e.jujuGroupName():   true,
+		e.globalGroupName(): true,
+	}
+	instanceIds := make([]string, 0, len(insts))
+	instanceVars := make(map[string]tags.TemplateVars)
+	groupVars := make(map[string]tags.TemplateVars)
+	for _, inst := range insts {
+		ec2Inst := inst.(*ec2Instance)
+		instVars := tags.ResourceTemplateVars(tagsMap(ec2Inst.Tags))
+		instanceIds = append(instanceIds, ec2Inst.InstanceId)
+		instanceVars[ec2Inst.InstanceId] = instVars
+		for _, g := range ec2Inst.SecurityGroups {
+			if !modelGroups[g.Name] {
+				groupVars[g.Id] = instVars
+			}
+		}
+	}
+
+	filter := amzec2.NewFilter()
+	filter.Add("tag:"+tags.JujuModel, e.uuid())
+	resourceVars := make(map[string]tags.TemplateVars)
+	groupsResp, err := e.ec2.SecurityGroups(nil, filter)
+	if err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "listing security groups")
+	}
+	for _, g := range groupsResp.Groups {
+		resourceVars[g.Id] = groupVars[g.Id]
+	}
+	volumesResp, err := e.ec2.Volumes(nil, filter)
+	if err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "listing volumes")
+	}
+	for _, v := range volumesResp.Volumes {
+		volumeVars := tags.ResourceTemplateVars(tagsMap(v.Tags))
+		if volumeVars == (tags.TemplateVars{}) {
+			for _, att := range v.Attachments {
+				volumeVars = instanceVars[att.InstanceId]
+			}
+		}
+		resourceVars[v.Id] = volumeVars
+	}
+
+	if len(removed) > 0 {
+		resourceIds := instanceIds
+		for id := range resourceVars {
+			resourceIds = append(resourceIds, id)
+		}
+		if err := e.deleteTags(ctx, removed, resourceIds); err != nil {
+			return errors.Annotate(err, "deleting removed tags")
+		}
+	}
+
+	// Resources which serve the same units get the same tags.
+	byVars := make(map[tags.TemplateVars][]string)
+	for id, v := range resourceVars {
+		byVars[v] = append(byVars[v], id)
+	}
+	tagger := tags.WithTemplateVars(e.Config(), vars)
+	for v, ids := range byVars {
+		resourceTags, _ := tags.ExpandedResourceTags(tags.WithTemplateVars(tagger, v))
+		if err := tagResources(e.ec2, ctx, resourceTags, ids...); err != nil {
+			return errors.Annotate(err, "tagging resources")
+		}
+	}
+	return nil
+}
+
+// deleteTags deletes the named tags from the specified resources. goamz
+// doesn't support deleting tags, so the AWS SDK is used.
+func (e *environ) deleteTags(ctx context.ProviderCallContext, keys, resourceIds []string) error {
+	if len(resourceIds) == 0 {
+		return nil
+	}
+	ec2Tags := make([]*ec2.Tag, len(keys))
+	for i, key := range keys {
+		ec2Tags[i] = &ec2.Tag{Key: aws.String(key)}
+	}
+	ec2Session := EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
+	_, err := ec2Session.DeleteTags(&ec2.DeleteTagsInput{
+		Resources: aws.StringSlice(resourceIds),
+		Tags:      ec2Tags,
+	})
+	return maybeConvertCredentialError(convertSDKError(err), ctx)
+}
+
+func tagsMap(ec2Tags []amzec2.Tag) map[string]string {
+	result := make(map[string]string, len(ec2Tags))
+	for _, t := range ec2Tags {
+		result[t.Key] = t.Value
+	}
+	return result
+}
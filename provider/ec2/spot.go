@@ -0,0 +1,204 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/juju/errors"
+	amzec2 "gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+const (
+	// spotInstanceLifecycle is the lifecycle of instances started on
+	// the spot market.
+	spotInstanceLifecycle = "spot"
+
+	// spotTerminationReason is the state reason code of spot instances
+	// that were reclaimed by AWS.
+	spotTerminationReason = "Server.SpotInstanceTermination"
+)
+
+var _ environs.InterruptedInstanceLister = (*environ)(nil)
+
+var runSpotInstances = _runSpotInstances
+
+// _runSpotInstances starts the instances described by ri as one-time spot
+// instances, terminated when interrupted. goamz doesn't support instance
+// market options, so the instances are started with the AWS SDK and then
+// read back with goamz.
+func _runSpotInstances(
+	e *amzec2.EC2, ec2Session ec2iface.EC2API, ctx context.ProviderCallContext,
+	ri *amzec2.RunInstances, maxPrice string, c environs.StatusCallbackFunc,
+) (*amzec2.RunInstancesResp, error) {
+	c(status.Allocating, "Requesting spot instance", nil)
+	reservation, err := ec2Session.RunInstances(spotRunInstancesInput(ri, maxPrice))
+	if err != nil {
+		return nil, maybeConvertCredentialError(convertSDKError(err), ctx)
+	}
+	ids := make([]string, len(reservation.Instances))
+	for i, inst := range reservation.Instances {
+		ids[i] = aws.StringValue(inst.InstanceId)
+	}
+
+	// The new instances may not be visible straight away.
+	var resp *amzec2.InstancesResp
+	for a := shortAttempt.Start(); a.Next(); {
+		resp, err = e.Instances(ids, nil)
+		if err == nil || ec2ErrCode(err) != "InvalidInstanceID.NotFound" {
+			break
+		}
+	}
+	if err != nil {
+		return nil, maybeConvertCredentialError(err, ctx)
+	}
+	result := &amzec2.RunInstancesResp{
+		ReservationId: aws.StringValue(reservation.ReservationId),
+		OwnerId:       aws.StringValue(reservation.OwnerId),
+	}
+	for _, r := range resp.Reservations {
+		result.Instances = append(result.Instances, r.Instances...)
+	}
+	return result, nil
+}
+
+// convertSDKError converts an error returned by the AWS SDK into the
+// goamz error type, so that the error code checks made on the results
+// of RunInstances apply to spot instances too.
+func convertSDKError(err error) error {
+	sdkErr, ok := err.(awserr.Error)
+	if !ok {
+		return err
+	}
+	result := &amzec2.Error{
+		Code:    sdkErr.Code(),
+		Message: sdkErr.Message(),
+	}
+	if reqErr, ok := sdkErr.(awserr.RequestFailure); ok {
+		result.StatusCode = reqErr.StatusCode()
+		result.RequestId = reqErr.RequestID()
+	}
+	return result
+}
+
+// spotRunInstancesInput converts the goamz arguments used to start
+// on-demand instances into an SDK request for spot instances.
+func spotRunInstancesInput(ri *amzec2.RunInstances, maxPrice string) *ec2.RunInstancesInput {
+	min, max := ri.MinCount, ri.MaxCount
+	if min == 0 {
+		min = 1
+	}
+	if max == 0 {
+		max = min
+	}
+	input := &ec2.RunInstancesInput{
+		ImageId:      aws.String(ri.ImageId),
+		InstanceType: aws.String(ri.InstanceType),
+		MinCount:     aws.Int64(int64(min)),
+		MaxCount:     aws.Int64(int64(max)),
+		InstanceMarketOptions: &ec2.InstanceMarketOptionsRequest{
+			MarketType: aws.String(ec2.MarketTypeSpot),
+			SpotOptions: &ec2.SpotMarketOptions{
+				SpotInstanceType:             aws.String(ec2.SpotInstanceTypeOneTime),
+				InstanceInterruptionBehavior: aws.String(ec2.InstanceInterruptionBehaviorTerminate),
+			},
+		},
+	}
+	if maxPrice != "" {
+		input.InstanceMarketOptions.SpotOptions.MaxPrice = aws.String(maxPrice)
+	}
+	if ri.UserData != nil {
+		input.UserData = aws.String(base64.StdEncoding.EncodeToString(ri.UserData))
+	}
+	if ri.AvailZone != "" {
+		input.Placement = &ec2.Placement{AvailabilityZone: aws.String(ri.AvailZone)}
+	}
+	if ri.SubnetId != "" {
+		input.SubnetId = aws.String(ri.SubnetId)
+	}
+	for _, g := range ri.SecurityGroups {
+		if g.Id != "" {
+			input.SecurityGroupIds = append(input.SecurityGroupIds, aws.String(g.Id))
+		} else {
+			input.SecurityGroups = append(input.SecurityGroups, aws.String(g.Name))
+		}
+	}
+	for _, b := range ri.BlockDeviceMappings {
+		mapping := &ec2.BlockDeviceMapping{
+			DeviceName: aws.String(b.DeviceName),
+		}
+		if b.VirtualName != "" {
+			mapping.VirtualName = aws.String(b.VirtualName)
+		} else {
+			ebs := &ec2.EbsBlockDevice{}
+			if b.SnapshotId != "" {
+				ebs.SnapshotId = aws.String(b.SnapshotId)
+			}
+			if b.VolumeType != "" {
+				ebs.VolumeType = aws.String(b.VolumeType)
+			}
+			if b.VolumeSize > 0 {
+				ebs.VolumeSize = aws.Int64(b.VolumeSize)
+			}
+			if b.IOPS > 0 {
+				ebs.Iops = aws.Int64(b.IOPS)
+			}
+			if b.DeleteOnTermination {
+				ebs.DeleteOnTermination = aws.Bool(true)
+			}
+			mapping.Ebs = ebs
+		}
+		input.BlockDeviceMappings = append(input.BlockDeviceMappings, mapping)
+	}
+	return input
+}
+
+// InterruptedInstances is part of the environs.InterruptedInstanceLister
+// interface.
+func (e *environ) InterruptedInstances(ctx context.ProviderCallContext, ids []instance.Id) (map[instance.Id]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	// Filter on the instance ids rather than asking for them, so that
+	// instances that have disappeared aren't an error.
+	idFilter := &ec2.Filter{Name: aws.String("instance-id")}
+	for _, id := range ids {
+		idFilter.Values = append(idFilter.Values, aws.String(string(id)))
+	}
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{idFilter, {
+			Name:   aws.String("instance-lifecycle"),
+			Values: []*string{aws.String(spotInstanceLifecycle)},
+		}},
+	}
+	ec2Session := EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
+	result := make(map[instance.Id]string)
+	err := ec2Session.DescribeInstancesPages(input, func(page *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, r := range page.Reservations {
+			for _, inst := range r.Instances {
+				if inst.StateReason == nil || aws.StringValue(inst.StateReason.Code) != spotTerminationReason {
+					continue
+				}
+				result[instance.Id(aws.StringValue(inst.InstanceId))] = fmt.Sprintf(
+					"spot instance interrupted: %s", aws.StringValue(inst.StateReason.Message),
+				)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Annotate(maybeConvertCredentialError(convertSDKError(err), ctx), "listing interrupted instances")
+	}
+	return result, nil
+}
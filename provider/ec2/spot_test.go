@@ -0,0 +1,103 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/base64"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	amzec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+)
+
+type SpotSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SpotSuite{})
+
+func (s *SpotSuite) TestSpotRunInstancesInput(c *gc.C) {
+	input := spotRunInstancesInput(&amzec2.RunInstances{
+		MinCount:     1,
+		MaxCount:     1,
+		ImageId:      "ami-0001",
+		InstanceType: "m5.large",
+		UserData:     []byte("#cloud-config"),
+		AvailZone:    "us-east-1a",
+		SubnetId:     "subnet-1",
+		SecurityGroups: []amzec2.SecurityGroup{
+			{Id: "sg-1", Name: "juju-model"},
+			{Name: "juju-machine-0"},
+		},
+		BlockDeviceMappings: []amzec2.BlockDeviceMapping{{
+			DeviceName:          "/dev/sda1",
+			VolumeSize:          8,
+			DeleteOnTermination: true,
+		}, {
+			DeviceName:  "/dev/sdb",
+			VirtualName: "ephemeral0",
+		}},
+	}, "0.05")
+
+	c.Assert(input, jc.DeepEquals, &ec2.RunInstancesInput{
+		ImageId:          aws.String("ami-0001"),
+		InstanceType:     aws.String("m5.large"),
+		MinCount:         aws.Int64(1),
+		MaxCount:         aws.Int64(1),
+		UserData:         aws.String(base64.StdEncoding.EncodeToString([]byte("#cloud-config"))),
+		Placement:        &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+		SubnetId:         aws.String("subnet-1"),
+		SecurityGroupIds: []*string{aws.String("sg-1")},
+		SecurityGroups:   []*string{aws.String("juju-machine-0")},
+		BlockDeviceMappings: []*ec2.BlockDeviceMapping{{
+			DeviceName: aws.String("/dev/sda1"),
+			Ebs: &ec2.EbsBlockDevice{
+				VolumeSize:          aws.Int64(8),
+				DeleteOnTermination: aws.Bool(true),
+			},
+		}, {
+			DeviceName:  aws.String("/dev/sdb"),
+			VirtualName: aws.String("ephemeral0"),
+		}},
+		InstanceMarketOptions: &ec2.InstanceMarketOptionsRequest{
+			MarketType: aws.String("spot"),
+			SpotOptions: &ec2.SpotMarketOptions{
+				MaxPrice:                     aws.String("0.05"),
+				SpotInstanceType:             aws.String("one-time"),
+				InstanceInterruptionBehavior: aws.String("terminate"),
+			},
+		},
+	})
+}
+
+func (s *SpotSuite) TestSpotRunInstancesInputNoMaxPrice(c *gc.C) {
+	input := spotRunInstancesInput(&amzec2.RunInstances{ImageId: "ami-0001"}, "")
+	c.Assert(input.MinCount, jc.DeepEquals, aws.Int64(1))
+	c.Assert(input.MaxCount, jc.DeepEquals, aws.Int64(1))
+	c.Assert(input.InstanceMarketOptions.SpotOptions.MaxPrice, gc.IsNil)
+}
+
+func (s *SpotSuite) TestConvertSDKError(c *gc.C) {
+	err := convertSDKError(awserr.NewRequestFailure(
+		awserr.New("InsufficientInstanceCapacity", "no capacity in Availability Zone us-east-1a", nil),
+		500, "req-1",
+	))
+	c.Assert(err, jc.DeepEquals, &amzec2.Error{
+		StatusCode: 500,
+		Code:       "InsufficientInstanceCapacity",
+		Message:    "no capacity in Availability Zone us-east-1a",
+		RequestId:  "req-1",
+	})
+	c.Assert(isZoneOrSubnetConstrainedError(err), jc.IsTrue)
+}
+
+func (s *SpotSuite) TestConvertSDKErrorOther(c *gc.C) {
+	err := errors.New("boom")
+	c.Assert(convertSDKError(err), gc.Equals, err)
+}
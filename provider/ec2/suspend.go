@@ -0,0 +1,59 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var _ environs.InstanceSuspender = (*environ)(nil)
+
+// SuspendInstances is part of the environs.InstanceSuspender interface.
+// The instances are stopped rather than terminated, so their EBS volumes
+// and private addresses are kept. Instances without an elastic IP get a
+// new public address when they are resumed.
+func (e *environ) SuspendInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	ec2Session := EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
+	instIds := awsInstanceIds(ids)
+	if _, err := ec2Session.StopInstances(&ec2.StopInstancesInput{InstanceIds: instIds}); err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "stopping instances")
+	}
+	if err := ec2Session.WaitUntilInstanceStopped(&ec2.DescribeInstancesInput{InstanceIds: instIds}); err != nil {
+		return errors.Annotate(err, "waiting for instances to stop")
+	}
+	return nil
+}
+
+// ResumeInstances is part of the environs.InstanceSuspender interface.
+func (e *environ) ResumeInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	ec2Session := EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
+	instIds := awsInstanceIds(ids)
+	if _, err := ec2Session.StartInstances(&ec2.StartInstancesInput{InstanceIds: instIds}); err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "starting instances")
+	}
+	if err := ec2Session.WaitUntilInstanceRunning(&ec2.DescribeInstancesInput{InstanceIds: instIds}); err != nil {
+		return errors.Annotate(err, "waiting for instances to start")
+	}
+	return nil
+}
+
+func awsInstanceIds(ids []instance.Id) []*string {
+	result := make([]*string, len(ids))
+	for i, id := range ids {
+		result[i] = aws.String(string(id))
+	}
+	return result
+}
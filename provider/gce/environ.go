@@ -32,7 +32,12 @@ type gceConnection interface {
 	Instances(prefix string, statuses ...string) ([]google.Instance, error)
 	AddInstance(spec google.InstanceSpec) (*google.Instance, error)
 	RemoveInstances(prefix string, ids ...string) error
+	// ResizeInstance stops the instance, changes its machine type
+	// and starts it again.
+	ResizeInstance(id, zone, machineType string) error
 	UpdateMetadata(key, value string, ids ...string) error
+	// RemoveMetadata removes the metadata key from the instances.
+	RemoveMetadata(key string, ids ...string) error
 
 	IngressRules(fwname string) (firewall.IngressRules, error)
 	OpenPorts(fwname string, rules firewall.IngressRules) error
@@ -4,9 +4,11 @@
 package gce
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/v2/arch"
 	"github.com/juju/version"
 
 	"github.com/juju/juju/core/constraints"
@@ -18,6 +20,13 @@ import (
 	"github.com/juju/juju/provider/gce/google"
 )
 
+var (
+	_ environs.InterruptedInstanceLister = (*environ)(nil)
+	_ environs.InstanceResizer           = (*environ)(nil)
+	_ environs.InstanceTagger            = (*environ)(nil)
+	_ environs.ResourceRetagger          = (*environ)(nil)
+)
+
 // instStatus is the list of statuses to accept when filtering
 // for "alive" instances.
 var instStatuses = []string{
@@ -103,6 +112,73 @@ func (env *environ) instances(ctx context.ProviderCallContext, statusFilters ...
 	return results, err
 }
 
+// InterruptedInstances is part of the environs.InterruptedInstanceLister
+// interface. Preempted instances are left terminated by GCE.
+func (env *environ) InterruptedInstances(ctx context.ProviderCallContext, ids []instance.Id) (map[instance.Id]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	terminated, err := env.gceInstances(ctx, google.StatusTerminated)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing interrupted instances")
+	}
+	result := make(map[instance.Id]string)
+	for _, inst := range terminated {
+		if !inst.Preemptible {
+			continue
+		}
+		for _, id := range ids {
+			if instance.Id(inst.ID) == id {
+				result[id] = "preemptible instance was preempted"
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// ResizeInstance is part of the environs.InstanceResizer interface.
+// The instance is stopped, its machine type changed and then started
+// again; its persistent disks are kept.
+func (env *environ) ResizeInstance(
+	ctx context.ProviderCallContext, id instance.Id, cons constraints.Value,
+) (*instance.HardwareCharacteristics, error) {
+	all, err := env.gceInstances(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var inst *google.Instance
+	for i := range all {
+		if all[i].ID == string(id) {
+			inst = &all[i]
+			break
+		}
+	}
+	if inst == nil {
+		return nil, errors.NotFoundf("instance %q", id)
+	}
+
+	// All GCE machine types are amd64.
+	instArch := arch.AMD64
+	itype, err := instances.ResizeInstanceType(allInstanceTypes, env.cloud.Region, instArch, cons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if itype.Name != inst.MachineType {
+		logger.Infof("resizing instance %q from %s to %s", id, inst.MachineType, itype.Name)
+		if err := env.gce.ResizeInstance(inst.ID, inst.ZoneName, itype.Name); err != nil {
+			return nil, google.HandleCredentialError(errors.Trace(err), ctx)
+		}
+	}
+	return &instance.HardwareCharacteristics{
+		Arch:             &instArch,
+		Mem:              &itype.Mem,
+		CpuCores:         &itype.CpuCores,
+		CpuPower:         itype.CpuPower,
+		AvailabilityZone: &inst.ZoneName,
+	}, nil
+}
+
 // ControllerInstances returns the IDs of the instances corresponding
 // to juju controllers.
 func (env *environ) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) ([]instance.Id, error) {
@@ -146,6 +222,46 @@ func (env *environ) AdoptResources(ctx context.ProviderCallContext, controllerUU
 	return nil
 }
 
+// TagInstance implements environs.InstanceTagger. The tags are set as
+// metadata on the instance, as they are when it is started.
+func (env *environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := env.gce.UpdateMetadata(k, tags[k], string(id)); err != nil {
+			return google.HandleCredentialError(errors.Annotatef(err, "tagging instance %q", id), ctx)
+		}
+	}
+	return nil
+}
+
+// RetagResources implements environs.ResourceRetagger. Disks are only
+// labelled with the controller and model UUIDs, so the resource tags
+// are only kept in the metadata of the model's instances, which the
+// removed tags are deleted from.
+func (env *environ) RetagResources(ctx context.ProviderCallContext, _ tags.TemplateVars, removed []string) error {
+	if len(removed) == 0 {
+		return nil
+	}
+	insts, err := env.AllInstances(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ids := make([]string, len(insts))
+	for i, inst := range insts {
+		ids[i] = string(inst.Id())
+	}
+	for _, k := range removed {
+		if err := env.gce.RemoveMetadata(k, ids...); err != nil {
+			return google.HandleCredentialError(errors.Annotatef(err, "deleting tag %q", k), ctx)
+		}
+	}
+	return nil
+}
+
 // TODO(ericsnow) Turn into an interface.
 type instPlacement struct {
 	Zone *google.AvailabilityZone
@@ -102,6 +102,56 @@ func (s *environInstSuite) TestBasicInstancesAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].Statuses, jc.DeepEquals, []string{google.StatusPending, google.StatusStaging, google.StatusRunning})
 }
 
+func (s *environInstSuite) TestInterruptedInstances(c *gc.C) {
+	spam := s.NewBaseInstance(c, "spam")
+	spam.InstanceSummary.Status = google.StatusTerminated
+	spam.InstanceSummary.Preemptible = true
+	ham := s.NewBaseInstance(c, "ham")
+	ham.InstanceSummary.Status = google.StatusTerminated
+	eggs := s.NewBaseInstance(c, "eggs")
+	eggs.InstanceSummary.Status = google.StatusTerminated
+	eggs.InstanceSummary.Preemptible = true
+	s.FakeConn.Insts = []google.Instance{*spam, *ham, *eggs}
+
+	interrupted, err := s.Env.InterruptedInstances(s.CallCtx, []instance.Id{"spam", "ham"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(interrupted, jc.DeepEquals, map[instance.Id]string{
+		"spam": "preemptible instance was preempted",
+	})
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].Statuses, jc.DeepEquals, []string{google.StatusTerminated})
+}
+
+func (s *environInstSuite) TestResizeInstance(c *gc.C) {
+	spam := s.NewBaseInstance(c, "spam")
+	spam.InstanceSummary.MachineType = "n1-standard-1"
+	s.FakeConn.Insts = []google.Instance{*spam}
+
+	hc, err := s.Env.ResizeInstance(s.CallCtx, "spam", constraints.MustParse("instance-type=n1-standard-2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hc.String(), gc.Equals, "arch=amd64 cores=2 cpu-power=550 mem=7500M availability-zone=home-zone")
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "ResizeInstance")
+	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[1].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[1].MachineType, gc.Equals, "n1-standard-2")
+}
+
+func (s *environInstSuite) TestResizeInstanceSameMachineType(c *gc.C) {
+	spam := s.NewBaseInstance(c, "spam")
+	spam.InstanceSummary.MachineType = "n1-standard-2"
+	s.FakeConn.Insts = []google.Instance{*spam}
+
+	_, err := s.Env.ResizeInstance(s.CallCtx, "spam", constraints.MustParse("instance-type=n1-standard-2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+}
+
+func (s *environInstSuite) TestResizeInstanceNotFound(c *gc.C) {
+	_, err := s.Env.ResizeInstance(s.CallCtx, "spam", constraints.MustParse("cores=2"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *environInstSuite) TestControllerInstances(c *gc.C) {
 	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
 
@@ -231,6 +281,52 @@ func (s *environInstSuite) TestAdoptResources(c *gc.C) {
 	c.Check(call.Value, gc.Equals, "other-uuid")
 }
 
+func (s *environInstSuite) TestTagInstance(c *gc.C) {
+	err := s.Env.TagInstance(s.CallCtx, "john", map[string]string{
+		"owner":       "bob",
+		"cost-center": "cc-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	for i, kv := range [][2]string{{"cost-center", "cc-1"}, {"owner", "bob"}} {
+		call := s.FakeConn.Calls[i]
+		c.Check(call.FuncName, gc.Equals, "UpdateMetadata")
+		c.Check(call.IDs, gc.DeepEquals, []string{"john"})
+		c.Check(call.Key, gc.Equals, kv[0])
+		c.Check(call.Value, gc.Equals, kv[1])
+	}
+}
+
+func (s *environInstSuite) TestTagInstanceInvalidCredentialError(c *gc.C) {
+	s.FakeConn.Err = gce.InvalidCredentialError
+	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
+	err := s.Env.TagInstance(s.CallCtx, "john", map[string]string{"owner": "bob"})
+	c.Check(err, gc.NotNil)
+	c.Assert(s.InvalidatedCredentials, jc.IsTrue)
+}
+
+func (s *environInstSuite) TestRetagResources(c *gc.C) {
+	john := s.NewInstance(c, "john")
+	misty := s.NewInstance(c, "misty")
+	s.FakeEnviron.Insts = []instances.Instance{john, misty}
+
+	err := s.Env.RetagResources(s.CallCtx, tags.TemplateVars{Owner: "bob"}, []string{"team", "site"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	for i, key := range []string{"team", "site"} {
+		call := s.FakeConn.Calls[i]
+		c.Check(call.FuncName, gc.Equals, "RemoveMetadata")
+		c.Check(call.IDs, gc.DeepEquals, []string{"john", "misty"})
+		c.Check(call.Key, gc.Equals, key)
+	}
+}
+
+func (s *environInstSuite) TestRetagResourcesNothingRemoved(c *gc.C) {
+	err := s.Env.RetagResources(s.CallCtx, tags.TemplateVars{Owner: "bob"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 0)
+}
+
 func (s *environInstSuite) TestAdoptResourcesInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
@@ -35,6 +35,8 @@ var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
 	constraints.AllocatePublicIP,
+	// Preemptible instances have a fixed price.
+	constraints.MaxPrice,
 }
 
 // instanceTypeConstraints defines the fields defined on each of the
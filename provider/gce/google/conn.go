@@ -47,6 +47,21 @@ type service interface {
 	// the instance is removed (or the request fails).
 	RemoveInstance(projectID, id, zone string) error
 
+	// StopInstance sends a request to the GCE API to stop the instance
+	// with the provided ID (in the specified zone). The call blocks until
+	// the instance is stopped (or the request fails).
+	StopInstance(projectID, zone, id string) error
+
+	// StartInstance sends a request to the GCE API to start the stopped
+	// instance with the provided ID (in the specified zone). The call
+	// blocks until the instance is started (or the request fails).
+	StartInstance(projectID, zone, id string) error
+
+	// SetMachineType sends a request to the GCE API to change the machine
+	// type of the stopped instance with the provided ID (in the specified
+	// zone). The call blocks until the request is completed or fails.
+	SetMachineType(projectID, zone, id, machineType string) error
+
 	// SetMetadata sends a request to the GCE API to update one
 	// instance's metadata. The call blocks until the request is
 	// completed or fails.
@@ -84,6 +84,28 @@ func (gce *Connection) Instances(prefix string, statuses ...string) ([]Instance,
 	return insts, nil
 }
 
+// ResizeInstance stops the identified instance, changes its machine
+// type and starts it again. The call blocks until the instance is
+// running again or a request fails.
+func (gce *Connection) ResizeInstance(id, zone, machineType string) error {
+	if err := gce.service.StopInstance(gce.projectID, zone, id); err != nil {
+		return errors.Annotatef(err, "stopping instance %q", id)
+	}
+	err := gce.service.SetMachineType(gce.projectID, zone, id, formatMachineType(zone, machineType))
+	if err != nil {
+		// Don't leave the instance stopped because the new machine
+		// type was refused.
+		if startErr := gce.service.StartInstance(gce.projectID, zone, id); startErr != nil {
+			logger.Errorf("cannot restart instance %q: %v", id, startErr)
+		}
+		return errors.Annotatef(err, "changing machine type of instance %q", id)
+	}
+	if err := gce.service.StartInstance(gce.projectID, zone, id); err != nil {
+		return errors.Annotatef(err, "starting instance %q", id)
+	}
+	return nil
+}
+
 // removeInstance sends a request to the GCE API to remove the instance
 // with the provided ID (in the specified zone). The call blocks until
 // the instance is removed (or the request fails).
@@ -191,6 +213,56 @@ func (gce *Connection) updateInstanceMetadata(instance *compute.Instance, key, v
 	return errors.Trace(gce.service.SetMetadata(gce.projectID, zoneName, instance.Name, metadata))
 }
 
+// RemoveMetadata removes the metadata key from all of the instance
+// ids given. The call blocks until all of the instances are updated
+// or the request fails.
+func (gce *Connection) RemoveMetadata(key string, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	instances, err := gce.service.ListInstances(gce.projectID, "")
+	if err != nil {
+		return errors.Annotatef(err, "removing metadata from instances %v", ids)
+	}
+	var failed []string
+	for _, instID := range ids {
+		for _, inst := range instances {
+			if inst.Name == instID {
+				if err := gce.removeInstanceMetadata(inst, key); err != nil {
+					failed = append(failed, instID)
+					logger.Errorf("while removing metadata %q from instance %q: %v",
+						key, instID, err)
+				}
+				break
+			}
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("some metadata removals failed: %v", failed)
+	}
+	return nil
+}
+
+func (gce *Connection) removeInstanceMetadata(instance *compute.Instance, key string) error {
+	metadata := instance.Metadata
+	if metadata == nil || findMetadataItem(metadata.Items, key) == nil {
+		// There's nothing to remove.
+		return nil
+	}
+	items := make([]*compute.MetadataItems, 0, len(metadata.Items))
+	for _, item := range metadata.Items {
+		if item != nil && item.Key == key {
+			continue
+		}
+		items = append(items, item)
+	}
+	metadata.Items = items
+	// The GCE API won't accept a full URL for the zone (lp:1667172).
+	zoneName := path.Base(instance.Zone)
+	return errors.Trace(gce.service.SetMetadata(gce.projectID, zoneName, instance.Name, metadata))
+}
+
 func findMetadataItem(items []*compute.MetadataItems, key string) *compute.MetadataItems {
 	for _, item := range items {
 		if item == nil {
@@ -104,6 +104,21 @@ func (s *instanceSuite) TestConnectionAddInstanceAPI(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestConnectionAddInstancePreemptible(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	s.InstanceSpec.Preemptible = true
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	automaticRestart := false
+	c.Check(s.FakeConn.Calls[0].InstValue.Scheduling, jc.DeepEquals, &compute.Scheduling{
+		Preemptible:       true,
+		AutomaticRestart:  &automaticRestart,
+		OnHostMaintenance: "TERMINATE",
+	})
+}
+
 func (s *connSuite) TestConnectionAddInstanceFailed(c *gc.C) {
 	s.FakeConn.Instance = &s.RawInstanceFull
 
@@ -184,6 +199,7 @@ func (s *connSuite) TestConnectionInstances(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	google.SetInstanceSpec(&s.Instance, nil)
+	s.Instance.MachineType = "mtype"
 	c.Check(insts, jc.DeepEquals, []google.Instance{s.Instance})
 }
 
@@ -235,6 +251,33 @@ func (s *connSuite) TestConnectionRemoveInstanceFirewallFailed(c *gc.C) {
 	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
 }
 
+func (s *connSuite) TestConnectionResizeInstanceAPI(c *gc.C) {
+	err := s.Conn.ResizeInstance("spam", "a-zone", "n1-standard-4")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "StopInstance")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "a-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "SetMachineType")
+	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[1].MachineType, gc.Equals, "zones/a-zone/machineTypes/n1-standard-4")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "StartInstance")
+	c.Check(s.FakeConn.Calls[2].ID, gc.Equals, "spam")
+}
+
+func (s *connSuite) TestConnectionResizeInstanceRestartsOnFailure(c *gc.C) {
+	failure := errors.New("<unknown>")
+	s.FakeConn.Err = failure
+	s.FakeConn.FailOnCall = 1
+
+	err := s.Conn.ResizeInstance("spam", "a-zone", "n1-standard-4")
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "StartInstance")
+}
+
 func (s *connSuite) TestConnectionRemoveInstances(c *gc.C) {
 	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
 
@@ -464,6 +507,48 @@ func (s *connSuite) TestUpdateMetadataChecksCurrentValue(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 }
 
+func (s *connSuite) TestRemoveMetadata(c *gc.C) {
+	s.RawInstanceFull.Zone = "http://eels/lone/wolf/a-zone"
+	s.RawInstanceFull.Metadata.Items = append(s.RawInstanceFull.Metadata.Items, makeMetadataItems("rick", "moranis"))
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+
+	err := s.Conn.RemoveMetadata("eggs", s.RawInstanceFull.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
+
+	call := s.FakeConn.Calls[1]
+	c.Check(call.FuncName, gc.Equals, "SetMetadata")
+	c.Check(call.ProjectID, gc.Equals, "spam")
+	c.Check(call.ZoneName, gc.Equals, "a-zone")
+	c.Check(call.InstanceId, gc.Equals, "spam")
+
+	md := call.Metadata
+	c.Check(md.Fingerprint, gc.Equals, "heymumwatchthis")
+	c.Assert(md.Items, gc.HasLen, 1)
+	checkMetadataItems(c, md.Items[0], "rick", "moranis")
+}
+
+func (s *connSuite) TestRemoveMetadataMissingKey(c *gc.C) {
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+	err := s.Conn.RemoveMetadata("rick", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Since the instance doesn't have the key we don't issue the update.
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
+}
+
+func (s *connSuite) TestRemoveMetadataError(c *gc.C) {
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+	s.FakeConn.Err = errors.New("kablooey")
+	s.FakeConn.FailOnCall = 1
+
+	err := s.Conn.RemoveMetadata("eggs", "spam")
+	c.Assert(err, gc.ErrorMatches, `some metadata removals failed: \[spam\]`)
+}
+
 func makeMetadataItems(key, value string) *compute.MetadataItems {
 	return &compute.MetadataItems{Key: key, Value: google.StringPtr(value)}
 }
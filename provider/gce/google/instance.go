@@ -52,10 +52,14 @@ type InstanceSpec struct {
 	// AvailabilityZone holds the name of the availability zone in which
 	// to create the instance.
 	AvailabilityZone string
+
+	// Preemptible indicates that the instance is cheaper, but may be
+	// stopped by GCE at any time.
+	Preemptible bool
 }
 
 func (is InstanceSpec) raw() *compute.Instance {
-	return &compute.Instance{
+	raw := &compute.Instance{
 		Name:              is.ID,
 		Disks:             is.disks(),
 		NetworkInterfaces: is.networkInterfaces(),
@@ -63,6 +67,16 @@ func (is InstanceSpec) raw() *compute.Instance {
 		Tags:              &compute.Tags{Items: is.Tags},
 		// MachineType is set in the addInstance call.
 	}
+	if is.Preemptible {
+		// Preemptible instances can't be restarted or migrated.
+		automaticRestart := false
+		raw.Scheduling = &compute.Scheduling{
+			Preemptible:       true,
+			AutomaticRestart:  &automaticRestart,
+			OnHostMaintenance: "TERMINATE",
+		}
+	}
+	return raw
 }
 
 // Summary builds an InstanceSummary based on the spec and returns it.
@@ -104,6 +118,8 @@ type InstanceSummary struct {
 	ZoneName string
 	// Status holds the status of the instance at a certain point in time.
 	Status string
+	// MachineType is the unqualified name of the instance's machine type.
+	MachineType string
 	// Metadata is the instance metadata.
 	Metadata map[string]string
 	// Addresses are the IP Addresses associated with the instance.
@@ -111,6 +127,8 @@ type InstanceSummary struct {
 	// NetworkInterfaces are the network connections associated with
 	// the instance.
 	NetworkInterfaces []*compute.NetworkInterface
+	// Preemptible indicates that GCE may stop the instance at any time.
+	Preemptible bool
 }
 
 func newInstanceSummary(raw *compute.Instance) InstanceSummary {
@@ -118,9 +136,11 @@ func newInstanceSummary(raw *compute.Instance) InstanceSummary {
 		ID:                raw.Name,
 		ZoneName:          path.Base(raw.Zone),
 		Status:            raw.Status,
+		MachineType:       path.Base(raw.MachineType),
 		Metadata:          unpackMetadata(raw.Metadata),
 		Addresses:         extractAddresses(raw.NetworkInterfaces...),
 		NetworkInterfaces: raw.NetworkInterfaces,
+		Preemptible:       raw.Scheduling != nil && raw.Scheduling.Preemptible,
 	}
 }
 
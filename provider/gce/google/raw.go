@@ -114,6 +114,37 @@ func (rc *rawConn) RemoveInstance(projectID, zone, id string) error {
 	return errors.Trace(err)
 }
 
+func (rc *rawConn) StopInstance(projectID, zone, id string) error {
+	call := rc.Instances.Stop(projectID, zone, id)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong, logOperationErrors)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) StartInstance(projectID, zone, id string) error {
+	call := rc.Instances.Start(projectID, zone, id)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong, logOperationErrors)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) SetMachineType(projectID, zone, id, machineType string) error {
+	request := &compute.InstancesSetMachineTypeRequest{MachineType: machineType}
+	call := rc.Instances.SetMachineType(projectID, zone, id, request)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong, logOperationErrors)
+	return errors.Trace(err)
+}
+
 func matchesPrefix(firewallName, namePrefix string) bool {
 	return firewallName == namePrefix || strings.HasPrefix(firewallName, namePrefix+"-")
 }
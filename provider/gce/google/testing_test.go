@@ -168,6 +168,7 @@ type fakeCall struct {
 	Metadata         *compute.Metadata
 	LabelFingerprint string
 	Labels           map[string]string
+	MachineType      string
 }
 
 type fakeConn struct {
@@ -266,6 +267,55 @@ func (rc *fakeConn) RemoveInstance(projectID, zone, id string) error {
 	return err
 }
 
+func (rc *fakeConn) StopInstance(projectID, zone, id string) error {
+	call := fakeCall{
+		FuncName:  "StopInstance",
+		ProjectID: projectID,
+		ID:        id,
+		ZoneName:  zone,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) StartInstance(projectID, zone, id string) error {
+	call := fakeCall{
+		FuncName:  "StartInstance",
+		ProjectID: projectID,
+		ID:        id,
+		ZoneName:  zone,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) SetMachineType(projectID, zone, id, machineType string) error {
+	call := fakeCall{
+		FuncName:    "SetMachineType",
+		ProjectID:   projectID,
+		ID:          id,
+		ZoneName:    zone,
+		MachineType: machineType,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
 func (rc *fakeConn) GetFirewalls(projectID, name string) ([]*compute.Firewall, error) {
 	call := fakeCall{
 		FuncName:  "GetFirewalls",
@@ -500,6 +500,7 @@ type fakeConnCall struct {
 	Value            string
 	LabelFingerprint string
 	Labels           map[string]string
+	MachineType      string
 }
 
 type fakeConn struct {
@@ -570,6 +571,16 @@ func (fc *fakeConn) RemoveInstances(prefix string, ids ...string) error {
 	return fc.err()
 }
 
+func (fc *fakeConn) ResizeInstance(id, zone, machineType string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:    "ResizeInstance",
+		ID:          id,
+		ZoneName:    zone,
+		MachineType: machineType,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) UpdateMetadata(key, value string, ids ...string) error {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "UpdateMetadata",
@@ -580,6 +591,15 @@ func (fc *fakeConn) UpdateMetadata(key, value string, ids ...string) error {
 	return fc.err()
 }
 
+func (fc *fakeConn) RemoveMetadata(key string, ids ...string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveMetadata",
+		Key:      key,
+		IDs:      ids,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) IngressRules(fwname string) (firewall.IngressRules, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName:     "Ports",
@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// The libvirt-specific config keys.
+const (
+	cfgStoragePool = "libvirt-pool"
+	cfgBridge      = "bridge"
+)
+
+var configSchema = environschema.Fields{
+	cfgStoragePool: {
+		Description: "The libvirt storage pool in which the images, root disks and volumes of instances are created.",
+		Type:        environschema.Tstring,
+	},
+	cfgBridge: {
+		Description: "The host bridge that the network interfaces of instances are attached to.",
+		Type:        environschema.Tstring,
+	},
+}
+
+// configFields is the spec for each libvirt config value's type.
+var configFields = func() schema.Fields {
+	fs, _, err := configSchema.ValidationSchema()
+	if err != nil {
+		panic(err)
+	}
+	return fs
+}()
+
+var configImmutableFields = []string{
+	// Changing the pool would orphan the root disks and volumes
+	// of existing instances.
+	cfgStoragePool,
+}
+
+var configDefaults = schema.Defaults{
+	cfgStoragePool: "default",
+	cfgBridge:      "virbr0",
+}
+
+type environConfig struct {
+	config *config.Config
+	attrs  map[string]interface{}
+}
+
+// newConfig builds a new environConfig from the provided Config
+// filling in default values, if any. It returns an error if the
+// resulting configuration is not valid.
+func newConfig(cfg, old *config.Config) (*environConfig, error) {
+	// Ensure that the provided config is valid.
+	if err := config.Validate(cfg, old); err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs, err := cfg.ValidateUnknownAttrs(configFields, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, attr := range []string{cfgStoragePool, cfgBridge} {
+		if attrs[attr].(string) == "" {
+			return nil, errors.Errorf("%s: must not be empty", attr)
+		}
+	}
+
+	if old != nil {
+		// There's an old configuration. Validate it so that any
+		// default values are correctly coerced for when we check
+		// the old values later.
+		oldEcfg, err := newConfig(old, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid base config")
+		}
+		for _, attr := range configImmutableFields {
+			oldv, newv := oldEcfg.attrs[attr], attrs[attr]
+			if oldv != newv {
+				return nil, errors.Errorf(
+					"%s: cannot change from %v to %v",
+					attr, oldv, newv,
+				)
+			}
+		}
+	}
+
+	// Record the defaults in the config.
+	cfg, err = cfg.Apply(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ecfg := &environConfig{
+		config: cfg,
+		attrs:  attrs,
+	}
+	return ecfg, nil
+}
+
+func (c *environConfig) storagePool() string {
+	return c.attrs[cfgStoragePool].(string)
+}
+
+func (c *environConfig) bridge() string {
+	return c.attrs[cfgBridge].(string)
+}
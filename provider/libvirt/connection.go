@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+// libvirtConnection is the subset of libvirt's API used by the provider,
+// for a connection to the libvirt daemon of one hypervisor host.
+type libvirtConnection interface {
+	// HostArch returns the architecture of the hypervisor host.
+	HostArch() (string, error)
+
+	// Domains returns the domains whose names start with prefix.
+	Domains(prefix string) ([]domain, error)
+
+	// CreateDomain defines the named persistent domain from the given
+	// XML definition, records the tags in its metadata, and starts it.
+	CreateDomain(name, definition string, tags map[string]string) error
+
+	// SetDomainTags replaces the tags recorded in the metadata of the
+	// named domain.
+	SetDomainTags(name string, tags map[string]string) error
+
+	// RemoveDomain stops the named domain, if it is running, and
+	// undefines it. The volumes of the domain's disks with the given
+	// targets, e.g. "vda", are deleted; its other volumes are left in
+	// place.
+	RemoveDomain(name string, diskTargets ...string) error
+
+	// DomainAddresses returns the IP addresses of the named domain's
+	// network interfaces.
+	DomainAddresses(name string) ([]string, error)
+
+	// AttachDisk attaches the raw volume at path to the named domain,
+	// identified to the guest by the given serial.
+	AttachDisk(domainName, path, serial string) error
+
+	// DetachDisk detaches the volume at path from the named domain.
+	DetachDisk(domainName, path string) error
+
+	// Volumes returns the volumes in the given storage pool.
+	Volumes(pool string) ([]volume, error)
+
+	// Volume returns the named volume in the given storage pool. An
+	// error satisfying errors.IsNotFound is returned if there is no
+	// such volume.
+	Volume(pool, name string) (volume, error)
+
+	// CreateVolume creates a volume in the given storage pool.
+	CreateVolume(pool string, spec volumeSpec) (volume, error)
+
+	// UploadVolume replaces the contents of the named volume with
+	// those of the local file at path.
+	UploadVolume(pool, name, path string) error
+
+	// RemoveVolume deletes the named volume from the given storage pool.
+	RemoveVolume(pool, name string) error
+}
+
+// Domain states reported by libvirt.
+const (
+	domainRunning  = "running"
+	domainPaused   = "paused"
+	domainShutOff  = "shut off"
+	domainCrashed  = "crashed"
+	domainShutdown = "in shutdown"
+)
+
+// domain describes a libvirt domain, i.e. a virtual machine.
+type domain struct {
+	// Name is the name of the domain.
+	Name string
+
+	// State is the domain's state, e.g. "running" or "shut off".
+	State string
+
+	// Tags holds the tags recorded in the domain's metadata.
+	Tags map[string]string
+}
+
+// volume describes a volume in a libvirt storage pool.
+type volume struct {
+	// Name is the name of the volume within its pool.
+	Name string
+
+	// Path is the path of the volume on the hypervisor host.
+	Path string
+
+	// Size is the capacity of the volume in MiB.
+	Size uint64
+}
+
+// volumeSpec describes a volume to create.
+type volumeSpec struct {
+	// Name is the name of the volume within its pool.
+	Name string
+
+	// Size is the capacity of the volume in MiB.
+	Size uint64
+
+	// Format is the format of the volume, e.g. "qcow2" or "raw".
+	Format string
+
+	// BackingVolume, if set, is the path of a qcow2 volume that
+	// the new volume is a copy-on-write overlay of.
+	BackingVolume string
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+)
+
+const (
+	credAttrPrivateKey   = "private-key"
+	credAttrKnownHostKey = "known-host-key"
+)
+
+// sshTransports holds the libvirt transports that authenticate with
+// an SSH private key.
+var sshTransports = set.NewStrings("ssh", "libssh", "libssh2")
+
+// credentialDir returns the directory in which the files holding the
+// credential of the given model are written.
+var credentialDir = func(modelUUID string) string {
+	return filepath.Join(os.TempDir(), "juju-libvirt-"+modelUUID)
+}
+
+// environProviderCredentials implements environs.ProviderCredentials.
+// libvirt connections are authenticated by the transport named in the
+// connection URI. With an empty credential the ssh keys of the user
+// running Juju are used for qemu+ssh URIs; an ssh-key credential
+// supplies the private key and the hypervisor's host key instead.
+type environProviderCredentials struct{}
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.EmptyAuthType: {},
+		cloud.SSHKeyAuthType: {{
+			credAttrPrivateKey, cloud.CredentialAttr{
+				Description: "The SSH private key used to connect to the hypervisor",
+				Hidden:      true,
+				FileAttr:    "private-key-path",
+			},
+		}, {
+			credAttrKnownHostKey, cloud.CredentialAttr{
+				Description: "The SSH host key of the hypervisor, e.g. ssh-ed25519 AAAA...",
+			},
+		}},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	return cloud.NewEmptyCloudCredential(), nil
+}
+
+// FinalizeCredential is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
+
+// connectionURI returns the URI with which virsh connects to the cloud.
+// For an ssh-key credential, the private key and a known_hosts file
+// holding the host key are written to dir, and passed to libvirt with
+// the keyfile and known_hosts URI parameters. no_tty stops ssh from
+// prompting, so an unknown host fails rather than hangs.
+func connectionURI(spec environscloudspec.CloudSpec, dir string) (string, error) {
+	if spec.Credential == nil || spec.Credential.AuthType() != cloud.SSHKeyAuthType {
+		return spec.Endpoint, nil
+	}
+	u, err := url.Parse(spec.Endpoint)
+	if err != nil {
+		return "", errors.NotValidf("endpoint %q", spec.Endpoint)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", errors.Trace(err)
+	}
+	attrs := spec.Credential.Attributes()
+	keyFile := filepath.Join(dir, "id")
+	if err := ioutil.WriteFile(keyFile, []byte(attrs[credAttrPrivateKey]), 0600); err != nil {
+		return "", errors.Annotate(err, "writing private key")
+	}
+	// ssh records hosts on a non-default port as [host]:port.
+	host := u.Hostname()
+	if port := u.Port(); port != "" && port != "22" {
+		host = "[" + host + "]:" + port
+	}
+	knownHosts := filepath.Join(dir, "known_hosts")
+	line := host + " " + strings.TrimSpace(attrs[credAttrKnownHostKey]) + "\n"
+	if err := ioutil.WriteFile(knownHosts, []byte(line), 0600); err != nil {
+		return "", errors.Annotate(err, "writing known hosts")
+	}
+
+	query := u.Query()
+	query.Set("keyfile", keyFile)
+	query.Set("known_hosts", knownHosts)
+	query.Set("no_tty", "1")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
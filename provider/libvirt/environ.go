@@ -0,0 +1,253 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/common"
+)
+
+type environ struct {
+	name  string
+	uuid  string
+	cloud environscloudspec.CloudSpec
+	conn  libvirtConnection
+
+	lock sync.Mutex // lock protects access to ecfg
+	ecfg *environConfig
+
+	// namespace is used to create the machine and device hostnames.
+	namespace instance.Namespace
+
+	// imageMutex serialises the uploading of images to the hypervisor.
+	imageMutex sync.Mutex
+}
+
+var _ environs.Environ = (*environ)(nil)
+var _ environs.InstanceTagger = (*environ)(nil)
+
+// Function entry points defined as variables so they can be overridden
+// for testing purposes.
+var (
+	newConnection = func(uri string) libvirtConnection {
+		return newVirshConnection(uri, runVirsh)
+	}
+	destroyEnv = common.Destroy
+	bootstrap  = common.Bootstrap
+)
+
+func newEnviron(cloud environscloudspec.CloudSpec, cfg *config.Config) (*environ, error) {
+	ecfg, err := newConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+
+	namespace, err := instance.NewNamespace(cfg.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	e := &environ{
+		name:      ecfg.config.Name(),
+		uuid:      ecfg.config.UUID(),
+		ecfg:      ecfg,
+		namespace: namespace,
+	}
+	if err = e.SetCloudSpec(cloud); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// SetCloudSpec is specified in the environs.Environ interface.
+func (env *environ) SetCloudSpec(spec environscloudspec.CloudSpec) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	uri, err := connectionURI(spec, credentialDir(env.uuid))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env.cloud = spec
+	env.conn = newConnection(uri)
+	return nil
+}
+
+// Name returns the name of the environment.
+func (env *environ) Name() string {
+	return env.name
+}
+
+// Provider returns the environment provider that created this env.
+func (*environ) Provider() environs.EnvironProvider {
+	return providerInstance
+}
+
+// SetConfig updates the env's configuration.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	ecfg, err := newConfig(cfg, env.ecfg.config)
+	if err != nil {
+		return errors.Annotate(err, "invalid config change")
+	}
+	env.ecfg = ecfg
+	return nil
+}
+
+// Config returns the configuration data with which the env was created.
+func (env *environ) Config() *config.Config {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg.config
+}
+
+func (env *environ) envConfig() *environConfig {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg
+}
+
+// PrepareForBootstrap implements environs.Environ.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext, controllerName string) error {
+	if ctx.ShouldVerifyCredentials() {
+		if _, err := env.conn.HostArch(); err != nil {
+			return errors.Annotatef(err, "connecting to %s", env.cloud.Endpoint)
+		}
+	}
+	return nil
+}
+
+// Create implements environs.Environ.
+func (env *environ) Create(ctx context.ProviderCallContext, p environs.CreateParams) error {
+	return nil
+}
+
+// Bootstrap creates a new instance, choosing the series and arch out of
+// available tools. The series and arch are returned along with a func
+// that must be called to finalize the bootstrap process by transferring
+// the tools and installing the initial juju controller.
+func (env *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.ProviderCallContext, params environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return bootstrap(ctx, env, callCtx, params)
+}
+
+// Destroy shuts down all known machines and destroys the rest of the
+// known environment.
+func (env *environ) Destroy(ctx context.ProviderCallContext) error {
+	return errors.Trace(destroyEnv(env, ctx))
+}
+
+// DestroyController implements the Environ interface.
+func (env *environ) DestroyController(ctx context.ProviderCallContext, controllerUUID string) error {
+	if err := env.Destroy(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	// Destroy the instances of the hosted models too. Their storage
+	// volumes are left in place, as the volumes are not tagged.
+	domains, err := env.conn.Domains("juju-")
+	if err != nil {
+		return errors.Annotate(err, "listing domains")
+	}
+	var ids []instance.Id
+	for _, d := range domains {
+		if d.Tags[tags.JujuController] != controllerUUID {
+			continue
+		}
+		ids = append(ids, instance.Id(d.Name))
+	}
+	return errors.Trace(env.removeDomains(ids))
+}
+
+// AdoptResources updates the controller tags on all instances to have the
+// new controller id. It's part of the Environ interface.
+func (env *environ) AdoptResources(ctx context.ProviderCallContext, controllerUUID string, fromVersion version.Number) error {
+	instances, err := env.allInstances()
+	if err != nil {
+		return errors.Annotate(err, "all instances")
+	}
+
+	var failed []instance.Id
+	for _, inst := range instances {
+		err := env.TagInstance(ctx, inst.Id(), map[string]string{tags.JujuController: controllerUUID})
+		if err != nil {
+			logger.Errorf("error setting controller uuid tag for %q: %v", inst.Id(), err)
+			failed = append(failed, inst.Id())
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("failed to update controller for some instances: %v", failed)
+	}
+	return nil
+}
+
+// TagInstance implements environs.InstanceTagger. The tags are recorded
+// in the metadata of the instance's domain, as they are when it is
+// started.
+func (env *environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	inst, err := env.instance(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	merged := make(map[string]string)
+	for k, v := range inst.domain.Tags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return errors.Annotatef(env.conn.SetDomainTags(string(id), merged), "tagging instance %q", id)
+}
+
+var unsupportedConstraints = []string{
+	constraints.CpuPower,
+	constraints.Tags,
+	constraints.InstanceType,
+	constraints.Container,
+	constraints.VirtType,
+	constraints.Spaces,
+	constraints.Zones,
+	constraints.AllocatePublicIP,
+	constraints.Spot,
+	constraints.MaxPrice,
+}
+
+// ConstraintsValidator returns a Validator value which is used to
+// validate and merge constraints.
+func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
+	hostArch, err := env.conn.HostArch()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, []string{hostArch})
+	return validator, nil
+}
+
+// PrecheckInstance verifies that the provided series and constraints
+// are valid for use in creating an instance in this environment.
+func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	if args.Placement != "" {
+		return errors.Errorf("unknown placement directive: %s", args.Placement)
+	}
+	return nil
+}
+
+// InstanceTypes implements environs.InstanceTypesFetcher.
+func (env *environ) InstanceTypes(ctx context.ProviderCallContext, c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	return instances.InstanceTypesWithCostMetadata{}, errors.NotSupportedf("InstanceTypes")
+}
@@ -0,0 +1,429 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	kvmlibvirt "github.com/juju/juju/container/kvm/libvirt"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/tools"
+)
+
+const (
+	// defaultCores is the number of cores of an instance whose
+	// constraints do not specify any.
+	defaultCores = 1
+
+	// defaultMem is the memory, in MiB, of an instance whose
+	// constraints do not specify any.
+	defaultMem = 2048
+
+	// rootDiskTarget and dataSourceTarget are the targets of an
+	// instance's root disk and cloud-init data source disk, in the
+	// order they are given to kvmlibvirt.NewDomain.
+	rootDiskTarget   = "vda"
+	dataSourceTarget = "vdb"
+
+	// nvramCode is the path, on the hypervisor host, of the firmware
+	// used to boot arm64 instances with UEFI.
+	nvramCode = "/usr/share/AAVMF/AAVMF_CODE.fd"
+)
+
+// MaintainInstance is specified in the InstanceBroker interface.
+func (*environ) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(
+	ctx context.ProviderCallContext, args environs.StartInstanceParams,
+) (*environs.StartInstanceResult, error) {
+	series := args.Tools.OneSeries()
+	logger.Debugf("StartInstance: %q, %s", args.InstanceConfig.MachineId, series)
+
+	arch, err := env.finishInstanceConfig(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	d, hwc, err := env.newDomain(args, series, arch)
+	if err != nil {
+		if args.StatusCallback != nil {
+			args.StatusCallback(status.ProvisioningError, err.Error(), nil)
+		}
+		return nil, errors.Trace(err)
+	}
+	logger.Infof("started instance %q", d.Name)
+
+	result := environs.StartInstanceResult{
+		Instance: newInstance(*d, env),
+		Hardware: hwc,
+	}
+	return &result, nil
+}
+
+func (env *environ) finishInstanceConfig(args environs.StartInstanceParams) (string, error) {
+	arch, err := env.conn.HostArch()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	tools, err := args.Tools.Match(tools.Filter{Arch: arch})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := args.InstanceConfig.SetTools(tools); err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, env.Config()); err != nil {
+		return "", errors.Trace(err)
+	}
+	return arch, nil
+}
+
+// newDomain creates the volumes of a new instance, defines its domain
+// and starts it. The volumes are created in the pool named by the
+// root-disk-source constraint, or the model's libvirt-pool.
+func (env *environ) newDomain(
+	args environs.StartInstanceParams, series, arch string,
+) (_ *domain, _ *instance.HardwareCharacteristics, err error) {
+	statusCallback := func(msg string) {
+		if args.StatusCallback != nil {
+			args.StatusCallback(status.Provisioning, msg, nil)
+		}
+	}
+
+	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	userData, err := env.userData(args)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	ecfg := env.envConfig()
+	pool := ecfg.storagePool()
+	if args.Constraints.HasRootDiskSource() {
+		pool = *args.Constraints.RootDiskSource
+	}
+
+	// Remove the volumes created for the instance if it cannot be
+	// started. Once its domain is defined, they are removed with it.
+	var created []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, name := range created {
+			if removeErr := env.conn.RemoveVolume(pool, name); removeErr != nil {
+				logger.Warningf("cannot remove volume %q: %v", name, removeErr)
+			}
+		}
+	}()
+
+	statusCallback("Creating cloud-init data source")
+	dataSource, err := env.createDataSourceVolume(pool, hostname, userData)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "creating cloud-init data source")
+	}
+	created = append(created, dataSource.Name)
+
+	image, err := env.ensureImage(pool, series, arch, statusCallback)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	rootDisk := common.MinRootDiskSizeGiB(series) * 1024
+	if args.Constraints.HasRootDisk() {
+		if *args.Constraints.RootDisk < rootDisk {
+			logger.Infof("root disk size %dM is smaller than the minimum of %dM, using the minimum",
+				*args.Constraints.RootDisk, rootDisk)
+		} else {
+			rootDisk = *args.Constraints.RootDisk
+		}
+	}
+	statusCallback("Creating root disk")
+	root, err := env.conn.CreateVolume(pool, volumeSpec{
+		Name:          hostname + "-root",
+		Size:          rootDisk,
+		Format:        "qcow2",
+		BackingVolume: image.Path,
+	})
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "creating root disk")
+	}
+	created = append(created, root.Name)
+
+	cores := uint64(defaultCores)
+	if args.Constraints.HasCpuCores() {
+		cores = *args.Constraints.CpuCores
+	}
+	mem := uint64(defaultMem)
+	if args.Constraints.HasMem() {
+		mem = *args.Constraints.Mem
+	}
+	params := domainParams{
+		hostname: hostname,
+		arch:     arch,
+		cores:    cores,
+		mem:      mem,
+		disks: []kvmlibvirt.DiskInfo{
+			diskInfo{driver: "qcow2", source: root.Path},
+			diskInfo{driver: "raw", source: dataSource.Path},
+		},
+		interfaces: []kvmlibvirt.InterfaceInfo{
+			interfaceInfo{
+				macAddress: network.GenerateVirtualMACAddress(),
+				bridge:     ecfg.bridge(),
+			},
+		},
+	}
+	def, err := kvmlibvirt.NewDomain(params)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	data, err := xml.Marshal(def)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	statusCallback("Starting instance")
+	if err := env.conn.CreateDomain(hostname, string(data), args.InstanceConfig.Tags); err != nil {
+		return nil, nil, errors.Annotate(err, "creating domain")
+	}
+
+	rootDisk = root.Size
+	hwc := &instance.HardwareCharacteristics{
+		Arch:     &arch,
+		CpuCores: &cores,
+		Mem:      &mem,
+		RootDisk: &rootDisk,
+	}
+	d := &domain{
+		Name:  hostname,
+		State: domainRunning,
+		Tags:  args.InstanceConfig.Tags,
+	}
+	return d, hwc, nil
+}
+
+// userData returns the cloud-init user data of a new instance.
+func (env *environ) userData(args environs.StartInstanceParams) ([]byte, error) {
+	cloudCfg, err := cloudinit.New(args.InstanceConfig.Series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if args.InstanceConfig.Controller != nil {
+		// Controllers start instances with virsh and genisoimage.
+		cloudCfg.AddPackage("libvirt-clients")
+		cloudCfg.AddPackage("genisoimage")
+	}
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudCfg, libvirtRenderer{})
+	if err != nil {
+		return nil, errors.Annotate(err, "composing user data")
+	}
+	logger.Debugf("libvirt user data; %d bytes", len(userData))
+	return userData, nil
+}
+
+// createDataSourceVolume creates a volume holding the cloud-init NoCloud
+// data source of the named instance.
+func (env *environ) createDataSourceVolume(pool, hostname string, userData []byte) (volume, error) {
+	f, err := ioutil.TempFile("", hostname+"-*.iso")
+	if err != nil {
+		return volume{}, errors.Trace(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+	if err := makeCloudInitISO(f.Name(), userData, metaData(hostname)); err != nil {
+		return volume{}, errors.Trace(err)
+	}
+	info, err := os.Stat(f.Name())
+	if err != nil {
+		return volume{}, errors.Trace(err)
+	}
+
+	name := hostname + "-ds.iso"
+	vol, err := env.conn.CreateVolume(pool, volumeSpec{
+		Name:   name,
+		Size:   (uint64(info.Size()) + (1 << 20) - 1) >> 20,
+		Format: "raw",
+	})
+	if err != nil {
+		return volume{}, errors.Trace(err)
+	}
+	if err := env.conn.UploadVolume(pool, name, f.Name()); err != nil {
+		if removeErr := env.conn.RemoveVolume(pool, name); removeErr != nil {
+			logger.Warningf("cannot remove volume %q: %v", name, removeErr)
+		}
+		return volume{}, errors.Trace(err)
+	}
+	return vol, nil
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
+	all, err := env.allInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]instances.Instance, len(all))
+	for i, inst := range all {
+		results[i] = inst
+	}
+	return results, nil
+}
+
+// AllRunningInstances implements environs.InstanceBroker.
+func (env *environ) AllRunningInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
+	all, err := env.allInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []instances.Instance
+	for _, inst := range all {
+		switch inst.domain.State {
+		case domainShutOff, domainCrashed:
+			continue
+		}
+		results = append(results, inst)
+	}
+	return results, nil
+}
+
+// StopInstances implements environs.InstanceBroker. The domains of the
+// instances are removed, along with their root disks and cloud-init
+// data sources.
+func (env *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	return errors.Trace(env.removeDomains(ids))
+}
+
+func (env *environ) removeDomains(ids []instance.Id) error {
+	var failed []instance.Id
+	for _, id := range ids {
+		if err := env.conn.RemoveDomain(string(id), rootDiskTarget, dataSourceTarget); err != nil {
+			logger.Errorf("cannot remove instance %q: %v", id, err)
+			failed = append(failed, id)
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("failed to remove some instances: %v", failed)
+	}
+	return nil
+}
+
+// domainParams implements the parameters of kvmlibvirt.NewDomain.
+type domainParams struct {
+	hostname   string
+	arch       string
+	cores      uint64
+	mem        uint64
+	disks      []kvmlibvirt.DiskInfo
+	interfaces []kvmlibvirt.InterfaceInfo
+}
+
+// Arch returns the architecture of the domain.
+func (p domainParams) Arch() string {
+	return p.arch
+}
+
+// CPUs returns the number of cores of the domain.
+func (p domainParams) CPUs() uint64 {
+	return p.cores
+}
+
+// DiskInfo returns the disks of the domain, the root disk first.
+func (p domainParams) DiskInfo() []kvmlibvirt.DiskInfo {
+	return p.disks
+}
+
+// Host returns the name of the domain.
+func (p domainParams) Host() string {
+	return p.hostname
+}
+
+// Loader returns the path of the UEFI firmware used to boot arm64
+// domains.
+func (p domainParams) Loader() string {
+	return nvramCode
+}
+
+// NetworkInfo returns the network interfaces of the domain.
+func (p domainParams) NetworkInfo() []kvmlibvirt.InterfaceInfo {
+	return p.interfaces
+}
+
+// RAM returns the memory of the domain in MiB.
+func (p domainParams) RAM() uint64 {
+	return p.mem
+}
+
+// ValidateDomainParams returns an error if the parameters are not
+// those of a bootable domain.
+func (p domainParams) ValidateDomainParams() error {
+	if p.hostname == "" {
+		return errors.Errorf("missing required hostname")
+	}
+	if len(p.disks) < 2 {
+		// We need at least the root disk and the data source disk.
+		return errors.Errorf("got %d disks, need at least 2", len(p.disks))
+	}
+	return nil
+}
+
+// diskInfo implements kvmlibvirt.DiskInfo.
+type diskInfo struct {
+	driver, source string
+}
+
+// Driver implements kvmlibvirt.DiskInfo.
+func (d diskInfo) Driver() string {
+	return d.driver
+}
+
+// Source implements kvmlibvirt.DiskInfo.
+func (d diskInfo) Source() string {
+	return d.source
+}
+
+// interfaceInfo implements kvmlibvirt.InterfaceInfo, for an interface
+// attached to a host bridge.
+type interfaceInfo struct {
+	macAddress string
+	bridge     string
+}
+
+// MACAddress implements kvmlibvirt.InterfaceInfo.
+func (i interfaceInfo) MACAddress() string {
+	return i.macAddress
+}
+
+// ParentInterfaceName implements kvmlibvirt.InterfaceInfo.
+func (i interfaceInfo) ParentInterfaceName() string {
+	return i.bridge
+}
+
+// ParentVirtualPortType implements kvmlibvirt.InterfaceInfo.
+func (i interfaceInfo) ParentVirtualPortType() string {
+	return ""
+}
+
+// InterfaceName implements kvmlibvirt.InterfaceInfo.
+func (i interfaceInfo) InterfaceName() string {
+	return "eth0"
+}
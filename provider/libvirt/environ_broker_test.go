@@ -0,0 +1,301 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/v2/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+)
+
+type environBrokerSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&environBrokerSuite{})
+
+func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
+	result, err := s.Env.StartInstance(s.CallCtx, s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	hostname, err := s.Env.namespace.Hostname("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Instance.Id(), gc.Equals, instance.Id(hostname))
+	c.Check(*result.Hardware.Arch, gc.Equals, arch.AMD64)
+	c.Check(*result.Hardware.CpuCores, gc.Equals, uint64(1))
+	c.Check(*result.Hardware.Mem, gc.Equals, uint64(2048))
+	c.Check(*result.Hardware.RootDisk, gc.Equals, uint64(8192))
+
+	// The data source, image and root disk were created in the pool.
+	volumes := s.FakeConn.pools["default"]
+	c.Assert(volumes, gc.HasLen, 3)
+	dataSource := volumes[hostname+"-ds.iso"]
+	c.Check(dataSource.Path, gc.Equals, "/var/lib/libvirt/images/"+hostname+"-ds.iso")
+	image := volumes["juju-image-focal-amd64-20201014"]
+	c.Check(image.Name, gc.Not(gc.Equals), "")
+	root := volumes[hostname+"-root"]
+	c.Check(root.Size, gc.Equals, uint64(8192))
+
+	s.FakeConn.CheckCall(c, 1, "CreateVolume", "default", volumeSpec{
+		Name:   hostname + "-ds.iso",
+		Format: "raw",
+	})
+	s.FakeConn.CheckCall(c, 4, "downloadImage", "server/releases/focal/disk1.img", "")
+	calls := s.FakeConn.Calls()
+	c.Assert(calls[5].FuncName, gc.Equals, "CreateVolume")
+	imageSpec := calls[5].Args[1].(volumeSpec)
+	c.Check(imageSpec.Name, gc.Equals, "juju-image-focal-amd64-20201014")
+	c.Check(imageSpec.Format, gc.Equals, "qcow2")
+	s.FakeConn.CheckCall(c, 7, "CreateVolume", "default", volumeSpec{
+		Name:          hostname + "-root",
+		Size:          8192,
+		Format:        "qcow2",
+		BackingVolume: image.Path,
+	})
+
+	// The cloud-init data source holds the user data.
+	c.Assert(s.ISOs, gc.HasLen, 1)
+	for _, iso := range s.ISOs {
+		c.Check(iso[0], jc.Contains, "#cloud-config")
+		c.Check(iso[0], jc.Contains, "genisoimage")
+		c.Check(iso[1], gc.Equals, "instance-id: "+hostname+"\nlocal-hostname: "+hostname+"\n")
+	}
+
+	// The domain boots from the root disk, with the data source
+	// attached, and is tagged.
+	d := s.FakeConn.domains[hostname]
+	c.Assert(d, gc.NotNil)
+	c.Check(d.Tags, jc.DeepEquals, s.StartInstArgs.InstanceConfig.Tags)
+	c.Check(d.definition, jc.Contains, `<name>`+hostname+`</name>`)
+	c.Check(d.definition, jc.Contains, `<vcpu>1</vcpu>`)
+	c.Check(d.definition, jc.Contains, `<memory unit="MiB">2048</memory>`)
+	c.Check(d.definition, jc.Contains, `<source file="`+root.Path+`"></source><target dev="vda"></target>`)
+	c.Check(d.definition, jc.Contains, `<source file="`+dataSource.Path+`"></source><target dev="vdb"></target>`)
+	c.Check(d.definition, jc.Contains, `<source bridge="virbr0"></source>`)
+}
+
+func (s *environBrokerSuite) TestStartInstanceConstraints(c *gc.C) {
+	cons := constraints.MustParse("cores=4 mem=8G root-disk=20G root-disk-source=fast")
+	s.StartInstArgs.Constraints = cons
+	s.FakeConn.pools["fast"] = map[string]volume{}
+
+	result, err := s.Env.StartInstance(s.CallCtx, s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*result.Hardware.CpuCores, gc.Equals, uint64(4))
+	c.Check(*result.Hardware.Mem, gc.Equals, uint64(8192))
+	c.Check(*result.Hardware.RootDisk, gc.Equals, uint64(20480))
+
+	c.Check(s.FakeConn.pools["default"], gc.HasLen, 0)
+	c.Check(s.FakeConn.pools["fast"], gc.HasLen, 3)
+	d := s.FakeConn.domains[string(result.Instance.Id())]
+	c.Check(d.definition, jc.Contains, `<vcpu>4</vcpu>`)
+	c.Check(d.definition, jc.Contains, `<memory unit="MiB">8192</memory>`)
+}
+
+func (s *environBrokerSuite) TestStartInstanceBridge(c *gc.C) {
+	cfg, err := s.Config.Apply(map[string]interface{}{"bridge": "br0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.Env.StartInstance(s.CallCtx, s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	d := s.FakeConn.domains[string(result.Instance.Id())]
+	c.Check(d.definition, jc.Contains, `<source bridge="br0"></source>`)
+}
+
+func (s *environBrokerSuite) TestStartInstanceReusesImage(c *gc.C) {
+	s.FakeConn.pools["default"]["juju-image-focal-amd64-20201014"] = volume{
+		Name: "juju-image-focal-amd64-20201014",
+		Path: "/var/lib/libvirt/images/focal",
+	}
+
+	_, err := s.Env.StartInstance(s.CallCtx, s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, call := range s.FakeConn.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "downloadImage")
+	}
+	c.Check(s.FakeConn.pools["default"], gc.HasLen, 3)
+}
+
+func (s *environBrokerSuite) TestStartInstanceCleansUp(c *gc.C) {
+	s.FakeConn.SetErrors(
+		nil,                          // HostArch
+		nil,                          // CreateVolume (data source)
+		nil,                          // UploadVolume
+		nil,                          // Volume (image)
+		nil,                          // downloadImage
+		nil,                          // CreateVolume (image)
+		nil,                          // UploadVolume
+		nil,                          // CreateVolume (root disk)
+		errors.New("no more memory"), // CreateDomain
+	)
+	var statuses []status.Status
+	s.StartInstArgs.StatusCallback = func(st status.Status, info string, data map[string]interface{}) error {
+		statuses = append(statuses, st)
+		return nil
+	}
+
+	_, err := s.Env.StartInstance(s.CallCtx, s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, "creating domain: no more memory")
+
+	// Only the shared image is left behind.
+	c.Check(s.FakeConn.pools["default"], gc.HasLen, 1)
+	c.Check(s.FakeConn.domains, gc.HasLen, 0)
+	c.Check(statuses[len(statuses)-1], gc.Equals, status.ProvisioningError)
+}
+
+func (s *environBrokerSuite) TestAllInstances(c *gc.C) {
+	running := s.AddDomain(c, "0", domainRunning, true)
+	stopped := s.AddDomain(c, "1", domainShutOff, false)
+	// Domains of other models are ignored.
+	other := s.Env.namespace.Prefix() + "2"
+	s.FakeConn.domains[other] = &fakeDomain{domain: domain{
+		Name: other, State: domainRunning,
+		Tags: map[string]string{tags.JujuModel: "other"},
+	}}
+
+	all, err := s.Env.AllInstances(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Check(all[0].Id(), gc.Equals, instance.Id(running))
+	c.Check(all[0].Status(s.CallCtx).Status, gc.Equals, status.Running)
+	c.Check(all[1].Id(), gc.Equals, instance.Id(stopped))
+	c.Check(all[1].Status(s.CallCtx), jc.DeepEquals, instance.Status{
+		Status:  status.Empty,
+		Message: domainShutOff,
+	})
+
+	runningInsts, err := s.Env.AllRunningInstances(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runningInsts, gc.HasLen, 1)
+	c.Check(runningInsts[0].Id(), gc.Equals, instance.Id(running))
+}
+
+func (s *environBrokerSuite) TestInstances(c *gc.C) {
+	name := s.AddDomain(c, "0", domainRunning, false)
+
+	insts, err := s.Env.Instances(s.CallCtx, []instance.Id{instance.Id(name), "missing"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(insts, gc.HasLen, 2)
+	c.Check(insts[0].Id(), gc.Equals, instance.Id(name))
+	c.Check(insts[1], gc.IsNil)
+
+	_, err = s.Env.Instances(s.CallCtx, []instance.Id{"missing"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environBrokerSuite) TestInstanceAddresses(c *gc.C) {
+	name := s.AddDomain(c, "0", domainRunning, false)
+	s.FakeConn.addresses[name] = []string{"192.168.122.45"}
+
+	insts, err := s.Env.Instances(s.CallCtx, []instance.Id{instance.Id(name)})
+	c.Assert(err, jc.ErrorIsNil)
+	addrs, err := insts[0].Addresses(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addrs, jc.DeepEquals, network.NewProviderAddresses("192.168.122.45"))
+}
+
+func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
+	name := s.AddDomain(c, "0", domainRunning, false)
+
+	err := s.Env.StopInstances(s.CallCtx, instance.Id(name))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.FakeConn.domains, gc.HasLen, 0)
+	s.FakeConn.CheckCall(c, 0, "RemoveDomain", name, []string{"vda", "vdb"})
+}
+
+func (s *environBrokerSuite) TestStopInstancesError(c *gc.C) {
+	name := s.AddDomain(c, "0", domainRunning, false)
+	s.FakeConn.SetErrors(errors.New("boom"))
+
+	err := s.Env.StopInstances(s.CallCtx, instance.Id(name))
+	c.Assert(err, gc.ErrorMatches, `failed to remove some instances: \[`+name+`\]`)
+}
+
+func (s *environBrokerSuite) TestControllerInstances(c *gc.C) {
+	controller := s.AddDomain(c, "0", domainRunning, true)
+	s.AddDomain(c, "1", domainRunning, false)
+
+	ids, err := s.Env.ControllerInstances(s.CallCtx, s.ControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, jc.DeepEquals, []instance.Id{instance.Id(controller)})
+
+	_, err = s.Env.ControllerInstances(s.CallCtx, "other")
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
+
+func (s *environBrokerSuite) TestTagInstance(c *gc.C) {
+	name := s.AddDomain(c, "0", domainRunning, false)
+
+	err := s.Env.TagInstance(s.CallCtx, instance.Id(name), map[string]string{"juju-units-deployed": "mysql/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.FakeConn.domains[name].Tags, jc.DeepEquals, map[string]string{
+		tags.JujuController:   s.ControllerUUID,
+		tags.JujuModel:        s.Config.UUID(),
+		"juju-units-deployed": "mysql/0",
+	})
+}
+
+func (s *environBrokerSuite) TestTagInstanceNotFound(c *gc.C) {
+	err := s.Env.TagInstance(s.CallCtx, "missing", map[string]string{"a": "b"})
+	c.Assert(err, gc.ErrorMatches, `instance "missing" not found`)
+}
+
+func (s *environBrokerSuite) TestAdoptResources(c *gc.C) {
+	name := s.AddDomain(c, "0", domainRunning, false)
+
+	err := s.Env.AdoptResources(s.CallCtx, "new-controller", version.Zero)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.FakeConn.domains[name].Tags[tags.JujuController], gc.Equals, "new-controller")
+}
+
+func (s *environBrokerSuite) TestDestroyController(c *gc.C) {
+	s.PatchValue(&destroyEnv, func(environs.Environ, context.ProviderCallContext) error {
+		return nil
+	})
+	hosted := "juju-abcdef-0"
+	s.FakeConn.domains[hosted] = &fakeDomain{domain: domain{
+		Name: hosted, State: domainRunning,
+		Tags: map[string]string{tags.JujuController: s.ControllerUUID, tags.JujuModel: "hosted"},
+	}}
+	otherController := "juju-fedcba-0"
+	s.FakeConn.domains[otherController] = &fakeDomain{domain: domain{
+		Name: otherController, State: domainRunning,
+		Tags: map[string]string{tags.JujuController: "other", tags.JujuModel: "other"},
+	}}
+
+	err := s.Env.DestroyController(s.CallCtx, s.ControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.FakeConn.domains, gc.HasLen, 1)
+	c.Check(s.FakeConn.domains[otherController], gc.NotNil)
+}
+
+func (s *environBrokerSuite) TestConstraintsValidator(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("arch=amd64 cores=2 mem=4G tags=foo instance-type=large")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unsupported, jc.SameContents, []string{"tags", "instance-type"})
+
+	_, err = validator.Validate(constraints.MustParse("arch=arm64"))
+	c.Assert(err, gc.ErrorMatches, `invalid constraint value: arch=arm64\nvalid values are: \[amd64\]`)
+}
+
+func (s *environBrokerSuite) TestPrecheckInstancePlacement(c *gc.C) {
+	err := s.Env.PrecheckInstance(s.CallCtx, environs.PrecheckInstanceParams{Placement: "zone=a"})
+	c.Assert(err, gc.ErrorMatches, "unknown placement directive: zone=a")
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
+)
+
+// Instances returns the available instances in the environment that
+// match the provided instance IDs. For IDs that did not match any
+// instances, the result at the corresponding index will be nil. In that
+// case the error will be environs.ErrPartialInstances (or
+// ErrNoInstances if none of the IDs match an instance).
+func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
+	if len(ids) == 0 {
+		return nil, environs.ErrNoInstances
+	}
+
+	all, err := env.allInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byId := make(map[instance.Id]*environInstance)
+	for _, inst := range all {
+		byId[inst.Id()] = inst
+	}
+
+	numFound := 0
+	results := make([]instances.Instance, len(ids))
+	for i, id := range ids {
+		if inst, ok := byId[id]; ok {
+			results[i] = inst
+			numFound++
+		}
+	}
+	if numFound == 0 {
+		return nil, environs.ErrNoInstances
+	} else if numFound != len(ids) {
+		return results, environs.ErrPartialInstances
+	}
+	return results, nil
+}
+
+// instance returns the instance of the model with the given ID.
+func (env *environ) instance(id instance.Id) (*environInstance, error) {
+	all, err := env.allInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, inst := range all {
+		if inst.Id() == id {
+			return inst, nil
+		}
+	}
+	return nil, errors.NotFoundf("instance %q", id)
+}
+
+// allInstances returns all of the model's instances, whatever their
+// state. The instances are the domains whose names have the model's
+// prefix and which are tagged with the model's UUID.
+func (env *environ) allInstances() ([]*environInstance, error) {
+	domains, err := env.conn.Domains(env.namespace.Prefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []*environInstance
+	for _, d := range domains {
+		if d.Tags[tags.JujuModel] != env.uuid {
+			continue
+		}
+		results = append(results, newInstance(d, env))
+	}
+	return results, nil
+}
+
+// ControllerInstances returns the IDs of the instances corresponding
+// to juju controllers.
+func (env *environ) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) ([]instance.Id, error) {
+	domains, err := env.conn.Domains("juju-")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var results []instance.Id
+	for _, d := range domains {
+		if d.Tags[tags.JujuController] != controllerUUID {
+			continue
+		}
+		if d.Tags[tags.JujuIsController] == "true" {
+			results = append(results, instance.Id(d.Name))
+		}
+	}
+	if len(results) == 0 {
+		return nil, environs.ErrNotBootstrapped
+	}
+	return results, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2/arch"
+
+	"github.com/juju/juju/environs/imagedownloads"
+	"github.com/juju/juju/environs/simplestreams"
+)
+
+const (
+	// biosFileType is the simplestreams file type of the images of
+	// instances that boot with a legacy BIOS.
+	biosFileType = "disk1.img"
+
+	// uefiFileType is the simplestreams file type of the images of
+	// instances that boot with UEFI, i.e. those on arm64.
+	uefiFileType = "uefi1.img"
+)
+
+// fetchImageMetadata returns the simplestreams metadata of the most
+// recent cloud image matching the given arguments. It is a variable so
+// that it can be replaced in tests.
+var fetchImageMetadata = imagedownloads.One
+
+// downloadImage downloads the image described by md into the file at
+// path, checking its checksum. It is a variable so that it can be
+// replaced in tests.
+var downloadImage = func(md *imagedownloads.Metadata, baseURL, path string) error {
+	dlURL, err := md.DownloadURL(baseURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := http.Get(dlURL.String())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.NotFoundf("got %d fetching image %q", resp.StatusCode, dlURL)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, hash), resp.Body); err != nil {
+		return errors.Annotatef(err, "downloading %q", dlURL)
+	}
+	if sum := fmt.Sprintf("%x", hash.Sum(nil)); sum != md.SHA256 {
+		return errors.Errorf("hash sum mismatch for %s: %s != %s", dlURL, sum, md.SHA256)
+	}
+	return errors.Trace(f.Close())
+}
+
+// imageVolumeName returns the name of the volume holding the cloud image
+// described by md. Image volumes are shared by all models using a pool.
+func imageVolumeName(md *imagedownloads.Metadata) string {
+	return fmt.Sprintf("juju-image-%s-%s-%s", md.Release, md.Arch, md.Version)
+}
+
+// ensureImage returns the volume in the given pool holding the most
+// recent cloud image for the series and architecture, uploading it
+// from the image-metadata-url, or the Ubuntu cloud images site, if
+// the pool does not already hold it.
+func (env *environ) ensureImage(pool, series, imageArch string, statusCallback func(string)) (volume, error) {
+	// Serialise the image uploads, so that instances started at the same
+	// time do not upload the same image.
+	env.imageMutex.Lock()
+	defer env.imageMutex.Unlock()
+
+	cfg := env.Config()
+	baseURL, _ := cfg.ImageMetadataURL()
+	var srcFunc func() simplestreams.DataSource
+	if baseURL != "" {
+		srcFunc = func() simplestreams.DataSource {
+			return imagedownloads.NewDataSource(baseURL)
+		}
+	}
+	fileType := biosFileType
+	if imageArch == arch.ARM64 {
+		fileType = uefiFileType
+	}
+	md, err := fetchImageMetadata(imageArch, series, cfg.ImageStream(), fileType, srcFunc)
+	if err != nil {
+		return volume{}, errors.Annotatef(err, "finding %s %s image", series, imageArch)
+	}
+
+	name := imageVolumeName(md)
+	vol, err := env.conn.Volume(pool, name)
+	if err == nil {
+		return vol, nil
+	} else if !errors.IsNotFound(err) {
+		return volume{}, errors.Trace(err)
+	}
+
+	statusCallback(fmt.Sprintf("downloading %s %s image", series, imageArch))
+	f, err := ioutil.TempFile("", name+"-")
+	if err != nil {
+		return volume{}, errors.Trace(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+	if err := downloadImage(md, baseURL, f.Name()); err != nil {
+		return volume{}, errors.Trace(err)
+	}
+	info, err := os.Stat(f.Name())
+	if err != nil {
+		return volume{}, errors.Trace(err)
+	}
+
+	statusCallback(fmt.Sprintf("uploading %s %s image", series, imageArch))
+	// Cloud images are published as qcow2, and back the qcow2 root
+	// disks of instances.
+	vol, err = env.conn.CreateVolume(pool, volumeSpec{
+		Name: name,
+		// The volume must be large enough to hold the image file.
+		Size:   (uint64(info.Size()) + (1 << 20) - 1) >> 20,
+		Format: "qcow2",
+	})
+	if err != nil {
+		return volume{}, errors.Trace(err)
+	}
+	if err := env.conn.UploadVolume(pool, name, f.Name()); err != nil {
+		if removeErr := env.conn.RemoveVolume(pool, name); removeErr != nil {
+			logger.Warningf("cannot remove volume %q: %v", name, removeErr)
+		}
+		return volume{}, errors.Annotatef(err, "uploading %s %s image", series, imageArch)
+	}
+	return vol, nil
+}
@@ -0,0 +1,14 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import "github.com/juju/juju/environs"
+
+const (
+	providerType = "libvirt"
+)
+
+func init() {
+	environs.RegisterProvider(providerType, providerInstance)
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+)
+
+type environInstance struct {
+	domain domain
+	env    *environ
+}
+
+var _ instances.Instance = (*environInstance)(nil)
+
+func newInstance(d domain, env *environ) *environInstance {
+	return &environInstance{
+		domain: d,
+		env:    env,
+	}
+}
+
+// Id implements instances.Instance.
+func (i *environInstance) Id() instance.Id {
+	return instance.Id(i.domain.Name)
+}
+
+// Status implements instances.Instance.
+func (i *environInstance) Status(ctx context.ProviderCallContext) instance.Status {
+	var jujuStatus status.Status
+	switch i.domain.State {
+	case domainRunning:
+		jujuStatus = status.Running
+	case domainCrashed:
+		jujuStatus = status.ProvisioningError
+	default:
+		jujuStatus = status.Empty
+	}
+	return instance.Status{
+		Status:  jujuStatus,
+		Message: i.domain.State,
+	}
+}
+
+// Addresses implements instances.Instance.
+func (i *environInstance) Addresses(ctx context.ProviderCallContext) (network.ProviderAddresses, error) {
+	addrs, err := i.env.conn.DomainAddresses(i.domain.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return network.NewProviderAddresses(addrs...), nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+	"github.com/juju/schema"
+	cryptossh "golang.org/x/crypto/ssh"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+)
+
+var logger = loggo.GetLogger("juju.provider.libvirt")
+
+const (
+	providerVersion1 = 1
+
+	currentProviderVersion = providerVersion1
+)
+
+type environProvider struct {
+	environProviderCredentials
+}
+
+var providerInstance environProvider
+
+var _ environs.CloudEnvironProvider = providerInstance
+
+// Version is part of the EnvironProvider interface.
+func (environProvider) Version() int {
+	return currentProviderVersion
+}
+
+// Open implements environs.EnvironProvider.
+func (environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	env, err := newEnviron(args.Cloud, args.Config)
+	return env, errors.Trace(err)
+}
+
+var cloudSchema = &jsonschema.Schema{
+	Type:     []jsonschema.Type{jsonschema.ObjectType},
+	Required: []string{cloud.EndpointKey, cloud.AuthTypesKey},
+	Order:    []string{cloud.EndpointKey, cloud.AuthTypesKey, cloud.RegionsKey},
+	Properties: map[string]*jsonschema.Schema{
+		cloud.EndpointKey: {
+			Singular: "the libvirt connection URI, e.g. qemu+ssh://user@host/system",
+			Type:     []jsonschema.Type{jsonschema.StringType},
+			Format:   jsonschema.FormatURI,
+		},
+		cloud.AuthTypesKey: {
+			Singular:    "auth type",
+			Plural:      "auth types",
+			Type:        []jsonschema.Type{jsonschema.ArrayType},
+			UniqueItems: jsonschema.Bool(true),
+			Items: &jsonschema.ItemSpec{
+				Schemas: []*jsonschema.Schema{{
+					Type: []jsonschema.Type{jsonschema.StringType},
+					Enum: []interface{}{
+						string(cloud.EmptyAuthType),
+						string(cloud.SSHKeyAuthType),
+					},
+				}},
+			},
+		},
+		cloud.RegionsKey: {
+			Type:     []jsonschema.Type{jsonschema.ObjectType},
+			Singular: "hypervisor",
+			Plural:   "hypervisors",
+			AdditionalProperties: &jsonschema.Schema{
+				Type:          []jsonschema.Type{jsonschema.ObjectType},
+				Required:      []string{cloud.EndpointKey},
+				MaxProperties: jsonschema.Int(1),
+				Properties: map[string]*jsonschema.Schema{
+					cloud.EndpointKey: {
+						Singular:      "the libvirt connection URI of the hypervisor",
+						Type:          []jsonschema.Type{jsonschema.StringType},
+						Format:        jsonschema.FormatURI,
+						Default:       "",
+						PromptDefault: "use cloud api url",
+					},
+				},
+			},
+		},
+	},
+}
+
+// CloudSchema returns the schema used to validate input for add-cloud.
+func (environProvider) CloudSchema() *jsonschema.Schema {
+	return cloudSchema
+}
+
+// Ping tests the connection to the cloud, to verify the endpoint is valid.
+func (environProvider) Ping(ctx context.ProviderCallContext, endpoint string) error {
+	if err := validateURI(endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := newConnection(endpoint).HostArch(); err != nil {
+		return errors.Annotatef(err, "no libvirt daemon available at %s", endpoint)
+	}
+	return nil
+}
+
+// PrepareConfig implements environs.EnvironProvider.
+func (environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return configWithDefaults(args.Config)
+}
+
+// Validate implements environs.EnvironProvider.Validate.
+func (environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	newCfg, err := newConfig(cfg, old)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	return newCfg.config, nil
+}
+
+// Schema returns the configuration schema for an environment.
+func (environProvider) Schema() environschema.Fields {
+	fields, err := config.Schema(configSchema)
+	if err != nil {
+		panic(err)
+	}
+	return fields
+}
+
+// ConfigSchema returns extra config attributes specific
+// to this provider only.
+func (environProvider) ConfigSchema() schema.Fields {
+	return configFields
+}
+
+// ConfigDefaults returns the default values for the
+// provider specific config attributes.
+func (environProvider) ConfigDefaults() schema.Defaults {
+	return configDefaults
+}
+
+func configWithDefaults(cfg *config.Config) (*config.Config, error) {
+	defaults := make(map[string]interface{})
+	if _, ok := cfg.StorageDefaultBlockSource(); !ok {
+		// Set the default block source.
+		defaults[config.StorageDefaultBlockSourceKey] = string(storageProviderType)
+	}
+	if len(defaults) == 0 {
+		return cfg, nil
+	}
+	return cfg.Apply(defaults)
+}
+
+func validateCloudSpec(spec environscloudspec.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateURI(spec.Endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	switch authType := spec.Credential.AuthType(); authType {
+	case cloud.EmptyAuthType:
+	case cloud.SSHKeyAuthType:
+		u, _ := url.Parse(spec.Endpoint)
+		if !sshTransports.Contains(strings.TrimPrefix(u.Scheme, "qemu+")) {
+			return errors.NotValidf("endpoint %q: %q auth-type requires an ssh transport", spec.Endpoint, authType)
+		}
+		attrs := spec.Credential.Attributes()
+		if attrs[credAttrPrivateKey] == "" {
+			return errors.NotValidf("missing %s", credAttrPrivateKey)
+		}
+		if _, _, _, _, err := cryptossh.ParseAuthorizedKey([]byte(attrs[credAttrKnownHostKey])); err != nil {
+			return errors.NotValidf("%s", credAttrKnownHostKey)
+		}
+	default:
+		return errors.NotSupportedf("%q auth-type", authType)
+	}
+	return nil
+}
+
+// validateURI checks that uri is a libvirt connection URI for the
+// QEMU driver, e.g. qemu+ssh://user@host/system.
+func validateURI(uri string) error {
+	if uri == "" {
+		return errors.NotValidf("missing endpoint")
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return errors.NotValidf("endpoint %q", uri)
+	}
+	if u.Scheme != "qemu" && !strings.HasPrefix(u.Scheme, "qemu+") {
+		return errors.NotValidf("endpoint %q: expected a qemu connection URI", uri)
+	}
+	return nil
+}
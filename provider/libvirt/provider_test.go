@@ -0,0 +1,213 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	sshtesting "github.com/juju/utils/v2/ssh/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/testing"
+)
+
+type providerSuite struct {
+	libvirt.BaseSuite
+
+	provider environs.EnvironProvider
+	spec     environscloudspec.CloudSpec
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	var err error
+	s.provider, err = environs.Provider("libvirt")
+	c.Check(err, jc.ErrorIsNil)
+
+	s.spec = libvirt.MakeTestCloudSpec()
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	env, err := environs.Open(s.provider, environs.OpenParams{
+		Cloud:  s.spec,
+		Config: s.Config,
+	})
+	c.Check(err, jc.ErrorIsNil)
+
+	envConfig := env.Config()
+	c.Assert(envConfig.Name(), gc.Equals, "testmodel")
+}
+
+func (s *providerSuite) TestOpenInvalidEndpoint(c *gc.C) {
+	s.spec.Endpoint = "lxc:///"
+	s.testOpenError(c, s.spec, `validating cloud spec: endpoint "lxc:///": expected a qemu connection URI not valid`)
+}
+
+func (s *providerSuite) TestOpenMissingEndpoint(c *gc.C) {
+	s.spec.Endpoint = ""
+	s.testOpenError(c, s.spec, `validating cloud spec: missing endpoint not valid`)
+}
+
+func (s *providerSuite) TestOpenMissingCredential(c *gc.C) {
+	s.spec.Credential = nil
+	s.testOpenError(c, s.spec, `validating cloud spec: missing credential not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedCredential(c *gc.C) {
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{})
+	s.spec.Credential = &credential
+	s.testOpenError(c, s.spec, `validating cloud spec: "userpass" auth-type not supported`)
+}
+
+func (s *providerSuite) sshKeyCredential(knownHostKey string) *cloud.Credential {
+	credential := cloud.NewCredential(cloud.SSHKeyAuthType, map[string]string{
+		"private-key":    "private",
+		"known-host-key": knownHostKey,
+	})
+	return &credential
+}
+
+func (s *providerSuite) TestOpenSSHKeyCredential(c *gc.C) {
+	s.spec.Endpoint = "qemu+ssh://ubuntu@10.0.0.1:2222/system"
+	s.spec.Credential = s.sshKeyCredential(sshtesting.ValidKeyOne.Key)
+	_, err := environs.Open(s.provider, environs.OpenParams{
+		Cloud:  s.spec,
+		Config: s.Config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	keyFile := filepath.Join(s.CredentialDir, "id")
+	knownHosts := filepath.Join(s.CredentialDir, "known_hosts")
+	c.Assert(s.ConnURI, gc.Equals, "qemu+ssh://ubuntu@10.0.0.1:2222/system?"+url.Values{
+		"keyfile":     {keyFile},
+		"known_hosts": {knownHosts},
+		"no_tty":      {"1"},
+	}.Encode())
+	data, err := ioutil.ReadFile(keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "private")
+	data, err = ioutil.ReadFile(knownHosts)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "[10.0.0.1]:2222 "+sshtesting.ValidKeyOne.Key+"\n")
+}
+
+func (s *providerSuite) TestOpenEmptyCredentialUsesEndpoint(c *gc.C) {
+	_, err := environs.Open(s.provider, environs.OpenParams{
+		Cloud:  s.spec,
+		Config: s.Config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.ConnURI, gc.Equals, "qemu+ssh://ubuntu@10.0.0.1/system")
+}
+
+func (s *providerSuite) TestOpenSSHKeyCredentialInvalidHostKey(c *gc.C) {
+	s.spec.Credential = s.sshKeyCredential("not a key")
+	s.testOpenError(c, s.spec, `validating cloud spec: known-host-key not valid`)
+}
+
+func (s *providerSuite) TestOpenSSHKeyCredentialNotSSHTransport(c *gc.C) {
+	s.spec.Endpoint = "qemu+tcp://10.0.0.1/system"
+	s.spec.Credential = s.sshKeyCredential(sshtesting.ValidKeyOne.Key)
+	s.testOpenError(c, s.spec, `validating cloud spec: endpoint "qemu\+tcp://10.0.0.1/system": "ssh-key" auth-type requires an ssh transport not valid`)
+}
+
+func (s *providerSuite) testOpenError(c *gc.C, spec environscloudspec.CloudSpec, expect string) {
+	_, err := environs.Open(s.provider, environs.OpenParams{
+		Cloud:  spec,
+		Config: s.Config,
+	})
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *providerSuite) TestPrepareConfig(c *gc.C) {
+	cfg, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
+		Config: s.Config,
+		Cloud:  s.spec,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	source, ok := cfg.StorageDefaultBlockSource()
+	c.Check(ok, jc.IsTrue)
+	c.Check(source, gc.Equals, "libvirt")
+}
+
+func (s *providerSuite) TestPing(c *gc.C) {
+	err := s.provider.Ping(s.CallCtx, "qemu+ssh://ubuntu@10.0.0.1/system")
+	c.Assert(err, jc.ErrorIsNil)
+	s.FakeConn.CheckCallNames(c, "HostArch")
+}
+
+func (s *providerSuite) TestPingUnreachable(c *gc.C) {
+	s.FakeConn.SetErrors(errors.New("connection refused"))
+	err := s.provider.Ping(s.CallCtx, "qemu+ssh://ubuntu@10.0.0.1/system")
+	c.Assert(err, gc.ErrorMatches, "no libvirt daemon available at qemu\\+ssh://ubuntu@10.0.0.1/system: connection refused")
+}
+
+func (s *providerSuite) TestPingInvalidEndpoint(c *gc.C) {
+	err := s.provider.Ping(s.CallCtx, "https://10.0.0.1")
+	c.Assert(err, gc.ErrorMatches, `endpoint "https://10.0.0.1": expected a qemu connection URI not valid`)
+	s.FakeConn.CheckNoCalls(c)
+}
+
+func (s *providerSuite) TestValidateDefaults(c *gc.C) {
+	cfg, err := s.provider.Validate(s.Config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	attrs := cfg.UnknownAttrs()
+	c.Check(attrs["libvirt-pool"], gc.Equals, "default")
+	c.Check(attrs["bridge"], gc.Equals, "virbr0")
+}
+
+func (s *providerSuite) TestValidateEmptyBridge(c *gc.C) {
+	cfg := s.NewConfig(c, testing.Attrs{"bridge": ""})
+	_, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, "invalid config: bridge: must not be empty")
+}
+
+func (s *providerSuite) TestValidateChangeBridge(c *gc.C) {
+	cfg := s.NewConfig(c, testing.Attrs{"bridge": "br0"})
+	_, err := s.provider.Validate(cfg, s.Config)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestValidateChangePool(c *gc.C) {
+	cfg := s.NewConfig(c, testing.Attrs{"libvirt-pool": "juju"})
+	_, err := s.provider.Validate(cfg, s.Config)
+	c.Assert(err, gc.ErrorMatches, "invalid config: libvirt-pool: cannot change from default to juju")
+}
+
+func (s *providerSuite) TestCredentialSchemas(c *gc.C) {
+	c.Assert(s.provider.CredentialSchemas(), jc.DeepEquals, map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.EmptyAuthType: {},
+		cloud.SSHKeyAuthType: {{
+			"private-key", cloud.CredentialAttr{
+				Description: "The SSH private key used to connect to the hypervisor",
+				Hidden:      true,
+				FileAttr:    "private-key-path",
+			},
+		}, {
+			"known-host-key", cloud.CredentialAttr{
+				Description: "The SSH host key of the hypervisor, e.g. ssh-ed25519 AAAA...",
+			},
+		}},
+	})
+}
+
+func (s *providerSuite) TestConfigSchema(c *gc.C) {
+	fields := s.provider.(config.ConfigSchemaSource).ConfigSchema()
+	_, ok := fields["libvirt-pool"]
+	c.Check(ok, jc.IsTrue)
+	_, ok = fields["bridge"]
+	c.Check(ok, jc.IsTrue)
+}
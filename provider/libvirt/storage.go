@@ -0,0 +1,289 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	storageProviderType = storage.ProviderType("libvirt")
+
+	// attrLibvirtPool is the attribute name for the libvirt storage
+	// pool in which a Juju storage pool's volumes are created. If it
+	// is not provided, the model's libvirt-pool is used.
+	attrLibvirtPool = "libvirt-pool"
+)
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (env *environ) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return []storage.ProviderType{storageProviderType}, nil
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == storageProviderType {
+		return &storageProvider{env}, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+// storageProvider is a storage provider for libvirt storage pool
+// volumes, exposed to Juju as block devices.
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+var storageConfigChecker = schema.FieldMap(
+	schema.Fields{
+		attrLibvirtPool: schema.String(),
+	},
+	schema.Defaults{
+		attrLibvirtPool: schema.Omit,
+	},
+)
+
+// ValidateConfig is part of the Provider interface.
+func (p *storageProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := p.libvirtPool(cfg)
+	return errors.Trace(err)
+}
+
+// libvirtPool returns the libvirt storage pool of the given Juju
+// storage pool's volumes.
+func (p *storageProvider) libvirtPool(cfg *storage.Config) (string, error) {
+	coerced, err := storageConfigChecker.Coerce(cfg.Attrs(), nil)
+	if err != nil {
+		return "", errors.Annotate(err, "validating libvirt storage config")
+	}
+	if pool, _ := coerced.(map[string]interface{})[attrLibvirtPool].(string); pool != "" {
+		return pool, nil
+	}
+	return p.env.envConfig().storagePool(), nil
+}
+
+// Supports is part of the Provider interface.
+func (p *storageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is part of the Provider interface.
+func (p *storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is part of the Provider interface.
+func (p *storageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is part of the Provider interface.
+func (p *storageProvider) Releasable() bool {
+	// Volumes carry no tags to record which model they belong to,
+	// so they cannot be released and imported into another model.
+	return false
+}
+
+// DefaultPools is part of the Provider interface.
+func (p *storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// FilesystemSource is part of the Provider interface.
+func (p *storageProvider) FilesystemSource(cfg *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// VolumeSource is part of the Provider interface.
+func (p *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	pool, err := p.libvirtPool(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &volumeSource{env: p.env, pool: pool}, nil
+}
+
+// volumeSource creates the volumes of a Juju storage pool in a libvirt
+// storage pool. The volumes are named after their tags, in the model's
+// namespace, e.g. juju-xxxxxx-volume-0.
+type volumeSource struct {
+	env  *environ
+	pool string
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// makeVolumeId returns the ID of the named volume in the given pool.
+func makeVolumeId(pool, name string) string {
+	return pool + ":" + name
+}
+
+// parseVolumeId returns the pool and name of the volume with the given ID.
+func parseVolumeId(id string) (pool, name string, _ error) {
+	fields := strings.SplitN(id, ":", 2)
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		return "", "", errors.NotValidf("volume ID %q", id)
+	}
+	return fields[0], fields[1], nil
+}
+
+// CreateVolumes is part of the VolumeSource interface.
+func (s *volumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(params))
+	for i, p := range params {
+		name := s.env.namespace.Value(p.Tag.String())
+		vol, err := s.env.conn.CreateVolume(s.pool, volumeSpec{
+			Name:   name,
+			Size:   p.Size,
+			Format: "raw",
+		})
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating volume %q", name)
+			continue
+		}
+		results[i].Volume = &storage.Volume{
+			Tag: p.Tag,
+			VolumeInfo: storage.VolumeInfo{
+				VolumeId:   makeVolumeId(s.pool, vol.Name),
+				Size:       vol.Size,
+				Persistent: true,
+			},
+		}
+	}
+	return results, nil
+}
+
+// ListVolumes is part of the VolumeSource interface.
+func (s *volumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	volumes, err := s.env.conn.Volumes(s.pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	prefix := s.env.namespace.Value("volume-")
+	var ids []string
+	for _, vol := range volumes {
+		if strings.HasPrefix(vol.Name, prefix) {
+			ids = append(ids, makeVolumeId(s.pool, vol.Name))
+		}
+	}
+	return ids, nil
+}
+
+// DescribeVolumes is part of the VolumeSource interface.
+func (s *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volIds))
+	for i, id := range volIds {
+		vol, err := s.volume(id)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId:   id,
+			Size:       vol.Size,
+			Persistent: true,
+		}
+	}
+	return results, nil
+}
+
+func (s *volumeSource) volume(id string) (volume, error) {
+	pool, name, err := parseVolumeId(id)
+	if err != nil {
+		return volume{}, errors.Trace(err)
+	}
+	vol, err := s.env.conn.Volume(pool, name)
+	return vol, errors.Trace(err)
+}
+
+// DestroyVolumes is part of the VolumeSource interface.
+func (s *volumeSource) DestroyVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	results := make([]error, len(volIds))
+	for i, id := range volIds {
+		pool, name, err := parseVolumeId(id)
+		if err != nil {
+			results[i] = errors.Trace(err)
+			continue
+		}
+		results[i] = errors.Annotatef(s.env.conn.RemoveVolume(pool, name), "destroying volume %q", name)
+	}
+	return results, nil
+}
+
+// ReleaseVolumes is part of the VolumeSource interface.
+func (s *volumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	return nil, errors.NotSupportedf("releasing volumes")
+}
+
+// ValidateVolumeParams is part of the VolumeSource interface.
+func (s *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return nil
+}
+
+// AttachVolumes is part of the VolumeSource interface.
+func (s *volumeSource) AttachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(params))
+	for i, p := range params {
+		vol, err := s.volume(p.VolumeId)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		serial := volumeSerial(p.VolumeId)
+		if err := s.env.conn.AttachDisk(string(p.InstanceId), vol.Path, serial); err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %q to %q", p.VolumeId, p.InstanceId)
+			continue
+		}
+		results[i].VolumeAttachment = &storage.VolumeAttachment{
+			Volume:  p.Volume,
+			Machine: p.Machine,
+			VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+				// Virtio disks are linked by their serial.
+				DeviceLink: "/dev/disk/by-id/virtio-" + serial,
+				ReadOnly:   p.ReadOnly,
+			},
+		}
+	}
+	return results, nil
+}
+
+// volumeSerial returns the serial by which the volume with the given ID
+// is identified to the instances it is attached to. Virtio limits
+// serials to 20 characters, so the pool name is left out.
+func volumeSerial(id string) string {
+	_, name, _ := parseVolumeId(id)
+	serial := name
+	if i := strings.LastIndex(name, "-volume-"); i >= 0 {
+		serial = "juju" + name[i:]
+	}
+	if len(serial) > 20 {
+		serial = serial[len(serial)-20:]
+	}
+	return serial
+}
+
+// DetachVolumes is part of the VolumeSource interface.
+func (s *volumeSource) DetachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(params))
+	for i, p := range params {
+		vol, err := s.volume(p.VolumeId)
+		if err != nil {
+			results[i] = errors.Trace(err)
+			continue
+		}
+		results[i] = errors.Annotatef(
+			s.env.conn.DetachDisk(string(p.InstanceId), vol.Path),
+			"detaching volume %q from %q", p.VolumeId, p.InstanceId,
+		)
+	}
+	return results, nil
+}
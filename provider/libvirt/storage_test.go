@@ -0,0 +1,176 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	BaseSuite
+
+	provider storage.Provider
+	source   storage.VolumeSource
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	var err error
+	s.provider, err = s.Env.StorageProvider(storageProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := storage.NewConfig("libvirt", storageProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.source, err = s.provider.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestProvider(c *gc.C) {
+	c.Check(s.provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Check(s.provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+	c.Check(s.provider.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Check(s.provider.Dynamic(), jc.IsTrue)
+	c.Check(s.provider.Releasable(), jc.IsFalse)
+}
+
+func (s *storageSuite) TestVolumeSourcePool(c *gc.C) {
+	cfg, err := storage.NewConfig("fast", storageProviderType, map[string]interface{}{
+		"libvirt-pool": "ssd",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := s.provider.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(source.(*volumeSource).pool, gc.Equals, "ssd")
+}
+
+func (s *storageSuite) TestValidateConfig(c *gc.C) {
+	cfg, err := storage.NewConfig("fast", storageProviderType, map[string]interface{}{
+		"libvirt-pool": 42,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `validating libvirt storage config: libvirt-pool: expected string, got int\(42\)`)
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	results, err := s.source.CreateVolumes(s.CallCtx, []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     1024,
+		Provider: storageProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+
+	name := s.Env.namespace.Value("volume-0")
+	c.Check(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		Tag: names.NewVolumeTag("0"),
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId:   "default:" + name,
+			Size:       1024,
+			Persistent: true,
+		},
+	})
+	s.FakeConn.CheckCall(c, 0, "CreateVolume", "default", volumeSpec{
+		Name:   name,
+		Size:   1024,
+		Format: "raw",
+	})
+}
+
+func (s *storageSuite) TestCreateVolumesError(c *gc.C) {
+	s.FakeConn.SetErrors(errors.New("pool full"))
+	results, err := s.source.CreateVolumes(s.CallCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating volume ".*-volume-0": pool full`)
+}
+
+func (s *storageSuite) TestListVolumes(c *gc.C) {
+	name := s.Env.namespace.Value("volume-0")
+	s.FakeConn.pools["default"] = map[string]volume{
+		name:                            {Name: name},
+		s.Env.namespace.Value("0-root"): {Name: s.Env.namespace.Value("0-root")},
+		"juju-abcdef-volume-0":          {Name: "juju-abcdef-volume-0"},
+	}
+
+	ids, err := s.source.ListVolumes(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, jc.DeepEquals, []string{"default:" + name})
+}
+
+func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
+	name := s.Env.namespace.Value("volume-0")
+	s.FakeConn.pools["default"][name] = volume{Name: name, Size: 2048}
+
+	results, err := s.source.DescribeVolumes(s.CallCtx, []string{"default:" + name, "default:missing", "invalid"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Check(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId:   "default:" + name,
+		Size:       2048,
+		Persistent: true,
+	})
+	c.Check(results[1].Error, jc.Satisfies, errors.IsNotFound)
+	c.Check(results[2].Error, gc.ErrorMatches, `volume ID "invalid" not valid`)
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	name := s.Env.namespace.Value("volume-0")
+	s.FakeConn.pools["default"][name] = volume{Name: name}
+
+	results, err := s.source.DestroyVolumes(s.CallCtx, []string{"default:" + name})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, jc.DeepEquals, []error{nil})
+	c.Check(s.FakeConn.pools["default"], gc.HasLen, 0)
+}
+
+func (s *storageSuite) TestAttachDetachVolumes(c *gc.C) {
+	machine := s.AddDomain(c, "0", domainRunning, false)
+	name := s.Env.namespace.Value("volume-0")
+	path := "/var/lib/libvirt/images/" + name
+	s.FakeConn.pools["default"][name] = volume{Name: name, Path: path}
+	params := []storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: instance.Id(machine),
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "default:" + name,
+	}}
+
+	results, err := s.source.AttachVolumes(s.CallCtx, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Check(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		Volume:  names.NewVolumeTag("0"),
+		Machine: names.NewMachineTag("0"),
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/disk/by-id/virtio-juju-volume-0",
+		},
+	})
+	c.Check(s.FakeConn.domains[machine].disks, jc.DeepEquals, []string{path})
+
+	errs, err := s.source.DetachVolumes(s.CallCtx, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(errs, jc.DeepEquals, []error{nil})
+	c.Check(s.FakeConn.domains[machine].disks, gc.HasLen, 0)
+}
+
+func (s *storageSuite) TestVolumeSerial(c *gc.C) {
+	c.Check(volumeSerial("default:juju-abcdef-volume-0"), gc.Equals, "juju-volume-0")
+	c.Check(volumeSerial("default:juju-abcdef-volume-0-1"), gc.Equals, "juju-volume-0-1")
+	c.Check(volumeSerial("default:juju-abcdef-volume-1234567890"), gc.Equals, "ju-volume-1234567890")
+}
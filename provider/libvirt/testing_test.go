@@ -0,0 +1,353 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/v2/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/imagedownloads"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+// These are fake config values for use in tests.
+var ConfigAttrs = testing.FakeConfig().Merge(testing.Attrs{
+	"type":            "libvirt",
+	"uuid":            "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+	"controller-uuid": "bfef02f1-932a-425a-a102-62175dcabd1d",
+})
+
+func MakeTestCloudSpec() environscloudspec.CloudSpec {
+	cred := cloud.NewEmptyCredential()
+	return environscloudspec.CloudSpec{
+		Type:       "libvirt",
+		Name:       "hypervisor",
+		Endpoint:   "qemu+ssh://ubuntu@10.0.0.1/system",
+		Credential: &cred,
+	}
+}
+
+type BaseSuite struct {
+	gitjujutesting.IsolationSuite
+
+	ControllerUUID string
+	Config         *config.Config
+	Env            *environ
+	FakeConn       *fakeConn
+	ConnURI        string
+	CredentialDir  string
+	CallCtx        context.ProviderCallContext
+	StartInstArgs  environs.StartInstanceParams
+
+	// ISOs records the user data and meta data of the cloud-init
+	// data sources created, keyed by path.
+	ISOs map[string][2]string
+}
+
+func (s *BaseSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.ControllerUUID = testing.FakeControllerConfig().ControllerUUID()
+	s.FakeConn = newFakeConn()
+	s.PatchValue(&newConnection, func(uri string) libvirtConnection {
+		s.ConnURI = uri
+		return s.FakeConn
+	})
+	s.CredentialDir = filepath.Join(c.MkDir(), "credential")
+	s.PatchValue(&credentialDir, func(string) string {
+		return s.CredentialDir
+	})
+	s.ISOs = make(map[string][2]string)
+	s.PatchValue(&makeCloudInitISO, func(path string, userData, metaData []byte) error {
+		s.ISOs[path] = [2]string{string(userData), string(metaData)}
+		return nil
+	})
+	s.PatchValue(&fetchImageMetadata, func(imageArch, series, stream, fileType string, _ func() simplestreams.DataSource) (*imagedownloads.Metadata, error) {
+		return &imagedownloads.Metadata{
+			Arch:    imageArch,
+			Release: series,
+			Version: "20201014",
+			FType:   fileType,
+			Path:    "server/releases/" + series + "/" + fileType,
+		}, nil
+	})
+	s.PatchValue(&downloadImage, func(md *imagedownloads.Metadata, baseURL, path string) error {
+		s.FakeConn.AddCall("downloadImage", md.Path, baseURL)
+		return s.FakeConn.NextErr()
+	})
+
+	env, err := newEnviron(MakeTestCloudSpec(), s.NewConfig(c, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	s.Env = env
+	s.Config = env.Config()
+	s.CallCtx = context.NewCloudCallContext()
+	s.initInst(c)
+}
+
+func (s *BaseSuite) NewConfig(c *gc.C, updates testing.Attrs) *config.Config {
+	var err error
+	cfg := testing.ModelConfig(c)
+	cfg, err = cfg.Apply(ConfigAttrs)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = cfg.Apply(updates)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *BaseSuite) initInst(c *gc.C) {
+	tools := []*coretools.Tools{{
+		Version: version.Binary{Arch: arch.AMD64, Series: "focal"},
+		URL:     "https://example.org",
+	}}
+
+	var cons constraints.Value
+	instanceConfig, err := instancecfg.NewBootstrapInstanceConfig(testing.FakeControllerConfig(), cons, cons, "focal", "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = instanceConfig.SetTools(tools)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.AuthorizedKeys = s.Config.AuthorizedKeys()
+	instanceConfig.Tags = map[string]string{
+		tags.JujuIsController: "true",
+		tags.JujuController:   s.ControllerUUID,
+		tags.JujuModel:        s.Config.UUID(),
+	}
+
+	s.StartInstArgs = environs.StartInstanceParams{
+		ControllerUUID: s.ControllerUUID,
+		InstanceConfig: instanceConfig,
+		Tools:          tools,
+		Constraints:    cons,
+	}
+}
+
+// AddDomain adds a domain of the model to the fake connection.
+func (s *BaseSuite) AddDomain(c *gc.C, machineId, state string, isController bool) string {
+	name, err := s.Env.namespace.Hostname(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	domainTags := map[string]string{
+		tags.JujuController: s.ControllerUUID,
+		tags.JujuModel:      s.Config.UUID(),
+	}
+	if isController {
+		domainTags[tags.JujuIsController] = "true"
+	}
+	s.FakeConn.domains[name] = &fakeDomain{
+		domain: domain{Name: name, State: state, Tags: domainTags},
+	}
+	return name
+}
+
+type fakeDomain struct {
+	domain
+	definition string
+	disks      []string
+}
+
+// fakeConn is an in-memory libvirtConnection, holding the domains of
+// a hypervisor and the volumes of its storage pools.
+type fakeConn struct {
+	gitjujutesting.Stub
+
+	arch      string
+	domains   map[string]*fakeDomain
+	pools     map[string]map[string]volume
+	addresses map[string][]string
+}
+
+var _ libvirtConnection = (*fakeConn)(nil)
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{
+		arch:      arch.AMD64,
+		domains:   make(map[string]*fakeDomain),
+		pools:     map[string]map[string]volume{"default": {}},
+		addresses: make(map[string][]string),
+	}
+}
+
+func (fc *fakeConn) HostArch() (string, error) {
+	fc.MethodCall(fc, "HostArch")
+	return fc.arch, fc.NextErr()
+}
+
+func (fc *fakeConn) Domains(prefix string) ([]domain, error) {
+	fc.MethodCall(fc, "Domains", prefix)
+	if err := fc.NextErr(); err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range fc.domains {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var result []domain
+	for _, name := range names {
+		d := fc.domains[name].domain
+		domainTags := make(map[string]string)
+		for k, v := range d.Tags {
+			domainTags[k] = v
+		}
+		d.Tags = domainTags
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+func (fc *fakeConn) CreateDomain(name, definition string, tags map[string]string) error {
+	fc.MethodCall(fc, "CreateDomain", name, definition, tags)
+	if err := fc.NextErr(); err != nil {
+		return err
+	}
+	fc.domains[name] = &fakeDomain{
+		domain:     domain{Name: name, State: domainRunning, Tags: tags},
+		definition: definition,
+	}
+	return nil
+}
+
+func (fc *fakeConn) SetDomainTags(name string, tags map[string]string) error {
+	fc.MethodCall(fc, "SetDomainTags", name, tags)
+	if err := fc.NextErr(); err != nil {
+		return err
+	}
+	d, ok := fc.domains[name]
+	if !ok {
+		return errors.NotFoundf("domain %q", name)
+	}
+	d.Tags = tags
+	return nil
+}
+
+func (fc *fakeConn) RemoveDomain(name string, diskTargets ...string) error {
+	fc.MethodCall(fc, "RemoveDomain", name, diskTargets)
+	if err := fc.NextErr(); err != nil {
+		return err
+	}
+	delete(fc.domains, name)
+	return nil
+}
+
+func (fc *fakeConn) DomainAddresses(name string) ([]string, error) {
+	fc.MethodCall(fc, "DomainAddresses", name)
+	return fc.addresses[name], fc.NextErr()
+}
+
+func (fc *fakeConn) AttachDisk(domainName, path, serial string) error {
+	fc.MethodCall(fc, "AttachDisk", domainName, path, serial)
+	if err := fc.NextErr(); err != nil {
+		return err
+	}
+	d, ok := fc.domains[domainName]
+	if !ok {
+		return errors.NotFoundf("domain %q", domainName)
+	}
+	d.disks = append(d.disks, path)
+	return nil
+}
+
+func (fc *fakeConn) DetachDisk(domainName, path string) error {
+	fc.MethodCall(fc, "DetachDisk", domainName, path)
+	if err := fc.NextErr(); err != nil {
+		return err
+	}
+	d, ok := fc.domains[domainName]
+	if !ok {
+		return errors.NotFoundf("domain %q", domainName)
+	}
+	for i, disk := range d.disks {
+		if disk == path {
+			d.disks = append(d.disks[:i], d.disks[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (fc *fakeConn) Volumes(pool string) ([]volume, error) {
+	fc.MethodCall(fc, "Volumes", pool)
+	if err := fc.NextErr(); err != nil {
+		return nil, err
+	}
+	volumes, ok := fc.pools[pool]
+	if !ok {
+		return nil, errors.NotFoundf("pool %q", pool)
+	}
+	var names []string
+	for name := range volumes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var result []volume
+	for _, name := range names {
+		result = append(result, volumes[name])
+	}
+	return result, nil
+}
+
+func (fc *fakeConn) Volume(pool, name string) (volume, error) {
+	fc.MethodCall(fc, "Volume", pool, name)
+	if err := fc.NextErr(); err != nil {
+		return volume{}, err
+	}
+	vol, ok := fc.pools[pool][name]
+	if !ok {
+		return volume{}, errors.NotFoundf("volume %q in pool %q", name, pool)
+	}
+	return vol, nil
+}
+
+func (fc *fakeConn) CreateVolume(pool string, spec volumeSpec) (volume, error) {
+	fc.MethodCall(fc, "CreateVolume", pool, spec)
+	if err := fc.NextErr(); err != nil {
+		return volume{}, err
+	}
+	volumes, ok := fc.pools[pool]
+	if !ok {
+		return volume{}, errors.NotFoundf("pool %q", pool)
+	}
+	if _, ok := volumes[spec.Name]; ok {
+		return volume{}, errors.AlreadyExistsf("volume %q", spec.Name)
+	}
+	vol := volume{
+		Name: spec.Name,
+		Path: "/var/lib/libvirt/images/" + spec.Name,
+		Size: spec.Size,
+	}
+	volumes[spec.Name] = vol
+	return vol, nil
+}
+
+func (fc *fakeConn) UploadVolume(pool, name, path string) error {
+	fc.MethodCall(fc, "UploadVolume", pool, name, path)
+	return fc.NextErr()
+}
+
+func (fc *fakeConn) RemoveVolume(pool, name string) error {
+	fc.MethodCall(fc, "RemoveVolume", pool, name)
+	if err := fc.NextErr(); err != nil {
+		return err
+	}
+	delete(fc.pools[pool], name)
+	return nil
+}
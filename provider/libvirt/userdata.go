@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+)
+
+type libvirtRenderer struct{}
+
+// Render implements renderers.ProviderRenderer.
+func (libvirtRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu, jujuos.CentOS, jujuos.OpenSUSE:
+		bytes, err := renderers.RenderYAML(cfg)
+		return bytes, errors.Trace(err)
+	default:
+		return nil, errors.Errorf("cannot encode userdata for OS %q", os)
+	}
+}
+
+// metaData returns the NoCloud meta-data of the named instance. The
+// instance-id is what cloud-init uses to tell whether it is the
+// instance's first boot.
+func metaData(hostname string) []byte {
+	return []byte(fmt.Sprintf("instance-id: %s\nlocal-hostname: %s\n", hostname, hostname))
+}
+
+// makeCloudInitISO writes an ISO image holding the given user data and
+// meta data to path, to be attached to an instance as a cloud-init
+// NoCloud data source. It is a variable so it can be replaced in tests.
+var makeCloudInitISO = func(path string, userData, metaData []byte) error {
+	dir, err := ioutil.TempDir("", "juju-libvirt-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The files must be named exactly "user-data" and "meta-data", and
+	// be given to genisoimage without a path, for NoCloud to find them.
+	// See http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html
+	if err := ioutil.WriteFile(filepath.Join(dir, "user-data"), userData, 0600); err != nil {
+		return errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "meta-data"), metaData, 0600); err != nil {
+		return errors.Trace(err)
+	}
+	cmd := exec.Command("genisoimage",
+		"-output", path,
+		"-volid", "cidata",
+		"-joliet", "-rock",
+		"user-data", "meta-data",
+	)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Annotatef(err, "creating cloud-init ISO: %s", out)
+	}
+	return nil
+}
@@ -0,0 +1,479 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2/arch"
+)
+
+const (
+	// metadataURI is the XML namespace of the metadata recorded in the
+	// domains created by Juju.
+	metadataURI = "https://juju.is/libvirt/1"
+
+	// metadataKey is the namespace prefix of the metadata recorded in
+	// the domains created by Juju.
+	metadataKey = "juju"
+)
+
+// virshConnection is a libvirtConnection that drives the libvirt daemon
+// with the virsh command line client. Because virsh talks to the daemon
+// through the connection URI, the hypervisor may be a remote host.
+type virshConnection struct {
+	uri string
+	run func(args ...string) (string, error)
+}
+
+func newVirshConnection(uri string, run func(args ...string) (string, error)) *virshConnection {
+	return &virshConnection{uri: uri, run: run}
+}
+
+// runVirsh runs virsh with the given arguments, returning its output.
+func runVirsh(args ...string) (string, error) {
+	logger.Tracef("running virsh %s", strings.Join(args, " "))
+	var stderr bytes.Buffer
+	cmd := exec.Command("virsh", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", errors.Trace(err)
+	}
+	return string(out), nil
+}
+
+// virsh runs the given virsh command against the connection's URI.
+func (c *virshConnection) virsh(command string, args ...string) (string, error) {
+	out, err := c.run(append([]string{"--connect", c.uri, command}, args...)...)
+	if err != nil {
+		return "", errors.Annotatef(err, "virsh %s", command)
+	}
+	return out, nil
+}
+
+// HostArch is part of the libvirtConnection interface.
+func (c *virshConnection) HostArch() (string, error) {
+	out, err := c.virsh("capabilities")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var caps struct {
+		Arch string `xml:"host>cpu>arch"`
+	}
+	if err := xml.Unmarshal([]byte(out), &caps); err != nil {
+		return "", errors.Annotate(err, "parsing host capabilities")
+	}
+	if caps.Arch == "" {
+		return "", errors.New("host capabilities do not include the cpu architecture")
+	}
+	return arch.NormaliseArch(caps.Arch), nil
+}
+
+// Domains is part of the libvirtConnection interface.
+func (c *virshConnection) Domains(prefix string) ([]domain, error) {
+	listed, err := c.listDomains()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []domain
+	for _, d := range listed {
+		if !strings.HasPrefix(d.name, prefix) {
+			continue
+		}
+		def, err := c.domainDefinition(d.name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, domain{
+			Name:  d.name,
+			State: d.state,
+			Tags:  def.tags(),
+		})
+	}
+	return result, nil
+}
+
+// listedDomain holds the name and state of a domain, as listed by
+// "virsh list".
+type listedDomain struct {
+	name  string
+	state string
+}
+
+// listDomains returns the names and states of all domains, running or
+// not, from a single "virsh list".
+func (c *virshConnection) listDomains() ([]listedDomain, error) {
+	out, err := c.virsh("list", "--all")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return parseDomainList(out), nil
+}
+
+// parseDomainList parses the table output by "virsh list", eg
+//
+//	 Id   Name            State
+//	------------------------------
+//	 1    juju-abcdef-0   running
+//	 -    juju-abcdef-1   shut off
+func parseDomainList(out string) []listedDomain {
+	var result []listedDomain
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] == "Id" {
+			continue
+		}
+		result = append(result, listedDomain{
+			name:  fields[1],
+			state: strings.Join(fields[2:], " "),
+		})
+	}
+	return result
+}
+
+// domainXML holds the parts of a domain's definition that the provider
+// reads back.
+type domainXML struct {
+	Metadata struct {
+		Tags *tagsXML `xml:"https://juju.is/libvirt/1 tags"`
+	} `xml:"metadata"`
+	Disks []struct {
+		Source struct {
+			File string `xml:"file,attr"`
+		} `xml:"source"`
+		Target struct {
+			Dev string `xml:"dev,attr"`
+		} `xml:"target"`
+	} `xml:"devices>disk"`
+}
+
+// tagsXML is the form in which tags are recorded in domain metadata.
+type tagsXML struct {
+	XMLName xml.Name `xml:"tags"`
+	Tags    []tagXML `xml:"tag"`
+}
+
+type tagXML struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (d *domainXML) tags() map[string]string {
+	tags := make(map[string]string)
+	if d.Metadata.Tags != nil {
+		for _, tag := range d.Metadata.Tags.Tags {
+			tags[tag.Key] = tag.Value
+		}
+	}
+	return tags
+}
+
+// domainDefinition returns the persistent definition of the named domain.
+func (c *virshConnection) domainDefinition(name string) (*domainXML, error) {
+	out, err := c.virsh("dumpxml", name, "--inactive")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var def domainXML
+	if err := xml.Unmarshal([]byte(out), &def); err != nil {
+		return nil, errors.Annotatef(err, "parsing definition of domain %q", name)
+	}
+	return &def, nil
+}
+
+// CreateDomain is part of the libvirtConnection interface.
+func (c *virshConnection) CreateDomain(name, definition string, tags map[string]string) (err error) {
+	f, err := ioutil.TempFile("", name+"-*.xml")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(definition)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Annotate(err, "writing domain XML")
+	}
+
+	if _, err := c.virsh("define", f.Name()); err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if _, undefineErr := c.virsh("undefine", name, "--nvram"); undefineErr != nil {
+			logger.Warningf("cannot undefine domain %q: %v", name, undefineErr)
+		}
+	}()
+	if err := c.SetDomainTags(name, tags); err != nil {
+		return errors.Trace(err)
+	}
+	// Start the domain again if the hypervisor host is rebooted.
+	if _, err := c.virsh("autostart", name); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := c.virsh("start", name); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// SetDomainTags is part of the libvirtConnection interface.
+func (c *virshConnection) SetDomainTags(name string, tags map[string]string) error {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var metadata tagsXML
+	for _, key := range keys {
+		metadata.Tags = append(metadata.Tags, tagXML{Key: key, Value: tags[key]})
+	}
+	data, err := xml.Marshal(metadata)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The tags are only recorded in the persistent definition, which
+	// is where they are read back from.
+	_, err = c.virsh("metadata", name,
+		"--uri", metadataURI,
+		"--key", metadataKey,
+		"--config",
+		"--set", string(data),
+	)
+	return errors.Trace(err)
+}
+
+// RemoveDomain is part of the libvirtConnection interface.
+func (c *virshConnection) RemoveDomain(name string, diskTargets ...string) error {
+	listed, err := c.listDomains()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	state := ""
+	for _, d := range listed {
+		if d.name == name {
+			state = d.state
+			break
+		}
+	}
+	if state == "" {
+		return nil
+	}
+	if state != domainShutOff {
+		if _, err := c.virsh("destroy", name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	args := []string{name, "--nvram"}
+	if len(diskTargets) > 0 {
+		args = append(args, "--storage", strings.Join(diskTargets, ","))
+	}
+	_, err = c.virsh("undefine", args...)
+	return errors.Trace(err)
+}
+
+// DomainAddresses is part of the libvirtConnection interface.
+func (c *virshConnection) DomainAddresses(name string) ([]string, error) {
+	// The DHCP leases of libvirt's own networks are the most reliable
+	// source of addresses; instances on other bridges can only be found
+	// in the host's ARP table.
+	out, err := c.virsh("domifaddr", name, "--source", "lease")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if addrs := parseDomIfAddr(out); len(addrs) > 0 {
+		return addrs, nil
+	}
+	out, err = c.virsh("domifaddr", name, "--source", "arp")
+	if err != nil {
+		logger.Debugf("cannot get addresses of domain %q from the ARP table: %v", name, err)
+		return nil, nil
+	}
+	return parseDomIfAddr(out), nil
+}
+
+// parseDomIfAddr parses the output of "virsh domifaddr", which is of
+// the form:
+//
+//	 Name       MAC address          Protocol     Address
+//	-------------------------------------------------------------------
+//	 vnet0      52:54:00:8f:61:23    ipv4         192.168.122.45/24
+func parseDomIfAddr(out string) []string {
+	var addrs []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 4 || fields[0] == "Name" {
+			continue
+		}
+		addr := fields[3]
+		if i := strings.Index(addr, "/"); i >= 0 {
+			addr = addr[:i]
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// AttachDisk is part of the libvirtConnection interface.
+func (c *virshConnection) AttachDisk(domainName, path, serial string) error {
+	def, err := c.domainDefinition(domainName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	used := make(map[string]bool)
+	for _, disk := range def.Disks {
+		if disk.Source.File == path {
+			// Already attached.
+			return nil
+		}
+		used[disk.Target.Dev] = true
+	}
+	target := ""
+	for i := 0; i < 26; i++ {
+		dev := fmt.Sprintf("vd%c", 'a'+i)
+		if !used[dev] {
+			target = dev
+			break
+		}
+	}
+	if target == "" {
+		return errors.Errorf("domain %q has no free disk targets", domainName)
+	}
+	_, err = c.virsh("attach-disk", domainName, path, target,
+		"--driver", "qemu",
+		"--subdriver", "raw",
+		"--serial", serial,
+		"--persistent",
+	)
+	return errors.Trace(err)
+}
+
+// DetachDisk is part of the libvirtConnection interface.
+func (c *virshConnection) DetachDisk(domainName, path string) error {
+	def, err := c.domainDefinition(domainName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, disk := range def.Disks {
+		if disk.Source.File == path {
+			_, err := c.virsh("detach-disk", domainName, path, "--persistent")
+			return errors.Trace(err)
+		}
+	}
+	// Not attached.
+	return nil
+}
+
+// Volumes is part of the libvirtConnection interface. The sizes of the
+// volumes are not reported.
+func (c *virshConnection) Volumes(pool string) ([]volume, error) {
+	out, err := c.virsh("vol-list", "--pool", pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The output is of the form:
+	//
+	//  Name          Path
+	// -----------------------------------------------
+	//  juju-0-root   /var/lib/libvirt/images/juju-0-root
+	var volumes []volume
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] == "Name" {
+			continue
+		}
+		volumes = append(volumes, volume{Name: fields[0], Path: fields[1]})
+	}
+	return volumes, nil
+}
+
+// Volume is part of the libvirtConnection interface.
+func (c *virshConnection) Volume(pool, name string) (volume, error) {
+	volumes, err := c.Volumes(pool)
+	if err != nil {
+		return volume{}, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		if v.Name != name {
+			continue
+		}
+		out, err := c.virsh("vol-info", "--pool", pool, "--vol", name, "--bytes")
+		if err != nil {
+			return volume{}, errors.Trace(err)
+		}
+		size, err := parseVolumeCapacity(out)
+		if err != nil {
+			return volume{}, errors.Annotatef(err, "volume %q", name)
+		}
+		v.Size = size
+		return v, nil
+	}
+	return volume{}, errors.NotFoundf("volume %q in pool %q", name, pool)
+}
+
+// parseVolumeCapacity returns the capacity in MiB reported by
+// "virsh vol-info --bytes".
+func parseVolumeCapacity(out string) (uint64, error) {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "Capacity:" {
+			continue
+		}
+		bytes, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, errors.Annotate(err, "parsing capacity")
+		}
+		return bytes / (1024 * 1024), nil
+	}
+	return 0, errors.New("capacity not reported")
+}
+
+// CreateVolume is part of the libvirtConnection interface.
+func (c *virshConnection) CreateVolume(pool string, spec volumeSpec) (volume, error) {
+	args := []string{
+		"--pool", pool,
+		"--name", spec.Name,
+		"--capacity", fmt.Sprintf("%dM", spec.Size),
+		"--format", spec.Format,
+	}
+	if spec.BackingVolume != "" {
+		args = append(args,
+			"--backing-vol", spec.BackingVolume,
+			"--backing-vol-format", "qcow2",
+		)
+	}
+	if _, err := c.virsh("vol-create-as", args...); err != nil {
+		return volume{}, errors.Trace(err)
+	}
+	v, err := c.Volume(pool, spec.Name)
+	return v, errors.Trace(err)
+}
+
+// UploadVolume is part of the libvirtConnection interface.
+func (c *virshConnection) UploadVolume(pool, name, path string) error {
+	_, err := c.virsh("vol-upload", "--pool", pool, "--vol", name, "--file", path)
+	return errors.Trace(err)
+}
+
+// RemoveVolume is part of the libvirtConnection interface.
+func (c *virshConnection) RemoveVolume(pool, name string) error {
+	_, err := c.virsh("vol-delete", "--pool", pool, "--vol", name)
+	return errors.Trace(err)
+}
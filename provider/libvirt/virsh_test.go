@@ -0,0 +1,135 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"strings"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type virshSuite struct {
+	gitjujutesting.IsolationSuite
+
+	stub    gitjujutesting.Stub
+	outputs map[string]string
+	conn    *virshConnection
+}
+
+var _ = gc.Suite(&virshSuite{})
+
+func (s *virshSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = gitjujutesting.Stub{}
+	s.outputs = make(map[string]string)
+	s.conn = newVirshConnection("qemu+ssh://ubuntu@10.0.0.1/system", func(args ...string) (string, error) {
+		s.stub.AddCall("virsh", args)
+		// Outputs are keyed by the command and its arguments,
+		// without the connection URI.
+		return s.outputs[strings.Join(args[2:], " ")], s.stub.NextErr()
+	})
+}
+
+func (s *virshSuite) TestHostArch(c *gc.C) {
+	s.outputs["capabilities"] = `
+<capabilities>
+  <host>
+    <cpu>
+      <arch>x86_64</arch>
+    </cpu>
+  </host>
+</capabilities>`
+	hostArch, err := s.conn.HostArch()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hostArch, gc.Equals, "amd64")
+	s.stub.CheckCall(c, 0, "virsh", []string{"--connect", "qemu+ssh://ubuntu@10.0.0.1/system", "capabilities"})
+}
+
+func (s *virshSuite) TestDomains(c *gc.C) {
+	s.outputs["list --all"] = `
+ Id   Name            State
+--------------------------------
+ 1    juju-abcdef-0   running
+ 2    other           running
+ -    juju-abcdef-1   shut off
+
+`
+	s.outputs["dumpxml juju-abcdef-0 --inactive"] = `
+<domain type="kvm">
+  <name>juju-abcdef-0</name>
+  <metadata>
+    <juju:tags xmlns:juju="https://juju.is/libvirt/1">
+      <juju:tag key="juju-model-uuid">deadbeef</juju:tag>
+    </juju:tags>
+  </metadata>
+</domain>`
+	s.outputs["dumpxml juju-abcdef-1 --inactive"] = `<domain type="kvm"><name>juju-abcdef-1</name></domain>`
+
+	domains, err := s.conn.Domains("juju-abcdef-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(domains, jc.DeepEquals, []domain{{
+		Name:  "juju-abcdef-0",
+		State: domainRunning,
+		Tags:  map[string]string{"juju-model-uuid": "deadbeef"},
+	}, {
+		Name:  "juju-abcdef-1",
+		State: domainShutOff,
+		Tags:  map[string]string{},
+	}})
+	// The domains are listed once, with their states, and only the
+	// definitions are read per domain.
+	s.stub.CheckCallNames(c, "virsh", "virsh", "virsh")
+}
+
+func (s *virshSuite) TestRemoveDomain(c *gc.C) {
+	s.outputs["list --all"] = `
+ Id   Name            State
+--------------------------------
+ 1    juju-abcdef-0   running
+`
+	err := s.conn.RemoveDomain("juju-abcdef-0", "vda")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{FuncName: "virsh", Args: []interface{}{[]string{"--connect", "qemu+ssh://ubuntu@10.0.0.1/system", "list", "--all"}}},
+		{FuncName: "virsh", Args: []interface{}{[]string{"--connect", "qemu+ssh://ubuntu@10.0.0.1/system", "destroy", "juju-abcdef-0"}}},
+		{FuncName: "virsh", Args: []interface{}{[]string{"--connect", "qemu+ssh://ubuntu@10.0.0.1/system", "undefine", "juju-abcdef-0", "--nvram", "--storage", "vda"}}},
+	})
+}
+
+func (s *virshSuite) TestRemoveDomainNotFound(c *gc.C) {
+	s.outputs["list --all"] = `
+ Id   Name            State
+--------------------------------
+`
+	err := s.conn.RemoveDomain("juju-abcdef-0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "virsh")
+}
+
+func (s *virshSuite) TestParseDomIfAddr(c *gc.C) {
+	out := `
+ Name       MAC address          Protocol     Address
+-------------------------------------------------------------------------------
+ vnet0      52:54:00:a1:b2:c3    ipv4         192.168.122.10/24
+ vnet0      52:54:00:a1:b2:c3    ipv6         fe80::5054:ff:fea1:b2c3/64
+`
+	c.Check(parseDomIfAddr(out), jc.DeepEquals, []string{"192.168.122.10", "fe80::5054:ff:fea1:b2c3"})
+}
+
+func (s *virshSuite) TestParseVolumeCapacity(c *gc.C) {
+	out := `
+Name:           juju-abcdef-volume-0
+Type:           file
+Capacity:       2147483648
+Allocation:     196608
+`
+	size, err := parseVolumeCapacity(out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, uint64(2048))
+
+	_, err = parseVolumeCapacity("Name: foo\n")
+	c.Check(err, gc.ErrorMatches, "capacity not reported")
+}
@@ -120,7 +120,14 @@ func (env *environ) newContainer(
 		return nil, errors.Trace(err)
 	}
 
-	image, err := target.FindImage(args.InstanceConfig.Series, arch, imageSources, true, statusCallback)
+	virtType := instance.DefaultInstanceType
+	if args.Constraints.HasVirtType() {
+		if virtType, err = instance.ParseVirtType(*args.Constraints.VirtType); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	image, err := target.FindImage(args.InstanceConfig.Series, arch, virtType, imageSources, true, statusCallback)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -185,7 +192,6 @@ func (env *environ) getContainerSpec(
 		Image:    image,
 		Config:   make(map[string]string),
 	}
-	cSpec.ApplyConstraints(serverVersion, args.Constraints)
 
 	cloudCfg, err := cloudinit.New(args.InstanceConfig.Series)
 	if err != nil {
@@ -214,6 +220,7 @@ func (env *environ) getContainerSpec(
 
 		cSpec.Devices = nics
 	}
+	cSpec.ApplyConstraints(serverVersion, args.Constraints)
 
 	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudCfg, lxdRenderer{})
 	if err != nil {
@@ -242,9 +249,9 @@ func (env *environ) getContainerSpec(
 	return cSpec, nil
 }
 
-// getTargetServer checks to see if a valid zone was passed as a placement
-// directive in the start-up start-up arguments. If so, a server for the
-// specific node is returned.
+// getTargetServer checks to see if a valid zone or cluster member was passed
+// as a placement directive in the start-up arguments. If so, a server for
+// the specific node is returned.
 func (env *environ) getTargetServer(
 	ctx context.ProviderCallContext, args environs.StartInstanceParams,
 ) (Server, error) {
@@ -263,6 +270,9 @@ type lxdPlacement struct {
 	nodeName string
 }
 
+// parsePlacement parses a placement directive naming the cluster member on
+// which to start an instance. The member may be given as "zone=<name>",
+// "lxd-member=<name>" or just "<name>".
 func (env *environ) parsePlacement(ctx context.ProviderCallContext, placement string) (*lxdPlacement, error) {
 	if placement == "" {
 		return &lxdPlacement{}, nil
@@ -274,7 +284,9 @@ func (env *environ) parsePlacement(ctx context.ProviderCallContext, placement st
 	if pos == -1 {
 		node = placement
 	} else {
-		if placement[:pos] != "zone" {
+		switch placement[:pos] {
+		case "zone", "lxd-member":
+		default:
 			return nil, fmt.Errorf("unknown placement directive: %v", placement)
 		}
 		node = placement[pos+1:]
@@ -284,17 +296,22 @@ func (env *environ) parsePlacement(ctx context.ProviderCallContext, placement st
 		return &lxdPlacement{}, nil
 	}
 
-	zones, err := env.AvailabilityZones(ctx)
-	if err != nil {
+	if err := env.validateMember(ctx, node); err != nil {
 		return nil, errors.Trace(err)
 	}
-	if err := zones.Validate(node); err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	return &lxdPlacement{nodeName: node}, nil
 }
 
+// validateMember returns an error if the input name is not that of an
+// available cluster member.
+func (env *environ) validateMember(ctx context.ProviderCallContext, member string) error {
+	zones, err := env.AvailabilityZones(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(zones.Validate(member))
+}
+
 // getHardwareCharacteristics compiles hardware-related details about
 // the given instance and relative to the provided spec and returns it.
 func (env *environ) getHardwareCharacteristics(
@@ -308,11 +325,15 @@ func (env *environ) getHardwareCharacteristics(
 	}
 	cores := uint64(container.CPUs())
 	mem := uint64(container.Mem())
-	return &instance.HardwareCharacteristics{
+	hwc := &instance.HardwareCharacteristics{
 		Arch:     &archStr,
 		CpuCores: &cores,
 		Mem:      &mem,
 	}
+	if rootDisk := container.RootDisk(); rootDisk > 0 {
+		hwc.RootDisk = &rootDisk
+	}
+	return hwc
 }
 
 // AllInstances implements environs.InstanceBroker.
@@ -336,17 +357,7 @@ func (env *environ) AllRunningInstances(ctx context.ProviderCallContext) ([]inst
 
 // StopInstances implements environs.InstanceBroker.
 func (env *environ) StopInstances(ctx context.ProviderCallContext, instances ...instance.Id) error {
-	prefix := env.namespace.Prefix()
-	var names []string
-	for _, id := range instances {
-		name := string(id)
-		if strings.HasPrefix(name, prefix) {
-			names = append(names, name)
-		} else {
-			logger.Warningf("ignoring request to stop container %q - not in namespace %q", name, prefix)
-		}
-	}
-
+	names := env.namespacedContainers("stop", instances)
 	err := env.server().RemoveContainers(names)
 	if err != nil {
 		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
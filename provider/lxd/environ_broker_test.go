@@ -8,6 +8,7 @@ import (
 	"reflect"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2/arch"
 	"github.com/lxc/lxd/shared/api"
@@ -17,6 +18,8 @@ import (
 	containerlxd "github.com/juju/juju/container/lxd"
 	lxdtesting "github.com/juju/juju/container/lxd/testing"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/provider/lxd"
 )
@@ -90,7 +93,7 @@ func (s *environBrokerSuite) TestStartInstanceDefaultNIC(c *gc.C) {
 	exp := svr.EXPECT()
 	gomock.InOrder(
 		exp.HostArch().Return(arch.AMD64),
-		exp.FindImage("bionic", arch.AMD64, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
+		exp.FindImage("bionic", arch.AMD64, instance.InstanceTypeContainer, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
 		exp.ServerVersion().Return("3.10.0"),
 		exp.GetNICsFromProfile("default").Return(s.defaultProfile.Devices, nil),
 		exp.CreateContainerFromSpec(matchesContainerSpec(check)).Return(&containerlxd.Container{}, nil),
@@ -129,7 +132,7 @@ func (s *environBrokerSuite) TestStartInstanceNonDefaultNIC(c *gc.C) {
 	exp := svr.EXPECT()
 	gomock.InOrder(
 		exp.HostArch().Return(arch.AMD64),
-		exp.FindImage("bionic", arch.AMD64, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
+		exp.FindImage("bionic", arch.AMD64, instance.InstanceTypeContainer, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
 		exp.ServerVersion().Return("3.10.0"),
 		exp.GetNICsFromProfile("default").Return(nics, nil),
 		exp.CreateContainerFromSpec(matchesContainerSpec(check)).Return(&containerlxd.Container{}, nil),
@@ -188,9 +191,9 @@ func (s *environBrokerSuite) TestStartInstanceWithPlacementAvailable(c *gc.C) {
 
 	// CreateContainerFromSpec is tested in container/lxd.
 	// we don't bother with detailed parameter assertions here.
-	tExp.CreateContainerFromImage(gomock.Any(), gomock.Any(), gomock.Any()).Return(createOp, nil)
-	tExp.UpdateContainerState(gomock.Any(), gomock.Any(), "").Return(startOp, nil)
-	tExp.GetContainer(gomock.Any()).Return(&api.Container{}, lxdtesting.ETag, nil)
+	tExp.CreateInstanceFromImage(gomock.Any(), gomock.Any(), gomock.Any()).Return(createOp, nil)
+	tExp.UpdateInstanceState(gomock.Any(), gomock.Any(), "").Return(startOp, nil)
+	tExp.GetInstance(gomock.Any()).Return(&api.Instance{}, lxdtesting.ETag, nil)
 
 	env := s.NewEnviron(c, svr, nil)
 
@@ -227,6 +230,32 @@ func (s *environBrokerSuite) TestStartInstanceWithPlacementNotPresent(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `availability zone "node03" not valid`)
 }
 
+func (s *environBrokerSuite) TestStartInstanceWithMemberPlacementNotPresent(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	members := []api.ClusterMember{{
+		ServerName: "node01",
+		Status:     "ONLINE",
+	}}
+
+	sExp := svr.EXPECT()
+	gomock.InOrder(
+		sExp.HostArch().Return(arch.AMD64),
+		sExp.IsClustered().Return(true),
+		sExp.GetClusterMembers().Return(members, nil),
+	)
+
+	env := s.NewEnviron(c, svr, nil)
+
+	args := s.GetStartInstanceArgs(c, "bionic")
+	args.Placement = "lxd-member=node03"
+
+	_, err := env.StartInstance(s.callCtx, args)
+	c.Assert(err, gc.ErrorMatches, `availability zone "node03" not valid`)
+}
+
 func (s *environBrokerSuite) TestStartInstanceWithPlacementNotAvailable(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -291,7 +320,7 @@ func (s *environBrokerSuite) TestStartInstanceWithConstraints(c *gc.C) {
 	exp := svr.EXPECT()
 	gomock.InOrder(
 		exp.HostArch().Return(arch.AMD64),
-		exp.FindImage("bionic", arch.AMD64, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
+		exp.FindImage("bionic", arch.AMD64, instance.InstanceTypeContainer, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
 		exp.ServerVersion().Return("3.10.0"),
 		exp.GetNICsFromProfile("default").Return(s.defaultProfile.Devices, nil),
 		exp.CreateContainerFromSpec(matchesContainerSpec(check)).Return(&containerlxd.Container{}, nil),
@@ -313,6 +342,45 @@ func (s *environBrokerSuite) TestStartInstanceWithConstraints(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *environBrokerSuite) TestStartInstanceVirtualMachine(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	// Check that a virtual machine with a sized root disk was requested.
+	check := func(spec containerlxd.ContainerSpec) bool {
+		if spec.VirtType != instance.InstanceTypeVM {
+			return false
+		}
+		return spec.Devices["root"]["size"] == "20480MiB"
+	}
+
+	vm := &containerlxd.Container{}
+	vm.Type = "virtual-machine"
+	vm.Devices = map[string]map[string]string{
+		"root": {"type": "disk", "path": "/", "pool": "default", "size": "20480MiB"},
+	}
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.HostArch().Return(arch.AMD64),
+		exp.FindImage("bionic", arch.AMD64, instance.InstanceTypeVM, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
+		exp.ServerVersion().Return("4.0.0"),
+		exp.GetNICsFromProfile("default").Return(s.defaultProfile.Devices, nil),
+		exp.CreateContainerFromSpec(matchesContainerSpec(check)).Return(vm, nil),
+		exp.HostArch().Return(arch.AMD64),
+	)
+
+	args := s.GetStartInstanceArgs(c, "bionic")
+	args.Constraints = constraints.MustParse("virt-type=virtual-machine root-disk=20G")
+
+	env := s.NewEnviron(c, svr, nil)
+	result, err := env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Hardware.RootDisk, gc.NotNil)
+	c.Check(*result.Hardware.RootDisk, gc.Equals, uint64(20480))
+}
+
 func (s *environBrokerSuite) TestStartInstanceWithCharmLXDProfile(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -336,7 +404,7 @@ func (s *environBrokerSuite) TestStartInstanceWithCharmLXDProfile(c *gc.C) {
 	exp := svr.EXPECT()
 	gomock.InOrder(
 		exp.HostArch().Return(arch.AMD64),
-		exp.FindImage("bionic", arch.AMD64, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
+		exp.FindImage("bionic", arch.AMD64, instance.InstanceTypeContainer, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
 		exp.ServerVersion().Return("3.10.0"),
 		exp.GetNICsFromProfile("default").Return(s.defaultProfile.Devices, nil),
 		exp.CreateContainerFromSpec(matchesContainerSpec(check)).Return(&containerlxd.Container{}, nil),
@@ -373,7 +441,7 @@ func (s *environBrokerSuite) TestStartInstanceInvalidCredentials(c *gc.C) {
 	exp := svr.EXPECT()
 	gomock.InOrder(
 		exp.HostArch().Return(arch.AMD64),
-		exp.FindImage("bionic", arch.AMD64, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
+		exp.FindImage("bionic", arch.AMD64, instance.InstanceTypeContainer, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
 		exp.ServerVersion().Return("3.10.0"),
 		exp.GetNICsFromProfile("default").Return(s.defaultProfile.Devices, nil),
 		exp.CreateContainerFromSpec(gomock.Any()).Return(&containerlxd.Container{}, fmt.Errorf("not authorized")),
@@ -411,6 +479,209 @@ func (s *environBrokerSuite) TestStopInstancesInvalidCredentials(c *gc.C) {
 	c.Assert(s.invalidCredential, jc.IsTrue)
 }
 
+func (s *environBrokerSuite) TestSuspendAndResumeInstances(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.StopContainer("juju-f75cba-1").Return(nil),
+		exp.StopContainer("juju-f75cba-2").Return(nil),
+		exp.StartContainer("juju-f75cba-1").Return(nil),
+	)
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceSuspender)
+	err := env.SuspendInstances(s.callCtx, "juju-f75cba-1", "juju-f75cba-2", "not-in-namespace-so-ignored")
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.ResumeInstances(s.callCtx, "juju-f75cba-1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *environBrokerSuite) TestSuspendInstancesInvalidCredentials(c *gc.C) {
+	c.Assert(s.invalidCredential, jc.IsFalse)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	svr.EXPECT().StopContainer("juju-f75cba-1").Return(fmt.Errorf("not authorized"))
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceSuspender)
+	err := env.SuspendInstances(s.callCtx, "juju-f75cba-1")
+	c.Assert(err, gc.ErrorMatches, `suspending container "juju-f75cba-1": not authorized`)
+	c.Assert(s.invalidCredential, jc.IsTrue)
+}
+
+func (s *environBrokerSuite) TestResizeInstance(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	container := &api.Instance{
+		Name: "juju-f75cba-1",
+		InstancePut: api.InstancePut{
+			Architecture: "x86_64",
+			Config:       map[string]string{"limits.cpu": "1"},
+		},
+	}
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.GetInstance("juju-f75cba-1").Return(container, lxdtesting.ETag, nil),
+		exp.ServerVersion().Return("3.10.0"),
+		exp.StopContainer("juju-f75cba-1").Return(nil),
+		exp.UpdateContainerConfig("juju-f75cba-1", map[string]string{
+			"limits.cpu":    "2",
+			"limits.memory": "4096MiB",
+		}).Return(nil),
+		exp.StartContainer("juju-f75cba-1").Return(nil),
+	)
+
+	env := s.NewEnviron(c, svr, nil)
+	hc, err := env.(environs.InstanceResizer).ResizeInstance(
+		s.callCtx, "juju-f75cba-1", constraints.MustParse("cores=2 mem=4G"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hc.String(), gc.Equals, "arch=amd64 cores=2 mem=4096M")
+}
+
+func (s *environBrokerSuite) TestResizeInstanceUnchanged(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	container := &api.Instance{
+		Name: "juju-f75cba-1",
+		InstancePut: api.InstancePut{
+			Architecture: "x86_64",
+			Config:       map[string]string{"limits.cpu": "2"},
+		},
+	}
+	exp := svr.EXPECT()
+	exp.GetInstance("juju-f75cba-1").Return(container, lxdtesting.ETag, nil)
+	exp.ServerVersion().Return("3.10.0")
+
+	env := s.NewEnviron(c, svr, nil)
+	hc, err := env.(environs.InstanceResizer).ResizeInstance(
+		s.callCtx, "juju-f75cba-1", constraints.MustParse("cores=2"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hc.String(), gc.Equals, "arch=amd64 cores=2")
+}
+
+func (s *environBrokerSuite) TestResizeInstanceRestartsOnFailure(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	container := &api.Instance{
+		Name:        "juju-f75cba-1",
+		InstancePut: api.InstancePut{Architecture: "x86_64"},
+	}
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.GetInstance("juju-f75cba-1").Return(container, lxdtesting.ETag, nil),
+		exp.ServerVersion().Return("3.10.0"),
+		exp.StopContainer("juju-f75cba-1").Return(nil),
+		exp.UpdateContainerConfig("juju-f75cba-1", gomock.Any()).Return(fmt.Errorf("boom")),
+		exp.StartContainer("juju-f75cba-1").Return(nil),
+	)
+
+	env := s.NewEnviron(c, svr, nil)
+	_, err := env.(environs.InstanceResizer).ResizeInstance(
+		s.callCtx, "juju-f75cba-1", constraints.MustParse("cores=2"),
+	)
+	c.Assert(err, gc.ErrorMatches, `updating limits of container "juju-f75cba-1": boom`)
+}
+
+func (s *environBrokerSuite) TestResizeInstanceArchitecture(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	container := &api.Instance{
+		Name:        "juju-f75cba-1",
+		InstancePut: api.InstancePut{Architecture: "x86_64"},
+	}
+	svr.EXPECT().GetInstance("juju-f75cba-1").Return(container, lxdtesting.ETag, nil)
+
+	env := s.NewEnviron(c, svr, nil)
+	_, err := env.(environs.InstanceResizer).ResizeInstance(
+		s.callCtx, "juju-f75cba-1", constraints.MustParse("arch=arm64"),
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot change architecture from "amd64" to "arm64"`)
+}
+
+func (s *environBrokerSuite) TestMigrateInstance(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	members := []api.ClusterMember{{
+		ServerName: "node01",
+		Status:     "ONLINE",
+	}, {
+		ServerName: "node02",
+		Status:     "ONLINE",
+	}}
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.IsClustered().Return(true),
+		exp.IsClustered().Return(true),
+		exp.GetClusterMembers().Return(members, nil),
+		exp.MoveContainer("juju-f75cba-1", "node02").Return(nil),
+	)
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceMigrator)
+	zone, err := env.MigrateInstance(s.callCtx, "juju-f75cba-1", "node02")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone, gc.Equals, "node02")
+}
+
+func (s *environBrokerSuite) TestMigrateInstanceNotClustered(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	svr.EXPECT().IsClustered().Return(false)
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceMigrator)
+	_, err := env.MigrateInstance(s.callCtx, "juju-f75cba-1", "node02")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *environBrokerSuite) TestMigrateInstanceMemberNotAvailable(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	members := []api.ClusterMember{{
+		ServerName: "node02",
+		Status:     "OFFLINE",
+	}}
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.IsClustered().Return(true),
+		exp.IsClustered().Return(true),
+		exp.GetClusterMembers().Return(members, nil),
+	)
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceMigrator)
+	_, err := env.MigrateInstance(s.callCtx, "juju-f75cba-1", "node02")
+	c.Assert(err, gc.ErrorMatches, `zone "node02" is unavailable`)
+}
+
+func (s *environBrokerSuite) TestMigrateInstanceNotInNamespace(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	env := s.NewEnviron(c, svr, nil).(environs.InstanceMigrator)
+	_, err := env.MigrateInstance(s.callCtx, "not-in-namespace", "node02")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *environBrokerSuite) TestImageSourcesDefault(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
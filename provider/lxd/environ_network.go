@@ -261,7 +261,7 @@ func (e *environ) NetworkInterfaces(ctx context.ProviderCallContext, ids []insta
 	return res, nil
 }
 
-func makeInterfaceInfo(container *lxdapi.Container, guestNetworkName string, netInfo lxdapi.ContainerStateNetwork) (network.InterfaceInfo, error) {
+func makeInterfaceInfo(container *lxdapi.Instance, guestNetworkName string, netInfo lxdapi.InstanceStateNetwork) (network.InterfaceInfo, error) {
 	var ni = network.InterfaceInfo{
 		MACAddress:          netInfo.Hwaddr,
 		MTU:                 netInfo.Mtu,
@@ -327,7 +327,7 @@ func detectInterfaceType(lxdIfaceType string) network.InterfaceType {
 	}
 }
 
-func hostNetworkForGuestNetwork(container *lxdapi.Container, guestNetwork string) string {
+func hostNetworkForGuestNetwork(container *lxdapi.Instance, guestNetwork string) string {
 	if container.ExpandedDevices == nil {
 		return ""
 	}
@@ -344,8 +344,8 @@ func hostNetworkForGuestNetwork(container *lxdapi.Container, guestNetwork string
 	return ""
 }
 
-func getContainerDetails(srv Server, containerID string) (*lxdapi.Container, *lxdapi.ContainerState, error) {
-	cont, _, err := srv.GetContainer(containerID)
+func getContainerDetails(srv Server, containerID string) (*lxdapi.Instance, *lxdapi.InstanceState, error) {
+	cont, _, err := srv.GetInstance(containerID)
 	if err != nil {
 		if isErrNotFound(err) {
 			return nil, nil, errors.NotFoundf("container %q", containerID)
@@ -353,7 +353,7 @@ func getContainerDetails(srv Server, containerID string) (*lxdapi.Container, *lx
 		return nil, nil, errors.Trace(err)
 	}
 
-	state, _, err := srv.GetContainerState(containerID)
+	state, _, err := srv.GetInstanceState(containerID)
 	if err != nil {
 		if isErrNotFound(err) {
 			return nil, nil, errors.NotFoundf("container %q", containerID)
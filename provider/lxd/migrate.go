@@ -0,0 +1,40 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ environs.InstanceMigrator = (*environ)(nil)
+
+// MigrateInstance is part of the environs.InstanceMigrator interface.
+// Cluster members are the provider's availability zones, so the name of
+// the member is returned as the instance's new zone.
+func (env *environ) MigrateInstance(ctx context.ProviderCallContext, id instance.Id, member string) (string, error) {
+	name := string(id)
+	if !strings.HasPrefix(name, env.namespace.Prefix()) {
+		return "", errors.NotFoundf("container %q in namespace %q", name, env.namespace.Prefix())
+	}
+	server := env.server()
+	if !server.IsClustered() {
+		return "", errors.NotSupportedf("moving containers on a server that is not clustered")
+	}
+	if err := env.validateMember(ctx, member); err != nil {
+		return "", errors.Trace(err)
+	}
+	logger.Infof("moving container %q to cluster member %q", name, member)
+	if err := server.MoveContainer(name, member); err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return "", errors.Trace(err)
+	}
+	return member, nil
+}
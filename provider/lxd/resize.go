@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ environs.InstanceResizer = (*environ)(nil)
+
+// ResizeInstance is part of the environs.InstanceResizer interface.
+// The container is stopped, its CPU and memory limits updated from the
+// constraints and then started again.
+func (env *environ) ResizeInstance(
+	ctx context.ProviderCallContext, id instance.Id, cons constraints.Value,
+) (*instance.HardwareCharacteristics, error) {
+	if cons.HasInstanceType() {
+		return nil, errors.NotSupportedf("resizing to an instance type")
+	}
+	server := env.server()
+	name := string(id)
+	raw, _, err := server.GetInstance(name)
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return nil, errors.Annotatef(err, "getting container %q", id)
+	}
+	container := &lxd.Container{Instance: *raw}
+	containerArch := container.Arch()
+	if cons.HasArch() && *cons.Arch != containerArch {
+		return nil, errors.Errorf("cannot change architecture from %q to %q", containerArch, *cons.Arch)
+	}
+
+	spec := lxd.ContainerSpec{Config: make(map[string]string)}
+	spec.ApplyConstraints(server.ServerVersion(), cons)
+	changed := false
+	for k, v := range spec.Config {
+		if container.Config[k] != v {
+			changed = true
+			break
+		}
+	}
+
+	if changed {
+		logger.Infof("resizing container %q with limits %v", id, spec.Config)
+		if err := server.StopContainer(name); err != nil {
+			common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+			return nil, errors.Annotatef(err, "stopping container %q", id)
+		}
+		if err := server.UpdateContainerConfig(name, spec.Config); err != nil {
+			// Don't leave the container stopped because LXD
+			// refused the new limits.
+			if startErr := server.StartContainer(name); startErr != nil {
+				logger.Errorf("cannot restart container %q: %v", id, startErr)
+			}
+			return nil, errors.Annotatef(err, "updating limits of container %q", id)
+		}
+		if err := server.StartContainer(name); err != nil {
+			return nil, errors.Annotatef(err, "starting container %q", id)
+		}
+		if container.Config == nil {
+			container.Config = make(map[string]string)
+		}
+		for k, v := range spec.Config {
+			container.Config[k] = v
+		}
+	}
+
+	hc := &instance.HardwareCharacteristics{Arch: &containerArch}
+	if cores := uint64(container.CPUs()); cores > 0 {
+		hc.CpuCores = &cores
+	}
+	if mem := uint64(container.Mem()); mem > 0 {
+		hc.Mem = &mem
+	}
+	return hc, nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/utils/v2"
 
 	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
@@ -33,7 +34,7 @@ import (
 // and provider utilizes.
 //go:generate go run github.com/golang/mock/mockgen -package lxd -destination server_mock_test.go github.com/juju/juju/provider/lxd Server,ServerFactory,InterfaceAddress
 type Server interface {
-	FindImage(string, string, []lxd.ServerSpec, bool, environs.StatusCallbackFunc) (lxd.SourcedImage, error)
+	FindImage(string, string, instance.VirtType, []lxd.ServerSpec, bool, environs.StatusCallbackFunc) (lxd.SourcedImage, error)
 	GetServer() (server *lxdapi.Server, ETag string, err error)
 	ServerVersion() string
 	GetConnectionInfo() (info *lxdclient.ConnectionInfo, err error)
@@ -80,8 +81,11 @@ type Server interface {
 	Name() string
 	GetNetworkNames() ([]string, error)
 	GetNetworkState(name string) (*lxdapi.NetworkState, error)
-	GetContainer(name string) (*lxdapi.Container, string, error)
-	GetContainerState(name string) (*lxdapi.ContainerState, string, error)
+	GetInstance(name string) (*lxdapi.Instance, string, error)
+	GetInstanceState(name string) (*lxdapi.InstanceState, string, error)
+	StartContainer(name string) error
+	StopContainer(name string) error
+	MoveContainer(name, member string) error
 }
 
 // ServerFactory creates a new factory for creating servers that are required
@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ environs.InstanceSuspender = (*environ)(nil)
+
+// SuspendInstances is part of the environs.InstanceSuspender interface.
+// The containers are stopped, keeping their storage and configuration.
+func (env *environ) SuspendInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	server := env.server()
+	for _, name := range env.namespacedContainers("suspend", ids) {
+		if err := server.StopContainer(name); err != nil {
+			common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+			return errors.Annotatef(err, "suspending container %q", name)
+		}
+	}
+	return nil
+}
+
+// ResumeInstances is part of the environs.InstanceSuspender interface.
+func (env *environ) ResumeInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	server := env.server()
+	for _, name := range env.namespacedContainers("resume", ids) {
+		if err := server.StartContainer(name); err != nil {
+			common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+			return errors.Annotatef(err, "resuming container %q", name)
+		}
+	}
+	return nil
+}
+
+// namespacedContainers returns the names of the containers with the
+// given IDs that belong to this model, logging those that are ignored.
+func (env *environ) namespacedContainers(action string, ids []instance.Id) []string {
+	prefix := env.namespace.Prefix()
+	var names []string
+	for _, id := range ids {
+		name := string(id)
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		} else {
+			logger.Warningf("ignoring request to %s container %q - not in namespace %q", action, name, prefix)
+		}
+	}
+	return names
+}
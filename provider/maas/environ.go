@@ -316,6 +316,17 @@ func (env *maasEnviron) SetCloudSpec(spec environscloudspec.CloudSpec) error {
 		return errors.Trace(err)
 	default:
 		env.maasController = controller
+		// The MAAS 2.0 controller cannot power machines off and on,
+		// so keep a raw client for those operations.
+		versionURL := maasServer
+		if _, _, includesVersion := gomaasapi.SplitVersionedURL(maasServer); !includesVersion {
+			versionURL = gomaasapi.AddAPIVersionToURL(maasServer, apiVersion2)
+		}
+		authClient, err := gomaasapi.NewAuthenticatedClient(versionURL, maasOAuth)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		env.maasClientUnlocked = gomaasapi.NewMAAS(*authClient)
 	}
 	env.apiVersion = apiVersion
 	env.storageUnlocked = NewStorage(env)
@@ -259,6 +259,30 @@ func (suite *environSuite) TestStopInstancesStopsAndReleasesInstances(c *gc.C) {
 	c.Assert(suite.testMAASObject.TestServer.OwnedNodes()["test2"], jc.IsFalse)
 }
 
+func (suite *environSuite) TestSuspendAndResumeInstances(c *gc.C) {
+	suite.getInstance("test1")
+	suite.getInstance("test2")
+	env := suite.makeEnviron()
+
+	err := env.SuspendInstances(suite.callCtx, "test1", "test2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.ResumeInstances(suite.callCtx, "test1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	operations := suite.testMAASObject.TestServer.NodeOperations()
+	c.Check(operations, gc.DeepEquals, map[string][]string{
+		"test1": {"stop", "start"},
+		"test2": {"stop"},
+	})
+	// The nodes are not released.
+	c.Check(suite.testMAASObject.TestServer.NodesOperations(), gc.HasLen, 0)
+}
+
+func (suite *environSuite) TestSuspendInstancesUnknownNode(c *gc.C) {
+	err := suite.makeEnviron().SuspendInstances(suite.callCtx, "unknown")
+	c.Assert(err, gc.ErrorMatches, `(?s)suspending nodes: node "unknown": ServerError: 404 .*`)
+}
+
 func (suite *environSuite) TestStopInstancesIgnoresConflict(c *gc.C) {
 	releaseNodes := func(nodes gomaasapi.MAASObject, ids url.Values) error {
 		return gomaasapi.ServerError{StatusCode: 409}
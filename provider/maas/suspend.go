@@ -0,0 +1,53 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maas
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ environs.InstanceSuspender = (*maasEnviron)(nil)
+
+// SuspendInstances is part of the environs.InstanceSuspender interface.
+// The nodes are powered off but stay allocated to the model, so they
+// keep their disks and addresses.
+func (env *maasEnviron) SuspendInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	op := "stop"
+	if env.usingMAAS2() {
+		op = "power_off"
+	}
+	return errors.Annotate(env.powerNodes(ctx, op, ids), "suspending nodes")
+}
+
+// ResumeInstances is part of the environs.InstanceSuspender interface.
+func (env *maasEnviron) ResumeInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	op := "start"
+	if env.usingMAAS2() {
+		op = "power_on"
+	}
+	return errors.Annotate(env.powerNodes(ctx, op, ids), "resuming nodes")
+}
+
+// powerNodes runs the given power operation on each of the nodes.
+// The MAAS 1.0 API calls them nodes and the 2.0 API machines.
+func (env *maasEnviron) powerNodes(ctx context.ProviderCallContext, op string, ids []instance.Id) error {
+	collection := "nodes"
+	if env.usingMAAS2() {
+		collection = "machines"
+	}
+	nodes := env.getMAASClient().GetSubObject(collection)
+	for _, id := range ids {
+		systemId := extractSystemId(id)
+		if _, err := nodes.GetSubObject(systemId).CallPost(op, nil); err != nil {
+			common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+			return errors.Annotatef(err, "node %q", systemId)
+		}
+	}
+	return nil
+}
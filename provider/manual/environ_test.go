@@ -149,7 +149,7 @@ exit 0
 
 func (s *environSuite) TestConstraintsValidator(c *gc.C) {
 	s.PatchValue(&sshprovisioner.DetectSeriesAndHardwareCharacteristics,
-		func(string) (instance.HardwareCharacteristics, string, error) {
+		func(string, string) (instance.HardwareCharacteristics, string, error) {
 			amd64 := "amd64"
 			return instance.HardwareCharacteristics{
 				Arch: &amd64,
@@ -66,6 +66,7 @@ var _ environs.Environ = (*Environ)(nil)
 var _ environs.Firewaller = (*Environ)(nil)
 var _ environs.Networking = (*Environ)(nil)
 var _ environs.NetworkingEnviron = (*Environ)(nil)
+var _ environs.InstanceTagger = (*Environ)(nil)
 
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
@@ -333,6 +334,8 @@ func (e *Environ) ConstraintsValidator(ctx envcontext.ProviderCallContext) (cons
 		constraints.VirtType,
 		constraints.Tags,
 		constraints.AllocatePublicIP,
+		constraints.Spot,
+		constraints.MaxPrice,
 	}
 
 	validator := constraints.NewValidator()
@@ -767,6 +770,34 @@ func (e *Environ) MaintainInstance(ctx envcontext.ProviderCallContext, args envi
 	return nil
 }
 
+// TagInstance implements environs.InstanceTagger. The given tags are
+// merged into the instance's freeform tags.
+func (e *Environ) TagInstance(ctx envcontext.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	inst, err := e.getOCIInstance(ctx, id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	freeformTags := make(map[string]string)
+	for k, v := range inst.raw.FreeformTags {
+		freeformTags[k] = v
+	}
+	for k, v := range tags {
+		freeformTags[k] = v
+	}
+	instanceId := string(id)
+	request := ociCore.UpdateInstanceRequest{
+		InstanceId: &instanceId,
+		UpdateInstanceDetails: ociCore.UpdateInstanceDetails{
+			FreeformTags: freeformTags,
+		},
+	}
+	if _, err := e.Compute.UpdateInstance(context.Background(), request); err != nil {
+		providerCommon.HandleCredentialError(err, ctx)
+		return errors.Annotatef(err, "tagging instance %q", id)
+	}
+	return nil
+}
+
 // Config implements environs.ConfigGetter.
 func (e *Environ) Config() *config.Config {
 	e.ecfgMutex.Lock()
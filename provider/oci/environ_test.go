@@ -592,6 +592,134 @@ func (e *environSuite) TestStopInstances(c *gc.C) {
 
 }
 
+func (e *environSuite) TestTagInstance(c *gc.C) {
+	ctrl := e.patchEnv(c)
+	defer ctrl.Finish()
+
+	getRequest, getResponse := makeGetInstanceRequestResponse(ociCore.Instance{
+		CompartmentId:      &e.testCompartment,
+		AvailabilityDomain: makeStringPointer("fakeZone1"),
+		Id:                 makeStringPointer("fakeInstance1"),
+		Region:             makeStringPointer("us-phoenix-1"),
+		Shape:              makeStringPointer("VM.Standard1.1"),
+		LifecycleState:     ociCore.InstanceLifecycleStateRunning,
+		FreeformTags: map[string]string{
+			"juju-model-uuid": "model-uuid",
+			"owner":           "alice",
+		},
+	})
+	updateRequest := ociCore.UpdateInstanceRequest{
+		InstanceId: makeStringPointer("fakeInstance1"),
+		UpdateInstanceDetails: ociCore.UpdateInstanceDetails{
+			FreeformTags: map[string]string{
+				"juju-model-uuid": "model-uuid",
+				"owner":           "bob",
+				"cost-center":     "cc-1",
+			},
+		},
+	}
+	gomock.InOrder(
+		e.compute.EXPECT().GetInstance(context.Background(), getRequest).Return(getResponse, nil),
+		e.compute.EXPECT().UpdateInstance(context.Background(), updateRequest).Return(
+			ociCore.UpdateInstanceResponse{}, nil),
+	)
+
+	err := e.env.TagInstance(nil, "fakeInstance1", map[string]string{
+		"owner":       "bob",
+		"cost-center": "cc-1",
+	})
+	c.Assert(err, gc.IsNil)
+}
+
+func (e *environSuite) TestRetagResources(c *gc.C) {
+	ctrl := e.patchEnv(c)
+	defer ctrl.Finish()
+
+	cfg, err := e.env.Config().Apply(map[string]interface{}{
+		"resource-tags": "owner=${owner} app=${application}",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = e.env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	modelUUID := e.env.Config().UUID()
+
+	listInstancesRequest, listInstancesResponse := makeListInstancesRequestResponse([]ociCore.Instance{{
+		CompartmentId:      &e.testCompartment,
+		AvailabilityDomain: makeStringPointer("fakeZone1"),
+		Id:                 makeStringPointer("fakeInstance1"),
+		Region:             makeStringPointer("us-phoenix-1"),
+		Shape:              makeStringPointer("VM.Standard1.1"),
+		LifecycleState:     ociCore.InstanceLifecycleStateRunning,
+		FreeformTags: map[string]string{
+			tags.JujuModel: modelUUID,
+			"owner":        "alice",
+			"team":         "a",
+		},
+	}, {
+		CompartmentId:      &e.testCompartment,
+		AvailabilityDomain: makeStringPointer("fakeZone1"),
+		Id:                 makeStringPointer("fakeInstance2"),
+		Region:             makeStringPointer("us-phoenix-1"),
+		Shape:              makeStringPointer("VM.Standard1.1"),
+		LifecycleState:     ociCore.InstanceLifecycleStateRunning,
+		FreeformTags: map[string]string{
+			tags.JujuModel: modelUUID,
+			"owner":        "alice",
+		},
+	}})
+	updateInstanceRequest := ociCore.UpdateInstanceRequest{
+		InstanceId: makeStringPointer("fakeInstance1"),
+		UpdateInstanceDetails: ociCore.UpdateInstanceDetails{
+			FreeformTags: map[string]string{
+				tags.JujuModel: modelUUID,
+				"owner":        "alice",
+			},
+		},
+	}
+	listVolumesRequest := ociCore.ListVolumesRequest{
+		CompartmentId: &e.testCompartment,
+	}
+	listVolumesResponse := ociCore.ListVolumesResponse{
+		Items: []ociCore.Volume{{
+			Id:             makeStringPointer("fakeVolumeID1"),
+			LifecycleState: ociCore.VolumeLifecycleStateAvailable,
+			FreeformTags: map[string]string{
+				tags.JujuModel:        modelUUID,
+				tags.JujuStorageOwner: "mysql/0",
+				"team":                "a",
+			},
+		}, {
+			Id:             makeStringPointer("fakeVolumeID2"),
+			LifecycleState: ociCore.VolumeLifecycleStateAvailable,
+			FreeformTags: map[string]string{
+				tags.JujuModel: "another-model",
+			},
+		}},
+	}
+	updateVolumeRequest := ociCore.UpdateVolumeRequest{
+		VolumeId: makeStringPointer("fakeVolumeID1"),
+		UpdateVolumeDetails: ociCore.UpdateVolumeDetails{
+			FreeformTags: map[string]string{
+				tags.JujuModel:        modelUUID,
+				tags.JujuStorageOwner: "mysql/0",
+				"owner":               "bob",
+				"app":                 "mysql",
+			},
+		},
+	}
+	gomock.InOrder(
+		e.compute.EXPECT().ListInstances(context.Background(), listInstancesRequest).Return(listInstancesResponse, nil),
+		e.compute.EXPECT().UpdateInstance(context.Background(), updateInstanceRequest).Return(
+			ociCore.UpdateInstanceResponse{}, nil),
+		e.storage.EXPECT().ListVolumes(context.Background(), listVolumesRequest).Return(listVolumesResponse, nil),
+		e.storage.EXPECT().UpdateVolume(context.Background(), updateVolumeRequest).Return(
+			ociCore.UpdateVolumeResponse{}, nil),
+	)
+
+	err = e.env.RetagResources(nil, tags.TemplateVars{Owner: "bob"}, []string{"team"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (e *environSuite) TestStopInstancesSingleFail(c *gc.C) {
 	ctrl := e.patchEnv(c)
 	defer ctrl.Finish()
@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	ociCore "github.com/oracle/oci-go-sdk/core"
+
+	"github.com/juju/juju/environs"
+	envcontext "github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	providerCommon "github.com/juju/juju/provider/oci/common"
+)
+
+var _ environs.ResourceRetagger = (*Environ)(nil)
+
+// RetagResources is part of the environs.ResourceRetagger interface.
+// The model's block volumes are retagged; the network resources are
+// only tagged with the controller and model UUIDs. Updating freeform
+// tags replaces all of them, so removed tags are deleted by leaving
+// them out of the update.
+func (e *Environ) RetagResources(ctx envcontext.ProviderCallContext, vars tags.TemplateVars, removed []string) error {
+	modelUUID := e.Config().UUID()
+	if len(removed) > 0 {
+		insts, err := e.allInstances(ctx, map[string]string{tags.JujuModel: modelUUID})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, inst := range insts {
+			freeformTags, changed := withoutTags(inst.raw.FreeformTags, removed)
+			if !changed {
+				continue
+			}
+			request := ociCore.UpdateInstanceRequest{
+				InstanceId: inst.raw.Id,
+				UpdateInstanceDetails: ociCore.UpdateInstanceDetails{
+					FreeformTags: freeformTags,
+				},
+			}
+			if _, err := e.Compute.UpdateInstance(context.Background(), request); err != nil {
+				providerCommon.HandleCredentialError(err, ctx)
+				return errors.Annotatef(err, "deleting tags from instance %q", inst.Id())
+			}
+		}
+	}
+
+	request := ociCore.ListVolumesRequest{
+		CompartmentId: e.ecfg().compartmentID(),
+	}
+	response, err := e.Storage.ListVolumes(context.Background(), request)
+	if err != nil {
+		providerCommon.HandleCredentialError(err, ctx)
+		return errors.Annotate(err, "listing volumes")
+	}
+	tagger := tags.WithTemplateVars(e.Config(), vars)
+	for _, vol := range response.Items {
+		if vol.FreeformTags[tags.JujuModel] != modelUUID {
+			continue
+		}
+		if vol.LifecycleState == ociCore.VolumeLifecycleStateTerminating ||
+			vol.LifecycleState == ociCore.VolumeLifecycleStateTerminated {
+			continue
+		}
+		volumeVars := tags.ResourceTemplateVars(vol.FreeformTags)
+		resourceTags, _ := tags.ExpandedResourceTags(tags.WithTemplateVars(tagger, volumeVars))
+		freeformTags, _ := withoutTags(vol.FreeformTags, removed)
+		for k, v := range resourceTags {
+			freeformTags[k] = v
+		}
+		request := ociCore.UpdateVolumeRequest{
+			VolumeId: vol.Id,
+			UpdateVolumeDetails: ociCore.UpdateVolumeDetails{
+				FreeformTags: freeformTags,
+			},
+		}
+		if _, err := e.Storage.UpdateVolume(context.Background(), request); err != nil {
+			providerCommon.HandleCredentialError(err, ctx)
+			return errors.Annotatef(err, "tagging volume %q", *vol.Id)
+		}
+	}
+	return nil
+}
+
+// withoutTags returns a copy of the freeform tags without the named
+// tags, and whether any of them were present.
+func withoutTags(freeformTags map[string]string, removed []string) (map[string]string, bool) {
+	result := make(map[string]string, len(freeformTags))
+	for k, v := range freeformTags {
+		result[k] = v
+	}
+	changed := false
+	for _, k := range removed {
+		if _, ok := result[k]; ok {
+			delete(result, k)
+			changed = true
+		}
+	}
+	return result, changed
+}
@@ -37,6 +37,7 @@ func (m *MockOCIComputeClient) EXPECT() *MockOCIComputeClientMockRecorder {
 
 // AttachVolume mocks base method
 func (m *MockOCIComputeClient) AttachVolume(arg0 context.Context, arg1 core.AttachVolumeRequest) (core.AttachVolumeResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AttachVolume", arg0, arg1)
 	ret0, _ := ret[0].(core.AttachVolumeResponse)
 	ret1, _ := ret[1].(error)
@@ -45,11 +46,13 @@ func (m *MockOCIComputeClient) AttachVolume(arg0 context.Context, arg1 core.Atta
 
 // AttachVolume indicates an expected call of AttachVolume
 func (mr *MockOCIComputeClientMockRecorder) AttachVolume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachVolume", reflect.TypeOf((*MockOCIComputeClient)(nil).AttachVolume), arg0, arg1)
 }
 
 // DetachVolume mocks base method
 func (m *MockOCIComputeClient) DetachVolume(arg0 context.Context, arg1 core.DetachVolumeRequest) (core.DetachVolumeResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DetachVolume", arg0, arg1)
 	ret0, _ := ret[0].(core.DetachVolumeResponse)
 	ret1, _ := ret[1].(error)
@@ -58,11 +61,13 @@ func (m *MockOCIComputeClient) DetachVolume(arg0 context.Context, arg1 core.Deta
 
 // DetachVolume indicates an expected call of DetachVolume
 func (mr *MockOCIComputeClientMockRecorder) DetachVolume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachVolume", reflect.TypeOf((*MockOCIComputeClient)(nil).DetachVolume), arg0, arg1)
 }
 
 // GetInstance mocks base method
 func (m *MockOCIComputeClient) GetInstance(arg0 context.Context, arg1 core.GetInstanceRequest) (core.GetInstanceResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetInstance", arg0, arg1)
 	ret0, _ := ret[0].(core.GetInstanceResponse)
 	ret1, _ := ret[1].(error)
@@ -71,11 +76,13 @@ func (m *MockOCIComputeClient) GetInstance(arg0 context.Context, arg1 core.GetIn
 
 // GetInstance indicates an expected call of GetInstance
 func (mr *MockOCIComputeClientMockRecorder) GetInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstance", reflect.TypeOf((*MockOCIComputeClient)(nil).GetInstance), arg0, arg1)
 }
 
 // GetVolumeAttachment mocks base method
 func (m *MockOCIComputeClient) GetVolumeAttachment(arg0 context.Context, arg1 core.GetVolumeAttachmentRequest) (core.GetVolumeAttachmentResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetVolumeAttachment", arg0, arg1)
 	ret0, _ := ret[0].(core.GetVolumeAttachmentResponse)
 	ret1, _ := ret[1].(error)
@@ -84,11 +91,13 @@ func (m *MockOCIComputeClient) GetVolumeAttachment(arg0 context.Context, arg1 co
 
 // GetVolumeAttachment indicates an expected call of GetVolumeAttachment
 func (mr *MockOCIComputeClientMockRecorder) GetVolumeAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolumeAttachment", reflect.TypeOf((*MockOCIComputeClient)(nil).GetVolumeAttachment), arg0, arg1)
 }
 
 // LaunchInstance mocks base method
 func (m *MockOCIComputeClient) LaunchInstance(arg0 context.Context, arg1 core.LaunchInstanceRequest) (core.LaunchInstanceResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "LaunchInstance", arg0, arg1)
 	ret0, _ := ret[0].(core.LaunchInstanceResponse)
 	ret1, _ := ret[1].(error)
@@ -97,11 +106,13 @@ func (m *MockOCIComputeClient) LaunchInstance(arg0 context.Context, arg1 core.La
 
 // LaunchInstance indicates an expected call of LaunchInstance
 func (mr *MockOCIComputeClientMockRecorder) LaunchInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchInstance", reflect.TypeOf((*MockOCIComputeClient)(nil).LaunchInstance), arg0, arg1)
 }
 
 // ListImages mocks base method
 func (m *MockOCIComputeClient) ListImages(arg0 context.Context, arg1 core.ListImagesRequest) (core.ListImagesResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListImages", arg0, arg1)
 	ret0, _ := ret[0].(core.ListImagesResponse)
 	ret1, _ := ret[1].(error)
@@ -110,11 +121,13 @@ func (m *MockOCIComputeClient) ListImages(arg0 context.Context, arg1 core.ListIm
 
 // ListImages indicates an expected call of ListImages
 func (mr *MockOCIComputeClientMockRecorder) ListImages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockOCIComputeClient)(nil).ListImages), arg0, arg1)
 }
 
 // ListInstances mocks base method
 func (m *MockOCIComputeClient) ListInstances(arg0 context.Context, arg1 core.ListInstancesRequest) (core.ListInstancesResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListInstances", arg0, arg1)
 	ret0, _ := ret[0].(core.ListInstancesResponse)
 	ret1, _ := ret[1].(error)
@@ -123,11 +136,13 @@ func (m *MockOCIComputeClient) ListInstances(arg0 context.Context, arg1 core.Lis
 
 // ListInstances indicates an expected call of ListInstances
 func (mr *MockOCIComputeClientMockRecorder) ListInstances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstances", reflect.TypeOf((*MockOCIComputeClient)(nil).ListInstances), arg0, arg1)
 }
 
 // ListShapes mocks base method
 func (m *MockOCIComputeClient) ListShapes(arg0 context.Context, arg1 core.ListShapesRequest) (core.ListShapesResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListShapes", arg0, arg1)
 	ret0, _ := ret[0].(core.ListShapesResponse)
 	ret1, _ := ret[1].(error)
@@ -136,11 +151,13 @@ func (m *MockOCIComputeClient) ListShapes(arg0 context.Context, arg1 core.ListSh
 
 // ListShapes indicates an expected call of ListShapes
 func (mr *MockOCIComputeClientMockRecorder) ListShapes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShapes", reflect.TypeOf((*MockOCIComputeClient)(nil).ListShapes), arg0, arg1)
 }
 
 // ListVnicAttachments mocks base method
 func (m *MockOCIComputeClient) ListVnicAttachments(arg0 context.Context, arg1 core.ListVnicAttachmentsRequest) (core.ListVnicAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListVnicAttachments", arg0, arg1)
 	ret0, _ := ret[0].(core.ListVnicAttachmentsResponse)
 	ret1, _ := ret[1].(error)
@@ -149,11 +166,13 @@ func (m *MockOCIComputeClient) ListVnicAttachments(arg0 context.Context, arg1 co
 
 // ListVnicAttachments indicates an expected call of ListVnicAttachments
 func (mr *MockOCIComputeClientMockRecorder) ListVnicAttachments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVnicAttachments", reflect.TypeOf((*MockOCIComputeClient)(nil).ListVnicAttachments), arg0, arg1)
 }
 
 // ListVolumeAttachments mocks base method
 func (m *MockOCIComputeClient) ListVolumeAttachments(arg0 context.Context, arg1 core.ListVolumeAttachmentsRequest) (core.ListVolumeAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListVolumeAttachments", arg0, arg1)
 	ret0, _ := ret[0].(core.ListVolumeAttachmentsResponse)
 	ret1, _ := ret[1].(error)
@@ -162,11 +181,13 @@ func (m *MockOCIComputeClient) ListVolumeAttachments(arg0 context.Context, arg1
 
 // ListVolumeAttachments indicates an expected call of ListVolumeAttachments
 func (mr *MockOCIComputeClientMockRecorder) ListVolumeAttachments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVolumeAttachments", reflect.TypeOf((*MockOCIComputeClient)(nil).ListVolumeAttachments), arg0, arg1)
 }
 
 // TerminateInstance mocks base method
 func (m *MockOCIComputeClient) TerminateInstance(arg0 context.Context, arg1 core.TerminateInstanceRequest) (core.TerminateInstanceResponse, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "TerminateInstance", arg0, arg1)
 	ret0, _ := ret[0].(core.TerminateInstanceResponse)
 	ret1, _ := ret[1].(error)
@@ -175,5 +196,21 @@ func (m *MockOCIComputeClient) TerminateInstance(arg0 context.Context, arg1 core
 
 // TerminateInstance indicates an expected call of TerminateInstance
 func (mr *MockOCIComputeClientMockRecorder) TerminateInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateInstance", reflect.TypeOf((*MockOCIComputeClient)(nil).TerminateInstance), arg0, arg1)
 }
+
+// UpdateInstance mocks base method
+func (m *MockOCIComputeClient) UpdateInstance(arg0 context.Context, arg1 core.UpdateInstanceRequest) (core.UpdateInstanceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstance", arg0, arg1)
+	ret0, _ := ret[0].(core.UpdateInstanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInstance indicates an expected call of UpdateInstance
+func (mr *MockOCIComputeClientMockRecorder) UpdateInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstance", reflect.TypeOf((*MockOCIComputeClient)(nil).UpdateInstance), arg0, arg1)
+}
@@ -5,8 +5,11 @@ package openstack
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -121,21 +124,21 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 
 	// TODO (stickupkid): Move this to the ClientFactory.
 	// We shouldn't have another wrapper around an existing client.
-	cinderCl := cinderClient{cinder.Basic(env.volumeURL, client.TenantId(), client.Token)}
+	handleRequest := cinder.SetAuthHeaderFn(client.Token, http.DefaultClient.Do)
 
 	cloudSpec := env.cloudUnlocked
 	if len(cloudSpec.CACertificates) > 0 {
-		cinderCl = cinderClient{cinder.BasicTLSConfig(
-			env.volumeURL,
-			client.TenantId(),
+		handleRequest = cinder.AuthHeaderTSLConfigDoRequestFn(
 			client.Token,
-			tlsConfig(cloudSpec.CACertificates)),
-		}
+			tlsConfig(cloudSpec.CACertificates),
+		)
 	}
 
 	return &openstackStorageAdapter{
-		cinderCl,
+		cinderClient{cinder.NewClient(client.TenantId(), env.volumeURL, handleRequest)},
 		novaClient{env.novaUnlocked},
+		env.volumeURL,
+		handleRequest,
 	}, nil
 }
 
@@ -693,6 +696,7 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	DeleteVolumeMetadata(volumeId, key string) error
 	ListVolumeAvailabilityZones() ([]cinder.AvailabilityZone, error)
 }
 
@@ -733,6 +737,11 @@ func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, err
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+
+	// volumeURL and handleRequest are used to send the requests
+	// which goose doesn't support.
+	volumeURL     *url.URL
+	handleRequest cinder.RequestHandlerFn
 }
 
 type cinderClient struct {
@@ -778,6 +787,36 @@ func (ga *openstackStorageAdapter) SetVolumeMetadata(volumeId string, metadata m
 	return ga.cinderClient.SetVolumeMetadata(volumeId, metadata)
 }
 
+// DeleteVolumeMetadata is part of the OpenstackStorage interface.
+// goose does not support deleting metadata, so it's sent directly.
+func (ga *openstackStorageAdapter) DeleteVolumeMetadata(volumeId, key string) error {
+	endpoint := *ga.volumeURL
+	if !strings.HasSuffix(endpoint.Path, "/") {
+		endpoint.Path += "/"
+	}
+	urlPath := url.URL{Path: fmt.Sprintf("volumes/%s/metadata/%s", volumeId, key)}
+	req, err := http.NewRequest("DELETE", endpoint.ResolveReference(&urlPath).String(), nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := ga.handleRequest(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return errors.NotFoundf("metadata %q on volume %q", key, volumeId)
+	case http.StatusUnauthorized:
+		return gooseerrors.NewUnauthorisedf(nil, nil, "deleting metadata %q from volume %q", key, volumeId)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	return errors.Errorf("invalid status (%d): %s", resp.StatusCode, body)
+}
+
 // DeleteVolume is part of the OpenstackStorage interface.
 func (ga *openstackStorageAdapter) DeleteVolume(volumeId string) error {
 	if err := ga.cinderClient.DeleteVolume(volumeId); err != nil {
@@ -4,6 +4,12 @@
 package openstack
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -46,6 +52,37 @@ func (s *cinderInternalSuite) TestStorageProviderTypesNotSupported(c *gc.C) {
 	c.Assert(types, gc.HasLen, 0)
 }
 
+func (s *cinderInternalSuite) TestDeleteVolumeMetadata(c *gc.C) {
+	var requests []string
+	status := http.StatusOK
+	volumeURL, err := url.Parse("https://cinder.invalid/v3/tenant-id")
+	c.Assert(err, jc.ErrorIsNil)
+	adapter := &openstackStorageAdapter{
+		volumeURL: volumeURL,
+		handleRequest: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.String())
+			return &http.Response{
+				StatusCode: status,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+
+	err = adapter.DeleteVolumeMetadata("vol-1", "cost centre")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, jc.DeepEquals, []string{
+		"DELETE https://cinder.invalid/v3/tenant-id/volumes/vol-1/metadata/cost%20centre",
+	})
+
+	status = http.StatusNotFound
+	err = adapter.DeleteVolumeMetadata("vol-1", "team")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	status = http.StatusUnauthorized
+	err = adapter.DeleteVolumeMetadata("vol-1", "team")
+	c.Assert(err, jc.Satisfies, IsAuthorisationFailure)
+}
+
 type testAuthClient struct {
 	client.AuthenticatingClient
 	regionEndpoints map[string]identity.ServiceURLs
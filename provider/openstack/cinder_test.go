@@ -851,6 +851,7 @@ type mockAdapter struct {
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	deleteVolumeMetadata  func(string, string) error
 	listAvailabilityZones func() ([]cinder.AvailabilityZone, error)
 }
 
@@ -921,6 +922,14 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) DeleteVolumeMetadata(volumeId, key string) error {
+	ma.MethodCall(ma, "DeleteVolumeMetadata", volumeId, key)
+	if ma.deleteVolumeMetadata != nil {
+		return ma.deleteVolumeMetadata(volumeId, key)
+	}
+	return nil
+}
+
 func (ma *mockAdapter) ListVolumeAvailabilityZones() ([]cinder.AvailabilityZone, error) {
 	ma.MethodCall(ma, "ListAvailabilityZones")
 	if ma.listAvailabilityZones != nil {
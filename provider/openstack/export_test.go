@@ -37,6 +37,8 @@ func InstanceFloatingIP(inst instances.Instance) *string {
 
 var (
 	NovaListAvailabilityZones = &novaListAvailabilityZones
+	NovaServerAction          = &novaServerAction
+	NovaDeleteServerMetadata  = &novaDeleteServerMetadata
 	NewOpenstackStorage       = &newOpenstackStorage
 )
 
@@ -5,6 +5,7 @@ package openstack_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
@@ -236,6 +237,13 @@ func makeMockAdapter() *mockAdapter {
 			}
 			return nil, errors.New("not found")
 		},
+		deleteVolumeMetadata: func(volumeId, key string) error {
+			if volume, ok := volumes[volumeId]; ok {
+				delete(volume.Metadata, key)
+				return nil
+			}
+			return errors.New("not found")
+		},
 	}
 }
 
@@ -769,6 +777,67 @@ func (s *localServerSuite) TestStartInstanceWaitForActiveDetails(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cannot run instance: max duration exceeded: instance .* has status BUILD")
 }
 
+func (s *localServerSuite) patchServerAction(c *gc.C) *[]string {
+	var actions []string
+	s.PatchValue(openstack.NovaServerAction, func(_ client.Client, serverID string, action interface{}) error {
+		data, err := json.Marshal(action)
+		c.Assert(err, jc.ErrorIsNil)
+		actions = append(actions, string(data))
+		if strings.Contains(string(data), "confirmResize") {
+			s.srv.Nova.SetServerStatus("")
+		} else {
+			s.srv.Nova.SetServerStatus(nova.StatusVerifyResize)
+		}
+		return nil
+	})
+	return &actions
+}
+
+func (s *localServerSuite) TestResizeInstance(c *gc.C) {
+	inst, _ := testing.AssertStartInstance(c, s.env, s.callCtx, s.ControllerUUID, "100")
+	defer s.srv.Nova.SetServerStatus("")
+	actions := s.patchServerAction(c)
+
+	resizer, ok := s.env.(environs.InstanceResizer)
+	c.Assert(ok, jc.IsTrue)
+	hc, err := resizer.ResizeInstance(s.callCtx, inst.Id(), constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*hc.Mem, gc.Equals, uint64(4096))
+	c.Assert(*hc.CpuCores, gc.Equals, uint64(2))
+	c.Assert(*actions, jc.DeepEquals, []string{
+		`{"resize":{"flavorRef":"3"}}`,
+		`{"confirmResize":null}`,
+	})
+}
+
+func (s *localServerSuite) TestResizeInstanceSameFlavor(c *gc.C) {
+	inst, hc := testing.AssertStartInstance(c, s.env, s.callCtx, s.ControllerUUID, "100")
+	actions := s.patchServerAction(c)
+
+	resizer := s.env.(environs.InstanceResizer)
+	newHC, err := resizer.ResizeInstance(s.callCtx, inst.Id(), constraints.MustParse("mem=1G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*newHC.Mem, gc.Equals, *hc.Mem)
+	c.Assert(*actions, gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestSuspendAndResumeInstances(c *gc.C) {
+	inst, _ := testing.AssertStartInstance(c, s.env, s.callCtx, s.ControllerUUID, "100")
+	defer s.srv.Nova.SetServerStatus("")
+	actions := s.patchServerAction(c)
+
+	suspender, ok := s.env.(environs.InstanceSuspender)
+	c.Assert(ok, jc.IsTrue)
+	err := suspender.SuspendInstances(s.callCtx, inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = suspender.ResumeInstances(s.callCtx, inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*actions, jc.DeepEquals, []string{
+		`{"os-stop":null}`,
+		`{"os-start":null}`,
+	})
+}
+
 func assertSecurityGroups(c *gc.C, env environs.Environ, expected []string) {
 	neutronClient := openstack.GetNeutronClient(env)
 	groups, err := neutronClient.ListSecurityGroupsV2()
@@ -2850,6 +2919,45 @@ func (s *localServerSuite) TestAdoptResourcesNoStorage(c *gc.C) {
 	s.checkGroupController(c, env, newController)
 }
 
+func (s *localServerSuite) TestRetagResources(c *gc.C) {
+	var deleted []string
+	s.PatchValue(openstack.NovaDeleteServerMetadata, func(_ client.Client, serverID, key string) error {
+		deleted = append(deleted, serverID+":"+key)
+		return nil
+	})
+	err := bootstrapEnv(c, s.env)
+	c.Assert(err, jc.ErrorIsNil)
+	allInstances, err := s.env.AllRunningInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allInstances, gc.HasLen, 1)
+	serverID := string(allInstances[0].Id())
+	err = s.env.(environs.InstanceTagger).TagInstance(s.callCtx, allInstances[0].Id(), map[string]string{"team": "a"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume := addVolume(c, s.env, s.callCtx, s.ControllerUUID, "99/9")
+	_, err = s.storageAdapter.SetVolumeMetadata(volume.VolumeId, map[string]string{"team": "a"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.env.Config().Apply(map[string]interface{}{
+		"resource-tags": "owner=${owner} project=${model}",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.(environs.ResourceRetagger).RetagResources(s.callCtx, tags.TemplateVars{Owner: "bob"}, []string{"team", "site"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(deleted, jc.DeepEquals, []string{serverID + ":team"})
+	v, err := s.storageAdapter.GetVolume(volume.VolumeId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(v.Metadata, jc.DeepEquals, map[string]string{
+		"juju-model-uuid":      coretesting.ModelTag.Id(),
+		"juju-controller-uuid": s.ControllerUUID,
+		"owner":                "bob",
+		"project":              s.env.Config().Name(),
+	})
+}
+
 func addVolume(
 	c *gc.C, env environs.Environ, callCtx context.ProviderCallContext, controllerUUID, name string,
 ) *storage.Volume {
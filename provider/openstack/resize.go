@@ -0,0 +1,147 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	"github.com/juju/utils/v2/arch"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
+	"gopkg.in/goose.v2/nova"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+)
+
+var _ environs.InstanceResizer = (*Environ)(nil)
+
+// resizeTimeout is how long to wait for Nova to resize a server.
+const resizeTimeout = 10 * time.Minute
+
+// novaServerAction sends an action to a server. goose does not
+// support the resize actions, so they're sent directly.
+var novaServerAction = func(c client.Client, serverID string, action interface{}) error {
+	url := fmt.Sprintf("servers/%s/action", serverID)
+	requestData := goosehttp.RequestData{
+		ReqValue:       action,
+		ExpectedStatus: []int{http.StatusAccepted, http.StatusNoContent},
+	}
+	return c.SendRequest(client.POST, "compute", "v2", url, &requestData)
+}
+
+type resizeAction struct {
+	Resize struct {
+		FlavorRef string `json:"flavorRef"`
+	} `json:"resize"`
+}
+
+type confirmResizeAction struct {
+	ConfirmResize *struct{} `json:"confirmResize"`
+}
+
+// ResizeInstance is part of the environs.InstanceResizer interface.
+// Nova stops the server, moves it to the new flavor and starts it
+// again; the resize is then confirmed so that it cannot be reverted.
+func (e *Environ) ResizeInstance(
+	ctx context.ProviderCallContext, id instance.Id, cons constraints.Value,
+) (*instance.HardwareCharacteristics, error) {
+	novaClient := e.nova()
+	server, err := novaClient.GetServer(string(id))
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return nil, errors.Annotatef(err, "getting server %q", id)
+	}
+	flavors, err := novaClient.ListFlavorsDetail()
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return nil, errors.Trace(err)
+	}
+
+	// As in findInstanceSpec, flavors are assumed to support all
+	// architectures; the architecture is a property of the image.
+	var allInstanceTypes []instances.InstanceType
+	for _, flavor := range flavors {
+		if !e.flavorFilter.AcceptFlavor(flavor) {
+			continue
+		}
+		allInstanceTypes = append(allInstanceTypes, instances.InstanceType{
+			Id:       flavor.Id,
+			Name:     flavor.Name,
+			Arches:   arch.AllSupportedArches,
+			Mem:      uint64(flavor.RAM),
+			CpuCores: uint64(flavor.VCPUs),
+			RootDisk: uint64(flavor.Disk * 1024),
+		})
+	}
+	itypes, err := instances.MatchingInstanceTypes(allInstanceTypes, e.cloud().Region, cons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	itype := itypes[0]
+
+	if itype.Id != server.Flavor.Id {
+		logger.Infof("resizing server %q from flavor %s to %s", id, server.Flavor.Name, itype.Name)
+		var resize resizeAction
+		resize.Resize.FlavorRef = itype.Id
+		if err := novaServerAction(e.client(), server.Id, resize); err != nil {
+			handleCredentialError(err, ctx)
+			return nil, errors.Annotatef(err, "resizing server %q", id)
+		}
+		if err := e.waitForResize(server.Id); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := novaServerAction(e.client(), server.Id, confirmResizeAction{}); err != nil {
+			handleCredentialError(err, ctx)
+			return nil, errors.Annotatef(err, "confirming resize of server %q", id)
+		}
+	}
+
+	hc := &instance.HardwareCharacteristics{
+		Mem:      &itype.Mem,
+		CpuCores: &itype.CpuCores,
+	}
+	if server.AvailabilityZone != "" {
+		hc.AvailabilityZone = &server.AvailabilityZone
+	}
+	return hc, nil
+}
+
+// waitForResize waits for Nova to finish resizing the identified server,
+// which then waits for the resize to be confirmed.
+func (e *Environ) waitForResize(serverID string) error {
+	errStillResizing := errors.Errorf("server %q is still resizing", serverID)
+	return retry.Call(retry.CallArgs{
+		Clock:       e.clock,
+		Delay:       10 * time.Second,
+		MaxDuration: resizeTimeout,
+		Func: func() error {
+			server, err := e.nova().GetServer(serverID)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			switch server.Status {
+			case nova.StatusVerifyResize:
+				return nil
+			case nova.StatusError:
+				msg := "unknown error"
+				if server.Fault != nil {
+					msg = server.Fault.Message
+				}
+				return errors.Errorf("server %q failed to resize: %s", serverID, msg)
+			}
+			return errStillResizing
+		},
+		IsFatalError: func(err error) bool {
+			return err != errStillResizing
+		},
+	})
+}
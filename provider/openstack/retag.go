@@ -0,0 +1,94 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/juju/errors"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+)
+
+var _ environs.ResourceRetagger = (*Environ)(nil)
+
+// novaDeleteServerMetadata deletes a metadata item from a server.
+// goose does not support deleting metadata, so it's sent directly.
+var novaDeleteServerMetadata = func(c client.Client, serverID, key string) error {
+	apiCall := fmt.Sprintf("servers/%s/metadata/%s", serverID, url.PathEscape(key))
+	requestData := goosehttp.RequestData{
+		ExpectedStatus: []int{http.StatusNoContent},
+	}
+	return c.SendRequest(client.DELETE, "compute", "v2", apiCall, &requestData)
+}
+
+// RetagResources is part of the environs.ResourceRetagger interface.
+// The model's Cinder volumes are retagged; a volume which isn't Juju
+// storage serves the units deployed to the server it's attached to.
+// Security groups have no metadata, so there's nothing else to retag.
+func (e *Environ) RetagResources(ctx context.ProviderCallContext, vars tags.TemplateVars, removed []string) error {
+	insts, err := e.AllInstances(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	serverVars := make(map[string]tags.TemplateVars)
+	for _, inst := range insts {
+		server := inst.(*openstackInstance).getServerDetail()
+		serverVars[server.Id] = tags.ResourceTemplateVars(server.Metadata)
+		for _, key := range removed {
+			if _, ok := server.Metadata[key]; !ok {
+				continue
+			}
+			if err := novaDeleteServerMetadata(e.client(), server.Id, key); err != nil {
+				handleCredentialError(err, ctx)
+				return errors.Annotatef(err, "deleting tag %q from server %q", key, server.Id)
+			}
+		}
+	}
+
+	cinder, err := e.cinderProvider()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("volumes not supported: not retagging volumes")
+		return nil
+	}
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return errors.Trace(err)
+	}
+	volumes, err := modelCinderVolumes(cinder.storageAdapter, e.uuid)
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return errors.Annotate(err, "listing volumes")
+	}
+	tagger := tags.WithTemplateVars(e.Config(), vars)
+	for _, v := range volumes {
+		for _, key := range removed {
+			if _, ok := v.Metadata[key]; !ok {
+				continue
+			}
+			if err := cinder.storageAdapter.DeleteVolumeMetadata(v.ID, key); err != nil {
+				handleCredentialError(err, ctx)
+				return errors.Annotatef(err, "deleting tag %q from volume %q", key, v.ID)
+			}
+		}
+		volumeVars := tags.ResourceTemplateVars(v.Metadata)
+		if volumeVars == (tags.TemplateVars{}) {
+			for _, att := range v.Attachments {
+				volumeVars = serverVars[att.ServerId]
+			}
+		}
+		resourceTags, _ := tags.ExpandedResourceTags(tags.WithTemplateVars(tagger, volumeVars))
+		if _, err := cinder.storageAdapter.SetVolumeMetadata(v.ID, resourceTags); err != nil {
+			handleCredentialError(err, ctx)
+			return errors.Annotatef(err, "tagging volume %q", v.ID)
+		}
+	}
+	return nil
+}
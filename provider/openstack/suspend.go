@@ -0,0 +1,46 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var _ environs.InstanceSuspender = (*Environ)(nil)
+
+type stopServerAction struct {
+	Stop *struct{} `json:"os-stop"`
+}
+
+type startServerAction struct {
+	Start *struct{} `json:"os-start"`
+}
+
+// SuspendInstances is part of the environs.InstanceSuspender interface.
+// Nova shuts the servers down, keeping their volumes, ports and floating
+// IPs.
+func (e *Environ) SuspendInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	for _, id := range ids {
+		if err := novaServerAction(e.client(), string(id), stopServerAction{}); err != nil {
+			handleCredentialError(err, ctx)
+			return errors.Annotatef(err, "stopping server %q", id)
+		}
+	}
+	return nil
+}
+
+// ResumeInstances is part of the environs.InstanceSuspender interface.
+func (e *Environ) ResumeInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	for _, id := range ids {
+		if err := novaServerAction(e.client(), string(id), startServerAction{}); err != nil {
+			handleCredentialError(err, ctx)
+			return errors.Annotatef(err, "starting server %q", id)
+		}
+	}
+	return nil
+}
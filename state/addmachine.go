@@ -84,6 +84,10 @@ type MachineTemplate struct {
 	// with the machine.
 	Placement string
 
+	// SSHProxyJump holds the "[user@]host" of the SSH jump host through
+	// which a manually provisioned machine is reached.
+	SSHProxyJump string
+
 	// principals holds the principal units that will
 	// associated with the machine.
 	principals []string
@@ -225,6 +229,11 @@ func (st *State) effectiveMachineTemplate(p MachineTemplate, allowController boo
 	} else if p.Nonce != "" {
 		return tmpl, errors.New("cannot specify a nonce without an instance id")
 	}
+	if p.SSHProxyJump != "" {
+		if err := network.ValidateSSHProxyJump(p.SSHProxyJump); err != nil {
+			return tmpl, errors.Trace(err)
+		}
+	}
 
 	// We ignore all constraints if there's a placement directive.
 	if p.Placement == "" {
@@ -513,6 +522,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPrivateAddress: fromNetworkAddress(privateAddr, network.OriginMachine),
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, network.OriginMachine),
 		Placement:               template.Placement,
+		SSHProxyJump:            template.SSHProxyJump,
 	}
 }
 
@@ -480,6 +480,18 @@ func allCollections() CollectionSchema {
 				Key: []string{"-updated"},
 			}},
 		},
+		hookHistoryC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "-started"},
+			}, {
+				// used for model-specific pruning
+				Key: []string{"model-uuid", "-started", "-_id"},
+			}, {
+				// used for global pruning (after size check)
+				Key: []string{"-started"},
+			}},
+		},
 
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
@@ -612,6 +624,7 @@ const (
 	spacesC                    = "spaces"
 	statusesC                  = "statuses"
 	statusesHistoryC           = "statuseshistory"
+	hookHistoryC               = "hookhistory"
 	storageAttachmentsC        = "storageattachments"
 	storageConstraintsC        = "storageconstraints"
 	deviceConstraintsC         = "deviceConstraints"
@@ -0,0 +1,185 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/storage"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// ApplicationCheck holds the outcome of one of the checks made on the
+// arguments to AddApplication.
+type ApplicationCheck struct {
+	// Name identifies what was checked, such as "storage" or
+	// "placement".
+	Name string
+
+	// Err holds the reason the check failed, or nil if it passed.
+	Err error
+}
+
+// applicationCheck is one of the checks made on the arguments to
+// AddApplication before the application is added.
+type applicationCheck struct {
+	name string
+	run  func() error
+}
+
+// ValidateAddApplication makes the same checks on args as AddApplication,
+// without adding the application. Unlike AddApplication, all of the checks
+// are run rather than stopping at the first failure. One result is
+// returned for each kind of check, holding its first failure if any.
+func (st *State) ValidateAddApplication(args AddApplicationArgs) ([]ApplicationCheck, error) {
+	if args.Charm == nil {
+		return nil, errors.Errorf("charm is nil")
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var results []ApplicationCheck
+	index := make(map[string]int)
+	record := func(name string, err error) {
+		i, ok := index[name]
+		if !ok {
+			index[name] = len(results)
+			results = append(results, ApplicationCheck{Name: name, Err: err})
+			return
+		}
+		if results[i].Err == nil {
+			results[i].Err = err
+		}
+	}
+	for _, check := range st.addApplicationChecks(model, &args) {
+		record(check.name, check.run())
+	}
+	record("endpoint-bindings", st.validateApplicationBindings(args))
+	return results, nil
+}
+
+// addApplicationChecks returns the checks made on args by AddApplication,
+// in the order they are run. Some of the checks fill in defaults in args
+// that later checks, and AddApplication itself, depend on.
+func (st *State) addApplicationChecks(model *Model, args *AddApplicationArgs) []applicationCheck {
+	return []applicationCheck{{
+		name: "application",
+		run: func() error {
+			if !names.IsValidApplication(args.Name) {
+				return errors.Errorf("invalid name")
+			}
+			if args.Charm == nil {
+				return errors.Errorf("charm is nil")
+			}
+			return nil
+		},
+	}, {
+		name: "series",
+		run: func() error {
+			return validateCharmSeries(model.Type(), args.Series, args.Charm)
+		},
+	}, {
+		name: "storage",
+		run: func() error {
+			// CAAS charms don't support volume/block storage yet.
+			if model.Type() == ModelTypeCAAS {
+				for name, charmStorage := range args.Charm.Meta().Storage {
+					if storageKind(charmStorage.Type) != storage.StorageKindBlock {
+						continue
+					}
+					var count uint64
+					if arg, ok := args.Storage[name]; ok {
+						count = arg.Count
+					}
+					if charmStorage.CountMin > 0 || count > 0 {
+						return errors.NotSupportedf("block storage on a Kubernetes model")
+					}
+				}
+			}
+			if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
+				return errors.Errorf("AttachStorage is non-empty but NumUnits is %d, must be 1", args.NumUnits)
+			}
+			return nil
+		},
+	}, {
+		name: "charm",
+		run: func() error {
+			return jujuversion.CheckJujuMinVersion(args.Charm.Meta().MinJujuVersion, jujuversion.Current)
+		},
+	}, {
+		name: "application",
+		run: func() error {
+			if exists, err := isNotDead(st, applicationsC, args.Name); err != nil {
+				return errors.Trace(err)
+			} else if exists {
+				return errors.Errorf("application already exists")
+			}
+			return checkModelActive(st)
+		},
+	}, {
+		name: "storage",
+		run: func() error {
+			if args.Storage == nil {
+				args.Storage = make(map[string]StorageConstraints)
+			}
+			sb, err := NewStorageBackend(st)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := addDefaultStorageConstraints(sb, args.Storage, args.Charm.Meta()); err != nil {
+				return errors.Trace(err)
+			}
+			return validateStorageConstraints(sb, args.Storage, args.Charm.Meta())
+		},
+	}, {
+		name: "devices",
+		run: func() error {
+			if args.Devices == nil {
+				args.Devices = make(map[string]DeviceConstraints)
+			}
+			deviceb, err := NewDeviceBackend(st)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return validateDeviceConstraints(deviceb, args.Devices, args.Charm.Meta())
+		},
+	}, {
+		name: "series",
+		run: func() error {
+			return st.processApplicationSeries(args)
+		},
+	}, {
+		name: "constraints",
+		run: func() error {
+			return st.validateApplicationConstraints(args)
+		},
+	}, {
+		name: "placement",
+		run: func() error {
+			if model.Type() == ModelTypeCAAS {
+				return st.checkCAASApplicationPlacement(args)
+			}
+			return st.checkIAASApplicationPlacement(args)
+		},
+	}}
+}
+
+// validateApplicationBindings checks that the endpoint bindings in args
+// are valid for the charm, as AddApplication does when it creates them.
+func (st *State) validateApplicationBindings(args AddApplicationArgs) error {
+	app := newApplication(st, &applicationDoc{
+		DocID:     st.docID(args.Name),
+		Name:      args.Name,
+		ModelUUID: st.ModelUUID(),
+	})
+	b, err := app.bindingsForOps(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = b.createOp(args.EndpointBindings, args.Charm.Meta())
+	return errors.Trace(err)
+}
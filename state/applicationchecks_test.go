@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ApplicationChecksSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ApplicationChecksSuite{})
+
+func checkNames(checks []state.ApplicationCheck) []string {
+	result := make([]string, len(checks))
+	for i, check := range checks {
+		result[i] = check.Name
+	}
+	return result
+}
+
+func failedChecks(checks []state.ApplicationCheck) map[string]string {
+	result := make(map[string]string)
+	for _, check := range checks {
+		if check.Err != nil {
+			result[check.Name] = check.Err.Error()
+		}
+	}
+	return result
+}
+
+func (s *ApplicationChecksSuite) TestValidateAddApplication(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	checks, err := s.State.ValidateAddApplication(state.AddApplicationArgs{
+		Name:  "wordpress",
+		Charm: charm,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checkNames(checks), jc.DeepEquals, []string{
+		"application", "series", "storage", "charm", "devices",
+		"constraints", "placement", "endpoint-bindings",
+	})
+	c.Assert(failedChecks(checks), gc.HasLen, 0)
+
+	// Nothing is added.
+	_, err = s.State.Application("wordpress")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationChecksSuite) TestValidateAddApplicationReportsAllFailures(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	s.AddTestingApplication(c, "wordpress", charm)
+
+	checks, err := s.State.ValidateAddApplication(state.AddApplicationArgs{
+		Name:             "wordpress",
+		Charm:            charm,
+		Series:           "centos7",
+		EndpointBindings: map[string]string{"extra": ""},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failedChecks(checks), jc.DeepEquals, map[string]string{
+		"application":       "application already exists",
+		"series":            `series "centos7" (OS "CentOS") not supported by charm, supported series are "quantal"`,
+		"endpoint-bindings": `unknown endpoint "extra" not valid`,
+	})
+}
+
+func (s *ApplicationChecksSuite) TestValidateAddApplicationStorage(c *gc.C) {
+	charm := s.AddTestingCharm(c, "storage-block")
+	checks, err := s.State.ValidateAddApplication(state.AddApplicationArgs{
+		Name:  "storage-block",
+		Charm: charm,
+		Storage: map[string]state.StorageConstraints{
+			"data": {Pool: "nonexistent", Count: 1, Size: 1024},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	failed := failedChecks(checks)
+	c.Assert(failed, gc.HasLen, 1)
+	c.Assert(failed["storage"], gc.Matches, `.*pool "nonexistent" not found`)
+}
+
+func (s *ApplicationChecksSuite) TestValidateAddApplicationNilCharm(c *gc.C) {
+	_, err := s.State.ValidateAddApplication(state.AddApplicationArgs{Name: "wordpress"})
+	c.Assert(err, gc.ErrorMatches, "charm is nil")
+}
@@ -767,6 +767,32 @@ func (st *State) Charm(curl *charm.URL) (*Charm, error) {
 	return newCharm(st, cdoc), nil
 }
 
+// UnstoredCharm returns the charm described by info without adding it
+// to the model. It is used to validate a deployment of a charm that has
+// not been added, with ValidateAddApplication; no application can be
+// added with it.
+func (st *State) UnstoredCharm(info CharmInfo) (*Charm, error) {
+	if info.ID == nil {
+		return nil, errors.New("*charm.URL was nil")
+	}
+	if err := info.Charm.Meta().Check(); err != nil {
+		return nil, errors.Annotatef(err, "malformed charm metadata")
+	}
+	cdoc := &charmDoc{
+		DocID:        info.ID.String(),
+		URL:          info.ID,
+		CharmVersion: info.Version,
+		Meta:         info.Charm.Meta(),
+		Config:       safeConfig(info.Charm),
+		Metrics:      info.Charm.Metrics(),
+		Actions:      info.Charm.Actions(),
+	}
+	if lpc, ok := info.Charm.(charm.LXDProfiler); ok {
+		cdoc.LXDProfile = safeLXDProfile(lpc.LXDProfile())
+	}
+	return newCharm(st, cdoc), nil
+}
+
 // LatestPlaceholderCharm returns the latest charm described by the
 // given URL but which is not yet deployed.
 func (st *State) LatestPlaceholderCharm(curl *charm.URL) (*Charm, error) {
@@ -289,6 +289,19 @@ func (s *CharmSuite) TestAddCharm(c *gc.C) {
 	c.Assert(doc.CharmVersion, gc.Equals, expVersion)
 }
 
+func (s *CharmSuite) TestUnstoredCharm(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	dummy, err := s.State.UnstoredCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.URL(), gc.DeepEquals, info.ID)
+	c.Assert(dummy.Meta(), jc.DeepEquals, info.Charm.Meta())
+	c.Assert(dummy.Config(), jc.DeepEquals, info.Charm.Config())
+
+	// The charm is not added to the model.
+	_, err = s.State.Charm(info.ID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *CharmSuite) TestAddCharmWithAuth(c *gc.C) {
 	// Check that adding charms from scratch works correctly.
 	info := s.dummyCharm(c, "")
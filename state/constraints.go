@@ -32,6 +32,8 @@ type constraintsDoc struct {
 	VirtType         *string
 	Zones            *[]string
 	AllocatePublicIP *bool
+	Spot             *bool
+	MaxPrice         *string
 }
 
 func newConstraintsDoc(cons constraints.Value, id string) constraintsDoc {
@@ -50,6 +52,8 @@ func newConstraintsDoc(cons constraints.Value, id string) constraintsDoc {
 		VirtType:         cons.VirtType,
 		Zones:            cons.Zones,
 		AllocatePublicIP: cons.AllocatePublicIP,
+		Spot:             cons.Spot,
+		MaxPrice:         cons.MaxPrice,
 	}
 	return result
 }
@@ -69,6 +73,8 @@ func (doc constraintsDoc) value() constraints.Value {
 		VirtType:         doc.VirtType,
 		Zones:            doc.Zones,
 		AllocatePublicIP: doc.AllocatePublicIP,
+		Spot:             doc.Spot,
+		MaxPrice:         doc.MaxPrice,
 	}
 	return result
 }
@@ -0,0 +1,145 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+)
+
+// HookRecord describes a single execution of a charm hook on a unit.
+type HookRecord struct {
+	// HookName is the name of the hook that was run.
+	HookName string
+
+	// Relation identifies the relation the hook was run for, if any.
+	Relation string
+
+	// RemoteUnit is the name of the remote unit that triggered the
+	// hook, if any.
+	RemoteUnit string
+
+	// Started is the time the hook started running.
+	Started time.Time
+
+	// Duration is how long the hook ran for.
+	Duration time.Duration
+
+	// ExitCode is the exit code of the hook process.
+	ExitCode int
+
+	// Output holds the combined stdout and stderr of the hook,
+	// truncated to status.MaxHookOutputSize bytes.
+	Output string
+}
+
+type hookHistoryDoc struct {
+	ModelUUID  string `bson:"model-uuid"`
+	Unit       string `bson:"unit"`
+	HookName   string `bson:"hook"`
+	Relation   string `bson:"relation,omitempty"`
+	RemoteUnit string `bson:"remoteunit,omitempty"`
+	Started    int64  `bson:"started"`
+	Duration   int64  `bson:"duration"`
+	ExitCode   int    `bson:"exitcode"`
+	Output     string `bson:"output,omitempty"`
+}
+
+func (doc *hookHistoryDoc) record() HookRecord {
+	return HookRecord{
+		HookName:   doc.HookName,
+		Relation:   doc.Relation,
+		RemoteUnit: doc.RemoteUnit,
+		Started:    time.Unix(0, doc.Started),
+		Duration:   time.Duration(doc.Duration),
+		ExitCode:   doc.ExitCode,
+		Output:     doc.Output,
+	}
+}
+
+// AddHookRecord records the execution of a hook on the unit.
+func (u *Unit) AddHookRecord(rec HookRecord) error {
+	if rec.HookName == "" {
+		return errors.NotValidf("empty hook name")
+	}
+	history, closer := u.st.db().GetCollection(hookHistoryC)
+	defer closer()
+
+	doc := &hookHistoryDoc{
+		Unit:       u.Name(),
+		HookName:   rec.HookName,
+		Relation:   rec.Relation,
+		RemoteUnit: rec.RemoteUnit,
+		Started:    rec.Started.UnixNano(),
+		Duration:   int64(rec.Duration),
+		ExitCode:   rec.ExitCode,
+		Output:     status.TruncateHookOutput(rec.Output),
+	}
+	if err := history.Writeable().Insert(doc); err != nil {
+		return errors.Annotatef(err, "cannot record hook %q for unit %q", rec.HookName, u.Name())
+	}
+	return nil
+}
+
+// HookHistory returns the most recent hook executions recorded for the
+// unit, newest first. If size is greater than zero, at most that many
+// records are returned.
+func (u *Unit) HookHistory(size int) ([]HookRecord, error) {
+	history, closer := u.st.db().GetCollection(hookHistoryC)
+	defer closer()
+
+	query := history.Find(bson.D{{"unit", u.Name()}}).Sort("-started")
+	if size > 0 {
+		query = query.Limit(size)
+	}
+	var docs []hookHistoryDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u.Name())
+	}
+	records := make([]HookRecord, len(docs))
+	for i, doc := range docs {
+		records[i] = doc.record()
+	}
+	return records, nil
+}
+
+// eraseHookHistory removes all hook history documents for the
+// named unit.
+func eraseHookHistory(mb modelBackend, unitName string) error {
+	history, closer := mb.db().GetCollection(hookHistoryC)
+	defer closer()
+
+	iter := history.Find(bson.D{{
+		"unit", unitName,
+	}}).Select(bson.M{"_id": 1}).Iter()
+	defer iter.Close()
+
+	logFormat := "deleted %d hook history documents for " + fmt.Sprintf("%q", unitName)
+	deleted, err := deleteInBatches(
+		history.Writeable().Underlying(), nil, "", iter,
+		logFormat, loggo.DEBUG,
+		noEarlyFinish,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if deleted > 0 {
+		logger.Debugf(logFormat, deleted)
+	}
+	return nil
+}
+
+// PruneHookHistory removes hook history entries until only the ones
+// newer than now - maxHistoryTime remain and the history is smaller
+// than maxHistoryMB.
+func PruneHookHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, hookHistoryC, "started", nil, NanoSeconds)
+	return errors.Trace(err)
+}
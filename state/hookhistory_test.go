@@ -0,0 +1,141 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type HookHistorySuite struct {
+	statetesting.StateSuite
+	application *state.Application
+	unit        *state.Unit
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.InitialTime = time.Now()
+	s.StateSuite.SetUpTest(c)
+	s.application = s.Factory.MakeApplication(c, nil)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.application})
+}
+
+func (s *HookHistorySuite) addRecords(c *gc.C, unit *state.Unit, count int, age time.Duration) {
+	start := s.Clock.Now().Add(-age)
+	for i := 0; i < count; i++ {
+		err := unit.AddHookRecord(state.HookRecord{
+			HookName: "config-changed",
+			Started:  start.Add(time.Duration(i) * time.Second),
+			Duration: time.Second,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *HookHistorySuite) TestAddHookRecord(c *gc.C) {
+	started := s.Clock.Now().Round(time.Second)
+	err := s.unit.AddHookRecord(state.HookRecord{
+		HookName:   "db-relation-changed",
+		Relation:   "db:2",
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Duration:   3 * time.Second,
+		ExitCode:   1,
+		Output:     "boom",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Started.Equal(started), jc.IsTrue)
+	history[0].Started = started
+	c.Assert(history[0], jc.DeepEquals, state.HookRecord{
+		HookName:   "db-relation-changed",
+		Relation:   "db:2",
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Duration:   3 * time.Second,
+		ExitCode:   1,
+		Output:     "boom",
+	})
+}
+
+func (s *HookHistorySuite) TestAddHookRecordEmptyHookName(c *gc.C) {
+	err := s.unit.AddHookRecord(state.HookRecord{})
+	c.Assert(err, gc.ErrorMatches, "empty hook name not valid")
+}
+
+func (s *HookHistorySuite) TestAddHookRecordTruncatesOutput(c *gc.C) {
+	output := strings.Repeat("x", status.MaxHookOutputSize) + "the end"
+	err := s.unit.AddHookRecord(state.HookRecord{
+		HookName: "install",
+		Started:  s.Clock.Now(),
+		Output:   output,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Output, gc.HasLen, status.MaxHookOutputSize)
+	c.Assert(strings.HasSuffix(history[0].Output, "the end"), jc.IsTrue)
+}
+
+func (s *HookHistorySuite) TestHookHistoryNewestFirst(c *gc.C) {
+	s.addRecords(c, s.unit, 5, time.Hour)
+
+	history, err := s.unit.HookHistory(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	for i := 1; i < len(history); i++ {
+		c.Assert(history[i].Started.Before(history[i-1].Started), jc.IsTrue)
+	}
+}
+
+func (s *HookHistorySuite) TestHookHistoryPerUnit(c *gc.C) {
+	other := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.application})
+	s.addRecords(c, s.unit, 2, time.Hour)
+	s.addRecords(c, other, 3, time.Hour)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	history, err = other.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+}
+
+func (s *HookHistorySuite) TestPruneHookHistoryByDate(c *gc.C) {
+	s.addRecords(c, s.unit, 10, 0)
+	s.addRecords(c, s.unit, 10, 24*time.Hour)
+
+	err := state.PruneHookHistory(s.State, 10*time.Hour, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 10)
+}
+
+func (s *HookHistorySuite) TestDestroyUnitErasesHookHistory(c *gc.C) {
+	s.addRecords(c, s.unit, 3, 0)
+
+	err := s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
@@ -138,6 +138,14 @@ type machineDoc struct {
 	// an instance for the machine.
 	Placement string `bson:",omitempty"`
 
+	// SSHProxyJump is the "[user@]host" of the SSH jump host through
+	// which a manually provisioned machine is reached.
+	SSHProxyJump string `bson:"ssh-proxy-jump,omitempty"`
+
+	// Resizes counts the resizes of the machine's instance, so that
+	// the agents of its units are told of them.
+	Resizes int `bson:"resizes,omitempty"`
+
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
@@ -1757,6 +1765,12 @@ func (m *Machine) Placement() string {
 	return m.doc.Placement
 }
 
+// SSHProxyJump returns the "[user@]host" of the SSH jump host through
+// which the machine is reached, or "" if it is reached directly.
+func (m *Machine) SSHProxyJump() string {
+	return m.doc.SSHProxyJump
+}
+
 // Constraints returns the exact constraints that should apply when provisioning
 // an instance for the machine.
 func (m *Machine) Constraints() (constraints.Value, error) {
@@ -1806,6 +1820,115 @@ func (m *Machine) setConstraintsOps(cons constraints.Value) ([]txn.Op, error) {
 	return ops, nil
 }
 
+// ResizeConstraints returns the constraints to resize the machine's
+// instance with: the machine's constraints, overridden by the given ones.
+func (m *Machine) ResizeConstraints(cons constraints.Value) (constraints.Value, error) {
+	validator, err := m.st.constraintsValidator()
+	if err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	if _, err := validator.Validate(cons); err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	mcons, err := m.Constraints()
+	if err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	return validator.Merge(mcons, cons)
+}
+
+// SetResized records that the machine's instance has been resized to
+// satisfy the given constraints, and now has the given hardware. Unlike
+// SetConstraints, it is only valid for provisioned machines.
+func (m *Machine) SetResized(cons constraints.Value, hc instance.HardwareCharacteristics) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life != Alive {
+			return nil, machineNotAliveErr
+		}
+		instData, err := getInstanceData(m.st, m.Id())
+		if errors.IsNotFound(err) {
+			return nil, errors.NotProvisionedf("machine %v", m.Id())
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		var set bson.D
+		if hc.Arch != nil {
+			set = append(set, bson.DocElem{"arch", *hc.Arch})
+		}
+		if hc.Mem != nil {
+			set = append(set, bson.DocElem{"mem", *hc.Mem})
+		}
+		if hc.RootDisk != nil {
+			set = append(set, bson.DocElem{"rootdisk", *hc.RootDisk})
+		}
+		if hc.CpuCores != nil {
+			set = append(set, bson.DocElem{"cpucores", *hc.CpuCores})
+		}
+		if hc.CpuPower != nil {
+			set = append(set, bson.DocElem{"cpupower", *hc.CpuPower})
+		}
+		if hc.AvailabilityZone != nil {
+			set = append(set, bson.DocElem{"availzone", *hc.AvailabilityZone})
+		}
+		ops := []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"resizes", 1}}}},
+		}, setConstraintsOp(m.globalKey(), cons)}
+		instOp := txn.Op{
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"instanceid", instData.InstanceId}},
+		}
+		if len(set) > 0 {
+			instOp.Update = bson.D{{"$set", set}}
+		}
+		return append(ops, instOp), nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot record resize of machine %v", m)
+}
+
+// SetAvailabilityZone records that the machine's instance has been moved
+// to the given availability zone.
+func (m *Machine) SetAvailabilityZone(zone string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life != Alive {
+			return nil, machineNotAliveErr
+		}
+		instData, err := getInstanceData(m.st, m.Id())
+		if errors.IsNotFound(err) {
+			return nil, errors.NotProvisionedf("machine %v", m.Id())
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"instanceid", instData.InstanceId}},
+			Update: bson.D{{"$set", bson.D{{"availzone", zone}}}},
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot record availability zone of machine %v", m)
+}
+
 // Status returns the status of the machine.
 func (m *Machine) Status() (status.StatusInfo, error) {
 	mStatus, err := getStatus(m.st.db(), m.globalKey(), "machine")
@@ -1834,6 +1957,13 @@ func (m *Machine) SetStatus(statusInfo status.StatusInfo) error {
 		fallthrough
 	case status.Down:
 		return errors.Errorf("cannot set status %q", statusInfo.Status)
+	case status.Suspended:
+		// Only a provisioned machine has an instance to suspend.
+		if _, err := m.InstanceId(); errors.IsNotProvisioned(err) {
+			return errors.Errorf("cannot set status %q on unprovisioned machine", statusInfo.Status)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
 	default:
 		return errors.Errorf("cannot set invalid status %q", statusInfo.Status)
 	}
@@ -1716,6 +1716,80 @@ func (s *MachineSuite) TestConstraintsLifecycle(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `constraints not found`)
 }
 
+func (s *MachineSuite) TestResizeConstraints(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetConstraints(constraints.MustParse("mem=1G cores=1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons, err := machine.ResizeConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, gc.DeepEquals, constraints.MustParse("mem=4G cores=1"))
+}
+
+func (s *MachineSuite) TestSetResized(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	instArch := "amd64"
+	mem := uint64(1024)
+	disk := uint64(8192)
+	err = machine.SetProvisioned("i-am", "", "fake_nonce", &instance.HardwareCharacteristics{
+		Arch:     &instArch,
+		Mem:      &mem,
+		RootDisk: &disk,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("mem=4G cores=2")
+	newMem := uint64(4096)
+	cores := uint64(2)
+	err = machine.SetResized(cons, instance.HardwareCharacteristics{
+		Mem:      &newMem,
+		CpuCores: &cores,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	mcons, err := machine.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mcons, gc.DeepEquals, cons)
+	hc, err := machine.HardwareCharacteristics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hc.String(), gc.Equals, "arch=amd64 cores=2 mem=4096M root-disk=8192M")
+}
+
+func (s *MachineSuite) TestSetResizedNotProvisioned(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetResized(constraints.MustParse("mem=4G"), instance.HardwareCharacteristics{})
+	c.Assert(err, gc.ErrorMatches, `cannot record resize of machine 2: machine 2 not provisioned`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *MachineSuite) TestSetAvailabilityZone(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	zone := "node01"
+	err = machine.SetProvisioned("i-am", "", "fake_nonce", &instance.HardwareCharacteristics{
+		AvailabilityZone: &zone,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetAvailabilityZone("node02")
+	c.Assert(err, jc.ErrorIsNil)
+
+	hc, err := machine.HardwareCharacteristics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*hc.AvailabilityZone, gc.Equals, "node02")
+}
+
+func (s *MachineSuite) TestSetAvailabilityZoneNotProvisioned(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetAvailabilityZone("node02")
+	c.Assert(err, gc.ErrorMatches, `cannot record availability zone of machine 2: machine 2 not provisioned`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotProvisioned)
+}
+
 func (s *MachineSuite) TestSetProviderAddresses(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -472,6 +472,12 @@ func (e *exporter) newMachine(exParent description.Machine, machine *Machine, in
 		args.Jobs = append(args.Jobs, job.MigrationValue())
 	}
 
+	// The description package has no SSH jump host, so it is dropped;
+	// the target controller connects to the machine directly.
+	if machine.doc.SSHProxyJump != "" {
+		e.logger.Warningf("SSH jump host %q for machine %q is not migrated", machine.doc.SSHProxyJump, machine.Id())
+	}
+
 	// A null value means that we don't yet know which containers
 	// are supported. An empty slice means 'no containers are supported'.
 	var exMachine description.Machine
@@ -2164,6 +2170,12 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 	if optionalErr != nil {
 		return description.ConstraintsArgs{}, errors.Trace(optionalErr)
 	}
+	// The description package has no spot or max-price constraints, so
+	// they are dropped; the machines keep running, but new instances
+	// for them are not requested as spot instances.
+	if doc["spot"] != nil || doc["maxprice"] != nil {
+		e.logger.Warningf("spot and max-price constraints for %q are not migrated", globalKey)
+	}
 	return result, nil
 }
 
@@ -123,6 +123,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		usermodelnameC,
 		// Metrics aren't migrated.
 		metricsC,
+		// Hook history is diagnostic only and isn't migrated.
+		hookHistoryC,
 		// Backup and restore information is not migrated.
 		restoreInfoC,
 		// reference counts are implementation details that should be
@@ -251,6 +253,9 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		// ForceDestroyed is only relevant for models that are being
 		// removed.
 		"ForceDestroyed",
+		// SuspendRequested is a request to the source controller's
+		// model suspender and is not migrated.
+		"SuspendRequested",
 		// ControllerUUID is recreated when the new model is created
 		// in the new controller (yay name changes).
 		"ControllerUUID",
@@ -328,6 +333,12 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"StopMongoUntilVersion",
 		// Ignored; it gets populated on demand when the agent restarts
 		"AgentStartedAt",
+		// Not yet supported by the description package; the export
+		// warns that it is dropped.
+		"SSHProxyJump",
+		// Resizes only tells the agents of units on the machine of
+		// resizes, which have already been seen by the source model.
+		"Resizes",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -428,6 +439,11 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Application",
 		// Resolved is not migrated as we check that all is good before we start.
 		"Resolved",
+		// HookKillVersion only has meaning to the running unit agent.
+		"HookKillVersion",
+		// SuspendStatus is only set while the model is being
+		// suspended or resumed.
+		"SuspendStatus",
 		// Series and CharmURL also come from the application.
 		"Series",
 		"CharmURL",
@@ -566,7 +582,13 @@ func (s *MigrationSuite) TestSequenceDocFields(c *gc.C) {
 }
 
 func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
-	fields := set.NewStrings(
+	ignored := set.NewStrings(
+		// Not yet supported by the description package. The export
+		// logs a warning when a model has either of them.
+		"Spot",
+		"MaxPrice",
+	)
+	migrated := set.NewStrings(
 		// ModelUUID shouldn't be exported, and is inherited
 		// from the model definition.
 		"ModelUUID",
@@ -585,7 +607,7 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Zones",
 		"AllocatePublicIP",
 	)
-	s.AssertExportedFields(c, constraintsDoc{}, fields)
+	s.AssertExportedFields(c, constraintsDoc{}, migrated.Union(ignored))
 }
 
 func (s *MigrationSuite) TestHistoricalStatusDocFields(c *gc.C) {
@@ -124,6 +124,11 @@ type modelDoc struct {
 	// this model. It only has any meaning when the model is dying or
 	// dead.
 	ForceDestroyed bool `bson:"force-destroyed,omitempty"`
+
+	// SuspendRequested is whether the model's machines should be
+	// powered down, either on request or by the model's suspend
+	// schedule.
+	SuspendRequested bool `bson:"suspend-requested,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -698,6 +703,31 @@ func (m *Model) ForceDestroyed() bool {
 	return m.doc.ForceDestroyed
 }
 
+// SuspendRequested returns whether the model's machines should be
+// suspended.
+func (m *Model) SuspendRequested() bool {
+	return m.doc.SuspendRequested
+}
+
+// SetSuspendRequested records whether the model's machines should be
+// suspended or resumed. The model suspender worker does the work of
+// powering machines off and on.
+func (m *Model) SetSuspendRequested(suspend bool) error {
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"suspend-requested", suspend}}}},
+	}}
+	err := m.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot set suspend requested on model %q: model is no longer alive", m)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return m.Refresh()
+}
+
 // Owner returns tag representing the owner of the model.
 // The owner is the user that created the model.
 func (m *Model) Owner() names.UserTag {
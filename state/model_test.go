@@ -457,6 +457,24 @@ func (s *ModelSuite) TestSLA(c *gc.C) {
 	c.Assert(slaCreds, gc.DeepEquals, []byte("auth advanced"))
 }
 
+func (s *ModelSuite) TestSetSuspendRequested(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.SuspendRequested(), jc.IsFalse)
+
+	err = model.SetSuspendRequested(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.SuspendRequested(), jc.IsTrue)
+
+	model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.SuspendRequested(), jc.IsTrue)
+
+	err = model.SetSuspendRequested(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.SuspendRequested(), jc.IsFalse)
+}
+
 func (s *ModelSuite) TestMeterStatus(c *gc.C) {
 	cfg, _ := s.createTestModelConfig(c)
 	owner := names.NewUserTag("test@remote")
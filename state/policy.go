@@ -56,6 +56,17 @@ func (st *State) precheckInstance(
 	placement string,
 	volumeAttachments []storage.VolumeAttachmentParams,
 ) error {
+	return st.precheckInstances(environs.PrecheckInstanceParams{
+		Series:            series,
+		Constraints:       cons,
+		Placement:         placement,
+		VolumeAttachments: volumeAttachments,
+	})
+}
+
+// precheckInstances is like precheckInstance, but takes the complete
+// parameters so that callers can specify how many instances are started.
+func (st *State) precheckInstances(params environs.PrecheckInstanceParams) error {
 	if st.policy == nil {
 		return nil
 	}
@@ -68,14 +79,7 @@ func (st *State) precheckInstance(
 	if prechecker == nil {
 		return errors.New("policy returned nil prechecker without an error")
 	}
-	return prechecker.PrecheckInstance(
-		context.CallContext(st),
-		environs.PrecheckInstanceParams{
-			Series:            series,
-			Constraints:       cons,
-			Placement:         placement,
-			VolumeAttachments: volumeAttachments,
-		})
+	return prechecker.PrecheckInstance(context.CallContext(st), params)
 }
 
 func (st *State) constraintsValidator() (constraints.Validator, error) {
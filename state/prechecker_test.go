@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testing/factory"
 )
 
 type PrecheckerSuite struct {
@@ -314,3 +315,27 @@ func (s *PrecheckerSuite) TestPrecheckAddApplicationMixedPlacement(c *gc.C) {
 		Placement: "somewhere",
 	})
 }
+
+func (s *PrecheckerSuite) TestPrecheckAddApplicationCAAS(c *gc.C) {
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer st.Close()
+	err := st.SetModelConstraints(constraints.MustParse("mem=1G"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.prechecker.precheckInstanceError = errors.Errorf("over quota")
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	_, err = st.AddApplication(state.AddApplicationArgs{
+		Name:        "gitlab",
+		Series:      "kubernetes",
+		Charm:       ch,
+		NumUnits:    3,
+		Constraints: constraints.MustParse("cpu-power=500"),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "gitlab": over quota`)
+	c.Assert(s.prechecker.precheckInstanceArgs, jc.DeepEquals, environs.PrecheckInstanceParams{
+		Series:      "kubernetes",
+		Constraints: constraints.MustParse("cpu-power=500 mem=1G"),
+		NumUnits:    3,
+	})
+}
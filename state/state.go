@@ -41,6 +41,7 @@ import (
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	stateerrors "github.com/juju/juju/state/errors"
@@ -1029,103 +1030,24 @@ type AddApplicationArgs struct {
 func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add application %q", args.Name)
 
-	// Sanity checks.
-	if !names.IsValidApplication(args.Name) {
-		return nil, errors.Errorf("invalid name")
-	}
-	if args.Charm == nil {
-		return nil, errors.Errorf("charm is nil")
-	}
-
 	model, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	if err := validateCharmSeries(model.Type(), args.Series, args.Charm); err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	// CAAS charms don't support volume/block storage yet.
-	if model.Type() == ModelTypeCAAS {
-		for name, charmStorage := range args.Charm.Meta().Storage {
-			if storageKind(charmStorage.Type) != storage.StorageKindBlock {
-				continue
-			}
-			var count uint64
-			if arg, ok := args.Storage[name]; ok {
-				count = arg.Count
-			}
-			if charmStorage.CountMin > 0 || count > 0 {
-				return nil, errors.NotSupportedf("block storage on a Kubernetes model")
-			}
+	for _, check := range st.addApplicationChecks(model, &args) {
+		if err := check.run(); err != nil {
+			return nil, errors.Trace(err)
 		}
 	}
 
-	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
-		return nil, errors.Errorf("AttachStorage is non-empty but NumUnits is %d, must be 1", args.NumUnits)
-	}
-
-	if err := jujuversion.CheckJujuMinVersion(args.Charm.Meta().MinJujuVersion, jujuversion.Current); err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	if exists, err := isNotDead(st, applicationsC, args.Name); err != nil {
-		return nil, errors.Trace(err)
-	} else if exists {
-		return nil, errors.Errorf("application already exists")
-	}
-	if err := checkModelActive(st); err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	// ensure storage
-	if args.Storage == nil {
-		args.Storage = make(map[string]StorageConstraints)
-	}
-	sb, err := NewStorageBackend(st)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := addDefaultStorageConstraints(sb, args.Storage, args.Charm.Meta()); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := validateStorageConstraints(sb, args.Storage, args.Charm.Meta()); err != nil {
-		return nil, errors.Trace(err)
-	}
-	storagePools := make(set.Strings)
-	for _, storageParams := range args.Storage {
-		storagePools.Add(storageParams.Pool)
-	}
-
-	// ensure Devices
-	if args.Devices == nil {
-		args.Devices = make(map[string]DeviceConstraints)
-	}
-	deviceb, err := NewDeviceBackend(st)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	if err := validateDeviceConstraints(deviceb, args.Devices, args.Charm.Meta()); err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	// Perform model specific arg processing.
+	// Fill in the model specific application fields.
 	scale := 0
 	placement := ""
 	hasResources := false
 	var operatorStatusDoc *statusDoc
 	nowNano := st.clock().Now().UnixNano()
-	switch model.Type() {
-	case ModelTypeIAAS:
-		if err := st.processIAASModelApplicationArgs(&args); err != nil {
-			return nil, errors.Trace(err)
-		}
-	case ModelTypeCAAS:
+	if model.Type() == ModelTypeCAAS {
 		hasResources = true // all k8s apps start with the assumption of resources
-		if err := st.processCAASModelApplicationArgs(&args); err != nil {
-			return nil, errors.Trace(err)
-		}
 		scale = args.NumUnits
 		if len(args.Placement) == 1 {
 			placement = args.Placement[0].Directive
@@ -1291,7 +1213,9 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	return nil, errors.Trace(err)
 }
 
-func (st *State) processCommonModelApplicationArgs(args *AddApplicationArgs) error {
+// processApplicationSeries defaults the series in args to the charm's
+// series, and otherwise checks that the series is supported by the charm.
+func (st *State) processApplicationSeries(args *AddApplicationArgs) error {
 	if args.Series == "" {
 		// args.Series is not set, so use the series in the URL.
 		args.Series = args.Charm.URL().Series
@@ -1336,7 +1260,12 @@ func (st *State) processCommonModelApplicationArgs(args *AddApplicationArgs) err
 			}
 		}
 	}
+	return nil
+}
 
+// validateApplicationConstraints checks that the constraints in args,
+// combined with the model constraints, are valid for the model.
+func (st *State) validateApplicationConstraints(args *AddApplicationArgs) error {
 	// Ignore constraints that result from this call as
 	// these would be accumulation of model and application constraints
 	// but we only want application constraints to be persisted here.
@@ -1352,11 +1281,9 @@ func (st *State) processCommonModelApplicationArgs(args *AddApplicationArgs) err
 	return errors.Trace(err)
 }
 
-func (st *State) processIAASModelApplicationArgs(args *AddApplicationArgs) error {
-	if err := st.processCommonModelApplicationArgs(args); err != nil {
-		return errors.Trace(err)
-	}
-
+// checkIAASApplicationPlacement checks that the units of an application
+// on an IAAS model can be placed as requested in args.
+func (st *State) checkIAASApplicationPlacement(args *AddApplicationArgs) error {
 	storagePools := make(set.Strings)
 	for _, storageParams := range args.Storage {
 		storagePools.Add(storageParams.Pool)
@@ -1450,19 +1377,23 @@ func (st *State) processIAASModelApplicationArgs(args *AddApplicationArgs) error
 	return nil
 }
 
-func (st *State) processCAASModelApplicationArgs(args *AddApplicationArgs) error {
-	if err := st.processCommonModelApplicationArgs(args); err != nil {
-		return errors.Trace(err)
-	}
+// checkCAASApplicationPlacement checks that the units of an application
+// on a CAAS model can be placed as requested in args.
+func (st *State) checkCAASApplicationPlacement(args *AddApplicationArgs) error {
 	if len(args.Placement) > 0 {
 		return errors.NotValidf("placement directives on k8s models")
 	}
-	return st.precheckInstance(
-		args.Series,
-		args.Constraints,
-		"",
-		nil,
-	)
+	// Each unit is a pod limited by the model constraints as well as the
+	// application's, so check that they all fit.
+	cons, err := st.ResolveConstraints(args.Constraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return st.precheckInstances(environs.PrecheckInstanceParams{
+		Series:      args.Series,
+		Constraints: cons,
+		NumUnits:    args.NumUnits,
+	})
 }
 
 // removeNils removes any keys with nil values from the given map.
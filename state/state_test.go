@@ -977,6 +977,35 @@ func (s *StateSuite) TestAddMachinePlacementIgnoresModelConstraints(c *gc.C) {
 	c.Assert(mcons, gc.DeepEquals, expectedCons)
 }
 
+func (s *StateSuite) TestAddMachineSSHProxyJump(c *gc.C) {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:                  "quantal",
+		Jobs:                    []state.MachineJob{state.JobHostUnits},
+		InstanceId:              "manual:10.0.0.3",
+		Nonce:                   "manual:10.0.0.3:nonce",
+		HardwareCharacteristics: instance.MustParseHardware("arch=amd64"),
+		SSHProxyJump:            "admin@bastion",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.SSHProxyJump(), gc.Equals, "admin@bastion")
+
+	m, err = s.State.Machine(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.SSHProxyJump(), gc.Equals, "admin@bastion")
+}
+
+func (s *StateSuite) TestAddMachineInvalidSSHProxyJump(c *gc.C) {
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:       "quantal",
+		Jobs:         []state.MachineJob{state.JobHostUnits},
+		InstanceId:   "manual:10.0.0.3",
+		Nonce:        "manual:10.0.0.3:nonce",
+		SSHProxyJump: "admin@bastion@other",
+	})
+	c.Assert(err, gc.ErrorMatches, `.*SSH proxy jump "admin@bastion@other", expected \[<user>@\]<host> not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *StateSuite) TestAddMachineWithVolumes(c *gc.C) {
 	pm := poolmanager.New(state.NewStateSettings(s.State), provider.CommonStorageProviders())
 	_, err := pm.Create("loop-pool", provider.LoopProviderType, map[string]interface{}{})
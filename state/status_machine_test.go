@@ -63,6 +63,36 @@ func (s *MachineStatusSuite) TestSetDownStatus(c *gc.C) {
 	s.checkInitialStatus(c)
 }
 
+func (s *MachineStatusSuite) TestSetSuspendedStatusUnprovisioned(c *gc.C) {
+	now := testing.ZeroTime()
+	sInfo := status.StatusInfo{
+		Status: status.Suspended,
+		Since:  &now,
+	}
+	err := s.machine.SetStatus(sInfo)
+	c.Check(err, gc.ErrorMatches, `cannot set status "suspended" on unprovisioned machine`)
+
+	s.checkInitialStatus(c)
+}
+
+func (s *MachineStatusSuite) TestSetSuspendedStatus(c *gc.C) {
+	err := s.machine.SetProvisioned("umbrella/0", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	now := testing.ZeroTime()
+	sInfo := status.StatusInfo{
+		Status:  status.Suspended,
+		Message: "suspended by admin",
+		Since:   &now,
+	}
+	err = s.machine.SetStatus(sInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Suspended)
+	c.Check(statusInfo.Message, gc.Equals, "suspended by admin")
+}
+
 func (s *MachineStatusSuite) TestSetUnknownStatus(c *gc.C) {
 	now := testing.ZeroTime()
 	sInfo := status.StatusInfo{
@@ -91,7 +91,9 @@ type unitDoc struct {
 	StorageAttachmentCount int `bson:"storageattachmentcount"`
 	MachineId              string
 	Resolved               ResolvedMode
-	Tools                  *tools.Tools `bson:",omitempty"`
+	HookKillVersion        int                     `bson:"hookkillversion,omitempty"`
+	SuspendStatus          model.UnitSuspendStatus `bson:"suspend-status,omitempty"`
+	Tools                  *tools.Tools            `bson:",omitempty"`
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
@@ -573,6 +575,12 @@ func (op *DestroyUnitOperation) eraseHistory() error {
 			return one
 		}
 	}
+	if err := eraseHookHistory(op.unit.st, op.unit.Name()); err != nil {
+		one := errors.Annotate(err, "hooks")
+		if op.FatalError(one) {
+			return one
+		}
+	}
 	return nil
 }
 
@@ -1037,6 +1045,60 @@ func (u *Unit) Resolved() ResolvedMode {
 	return u.doc.Resolved
 }
 
+// HookKillVersion returns a counter that is incremented each time
+// RequestHookKill is called for the unit.
+func (u *Unit) HookKillVersion() int {
+	return u.doc.HookKillVersion
+}
+
+// RequestHookKill asks the unit agent to kill the hook it is currently
+// running, if any. A killed hook leaves the unit in an error state, from
+// which it can be resolved as usual.
+func (u *Unit) RequestHookKill() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot request hook kill for unit %q", u)
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$inc", bson.D{{"hookkillversion", 1}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err == nil {
+		u.doc.HookKillVersion++
+		return nil
+	} else if err != txn.ErrAborted {
+		return err
+	}
+	return stateerrors.ErrDead
+}
+
+// SuspendStatus returns the unit's progress through the suspension or
+// resumption of its model.
+func (u *Unit) SuspendStatus() model.UnitSuspendStatus {
+	return u.doc.SuspendStatus
+}
+
+// SetSuspendStatus records the unit's progress through the suspension or
+// resumption of its model.
+func (u *Unit) SetSuspendStatus(status model.UnitSuspendStatus) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set suspend status for unit %q", u)
+	if err := model.ValidateUnitSuspendStatus(status); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"suspend-status", status}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err == nil {
+		u.doc.SuspendStatus = status
+		return nil
+	} else if err != txn.ErrAborted {
+		return err
+	}
+	return stateerrors.ErrDead
+}
+
 // IsPrincipal returns whether the unit is deployed in its own container,
 // and can therefore have subordinate applications deployed alongside it.
 func (u *Unit) IsPrincipal() bool {
@@ -1741,6 +1741,58 @@ func (s *UnitSuite) TestResolve(c *gc.C) {
 	c.Assert(s.unit.Resolved(), gc.Equals, state.ResolvedNoHooks)
 }
 
+func (s *UnitSuite) TestRequestHookKill(c *gc.C) {
+	c.Assert(s.unit.HookKillVersion(), gc.Equals, 0)
+
+	err := s.unit.RequestHookKill()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.HookKillVersion(), gc.Equals, 1)
+	err = s.unit.RequestHookKill()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.HookKillVersion(), gc.Equals, 2)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.HookKillVersion(), gc.Equals, 2)
+}
+
+func (s *UnitSuite) TestRequestHookKillDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RequestHookKill()
+	c.Assert(err, gc.ErrorMatches, `cannot request hook kill for unit "wordpress/0": not found or dead`)
+}
+
+func (s *UnitSuite) TestSetSuspendStatus(c *gc.C) {
+	c.Assert(s.unit.SuspendStatus(), gc.Equals, model.UnitSuspendNotStarted)
+
+	err := s.unit.SetSuspendStatus(model.UnitSuspending)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.SuspendStatus(), gc.Equals, model.UnitSuspending)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.SuspendStatus(), gc.Equals, model.UnitSuspending)
+
+	err = s.unit.SetSuspendStatus(model.UnitSuspendNotStarted)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.SuspendStatus(), gc.Equals, model.UnitSuspendNotStarted)
+}
+
+func (s *UnitSuite) TestSetSuspendStatusInvalid(c *gc.C) {
+	err := s.unit.SetSuspendStatus("sleeping")
+	c.Assert(err, gc.ErrorMatches, `cannot set suspend status for unit "wordpress/0": unit suspend status "sleeping" not valid`)
+}
+
+func (s *UnitSuite) TestSetSuspendStatusDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetSuspendStatus(model.UnitSuspending)
+	c.Assert(err, gc.ErrorMatches, `cannot set suspend status for unit "wordpress/0": not found or dead`)
+}
+
 func (s *UnitSuite) TestGetSetClearResolved(c *gc.C) {
 	mode := s.unit.Resolved()
 	c.Assert(mode, gc.Equals, state.ResolvedNone)
@@ -2726,6 +2778,31 @@ func (s *UnitSuite) TestWatchMachineAndEndpointAddressesHash(c *gc.C) {
 	wc.AssertChange("c895e8b57123efd2194d48b74db431e4db4c3ae4fa75f55aa6f32c7f39f29abd")
 }
 
+func (s *UnitSuite) TestWatchMachineAndEndpointAddressesHashResized(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetProvisioned("i-am", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.State.StartSync()
+	w, err := unit.WatchMachineAndEndpointAddressesHash()
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { _ = w.Stop() }()
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChanges()
+
+	// Resizing the machine's instance should trigger a change.
+	mem := uint64(4096)
+	err = m.SetResized(constraints.MustParse("mem=4G"), instance.HardwareCharacteristics{Mem: &mem})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChanges()
+	wc.AssertNoChange()
+}
+
 func unitMachine(c *gc.C, st *state.State, u *state.Unit) *state.Machine {
 	machineId, err := u.AssignedMachineId()
 	c.Assert(err, jc.ErrorIsNil)
@@ -2018,6 +2018,13 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to the
+// application's application configuration settings, as opposed to its
+// charm configuration settings.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -4343,6 +4350,7 @@ func (w *hashWatcher) loop() error {
 // is recalculated when any of the following events occurs:
 // - the machine addresses for the unit change.
 // - the endpoint bindings for the unit's application change.
+// - the machine's instance is resized.
 func (u *Unit) WatchMachineAndEndpointAddressesHash() (StringsWatcher, error) {
 	app, err := u.Application()
 	if err != nil {
@@ -4411,6 +4419,12 @@ func hashMachineAddressesForEndpointBindings(m *Machine, bindingsToSpaceIDs map[
 		}
 		_, _ = hash.Write([]byte(fmt.Sprintf("%s:%s", epName, bindingsToSpaceIDs[epName])))
 	}
+
+	// Resizes change the hash too, so that the unit's charm can adapt
+	// to the new hardware.
+	if m.doc.Resizes > 0 {
+		_, _ = hash.Write([]byte(fmt.Sprintf("resized:%d", m.doc.Resizes)))
+	}
 	return fmt.Sprintf("%x", hash.Sum(nil)), nil
 }
 
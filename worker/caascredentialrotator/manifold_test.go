@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caascredentialrotator_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	dt "github.com/juju/worker/v2/dependency/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cloud"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/worker/caascredentialrotator"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+// validManifoldConfig returns a minimal config stuffed with dummy objects
+// that will explode when used.
+func validManifoldConfig() caascredentialrotator.ManifoldConfig {
+	return caascredentialrotator.ManifoldConfig{
+		APICallerName: "api-caller",
+		Clock:         testclock.NewClock(time.Time{}),
+		NewFacade: func(base.APICaller) (caascredentialrotator.Facade, error) {
+			panic("NewFacade")
+		},
+		NewWorker: func(caascredentialrotator.Config) (worker.Worker, error) {
+			panic("NewWorker")
+		},
+		RotateCredential: func(environscloudspec.CloudSpec) (*cloud.Credential, error) {
+			panic("RotateCredential")
+		},
+		Refreshed: func() <-chan struct{} {
+			panic("Refreshed")
+		},
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (*ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := caascredentialrotator.Manifold(validManifoldConfig())
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller"})
+}
+
+func (*ManifoldSuite) TestFilterErrModelCredentialChanged(c *gc.C) {
+	manifold := caascredentialrotator.Manifold(validManifoldConfig())
+	err := manifold.Filter(caascredentialrotator.ErrModelCredentialChanged)
+	c.Check(err, gc.Equals, dependency.ErrBounce)
+}
+
+func (*ManifoldSuite) TestFilterOther(c *gc.C) {
+	manifold := caascredentialrotator.Manifold(validManifoldConfig())
+	expect := errors.New("whatever")
+	c.Check(manifold.Filter(expect), gc.Equals, expect)
+}
+
+func (*ManifoldSuite) TestValidate(c *gc.C) {
+	for _, t := range []struct {
+		mutate func(*caascredentialrotator.ManifoldConfig)
+		expect string
+	}{
+		{func(cfg *caascredentialrotator.ManifoldConfig) { cfg.APICallerName = "" }, "empty APICallerName not valid"},
+		{func(cfg *caascredentialrotator.ManifoldConfig) { cfg.Clock = nil }, "nil Clock not valid"},
+		{func(cfg *caascredentialrotator.ManifoldConfig) { cfg.NewFacade = nil }, "nil NewFacade not valid"},
+		{func(cfg *caascredentialrotator.ManifoldConfig) { cfg.NewWorker = nil }, "nil NewWorker not valid"},
+		{func(cfg *caascredentialrotator.ManifoldConfig) { cfg.RotateCredential = nil }, "nil RotateCredential not valid"},
+		{func(cfg *caascredentialrotator.ManifoldConfig) { cfg.Refreshed = nil }, "nil Refreshed not valid"},
+		{func(cfg *caascredentialrotator.ManifoldConfig) { cfg.Logger = nil }, "nil Logger not valid"},
+	} {
+		config := validManifoldConfig()
+		t.mutate(&config)
+		err := config.Validate()
+		c.Check(err, gc.ErrorMatches, t.expect)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (*ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+	})
+	manifold := caascredentialrotator.Manifold(validManifoldConfig())
+
+	w, err := manifold.Start(context)
+	c.Check(w, gc.IsNil)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (*ManifoldSuite) TestStartSuccess(c *gc.C) {
+	expectCaller := &struct{ base.APICaller }{}
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": expectCaller,
+	})
+	expectFacade := &struct{ caascredentialrotator.Facade }{}
+	expectWorker := &struct{ worker.Worker }{}
+	config := validManifoldConfig()
+	config.NewFacade = func(caller base.APICaller) (caascredentialrotator.Facade, error) {
+		c.Check(caller, gc.Equals, expectCaller)
+		return expectFacade, nil
+	}
+	config.NewWorker = func(workerConfig caascredentialrotator.Config) (worker.Worker, error) {
+		c.Check(workerConfig.Facade, gc.Equals, expectFacade)
+		c.Check(workerConfig.Clock, gc.Equals, config.Clock)
+		c.Check(workerConfig.RefreshInterval, gc.Equals, caascredentialrotator.DefaultRefreshInterval)
+		return expectWorker, nil
+	}
+	manifold := caascredentialrotator.Manifold(config)
+
+	w, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(w, gc.Equals, expectWorker)
+}
@@ -0,0 +1,30 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caascredentialrotator
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/caasagent"
+	"github.com/juju/juju/api/credentialvalidator"
+)
+
+// NewFacade creates a Facade from the credentialvalidator and caasagent
+// API facades.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	agent, err := caasagent.NewClient(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &facadeShim{
+		Facade: credentialvalidator.NewFacade(apiCaller),
+		Client: agent,
+	}, nil
+}
+
+type facadeShim struct {
+	*credentialvalidator.Facade
+	*caasagent.Client
+}
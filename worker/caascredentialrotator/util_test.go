@@ -0,0 +1,71 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caascredentialrotator_test
+
+import (
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+)
+
+// mockFacade implements caascredentialrotator.Facade for use in the tests.
+type mockFacade struct {
+	*testing.Stub
+	credential base.StoredCredential
+	spec       environscloudspec.CloudSpec
+
+	watcher      *watchertest.MockNotifyWatcher
+	modelWatcher *watchertest.MockNotifyWatcher
+	updated      chan cloud.Credential
+}
+
+// ModelCredential is part of the caascredentialrotator.Facade interface.
+func (m *mockFacade) ModelCredential() (base.StoredCredential, bool, error) {
+	m.AddCall("ModelCredential")
+	if err := m.NextErr(); err != nil {
+		return base.StoredCredential{}, false, err
+	}
+	return m.credential, m.credential.CloudCredential != "", nil
+}
+
+// WatchCredential is part of the caascredentialrotator.Facade interface.
+func (m *mockFacade) WatchCredential(id string) (watcher.NotifyWatcher, error) {
+	m.AddCall("WatchCredential", id)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.watcher, nil
+}
+
+// WatchModelCredential is part of the caascredentialrotator.Facade interface.
+func (m *mockFacade) WatchModelCredential() (watcher.NotifyWatcher, error) {
+	m.AddCall("WatchModelCredential")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.modelWatcher, nil
+}
+
+// CloudSpec is part of the caascredentialrotator.Facade interface.
+func (m *mockFacade) CloudSpec() (environscloudspec.CloudSpec, error) {
+	m.AddCall("CloudSpec")
+	if err := m.NextErr(); err != nil {
+		return environscloudspec.CloudSpec{}, err
+	}
+	return m.spec, nil
+}
+
+// UpdateModelCredential is part of the caascredentialrotator.Facade interface.
+func (m *mockFacade) UpdateModelCredential(credential cloud.Credential) error {
+	m.AddCall("UpdateModelCredential", credential)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.updated <- credential
+	return nil
+}
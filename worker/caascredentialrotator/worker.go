@@ -0,0 +1,244 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caascredentialrotator
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/watcher"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+)
+
+// ErrModelCredentialChanged indicates that a Worker has bounced because
+// its model's cloud credential has changed.
+var ErrModelCredentialChanged = errors.New("model cloud credential has changed")
+
+// DefaultRefreshInterval is how often the credential is refreshed from
+// its token secret while it's still valid.
+const DefaultRefreshInterval = time.Hour
+
+// Facade exposes functionality required by a Worker to check and rotate
+// the cloud credential that a model uses.
+type Facade interface {
+	// ModelCredential gets model's cloud credential.
+	ModelCredential() (base.StoredCredential, bool, error)
+
+	// WatchCredential gets cloud credential watcher.
+	WatchCredential(string) (watcher.NotifyWatcher, error)
+
+	// WatchModelCredential gets model's cloud credential watcher.
+	WatchModelCredential() (watcher.NotifyWatcher, error)
+
+	// CloudSpec returns the cloud spec of the model, including the
+	// content of its credential.
+	CloudSpec() (environscloudspec.CloudSpec, error)
+
+	// UpdateModelCredential replaces the content of the model's cloud
+	// credential.
+	UpdateModelCredential(cloud.Credential) error
+}
+
+// RotateCredentialFunc returns the rotated credential of a cloud spec,
+// or a NotFound error if its credential can't be rotated.
+type RotateCredentialFunc func(environscloudspec.CloudSpec) (*cloud.Credential, error)
+
+// RefreshedFunc returns a channel which is closed the next time the
+// tokens of a credential are refreshed in this process, such as the ID
+// token of an oidc credential.
+type RefreshedFunc func() <-chan struct{}
+
+// Config holds the dependencies and configuration for a Worker.
+type Config struct {
+	Facade           Facade
+	RotateCredential RotateCredentialFunc
+	Refreshed        RefreshedFunc
+	Clock            clock.Clock
+	RefreshInterval  time.Duration
+	Logger           Logger
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional Worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.RotateCredential == nil {
+		return errors.NotValidf("nil RotateCredential")
+	}
+	if config.Refreshed == nil {
+		return errors.NotValidf("nil Refreshed")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.RefreshInterval <= 0 {
+		return errors.NotValidf("non-positive RefreshInterval")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a Worker that rotates the model's cloud credential
+// from the in-cluster secret it refers to, both periodically and as soon
+// as the credential is found to be invalid. Tokens refreshed in this
+// process are saved to the credential as soon as they are refreshed.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	mc, _, err := config.Facade.ModelCredential()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	mcw, err := config.Facade.WatchModelCredential()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	r := &rotator{
+		config:                 config,
+		credential:             mc,
+		modelCredentialWatcher: mcw,
+	}
+	plan := catacomb.Plan{
+		Site: &r.catacomb,
+		Work: r.loop,
+		Init: []worker.Worker{r.modelCredentialWatcher},
+	}
+	if mc.CloudCredential != "" {
+		r.credentialWatcher, err = config.Facade.WatchCredential(mc.CloudCredential)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		plan.Init = append(plan.Init, r.credentialWatcher)
+	}
+	if err := catacomb.Invoke(plan); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r, nil
+}
+
+type rotator struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	credential             base.StoredCredential
+	modelCredentialWatcher watcher.NotifyWatcher
+	// could be nil when there is no model credential to watch
+	credentialWatcher watcher.NotifyWatcher
+}
+
+// Kill is part of the worker.Worker interface.
+func (r *rotator) Kill() {
+	r.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (r *rotator) Wait() error {
+	return r.catacomb.Wait()
+}
+
+func (r *rotator) loop() error {
+	var (
+		credentialChanges watcher.NotifyChannel
+		refresh           <-chan time.Time
+	)
+	if r.credentialWatcher != nil {
+		credentialChanges = r.credentialWatcher.Changes()
+		refresh = r.config.Clock.After(0)
+	}
+
+	for {
+		var refreshed <-chan struct{}
+		if r.credentialWatcher != nil {
+			refreshed = r.config.Refreshed()
+		}
+		select {
+		case <-r.catacomb.Dying():
+			return r.catacomb.ErrDying()
+		case _, ok := <-r.modelCredentialWatcher.Changes():
+			if !ok {
+				return r.catacomb.ErrDying()
+			}
+			mc, _, err := r.config.Facade.ModelCredential()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if mc.CloudCredential != r.credential.CloudCredential {
+				return ErrModelCredentialChanged
+			}
+		case _, ok := <-credentialChanges:
+			if !ok {
+				return r.catacomb.ErrDying()
+			}
+			mc, _, err := r.config.Facade.ModelCredential()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			wasValid := r.credential.Valid
+			r.credential = mc
+			if wasValid && !mc.Valid {
+				// Don't wait for the next refresh to replace an
+				// expired token.
+				if err := r.rotate(); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		case <-refresh:
+			if err := r.rotate(); err != nil {
+				return errors.Trace(err)
+			}
+			refresh = r.config.Clock.After(r.config.RefreshInterval)
+		case <-refreshed:
+			if err := r.rotate(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// rotate replaces the content of the model credential with the rotated
+// credential read from the cluster, if it has changed. Failures to read
+// the rotated credential are logged, and tried again at the next refresh.
+func (r *rotator) rotate() error {
+	logger := r.config.Logger
+	spec, err := r.config.Facade.CloudSpec()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return nil
+	}
+	rotated, err := r.config.RotateCredential(spec)
+	if errors.IsNotFound(err) {
+		logger.Debugf("not rotating credential %q: %v", r.credential.CloudCredential, err)
+		return nil
+	} else if err != nil {
+		logger.Warningf("cannot rotate credential %q: %v", r.credential.CloudCredential, err)
+		return nil
+	}
+	if reflect.DeepEqual(rotated.Attributes(), spec.Credential.Attributes()) {
+		if !r.credential.Valid {
+			logger.Warningf("credential %q is not valid, but has not been rotated", r.credential.CloudCredential)
+		}
+		return nil
+	}
+	if err := r.config.Facade.UpdateModelCredential(*rotated); err != nil {
+		return errors.Annotatef(err, "updating credential %q", r.credential.CloudCredential)
+	}
+	logger.Infof("rotated credential %q", r.credential.CloudCredential)
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caascredentialrotator_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/watcher/watchertest"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/caascredentialrotator"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock                  *testclock.Clock
+	facade                 *mockFacade
+	config                 caascredentialrotator.Config
+	credentialChanges      chan struct{}
+	modelCredentialChanges chan struct{}
+
+	current cloud.Credential
+	// rotated is returned by the RotateCredential func, and each call
+	// is recorded in rotations.
+	rotated   *cloud.Credential
+	rotateErr error
+	rotations chan environscloudspec.CloudSpec
+	// refreshed is sent to by tests to report a refresh of tokens.
+	refreshed chan struct{}
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+const credentialID = "cloud/user/credential"
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.clock = testclock.NewClock(time.Time{})
+	s.credentialChanges = make(chan struct{})
+	s.modelCredentialChanges = make(chan struct{})
+	s.current = cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		"Token":        "current",
+		"token-secret": "kube-system/juju-token",
+	})
+	s.facade = &mockFacade{
+		Stub:         &testing.Stub{},
+		credential:   base.StoredCredential{CloudCredential: credentialID, Valid: true},
+		spec:         environscloudspec.CloudSpec{Credential: &s.current},
+		watcher:      watchertest.NewMockNotifyWatcher(s.credentialChanges),
+		modelWatcher: watchertest.NewMockNotifyWatcher(s.modelCredentialChanges),
+		updated:      make(chan cloud.Credential, 1),
+	}
+	s.rotated = &s.current
+	s.rotateErr = nil
+	s.rotations = make(chan environscloudspec.CloudSpec, 10)
+	s.refreshed = make(chan struct{})
+
+	s.config = caascredentialrotator.Config{
+		Facade: s.facade,
+		RotateCredential: func(spec environscloudspec.CloudSpec) (*cloud.Credential, error) {
+			rotated, err := s.rotated, s.rotateErr
+			s.rotations <- spec
+			return rotated, err
+		},
+		Refreshed: func() <-chan struct{} {
+			return s.refreshed
+		},
+		Clock:           s.clock,
+		RefreshInterval: time.Hour,
+		Logger:          loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := caascredentialrotator.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) waitRotation(c *gc.C) {
+	select {
+	case spec := <-s.rotations:
+		c.Assert(spec.Credential, gc.Equals, &s.current)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("credential not rotated")
+	}
+}
+
+func (s *WorkerSuite) waitUpdate(c *gc.C) cloud.Credential {
+	select {
+	case credential := <-s.facade.updated:
+		return credential
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("credential not updated")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoUpdate(c *gc.C) {
+	select {
+	case credential := <-s.facade.updated:
+		c.Fatalf("unexpected update to %v", credential)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for _, t := range []struct {
+		mutate func(*caascredentialrotator.Config)
+		expect string
+	}{
+		{func(cfg *caascredentialrotator.Config) { cfg.Facade = nil }, "nil Facade not valid"},
+		{func(cfg *caascredentialrotator.Config) { cfg.RotateCredential = nil }, "nil RotateCredential not valid"},
+		{func(cfg *caascredentialrotator.Config) { cfg.Refreshed = nil }, "nil Refreshed not valid"},
+		{func(cfg *caascredentialrotator.Config) { cfg.Clock = nil }, "nil Clock not valid"},
+		{func(cfg *caascredentialrotator.Config) { cfg.RefreshInterval = 0 }, "non-positive RefreshInterval not valid"},
+		{func(cfg *caascredentialrotator.Config) { cfg.Logger = nil }, "nil Logger not valid"},
+	} {
+		config := s.config
+		t.mutate(&config)
+		w, err := caascredentialrotator.NewWorker(config)
+		c.Check(w, gc.IsNil)
+		c.Check(err, gc.ErrorMatches, t.expect)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *WorkerSuite) TestRotatesAtStart(c *gc.C) {
+	rotated := cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		"Token":        "rotated",
+		"token-secret": "kube-system/juju-token",
+	})
+	s.rotated = &rotated
+	w := s.startWorker(c)
+
+	s.waitRotation(c)
+	c.Assert(s.waitUpdate(c), jc.DeepEquals, rotated)
+	workertest.CleanKill(c, w)
+	s.facade.CheckCallNames(c, "ModelCredential", "WatchModelCredential", "WatchCredential", "CloudSpec", "UpdateModelCredential")
+}
+
+func (s *WorkerSuite) TestUnchangedNotUpdated(c *gc.C) {
+	w := s.startWorker(c)
+	s.waitRotation(c)
+	s.assertNoUpdate(c)
+
+	// The credential is refreshed again after the interval.
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitRotation(c)
+	s.assertNoUpdate(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestRotateErrorLogged(c *gc.C) {
+	s.rotateErr = errors.New("boom")
+	w := s.startWorker(c)
+	s.waitRotation(c)
+	s.assertNoUpdate(c)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestRotatesWhenInvalidated(c *gc.C) {
+	w := s.startWorker(c)
+	s.waitRotation(c)
+
+	rotated := cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		"Token":        "rotated",
+		"token-secret": "kube-system/juju-token",
+	})
+	s.rotated = &rotated
+	s.facade.credential.Valid = false
+	select {
+	case s.credentialChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending credential change")
+	}
+	s.waitRotation(c)
+	c.Assert(s.waitUpdate(c), jc.DeepEquals, rotated)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestRotatesWhenRefreshed(c *gc.C) {
+	w := s.startWorker(c)
+	s.waitRotation(c)
+	s.assertNoUpdate(c)
+
+	refreshed := cloud.NewCredential(cloud.OIDCAuthType, map[string]string{
+		"IDToken":      "refreshed",
+		"RefreshToken": "next",
+	})
+	s.rotated = &refreshed
+	select {
+	case s.refreshed <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out reporting refresh")
+	}
+	s.waitRotation(c)
+	c.Assert(s.waitUpdate(c), jc.DeepEquals, refreshed)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestModelCredentialChanged(c *gc.C) {
+	w := s.startWorker(c)
+	s.waitRotation(c)
+
+	s.facade.credential.CloudCredential = "cloud/user/another"
+	select {
+	case s.modelCredentialChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending model credential change")
+	}
+	err := workertest.CheckKilled(c, w)
+	c.Assert(errors.Cause(err), gc.Equals, caascredentialrotator.ErrModelCredentialChanged)
+}
+
+func (s *WorkerSuite) TestNoModelCredential(c *gc.C) {
+	s.facade.credential = base.StoredCredential{Valid: true}
+	w := s.startWorker(c)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+	c.Assert(s.rotations, gc.HasLen, 0)
+	s.facade.CheckCallNames(c, "ModelCredential", "WatchModelCredential")
+}
@@ -6,11 +6,13 @@ package caasfirewaller
 import (
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
-	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/catacomb"
 
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/tags"
 )
 
@@ -27,6 +29,16 @@ type applicationWorker struct {
 	initial           bool
 	previouslyExposed bool
 
+	// modelConfigChanges is signalled when the model config changes,
+	// which may turn network policies on or off.
+	modelConfigChanges chan struct{}
+	// ingressChanges is signalled when the networks allowed in on a
+	// cross model relation change.
+	ingressChanges chan struct{}
+	ingressWatched set.Strings
+	policyApplied  bool
+	policyEnabled  bool
+
 	logger Logger
 }
 
@@ -38,16 +50,19 @@ func newApplicationWorker(
 	applicationExposer ServiceExposer,
 	lifeGetter LifeGetter,
 	logger Logger,
-) (worker.Worker, error) {
+) (*applicationWorker, error) {
 	w := &applicationWorker{
-		controllerUUID:    controllerUUID,
-		modelUUID:         modelUUID,
-		application:       application,
-		applicationGetter: applicationGetter,
-		serviceExposer:    applicationExposer,
-		lifeGetter:        lifeGetter,
-		initial:           true,
-		logger:            logger,
+		controllerUUID:     controllerUUID,
+		modelUUID:          modelUUID,
+		application:        application,
+		applicationGetter:  applicationGetter,
+		serviceExposer:     applicationExposer,
+		lifeGetter:         lifeGetter,
+		initial:            true,
+		modelConfigChanges: make(chan struct{}, 1),
+		ingressChanges:     make(chan struct{}, 1),
+		ingressWatched:     set.NewStrings(),
+		logger:             logger,
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -68,6 +83,14 @@ func (w *applicationWorker) Wait() error {
 	return w.catacomb.Wait()
 }
 
+// modelConfigChanged tells the worker the model config has changed.
+func (w *applicationWorker) modelConfigChanged() {
+	select {
+	case w.modelConfigChanges <- struct{}{}:
+	default:
+	}
+}
+
 func (w *applicationWorker) loop() (err error) {
 	defer func() {
 		// If the application has been deleted, we can return nil.
@@ -84,7 +107,26 @@ func (w *applicationWorker) loop() (err error) {
 		return errors.Trace(err)
 	}
 
+	// Older controllers don't report relations, so no network
+	// policy is managed for the application.
+	var (
+		relationsChanges   watcher.StringsChannel
+		modelConfigChanges <-chan struct{}
+	)
+	relationsWatcher, err := w.applicationGetter.WatchApplicationRelations(w.application)
+	if err != nil && !errors.IsNotSupported(err) {
+		return errors.Trace(err)
+	}
+	if err == nil {
+		if err := w.catacomb.Add(relationsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		relationsChanges = relationsWatcher.Changes()
+		modelConfigChanges = w.modelConfigChanges
+	}
+
 	for {
+		var err error
 		select {
 		case <-w.catacomb.Dying():
 			return w.catacomb.ErrDying()
@@ -92,30 +134,36 @@ func (w *applicationWorker) loop() (err error) {
 			if !ok {
 				return errors.New("application watcher closed")
 			}
-			if err := w.processApplicationChange(); err != nil {
-				if strings.Contains(err.Error(), "unexpected EOF") {
-					return nil
-				}
-				return errors.Trace(err)
+			err = w.processApplicationChange()
+			if err == nil && relationsChanges != nil {
+				// Expose settings feed into the network policy.
+				err = w.processNetworkPolicyChange()
+			}
+		case relationKeys, ok := <-relationsChanges:
+			if !ok {
+				return errors.New("relations watcher closed")
 			}
+			err = w.watchIngressNetworks(relationKeys)
+			if err == nil {
+				err = w.processNetworkPolicyChange()
+			}
+		case <-w.ingressChanges:
+			err = w.processNetworkPolicyChange()
+		case <-modelConfigChanges:
+			err = w.processModelConfigChange()
+		}
+		if err != nil {
+			if strings.Contains(err.Error(), "unexpected EOF") {
+				return nil
+			}
+			return errors.Trace(err)
 		}
 	}
 }
 
 func (w *applicationWorker) processApplicationChange() (err error) {
 	defer func() {
-		// Not found could be because the app got removed or there's
-		// no container service created yet as the app is still being set up.
-		if errors.IsNotFound(err) {
-			// Perhaps the app got removed while we were processing.
-			if _, err2 := w.lifeGetter.Life(w.application); err2 != nil {
-				err = err2
-				return
-			}
-			// Ignore not found error because the ip could be not ready yet at this stage.
-			w.logger.Warningf("processing change for application %q, %v", w.application, err)
-			err = nil
-		}
+		err = w.maybeIgnoreNotFound(err)
 	}()
 
 	exposed, err := w.applicationGetter.IsExposed(w.application)
@@ -147,3 +195,106 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	}
 	return nil
 }
+
+func (w *applicationWorker) processNetworkPolicyChange() (err error) {
+	defer func() {
+		err = w.maybeIgnoreNotFound(err)
+	}()
+
+	policy, err := w.applicationGetter.NetworkPolicy(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.ensureNetworkPolicy(policy))
+}
+
+func (w *applicationWorker) processModelConfigChange() (err error) {
+	defer func() {
+		err = w.maybeIgnoreNotFound(err)
+	}()
+
+	policy, err := w.applicationGetter.NetworkPolicy(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Only turning network policies on or off in the
+	// model config affects the application.
+	if w.policyApplied && policy.Enabled == w.policyEnabled {
+		return nil
+	}
+	return errors.Trace(w.ensureNetworkPolicy(policy))
+}
+
+func (w *applicationWorker) ensureNetworkPolicy(policy caas.NetworkPolicyParams) error {
+	if err := w.serviceExposer.EnsureNetworkPolicy(w.application, policy); err != nil {
+		return errors.Trace(err)
+	}
+	w.policyApplied = true
+	w.policyEnabled = policy.Enabled
+	return nil
+}
+
+// watchIngressNetworks starts watching the networks allowed in on any
+// of the relations not already watched, as consuming models can change
+// them at any time for cross model relations.
+func (w *applicationWorker) watchIngressNetworks(relationKeys []string) error {
+	for _, key := range relationKeys {
+		if w.ingressWatched.Contains(key) {
+			continue
+		}
+		ingressWatcher, err := w.applicationGetter.WatchRelationIngressNetworks(key)
+		if errors.IsNotFound(err) {
+			// The relation has been removed.
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.catacomb.Add(ingressWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		w.ingressWatched.Add(key)
+		go w.forwardIngressChanges(ingressWatcher.Changes())
+	}
+	return nil
+}
+
+// forwardIngressChanges signals the worker when the networks allowed in
+// on a relation change. The initial event is skipped, as the network
+// policy is applied after the watcher is started anyway.
+func (w *applicationWorker) forwardIngressChanges(changes watcher.StringsChannel) {
+	initial := true
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+			if initial {
+				initial = false
+				continue
+			}
+			select {
+			case w.ingressChanges <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// maybeIgnoreNotFound ignores a not found error if the application
+// still exists. Not found could be because the app got removed or there's
+// no container service created yet as the app is still being set up.
+func (w *applicationWorker) maybeIgnoreNotFound(err error) error {
+	if !errors.IsNotFound(err) {
+		return err
+	}
+	// Perhaps the app got removed while we were processing.
+	if _, err2 := w.lifeGetter.Life(w.application); err2 != nil {
+		return err2
+	}
+	// Ignore not found error because the ip could be not ready yet at this stage.
+	w.logger.Warningf("processing change for application %q, %v", w.application, err)
+	return nil
+}
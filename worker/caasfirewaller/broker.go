@@ -3,9 +3,13 @@
 
 package caasfirewaller
 
-import "github.com/juju/juju/core/application"
+import (
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
 
 type ServiceExposer interface {
 	ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error
 	UnexposeService(appName string) error
+	EnsureNetworkPolicy(appName string, params caas.NetworkPolicyParams) error
 }
@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
@@ -15,6 +16,7 @@ import (
 type Client interface {
 	ApplicationGetter
 	LifeGetter
+	ModelConfigWatcher
 }
 
 // ApplicationGetter provides an interface for
@@ -26,6 +28,9 @@ type ApplicationGetter interface {
 	WatchApplication(string) (watcher.NotifyWatcher, error)
 	IsExposed(string) (bool, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
+	WatchApplicationRelations(string) (watcher.StringsWatcher, error)
+	WatchRelationIngressNetworks(string) (watcher.StringsWatcher, error)
+	NetworkPolicy(string) (caas.NetworkPolicyParams, error)
 }
 
 // LifeGetter provides an interface for getting the
@@ -33,3 +38,9 @@ type ApplicationGetter interface {
 type LifeGetter interface {
 	Life(string) (life.Value, error)
 }
+
+// ModelConfigWatcher provides an interface for watching
+// for changes to the model config.
+type ModelConfigWatcher interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+}
@@ -4,6 +4,7 @@
 package caasfirewaller_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 
 	"github.com/juju/juju/api/base"
@@ -29,8 +30,9 @@ type fakeClient struct {
 
 type mockServiceExposer struct {
 	testing.Stub
-	exposed   chan<- struct{}
-	unexposed chan<- struct{}
+	exposed         chan<- struct{}
+	unexposed       chan<- struct{}
+	networkPolicies chan<- caas.NetworkPolicyParams
 }
 
 func (m *mockServiceExposer) ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error {
@@ -45,11 +47,23 @@ func (m *mockServiceExposer) UnexposeService(appName string) error {
 	return m.NextErr()
 }
 
+// EnsureNetworkPolicy reports the policy on the networkPolicies channel
+// rather than recording a call, so the expose calls can be checked
+// without racing with it.
+func (m *mockServiceExposer) EnsureNetworkPolicy(appName string, params caas.NetworkPolicyParams) error {
+	m.networkPolicies <- params
+	return nil
+}
+
 type mockApplicationGetter struct {
 	testing.Stub
-	allWatcher *watchertest.MockStringsWatcher
-	appWatcher *watchertest.MockNotifyWatcher
-	exposed    bool
+	allWatcher          *watchertest.MockStringsWatcher
+	appWatcher          *watchertest.MockNotifyWatcher
+	relationsWatcher    *watchertest.MockStringsWatcher
+	ingressWatchers     map[string]*watchertest.MockStringsWatcher
+	exposed             bool
+	networkPolicy       caas.NetworkPolicyParams
+	policiesUnsupported bool
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -81,6 +95,37 @@ func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.C
 	return application.ConfigAttributes{"juju-external-hostname": "exthost"}, a.NextErr()
 }
 
+func (m *mockApplicationGetter) WatchApplicationRelations(appName string) (watcher.StringsWatcher, error) {
+	m.MethodCall(m, "WatchApplicationRelations", appName)
+	if m.policiesUnsupported {
+		return nil, errors.NotSupportedf("watching application relations")
+	}
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.relationsWatcher, nil
+}
+
+func (m *mockApplicationGetter) WatchRelationIngressNetworks(relationKey string) (watcher.StringsWatcher, error) {
+	m.MethodCall(m, "WatchRelationIngressNetworks", relationKey)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	w, ok := m.ingressWatchers[relationKey]
+	if !ok {
+		return nil, errors.NotFoundf("relation %q", relationKey)
+	}
+	return w, nil
+}
+
+func (m *mockApplicationGetter) NetworkPolicy(appName string) (caas.NetworkPolicyParams, error) {
+	m.MethodCall(m, "NetworkPolicy", appName)
+	if err := m.NextErr(); err != nil {
+		return caas.NetworkPolicyParams{}, err
+	}
+	return m.networkPolicy, nil
+}
+
 type mockLifeGetter struct {
 	testing.Stub
 	life life.Value
@@ -93,3 +138,20 @@ func (m *mockLifeGetter) Life(entityName string) (life.Value, error) {
 	}
 	return m.life, nil
 }
+
+type mockModelConfigWatcher struct {
+	testing.Stub
+	watcher     *watchertest.MockNotifyWatcher
+	unsupported bool
+}
+
+func (m *mockModelConfigWatcher) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchForModelConfigChanges")
+	if m.unsupported {
+		return nil, errors.NotSupportedf("watching model config")
+	}
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.watcher, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/worker/v2/catacomb"
 
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/watcher"
 )
 
 // Logger is here to stop the desire of creating a package level Logger.
@@ -17,12 +18,13 @@ var logger interface{}
 
 // Config holds configuration for the CAAS unit firewaller worker.
 type Config struct {
-	ControllerUUID    string
-	ModelUUID         string
-	ApplicationGetter ApplicationGetter
-	LifeGetter        LifeGetter
-	ServiceExposer    ServiceExposer
-	Logger            Logger
+	ControllerUUID     string
+	ModelUUID          string
+	ApplicationGetter  ApplicationGetter
+	LifeGetter         LifeGetter
+	ServiceExposer     ServiceExposer
+	ModelConfigWatcher ModelConfigWatcher
+	Logger             Logger
 }
 
 // Validate validates the worker configuration.
@@ -42,6 +44,9 @@ func (config Config) Validate() error {
 	if config.LifeGetter == nil {
 		return errors.NotValidf("missing LifeGetter")
 	}
+	if config.ModelConfigWatcher == nil {
+		return errors.NotValidf("missing ModelConfigWatcher")
+	}
 	if config.Logger == nil {
 		return errors.NotValidf("missing Logger")
 	}
@@ -86,11 +91,32 @@ func (p *firewaller) loop() error {
 		return errors.Trace(err)
 	}
 
-	appWorkers := make(map[string]worker.Worker)
+	// Older controllers don't manage network policies,
+	// so there's no need to watch the model config.
+	var modelConfigChanges watcher.NotifyChannel
+	modelConfigWatcher, err := p.config.ModelConfigWatcher.WatchForModelConfigChanges()
+	if err != nil && !errors.IsNotSupported(err) {
+		return errors.Trace(err)
+	}
+	if err == nil {
+		if err := p.catacomb.Add(modelConfigWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		modelConfigChanges = modelConfigWatcher.Changes()
+	}
+
+	appWorkers := make(map[string]*applicationWorker)
 	for {
 		select {
 		case <-p.catacomb.Dying():
 			return p.catacomb.ErrDying()
+		case _, ok := <-modelConfigChanges:
+			if !ok {
+				return errors.New("model config watcher closed channel")
+			}
+			for _, w := range appWorkers {
+				w.modelConfigChanged()
+			}
 		case apps, ok := <-w.Changes():
 			if !ok {
 				return errors.New("watcher closed channel")
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/worker/v2/workertest"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher/watchertest"
@@ -23,15 +24,20 @@ import (
 type WorkerSuite struct {
 	testing.IsolationSuite
 
-	config            caasfirewaller.Config
-	applicationGetter mockApplicationGetter
-	serviceExposer    mockServiceExposer
-	lifeGetter        mockLifeGetter
+	config             caasfirewaller.Config
+	applicationGetter  mockApplicationGetter
+	serviceExposer     mockServiceExposer
+	lifeGetter         mockLifeGetter
+	modelConfigWatcher mockModelConfigWatcher
 
 	applicationChanges chan []string
 	appExposedChange   chan struct{}
+	relationsChange    chan []string
+	ingressChange      chan []string
+	modelConfigChange  chan struct{}
 	serviceExposed     chan struct{}
 	serviceUnexposed   chan struct{}
+	networkPolicies    chan caas.NetworkPolicyParams
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -43,28 +49,47 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.appExposedChange = make(chan struct{})
 	s.serviceExposed = make(chan struct{})
 	s.serviceUnexposed = make(chan struct{})
+	s.relationsChange = make(chan []string)
+	s.ingressChange = make(chan []string)
+	s.modelConfigChange = make(chan struct{})
+	// Buffered so tests only need to read the policies they care about.
+	s.networkPolicies = make(chan caas.NetworkPolicyParams, 10)
 
 	s.applicationGetter = mockApplicationGetter{
-		allWatcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
-		appWatcher: watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		allWatcher:       watchertest.NewMockStringsWatcher(s.applicationChanges),
+		appWatcher:       watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		relationsWatcher: watchertest.NewMockStringsWatcher(s.relationsChange),
+		ingressWatchers: map[string]*watchertest.MockStringsWatcher{
+			"gitlab:website remote-apache:proxy": watchertest.NewMockStringsWatcher(s.ingressChange),
+		},
+		networkPolicy: caas.NetworkPolicyParams{
+			Endpoints: map[string]caas.EndpointIngress{
+				"db": {Applications: []string{"mysql"}},
+			},
+		},
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.allWatcher) })
 
 	s.lifeGetter = mockLifeGetter{
 		life: life.Alive,
 	}
+	s.modelConfigWatcher = mockModelConfigWatcher{
+		watcher: watchertest.NewMockNotifyWatcher(s.modelConfigChange),
+	}
 	s.serviceExposer = mockServiceExposer{
-		exposed:   s.serviceExposed,
-		unexposed: s.serviceUnexposed,
+		exposed:         s.serviceExposed,
+		unexposed:       s.serviceUnexposed,
+		networkPolicies: s.networkPolicies,
 	}
 
 	s.config = caasfirewaller.Config{
-		ControllerUUID:    coretesting.ControllerTag.Id(),
-		ModelUUID:         coretesting.ModelTag.Id(),
-		ApplicationGetter: &s.applicationGetter,
-		ServiceExposer:    &s.serviceExposer,
-		LifeGetter:        &s.lifeGetter,
-		Logger:            loggo.GetLogger("test"),
+		ControllerUUID:     coretesting.ControllerTag.Id(),
+		ModelUUID:          coretesting.ModelTag.Id(),
+		ApplicationGetter:  &s.applicationGetter,
+		ServiceExposer:     &s.serviceExposer,
+		LifeGetter:         &s.lifeGetter,
+		ModelConfigWatcher: &s.modelConfigWatcher,
+		Logger:             loggo.GetLogger("test"),
 	}
 }
 
@@ -97,6 +122,10 @@ func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 		config.LifeGetter = nil
 	}, `missing LifeGetter not valid`)
 
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.ModelConfigWatcher = nil
+	}, `missing ModelConfigWatcher not valid`)
+
 	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
 		config.Logger = nil
 	}, `missing Logger not valid`)
@@ -246,7 +275,7 @@ func (s *WorkerSuite) TestRemoveApplicationStopsWorker(c *gc.C) {
 	// with the worker loop. First time around the loop the
 	// application's alive, then it's gone.
 	//s.lifeGetter.life = life.Dead
-	s.applicationGetter.SetErrors(nil, nil, errors.NotFoundf("application"))
+	s.applicationGetter.SetErrors(nil, nil, nil, errors.NotFoundf("application"))
 
 	w, err := caasfirewaller.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -284,3 +313,162 @@ func (s *WorkerSuite) TestWatcherErrorStopsWorker(c *gc.C) {
 	err = workertest.CheckKilled(c, w)
 	c.Assert(err, gc.ErrorMatches, "splat")
 }
+
+func (s *WorkerSuite) assertNetworkPolicy(c *gc.C) {
+	select {
+	case policy := <-s.networkPolicies:
+		c.Assert(policy, jc.DeepEquals, s.applicationGetter.networkPolicy)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy")
+	}
+}
+
+func (s *WorkerSuite) TestNetworkPolicy(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+	s.assertNetworkPolicy(c)
+
+	s.applicationGetter.networkPolicy = caas.NetworkPolicyParams{
+		Endpoints: map[string]caas.EndpointIngress{
+			"db": {Applications: []string{"mysql", "postgresql"}},
+		},
+	}
+	select {
+	case s.relationsChange <- []string{"gitlab:db postgresql:db"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending relations change")
+	}
+	s.assertNetworkPolicy(c)
+}
+
+func (s *WorkerSuite) assertNoNetworkPolicy(c *gc.C) {
+	select {
+	case <-s.networkPolicies:
+		c.Fatal("unexpected network policy")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestNetworkPolicyModelConfigChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+	s.assertNetworkPolicy(c)
+
+	// Turning network policies on applies the policy again.
+	s.applicationGetter.networkPolicy.Enabled = true
+	s.sendModelConfigChange(c)
+	s.assertNetworkPolicy(c)
+
+	// Other model config changes are ignored.
+	s.sendModelConfigChange(c)
+	s.assertNoNetworkPolicy(c)
+}
+
+func (s *WorkerSuite) sendModelConfigChange(c *gc.C) {
+	select {
+	case s.modelConfigChange <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending model config change")
+	}
+}
+
+func (s *WorkerSuite) TestNetworkPolicyIngressChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	select {
+	case s.relationsChange <- []string{"gitlab:website remote-apache:proxy"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending relations change")
+	}
+	s.assertNetworkPolicy(c)
+
+	// The initial event is skipped, as the policy has just been applied.
+	s.applicationGetter.networkPolicy = caas.NetworkPolicyParams{
+		Endpoints: map[string]caas.EndpointIngress{
+			"website": {CIDRs: []string{"192.168.1.0/24"}},
+		},
+	}
+	for _, change := range [][]string{nil, {"192.168.1.0/24"}} {
+		select {
+		case s.ingressChange <- change:
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out sending ingress networks change")
+		}
+	}
+	s.assertNetworkPolicy(c)
+	s.assertNoNetworkPolicy(c)
+
+	// The relation is only watched once.
+	select {
+	case s.relationsChange <- []string{"gitlab:website remote-apache:proxy"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending relations change")
+	}
+	s.assertNetworkPolicy(c)
+	workertest.CleanKill(c, w)
+	s.applicationGetter.CheckCallNames(c,
+		"WatchApplications", "WatchApplication", "WatchApplicationRelations",
+		"WatchRelationIngressNetworks", "NetworkPolicy", "NetworkPolicy", "NetworkPolicy")
+}
+
+func (s *WorkerSuite) TestNetworkPolicyNotSupported(c *gc.C) {
+	s.applicationGetter.policiesUnsupported = true
+	s.modelConfigWatcher.unsupported = true
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+	select {
+	case <-s.networkPolicies:
+		c.Fatal("unexpected network policy")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.applicationGetter.CheckCallNames(c,
+		"WatchApplications", "WatchApplication", "WatchApplicationRelations", "IsExposed")
+}
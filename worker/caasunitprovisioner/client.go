@@ -34,6 +34,7 @@ type ApplicationGetter interface {
 	ApplicationConfig(string) (application.ConfigAttributes, error)
 	DeploymentMode(string) (caas.DeploymentMode, error)
 	WatchApplicationScale(string) (watcher.NotifyWatcher, error)
+	WatchApplicationConfig(string) (watcher.NotifyWatcher, error)
 	ApplicationScale(string) (int, error)
 }
 
@@ -77,6 +77,19 @@ func (w *deploymentWorker) loop() error {
 	}
 	w.catacomb.Add(appScaleWatcher)
 
+	// Changes to the application config, such as the autoscale settings,
+	// need to be applied to the workload even if the scale and pod spec
+	// are unchanged. Older controllers cannot watch the config.
+	var appConfigChan watcher.NotifyChannel
+	appConfigWatcher, err := w.applicationGetter.WatchApplicationConfig(w.application)
+	if err != nil && !errors.IsNotSupported(err) {
+		return errors.Trace(err)
+	}
+	if err == nil {
+		w.catacomb.Add(appConfigWatcher)
+		appConfigChan = appConfigWatcher.Changes()
+	}
+
 	var (
 		pw            watcher.NotifyWatcher
 		provisionChan watcher.NotifyChannel
@@ -86,6 +99,8 @@ func (w *deploymentWorker) loop() error {
 	)
 
 	gotSpecNotify := false
+	gotScaleNotify := false
+	configChanged := false
 	serviceUpdated := false
 	desiredScale := 0
 	logger := w.logger
@@ -102,6 +117,7 @@ func (w *deploymentWorker) loop() error {
 			if err != nil {
 				return errors.Trace(err)
 			}
+			gotScaleNotify = true
 			logger.Debugf("desiredScale changed to %d", desiredScale)
 			if desiredScale > 0 && provisionChan == nil {
 				var err error
@@ -117,6 +133,15 @@ func (w *deploymentWorker) loop() error {
 				return errors.New("watcher closed channel")
 			}
 			gotSpecNotify = true
+		case _, ok := <-appConfigChan:
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			configChanged = true
+			logger.Debugf("application config changed")
+			if !gotScaleNotify {
+				continue
+			}
 		}
 		if desiredScale > 0 && !gotSpecNotify {
 			continue
@@ -141,10 +166,11 @@ func (w *deploymentWorker) loop() error {
 				return errors.Trace(err)
 			}
 			currentScale = 0
+			configChanged = false
 			continue
 		}
 
-		if desiredScale == currentScale && isProvisionInfoEqual(info, currentInfo) {
+		if desiredScale == currentScale && isProvisionInfoEqual(info, currentInfo) && !configChanged {
 			continue
 		}
 
@@ -176,6 +202,7 @@ func (w *deploymentWorker) loop() error {
 
 		currentScale = desiredScale
 		currentInfo = info
+		configChanged = false
 
 		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
 		if err != nil {
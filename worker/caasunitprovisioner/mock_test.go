@@ -149,6 +149,7 @@ type mockApplicationGetter struct {
 	testing.Stub
 	watcher        *watchertest.MockStringsWatcher
 	scaleWatcher   *watchertest.MockNotifyWatcher
+	configWatcher  *watchertest.MockNotifyWatcher
 	deploymentMode caas.DeploymentMode
 	scale          int
 }
@@ -181,6 +182,14 @@ func (a *mockApplicationGetter) WatchApplicationScale(application string) (watch
 	return a.scaleWatcher, nil
 }
 
+func (a *mockApplicationGetter) WatchApplicationConfig(application string) (watcher.NotifyWatcher, error) {
+	a.MethodCall(a, "WatchApplicationConfig", application)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.configWatcher, nil
+}
+
 func (a *mockApplicationGetter) ApplicationScale(application string) (int, error) {
 	a.MethodCall(a, "ApplicationScale", application)
 	if err := a.NextErr(); err != nil {
@@ -46,17 +46,18 @@ type WorkerSuite struct {
 	unitUpdater        mockUnitUpdater
 	statusSetter       *caasunitprovisioner.MockProvisioningStatusSetter
 
-	applicationChanges      chan []string
-	applicationScaleChanges chan struct{}
-	caasUnitsChanges        chan struct{}
-	caasServiceChanges      chan struct{}
-	caasOperatorChanges     chan struct{}
-	containerSpecChanges    chan struct{}
-	serviceDeleted          chan struct{}
-	serviceEnsured          chan struct{}
-	serviceUpdated          chan struct{}
-	resourcesCleared        chan struct{}
-	clock                   *testclock.Clock
+	applicationChanges       chan []string
+	applicationScaleChanges  chan struct{}
+	applicationConfigChanges chan struct{}
+	caasUnitsChanges         chan struct{}
+	caasServiceChanges       chan struct{}
+	caasOperatorChanges      chan struct{}
+	containerSpecChanges     chan struct{}
+	serviceDeleted           chan struct{}
+	serviceEnsured           chan struct{}
+	serviceUpdated           chan struct{}
+	resourcesCleared         chan struct{}
+	clock                    *testclock.Clock
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -118,6 +119,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationChanges = make(chan []string)
 	s.applicationScaleChanges = make(chan struct{})
+	s.applicationConfigChanges = make(chan struct{})
 	s.caasUnitsChanges = make(chan struct{})
 	s.caasServiceChanges = make(chan struct{})
 	s.caasOperatorChanges = make(chan struct{})
@@ -130,6 +132,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.applicationGetter = mockApplicationGetter{
 		watcher:        watchertest.NewMockStringsWatcher(s.applicationChanges),
 		scaleWatcher:   watchertest.NewMockNotifyWatcher(s.applicationScaleChanges),
+		configWatcher:  watchertest.NewMockNotifyWatcher(s.applicationConfigChanges),
 		deploymentMode: caas.ModeWorkload,
 	}
 	s.applicationUpdater = mockApplicationUpdater{
@@ -301,7 +304,7 @@ func (s *WorkerSuite) TestScaleChangedInJuju(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
 
-	s.applicationGetter.CheckCallNames(c, "WatchApplications", "DeploymentMode", "WatchApplicationScale", "ApplicationScale", "ApplicationConfig")
+	s.applicationGetter.CheckCallNames(c, "WatchApplications", "DeploymentMode", "WatchApplicationScale", "WatchApplicationConfig", "ApplicationScale", "ApplicationConfig")
 	s.podSpecGetter.CheckCallNames(c, "WatchPodSpec", "ProvisioningInfo", "ProvisioningInfo")
 	s.podSpecGetter.CheckCall(c, 0, "WatchPodSpec", "gitlab")
 	s.podSpecGetter.CheckCall(c, 1, "ProvisioningInfo", "gitlab") // not found
@@ -353,6 +356,32 @@ func (s *WorkerSuite) TestScaleChangedInJuju(c *gc.C) {
 		"gitlab", newExpectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestConfigChangedInJuju(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+	// Changing the application config, eg the autoscale settings,
+	// ensures the service again even though the scale is unchanged.
+	select {
+	case s.applicationConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending config change")
+	}
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", getExpectedServiceParams(), 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
 func intPtr(i int) *int {
 	return &i
 }
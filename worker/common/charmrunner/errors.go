@@ -47,3 +47,34 @@ func IsMissingHookError(err error) bool {
 func NewMissingHookError(hookName string) error {
 	return &missingHookError{hookName}
 }
+
+type hookKilledError struct {
+	reason string
+}
+
+func (e *hookKilledError) Error() string {
+	return "hook killed: " + e.reason
+}
+
+// IsHookKilledError returns true if the error indicates that the hook
+// was killed before it completed, either because it ran for longer than
+// the model's hook-timeout or because an operator asked for it.
+func IsHookKilledError(err error) bool {
+	_, ok := err.(*hookKilledError)
+	return ok
+}
+
+// NewHookKilledError returns an error indicating that the hook was
+// killed for the supplied reason.
+func NewHookKilledError(reason string) error {
+	return &hookKilledError{reason}
+}
+
+// HookKilledReason returns the reason recorded in a hook killed error,
+// or the empty string if the error is not one.
+func HookKilledReason(err error) string {
+	if e, ok := err.(*hookKilledError); ok {
+		return e.reason
+	}
+	return ""
+}
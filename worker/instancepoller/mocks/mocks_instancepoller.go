@@ -89,6 +89,18 @@ func (m *MockMachine) EXPECT() *MockMachineMockRecorder {
 	return m.recorder
 }
 
+// ApplySpotInterruptionPolicy mocks base method
+func (m *MockMachine) ApplySpotInterruptionPolicy() error {
+	ret := m.ctrl.Call(m, "ApplySpotInterruptionPolicy")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplySpotInterruptionPolicy indicates an expected call of ApplySpotInterruptionPolicy
+func (mr *MockMachineMockRecorder) ApplySpotInterruptionPolicy() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySpotInterruptionPolicy", reflect.TypeOf((*MockMachine)(nil).ApplySpotInterruptionPolicy))
+}
+
 // Id mocks base method
 func (m *MockMachine) Id() string {
 	ret := m.ctrl.Call(m, "Id")
@@ -64,6 +64,7 @@ type Machine interface {
 	Status() (params.StatusResult, error)
 	Life() life.Value
 	IsManual() (bool, error)
+	ApplySpotInterruptionPolicy() error
 }
 
 // FacadeAPI specifies the api-server methods needed by the instance
@@ -365,7 +366,27 @@ func (u *updaterWorker) pollGroupMembers(groupType pollGroupType) error {
 		return errors.Trace(err)
 	}
 
+	interrupted := u.interruptedInstances(instList)
+	for id, message := range interrupted {
+		entry := u.instanceIDToGroupEntry[id]
+		if entry == nil {
+			continue
+		}
+		if err := u.markInterrupted(entry, message); err != nil {
+			return errors.Trace(err)
+		}
+		if groupType == shortPollGroup {
+			entry.bumpShortPollInterval(u.config.Clock)
+		}
+	}
+
 	for idx, info := range infoList {
+		// Interrupted instances have already been dealt with; the
+		// status reported by the provider would only mask that.
+		if _, ok := interrupted[instList[idx]]; ok {
+			continue
+		}
+
 		// No details found for this instance. This most probably means
 		// that the unit has been killed and we haven't been notified
 		// yet. Log the error and keep going.
@@ -380,22 +401,75 @@ func (u *updaterWorker) pollGroupMembers(groupType pollGroupType) error {
 		}
 
 		entry := u.instanceIDToGroupEntry[instList[idx]]
-		providerStatus, providerAddrCount, err := u.processProviderInfo(entry, info, ifList)
+		machineStatus, err := entry.m.Status()
 		if err != nil {
 			return errors.Trace(err)
 		}
+		suspended := status.Status(machineStatus.Status) == status.Suspended
 
-		machineStatus, err := entry.m.Status()
+		providerStatus, providerAddrCount, err := u.processProviderInfo(entry, info, ifList, suspended)
 		if err != nil {
 			return errors.Trace(err)
 		}
 
+		if suspended {
+			// A suspended machine is not expected to change until it
+			// is resumed, which resets its machine status.
+			u.moveEntryToPollGroup(longPollGroup, entry)
+			continue
+		}
 		u.maybeSwitchPollGroup(groupType, entry, providerStatus, status.Status(machineStatus.Status), providerAddrCount)
 	}
 
 	return nil
 }
 
+// interruptedInstances returns the instances in instList that the provider
+// has interrupted, keyed by ID, with the reason for each. Providers that do
+// not support interruptible instances report none.
+func (u *updaterWorker) interruptedInstances(instList []instance.Id) map[instance.Id]string {
+	lister, ok := u.config.Environ.(environs.InterruptedInstanceLister)
+	if !ok {
+		return nil
+	}
+	interrupted, err := lister.InterruptedInstances(u.callContext, instList)
+	if err != nil {
+		// Not knowing about interruptions is no reason to stop
+		// polling the instances' status and addresses.
+		u.config.Logger.Warningf("cannot list interrupted instances: %v", err)
+		return nil
+	}
+	return interrupted
+}
+
+// markInterrupted sets the instance status of an entry's machine to
+// interrupted, unless that is already its status. When the machine is
+// first marked interrupted, the model's spot-interruption-policy is
+// applied to it, which may add units to replace its own.
+func (u *updaterWorker) markInterrupted(entry *pollGroupEntry, message string) error {
+	curStatus, err := entry.m.InstanceStatus()
+	if err != nil {
+		u.config.Logger.Warningf("cannot get current instance status for machine %v (instance ID %q): %v", entry.m.Id(), entry.instanceID, err)
+		return nil
+	}
+	if status.Status(curStatus.Status) == status.Interrupted && curStatus.Info == message {
+		return nil
+	}
+	u.config.Logger.Infof("machine %q (instance ID %q) was interrupted: %s", entry.m.Id(), entry.instanceID, message)
+	if status.Status(curStatus.Status) != status.Interrupted {
+		// The policy is applied before the status is set, so that it
+		// is tried again on the next poll if it fails.
+		if err := entry.m.ApplySpotInterruptionPolicy(); err != nil {
+			return errors.Annotatef(err, "applying spot interruption policy to machine %q", entry.m.Id())
+		}
+	}
+	if err := entry.m.SetInstanceStatus(status.Interrupted, message, nil); err != nil {
+		u.config.Logger.Errorf("cannot set instance status on %q: %v", entry.m, err)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (u *updaterWorker) resolveInstanceID(entry *pollGroupEntry) error {
 	if entry.instanceID != "" {
 		return nil // already resolved
@@ -414,8 +488,11 @@ func (u *updaterWorker) resolveInstanceID(entry *pollGroupEntry) error {
 // processProviderInfo updates an entry's machine status and set of provider
 // addresses based on the information collected from the provider. It returns
 // back the *instance* status and the number of provider addresses currently
-// known for the machine.
-func (u *updaterWorker) processProviderInfo(entry *pollGroupEntry, info instances.Instance, providerIfaceList network.InterfaceInfos) (status.Status, int, error) {
+// known for the machine. The addresses of suspended machines are left alone,
+// as a powered off instance may not report them.
+func (u *updaterWorker) processProviderInfo(
+	entry *pollGroupEntry, info instances.Instance, providerIfaceList network.InterfaceInfos, suspended bool,
+) (status.Status, int, error) {
 	curStatus, err := entry.m.InstanceStatus()
 	if err != nil {
 		// This should never occur since the machine is provisioned. If
@@ -452,6 +529,9 @@ func (u *updaterWorker) processProviderInfo(entry *pollGroupEntry, info instance
 	if entry.m.Life() == life.Dead {
 		return status.Unknown, -1, nil
 	}
+	if suspended {
+		return providerStatus.Status, -1, nil
+	}
 
 	// Check whether the provider addresses for this machine need to be
 	// updated.
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/instances"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/instancepoller/mocks"
@@ -217,7 +218,7 @@ func (s *workerSuite) TestUpdateOfStatusAndAddressDetails(c *gc.C) {
 	machine.EXPECT().SetInstanceStatus(status.Running, "Running wild", nil).Return(nil)
 	machine.EXPECT().SetProviderNetworkConfig(testNetIfs).Return(testAddrs, true, nil)
 
-	providerStatus, addrCount, err := updWorker.processProviderInfo(entry, instInfo, testNetIfs)
+	providerStatus, addrCount, err := updWorker.processProviderInfo(entry, instInfo, testNetIfs, false)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(providerStatus, gc.Equals, status.Running)
 	c.Assert(addrCount, gc.Equals, len(testAddrs))
@@ -565,6 +566,180 @@ func (s *workerSuite) TestLongPollNoMachineInGroupKnownByProvider(c *gc.C) {
 	})
 }
 
+func (s *workerSuite) startInterruptibleWorker(c *gc.C, ctrl *gomock.Controller, interrupted map[instance.Id]string, err error) (worker.Worker, workerMocks) {
+	return s.startWorkerWithEnviron(c, ctrl, func(env *mocks.MockEnviron) Environ {
+		return &interruptibleEnviron{MockEnviron: env, interrupted: interrupted, err: err}
+	})
+}
+
+func (s *workerSuite) TestInterruptedInstanceStatusIsSet(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	instID := instance.Id("b4dc0ffee")
+	w, mocked := s.startInterruptibleWorker(c, ctrl, map[instance.Id]string{
+		instID: "spot instance interrupted",
+	}, nil)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+	machine.EXPECT().Id().Return("0").AnyTimes()
+	machine.EXPECT().InstanceId().Return(instID, nil)
+	machine.EXPECT().InstanceStatus().Return(params.StatusResult{Status: string(status.Running)}, nil)
+	gomock.InOrder(
+		machine.EXPECT().ApplySpotInterruptionPolicy().Return(nil),
+		machine.EXPECT().SetInstanceStatus(status.Interrupted, "spot instance interrupted", nil).Return(nil),
+	)
+	updWorker.appendToShortPollGroup(machineTag, machine)
+
+	// The provider no longer reports interrupted instances; they must
+	// still be marked as interrupted.
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{instID}).Return(
+		nil, environs.ErrNoInstances,
+	)
+	mocked.environ.EXPECT().NetworkInterfaces(gomock.Any(), []instance.Id{instID}).Return(
+		nil, errors.NotSupportedf("network interfaces"),
+	)
+
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(ShortPoll)
+	})
+}
+
+func (s *workerSuite) TestInterruptionPolicyErrorLeavesStatus(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	instID := instance.Id("b4dc0ffee")
+	w, mocked := s.startInterruptibleWorker(c, ctrl, map[instance.Id]string{
+		instID: "spot instance interrupted",
+	}, nil)
+	defer workertest.DirtyKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	// The instance status is not set, so the policy is applied again
+	// when the machine is next polled.
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+	machine.EXPECT().Id().Return("0").AnyTimes()
+	machine.EXPECT().InstanceId().Return(instID, nil)
+	machine.EXPECT().InstanceStatus().Return(params.StatusResult{Status: string(status.Running)}, nil)
+	machine.EXPECT().ApplySpotInterruptionPolicy().Return(errors.New("boom"))
+	updWorker.appendToShortPollGroup(machineTag, machine)
+
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{instID}).Return(
+		nil, environs.ErrNoInstances,
+	)
+	mocked.environ.EXPECT().NetworkInterfaces(gomock.Any(), []instance.Id{instID}).Return(
+		nil, errors.NotSupportedf("network interfaces"),
+	)
+
+	mocked.clock.Advance(ShortPoll)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `applying spot interruption policy to machine "0": boom`)
+}
+
+func (s *workerSuite) TestSuspendedMachineKeepsAddressesAndMovesToLongPollGroup(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, mocked := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	// The machine has been suspended, so its instance is stopped and the
+	// provider no longer reports its addresses. SetProviderNetworkConfig
+	// must not be called, or the machine would lose its addresses.
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+	machine.EXPECT().Id().Return("0").AnyTimes()
+	machine.EXPECT().Life().Return(life.Alive)
+	machine.EXPECT().InstanceId().Return(instance.Id("b4dc0ffee"), nil)
+	machine.EXPECT().InstanceStatus().Return(params.StatusResult{Status: string(status.Running)}, nil)
+	machine.EXPECT().Status().Return(params.StatusResult{Status: string(status.Suspended)}, nil)
+	machine.EXPECT().SetInstanceStatus(status.Stopped, "stopped", nil).Return(nil)
+	updWorker.appendToShortPollGroup(machineTag, machine)
+
+	instInfo := mocks.NewMockInstance(ctrl)
+	instInfo.EXPECT().Status(gomock.Any()).Return(instance.Status{Status: status.Stopped, Message: "stopped"})
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"b4dc0ffee"}).Return([]instances.Instance{instInfo}, nil)
+	mocked.environ.EXPECT().NetworkInterfaces(gomock.Any(), []instance.Id{"b4dc0ffee"}).Return(
+		[]network.InterfaceInfos{nil}, nil,
+	)
+
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(ShortPoll)
+	})
+	c.Assert(updWorker.pollGroup[shortPollGroup], gc.HasLen, 0)
+	c.Assert(updWorker.pollGroup[longPollGroup], gc.HasLen, 1)
+}
+
+func (s *workerSuite) TestInterruptedInstanceStatusIsNotReset(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	instID := instance.Id("b4dc0ffee")
+	w, mocked := s.startInterruptibleWorker(c, ctrl, map[instance.Id]string{
+		instID: "spot virtual machine evicted",
+	}, nil)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+	machine.EXPECT().InstanceId().Return(instID, nil)
+	machine.EXPECT().InstanceStatus().Return(params.StatusResult{
+		Status: string(status.Interrupted),
+		Info:   "spot virtual machine evicted",
+	}, nil)
+	updWorker.appendToShortPollGroup(machineTag, machine)
+
+	// The instance info is not consulted, so its status (running, for
+	// evicted azure virtual machines) does not overwrite interrupted.
+	instInfo := mocks.NewMockInstance(ctrl)
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{instID}).Return(
+		[]instances.Instance{instInfo}, nil,
+	)
+	mocked.environ.EXPECT().NetworkInterfaces(gomock.Any(), []instance.Id{instID}).Return(
+		[]network.InterfaceInfos{testNetIfs}, nil,
+	)
+
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(ShortPoll)
+	})
+}
+
+func (s *workerSuite) TestInterruptedInstancesErrorIsIgnored(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, mocked := s.startInterruptibleWorker(c, ctrl, nil, errors.New("boom"))
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+	machine.EXPECT().Life().Return(life.Alive)
+	machine.EXPECT().InstanceId().Return(instance.Id("b4dc0ffee"), nil)
+	machine.EXPECT().InstanceStatus().Return(params.StatusResult{Status: string(status.Running)}, nil)
+	machine.EXPECT().Status().Return(params.StatusResult{Status: string(status.Started)}, nil)
+	machine.EXPECT().SetProviderNetworkConfig(testNetIfs).Return(testAddrs, false, nil)
+	updWorker.appendToShortPollGroup(machineTag, machine)
+
+	instInfo := mocks.NewMockInstance(ctrl)
+	instInfo.EXPECT().Status(gomock.Any()).Return(instance.Status{Status: status.Running})
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"b4dc0ffee"}).Return([]instances.Instance{instInfo}, nil)
+	mocked.environ.EXPECT().NetworkInterfaces(gomock.Any(), []instance.Id{"b4dc0ffee"}).Return(
+		[]network.InterfaceInfos{testNetIfs}, nil,
+	)
+
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(ShortPoll)
+	})
+}
+
 func (s *workerSuite) assertWorkerCompletesLoop(c *gc.C, w *updaterWorker, triggerFn func()) {
 	s.assertWorkerCompletesLoops(c, w, 1, triggerFn)
 }
@@ -592,6 +767,12 @@ type workerMocks struct {
 }
 
 func (s *workerSuite) startWorker(c *gc.C, ctrl *gomock.Controller) (worker.Worker, workerMocks) {
+	return s.startWorkerWithEnviron(c, ctrl, func(env *mocks.MockEnviron) Environ { return env })
+}
+
+// startWorkerWithEnviron starts a worker whose Environ is the mock environ
+// wrapped by wrapEnviron.
+func (s *workerSuite) startWorkerWithEnviron(c *gc.C, ctrl *gomock.Controller, wrapEnviron func(*mocks.MockEnviron) Environ) (worker.Worker, workerMocks) {
 	workerMainLoopEnteredCh := make(chan struct{}, 1)
 	mocked := workerMocks{
 		clock:     testclock.NewClock(time.Now()),
@@ -602,7 +783,7 @@ func (s *workerSuite) startWorker(c *gc.C, ctrl *gomock.Controller) (worker.Work
 	w, err := NewWorker(Config{
 		Clock:         mocked.clock,
 		Facade:        mocked.facadeAPI,
-		Environ:       mocked.environ,
+		Environ:       wrapEnviron(mocked.environ),
 		CredentialAPI: mocks.NewMockCredentialAPI(ctrl),
 		Logger:        loggo.GetLogger("juju.worker.instancepoller"),
 	})
@@ -619,6 +800,28 @@ func (s *workerSuite) startWorker(c *gc.C, ctrl *gomock.Controller) (worker.Work
 	return w, mocked
 }
 
+// interruptibleEnviron is an Environ that also implements
+// environs.InterruptedInstanceLister.
+type interruptibleEnviron struct {
+	*mocks.MockEnviron
+
+	interrupted map[instance.Id]string
+	err         error
+}
+
+func (e *interruptibleEnviron) InterruptedInstances(_ context.ProviderCallContext, ids []instance.Id) (map[instance.Id]string, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	result := make(map[instance.Id]string)
+	for _, id := range ids {
+		if message, ok := e.interrupted[id]; ok {
+			result[id] = message
+		}
+	}
+	return result, nil
+}
+
 // mockFacadeAPI is a workaround for not being able to use gomock for the
 // FacadeAPI interface. Because the Machine() method returns a Machine interface,
 // gomock will import instancepoller and cause an import cycle.
@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"math/rand"
 	"path"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -92,6 +94,20 @@ func (ctx *limitedContext) ModelType() model.ModelType {
 // SetProcess implements runner.Context.
 func (ctx *limitedContext) SetProcess(process context.HookProcess) {}
 
+// KillCharmHook implements runner.Context.
+func (ctx *limitedContext) KillCharmHook() error {
+	return charmrunner.ErrNoProcess
+}
+
+// HookTimeout implements runner.Context.
+func (ctx *limitedContext) HookTimeout() time.Duration { return 0 }
+
+// Clock implements runner.Context.
+func (ctx *limitedContext) Clock() context.Clock { return ctx.config.clock }
+
+// RecordHookRun implements runner.Context.
+func (ctx *limitedContext) RecordHookRun(run context.HookRun) {}
+
 // ActionData implements runner.Context.
 func (ctx *limitedContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/metrics/spool"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -106,6 +107,20 @@ func (ctx *hookContext) addJujuUnitsMetric() error {
 // SetProcess implements runner.Context.
 func (ctx *hookContext) SetProcess(process context.HookProcess) {}
 
+// KillCharmHook implements runner.Context.
+func (ctx *hookContext) KillCharmHook() error {
+	return charmrunner.ErrNoProcess
+}
+
+// HookTimeout implements runner.Context.
+func (ctx *hookContext) HookTimeout() time.Duration { return 0 }
+
+// Clock implements runner.Context.
+func (ctx *hookContext) Clock() context.Clock { return ctx.config.clock }
+
+// RecordHookRun implements runner.Context.
+func (ctx *hookContext) RecordHookRun(run context.HookRun) {}
+
 // ActionData implements runner.Context.
 func (ctx *hookContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
@@ -90,6 +90,7 @@ type stopper interface {
 // Clock represents time methods used by this package.
 type Clock interface {
 	Now() time.Time
+	After(time.Duration) <-chan time.Time
 }
 
 // Logger represents the logging methods used in this package.
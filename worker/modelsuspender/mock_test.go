@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender_test
+
+import (
+	"sync"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/api/modelsuspender"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/context"
+)
+
+// mockFacade stands in for both the controller and the unit agents: it
+// moves units straight on to the next suspend status, as if their
+// pre-suspend and post-resume hooks ran instantly.
+type mockFacade struct {
+	testing.Stub
+
+	mu         sync.Mutex
+	changes    chan struct{}
+	suspension modelsuspender.Suspension
+	stages     []modelsuspender.Stage
+}
+
+func (f *mockFacade) WatchModelSuspension() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchModelSuspension")
+	return watchertest.NewMockNotifyWatcher(f.changes), f.NextErr()
+}
+
+func (f *mockFacade) ModelSuspension() (modelsuspender.Suspension, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.suspension, nil
+}
+
+func (f *mockFacade) SetModelSuspendRequested(requested bool) error {
+	f.MethodCall(f, "SetModelSuspendRequested", requested)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.suspension.Requested = requested
+	return f.NextErr()
+}
+
+func (f *mockFacade) SuspensionPlan() ([]modelsuspender.Stage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stages := make([]modelsuspender.Stage, len(f.stages))
+	for i, stage := range f.stages {
+		for _, m := range stage.Machines {
+			m.Units = append([]modelsuspender.Unit(nil), m.Units...)
+			stages[i].Machines = append(stages[i].Machines, m)
+		}
+	}
+	return stages, nil
+}
+
+func (f *mockFacade) SetUnitSuspendStatus(suspendStatus model.UnitSuspendStatus, units ...names.UnitTag) error {
+	f.MethodCall(f, "SetUnitSuspendStatus", suspendStatus, units)
+	next := map[model.UnitSuspendStatus]model.UnitSuspendStatus{
+		model.UnitSuspending: model.UnitSuspended,
+		model.UnitResuming:   model.UnitSuspendNotStarted,
+	}[suspendStatus]
+	f.update(func(m *modelsuspender.Machine) {
+		for i, u := range m.Units {
+			for _, tag := range units {
+				if u.Tag == tag {
+					m.Units[i].SuspendStatus = next
+				}
+			}
+		}
+	})
+	return f.NextErr()
+}
+
+func (f *mockFacade) SetMachineStatus(tag names.MachineTag, machineStatus status.Status, info string) error {
+	f.MethodCall(f, "SetMachineStatus", tag, machineStatus, info)
+	f.update(func(m *modelsuspender.Machine) {
+		if m.Tag == tag {
+			m.Status = machineStatus
+		}
+	})
+	return f.NextErr()
+}
+
+func (f *mockFacade) update(fn func(*modelsuspender.Machine)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, stage := range f.stages {
+		for i := range stage.Machines {
+			fn(&stage.Machines[i])
+		}
+	}
+}
+
+type mockEnviron struct {
+	testing.Stub
+}
+
+func (e *mockEnviron) SuspendInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	e.MethodCall(e, "SuspendInstances", ids)
+	return e.NextErr()
+}
+
+func (e *mockEnviron) ResumeInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	e.MethodCall(e, "ResumeInstances", ids)
+	return e.NextErr()
+}
+
+type mockCredentialAPI struct{}
+
+func (mockCredentialAPI) InvalidateModelCredential(string) error {
+	return nil
+}
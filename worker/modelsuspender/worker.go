@@ -0,0 +1,388 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/api/modelsuspender"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/worker/common"
+)
+
+// UnitPoll is how often the worker checks whether the units in a stage
+// have finished running their pre-suspend or post-resume hooks, and
+// UnitTimeout is how long it waits for them before carrying on anyway.
+var (
+	UnitPoll    = 5 * time.Second
+	UnitTimeout = 10 * time.Minute
+)
+
+// Facade exposes the controller methods needed by the model suspender.
+type Facade interface {
+	WatchModelSuspension() (watcher.NotifyWatcher, error)
+	ModelSuspension() (modelsuspender.Suspension, error)
+	SetModelSuspendRequested(bool) error
+	SuspensionPlan() ([]modelsuspender.Stage, error)
+	SetUnitSuspendStatus(model.UnitSuspendStatus, ...names.UnitTag) error
+	SetMachineStatus(names.MachineTag, status.Status, string) error
+}
+
+// Config holds the resources and configuration needed to run the model
+// suspender worker.
+type Config struct {
+	Facade  Facade
+	Environ environs.InstanceSuspender
+	Clock   clock.Clock
+	Logger  Logger
+
+	CredentialAPI common.CredentialAPI
+}
+
+// Validate checks whether the worker configuration settings are valid.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.CredentialAPI == nil {
+		return errors.NotValidf("nil CredentialAPI")
+	}
+	return nil
+}
+
+type suspenderWorker struct {
+	config      Config
+	catacomb    catacomb.Catacomb
+	callContext context.ProviderCallContext
+
+	// schedule and scheduleSuspended record the suspend schedule last
+	// seen by the worker, and whether it asked for the model to be
+	// suspended at that time. The worker only changes the model's
+	// suspend request when either of them changes, so that a manual
+	// suspend-model or resume-model holds until the next scheduled
+	// transition.
+	schedule          string
+	scheduleSuspended bool
+	scheduleKnown     bool
+}
+
+// NewWorker returns a worker that suspends the model's machines when
+// the suspension of the model is requested, either by suspend-model or
+// by the model's suspend schedule, and resumes them when it is no
+// longer requested.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &suspenderWorker{
+		config:      config,
+		callContext: common.NewCloudCallContext(config.CredentialAPI, nil),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *suspenderWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *suspenderWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *suspenderWorker) loop() error {
+	watcher, err := w.config.Facade.WatchModelSuspension()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var scheduleTimer clock.Timer
+	defer func() {
+		if scheduleTimer != nil {
+			_ = scheduleTimer.Stop()
+		}
+	}()
+	for {
+		var scheduleChanges <-chan time.Time
+		if scheduleTimer != nil {
+			scheduleChanges = scheduleTimer.Chan()
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("model suspension watcher closed")
+			}
+		case <-scheduleChanges:
+		}
+
+		requested, next, err := w.checkSchedule()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if scheduleTimer != nil {
+			_ = scheduleTimer.Stop()
+			scheduleTimer = nil
+		}
+		if !next.IsZero() {
+			scheduleTimer = w.config.Clock.NewTimer(next.Sub(w.config.Clock.Now()))
+		}
+		if err := w.converge(requested); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// checkSchedule applies the model's suspend schedule, and returns
+// whether the suspension of the model is requested and when the
+// schedule next changes. The returned time is zero if there is no
+// schedule, or it never changes.
+func (w *suspenderWorker) checkSchedule() (bool, time.Time, error) {
+	suspension, err := w.config.Facade.ModelSuspension()
+	if err != nil {
+		return false, time.Time{}, errors.Trace(err)
+	}
+	schedule, err := model.ParseSuspendSchedule(suspension.Schedule)
+	if err != nil {
+		return false, time.Time{}, errors.Trace(err)
+	}
+	if schedule == nil {
+		w.schedule, w.scheduleKnown = "", true
+		return suspension.Requested, time.Time{}, nil
+	}
+
+	now := w.config.Clock.Now()
+	suspended := schedule.Suspended(now)
+	changed := !w.scheduleKnown ||
+		w.schedule != suspension.Schedule ||
+		w.scheduleSuspended != suspended
+	// The first time the worker sees the schedule it only records it;
+	// the worker may have been restarted after the model was manually
+	// suspended or resumed.
+	act := w.scheduleKnown && changed && suspended != suspension.Requested
+	w.schedule, w.scheduleSuspended, w.scheduleKnown = suspension.Schedule, suspended, true
+	if act {
+		w.config.Logger.Infof("suspend schedule %q: setting suspend requested to %v", suspension.Schedule, suspended)
+		if err := w.config.Facade.SetModelSuspendRequested(suspended); err != nil {
+			return false, time.Time{}, errors.Trace(err)
+		}
+		suspension.Requested = suspended
+	}
+	return suspension.Requested, schedule.NextChange(now), nil
+}
+
+// converge suspends or resumes the model's machines, a stage at a time.
+// It stops early if the suspension of the model is requested or
+// cancelled part way through; the watcher will then trigger another
+// run in the other direction.
+func (w *suspenderWorker) converge(suspend bool) error {
+	stages, err := w.config.Facade.SuspensionPlan()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i := range stages {
+		stage := stages[i]
+		if !suspend {
+			stage = stages[len(stages)-1-i]
+		}
+		var done bool
+		if suspend {
+			done, err = w.suspendStage(stage)
+		} else {
+			done, err = w.resumeStage(stage)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if done {
+			continue
+		}
+		suspension, err := w.config.Facade.ModelSuspension()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if suspension.Requested != suspend {
+			w.config.Logger.Infof("suspend requested changed to %v, stopping", suspension.Requested)
+			return nil
+		}
+	}
+	return nil
+}
+
+// suspendStage runs the pre-suspend hook of the units on the stage's
+// running machines, then powers the machines off. It returns true if
+// there was nothing to do.
+func (w *suspenderWorker) suspendStage(stage modelsuspender.Stage) (bool, error) {
+	var (
+		machines []names.MachineTag
+		ids      []instance.Id
+		units    []names.UnitTag
+	)
+	for _, m := range stage.Machines {
+		if m.Status == status.Suspended {
+			continue
+		}
+		machines = append(machines, m.Tag)
+		ids = append(ids, m.InstanceId)
+		for _, u := range m.Units {
+			switch u.SuspendStatus {
+			case model.UnitSuspendNotStarted, model.UnitResuming:
+				units = append(units, u.Tag)
+			}
+		}
+	}
+	if len(machines) == 0 {
+		return true, nil
+	}
+
+	if len(units) > 0 {
+		if err := w.config.Facade.SetUnitSuspendStatus(model.UnitSuspending, units...); err != nil {
+			return false, errors.Annotate(err, "requesting units to suspend")
+		}
+	}
+	if err := w.waitForUnits(machines, model.UnitSuspended); err != nil {
+		return false, errors.Trace(err)
+	}
+
+	// The machines are only marked suspended once the provider has
+	// powered them off, so that a failed attempt is retried in full.
+	w.config.Logger.Infof("suspending machines %v", machines)
+	if err := w.config.Environ.SuspendInstances(w.callContext, ids...); err != nil {
+		return false, errors.Annotatef(err, "suspending machines %v", machines)
+	}
+	for _, tag := range machines {
+		if err := w.config.Facade.SetMachineStatus(tag, status.Suspended, ""); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+	return false, nil
+}
+
+// resumeStage powers the stage's suspended machines on, then runs the
+// post-resume hook of the units on them. It returns true if there was
+// nothing to do.
+func (w *suspenderWorker) resumeStage(stage modelsuspender.Stage) (bool, error) {
+	var (
+		suspended []names.MachineTag
+		machines  []names.MachineTag
+		ids       []instance.Id
+		units     []names.UnitTag
+	)
+	for _, m := range stage.Machines {
+		if m.Status == status.Suspended {
+			suspended = append(suspended, m.Tag)
+			ids = append(ids, m.InstanceId)
+		}
+		for _, u := range m.Units {
+			switch u.SuspendStatus {
+			case model.UnitSuspending, model.UnitSuspended:
+				units = append(units, u.Tag)
+			}
+		}
+		machines = append(machines, m.Tag)
+	}
+	if len(suspended) == 0 && len(units) == 0 {
+		return true, nil
+	}
+
+	if len(suspended) > 0 {
+		w.config.Logger.Infof("resuming machines %v", suspended)
+		if err := w.config.Environ.ResumeInstances(w.callContext, ids...); err != nil {
+			return false, errors.Annotatef(err, "resuming machines %v", suspended)
+		}
+		for _, tag := range suspended {
+			if err := w.config.Facade.SetMachineStatus(tag, status.Started, "resuming"); err != nil {
+				return false, errors.Trace(err)
+			}
+		}
+	}
+	if len(units) > 0 {
+		if err := w.config.Facade.SetUnitSuspendStatus(model.UnitResuming, units...); err != nil {
+			return false, errors.Annotate(err, "requesting units to resume")
+		}
+	}
+	return false, errors.Trace(w.waitForUnits(machines, model.UnitSuspendNotStarted))
+}
+
+// waitForUnits waits for the units on the given machines to reach the
+// given suspend status, giving up after UnitTimeout.
+func (w *suspenderWorker) waitForUnits(machines []names.MachineTag, want model.UnitSuspendStatus) error {
+	timeout := w.config.Clock.After(UnitTimeout)
+	for {
+		pending, err := w.pendingUnits(machines, want)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timeout:
+			w.config.Logger.Warningf("timed out waiting for units %v, continuing", pending)
+			return nil
+		case <-w.config.Clock.After(UnitPoll):
+		}
+	}
+}
+
+// pendingUnits returns the units on the given machines that have not
+// reached the given suspend status.
+func (w *suspenderWorker) pendingUnits(machines []names.MachineTag, want model.UnitSuspendStatus) ([]names.UnitTag, error) {
+	stages, err := w.config.Facade.SuspensionPlan()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	wanted := make(map[names.MachineTag]bool)
+	for _, tag := range machines {
+		wanted[tag] = true
+	}
+	var pending []names.UnitTag
+	for _, stage := range stages {
+		for _, m := range stage.Machines {
+			if !wanted[m.Tag] {
+				continue
+			}
+			for _, u := range m.Units {
+				if u.SuspendStatus != want {
+					pending = append(pending, u.Tag)
+				}
+			}
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelsuspender_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	apimodelsuspender "github.com/juju/juju/api/modelsuspender"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/modelsuspender"
+)
+
+type workerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testclock.Clock
+	facade  *mockFacade
+	environ *mockEnviron
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	// A Monday morning.
+	s.clock = testclock.NewClock(time.Date(2020, 10, 5, 9, 0, 0, 0, time.UTC))
+	s.facade = &mockFacade{
+		changes: make(chan struct{}, 1),
+		// wordpress requires mysql, so it is suspended first.
+		stages: []apimodelsuspender.Stage{{
+			Machines: []apimodelsuspender.Machine{{
+				Tag:        names.NewMachineTag("1"),
+				InstanceId: "inst-1",
+				Status:     status.Started,
+				Units:      []apimodelsuspender.Unit{{Tag: names.NewUnitTag("wordpress/0")}},
+			}},
+		}, {
+			Machines: []apimodelsuspender.Machine{{
+				Tag:        names.NewMachineTag("0"),
+				InstanceId: "inst-0",
+				Status:     status.Started,
+				Units:      []apimodelsuspender.Unit{{Tag: names.NewUnitTag("mysql/0")}},
+			}},
+		}},
+	}
+	s.environ = &mockEnviron{}
+}
+
+func (s *workerSuite) config() modelsuspender.Config {
+	return modelsuspender.Config{
+		Facade:        s.facade,
+		Environ:       s.environ,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+		CredentialAPI: mockCredentialAPI{},
+	}
+}
+
+func (s *workerSuite) TestValidateConfig(c *gc.C) {
+	for _, test := range []struct {
+		mutate func(*modelsuspender.Config)
+		err    string
+	}{
+		{func(cfg *modelsuspender.Config) { cfg.Facade = nil }, "nil Facade not valid"},
+		{func(cfg *modelsuspender.Config) { cfg.Environ = nil }, "nil Environ not valid"},
+		{func(cfg *modelsuspender.Config) { cfg.Clock = nil }, "nil Clock not valid"},
+		{func(cfg *modelsuspender.Config) { cfg.Logger = nil }, "nil Logger not valid"},
+		{func(cfg *modelsuspender.Config) { cfg.CredentialAPI = nil }, "nil CredentialAPI not valid"},
+	} {
+		config := s.config()
+		test.mutate(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+// waitForCalls waits until the stub has recorded the given number of
+// calls, and returns them.
+func waitForCalls(c *gc.C, stub *jujutesting.Stub, n int) []jujutesting.StubCall {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if calls := stub.Calls(); len(calls) >= n {
+			return calls
+		}
+	}
+	c.Fatalf("timed out waiting for %d calls, got %v", n, stub.Calls())
+	return nil
+}
+
+func (s *workerSuite) TestSuspendsInStageOrder(c *gc.C) {
+	s.facade.suspension.Requested = true
+	w, err := modelsuspender.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.facade.changes <- struct{}{}
+
+	waitForCalls(c, &s.environ.Stub, 2)
+	s.environ.CheckCalls(c, []jujutesting.StubCall{
+		{"SuspendInstances", []interface{}{[]instance.Id{"inst-1"}}},
+		{"SuspendInstances", []interface{}{[]instance.Id{"inst-0"}}},
+	})
+	s.facade.CheckCalls(c, []jujutesting.StubCall{
+		{"WatchModelSuspension", nil},
+		{"SetUnitSuspendStatus", []interface{}{model.UnitSuspending, []names.UnitTag{names.NewUnitTag("wordpress/0")}}},
+		{"SetMachineStatus", []interface{}{names.NewMachineTag("1"), status.Suspended, ""}},
+		{"SetUnitSuspendStatus", []interface{}{model.UnitSuspending, []names.UnitTag{names.NewUnitTag("mysql/0")}}},
+		{"SetMachineStatus", []interface{}{names.NewMachineTag("0"), status.Suspended, ""}},
+	})
+}
+
+func (s *workerSuite) TestResumesInReverseStageOrder(c *gc.C) {
+	for _, stage := range s.facade.stages {
+		stage.Machines[0].Status = status.Suspended
+		stage.Machines[0].Units[0].SuspendStatus = model.UnitSuspended
+	}
+	w, err := modelsuspender.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.facade.changes <- struct{}{}
+
+	waitForCalls(c, &s.facade.Stub, 5)
+	workertest.CleanKill(c, w)
+	s.environ.CheckCalls(c, []jujutesting.StubCall{
+		{"ResumeInstances", []interface{}{[]instance.Id{"inst-0"}}},
+		{"ResumeInstances", []interface{}{[]instance.Id{"inst-1"}}},
+	})
+	s.facade.CheckCalls(c, []jujutesting.StubCall{
+		{"WatchModelSuspension", nil},
+		{"SetMachineStatus", []interface{}{names.NewMachineTag("0"), status.Started, "resuming"}},
+		{"SetUnitSuspendStatus", []interface{}{model.UnitResuming, []names.UnitTag{names.NewUnitTag("mysql/0")}}},
+		{"SetMachineStatus", []interface{}{names.NewMachineTag("1"), status.Started, "resuming"}},
+		{"SetUnitSuspendStatus", []interface{}{model.UnitResuming, []names.UnitTag{names.NewUnitTag("wordpress/0")}}},
+	})
+}
+
+func (s *workerSuite) TestNothingToDo(c *gc.C) {
+	w, err := modelsuspender.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.facade.changes <- struct{}{}
+	s.facade.changes <- struct{}{}
+
+	workertest.CleanKill(c, w)
+	s.environ.CheckNoCalls(c)
+	s.facade.CheckCallNames(c, "WatchModelSuspension")
+}
+
+func (s *workerSuite) TestScheduleSuspendsAtTransition(c *gc.C) {
+	s.facade.suspension.Schedule = "Mon 10:00-12:00"
+	w, err := modelsuspender.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.facade.changes <- struct{}{}
+
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	waitForCalls(c, &s.environ.Stub, 2)
+	c.Assert(s.facade.Calls()[1], jc.DeepEquals, jujutesting.StubCall{
+		FuncName: "SetModelSuspendRequested",
+		Args:     []interface{}{true},
+	})
+}
+
+func (s *workerSuite) TestScheduleFirstSeenDoesNotOverride(c *gc.C) {
+	// The model was resumed by hand during a scheduled suspension; a
+	// restarted worker leaves it running.
+	s.facade.suspension.Schedule = "Mon 08:00-12:00"
+	w, err := modelsuspender.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.facade.changes <- struct{}{}
+
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+	s.environ.CheckNoCalls(c)
+	s.facade.CheckCallNames(c, "WatchModelSuspension")
+}
+
+func (s *workerSuite) TestSuspendError(c *gc.C) {
+	s.facade.suspension.Requested = true
+	s.environ.SetErrors(errors.New("boom"))
+	w, err := modelsuspender.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	s.facade.changes <- struct{}{}
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `suspending machines \[machine-1\]: boom`)
+
+	// The machine is not marked suspended, so the next run retries it.
+	s.facade.CheckCallNames(c, "WatchModelSuspension", "SetUnitSuspendStatus")
+	c.Assert(s.facade.stages[0].Machines[0].Status, gc.Equals, status.Started)
+}
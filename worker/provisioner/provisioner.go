@@ -4,6 +4,7 @@
 package provisioner
 
 import (
+	"reflect"
 	"sync"
 	"time"
 
@@ -250,6 +251,7 @@ func (p *environProvisioner) loop() error {
 		return errors.Trace(err)
 	}
 
+	resourceTags, _ := modelConfig.ResourceTags()
 	for {
 		select {
 		case <-p.catacomb.Dying():
@@ -266,6 +268,18 @@ func (p *environProvisioner) loop() error {
 				return errors.Annotate(err, "loaded invalid model configuration")
 			}
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			newResourceTags, _ := modelConfig.ResourceTags()
+			if !reflect.DeepEqual(newResourceTags, resourceTags) {
+				p.logger.Infof("resource tags changed, retagging resources")
+				var removed []string
+				for key := range resourceTags {
+					if _, ok := newResourceTags[key]; !ok {
+						removed = append(removed, key)
+					}
+				}
+				resourceTags = newResourceTags
+				task.RetagResources(removed)
+			}
 		}
 	}
 }
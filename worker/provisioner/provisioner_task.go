@@ -37,7 +37,9 @@ import (
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/tags"
 	providercommon "github.com/juju/juju/provider/common"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
@@ -52,6 +54,13 @@ type ProvisionerTask interface {
 	// should harvest machines. See config.HarvestMode for
 	// documentation of behavior.
 	SetHarvestMode(mode config.HarvestMode)
+
+	// RetagResources requests that the instances of all provisioned
+	// machines, and the model's other resources, be tagged with their
+	// current resource tags, for when the model's resource tags change.
+	// The tags named in removed are no longer in the resource tags, and
+	// are deleted.
+	RetagResources(removed []string)
 }
 
 type MachineGetter interface {
@@ -108,6 +117,8 @@ func NewProvisionerTask(
 		auth:                       auth,
 		harvestMode:                harvestMode,
 		harvestModeChan:            make(chan config.HarvestMode, 1),
+		retagChan:                  make(chan struct{}, 1),
+		retagRemoved:               set.NewStrings(),
 		machines:                   make(map[string]apiprovisioner.MachineProvisioner),
 		availabilityZoneMachines:   make([]*AvailabilityZoneMachine, 0),
 		imageStream:                imageStream,
@@ -140,6 +151,9 @@ type provisionerTask struct {
 	imageStream                string
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
+	retagChan                  chan struct{}
+	retagMutex                 sync.Mutex
+	retagRemoved               set.Strings
 	retryStartInstanceStrategy RetryStrategy
 	// instance id -> instance
 	instances map[instance.Id]instances.Instance
@@ -174,6 +188,7 @@ func (task *provisionerTask) loop() error {
 	// map. Otherwise we will potentially see all legitimate instances
 	// as unknown.
 	var harvestModeChan chan config.HarvestMode
+	var retagChan chan struct{}
 
 	// When the watcher is started, it will have the initial changes be all
 	// the machines that are relevant. Also, since this is available straight
@@ -193,6 +208,7 @@ func (task *provisionerTask) loop() error {
 			// We've seen a set of changes.
 			// Enable modification of harvesting mode.
 			harvestModeChan = task.harvestModeChan
+			retagChan = task.retagChan
 		case harvestMode := <-harvestModeChan:
 			if harvestMode == task.harvestMode {
 				break
@@ -205,6 +221,10 @@ func (task *provisionerTask) loop() error {
 					return errors.Annotate(err, "failed to process machines after safe mode disabled")
 				}
 			}
+		case <-retagChan:
+			if err := task.retagResources(); err != nil {
+				return errors.Annotate(err, "failed to retag resources")
+			}
 		case <-task.retryChanges:
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
@@ -221,6 +241,92 @@ func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	}
 }
 
+// RetagResources implements ProvisionerTask.RetagResources().
+func (task *provisionerTask) RetagResources(removed []string) {
+	task.retagMutex.Lock()
+	for _, key := range removed {
+		task.retagRemoved.Add(key)
+	}
+	task.retagMutex.Unlock()
+	// A pending request covers any number of changes.
+	select {
+	case task.retagChan <- struct{}{}:
+	default:
+	}
+}
+
+// retagResources tags the instances of the task's provisioned machines
+// with the tags in their provisioning info, and the model's other
+// resources with the model's resource tags. Tags which are no longer
+// configured are deleted first, in case they have since been added
+// again.
+func (task *provisionerTask) retagResources() error {
+	task.retagMutex.Lock()
+	removed := task.retagRemoved.SortedValues()
+	task.retagRemoved = set.NewStrings()
+	task.retagMutex.Unlock()
+
+	task.machinesMutex.RLock()
+	machines := make([]apiprovisioner.MachineProvisioner, 0, len(task.machines))
+	for _, m := range task.machines {
+		machines = append(machines, m)
+	}
+	task.machinesMutex.RUnlock()
+
+	type machineInstance struct {
+		machine apiprovisioner.MachineProvisioner
+		id      instance.Id
+		tags    map[string]string
+	}
+	var machineInstances []machineInstance
+	var vars tags.TemplateVars
+	for _, m := range machines {
+		instId, err := m.InstanceId()
+		switch {
+		case err == nil:
+		case params.IsCodeNotProvisioned(err):
+			continue
+		case params.IsCodeNotFoundOrCodeUnauthorized(err):
+			continue
+		default:
+			return errors.Trace(err)
+		}
+		// Manually provisioned machines are not cloud instances.
+		if strings.HasPrefix(string(instId), manual.ManualInstancePrefix) {
+			continue
+		}
+		pInfo, err := m.ProvisioningInfo()
+		if err != nil {
+			task.logger.Warningf("cannot get provisioning info for machine %q: %v", m, err)
+			continue
+		}
+		vars.Owner = pInfo.ModelOwner
+		machineInstances = append(machineInstances, machineInstance{m, instId, pInfo.Tags})
+	}
+
+	if retagger, ok := task.broker.(environs.ResourceRetagger); ok {
+		if err := retagger.RetagResources(task.cloudCallCtx, vars, removed); err != nil {
+			task.logger.Warningf("cannot retag model resources: %v", err)
+		}
+	} else {
+		task.logger.Debugf("provider does not support retagging resources")
+	}
+
+	tagger, ok := task.broker.(environs.InstanceTagger)
+	if !ok {
+		task.logger.Debugf("provider does not support tagging instances")
+		return nil
+	}
+	for _, mi := range machineInstances {
+		if err := tagger.TagInstance(task.cloudCallCtx, mi.id, mi.tags); err != nil {
+			task.logger.Warningf("cannot tag instance %q of machine %q: %v", mi.id, mi.machine, err)
+			continue
+		}
+		task.logger.Debugf("tagged instance %q of machine %q", mi.id, mi.machine)
+	}
+	return nil
+}
+
 func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	results, err := task.machineGetter.MachinesWithTransientErrors()
 	if err != nil {
@@ -725,6 +831,7 @@ func (task *provisionerTask) constructStartInstanceParams(
 		Constraints:       provisioningInfo.Constraints,
 		Tools:             possibleTools,
 		InstanceConfig:    instanceConfig,
+		ResourceTagVars:   tags.TemplateVars{Owner: provisioningInfo.ModelOwner},
 		Placement:         provisioningInfo.Placement,
 		Volumes:           volumes,
 		VolumeAttachments: volumeAttachments,
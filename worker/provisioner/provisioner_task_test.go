@@ -38,6 +38,7 @@ import (
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/provider/common/mocks"
 	coretesting "github.com/juju/juju/testing"
@@ -175,6 +176,42 @@ func (s *ProvisionerTaskSuite) TestStopInstancesIgnoresMachinesWithKeep(c *gc.C)
 	c.Assert(m1.markForRemoval, jc.IsTrue)
 }
 
+func (s *ProvisionerTaskSuite) TestRetagResources(c *gc.C) {
+	broker := &testInstanceTaggerBroker{s.instanceBroker}
+	task := s.newProvisionerTaskWithBroker(c, broker, nil)
+	defer workertest.CleanKill(c, task)
+
+	i0 := &testInstance{id: "zero"}
+	i1 := &testInstance{id: "manual:10.0.0.1"}
+	s.instances = []instances.Instance{i0}
+	s.machinesResults = []apiprovisioner.MachineResult{
+		{Machine: &testMachine{
+			id:       "0",
+			life:     life.Alive,
+			instance: i0,
+			tags:     map[string]string{"cost-center": "cc-1"},
+		}},
+		{Machine: &testMachine{
+			id:       "1",
+			life:     life.Alive,
+			instance: i1,
+		}},
+	}
+	s.sendModelMachinesChange(c, "0", "1")
+	s.waitForTask(c, []string{"AllRunningInstances"})
+
+	task.RetagResources([]string{"team"})
+	s.waitForTask(c, []string{"RetagResources", "TagInstance"})
+
+	workertest.CleanKill(c, task)
+	close(s.instanceBroker.callsChan)
+	s.instanceBroker.CheckCalls(c, []testing.StubCall{
+		{"AllRunningInstances", []interface{}{s.callCtx}},
+		{"RetagResources", []interface{}{s.callCtx, tags.TemplateVars{Owner: "admin"}, []string{"team"}}},
+		{"TagInstance", []interface{}{s.callCtx, instance.Id("zero"), map[string]string{"cost-center": "cc-1"}}},
+	})
+}
+
 func (s *ProvisionerTaskSuite) TestProvisionerRetries(c *gc.C) {
 	s.instanceBroker.SetErrors(
 		errors.New("errors 1"),
@@ -753,6 +790,22 @@ func (t *testInstanceBroker) MaintainInstance(ctx context.ProviderCallContext, a
 	return nil
 }
 
+type testInstanceTaggerBroker struct {
+	*testInstanceBroker
+}
+
+func (t *testInstanceTaggerBroker) RetagResources(ctx context.ProviderCallContext, vars tags.TemplateVars, removed []string) error {
+	t.AddCall("RetagResources", ctx, vars, removed)
+	t.callsChan <- "RetagResources"
+	return t.NextErr()
+}
+
+func (t *testInstanceTaggerBroker) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	t.AddCall("TagInstance", ctx, id, tags)
+	t.callsChan <- "TagInstance"
+	return t.NextErr()
+}
+
 type testInstance struct {
 	instances.Instance
 	id string
@@ -776,6 +829,7 @@ type testMachine struct {
 	instStatusMsg  string
 	modStatusMsg   string
 	topology       params.ProvisioningNetworkTopology
+	tags           map[string]string
 }
 
 func (m *testMachine) Id() string {
@@ -867,6 +921,8 @@ func (m *testMachine) ProvisioningInfo() (*params.ProvisioningInfoV10, error) {
 			ControllerConfig: coretesting.FakeControllerConfig(),
 			Series:           series.DefaultSupportedLTS(),
 			Constraints:      constraints.MustParse(m.constraints),
+			Tags:             m.tags,
+			ModelOwner:       "admin",
 		},
 		ProvisioningNetworkTopology: m.topology,
 	}, nil
@@ -16,6 +16,12 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// PreSuspend runs before the unit's machine is powered off when
+	// the model is suspended, and PostResume runs once it is powered
+	// on again.
+	PreSuspend hooks.Kind = "pre-suspend"
+	PostResume hooks.Kind = "post-resume"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -78,7 +84,7 @@ func (hi Info) Validate() error {
 		}
 		return nil
 	// TODO(fwereade): define these in charm/hooks...
-	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
+	case LeaderElected, LeaderDeposed, LeaderSettingsChanged, PreSuspend, PostResume:
 		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
@@ -141,6 +141,11 @@ func (opc *operationCallbacks) SetUpgradeSeriesStatus(upgradeSeriesStatus model.
 	return setUpgradeSeriesStatus(opc.u, upgradeSeriesStatus, reason)
 }
 
+// SetSuspendStatus is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) SetSuspendStatus(suspendStatus model.UnitSuspendStatus) error {
+	return opc.u.unit.SetSuspendStatus(suspendStatus)
+}
+
 // RemoteInit is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) RemoteInit(runningStatus remotestate.ContainerRunningStatus, abort <-chan struct{}) error {
 	if opc.u.modelType != model.CAAS {
@@ -63,6 +63,13 @@ type Operation interface {
 	RemoteStateChanged(snapshot remotestate.Snapshot)
 }
 
+// HookKiller is implemented by operations that run a charm hook which
+// may be killed at an operator's request.
+type HookKiller interface {
+	// KillHook kills the hook currently being executed, if any.
+	KillHook()
+}
+
 // Executor records and exposes uniter state, and applies suitable changes as
 // operations are run or skipped.
 type Executor interface {
@@ -149,6 +156,9 @@ type CommandArgs struct {
 	// TODO(jam): 2019-10-24 Include RemoteAppName
 	// ForceRemoteUnit skips unit inference and existence validation.
 	ForceRemoteUnit bool
+	// RelationSettings holds unit relation settings, keyed on unit name,
+	// that the commands see in place of the current ones.
+	RelationSettings map[string]map[string]string
 	// RunLocation describes where the command must run.
 	RunLocation runner.RunLocation
 }
@@ -217,6 +227,11 @@ type Callbacks interface {
 	// supply a reason as to why it is making the change.
 	SetUpgradeSeriesStatus(status model.UpgradeSeriesStatus, reason string) error
 
+	// SetSuspendStatus records the unit's progress through the
+	// suspension or resumption of its model, once its pre-suspend or
+	// post-resume hook has completed.
+	SetSuspendStatus(status model.UnitSuspendStatus) error
+
 	// RemoteInit copies the charm to the remote instance. CAAS only.
 	RemoteInit(runningStatus remotestate.ContainerRunningStatus, abort <-chan struct{}) error
 }
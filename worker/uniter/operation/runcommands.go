@@ -46,7 +46,8 @@ func (rc *runCommands) Prepare(state State) (*State, error) {
 		RelationId:     rc.args.RelationId,
 		RemoteUnitName: rc.args.RemoteUnitName,
 		// TODO(jam): 2019-10-24 include RemoteAppName
-		ForceRemoteUnit: rc.args.ForceRemoteUnit,
+		ForceRemoteUnit:  rc.args.ForceRemoteUnit,
+		RelationSettings: rc.args.RelationSettings,
 	})
 	if err != nil {
 		return nil, err
@@ -33,10 +33,13 @@ func (s *RunCommandsSuite) TestPrepareError(c *gc.C) {
 	newState, err := op.Prepare(operation.State{})
 	c.Assert(err, gc.ErrorMatches, "blooey")
 	c.Assert(newState, gc.IsNil)
-	c.Assert(*runnerFactory.MockNewCommandRunner.gotInfo, gc.Equals, context.CommandInfo{
+	c.Assert(*runnerFactory.MockNewCommandRunner.gotInfo, jc.DeepEquals, context.CommandInfo{
 		RelationId:      123,
 		RemoteUnitName:  "foo/456",
 		ForceRemoteUnit: true,
+		RelationSettings: map[string]map[string]string{
+			"foo/456": {"key": "value"},
+		},
 	})
 }
 
@@ -57,10 +60,13 @@ func (s *RunCommandsSuite) TestPrepareSuccess(c *gc.C) {
 	newState, err := op.Prepare(operation.State{})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(newState, gc.IsNil)
-	c.Assert(*runnerFactory.MockNewCommandRunner.gotInfo, gc.Equals, context.CommandInfo{
+	c.Assert(*runnerFactory.MockNewCommandRunner.gotInfo, jc.DeepEquals, context.CommandInfo{
 		RelationId:      123,
 		RemoteUnitName:  "foo/456",
 		ForceRemoteUnit: true,
+		RelationSettings: map[string]map[string]string{
+			"foo/456": {"key": "value"},
+		},
 	})
 	ctx.CheckCall(c, 0, "Prepare")
 }
@@ -5,6 +5,7 @@ package operation
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/juju/charm/v8/hooks"
 	"github.com/juju/errors"
@@ -32,6 +33,9 @@ type runHook struct {
 
 	hookFound bool
 
+	mu     sync.Mutex
+	killed bool
+
 	RequiresMachineLock
 }
 
@@ -122,6 +126,9 @@ func (rh *runHook) Execute(state State) (*State, error) {
 
 	handlerType, err := rh.runner.RunHook(rh.name)
 	cause := errors.Cause(err)
+	if err != nil && rh.wasKilled() {
+		cause = charmrunner.NewHookKilledError("killed by operator request")
+	}
 	switch {
 	case charmrunner.IsMissingHookError(cause):
 		rh.hookFound = false
@@ -132,6 +139,16 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case cause == context.ErrReboot:
 		err = ErrNeedsReboot
 	case err == nil:
+	case charmrunner.IsHookKilledError(cause):
+		reason := charmrunner.HookKilledReason(cause)
+		rh.logger.Errorf("hook %q (via %s) was killed: %s", rh.name, handlerType, reason)
+		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		return stateChange{
+			Kind:           RunHook,
+			Step:           Pending,
+			Hook:           &rh.info,
+			HookKillReason: reason,
+		}.apply(state), ErrHookFailed
 	default:
 		rh.logger.Errorf("hook %q (via %s) failed: %v", rh.name, handlerType, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
@@ -283,6 +300,10 @@ func (rh *runHook) Commit(state State) (*State, error) {
 	case hooks.PostSeriesUpgrade:
 		message := createUpgradeSeriesStatusMessage(rh.name, rh.hookFound)
 		err = rh.callbacks.SetUpgradeSeriesStatus(model.UpgradeSeriesCompleted, message)
+	case hook.PreSuspend:
+		err = rh.callbacks.SetSuspendStatus(model.UnitSuspended)
+	case hook.PostResume:
+		err = rh.callbacks.SetSuspendStatus(model.UnitSuspendNotStarted)
 	}
 	if err != nil {
 		return nil, err
@@ -308,3 +329,35 @@ func (rh *runHook) Commit(state State) (*State, error) {
 // of the operation.
 func (rh *runHook) RemoteStateChanged(snapshot remotestate.Snapshot) {
 }
+
+// KillHook kills the hook process if one is running.
+// KillHook is part of the HookKiller interface.
+func (rh *runHook) KillHook() {
+	if rh.runner == nil {
+		return
+	}
+	rh.logger.Infof("killing %q hook at operator request", rh.name)
+	// Record the kill before the process dies, so that Execute
+	// reports the failure correctly.
+	rh.setKilled(true)
+	err := rh.runner.Context().KillCharmHook()
+	if err == charmrunner.ErrNoProcess {
+		rh.logger.Infof("no %q hook process to kill", rh.name)
+		rh.setKilled(false)
+	} else if err != nil {
+		rh.logger.Errorf("cannot kill %q hook: %v", rh.name, err)
+		rh.setKilled(false)
+	}
+}
+
+func (rh *runHook) setKilled(killed bool) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	rh.killed = killed
+}
+
+func (rh *runHook) wasKilled() bool {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	return rh.killed
+}
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
@@ -279,6 +280,62 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteHookTimedOut(c *gc.C) {
+	runErr := charmrunner.NewHookKilledError("timed out after 1m0s")
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:           operation.RunHook,
+		Step:           operation.Pending,
+		Hook:           &hook.Info{Kind: hooks.ConfigChanged},
+		HookKillReason: "timed out after 1m0s",
+	})
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+}
+
+func (s *RunHookSuite) TestExecuteHookKilled(c *gc.C) {
+	runErr := errors.New("signal: killed")
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	op.(operation.HookKiller).KillHook()
+	c.Assert(runnerFactory.MockNewHookRunner.runner.context.(*MockContext).killCalled, jc.IsTrue)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:           operation.RunHook,
+		Step:           operation.Pending,
+		Hook:           &hook.Info{Kind: hooks.ConfigChanged},
+		HookKillReason: "killed by operator request",
+	})
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+}
+
+func (s *RunHookSuite) TestExecuteHookKillNoProcess(c *gc.C) {
+	runErr := errors.New("graaargh")
+	op, _, _ := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr,
+		func(ctx *MockContext) {
+			ctx.killErr = charmrunner.ErrNoProcess
+		},
+	)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing was killed, so the failure is reported as usual.
+	op.(operation.HookKiller).KillHook()
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.IsNil)
+}
+
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
 	op, callbacks, f := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.Install, nil)
 	err := f.MockNewHookRunner.runner.Context().SetUnitStatus(jujuc.StatusInfo{Status: "blocked", Info: "no database"})
@@ -574,6 +631,26 @@ func (s *RunHookSuite) TestCommitSuccess_Start_Preserve(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestCommitSuccess_SetSuspendStatus(c *gc.C) {
+	for kind, expect := range map[hooks.Kind]model.UnitSuspendStatus{
+		hook.PreSuspend: model.UnitSuspended,
+		hook.PostResume: model.UnitSuspendNotStarted,
+	} {
+		c.Logf("hook %v", kind)
+		callbacks := &CommitHookCallbacks{
+			MockCommitHook: &MockCommitHook{},
+		}
+		factory := newOpFactory(nil, callbacks)
+		op, err := factory.NewRunHook(hook.Info{Kind: kind})
+		c.Assert(err, jc.ErrorIsNil)
+
+		_, err = op.Commit(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(callbacks.suspendStatus, gc.NotNil)
+		c.Assert(*callbacks.suspendStatus, gc.Equals, expect)
+	}
+}
+
 func (s *RunHookSuite) assertCommitSuccess_RelationBroken_SetStatus(c *gc.C, suspended, leader bool) {
 	ctx := &MockContext{
 		isLeader: leader,
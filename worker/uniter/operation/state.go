@@ -9,6 +9,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter/hook"
 )
 
@@ -91,6 +92,11 @@ type State struct {
 	// upgrade is complete (instead of running an upgrade-charm hook).
 	Hook *hook.Info `yaml:"hook,omitempty"`
 
+	// HookKillReason holds the reason the hook recorded in Hook was killed
+	// before it completed. It is only set while the uniter is in an error
+	// state caused by that hook being killed.
+	HookKillReason string `yaml:"hook-kill-reason,omitempty"`
+
 	// ActionId holds action information relevant to the current operation. If
 	// Kind is Continue, it holds the last action that was executed; if Kind is
 	// RunAction, it holds the running action.
@@ -114,6 +120,12 @@ type State struct {
 	// machine/container addresses - it's used to determine whether we
 	// need to run config-changed.
 	AddressesHash string `yaml:"addresses-hash,omitempty"`
+
+	// SuspendStatus is the suspend status, suspending or resuming,
+	// for which a pre-suspend or post-resume hook was last committed.
+	// It is persisted so that a restarted uniter does not run the
+	// hook again.
+	SuspendStatus model.UnitSuspendStatus `yaml:"suspend-status,omitempty"`
 }
 
 // Validate returns an error if the state violates expectations.
@@ -190,6 +202,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookKillReason  string
 }
 
 func (change stateChange) apply(state State) *State {
@@ -198,6 +211,7 @@ func (change stateChange) apply(state State) *State {
 	state.Hook = change.Hook
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
+	state.HookKillReason = change.HookKillReason
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
 	return &state
 }
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/operation/mocks"
@@ -240,6 +241,13 @@ var stateTests = []stateTest{
 			Step:   operation.Pending,
 			Leader: true,
 		},
+	}, {
+		description: "continue operation with suspend status",
+		st: operation.State{
+			Kind:          operation.Continue,
+			Step:          operation.Pending,
+			SuspendStatus: model.UnitSuspending,
+		},
 	},
 }
 
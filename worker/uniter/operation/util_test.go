@@ -227,12 +227,18 @@ func (mock *MockCommitHook) Call(hookInfo hook.Info) error {
 type CommitHookCallbacks struct {
 	operation.Callbacks
 	*MockCommitHook
+	suspendStatus *model.UnitSuspendStatus
 }
 
 func (cb *CommitHookCallbacks) CommitHook(hookInfo hook.Info) error {
 	return cb.MockCommitHook.Call(hookInfo)
 }
 
+func (cb *CommitHookCallbacks) SetSuspendStatus(status model.UnitSuspendStatus) error {
+	cb.suspendStatus = &status
+	return nil
+}
+
 type MockNewActionRunner struct {
 	gotActionId *string
 	gotCancel   <-chan struct{}
@@ -317,6 +323,13 @@ type MockContext struct {
 	status          jujuc.StatusInfo
 	isLeader        bool
 	relation        *MockRelation
+	killCalled      bool
+	killErr         error
+}
+
+func (mock *MockContext) KillCharmHook() error {
+	mock.killCalled = true
+	return mock.killErr
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -562,7 +575,10 @@ var someCommandArgs = operation.CommandArgs{
 	RelationId:      123,
 	RemoteUnitName:  "foo/456",
 	ForceRemoteUnit: true,
-	RunLocation:     runner.Workload,
+	RelationSettings: map[string]map[string]string{
+		"foo/456": {"key": "value"},
+	},
+	RunLocation: runner.Workload,
 }
 
 type RemoteInitCallbacks struct {
@@ -217,6 +217,8 @@ type mockUnit struct {
 	life                             life.Value
 	providerID                       string
 	resolved                         params.ResolvedMode
+	suspendStatus                    model.UnitSuspendStatus
+	hookKillVersion                  int
 	application                      mockApplication
 	unitWatcher                      *mockNotifyWatcher
 	addressesWatcher                 *mockStringsWatcher
@@ -250,6 +252,14 @@ func (u *mockUnit) Resolved() params.ResolvedMode {
 	return u.resolved
 }
 
+func (u *mockUnit) HookKillVersion() int {
+	return u.hookKillVersion
+}
+
+func (u *mockUnit) SuspendStatus() model.UnitSuspendStatus {
+	return u.suspendStatus
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.application, nil
 }
@@ -45,6 +45,14 @@ type Snapshot struct {
 	// ProviderID is the cloud container's provider ID.
 	ProviderID string
 
+	// HookKillVersion increments each time an operator asks
+	// for the unit's currently running hook to be killed.
+	HookKillVersion int
+
+	// SuspendStatus is the unit's progress through the suspension
+	// or resumption of its model.
+	SuspendStatus model.UnitSuspendStatus
+
 	// RetryHookVersion increments each time a failed
 	// hook is meant to be retried if ResolvedMode is
 	// set to ResolvedNone.
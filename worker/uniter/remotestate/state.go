@@ -40,6 +40,8 @@ type Unit interface {
 	Refresh() error
 	ProviderID() string
 	Resolved() params.ResolvedMode
+	HookKillVersion() int
+	SuspendStatus() model.UnitSuspendStatus
 	Application() (Application, error)
 	Tag() names.UnitTag
 	Watch() (watcher.NotifyWatcher, error)
@@ -23,12 +23,13 @@ import (
 type ResolverConfig struct {
 	ModelType           model.ModelType
 	ClearResolved       func() error
-	ReportHookError     func(hook.Info) error
+	ReportHookError     func(hookInfo hook.Info, killReason string) error
 	ShouldRetryHooks    bool
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
 	VerifyCharmProfile  resolver.Resolver
 	UpgradeSeries       resolver.Resolver
+	Suspend             resolver.Resolver
 	Reboot              resolver.Resolver
 	Leadership          resolver.Resolver
 	Actions             resolver.Resolver
@@ -74,6 +75,16 @@ func (s *uniterResolver) NextOp(
 		return op, err
 	}
 
+	// Likewise, nothing else should run once the unit has prepared for
+	// its machine to be suspended.
+	op, err = s.config.Suspend.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		if errors.Cause(err) == resolver.ErrDoNotProceed {
+			return nil, resolver.ErrNoOperation
+		}
+		return op, err
+	}
+
 	// Check if we need to notify the charms because a reboot was detected.
 	op, err = s.config.Reboot.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
@@ -230,7 +241,7 @@ func (s *uniterResolver) nextOpHookError(
 ) (operation.Operation, error) {
 
 	// Report the hook error.
-	if err := s.config.ReportHookError(*localState.Hook); err != nil {
+	if err := s.config.ReportHookError(*localState.Hook, localState.HookKillReason); err != nil {
 		return nil, errors.Trace(err)
 	}
 
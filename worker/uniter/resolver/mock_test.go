@@ -73,6 +73,30 @@ func (f *mockOpFactory) NewSkipRemoteInit(retry bool) (operation.Operation, erro
 	return f.op, f.NextErr()
 }
 
+type mockHookKillerOpFactory struct {
+	*mockOpFactory
+	op operation.Operation
+}
+
+func (f *mockHookKillerOpFactory) NewRunHook(info hook.Info) (operation.Operation, error) {
+	f.MethodCall(f, "NewRunHook", info)
+	return f.op, f.NextErr()
+}
+
+type mockHookKillerOp struct {
+	mockOp
+	killed             int
+	remoteStateChanged int
+}
+
+func (op *mockHookKillerOp) RemoteStateChanged(remotestate.Snapshot) {
+	op.remoteStateChanged++
+}
+
+func (op *mockHookKillerOp) KillHook() {
+	op.killed++
+}
+
 type mockOpExecutor struct {
 	operation.Executor
 	testing.Stub
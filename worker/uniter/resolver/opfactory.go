@@ -138,6 +138,16 @@ func (s *resolverOpFactory) wrapUpgradeOp(op operation.Operation, charmURL *char
 }
 
 func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) operation.Operation {
+	if killer, ok := op.(operation.HookKiller); ok {
+		// Only kill requests made after the operation was
+		// created are intended for the hook it runs.
+		op = &hookKillWrapper{
+			Operation:       op,
+			killer:          killer,
+			hookKillVersion: s.RemoteState.HookKillVersion,
+		}
+	}
+
 	switch info.Kind {
 	case hooks.PreSeriesUpgrade:
 		op = onPrepareWrapper{op, func() {
@@ -158,6 +168,20 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func(*operation.State) {
 			s.LocalState.UpgradeSeriesStatus = model.UpgradeSeriesCompleted
 		}}
+	case hook.PreSuspend:
+		op = onCommitWrapper{op, func(state *operation.State) {
+			if state != nil {
+				// Assign this on the operation.State so it gets
+				// written into the state file on disk.
+				state.SuspendStatus = model.UnitSuspending
+			}
+		}}
+	case hook.PostResume:
+		op = onCommitWrapper{op, func(state *operation.State) {
+			if state != nil {
+				state.SuspendStatus = model.UnitResuming
+			}
+		}}
 	case hooks.ConfigChanged:
 		configHash := s.RemoteState.ConfigHash
 		trustHash := s.RemoteState.TrustHash
@@ -213,6 +237,22 @@ func (op onCommitWrapper) Commit(state operation.State) (*operation.State, error
 	return st, nil
 }
 
+// hookKillWrapper kills the wrapped operation's hook when the remote
+// state reports a kill request newer than the operation.
+type hookKillWrapper struct {
+	operation.Operation
+	killer          operation.HookKiller
+	hookKillVersion int
+}
+
+func (op *hookKillWrapper) RemoteStateChanged(snapshot remotestate.Snapshot) {
+	op.Operation.RemoteStateChanged(snapshot)
+	if snapshot.HookKillVersion > op.hookKillVersion {
+		op.hookKillVersion = snapshot.HookKillVersion
+		op.killer.KillHook()
+	}
+}
+
 type onPrepareWrapper struct {
 	operation.Operation
 	onPrepare func()
@@ -61,6 +61,31 @@ func (s *ResolverOpFactorySuite) testUpdateStatusChanged(
 	c.Assert(f.LocalState.UpdateStatusVersion, gc.Equals, 1)
 }
 
+func (s *ResolverOpFactorySuite) TestHookKillRequested(c *gc.C) {
+	op := &mockHookKillerOp{}
+	f := resolver.NewResolverOpFactory(&mockHookKillerOpFactory{
+		mockOpFactory: s.opFactory,
+		op:            op,
+	})
+	f.RemoteState.HookKillVersion = 1
+
+	wrapped, err := f.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A kill request made before the operation was created
+	// is not for this hook.
+	wrapped.RemoteStateChanged(remotestate.Snapshot{HookKillVersion: 1})
+	c.Assert(op.killed, gc.Equals, 0)
+
+	wrapped.RemoteStateChanged(remotestate.Snapshot{HookKillVersion: 2})
+	c.Assert(op.killed, gc.Equals, 1)
+	c.Assert(op.remoteStateChanged, gc.Equals, 2)
+
+	// Each request is acted on only once.
+	wrapped.RemoteStateChanged(remotestate.Snapshot{HookKillVersion: 2})
+	c.Assert(op.killed, gc.Equals, 1)
+}
+
 func (s *ResolverOpFactorySuite) TestConfigChanged(c *gc.C) {
 	s.testConfigChanged(c, resolver.ResolverOpFactory.NewRunHook)
 	s.testConfigChanged(c, resolver.ResolverOpFactory.NewSkipHook)
@@ -88,6 +113,28 @@ func (s *ResolverOpFactorySuite) TestUpgradeSeriesStatusChanged(c *gc.C) {
 	c.Assert(f.LocalState.UpgradeSeriesStatus, gc.Equals, model.UpgradeSeriesPrepareCompleted)
 }
 
+func (s *ResolverOpFactorySuite) TestSuspendStatusChanged(c *gc.C) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+	f.RemoteState.SuspendStatus = model.UnitSuspending
+
+	op, err := f.NewRunHook(hook.Info{Kind: hook.PreSuspend})
+	c.Assert(err, jc.ErrorIsNil)
+	resultState, err := op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	// The suspend status is set on the result state, so that it is
+	// written to disk by the executor.
+	c.Assert(resultState, gc.NotNil)
+	c.Assert(resultState.SuspendStatus, gc.Equals, model.UnitSuspending)
+
+	f.RemoteState.SuspendStatus = model.UnitResuming
+	op, err = f.NewRunHook(hook.Info{Kind: hook.PostResume})
+	c.Assert(err, jc.ErrorIsNil)
+	resultState, err = op.Commit(*resultState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resultState, gc.NotNil)
+	c.Assert(resultState.SuspendStatus, gc.Equals, model.UnitResuming)
+}
+
 func (s *ResolverOpFactorySuite) TestNewHookError(c *gc.C) {
 	s.opFactory.SetErrors(
 		errors.New("NewRunHook fails"),
@@ -45,6 +45,9 @@ type RunCommandsArgs struct {
 	RemoteApplicationName string
 	// ForceRemoteUnit skips relation membership and existence validation.
 	ForceRemoteUnit bool
+	// RelationSettings holds unit relation settings, keyed on unit name,
+	// that the commands see in place of the current ones.
+	RelationSettings map[string]map[string]string
 	// UnitName is the unit for which the command is being run.
 	UnitName string
 	// Token is the unit token when run under CAAS environments for auth.
@@ -309,8 +312,9 @@ func (c *ChannelCommandRunner) RunCommands(args RunCommandsArgs) (results *exec.
 		RelationId:     args.RelationId,
 		RemoteUnitName: args.RemoteUnitName,
 		// TODO(jam): 2019-10-24 Include RemoteAppName
-		ForceRemoteUnit: args.ForceRemoteUnit,
-		RunLocation:     runLocation,
+		ForceRemoteUnit:  args.ForceRemoteUnit,
+		RelationSettings: args.RelationSettings,
+		RunLocation:      runLocation,
 	}
 	if err := operationArgs.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -74,6 +74,9 @@ type Paths interface {
 
 // Clock defines the methods of the full clock.Clock that are needed here.
 type Clock interface {
+	// Now returns the current clock time.
+	Now() time.Time
+
 	// After waits for the duration to elapse and then sends the
 	// current time on the returned channel.
 	After(time.Duration) <-chan time.Time
@@ -81,6 +84,10 @@ type Clock interface {
 
 var ErrIsNotLeader = errors.Errorf("this unit is not the leader")
 
+// HookTimeoutOptionName is the name of the charm config option a charm may
+// declare to override the model's hook-timeout for its own hooks.
+const HookTimeoutOptionName = "hook-timeout"
+
 // ComponentConfig holds all the information related to a hook context
 // needed by components.
 type ComponentConfig struct {
@@ -129,6 +136,7 @@ type HookUnit interface {
 	LogActionMessage(names.ActionTag, string) error
 	Name() string
 	NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error)
+	RecordHookRun(params.HookRecord) error
 	RequestReboot() error
 	SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error
 	SetAgentStatus(agentStatus status.Status, info string, data map[string]interface{}) error
@@ -248,6 +256,10 @@ type HookContext struct {
 	// the hook will be killed and requeued
 	rebootPriority jujuc.RebootPriority
 
+	// hookTimeout is the maximum time a hook may run for before it is
+	// killed. Zero means no limit.
+	hookTimeout time.Duration
+
 	// storage provides access to the information about storage attached to the unit.
 	storage StorageContextAccessor
 
@@ -444,7 +456,7 @@ func (ctx *HookContext) RequestReboot(priority jujuc.RebootPriority) error {
 	var err error
 	if priority == jujuc.RebootNow {
 		// At this point, the hook should be running
-		err = ctx.killCharmHook()
+		err = ctx.KillCharmHook()
 	}
 
 	switch err {
@@ -481,6 +493,80 @@ func (ctx *HookContext) SetProcess(process HookProcess) {
 	ctx.process = process
 }
 
+// HookTimeout returns the maximum time a hook may run before being killed.
+// Implements runner.Context.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+// charmHookTimeout returns the hook timeout set in the charm's config, and
+// whether the charm declares a hook-timeout option at all. The value may be
+// a duration string, eg "30m", or a number of seconds.
+func (ctx *HookContext) charmHookTimeout() (time.Duration, bool, error) {
+	settings, err := ctx.ConfigSettings()
+	if err != nil {
+		return 0, false, errors.Trace(err)
+	}
+	var timeout time.Duration
+	switch value := settings[HookTimeoutOptionName].(type) {
+	case nil:
+		return 0, false, nil
+	case string:
+		if timeout, err = time.ParseDuration(value); err != nil {
+			return 0, false, errors.NotValidf("charm %s %q", HookTimeoutOptionName, value)
+		}
+	case int64:
+		timeout = time.Duration(value) * time.Second
+	default:
+		return 0, false, errors.NotValidf("charm %s %v", HookTimeoutOptionName, value)
+	}
+	if timeout < 0 {
+		return 0, false, errors.NotValidf("negative charm %s %v", HookTimeoutOptionName, timeout)
+	}
+	return timeout, true, nil
+}
+
+// Clock returns the clock used for time operations in this context.
+// Implements runner.Context.
+func (ctx *HookContext) Clock() Clock {
+	return ctx.clock
+}
+
+// HookRun holds the details of a single hook execution, as recorded
+// in the unit's hook history.
+type HookRun struct {
+	HookName string
+	Started  time.Time
+	Duration time.Duration
+	ExitCode int
+	// Output holds the tail of the hook's combined stdout and stderr.
+	Output string
+}
+
+// RecordHookRun adds the hook execution to the unit's hook history on
+// the controller. The history is for diagnostics only, so failures are
+// logged rather than returned.
+// Implements runner.Context.
+func (ctx *HookContext) RecordHookRun(run HookRun) {
+	record := params.HookRecord{
+		HookName:   run.HookName,
+		RemoteUnit: ctx.remoteUnitName,
+		Started:    run.Started,
+		Duration:   run.Duration,
+		ExitCode:   run.ExitCode,
+		Output:     run.Output,
+	}
+	if rctx, err := ctx.Relation(ctx.relationId); err == nil {
+		record.Relation = rctx.FakeId()
+	}
+	err := ctx.unit.RecordHookRun(record)
+	if errors.IsNotImplemented(err) {
+		ctx.logger.Debugf("controller does not support hook history")
+	} else if err != nil {
+		ctx.logger.Warningf("cannot record %q hook run: %v", run.HookName, err)
+	}
+}
+
 // Id returns an integer which uniquely identifies the relation.
 // Implements jujuc.HookContext.ContextRelation, part of runner.Context.
 func (ctx *HookContext) Id() string {
@@ -1217,8 +1303,9 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 	return unhandledErr
 }
 
-// killCharmHook tries to kill the current running charm hook.
-func (ctx *HookContext) killCharmHook() error {
+// KillCharmHook tries to kill the current running charm hook.
+// Implements runner.Context.
+func (ctx *HookContext) KillCharmHook() error {
 	proc := ctx.GetProcess()
 	if proc == nil {
 		// nothing to kill
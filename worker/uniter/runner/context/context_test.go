@@ -763,6 +763,86 @@ func (s *mockHookContextSuite) TestSequentialFlushOfCacheValues(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *mockHookContextSuite) TestRecordHookRun(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	started := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.mockUnit.EXPECT().RecordHookRun(params.HookRecord{
+		HookName:   "db-relation-changed",
+		Relation:   "db:22",
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Duration:   time.Second,
+		ExitCode:   1,
+		Output:     "boom",
+	}).Return(nil)
+
+	hookContext := context.NewMockUnitHookContext("wordpress/0", s.mockUnit)
+	context.SetEnvironmentHookContextRelation(hookContext, 22, "db", "mysql/0", "mysql", "")
+	hookContext.RecordHookRun(context.HookRun{
+		HookName: "db-relation-changed",
+		Started:  started,
+		Duration: time.Second,
+		ExitCode: 1,
+		Output:   "boom",
+	})
+}
+
+func (s *mockHookContextSuite) TestRecordHookRunError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.mockUnit.EXPECT().RecordHookRun(params.HookRecord{
+		HookName: "install",
+	}).Return(errors.New("boom"))
+
+	// Failing to record the hook run is not fatal.
+	hookContext := context.NewMockUnitHookContext("wordpress/0", s.mockUnit)
+	hookContext.RecordHookRun(context.HookRun{HookName: "install"})
+}
+
+func (s *mockHookContextSuite) TestCharmHookTimeout(c *gc.C) {
+	for i, test := range []struct {
+		value   interface{}
+		timeout time.Duration
+	}{
+		{value: "90s", timeout: 90 * time.Second},
+		{value: int64(120), timeout: 2 * time.Minute},
+		{value: "0s", timeout: 0},
+	} {
+		c.Logf("test %d: %v", i, test.value)
+		ctrl := s.setupMocks(c)
+		s.mockUnit.EXPECT().ConfigSettings().Return(charm.Settings{"hook-timeout": test.value}, nil)
+
+		hookContext := context.NewMockUnitHookContext("wordpress/0", s.mockUnit)
+		timeout, ok, err := context.CharmHookTimeout(hookContext)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(ok, jc.IsTrue)
+		c.Check(timeout, gc.Equals, test.timeout)
+		ctrl.Finish()
+	}
+}
+
+func (s *mockHookContextSuite) TestCharmHookTimeoutNotDeclared(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.mockUnit.EXPECT().ConfigSettings().Return(charm.Settings{"title": "My Title"}, nil)
+
+	hookContext := context.NewMockUnitHookContext("wordpress/0", s.mockUnit)
+	_, ok, err := context.CharmHookTimeout(hookContext)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *mockHookContextSuite) TestCharmHookTimeoutInvalid(c *gc.C) {
+	for i, value := range []interface{}{"soon", "-1m", int64(-1), true} {
+		c.Logf("test %d: %v", i, value)
+		ctrl := s.setupMocks(c)
+		s.mockUnit.EXPECT().ConfigSettings().Return(charm.Settings{"hook-timeout": value}, nil)
+
+		hookContext := context.NewMockUnitHookContext("wordpress/0", s.mockUnit)
+		_, _, err := context.CharmHookTimeout(hookContext)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		ctrl.Finish()
+	}
+}
+
 func (s *mockHookContextSuite) setupMocks(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 	s.mockUnit = mocks.NewMockHookUnit(ctrl)
@@ -31,6 +31,10 @@ type CommandInfo struct {
 	// TODO(jam): 2019-10-23 Add RemoteApplicationName
 	// ForceRemoteUnit skips unit inference and existence validation.
 	ForceRemoteUnit bool
+	// RelationSettings holds unit relation settings, keyed on unit name,
+	// that the commands see in place of the current ones. It is used to
+	// replay a failed hook with the settings it ran with.
+	RelationSettings map[string]map[string]string
 }
 
 // ContextFactory represents a long-lived object that can create execution contexts
@@ -244,6 +248,15 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 	}
 	ctx.id = f.newId(hookName)
 	ctx.hookName = hookName
+
+	// A hook-timeout declared by the charm takes precedence over the
+	// model's hook-timeout.
+	timeout, ok, err := ctx.charmHookTimeout()
+	if err != nil {
+		f.logger.Warningf("using model hook-timeout for %q: %v", hookName, err)
+	} else if ok {
+		ctx.hookTimeout = timeout
+	}
 	return ctx, nil
 }
 
@@ -260,6 +273,12 @@ func (f *contextFactory) CommandContext(commandInfo CommandInfo) (*HookContext,
 	}
 	ctx.relationId = relationId
 	ctx.remoteUnitName = remoteUnitName
+	if len(commandInfo.RelationSettings) > 0 {
+		if relationId == -1 {
+			return nil, errors.New("relation settings provided without a relation")
+		}
+		ctx.relations[relationId].restoreSettings(ctx.unitName, commandInfo.RelationSettings)
+	}
 	ctx.id = f.newId("run-commands")
 	return ctx, nil
 }
@@ -326,6 +345,7 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 	}
 	ctx.legacyProxySettings = modelConfig.LegacyProxySettings()
 	ctx.jujuProxySettings = modelConfig.JujuProxySettings()
+	ctx.hookTimeout = modelConfig.HookTimeout()
 
 	statusCode, statusInfo, err := f.unit.MeterStatus()
 	if err != nil {
@@ -630,6 +630,73 @@ func (s *ContextFactorySuite) TestNewCommandContextInferRemoteUnit(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestNewCommandContextRelationSettings(c *gc.C) {
+	s.setUpCacheMethods(c)
+	s.membership[0] = []string{"rel0/0"}
+	s.updateCache(0, "rel0/0", params.Settings{"current": "value"})
+
+	ctx, err := s.factory.CommandContext(context.CommandInfo{
+		RelationId:     0,
+		RemoteUnitName: "rel0/0",
+		RelationSettings: map[string]map[string]string{
+			"rel0/0": {"restored": "remote"},
+			"u/0":    {"restored": "local"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	relCtx, err := ctx.Relation(0)
+	c.Assert(err, jc.ErrorIsNil)
+	remote, err := relCtx.ReadSettings("rel0/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remote, jc.DeepEquals, params.Settings{"restored": "remote"})
+	local, err := relCtx.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(local.Map(), jc.DeepEquals, params.Settings{"restored": "local"})
+
+	// The restored settings are not written to the factory's cache.
+	cached, found := s.getCache(0, "rel0/0")
+	c.Assert(found, jc.IsTrue)
+	c.Assert(cached, jc.DeepEquals, params.Settings{"current": "value"})
+}
+
+func (s *ContextFactorySuite) TestNewCommandContextRelationSettingsNotWritten(c *gc.C) {
+	ctx, err := s.factory.CommandContext(context.CommandInfo{
+		RelationId: 0,
+		RelationSettings: map[string]map[string]string{
+			"u/0": {"restored": "local"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	relCtx, err := ctx.Relation(0)
+	c.Assert(err, jc.ErrorIsNil)
+	local, err := relCtx.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	local.Set("changed", "by-hook")
+	c.Assert(local.Map(), jc.DeepEquals, params.Settings{
+		"restored": "local",
+		"changed":  "by-hook",
+	})
+
+	err = ctx.Flush("", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Neither the restored settings nor the changes made to them are
+	// written back to the relation.
+	settings, err := s.relunits[0].ReadSettings("u/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"relation-name": "db0"})
+}
+
+func (s *ContextFactorySuite) TestNewCommandContextRelationSettingsNoRelation(c *gc.C) {
+	_, err := s.factory.CommandContext(context.CommandInfo{
+		RelationId:       -1,
+		RelationSettings: map[string]map[string]string{"u/0": {"foo": "bar"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "relation settings provided without a relation")
+}
+
 func (s *ContextFactorySuite) TestNewHookContextPrunesNonMemberCaches(c *gc.C) {
 
 	// Write cached member settings for a member and a non-member.
@@ -4,6 +4,8 @@
 package context
 
 import (
+	"time"
+
 	"github.com/juju/charm/v8"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -245,3 +247,7 @@ func CachedAppSettings(cf0 ContextFactory, relId int, appName string) (params.Se
 func (ctx *HookContext) SLALevel() string {
 	return ctx.slaLevel
 }
+
+func CharmHookTimeout(ctx *HookContext) (time.Duration, bool, error) {
+	return ctx.charmHookTimeout()
+}
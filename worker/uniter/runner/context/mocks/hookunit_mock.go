@@ -66,20 +66,6 @@ func (mr *MockHookUnitMockRecorder) ApplicationName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationName", reflect.TypeOf((*MockHookUnit)(nil).ApplicationName))
 }
 
-// ClosePorts mocks base method
-func (m *MockHookUnit) ClosePorts(arg0 string, arg1, arg2 int) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ClosePorts", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// ClosePorts indicates an expected call of ClosePorts
-func (mr *MockHookUnitMockRecorder) ClosePorts(arg0, arg1, arg2 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePorts", reflect.TypeOf((*MockHookUnit)(nil).ClosePorts), arg0, arg1, arg2)
-}
-
 // CommitHookChanges mocks base method
 func (m *MockHookUnit) CommitHookChanges(arg0 params.CommitHookChangesArgs) error {
 	m.ctrl.T.Helper()
@@ -152,18 +138,18 @@ func (mr *MockHookUnitMockRecorder) NetworkInfo(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkInfo", reflect.TypeOf((*MockHookUnit)(nil).NetworkInfo), arg0, arg1)
 }
 
-// OpenPorts mocks base method
-func (m *MockHookUnit) OpenPorts(arg0 string, arg1, arg2 int) error {
+// RecordHookRun mocks base method
+func (m *MockHookUnit) RecordHookRun(arg0 params.HookRecord) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "OpenPorts", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "RecordHookRun", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// OpenPorts indicates an expected call of OpenPorts
-func (mr *MockHookUnitMockRecorder) OpenPorts(arg0, arg1, arg2 interface{}) *gomock.Call {
+// RecordHookRun indicates an expected call of RecordHookRun
+func (mr *MockHookUnitMockRecorder) RecordHookRun(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenPorts", reflect.TypeOf((*MockHookUnit)(nil).OpenPorts), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordHookRun", reflect.TypeOf((*MockHookUnit)(nil).RecordHookRun), arg0)
 }
 
 // RequestReboot mocks base method
@@ -94,6 +94,14 @@ type ContextRelation struct {
 
 	// cache holds remote unit membership and settings.
 	cache *RelationCache
+
+	// restoredSettings holds remote unit settings that are read in
+	// place of those in the cache.
+	restoredSettings map[string]params.Settings
+
+	// restoredLocalSettings, if set, is used in place of the local
+	// unit's settings and is never written back.
+	restoredLocalSettings jujuc.Settings
 }
 
 // NewContextRelation creates a new context for the given relation unit.
@@ -128,14 +136,40 @@ func (ctx *ContextRelation) UnitNames() []string {
 }
 
 func (ctx *ContextRelation) ReadSettings(unit string) (settings params.Settings, err error) {
+	if settings, ok := ctx.restoredSettings[unit]; ok {
+		return settings, nil
+	}
 	return ctx.cache.Settings(unit)
 }
 
+// restoreSettings makes the relation's unit settings, keyed on unit name,
+// those given. The restored settings, including the local unit's, are
+// only seen by this context and are never written back to the relation.
+func (ctx *ContextRelation) restoreSettings(localUnitName string, settings map[string]map[string]string) {
+	for unitName, unitSettings := range settings {
+		restored := make(params.Settings, len(unitSettings))
+		for key, value := range unitSettings {
+			restored[key] = value
+		}
+		if unitName == localUnitName {
+			ctx.restoredLocalSettings = &overlaySettings{settings: restored}
+			continue
+		}
+		if ctx.restoredSettings == nil {
+			ctx.restoredSettings = make(map[string]params.Settings)
+		}
+		ctx.restoredSettings[unitName] = restored
+	}
+}
+
 func (ctx *ContextRelation) ReadApplicationSettings(app string) (settings params.Settings, err error) {
 	return ctx.cache.ApplicationSettings(app)
 }
 
 func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
+	if ctx.restoredLocalSettings != nil {
+		return ctx.restoredLocalSettings, nil
+	}
 	if ctx.settings == nil {
 		node, err := ctx.ru.Settings()
 		if err != nil {
@@ -194,3 +228,28 @@ func (ctx *ContextRelation) RemoteApplicationName() string {
 func (ctx *ContextRelation) Life() life.Value {
 	return ctx.ru.Relation().Life()
 }
+
+// overlaySettings is an in-memory jujuc.Settings holding restored
+// settings; changes made to it are discarded with the context.
+type overlaySettings struct {
+	settings params.Settings
+}
+
+// Map is part of the jujuc.Settings interface.
+func (s *overlaySettings) Map() params.Settings {
+	settingsCopy := make(params.Settings, len(s.settings))
+	for key, value := range s.settings {
+		settingsCopy[key] = value
+	}
+	return settingsCopy
+}
+
+// Set is part of the jujuc.Settings interface.
+func (s *overlaySettings) Set(key, value string) {
+	s.settings[key] = value
+}
+
+// Delete is part of the jujuc.Settings interface.
+func (s *overlaySettings) Delete(key string) {
+	delete(s.settings, key)
+}
@@ -27,6 +27,7 @@ func ClientScript(c *HooksContext, match []string, debugAt string) string {
 	}
 
 	s := strings.Replace(debugHooksClientScript, "{unit_name}", c.Unit, -1)
+	s = strings.Replace(s, "{tmux_setup}", tmuxSetupScript, 1)
 	s = strings.Replace(s, "{tmux_conf}", tmuxConf, 1)
 	s = strings.Replace(s, "{entry_flock}", c.ClientFileLock(), -1)
 	s = strings.Replace(s, "{exit_flock}", c.ClientExitFileLock(), -1)
@@ -78,23 +79,7 @@ echo "{hook_args}" | base64 -d > {entry_flock}
 # Lock the juju-<unit>-debug-exit lockfile.
 flock -n 9 || exit 1
 
-# Wait for tmux to be installed.
-while [ ! -f /usr/bin/tmux ]; do
-    sleep 1
-done
-
-if [ ! -f ~/.tmux.conf ]; then
-        if [ -f /usr/share/byobu/profiles/tmux ]; then
-                # Use byobu/tmux profile for familiar keybindings and branding
-                echo "source-file /usr/share/byobu/profiles/tmux" > ~/.tmux.conf
-        else
-                # Otherwise, use the legacy juju/tmux configuration
-                cat > ~/.tmux.conf <<END
-                {tmux_conf}
-END
-        fi
-fi
-
+{tmux_setup}
 (
     # Close the inherited lock FD, or tmux will keep it open.
     exec 9>&-
@@ -115,6 +100,26 @@ fi
 exit $?
 `
 
+// tmuxSetupScript waits for tmux to be installed, and configures it
+// if the user has not already done so.
+const tmuxSetupScript = `# Wait for tmux to be installed.
+while [ ! -f /usr/bin/tmux ]; do
+    sleep 1
+done
+
+if [ ! -f ~/.tmux.conf ]; then
+        if [ -f /usr/share/byobu/profiles/tmux ]; then
+                # Use byobu/tmux profile for familiar keybindings and branding
+                echo "source-file /usr/share/byobu/profiles/tmux" > ~/.tmux.conf
+        else
+                # Otherwise, use the legacy juju/tmux configuration
+                cat > ~/.tmux.conf <<END
+                {tmux_conf}
+END
+        fi
+fi
+`
+
 const tmuxConf = `
 # Status bar
 set-option -g status-bg black
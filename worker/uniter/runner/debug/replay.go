@@ -0,0 +1,272 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// FailedHook holds the context in which a hook failed, so that the
+// hook can later be replayed with "juju debug-hooks --replay-last".
+type FailedHook struct {
+	// HookName is the name of the hook that failed.
+	HookName string
+
+	// HookRunner is the path of the script that handled the hook.
+	HookRunner string
+
+	// CharmDir is the charm directory the hook was run in.
+	CharmDir string
+
+	// Env holds the environment the hook was run with.
+	Env []string
+
+	// Relation identifies the relation the hook was run for, if any.
+	Relation string
+
+	// RemoteUnit is the name of the remote unit that triggered
+	// the hook, if any.
+	RemoteUnit string
+
+	// RelationSettings holds the relation settings of the local
+	// and remote units, keyed on unit name, as they were when
+	// the hook was run.
+	RelationSettings map[string]map[string]string
+}
+
+// relationSettingsFile is the name of the file in the replay directory
+// holding the relation settings the hook ran with.
+const relationSettingsFile = "relation-settings.yaml"
+
+// replayEnvFilter holds the environment variables that are tied to the
+// hook context the hook originally ran in. That context no longer
+// exists, so these are not restored in a replay session.
+var replayEnvFilter = []string{
+	"JUJU_AGENT_SOCKET_ADDRESS",
+	"JUJU_AGENT_SOCKET_NETWORK",
+	"JUJU_AGENT_TOKEN",
+	"JUJU_CONTEXT_ID",
+}
+
+// ReplayDir returns the directory in which the context of the unit's
+// last failed hook is kept.
+func (c *HooksContext) ReplayDir() string {
+	return c.ClientFileLock() + "-replay"
+}
+
+func (c *HooksContext) replaySessionName() string {
+	return c.Unit + "-replay"
+}
+
+// SaveFailedHook records the context of a failed hook, replacing any
+// previously recorded one, so that it can be replayed in a debug
+// session.
+func (c *HooksContext) SaveFailedHook(hook FailedHook) error {
+	replayDir := c.ReplayDir()
+	tmpDir, err := ioutil.TempDir(c.FlockDir, filepath.Base(replayDir)+"-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	files, err := c.replayFiles(hook)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, file := range files {
+		if err := ioutil.WriteFile(
+			filepath.Join(tmpDir, file.filename),
+			[]byte(file.contents),
+			file.mode,
+		); err != nil {
+			return errors.Annotatef(err, "writing %q", file.filename)
+		}
+	}
+	if err := os.RemoveAll(replayDir); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(tmpDir, replayDir))
+}
+
+// SaveFailedHookScript returns a bash script that records the context of a
+// failed hook, in the same way as SaveFailedHook, when run where the hook
+// ran. It is used for hooks that run on a Kubernetes workload pod, which
+// is where debug sessions for the unit are opened.
+func (c *HooksContext) SaveFailedHookScript(hook FailedHook) (string, error) {
+	files, err := c.replayFiles(hook)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	replayDir := utils.ShQuote(c.ReplayDir())
+	var buf strings.Builder
+	buf.WriteString("set -e\n")
+	fmt.Fprintf(&buf, "mkdir -p %s\n", utils.ShQuote(c.FlockDir))
+	fmt.Fprintf(&buf, "tmp=$(mktemp -d %s)\n", utils.ShQuote(c.ReplayDir()+"-XXXXXX"))
+	buf.WriteString("trap 'rm -rf \"$tmp\"' EXIT\n")
+	for _, file := range files {
+		encoded := base64.StdEncoding.EncodeToString([]byte(file.contents))
+		fmt.Fprintf(&buf, "echo %s | base64 -d > \"$tmp/%s\"\n", encoded, file.filename)
+		fmt.Fprintf(&buf, "chmod %o \"$tmp/%s\"\n", file.mode, file.filename)
+	}
+	fmt.Fprintf(&buf, "rm -rf %s\n", replayDir)
+	fmt.Fprintf(&buf, "mv \"$tmp\" %s\n", replayDir)
+	return buf.String(), nil
+}
+
+type replayFile struct {
+	filename string
+	contents string
+	mode     os.FileMode
+}
+
+// replayFiles returns the files that make up the replay directory for the
+// failed hook. The files reference the replay directory itself, not any
+// temporary directory they are written to.
+func (c *HooksContext) replayFiles(hook FailedHook) ([]replayFile, error) {
+	settings, err := goyaml.Marshal(hook.RelationSettings)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var dispatchPath string
+	for _, kv := range hook.Env {
+		if strings.HasPrefix(kv, "JUJU_DISPATCH_PATH=") {
+			dispatchPath = strings.TrimPrefix(kv, "JUJU_DISPATCH_PATH=")
+		}
+	}
+	runCmd := buildRunHookCmd(hook.HookName, hook.HookRunner, hook.CharmDir)
+	replayDir := c.ReplayDir()
+	return []replayFile{
+		{"env.sh", replayEnvScript(hook.Env), 0600},
+		{relationSettingsFile, string(settings), 0600},
+		{"welcome.msg", replayWelcomeMessage(hook), 0644},
+		{"init.sh", strings.Replace(debugReplayInitScript, "__JUJU_REPLAY__", replayDir, -1), 0755},
+		{"replay-hook", c.replayHookScript(hook, dispatchPath, runCmd), 0755},
+	}, nil
+}
+
+// replayEnvScript returns a script that exports the environment the
+// failed hook ran with.
+func replayEnvScript(env []string) string {
+	var buf strings.Builder
+	for _, kv := range env {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || isFilteredReplayVar(parts[0]) {
+			continue
+		}
+		fmt.Fprintf(&buf, "export %s=%s\n", parts[0], utils.ShQuote(parts[1]))
+	}
+	return buf.String()
+}
+
+func isFilteredReplayVar(name string) bool {
+	for _, filtered := range replayEnvFilter {
+		if name == filtered {
+			return true
+		}
+	}
+	return false
+}
+
+// replayHookScript returns a script that reruns the failed hook through
+// juju-run, which provides a live hook context for the same relation
+// and remote unit, with the relation settings the hook ran with.
+func (c *HooksContext) replayHookScript(hook FailedHook, dispatchPath, runCmd string) string {
+	args := []string{"juju-run", "-u", utils.ShQuote(c.Unit)}
+	if hook.Relation != "" {
+		args = append(args, "-r", utils.ShQuote(hook.Relation))
+		if hook.RemoteUnit != "" {
+			// The remote unit may have since left the relation.
+			args = append(args, "--remote-unit", utils.ShQuote(hook.RemoteUnit), "--force-remote-unit")
+		}
+		if len(hook.RelationSettings) > 0 {
+			settingsPath := filepath.Join(c.ReplayDir(), relationSettingsFile)
+			args = append(args, "--relation-settings", utils.ShQuote(settingsPath))
+		}
+	}
+	commands := fmt.Sprintf("export JUJU_DISPATCH_PATH=%s; export JUJU_HOOK_NAME=%s; %s",
+		utils.ShQuote(dispatchPath), utils.ShQuote(hook.HookName), runCmd)
+	args = append(args, utils.ShQuote(commands))
+	return "#!/bin/bash\nexec " + strings.Join(args, " ") + "\n"
+}
+
+func replayWelcomeMessage(hook FailedHook) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "This is a Juju debug-hooks replay session for the failed %q hook.\n", hook.HookName)
+	if hook.Relation != "" {
+		fmt.Fprintf(&buf, "It ran for relation %s", hook.Relation)
+		if hook.RemoteUnit != "" {
+			fmt.Fprintf(&buf, ", triggered by %s", hook.RemoteUnit)
+		}
+		buf.WriteString(".\n")
+	}
+	buf.WriteString(`
+The environment the hook ran with has been restored. The relation
+settings of the local and remote units at the time of the failure
+are in $JUJU_REPLAY/relation-settings.yaml, and are restored in the
+context replay-hook runs the hook in:
+`)
+	units := make([]string, 0, len(hook.RelationSettings))
+	for unit := range hook.RelationSettings {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	for _, unit := range units {
+		fmt.Fprintf(&buf, "  %s (%d keys)\n", unit, len(hook.RelationSettings[unit]))
+	}
+	buf.WriteString(`
+Hook tools cannot be used directly in this shell, as the hook context
+the hook failed in no longer exists. To rerun the hook, with hook tools,
+in a new context for the same relation and remote unit, use:
+
+replay-hook
+
+Edit the charm in $JUJU_CHARM_DIR and rerun it as often as needed.
+Run 'exit' or press CTRL+a d to end the session.
+
+`)
+	return buf.String()
+}
+
+// ClientReplayScript returns a bash script suitable for executing on the
+// unit system to open a tmux session in which the unit's last failed hook
+// can be replayed.
+func ClientReplayScript(c *HooksContext) string {
+	s := strings.Replace(debugReplayClientScript, "{session_name}", c.replaySessionName(), -1)
+	s = strings.Replace(s, "{unit_name}", c.Unit, -1)
+	s = strings.Replace(s, "{replay_dir}", c.ReplayDir(), -1)
+	s = strings.Replace(s, "{tmux_setup}", strings.Replace(tmuxSetupScript, "{tmux_conf}", tmuxConf, 1), 1)
+	return s
+}
+
+const debugReplayClientScript = `#!/bin/bash
+if [ ! -d {replay_dir} ]; then
+	echo "No failed hook has been recorded for {unit_name}" >&2
+	exit 1
+fi
+
+{tmux_setup}
+if ! tmux has-session -t {session_name} 2>/dev/null; then
+	tmux new-session -d -s {session_name} "/bin/bash --noprofile --init-file {replay_dir}/init.sh"
+fi
+exec tmux attach-session -t {session_name}
+`
+
+const debugReplayInitScript = `#!/bin/bash
+export JUJU_REPLAY=__JUJU_REPLAY__
+. $JUJU_REPLAY/env.sh
+export PATH=$JUJU_REPLAY:$PATH
+export PS1="$JUJU_UNIT_NAME:replay:$JUJU_DISPATCH_PATH % "
+cd $JUJU_CHARM_DIR
+cat $JUJU_REPLAY/welcome.msg
+`
@@ -0,0 +1,164 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/testing"
+)
+
+type DebugHooksReplaySuite struct {
+	testing.BaseSuite
+	ctx *HooksContext
+}
+
+var _ = gc.Suite(&DebugHooksReplaySuite{})
+
+func (s *DebugHooksReplaySuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently debug does not work on windows")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.ctx = NewHooksContext("foo/8")
+	s.ctx.FlockDir = c.MkDir()
+}
+
+func (s *DebugHooksReplaySuite) failedHook() FailedHook {
+	return FailedHook{
+		HookName:   "db-relation-changed",
+		HookRunner: "/var/lib/juju/charm/dispatch",
+		CharmDir:   "/var/lib/juju/charm",
+		Env: []string{
+			"JUJU_CHARM_DIR=/var/lib/juju/charm",
+			"JUJU_CONTEXT_ID=foo/8-db-relation-changed-123",
+			"JUJU_AGENT_SOCKET_ADDRESS=@/var/lib/juju/agents/unit-foo-8/agent.socket",
+			"JUJU_AGENT_SOCKET_NETWORK=unix",
+			"JUJU_DISPATCH_PATH=hooks/db-relation-changed",
+			"JUJU_RELATION_ID=db:2",
+			"QUOTED=it's got 'quotes' and $dollars",
+		},
+		Relation:   "db:2",
+		RemoteUnit: "mysql/0",
+		RelationSettings: map[string]map[string]string{
+			"foo/8":   {"database": "foo"},
+			"mysql/0": {"host": "10.0.0.1", "port": "3306"},
+		},
+	}
+}
+
+func (s *DebugHooksReplaySuite) readReplayFile(c *gc.C, name string) string {
+	data, err := ioutil.ReadFile(filepath.Join(s.ctx.ReplayDir(), name))
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
+
+func (s *DebugHooksReplaySuite) TestSaveFailedHook(c *gc.C) {
+	err := s.ctx.SaveFailedHook(s.failedHook())
+	c.Assert(err, jc.ErrorIsNil)
+
+	env := s.readReplayFile(c, "env.sh")
+	c.Assert(env, gc.Not(gc.Matches), `(.|\n)*JUJU_CONTEXT_ID(.|\n)*`)
+	c.Assert(env, gc.Not(gc.Matches), `(.|\n)*JUJU_AGENT_SOCKET(.|\n)*`)
+	c.Assert(env, gc.Matches, `(.|\n)*export JUJU_RELATION_ID='db:2'\n(.|\n)*`)
+
+	var settings map[string]map[string]string
+	err = goyaml.Unmarshal([]byte(s.readReplayFile(c, "relation-settings.yaml")), &settings)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, s.failedHook().RelationSettings)
+
+	c.Assert(s.readReplayFile(c, "replay-hook"), gc.Equals, `#!/bin/bash
+exec juju-run -u 'foo/8' -r 'db:2' --remote-unit 'mysql/0' --force-remote-unit --relation-settings '`+
+		s.ctx.ReplayDir()+`/relation-settings.yaml' 'export JUJU_DISPATCH_PATH='"'"'hooks/db-relation-changed'"'"'; export JUJU_HOOK_NAME='"'"'db-relation-changed'"'"'; ./dispatch'
+`)
+	c.Assert(s.readReplayFile(c, "init.sh"), gc.Matches,
+		`(.|\n)*export JUJU_REPLAY=`+regexp.QuoteMeta(s.ctx.ReplayDir())+`\n(.|\n)*`)
+	c.Assert(s.readReplayFile(c, "welcome.msg"), gc.Matches,
+		`(.|\n)*It ran for relation db:2, triggered by mysql/0\.\n(.|\n)*`)
+
+	info, err := os.Stat(filepath.Join(s.ctx.ReplayDir(), "env.sh"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+}
+
+func (s *DebugHooksReplaySuite) TestSaveFailedHookEnvRoundTrips(c *gc.C) {
+	err := s.ctx.SaveFailedHook(s.failedHook())
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := exec.Command("/bin/bash", "-c",
+		`. "$0"; echo "$QUOTED"`, filepath.Join(s.ctx.ReplayDir(), "env.sh"),
+	).CombinedOutput()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, "it's got 'quotes' and $dollars\n")
+}
+
+func (s *DebugHooksReplaySuite) TestSaveFailedHookNoRelation(c *gc.C) {
+	err := s.ctx.SaveFailedHook(FailedHook{
+		HookName:   "install",
+		HookRunner: "/var/lib/juju/charm/hooks/install",
+		CharmDir:   "/var/lib/juju/charm",
+		Env:        []string{"JUJU_DISPATCH_PATH=hooks/install"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.readReplayFile(c, "replay-hook"), gc.Equals, `#!/bin/bash
+exec juju-run -u 'foo/8' 'export JUJU_DISPATCH_PATH='"'"'hooks/install'"'"'; export JUJU_HOOK_NAME='"'"'install'"'"'; ./$JUJU_DISPATCH_PATH'
+`)
+}
+
+func (s *DebugHooksReplaySuite) TestSaveFailedHookReplacesPrevious(c *gc.C) {
+	err := s.ctx.SaveFailedHook(s.failedHook())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.ctx.SaveFailedHook(FailedHook{HookName: "install"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.readReplayFile(c, "welcome.msg"), gc.Matches, `(.|\n)*failed "install" hook(.|\n)*`)
+	entries, err := ioutil.ReadDir(s.ctx.FlockDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Name(), gc.Equals, filepath.Base(s.ctx.ReplayDir()))
+}
+
+func (s *DebugHooksReplaySuite) TestSaveFailedHookScript(c *gc.C) {
+	// Write a previous replay, to check that it is replaced.
+	err := s.ctx.SaveFailedHook(FailedHook{HookName: "install"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	script, err := s.ctx.SaveFailedHookScript(s.failedHook())
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := exec.Command("/bin/bash", "-c", script).CombinedOutput()
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("%s", out))
+
+	files, err := s.ctx.replayFiles(s.failedHook())
+	c.Assert(err, jc.ErrorIsNil)
+	for _, file := range files {
+		c.Check(s.readReplayFile(c, file.filename), gc.Equals, file.contents)
+		info, err := os.Stat(filepath.Join(s.ctx.ReplayDir(), file.filename))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(info.Mode().Perm(), gc.Equals, file.mode)
+	}
+	entries, err := ioutil.ReadDir(s.ctx.FlockDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Name(), gc.Equals, filepath.Base(s.ctx.ReplayDir()))
+}
+
+func (s *DebugHooksReplaySuite) TestClientReplayScript(c *gc.C) {
+	result := ClientReplayScript(s.ctx)
+	// No variables left behind.
+	c.Assert(result, gc.Not(gc.Matches), `(.|\n)*{[a-z_]+}(.|\n)*`)
+	c.Assert(result, gc.Matches,
+		`(.|\n)*if \[ ! -d `+regexp.QuoteMeta(s.ctx.ReplayDir())+` \](.|\n)*`)
+	c.Assert(result, gc.Matches,
+		`(.|\n)*--init-file `+regexp.QuoteMeta(s.ctx.ReplayDir())+`/init.sh(.|\n)*`)
+	c.Assert(result, gc.Matches, `(.|\n)*exec tmux attach-session -t foo/8-replay\n`)
+}
@@ -5,6 +5,7 @@ package runner
 
 import (
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 )
 
 var (
@@ -17,3 +18,23 @@ var (
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+type patcher interface {
+	PatchValue(dest, value interface{})
+}
+
+// PatchDebugFlockDir makes the runner look for debug sessions, and save
+// the context of failed hooks, in dir.
+func PatchDebugFlockDir(p patcher, dir string) {
+	p.PatchValue(&newHooksContext, func(unitName string) *debug.HooksContext {
+		ctx := debug.NewHooksContext(unitName)
+		ctx.FlockDir = dir
+		return ctx
+	})
+}
+
+// RunHookOnRemote runs the named hook with the runner's remote executor.
+func RunHookOnRemote(r Runner, hookName string) (HookHandlerType, error) {
+	rnr := r.(*runner)
+	return rnr.runCharmHookWithLocation(hookName, "hooks", runOnRemote, rnr.context.HookTimeout())
+}
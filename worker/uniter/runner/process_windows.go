@@ -0,0 +1,18 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on windows.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the supplied process; process groups are
+// not supported on windows.
+func killProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
@@ -17,6 +17,7 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/juju/charm/v8/hooks"
 	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
@@ -111,6 +113,10 @@ type Context interface {
 	HookVars(paths context.Paths, remote bool, getEnvFunc context.GetEnvFunc) ([]string, error)
 	ActionData() (*context.ActionData, error)
 	SetProcess(process context.HookProcess)
+	KillCharmHook() error
+	HookTimeout() time.Duration
+	Clock() context.Clock
+	RecordHookRun(run context.HookRun)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
 	ModelType() model.ModelType
@@ -382,15 +388,17 @@ func (runner *runner) RunAction(actionName string) (HookHandlerType, error) {
 		return InvalidHookHandler, errors.Trace(err)
 	}
 	runner.logger().Debugf("running action %q on %v", actionName, rMode)
-	return runner.runCharmHookWithLocation(actionName, "actions", rMode)
+	return runner.runCharmHookWithLocation(actionName, "actions", rMode, 0)
 }
 
 // RunHook exists to satisfy the Runner interface.
 func (runner *runner) RunHook(hookName string) (HookHandlerType, error) {
-	return runner.runCharmHookWithLocation(hookName, "hooks", runOnLocal)
+	return runner.runCharmHookWithLocation(hookName, "hooks", runOnLocal, runner.context.HookTimeout())
 }
 
-func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, rMode runMode) (hookHandlerType HookHandlerType, err error) {
+// runCharmHookWithLocation runs the named hook or action. If timeout is
+// non-zero, the hook is killed if it has not completed in that time.
+func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, rMode runMode, timeout time.Duration) (hookHandlerType HookHandlerType, err error) {
 	token := ""
 	if rMode == runOnRemote {
 		token, err = utils.RandomPassword()
@@ -441,7 +449,7 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 	}()
 
 	logger := runner.logger()
-	debugctx := debug.NewHooksContext(runner.context.UnitName())
+	debugctx := newHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
 		// Note: hookScript might be relative but the debug session only requires its name
 		hookHandlerType, hookScript, err := runner.discoverHookHandler(
@@ -464,9 +472,87 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 		return InvalidHookHandler, err
 	}
 	if rMode == runOnRemote {
-		return hookHandlerType, runner.runCharmProcessOnRemote(hookScript, hookName, charmDir, env)
+		err = runner.runCharmProcessOnRemote(hookScript, hookName, charmDir, env, timeout)
+	} else {
+		err = runner.runCharmProcessOnLocal(hookScript, hookName, charmDir, env, timeout)
+	}
+	if err != nil && charmLocation == "hooks" {
+		runner.saveFailedHook(rMode, hookName, hookScript, charmDir, env)
+	}
+	return hookHandlerType, err
+}
+
+// saveFailedHook records the context of a failed hook, where the hook
+// ran, so that it can be replayed with "juju debug-hooks --replay-last".
+// Failures are logged rather than returned, so they do not mask the hook
+// error.
+func (runner *runner) saveFailedHook(rMode runMode, hookName, hookScript, charmDir string, env []string) {
+	failed := debug.FailedHook{
+		HookName:   hookName,
+		HookRunner: hookScript,
+		CharmDir:   charmDir,
+		Env:        env,
+	}
+	if rel, err := runner.context.HookRelation(); err == nil {
+		failed.Relation = rel.FakeId()
+		failed.RelationSettings = make(map[string]map[string]string)
+		if settings, err := rel.Settings(); err == nil {
+			failed.RelationSettings[runner.context.UnitName()] = settings.Map()
+		}
+		if remoteUnit, err := runner.context.RemoteUnitName(); err == nil && remoteUnit != "" {
+			failed.RemoteUnit = remoteUnit
+			if settings, err := rel.ReadSettings(remoteUnit); err == nil {
+				failed.RelationSettings[remoteUnit] = settings
+			}
+		}
+	}
+	debugctx := newHooksContext(runner.context.UnitName())
+	var err error
+	if rMode == runOnRemote {
+		err = runner.saveFailedHookOnRemote(debugctx, failed)
+	} else {
+		err = debugctx.SaveFailedHook(failed)
+	}
+	if err != nil {
+		runner.logger().Warningf("cannot save context of failed hook %q for replay: %v", hookName, err)
+	}
+}
+
+// saveFailedHookOnRemote records the context of a failed hook on the
+// workload pod, which is where debug sessions for the unit are opened.
+func (runner *runner) saveFailedHookOnRemote(debugctx *debug.HooksContext, failed debug.FailedHook) error {
+	script, err := debugctx.SaveFailedHookScript(failed)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	executor, err := runner.getExecutor(runOnRemote)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var stdout, stderr bytes.Buffer
+	resp, err := executor(ExecParams{
+		Commands:      []string{script},
+		WorkingDir:    "/",
+		ProcessSetter: func(context.HookProcess) {},
+		Stdout:        &stdout,
+		Stderr:        &stderr,
+	})
+	if err != nil {
+		return errors.Trace(err)
 	}
-	return hookHandlerType, runner.runCharmProcessOnLocal(hookScript, hookName, charmDir, env)
+	if resp.Code != 0 {
+		return errors.Errorf("exit status %d: %s", resp.Code, strings.TrimSpace(string(resp.Stderr)))
+	}
+	return nil
+}
+
+// newHooksContext is a var so it can be replaced for testing.
+var newHooksContext = debug.NewHooksContext
+
+// hookTimeoutError returns the error reported for a hook that was killed
+// because it ran for longer than the configured hook-timeout.
+func hookTimeoutError(timeout time.Duration) error {
+	return charmrunner.NewHookKilledError(fmt.Sprintf("timed out after %v", timeout))
 }
 
 // loggerAdaptor implements MessageReceiver and
@@ -521,7 +607,39 @@ func (b *bufferAdaptor) Bytes() []byte {
 	return b.outCopy.Bytes()
 }
 
-func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, env []string) error {
+// tailAdaptor implements MessageReceiver, keeping only the
+// last status.MaxHookOutputSize bytes of the output it receives.
+type tailAdaptor struct {
+	mu   sync.Mutex
+	tail []byte
+}
+
+// Messagef implements the charmrunner MessageReceiver interface
+func (t *tailAdaptor) Messagef(isPrefix bool, message string, args ...interface{}) {
+	formattedMessage := message
+	if len(args) > 0 {
+		formattedMessage = fmt.Sprintf(message, args...)
+	}
+	if !isPrefix {
+		formattedMessage += "\n"
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tail = append(t.tail, formattedMessage...)
+	if len(t.tail) > status.MaxHookOutputSize {
+		t.tail = t.tail[len(t.tail)-status.MaxHookOutputSize:]
+	}
+}
+
+// String returns the buffered output.
+func (t *tailAdaptor) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.tail)
+}
+
+func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, env []string, timeout time.Duration) error {
 	var cancel <-chan struct{}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
@@ -565,6 +683,15 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 	if err != nil {
 		return errors.Trace(err)
 	}
+
+	clk := runner.context.Clock()
+	stopTimer := func() bool { return false }
+	if !runningAction && timeout > 0 {
+		timeoutCancel := make(chan struct{})
+		cancel = timeoutCancel
+		stopTimer = startHookTimer(clk, timeout, func() { close(timeoutCancel) })
+	}
+	started := clk.Now()
 	resp, err := executor(
 		ExecParams{
 			Commands:     []string{hook},
@@ -577,6 +704,7 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 			StderrLogger: hookErrLogger,
 		},
 	)
+	timedOut := stopTimer()
 
 	// If we are running an action, record stdout and stderr.
 	if runningAction && resp != nil {
@@ -584,16 +712,71 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 			return errors.Trace(err)
 		}
 	}
+	if !runningAction {
+		run := context.HookRun{
+			HookName: hookName,
+			Started:  started,
+			Duration: clk.Now().Sub(started),
+			ExitCode: -1,
+		}
+		if resp != nil {
+			// Hook stdout and stderr share a buffer, so the combined
+			// output is all in Stdout.
+			run.ExitCode = resp.Code
+			run.Output = status.TruncateHookOutput(string(resp.Stdout) + string(resp.Stderr))
+		}
+		runner.recordHookRun(run)
+	}
 
+	if err != nil && timedOut {
+		runner.logger().Warningf("hook %q exceeded hook-timeout of %v and was killed", hookName, timeout)
+		return errors.Trace(hookTimeoutError(timeout))
+	}
 	return errors.Trace(err)
 }
 
+// recordHookRun adds the hook run to the unit's hook history. Successful
+// update-status runs are not recorded, as that would be a controller
+// write for every unit at each update-status interval.
+func (runner *runner) recordHookRun(run context.HookRun) {
+	if run.HookName == string(hooks.UpdateStatus) && run.ExitCode == 0 {
+		return
+	}
+	runner.context.RecordHookRun(run)
+}
+
+// startHookTimer calls onTimeout once the timeout has elapsed, unless the
+// returned stop func is called first. Calling stop reports whether the
+// timeout fired.
+func startHookTimer(clk context.Clock, timeout time.Duration, onTimeout func()) (stop func() bool) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	expired := false
+	go func() {
+		defer close(stopped)
+		select {
+		case <-clk.After(timeout):
+			expired = true
+			onTimeout()
+		case <-done:
+		}
+	}()
+	return func() bool {
+		close(done)
+		<-stopped
+		return expired
+	}
+}
+
 // Check still tested
-func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, env []string) error {
+func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, env []string, timeout time.Duration) error {
 	hookCmd := hookCommand(hook)
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	// Run the hook in its own process group so that killing it
+	// also kills anything it started.
+	setProcessGroup(ps)
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -623,6 +806,7 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	var cancel <-chan struct{}
 	var actionOut *bufferAdaptor
 	var actionErr *bufferAdaptor
+	var hookOutput *tailAdaptor
 	actionData, err := runner.context.ActionData()
 	runningAction := err == nil && actionData != nil
 	if runningAction {
@@ -631,26 +815,42 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 		actionErr = &bufferAdaptor{ReadWriter: errWriter}
 		hookErrLogger.AddReceiver(actionErr)
 		cancel = actionData.Cancel
+	} else {
+		// The combined output is kept for the hook history.
+		hookOutput = &tailAdaptor{}
+		hookOutLogger.AddReceiver(hookOutput)
+		hookErrLogger.AddReceiver(hookOutput)
 	}
 
+	clk := runner.context.Clock()
+	started := clk.Now()
 	err = ps.Start()
 	var exitErr error
 	if err == nil {
+		proc := hookProcess{ps.Process}
 		done := make(chan struct{})
 		if cancel != nil {
 			go func() {
 				select {
 				case <-cancel:
-					ps.Process.Kill()
+					_ = proc.Kill()
 				case <-done:
 				}
 			}()
 		}
+		stopTimer := func() bool { return false }
+		if !runningAction && timeout > 0 {
+			stopTimer = startHookTimer(clk, timeout, func() { _ = proc.Kill() })
+		}
 		// Record the *os.Process of the hook
-		runner.context.SetProcess(hookProcess{ps.Process})
+		runner.context.SetProcess(proc)
 		// Block until execution finishes
 		exitErr = ps.Wait()
 		close(done)
+		if stopTimer() {
+			runner.logger().Warningf("hook %q exceeded hook-timeout of %v and was killed", hookName, timeout)
+			exitErr = hookTimeoutError(timeout)
+		}
 	} else {
 		exitErr = err
 	}
@@ -670,6 +870,18 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 		if err := runner.updateActionResults(resp); err != nil {
 			return errors.Trace(err)
 		}
+	} else {
+		run := context.HookRun{
+			HookName: hookName,
+			Started:  started,
+			Duration: clk.Now().Sub(started),
+			ExitCode: -1,
+			Output:   hookOutput.String(),
+		}
+		if ps.ProcessState != nil {
+			run.ExitCode = ps.ProcessState.ExitCode()
+		}
+		runner.recordHookRun(run)
 	}
 
 	return errors.Trace(exitErr)
@@ -760,6 +972,12 @@ type hookProcess struct {
 	*os.Process
 }
 
+// Kill kills the hook process along with any other processes in its
+// process group, where the platform supports it.
+func (p hookProcess) Kill() error {
+	return killProcessGroup(p.Process)
+}
+
 func (p hookProcess) Pid() int {
 	return p.Process.Pid
 }
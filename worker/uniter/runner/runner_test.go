@@ -7,12 +7,15 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	osexec "os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
 
 	"github.com/juju/charm/v8/hooks"
+	"github.com/juju/clock"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/proxy"
@@ -22,10 +25,13 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/model"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -229,6 +235,9 @@ type MockContext struct {
 	flushFailure    error
 	flushResult     error
 	modelType       model.ModelType
+	hookTimeout     time.Duration
+	clock           context.Clock
+	hookRuns        []context.HookRun
 }
 
 func (ctx *MockContext) GetLogger(module string) loggo.Logger {
@@ -262,6 +271,25 @@ func (ctx *MockContext) SetProcess(process context.HookProcess) {
 	ctx.expectPid = process.Pid()
 }
 
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+func (ctx *MockContext) Clock() context.Clock {
+	if ctx.clock == nil {
+		return clock.WallClock
+	}
+	return ctx.clock
+}
+
+func (ctx *MockContext) RecordHookRun(run context.HookRun) {
+	ctx.hookRuns = append(ctx.hookRuns, run)
+}
+
+func (ctx *MockContext) HookRelation() (jujuc.ContextRelation, error) {
+	return nil, errors.NotFoundf("hook relation")
+}
+
 func (ctx *MockContext) Prepare() error {
 	return nil
 }
@@ -292,7 +320,8 @@ func (ctx *MockContext) ModelType() model.ModelType {
 
 type RunMockContextSuite struct {
 	envtesting.IsolationSuite
-	paths runnertesting.RealPaths
+	paths    runnertesting.RealPaths
+	flockDir string
 }
 
 var _ = gc.Suite(&RunMockContextSuite{})
@@ -300,6 +329,8 @@ var _ = gc.Suite(&RunMockContextSuite{})
 func (s *RunMockContextSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.paths = runnertesting.NewRealPaths(c)
+	s.flockDir = c.MkDir()
+	runner.PatchDebugFlockDir(s, s.flockDir)
 }
 
 func (s *RunMockContextSuite) assertRecordedPid(c *gc.C, expectPid int) {
@@ -345,6 +376,212 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	clk := testclock.NewClock(time.Time{})
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+		clock:       clk,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		hang: true,
+	}, s.paths.GetCharmDir())
+	go func() {
+		err := clk.WaitAdvance(100*time.Millisecond, coretesting.LongWait, 1)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "hook killed: timed out after 100ms")
+	c.Assert(charmrunner.IsHookKilledError(errors.Cause(ctx.flushFailure)), jc.IsTrue)
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
+func (s *RunMockContextSuite) TestRunHookOnRemoteTimeout(c *gc.C) {
+	clk := testclock.NewClock(time.Time{})
+	ctx := &MockContext{
+		modelType:   model.CAAS,
+		hookTimeout: time.Minute,
+		clock:       clk,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+
+	execFunc := func(params runner.ExecParams) (*exec.ExecResponse, error) {
+		if !strings.HasSuffix(params.Commands[0], hookName) {
+			return &exec.ExecResponse{}, nil
+		}
+		// Hang until the hook timer cancels the hook.
+		<-params.Cancel
+		return nil, errors.New("cancelled")
+	}
+	go func() {
+		err := clk.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+	_, err := runner.RunHookOnRemote(runner.NewRunner(ctx, s.paths, execFunc), "something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "hook killed: timed out after 1m0s")
+}
+
+func (s *RunMockContextSuite) TestRunHookWithinTimeout(c *gc.C) {
+	ctx := &MockContext{
+		hookTimeout: 10 * time.Second,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+}
+
+func (s *RunMockContextSuite) TestRunHookRecordsHookRun(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		code:   3,
+		stdout: "starting",
+		stderr: "something bad",
+	}, s.paths.GetCharmDir())
+	start := time.Now()
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 3")
+	c.Assert(ctx.hookRuns, gc.HasLen, 1)
+	run := ctx.hookRuns[0]
+	c.Assert(run.HookName, gc.Equals, "something-happened")
+	c.Assert(run.ExitCode, gc.Equals, 3)
+	// Both stdout and stderr are recorded.
+	c.Assert(run.Output, jc.Contains, "starting\n")
+	c.Assert(run.Output, jc.Contains, "something bad\n")
+	c.Assert(run.Started.Before(start), jc.IsFalse)
+	c.Assert(run.Duration > 0, jc.IsTrue)
+}
+
+func (s *RunMockContextSuite) TestRunHookSkipsSuccessfulUpdateStatus(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: "update-status",
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("update-status")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.hookRuns, gc.HasLen, 0)
+}
+
+func (s *RunMockContextSuite) TestRunHookRecordsFailedUpdateStatus(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: "update-status",
+		perm: 0700,
+		code: 1,
+	}, s.paths.GetCharmDir())
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("update-status")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 1")
+	c.Assert(ctx.hookRuns, gc.HasLen, 1)
+	c.Assert(ctx.hookRuns[0].HookName, gc.Equals, "update-status")
+	c.Assert(ctx.hookRuns[0].ExitCode, gc.Equals, 1)
+}
+
+func (s *RunMockContextSuite) TestRunHookSavesFailedHook(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 3,
+	}, s.paths.GetCharmDir())
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 3")
+
+	debugctx := debug.NewHooksContext(ctx.UnitName())
+	debugctx.FlockDir = s.flockDir
+	replayHook, err := ioutil.ReadFile(filepath.Join(debugctx.ReplayDir(), "replay-hook"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(replayHook), gc.Matches, `(?s).*juju-run -u 'some-unit/999' .*JUJU_HOOK_NAME=.*something-happened.*`)
+}
+
+func (s *RunMockContextSuite) TestRunHookOnRemoteSavesFailedHook(c *gc.C) {
+	ctx := &MockContext{modelType: model.CAAS}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+
+	var hookRun bool
+	execFunc := func(params runner.ExecParams) (*exec.ExecResponse, error) {
+		switch {
+		case strings.HasSuffix(params.Commands[0], hookName):
+			hookRun = true
+			return &exec.ExecResponse{Code: 3}, errors.New("exit status 3")
+		case strings.Contains(params.Commands[0], "base64 -d"):
+			// Run the script saving the failed hook here, in place
+			// of the workload pod.
+			out, err := osexec.Command("/bin/bash", "-c", params.Commands[0]).CombinedOutput()
+			c.Assert(err, jc.ErrorIsNil, gc.Commentf("%s", out))
+		}
+		return &exec.ExecResponse{}, nil
+	}
+	_, err := runner.RunHookOnRemote(runner.NewRunner(ctx, s.paths, execFunc), "something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hookRun, jc.IsTrue)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 3")
+
+	debugctx := debug.NewHooksContext(ctx.UnitName())
+	debugctx.FlockDir = s.flockDir
+	replayHook, err := ioutil.ReadFile(filepath.Join(debugctx.ReplayDir(), "replay-hook"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(replayHook), gc.Matches, `(?s).*juju-run -u 'some-unit/999' .*JUJU_HOOK_NAME=.*something-happened.*`)
+}
+
+func (s *RunMockContextSuite) TestRunHookSuccessDoesNotSaveFailedHook(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, jc.ErrorIsNil)
+
+	entries, err := ioutil.ReadDir(s.flockDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *RunMockContextSuite) TestRunActionDoesNotRecordHookRun(c *gc.C) {
+	ctx := &MockContext{
+		actionData:    &context.ActionData{},
+		actionResults: map[string]interface{}{},
+	}
+	makeCharm(c, hookSpec{
+		dir:  "actions",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.hookRuns, gc.HasLen, 0)
+}
+
 func (s *RunHookSuite) TestRunActionDispatchingHookHandler(c *gc.C) {
 	ctx := &MockContext{
 		actionData:    &context.ActionData{},
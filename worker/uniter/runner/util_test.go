@@ -58,6 +58,7 @@ type ContextSuite struct {
 
 func (s *ContextSuite) SetUpTest(c *gc.C) {
 	s.JujuConnSuite.SetUpTest(c)
+	runner.PatchDebugFlockDir(s, c.MkDir())
 
 	s.machine = nil
 
@@ -207,6 +208,8 @@ type hookSpec struct {
 	stderr string
 	// background holds a string to print in the background after 0.2s.
 	background string
+	// hang causes the hook to loop forever rather than exit.
+	hang bool
 	// missingShebang will omit the '#!/bin/bash' line
 	missingShebang bool
 }
@@ -253,6 +256,9 @@ func makeCharm(c *gc.C, spec hookSpec, charmDir string) {
 		// expected.
 		printf("(sleep 0.2; echo %s; sleep 10) &", spec.background)
 	}
+	if spec.hang {
+		printf("while :; do :; done")
+	}
 	printf("exit %d", spec.code)
 }
 
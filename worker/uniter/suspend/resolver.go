@@ -0,0 +1,52 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package suspend
+
+import (
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+// Logger represents the logging methods used by this package.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Tracef(string, ...interface{})
+}
+
+type suspendResolver struct{ logger Logger }
+
+// NewResolver returns a new resolver that runs the pre-suspend and
+// post-resume hooks as the unit's model is suspended and resumed.
+func NewResolver(logger Logger) resolver.Resolver {
+	return &suspendResolver{logger}
+}
+
+// NextOp is defined on the Resolver interface.
+func (r *suspendResolver) NextOp(
+	localState resolver.LocalState, remoteState remotestate.Snapshot, opFactory operation.Factory,
+) (operation.Operation, error) {
+	// Once the unit has run its pre-suspend hook it should do nothing
+	// more until its machine is powered off, and then on again.
+	if remoteState.SuspendStatus == model.UnitSuspended {
+		r.logger.Debugf("unit suspended, waiting for resume request")
+		return nil, resolver.ErrDoNotProceed
+	}
+
+	r.logger.Tracef("localState.Kind=%q, localState.SuspendStatus=%q, remoteState.SuspendStatus=%q",
+		localState.Kind, localState.SuspendStatus, remoteState.SuspendStatus)
+
+	if localState.Kind != operation.Continue || localState.SuspendStatus == remoteState.SuspendStatus {
+		return nil, resolver.ErrNoOperation
+	}
+	switch remoteState.SuspendStatus {
+	case model.UnitSuspending:
+		return opFactory.NewRunHook(hook.Info{Kind: hook.PreSuspend})
+	case model.UnitResuming:
+		return opFactory.NewRunHook(hook.Info{Kind: hook.PostResume})
+	}
+	return nil, resolver.ErrNoOperation
+}
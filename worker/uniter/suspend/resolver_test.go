@@ -0,0 +1,109 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package suspend_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/operation/mocks"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/suspend"
+)
+
+type ResolverSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ResolverSuite{})
+
+func (ResolverSuite) NewResolver() resolver.Resolver {
+	logger := loggo.GetLogger("test")
+	logger.SetLogLevel(loggo.TRACE)
+	return suspend.NewResolver(logger)
+}
+
+func continueState(suspendStatus model.UnitSuspendStatus) resolver.LocalState {
+	return resolver.LocalState{
+		State: operation.State{
+			Kind:          operation.Continue,
+			SuspendStatus: suspendStatus,
+		},
+	}
+}
+
+func (s ResolverSuite) TestNextOpSuspended(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	_, err := s.NewResolver().NextOp(continueState(model.UnitSuspending), remotestate.Snapshot{
+		SuspendStatus: model.UnitSuspended,
+	}, mocks.NewMockFactory(ctrl))
+	c.Assert(err, gc.Equals, resolver.ErrDoNotProceed)
+}
+
+func (s ResolverSuite) TestNextOpPreSuspend(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockOp := mocks.NewMockOperation(ctrl)
+	mockFactory := mocks.NewMockFactory(ctrl)
+	mockFactory.EXPECT().NewRunHook(hook.Info{Kind: hook.PreSuspend}).Return(mockOp, nil)
+
+	op, err := s.NewResolver().NextOp(continueState(model.UnitSuspendNotStarted), remotestate.Snapshot{
+		SuspendStatus: model.UnitSuspending,
+	}, mockFactory)
+	c.Assert(err, gc.IsNil)
+	c.Assert(op, gc.Equals, mockOp)
+}
+
+func (s ResolverSuite) TestNextOpPostResume(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockOp := mocks.NewMockOperation(ctrl)
+	mockFactory := mocks.NewMockFactory(ctrl)
+	mockFactory.EXPECT().NewRunHook(hook.Info{Kind: hook.PostResume}).Return(mockOp, nil)
+
+	// A uniter restarted when its machine is powered back on reads
+	// the suspend status of its pre-suspend hook from its state.
+	op, err := s.NewResolver().NextOp(continueState(model.UnitSuspending), remotestate.Snapshot{
+		SuspendStatus: model.UnitResuming,
+	}, mockFactory)
+	c.Assert(err, gc.IsNil)
+	c.Assert(op, gc.Equals, mockOp)
+}
+
+func (s ResolverSuite) TestNextOpHookAlreadyCommitted(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	for _, suspendStatus := range []model.UnitSuspendStatus{
+		model.UnitSuspending,
+		model.UnitResuming,
+	} {
+		_, err := s.NewResolver().NextOp(continueState(suspendStatus), remotestate.Snapshot{
+			SuspendStatus: suspendStatus,
+		}, mocks.NewMockFactory(ctrl))
+		c.Check(err, gc.Equals, resolver.ErrNoOperation)
+	}
+}
+
+func (s ResolverSuite) TestNextOpNotContinue(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	_, err := s.NewResolver().NextOp(resolver.LocalState{
+		State: operation.State{Kind: operation.RunHook},
+	}, remotestate.Snapshot{
+		SuspendStatus: model.UnitSuspending,
+	}, mocks.NewMockFactory(ctrl))
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	"github.com/juju/juju/worker/uniter/storage"
+	"github.com/juju/juju/worker/uniter/suspend"
 	"github.com/juju/juju/worker/uniter/upgradeseries"
 	"github.com/juju/juju/worker/uniter/verifycharmprofile"
 )
@@ -455,6 +456,9 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			UpgradeSeries: upgradeseries.NewResolver(
 				u.logger.Child("upgradeseries"),
 			),
+			Suspend: suspend.NewResolver(
+				u.logger.Child("suspend"),
+			),
 			Reboot: reboot.NewResolver(u.logger, rebootDetected, u.modelType),
 			Leadership: uniterleadership.NewResolver(
 				u.logger.Child("leadership"),
@@ -837,7 +841,7 @@ func (u *Uniter) acquireExecutionLock(action string) (func(), error) {
 	return releaser, nil
 }
 
-func (u *Uniter) reportHookError(hookInfo hook.Info) error {
+func (u *Uniter) reportHookError(hookInfo hook.Info, killReason string) error {
 	// Set the agent status to "error". We must do this here in case the
 	// hook is interrupted (e.g. unit agent crashes), rather than immediately
 	// after attempting a runHookOp.
@@ -856,5 +860,9 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if killReason != "" {
+		statusData["killed"] = killReason
+		statusMessage = fmt.Sprintf("%s (%s)", statusMessage, killReason)
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }